@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"traveler/internal/dca"
+	"traveler/internal/money"
 	"traveler/internal/provider"
 	"traveler/internal/strategy"
 	"traveler/pkg/model"
@@ -514,24 +515,7 @@ func printMonthlyReturns(snapshots []dailySnapshot) {
 }
 
 func formatKRW(v float64) string {
-	neg := ""
-	if v < 0 {
-		neg = "-"
-		v = -v
-	}
-	s := fmt.Sprintf("%.0f", v)
-	n := len(s)
-	if n <= 3 {
-		return neg + s
-	}
-	result := ""
-	for i, c := range s {
-		if i > 0 && (n-i)%3 == 0 {
-			result += ","
-		}
-		result += string(c)
-	}
-	return neg + result
+	return money.Group(v, 0)
 }
 
 func fetchFearGreedHistory(ctx context.Context, days int) (map[string]int, error) {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"traveler/internal/backtest"
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+)
+
+func main() {
+	def := strategy.DefaultPairsConfig()
+	params := def
+
+	var (
+		pairsFlag string
+		days      int
+		capital   float64
+		alloc     float64
+		maxHold   int
+		borrow    float64
+		verbose   bool
+		noCache   bool
+		dataDir   string
+	)
+	flag.StringVar(&pairsFlag, "pairs", "", "Pairs as A:B,C:D (empty = strategy defaults: KO:PEP, XOM:CVX, V:MA, ...)")
+	flag.IntVar(&days, "days", 250, "Backtest period in trading days")
+	flag.Float64Var(&capital, "capital", 10000, "Initial capital per pair ($)")
+	flag.Float64Var(&alloc, "alloc", 0.5, "Gross long+short exposure per trade (fraction of capital)")
+	flag.IntVar(&maxHold, "max-hold", 20, "Close the pair after this many trading days")
+	flag.Float64Var(&borrow, "borrow", 1, "Short borrow cost (annual %, negative = none)")
+	flag.IntVar(&params.Lookback, "lookback", def.Lookback, "Regression / z-score window (trading days)")
+	flag.Float64Var(&params.EntryZ, "entry-z", def.EntryZ, "Enter when |z| reaches this")
+	flag.Float64Var(&params.ExitZ, "exit-z", def.ExitZ, "Exit when |z| falls back to this")
+	flag.Float64Var(&params.StopZ, "stop-z", def.StopZ, "Stop out when |z| widens to this")
+	flag.Float64Var(&params.MinCorrelation, "min-corr", def.MinCorrelation, "Minimum daily return correlation")
+	flag.Float64Var(&params.MaxHalfLife, "max-half-life", def.MaxHalfLife, "Maximum spread half-life (trading days)")
+	flag.BoolVar(&verbose, "verbose", false, "Print individual trades")
+	flag.BoolVar(&noCache, "no-cache", false, "Skip cache, fetch fresh data")
+	flag.StringVar(&dataDir, "data-dir", "", "Data directory (default: ~/.traveler)")
+	flag.Parse()
+
+	pairs := params.Pairs
+	if pairsFlag != "" {
+		var err error
+		if pairs, err = parsePairs(pairsFlag); err != nil {
+			log.Fatalf("Invalid -pairs: %v", err)
+		}
+	}
+	if params.ExitZ >= params.EntryZ || params.StopZ <= params.EntryZ {
+		log.Fatal("Need exit-z < entry-z < stop-z")
+	}
+	if dataDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataDir = filepath.Join(home, ".traveler")
+		} else {
+			dataDir = "."
+		}
+	}
+
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  Pairs Backtester (US)")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Printf("Capital: $%.0f/pair | Days: %d | z: entry %.1f, exit %.1f, stop %.1f | Lookback: %d\n\n",
+		capital, days, params.EntryZ, params.ExitZ, params.StopZ, params.Lookback)
+
+	var syms []string
+	for _, p := range pairs {
+		syms = append(syms, p[0], p[1])
+	}
+	ctx := context.Background()
+	allCandles, err := backtest.FetchStockData(ctx, provider.NewYahooProvider(), syms, days+params.Lookback+30, dataDir, noCache)
+	if err != nil {
+		log.Fatalf("Failed to fetch data: %v", err)
+	}
+
+	sim := backtest.NewPairSimulator(backtest.PairSimConfig{
+		Params:        params,
+		Capital:       capital,
+		AllocPct:      alloc,
+		MaxHoldDays:   maxHold,
+		BorrowRatePct: borrow,
+	})
+
+	fmt.Printf("%-10s %6s %8s %10s %8s %8s %8s\n", "Pair", "Trades", "WinRate", "P&L", "Return", "MaxDD", "AvgHold")
+	fmt.Println(strings.Repeat("─", 64))
+	var totalPnL, totalCap float64
+	for _, p := range pairs {
+		a, b := allCandles[p[0]], allCandles[p[1]]
+		if len(a) == 0 || len(b) == 0 {
+			fmt.Printf("%-10s no data\n", p[0]+"/"+p[1])
+			continue
+		}
+		res := sim.Run(p[0], p[1], a, b, days)
+		totalPnL += res.TotalPnL
+		totalCap += capital
+		fmt.Printf("%-10s %6d %7.1f%% %+10.2f %+7.2f%% %7.2f%% %7.1fd\n",
+			p[0]+"/"+p[1], len(res.Trades), res.WinRate, res.TotalPnL, res.ReturnPct, res.MaxDrawdownPct, res.AvgHoldDays)
+		if verbose {
+			for _, t := range res.Trades {
+				fmt.Printf("    %s → %s  long %s %.0f @ %.2f→%.2f, short %s %.0f @ %.2f→%.2f  z %+.2f→%+.2f  %+.2f (%s)\n",
+					t.EntryDate.Format("2006-01-02"), t.ExitDate.Format("2006-01-02"),
+					t.LongSymbol, t.LongQty, t.LongEntry, t.LongExit,
+					t.ShortSymbol, t.ShortQty, t.ShortEntry, t.ShortExit,
+					t.EntryZ, t.ExitZ, t.PnL, t.ExitReason)
+			}
+		}
+	}
+	fmt.Println(strings.Repeat("─", 64))
+	if totalCap > 0 {
+		fmt.Printf("%-10s %6s %8s %+10.2f %+7.2f%%\n", "Total", "", "", totalPnL, totalPnL/totalCap*100)
+	}
+}
+
+// parsePairs "KO:PEP,XOM:CVX" → [[KO PEP] [XOM CVX]]
+func parsePairs(s string) ([][2]string, error) {
+	var pairs [][2]string
+	for _, part := range strings.Split(s, ",") {
+		legs := strings.Split(strings.TrimSpace(part), ":")
+		if len(legs) != 2 || legs[0] == "" || legs[1] == "" {
+			return nil, fmt.Errorf("%q is not A:B", part)
+		}
+		pairs = append(pairs, [2]string{strings.ToUpper(legs[0]), strings.ToUpper(legs[1])})
+	}
+	return pairs, nil
+}
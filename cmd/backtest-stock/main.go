@@ -26,6 +26,8 @@ type cliConfig struct {
 	noCache  bool
 	dataDir  string
 	optimize bool
+	tranches bool
+	t3R      float64
 }
 
 func main() {
@@ -39,6 +41,8 @@ func main() {
 	flag.BoolVar(&cfg.noCache, "no-cache", false, "Skip cache, fetch fresh data")
 	flag.StringVar(&cfg.dataDir, "data-dir", "", "Data directory (default: ~/.traveler)")
 	flag.BoolVar(&cfg.optimize, "optimize", false, "Run optimization across multiple regime-strategy configurations")
+	flag.BoolVar(&cfg.tranches, "tranches", false, "Three-tranche exits: 1/3 at 1R, 1/3 at 2R, trailing runner")
+	flag.Float64Var(&cfg.t3R, "t3-r", 0, "With -tranches: close the runner at this R multiple (0 = trailing stop only)")
 	flag.Parse()
 
 	// Defaults
@@ -49,6 +53,10 @@ func main() {
 			cfg.capital = 5000
 		}
 	}
+	tranches, err := strategy.TrancheExit{Enabled: cfg.tranches, T3R: cfg.t3R}.Normalized()
+	if err != nil {
+		log.Fatalf("Invalid tranches: %v", err)
+	}
 	if cfg.dataDir == "" {
 		if home, err := os.UserHomeDir(); err == nil {
 			cfg.dataDir = filepath.Join(home, ".traveler")
@@ -149,6 +157,7 @@ func main() {
 		InitialCapital: cfg.capital,
 		MaxPositions:   sizerCfg.MaxPositions,
 		Commission:     sizerCfg.CommissionRate,
+		Tranches:       tranches,
 		Verbose:        cfg.verbose,
 	}
 
@@ -164,6 +173,7 @@ func main() {
 	// Single backtest with default meta strategy
 	btProvider := backtest.NewBacktestProvider(allCandles)
 	metaCfg := strategy.DefaultStockMetaConfig(cfg.market)
+	metaCfg.Tranches = tranches
 	meta := strategy.NewStockMetaStrategy(metaCfg, btProvider)
 	strategies := []strategy.Strategy{meta}
 
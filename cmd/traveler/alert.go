@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/alert"
+	"traveler/internal/config"
+	"traveler/internal/notify"
+)
+
+// newAlertCmd `traveler alert` 가격/지표/계좌 알림 규칙 (주문 없음)
+func newAlertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alert",
+		Short: "Price, indicator and drawdown alerts (no trading)",
+		Long: `Manage alert rules stored in alert_rules.json. Running daemons evaluate the rules
+of their market on every monitor cycle (at most once a minute) and notify via
+Telegram (TELEGRAM_BOT_TOKEN/TELEGRAM_CHAT_ID) and the alert.triggered webhook.
+"traveler alert watch" does the same without a daemon, for manual traders.
+
+Rule syntax: <subject> <op> <value>
+  subject: SYMBOL (price), RSI(n) SYMBOL, RSI(n) held (every held position), drawdown (% from peak equity)
+  op:      >, <, above, below, crosses above, crosses below
+
+above/below fire once when the condition becomes true and re-arm when it turns false;
+crosses fire only when the previous check was on the other side.`,
+		Example: `  traveler alert add "AAPL crosses above 200"
+  traveler alert list
+  traveler alert rm a3
+  traveler alert watch --market us --interval 5m`,
+	}
+	cmd.AddCommand(newAlertAddCmd(), newAlertListCmd(), newAlertRemoveCmd(), newAlertWatchCmd())
+	return cmd
+}
+
+func newAlertAddCmd() *cobra.Command {
+	var (
+		market   string
+		cooldown time.Duration
+		note     string
+	)
+	cmd := &cobra.Command{
+		Use:   "add RULE",
+		Short: "Add an alert rule",
+		Example: `  traveler alert add "AAPL crosses above 200"
+  traveler alert add "RSI(14) held > 75" --market kr
+  traveler alert add "drawdown > 3%" --note "review open risk"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			r, err := alert.ParseRule(args[0], market)
+			if err != nil {
+				return err
+			}
+			r.Cooldown = cooldown
+			r.Note = note
+			r, err = alert.NewStore(resolveDataDir()).Add(r)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Added %s [%s]: %s\n", r.ID, r.Market, r)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&market, "market", "", "market: us, kr or crypto (default: inferred from symbol, us for drawdown)")
+	cmd.Flags().DurationVar(&cooldown, "cooldown", 0, "minimum time between repeated alerts (default 30m)")
+	cmd.Flags().StringVar(&note, "note", "", "note appended to the notification")
+	return cmd
+}
+
+func newAlertListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List alert rules",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			rules, err := alert.NewStore(resolveDataDir()).List()
+			if err != nil {
+				return err
+			}
+			if len(rules) == 0 {
+				fmt.Println("No alert rules. Add one with: traveler alert add \"AAPL crosses above 200\"")
+				return nil
+			}
+			fmt.Printf("%-5s %-7s %-36s %s\n", "ID", "Market", "Rule", "Note")
+			fmt.Println(strings.Repeat("-", 70))
+			for _, r := range rules {
+				fmt.Printf("%-5s %-7s %-36s %s\n", r.ID, r.Market, r, r.Note)
+			}
+			return nil
+		},
+	}
+}
+
+func newAlertRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm ID",
+		Aliases: []string{"remove"},
+		Short:   "Remove an alert rule",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := alert.NewStore(resolveDataDir()).Remove(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Removed %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newAlertWatchCmd() *cobra.Command {
+	var (
+		market   string
+		interval time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Evaluate alert rules in a loop without trading",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			b, p, err := connectKISMarket(cfg, market)
+			if err != nil {
+				return err
+			}
+
+			engine := alert.NewEngine(resolveDataDir(), market, b, p)
+			engine.SetInterval(0)
+			tg := notify.NewTelegramNotifier()
+			webhooks := newWebhooks(cfg).WithSource("cli-" + market)
+			engine.OnAlert(func(a alert.Alert) {
+				fmt.Printf("%s 🔔 %s\n", a.Time.Format("15:04:05"), a.Message)
+				tg.Send(context.Background(), "🔔 "+a.Message)
+				webhooks.Emit(notify.EventAlert, a)
+			})
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			fmt.Printf("Watching %s alert rules every %s (Ctrl+C to stop)\n", market, interval)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				if _, err := engine.Evaluate(ctx); err != nil {
+					return err
+				}
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "evaluation interval")
+	return cmd
+}
@@ -0,0 +1,672 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/backtest"
+	"traveler/internal/config"
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/internal/symbols"
+)
+
+// newBacktestCmd `traveler backtest` 저장된 백테스트 결과 관리 (실행은 --backtest 플래그)
+func newBacktestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backtest",
+		Short: "Inspect and compare saved backtest results",
+		Long: `Backtests run with --backtest --bt-save <name> are saved as JSON under
+<data-dir>/backtests. Use these subcommands to list and compare them.
+
+Every backtest run (saved or not) is also recorded in the experiment registry
+(<data-dir>/experiments.json) with its config hash; see 'backtest experiments'.`,
+		Example: `  traveler --backtest --strategy pullback --universe sp500 --bt-save pullback-base
+  traveler backtest list
+  traveler backtest compare pullback-base pullback-atr
+  traveler backtest experiments --limit 10`,
+	}
+	cmd.AddCommand(newBacktestListCmd(), newBacktestCompareCmd(), newBacktestExperimentsCmd())
+	return cmd
+}
+
+func newBacktestListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List saved backtest results",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dir := backtest.RunsDir(resolveDataDir())
+			runs, err := backtest.ListRuns(dir)
+			if err != nil {
+				return err
+			}
+			if len(runs) == 0 {
+				fmt.Printf("No saved backtests in %s (run with --backtest --bt-save <name>)\n", dir)
+				return nil
+			}
+			fmt.Printf("%-24s %-10s %-25s %8s %9s  %s\n", "NAME", "KIND", "PERIOD", "TRADES", "RETURN", "SAVED")
+			for _, r := range runs {
+				fmt.Printf("%-24s %-10s %-25s %8d %+8.1f%%  %s\n",
+					r.Name, r.Kind, r.Period, r.TotalTrades, r.TotalReturnPct, r.CreatedAt.Format("2006-01-02 15:04"))
+			}
+			return nil
+		},
+	}
+}
+
+func newBacktestCompareCmd() *cobra.Command {
+	var noChart bool
+	cmd := &cobra.Command{
+		Use:   "compare <run1> <run2>",
+		Short: "Diff two saved backtest results side by side",
+		Long: `Compare two saved backtests: stats table with deltas, parameters that differ,
+and overlaid equity curves (normalized to 100 at start).
+
+Arguments are JSON file paths or names saved under <data-dir>/backtests.`,
+		ValidArgsFunction: completeArgs(2, savedBacktestNames),
+		Args:              cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			a, err := loadBacktestRun(args[0])
+			if err != nil {
+				return err
+			}
+			b, err := loadBacktestRun(args[1])
+			if err != nil {
+				return err
+			}
+			printBacktestComparison(backtest.CompareRuns(a, b), !noChart)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&noChart, "no-chart", false, "omit the equity curve overlay")
+	return cmd
+}
+
+// backtestRunPath 이름 또는 경로 → JSON 경로 (경로 구분자나 .json이 없으면 <data-dir>/backtests/<name>.json)
+func backtestRunPath(nameOrPath string) string {
+	if strings.ContainsAny(nameOrPath, `/\`) || strings.HasSuffix(nameOrPath, ".json") {
+		return nameOrPath
+	}
+	return filepath.Join(backtest.RunsDir(resolveDataDir()), nameOrPath+".json")
+}
+
+func loadBacktestRun(nameOrPath string) (*backtest.SavedRun, error) {
+	path := nameOrPath
+	if _, err := os.Stat(path); err != nil {
+		path = backtestRunPath(nameOrPath)
+	}
+	run, err := backtest.LoadRun(path)
+	if err != nil {
+		return nil, fmt.Errorf("load backtest %s: %w", nameOrPath, err)
+	}
+	return run, nil
+}
+
+// recordBacktestRun 실험 레지스트리에 기록하고 --bt-save 지정 시 결과 저장
+func recordBacktestRun(run *backtest.SavedRun) {
+	savedAs := saveBacktestRun(run)
+
+	store, err := backtest.NewExperimentStore(resolveDataDir())
+	if err != nil {
+		fmt.Printf("Warning: experiment registry unavailable: %v\n", err)
+		return
+	}
+	exp, prior, err := store.Record(run, savedAs)
+	if err != nil {
+		fmt.Printf("Warning: could not record experiment: %v\n", err)
+		return
+	}
+	fmt.Printf("\nExperiment %s (config %s)\n", exp.ID, exp.ConfigHash)
+	if len(prior) > 0 {
+		p := prior[0]
+		fmt.Printf(" Same config already run %d time(s); last %s on %s: %+.1f%%, %d trades\n",
+			len(prior), p.ID, p.CreatedAt.Format("2006-01-02"), p.Stats.TotalReturnPct, p.Stats.TotalTrades)
+	}
+}
+
+// saveBacktestRun --bt-save 지정 시 결과 저장 (저장 경로 반환)
+func saveBacktestRun(run *backtest.SavedRun) string {
+	if btSave == "" {
+		return ""
+	}
+	path := backtestRunPath(btSave)
+	if run.Params.Scenario != "" { // 시나리오마다 따로 저장: <name>-<scenario>.json
+		path = strings.TrimSuffix(path, ".json") + "-" + run.Params.Scenario + ".json"
+	}
+	if run.Label == "" || run.Label == btSave {
+		run.Label = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	if err := backtest.SaveRun(path, run); err != nil {
+		fmt.Printf("\nWarning: could not save backtest: %v\n", err)
+		return ""
+	}
+	fmt.Printf("\nBacktest saved to %s\n", path)
+	return path
+}
+
+func newBacktestExperimentsCmd() *cobra.Command {
+	var (
+		hash  string
+		limit int
+	)
+	cmd := &cobra.Command{
+		Use:     "experiments",
+		Aliases: []string{"exp"},
+		Short:   "List recorded backtest runs (experiment registry)",
+		Long: `Every backtest run is recorded with a short ID, a config hash (same parameters,
+universe and period length → same hash) and its headline results, so you can
+see what has already been tried while tuning.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			store, err := backtest.NewExperimentStore(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			exps := store.All()
+			if hash != "" {
+				exps = store.ByHash(hash)
+			}
+			if len(exps) == 0 {
+				fmt.Println("No experiments recorded yet (run with --backtest)")
+				return nil
+			}
+			if limit > 0 && len(exps) > limit {
+				exps = exps[:limit]
+			}
+			fmt.Printf("%-8s %-13s %-16s %-10s %5s %6s %5s %4s %8s %7s %6s  %s\n",
+				"ID", "CONFIG", "DATE", "KIND", "DAYS", "RISK", "STOP", "TGT", "RETURN", "MAXDD", "TRADES", "NOTE")
+			for _, e := range exps {
+				note := e.Note
+				if note == "" && e.SavedAs != "" {
+					note = "saved: " + strings.TrimSuffix(filepath.Base(e.SavedAs), ".json")
+				}
+				fmt.Printf("%-8s %-13s %-16s %-10s %5d %5.1f%% %4.1f%% %3.1fR %+7.1f%% %6.1f%% %6d  %s\n",
+					e.ID, e.ConfigHash, e.CreatedAt.Format("2006-01-02 15:04"), e.Kind, e.Params.Days,
+					e.Params.RiskPerTrade*100, e.Params.StopLossPct*100, e.Params.TargetRMultiple,
+					e.Stats.TotalReturnPct, e.Stats.MaxDrawdown, e.Stats.TotalTrades, note)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&hash, "hash", "", "only runs with this config hash (prefix)")
+	cmd.Flags().IntVar(&limit, "limit", 30, "max rows (0 = all)")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a recorded experiment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			store, err := backtest.NewExperimentStore(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			e, err := store.Find(args[0])
+			if err != nil {
+				return err
+			}
+			data, err := json.MarshalIndent(e, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			if same := store.ByHash(e.ConfigHash); len(same) > 1 {
+				fmt.Printf("\n%d runs share config %s:\n", len(same), e.ConfigHash)
+				for _, o := range same {
+					fmt.Printf("  %s  %s  %+.1f%%\n", o.ID, o.CreatedAt.Format("2006-01-02 15:04"), o.Stats.TotalReturnPct)
+				}
+			}
+			return nil
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "note <id> <text>",
+		Short: "Attach a note to a recorded experiment",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			store, err := backtest.NewExperimentStore(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			return store.SetNote(args[0], strings.Join(args[1:], " "))
+		},
+	})
+	return cmd
+}
+
+func printBacktestComparison(cmp *backtest.RunComparison, chart bool) {
+	line := strings.Repeat("=", 70)
+	fmt.Println(line)
+	fmt.Println(" BACKTEST COMPARISON")
+	fmt.Println(line)
+	fmt.Printf(" A: %-20s %s %s (%s)\n", cmp.A.Label, cmp.A.Kind, cmp.A.Period, cmp.A.CreatedAt.Format("2006-01-02 15:04"))
+	fmt.Printf(" B: %-20s %s %s (%s)\n", cmp.B.Label, cmp.B.Kind, cmp.B.Period, cmp.B.CreatedAt.Format("2006-01-02 15:04"))
+
+	fmt.Printf("\n %-18s %12s %12s %12s\n", "Metric", "A", "B", "Δ (B-A)")
+	fmt.Println(" " + strings.Repeat("-", 58))
+	for _, m := range cmp.Metrics {
+		mark := ""
+		switch m.Better {
+		case "a":
+			mark = "  ← A"
+		case "b":
+			mark = "  ← B"
+		}
+		fmt.Printf(" %-18s %12s %12s %12s%s\n", m.Name,
+			formatMetric(m.A, m.Format, false), formatMetric(m.B, m.Format, false), formatMetric(m.Delta, m.Format, true), mark)
+	}
+
+	if len(cmp.Params) > 0 {
+		fmt.Println("\n--- Parameters that differ ---")
+		for _, p := range cmp.Params {
+			fmt.Printf(" %-18s %12s → %s\n", p.Name, p.A, p.B)
+		}
+	} else {
+		fmt.Println("\n Parameters identical")
+	}
+
+	if chart && len(cmp.EquityA) > 1 && len(cmp.EquityB) > 1 {
+		fmt.Println("\n--- Equity (start = 100)  * = A, o = B, # = both ---")
+		fmt.Print(renderEquityOverlay(cmp.EquityA, cmp.EquityB, 60, 14))
+	}
+	fmt.Println(line)
+}
+
+func formatMetric(v float64, format string, signed bool) string {
+	sign := ""
+	if signed && v > 0 {
+		sign = "+"
+	}
+	switch format {
+	case "pct":
+		return fmt.Sprintf("%s%.2f%%", sign, v)
+	case "r":
+		return fmt.Sprintf("%s%.2fR", sign, v)
+	case "int":
+		return fmt.Sprintf("%s%d", sign, int(math.Round(v)))
+	case "days":
+		return fmt.Sprintf("%s%dd", sign, int(math.Round(v)))
+	default:
+		return fmt.Sprintf("%s%.2f", sign, v)
+	}
+}
+
+// renderEquityOverlay 두 자산 곡선을 날짜축에 맞춰 텍스트 차트로 겹쳐 그림
+func renderEquityOverlay(a, b []backtest.EquityPoint, width, height int) string {
+	type pt struct {
+		t time.Time
+		v float64
+	}
+	parse := func(series []backtest.EquityPoint) []pt {
+		out := make([]pt, 0, len(series))
+		for _, p := range series {
+			if t, err := time.Parse("2006-01-02", p.Date); err == nil {
+				out = append(out, pt{t, p.Equity})
+			}
+		}
+		return out
+	}
+	pa, pb := parse(a), parse(b)
+	if len(pa) == 0 || len(pb) == 0 {
+		return ""
+	}
+
+	start, end := pa[0].t, pa[len(pa)-1].t
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range [][]pt{pa, pb} {
+		if s[0].t.Before(start) {
+			start = s[0].t
+		}
+		if s[len(s)-1].t.After(end) {
+			end = s[len(s)-1].t
+		}
+		for _, p := range s {
+			lo, hi = math.Min(lo, p.v), math.Max(hi, p.v)
+		}
+	}
+	if hi-lo < 1e-9 {
+		hi, lo = hi+1, lo-1
+	}
+	span := end.Sub(start)
+
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", width))
+	}
+	plot := func(s []pt, mark rune) {
+		// 각 열에 해당 시점 직전 값 (계단식)
+		j := 0
+		for x := 0; x < width; x++ {
+			t := start
+			if span > 0 {
+				t = start.Add(time.Duration(float64(span) * float64(x) / float64(width-1)))
+			}
+			if t.Before(s[0].t) {
+				continue
+			}
+			for j+1 < len(s) && !s[j+1].t.After(t) {
+				j++
+			}
+			if t.After(s[len(s)-1].t) && x > 0 {
+				break
+			}
+			y := int(math.Round((s[j].v - lo) / (hi - lo) * float64(height-1)))
+			row := height - 1 - y
+			switch grid[row][x] {
+			case ' ':
+				grid[row][x] = mark
+			case mark:
+			default:
+				grid[row][x] = '#'
+			}
+		}
+	}
+	plot(pa, '*')
+	plot(pb, 'o')
+
+	var sb strings.Builder
+	for i, row := range grid {
+		label := ""
+		switch i {
+		case 0:
+			label = fmt.Sprintf("%7.1f", hi)
+		case height - 1:
+			label = fmt.Sprintf("%7.1f", lo)
+		}
+		fmt.Fprintf(&sb, " %7s |%s\n", label, strings.TrimRight(string(row), " "))
+	}
+	fmt.Fprintf(&sb, " %7s +%s\n", "", strings.Repeat("-", width))
+	fmt.Fprintf(&sb, " %7s  %-*s%s\n", "", width-10, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	return sb.String()
+}
+
+// backtestProvider --data-source에 따라 백테스트 일봉 Provider 구성
+// (auto/cache는 <data-dir>/candles/daily 영구 캐시 사용, cache는 네트워크 없음,
+// network도 받은 일봉을 캐시에 남겨 이어지는 스캔이 재사용)
+func backtestProvider(p provider.Provider) (provider.Provider, error) {
+	mode, err := provider.ParseDataSource(btDataSource)
+	if err != nil {
+		return nil, err
+	}
+	store := provider.NewCandleStore(resolveDataDir())
+	if mode == provider.DataSourceNetwork {
+		return provider.NewStoreProvider(p, store, mode), nil
+	}
+	fmt.Printf("Data source: %s (%s)\n", mode, store.Dir())
+	return provider.NewStoreProvider(p, store, mode), nil
+}
+
+// backtestOffline --data-source cache (네트워크 전용 부가 기능은 건너뜀)
+func backtestOffline() bool {
+	mode, _ := provider.ParseDataSource(btDataSource)
+	return mode == provider.DataSourceCache
+}
+
+// backtestFeeMarket 백테스트 수수료 프리셋 마켓 (과반이 한국 종목이면 kr)
+func backtestFeeMarket(syms []string) string {
+	kr := 0
+	for _, s := range syms {
+		if symbols.IsKoreanSymbol(s) {
+			kr++
+		}
+	}
+	if len(syms) > 0 && kr*2 > len(syms) {
+		return "kr"
+	}
+	return "us"
+}
+
+// backtestBenchmark --benchmark 값 (빈 값 = 마켓 기본 ETF, none = 비교 안 함)
+func backtestBenchmark(market string) string {
+	switch strings.ToLower(btBenchmark) {
+	case "none", "off":
+		return ""
+	case "":
+		if market == "kr" {
+			return "069500"
+		}
+		return "SPY"
+	}
+	return strings.ToUpper(btBenchmark)
+}
+
+// backtestStopModel --stop-model 또는 config의 pullback 손절 모델 (백테스트는 pullback 전략)
+func backtestStopModel() (strategy.StopModel, error) {
+	if btStopModel != "" {
+		return strategy.ParseStopModel(btStopModel)
+	}
+	m, _ := strategy.StopModelFor("pullback")
+	return m, nil
+}
+
+// resolveBacktestParams 명시하지 않은 --bt-* 플래그는 config backtest 값으로 (0이면 플래그 기본값 유지)
+func resolveBacktestParams(cmd *cobra.Command, c config.BacktestConfig) error {
+	flags := cmd.Flags()
+	if !flags.Changed("bt-risk") && c.RiskPct != 0 {
+		btRiskPct = c.RiskPct
+	}
+	if !flags.Changed("bt-stop") && c.StopPct != 0 {
+		btStopPct = c.StopPct
+	}
+	if !flags.Changed("bt-target-r") && c.TargetR != 0 {
+		btTargetR = c.TargetR
+	}
+	if !flags.Changed("bt-maxhold") && c.MaxHoldDays != 0 {
+		btMaxHold = c.MaxHoldDays
+	}
+	if !flags.Changed("bt-max-positions") && c.MaxPositions != 0 {
+		btMaxPositions = c.MaxPositions
+	}
+	switch {
+	case btRiskPct <= 0 || btRiskPct > 100:
+		return fmt.Errorf("--bt-risk must be in (0, 100] percent (got %g)", btRiskPct)
+	case btStopPct <= 0 || btStopPct >= 100:
+		return fmt.Errorf("--bt-stop must be in (0, 100) percent (got %g)", btStopPct)
+	case btTargetR <= 0:
+		return fmt.Errorf("--bt-target-r must be positive (got %g)", btTargetR)
+	case btMaxHold < 1:
+		return fmt.Errorf("--bt-maxhold must be at least 1 trading day (got %d)", btMaxHold)
+	case btMaxPositions < 1:
+		return fmt.Errorf("--bt-max-positions must be at least 1 (got %d)", btMaxPositions)
+	}
+	return resolveBacktestRange(cmd)
+}
+
+// resolveBacktestRange --from/--to 파싱. 구간을 주면 거래일 수(--backtest-days)를 구간 길이로 맞춘다.
+func resolveBacktestRange(cmd *cobra.Command) error {
+	if btFromStr == "" {
+		if btToStr != "" {
+			return fmt.Errorf("--to requires --from")
+		}
+		return nil
+	}
+	if btScenario != "" {
+		return fmt.Errorf("--from/--to cannot be combined with --scenario (scenarios set their own periods)")
+	}
+	if cmd.Flags().Changed("backtest-days") {
+		return fmt.Errorf("--from/--to replaces --backtest-days; use one or the other")
+	}
+	from, err := time.Parse("2006-01-02", btFromStr)
+	if err != nil {
+		return fmt.Errorf("invalid --from %q (want YYYY-MM-DD)", btFromStr)
+	}
+	end := time.Now()
+	if btToStr != "" {
+		if btTo, err = time.Parse("2006-01-02", btToStr); err != nil {
+			return fmt.Errorf("invalid --to %q (want YYYY-MM-DD)", btToStr)
+		}
+		end = btTo
+	}
+	if !from.Before(end) {
+		return fmt.Errorf("--from %s must be before --to %s", btFromStr, end.Format("2006-01-02"))
+	}
+	btFrom = from
+	backtestDays = backtest.TradingDaysBetween(from, end)
+	return nil
+}
+
+// backtestRangeLabel 배너용 구간 ("2022-01-03 ~ 2022-12-30", 종료일 없으면 "~ latest")
+func backtestRangeLabel() string {
+	to := "latest"
+	if !btTo.IsZero() {
+		to = btTo.Format("2006-01-02")
+	}
+	return btFrom.Format("2006-01-02") + " ~ " + to
+}
+
+// printDrawdownStats 낙폭/회복 기간 요약
+func printDrawdownStats(label string, st *backtest.DrawdownStats) {
+	fmt.Printf(" %s: max drawdown %.1f%% (%s → %s, %d days)\n",
+		label, st.MaxDrawdown, st.PeakDate, st.TroughDate, st.PeakToTroughDays)
+	if st.RecoveryDays >= 0 {
+		fmt.Printf("   Recovered in %d days (%s)", st.RecoveryDays, st.RecoveryDate)
+	} else {
+		fmt.Printf("   Not recovered by period end")
+	}
+	fmt.Printf(" | longest underwater %d days | below peak %.0f%% of days", st.LongestUnderwaterDays, st.UnderwaterPct)
+	if st.CurrentUnderwaterDays > 0 {
+		fmt.Printf(" | underwater now %d days", st.CurrentUnderwaterDays)
+	}
+	fmt.Println()
+}
+
+// runScenarioBacktests --scenario: 스트레스 구간마다 같은 설정으로 포트폴리오 백테스트 후 나란히 요약
+func runScenarioBacktests(ctx context.Context, syms []string, p provider.Provider, cfg backtest.PortfolioBacktestConfig) error {
+	scenarios, err := backtest.ParseScenarios(btScenario)
+	if err != nil {
+		return err
+	}
+
+	type scenarioRun struct {
+		sc     backtest.Scenario
+		result *backtest.PortfolioBacktestResult
+		err    error
+	}
+	runs := make([]scenarioRun, 0, len(scenarios))
+	for _, sc := range scenarios {
+		fmt.Printf("\n=== %s: %s (%s) ===\n", sc.Name, sc.Title, sc.Period())
+		scCfg := cfg
+		scCfg.From, scCfg.To = sc.Start, sc.End
+		result, err := backtest.NewPortfolioBacktester(scCfg, p).Run(ctx, syms, sc.TradingDays())
+		if err != nil {
+			fmt.Printf(" %s failed: %v\n", sc.Name, err)
+		} else if result.TotalTrades > 0 {
+			recordBacktestRun(backtest.NewPortfolioRun(btSave, scCfg,
+				backtest.RunParams{Days: result.TradingDays, Universe: universe, Symbols: syms, Scenario: sc.Name}, result))
+		}
+		runs = append(runs, scenarioRun{sc: sc, result: result, err: err})
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 100))
+	fmt.Println(" STRESS SCENARIOS")
+	fmt.Println(strings.Repeat("=", 100))
+	fmt.Printf(" %-11s %-23s %6s %7s %9s %9s %8s %9s %6s %7s\n",
+		"SCENARIO", "PERIOD", "TRADES", "WIN%", "RETURN", "BENCH", "MAX DD", "RECOVERY", "PF", "EXP R")
+	worst := -1
+	for i, r := range runs {
+		if r.err != nil {
+			fmt.Printf(" %-11s %-23s  error: %v\n", r.sc.Name, r.sc.Period(), r.err)
+			continue
+		}
+		res := r.result
+		bench := "-"
+		if res.Benchmark != "" {
+			bench = fmt.Sprintf("%+.1f%%", res.BenchmarkReturnPct)
+		}
+		recovery := "-"
+		if res.Drawdown != nil {
+			if res.Drawdown.RecoveryDays >= 0 {
+				recovery = fmt.Sprintf("%dd", res.Drawdown.RecoveryDays)
+			} else {
+				recovery = "never"
+			}
+		}
+		fmt.Printf(" %-11s %-23s %6d %6.1f%% %+8.1f%% %9s %7.1f%% %9s %6.2f %+7.2f\n",
+			r.sc.Name, res.Period, res.TotalTrades, res.WinRate, res.TotalReturnPct, bench,
+			res.MaxDrawdown, recovery, res.ProfitFactor, res.ExpectancyR)
+		if worst < 0 || res.MaxDrawdown > runs[worst].result.MaxDrawdown {
+			worst = i
+		}
+	}
+	if worst >= 0 {
+		w := runs[worst]
+		fmt.Printf("\n Worst case: %s — max drawdown %.1f%%, return %+.1f%%\n",
+			w.sc.Name, w.result.MaxDrawdown, w.result.TotalReturnPct)
+	}
+	fmt.Println(strings.Repeat("=", 100))
+	return nil
+}
+
+// printGapStats 갭 손절 (시가가 손절가 아래) 통계
+func printGapStats(g *backtest.GapStats) {
+	fmt.Println("\n--- Gap Risk ---")
+	fmt.Printf(" Gap Stops:       %d/%d stops (%.0f%%) opened below the stop and filled at the open\n",
+		g.GapStops, g.Stops, g.GapStopPct)
+	if g.GapStops == 0 {
+		return
+	}
+	fmt.Printf(" Extra Loss:      %s vs filling at the stop\n", formatMoney(g.GapLoss))
+	fmt.Printf(" Gap Below Stop:  avg %.2f%% | worst %.2f%%\n", g.AvgGapPct, g.WorstGapPct)
+	fmt.Printf(" Gap Stop R:      avg %+.2fR | worst %+.2fR\n", g.AvgR, g.WorstR)
+}
+
+// printFrictionReport 비용 배수별 기대값 표
+func printFrictionReport(r *backtest.FrictionReport) {
+	if r == nil {
+		return
+	}
+	fmt.Printf("\n--- Cost Sensitivity (%d trades) ---\n", r.Trades)
+	fmt.Printf(" %6s %9s %9s %13s %8s %7s %6s %14s\n",
+		"COSTS", "SLIPPAGE", "COMMISSN", "EXPECTANCY", "EXP R", "WIN%", "PF", "TOTAL P&L")
+	for _, l := range r.Levels {
+		fmt.Printf(" %5.1fx %8.3f%% %8.3f%% %13s %+8.2f %6.1f%% %6.2f %14s\n",
+			l.Multiplier, l.Slippage*100, l.Commission*100, formatMoney(l.Expectancy),
+			l.ExpectancyR, l.WinRate, l.ProfitFactor, formatMoney(l.TotalPnL))
+	}
+	switch {
+	case r.Breakeven == 0:
+		fmt.Println(" No edge: expectancy is negative even with zero slippage and commission")
+	case r.Breakeven < 0:
+		fmt.Println(" Edge survives 10x the assumed costs")
+	case r.Fragile:
+		fmt.Printf(" WARNING: edge disappears at %.1fx the assumed costs — likely not tradable with realistic frictions\n", r.Breakeven)
+	default:
+		fmt.Printf(" Breakeven at %.1fx the assumed costs\n", r.Breakeven)
+	}
+}
+
+// printSizingStress 리스크 × 최대 포지션 Monte Carlo 결과 표
+func printSizingStress(res *backtest.SizingStressResult) {
+	if res == nil {
+		return
+	}
+	fmt.Printf("\n--- Position Sizing Stress Test (%d trades resampled, %d rounds, %.1f signals/round) ---\n",
+		res.Trades, res.Rounds, res.SignalsPerRound)
+	fmt.Printf(" %6s %5s %6s %9s %9s %9s %8s %8s %6s %7s\n",
+		"Risk", "MaxP", "Heat", "Median", "Worst5%", "Best95%", "MedDD", "P95DD", "Ruin", "Ret/DD")
+	for i := range res.Scenarios {
+		s := &res.Scenarios[i]
+		mark := ""
+		if s == res.Recommended {
+			mark = "  ◀"
+		}
+		fmt.Printf(" %5.1f%% %5d %5.1f%% %+8.1f%% %+8.1f%% %+8.1f%% %7.1f%% %7.1f%% %5.1f%% %7.2f%s\n",
+			s.RiskPerTrade*100, s.MaxPositions, s.Heat*100, s.MedianReturn, s.WorstCase, s.BestCase,
+			s.MedianDrawdown, s.P95Drawdown, s.RuinProbability, s.ReturnToDD, mark)
+	}
+	fmt.Println(" Ruin = drawdown of 50% or more. Heat = risk per trade x max positions.")
+	if r := res.Recommended; r != nil {
+		fmt.Printf(" Suggested SizerConfig: RiskPerTrade %.3f, MaxPositions %d (highest median growth with P95 drawdown <= 25%%, ruin < 1%%)\n",
+			r.RiskPerTrade, r.MaxPositions)
+	} else {
+		fmt.Println(" No combination kept P95 drawdown <= 25% with ruin < 1% — reduce risk below 0.5%.")
+	}
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/broker"
+	"traveler/internal/config"
+	"traveler/internal/money"
+	"traveler/internal/notify"
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+)
+
+// briefGapAlertPct 이 이상 갭이면 보유 종목에 표시
+const briefGapAlertPct = 3.0
+
+// newBriefCmd `traveler brief` 장 시작 전 브리핑 (보유 종목 갭, 실적 발표, 레짐, 전일 시그널 유효성)
+func newBriefCmd() *cobra.Command {
+	var (
+		market       string
+		output       string
+		sendNotify   bool
+		earningsDays int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "brief",
+		Short: "Pre-market briefing: overnight gaps, earnings, regime, yesterday's signals",
+		Long: `Summarize what matters before the open:
+  - overnight gaps for held positions (US: last pre-market trade vs previous close;
+    KR has no pre-market quote, so the gap is shown once the session opens)
+  - upcoming earnings for holdings
+  - market regime (benchmark vs MA20/MA50, RSI)
+  - yesterday's scan signals that are still actionable
+
+The briefing is printed, saved to <data-dir>/brief_<market>_<date>.txt (or --output),
+and with --notify sent via Telegram (TELEGRAM_BOT_TOKEN/TELEGRAM_CHAT_ID) and
+email (SMTP_HOST/REPORT_EMAIL_TO).`,
+		Example: `  traveler brief
+  traveler brief --market kr --notify
+  traveler brief --output - --earnings-days 7`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			loadEnvFile()
+
+			b, p, err := connectKISMarket(cfg, market)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			text := buildBrief(ctx, b, p, market, earningsDays)
+			fmt.Print(text)
+
+			if output == "" {
+				output = filepath.Join(resolveDataDir(), fmt.Sprintf("brief_%s_%s.txt", market, time.Now().Format("2006-01-02")))
+			}
+			if output != "-" {
+				if err := os.WriteFile(output, []byte(text), 0644); err != nil {
+					return fmt.Errorf("write brief: %w", err)
+				}
+				fmt.Printf("\nSaved to %s\n", output)
+			}
+
+			if sendNotify {
+				sendBrief(ctx, market, text)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr")
+	cmd.Flags().StringVar(&output, "output", "", "output file (default <data-dir>/brief_<market>_<date>.txt, '-' to skip)")
+	cmd.Flags().BoolVar(&sendNotify, "notify", false, "send via Telegram and email if configured")
+	cmd.Flags().IntVar(&earningsDays, "earnings-days", 14, "report earnings within this many days")
+	return cmd
+}
+
+// buildBrief 브리핑 텍스트 생성 (섹션별 조회 실패는 해당 섹션에 표시하고 계속)
+func buildBrief(ctx context.Context, b broker.Broker, p provider.Provider, market string, earningsDays int) string {
+	cur := money.ForMarket(market)
+	loc := briefMarketLocation(market)
+	now := time.Now().In(loc)
+
+	var sb strings.Builder
+	line := strings.Repeat("=", 70)
+	fmt.Fprintf(&sb, "\n%s\n PRE-MARKET BRIEF — %s %s\n%s\n", line, strings.ToUpper(market), now.Format("2006-01-02 (Mon) 15:04 MST"), line)
+
+	balance, err := b.GetBalance(ctx)
+	var positions []broker.Position
+	equity := 0.0
+	if err != nil {
+		fmt.Fprintf(&sb, "  ! balance unavailable: %v\n", err)
+	} else {
+		positions = balance.Positions
+		equity = balance.TotalEquity
+		fmt.Fprintf(&sb, "  Equity %s, cash %s\n", money.Format(balance.TotalEquity, cur), money.Format(balance.CashBalance, cur))
+	}
+	if len(positions) == 0 {
+		if ps, err := b.GetPositions(ctx); err == nil {
+			positions = ps
+		}
+	}
+
+	// 1) 마켓 레짐
+	metaCfg := strategy.DefaultStockMetaConfig(market, equity)
+	info := strategy.NewStockMetaStrategy(metaCfg, p).GetRegimeInfo(ctx)
+	sb.WriteString("\nMARKET REGIME\n-------------\n")
+	if info.Price > 0 {
+		fmt.Fprintf(&sb, "  %s (%s): %s %+.2f%%, MA20 %s, MA50 %s, RSI %.0f\n",
+			strings.ToUpper(string(info.Regime)), info.Symbol, money.Price(info.Price, cur), info.DayChangePct,
+			money.Price(info.MA20, cur), money.Price(info.MA50, cur), info.RSI14)
+	} else {
+		fmt.Fprintf(&sb, "  %s (benchmark data unavailable)\n", strings.ToUpper(string(info.Regime)))
+	}
+
+	plans := map[string]*trader.PositionPlan{}
+	if ps, err := trader.NewPlanStore(resolveDataDir()); err == nil {
+		plans = ps.All()
+	}
+
+	// 2) 보유 종목 갭
+	sb.WriteString("\nHOLDINGS — OVERNIGHT GAPS\n-------------------------\n")
+	if len(positions) == 0 {
+		sb.WriteString("  No positions\n")
+	}
+	held := make(map[string]bool, len(positions))
+	yahoo := provider.NewYahooProvider()
+	for _, pos := range positions {
+		held[pos.Symbol] = true
+		prevClose, price := briefPrevClose(ctx, p, pos.Symbol, now, loc), pos.CurrentPrice
+		gapPrice := briefGapPrice(ctx, yahoo, market, pos.Symbol, price, now)
+		var flags []string
+		gapText := "n/a"
+		if prevClose > 0 && gapPrice > 0 {
+			price = gapPrice
+			gap := (price/prevClose - 1) * 100
+			gapText = fmt.Sprintf("%+.2f%%", gap)
+			if math.Abs(gap) >= briefGapAlertPct {
+				flags = append(flags, "large gap")
+			}
+		}
+		if plan := plans[pos.Symbol]; plan != nil {
+			if plan.StopLoss > 0 && price > 0 && price <= plan.StopLoss {
+				flags = append(flags, "AT/BELOW STOP "+money.Price(plan.StopLoss, cur))
+			}
+			if plan.Target1 > 0 && price >= plan.Target1 && !plan.Target1Hit {
+				flags = append(flags, "above T1")
+			}
+			if plan.CrossesExDividend(now) {
+				flags = append(flags, "ex-div "+plan.ExDividendDate)
+			}
+		} else {
+			flags = append(flags, "no plan")
+		}
+		fmt.Fprintf(&sb, "  %-14s %s → %s  gap %-8s P&L %+.1f%%",
+			briefName(pos.Symbol), money.Price(prevClose, cur), money.Price(price, cur), gapText, pos.UnrealizedPct)
+		if len(flags) > 0 {
+			fmt.Fprintf(&sb, "  [%s]", strings.Join(flags, ", "))
+		}
+		sb.WriteString("\n")
+	}
+
+	// 3) 실적 발표
+	fmt.Fprintf(&sb, "\nUPCOMING EARNINGS (%dd)\n----------------------\n", earningsDays)
+	if len(positions) > 0 {
+		fc := provider.NewFundamentalsChecker(resolveDataDir(), nil)
+		if err := fc.Init(ctx); err != nil {
+			fmt.Fprintf(&sb, "  ! earnings calendar unavailable: %v\n", err)
+		} else {
+			found := 0
+			horizon := now.AddDate(0, 0, earningsDays)
+			for _, pos := range positions {
+				date, err := fc.NextEarningsDate(ctx, pos.Symbol)
+				if err != nil || date.IsZero() || date.After(horizon) {
+					continue
+				}
+				found++
+				note := ""
+				if plan := plans[pos.Symbol]; plan != nil && !date.After(plan.PlannedExitDate()) {
+					note = "  (within planned hold)"
+				}
+				fmt.Fprintf(&sb, "  %-14s %s, in %d days%s\n", briefName(pos.Symbol),
+					date.In(loc).Format("2006-01-02 Mon"), int(date.Sub(now).Hours()/24), note)
+			}
+			if found == 0 {
+				sb.WriteString("  None for current holdings\n")
+			}
+		}
+	} else {
+		sb.WriteString("  No holdings\n")
+	}
+
+	// 4) 전일 시그널 유효성
+	sb.WriteString("\nYESTERDAY'S SIGNALS\n-------------------\n")
+	sigs, scannedAt, err := loadLastScanSignals(market)
+	switch {
+	case err != nil:
+		fmt.Fprintf(&sb, "  No saved scan (%v)\n", err)
+	case len(sigs) == 0:
+		fmt.Fprintf(&sb, "  Scan at %s found no signals\n", scannedAt.In(loc).Format("01-02 15:04"))
+	default:
+		fmt.Fprintf(&sb, "  Scan at %s, %d signals\n", scannedAt.In(loc).Format("01-02 15:04"), len(sigs))
+		for _, sig := range sigs {
+			if sig.Guide == nil {
+				continue
+			}
+			status := briefSignalStatus(ctx, p, sig, held[sig.Stock.Symbol])
+			fmt.Fprintf(&sb, "  %-14s %-20s entry %s stop %s T1 %s — %s\n",
+				briefName(sig.Stock.Symbol), sig.Strategy, money.Price(sig.Guide.EntryPrice, cur),
+				money.Price(sig.Guide.StopLoss, cur), money.Price(sig.Guide.Target1, cur), status)
+		}
+	}
+
+	sb.WriteString(line + "\n")
+	return sb.String()
+}
+
+// briefSignalStatus 전일 시그널이 아직 유효한지 (최근 종가 기준)
+func briefSignalStatus(ctx context.Context, p provider.Provider, sig strategy.Signal, held bool) string {
+	if held {
+		return "already held"
+	}
+	candles, err := p.GetDailyCandles(ctx, sig.Stock.Symbol, 2)
+	if err != nil || len(candles) == 0 {
+		return "price unavailable"
+	}
+	last := candles[len(candles)-1].Close
+	g := sig.Guide
+	switch {
+	case g.StopLoss > 0 && last <= g.StopLoss:
+		return "INVALID (stop hit)"
+	case g.Target1 > 0 && last >= g.Target1:
+		return "INVALID (T1 reached)"
+	case g.EntryPrice > 0 && last > g.EntryPrice*1.02:
+		return fmt.Sprintf("extended (+%.1f%% vs entry)", (last/g.EntryPrice-1)*100)
+	default:
+		return "VALID"
+	}
+}
+
+// briefGapPrice 갭 계산용 가격
+// 장 시작 전 잔고 현재가는 전일 종가라 갭이 항상 0 → US는 프리마켓 마지막 체결가,
+// 정규장 중이면 잔고 현재가. 프리마켓 체결이 없거나 KR 장 전이면 0 (갭 n/a)
+func briefGapPrice(ctx context.Context, yahoo *provider.YahooProvider, market, symbol string, current float64, now time.Time) float64 {
+	open := 9*60 + 30
+	if market == "kr" {
+		open = 9 * 60
+	}
+	if now.Weekday() != time.Saturday && now.Weekday() != time.Sunday && now.Hour()*60+now.Minute() >= open {
+		return current
+	}
+	if market != "us" {
+		return 0
+	}
+	price, _, err := yahoo.GetPreMarketQuote(ctx, symbol)
+	if err != nil {
+		return 0
+	}
+	return price
+}
+
+// briefPrevClose 직전 완료 일봉 종가 (오늘 일봉이 이미 있으면 그 전날)
+func briefPrevClose(ctx context.Context, p provider.Provider, symbol string, now time.Time, loc *time.Location) float64 {
+	candles, err := p.GetDailyCandles(ctx, symbol, 5)
+	if err != nil || len(candles) == 0 {
+		return 0
+	}
+	last := candles[len(candles)-1]
+	if last.Time.In(loc).Format("2006-01-02") == now.Format("2006-01-02") && len(candles) >= 2 {
+		return candles[len(candles)-2].Close
+	}
+	return last.Close
+}
+
+// loadLastScanSignals 데몬/웹이 저장한 last_scan_<market>.json의 시그널 (확률 높은 순)
+func loadLastScanSignals(market string) ([]strategy.Signal, time.Time, error) {
+	path := filepath.Join(resolveDataDir(), fmt.Sprintf("last_scan_%s.json", market))
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var scan struct {
+		Signals []strategy.Signal `json:"signals"`
+	}
+	if err := json.Unmarshal(data, &scan); err != nil {
+		return nil, time.Time{}, err
+	}
+	sort.SliceStable(scan.Signals, func(i, j int) bool {
+		return scan.Signals[i].Probability > scan.Signals[j].Probability
+	})
+	return scan.Signals, st.ModTime(), nil
+}
+
+// sendBrief 텔레그램/이메일 발송 (설정된 채널만)
+func sendBrief(ctx context.Context, market, text string) {
+	sent := false
+	if tg := notify.NewTelegramNotifier(); tg != nil {
+		msg := text
+		if r := []rune(msg); len(r) > 3900 { // Telegram 4096자 제한
+			msg = string(r[:3900]) + "\n…"
+		}
+		if err := tg.SendSync(ctx, "```\n"+msg+"\n```"); err != nil {
+			fmt.Printf("Telegram send failed: %v\n", err)
+		} else {
+			sent = true
+			fmt.Println("Sent via Telegram")
+		}
+	}
+	if mailer := notify.NewEmailNotifier(); mailer != nil {
+		subject := fmt.Sprintf("[traveler] %s pre-market brief %s", strings.ToUpper(market), time.Now().Format("2006-01-02"))
+		if err := mailer.Send(subject, text, nil); err != nil {
+			fmt.Printf("Email send failed: %v\n", err)
+		} else {
+			sent = true
+			fmt.Printf("Sent via email to %s\n", strings.Join(mailer.Recipients(), ", "))
+		}
+	}
+	if !sent {
+		fmt.Println("No notifier configured (TELEGRAM_BOT_TOKEN/TELEGRAM_CHAT_ID or SMTP_HOST/REPORT_EMAIL_TO)")
+	}
+}
+
+func briefMarketLocation(market string) *time.Location {
+	name := "America/New_York"
+	if market == "kr" {
+		name = "Asia/Seoul"
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+func briefName(symbol string) string {
+	if symbols.IsKoreanSymbol(symbol) {
+		return symbols.GetKRSymbolName(symbol)
+	}
+	return symbol
+}
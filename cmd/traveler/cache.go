@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/config"
+	"traveler/internal/provider"
+	"traveler/pkg/model"
+)
+
+// cacheEntry 종목별 캐시 현황
+type cacheEntry struct {
+	Symbol        string
+	DailyBytes    int64
+	Candles       int
+	First, Last   time.Time
+	IntradayDays  int
+	IntradayBytes int64
+	Err           error
+}
+
+// newCacheCmd `traveler cache` 로컬 캐시 점검/정리
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and maintain the local candle cache (<data-dir>/candles)",
+		Example: `  traveler cache stats --top 10 --sort stale
+  traveler cache prune --stale-days 60 --keep-years 10 --dry-run
+  traveler cache verify --fix --refetch`,
+	}
+	cmd.AddCommand(newCacheStatsCmd(), newCachePruneCmd(), newCacheVerifyCmd())
+	return cmd
+}
+
+func newCacheStatsCmd() *cobra.Command {
+	var (
+		top    int
+		sortBy string
+	)
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show cache size, bar counts and date ranges per symbol",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			store := provider.NewCandleStore(resolveDataDir())
+			entries, err := scanCache(store)
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				fmt.Printf("Cache is empty (%s). Populate it with 'traveler fetch'.\n", store.Dir())
+				return nil
+			}
+
+			switch sortBy {
+			case "name":
+				sort.Slice(entries, func(i, j int) bool { return entries[i].Symbol < entries[j].Symbol })
+			case "stale":
+				sort.Slice(entries, func(i, j int) bool { return entries[i].Last.Before(entries[j].Last) })
+			default:
+				sort.Slice(entries, func(i, j int) bool {
+					return entries[i].DailyBytes+entries[i].IntradayBytes > entries[j].DailyBytes+entries[j].IntradayBytes
+				})
+			}
+
+			var totalDaily, totalIntraday int64
+			var candles, intradayDays, stale, corrupt int
+			for _, e := range entries {
+				totalDaily += e.DailyBytes
+				totalIntraday += e.IntradayBytes
+				candles += e.Candles
+				intradayDays += e.IntradayDays
+				if e.Err != nil {
+					corrupt++
+				} else if e.Candles > 0 && time.Since(e.Last) > 7*24*time.Hour {
+					stale++
+				}
+			}
+
+			fmt.Printf("\nCANDLE CACHE  %s\n", filepath.Dir(store.Dir()))
+			fmt.Println(strings.Repeat("=", 82))
+			fmt.Printf("%-10s %9s %7s %-10s %-10s %9s %10s\n", "Symbol", "Daily", "Bars", "First", "Last", "Intraday", "Size")
+			fmt.Println(strings.Repeat("-", 82))
+			shown := entries
+			if top > 0 && len(shown) > top {
+				shown = shown[:top]
+			}
+			for _, e := range shown {
+				if e.Err != nil {
+					fmt.Printf("%-10s %9s  CORRUPT: %v\n", e.Symbol, formatBytes(e.DailyBytes), e.Err)
+					continue
+				}
+				fmt.Printf("%-10s %9s %7d %-10s %-10s %8dd %10s\n", e.Symbol, formatBytes(e.DailyBytes), e.Candles,
+					cacheDate(e.First), cacheDate(e.Last), e.IntradayDays, formatBytes(e.DailyBytes+e.IntradayBytes))
+			}
+			if len(shown) < len(entries) {
+				fmt.Printf("... %d more (use --top 0 to show all)\n", len(entries)-len(shown))
+			}
+			fmt.Println(strings.Repeat("-", 82))
+			fmt.Printf("%d symbols, %d daily bars (%s), %d intraday days (%s), total %s\n",
+				len(entries), candles, formatBytes(totalDaily), intradayDays, formatBytes(totalIntraday), formatBytes(totalDaily+totalIntraday))
+			if stale > 0 || corrupt > 0 {
+				fmt.Printf("%d stale (>7d since last bar), %d corrupt — see 'traveler cache prune' / 'traveler cache verify'\n", stale, corrupt)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&top, "top", 20, "show the N largest symbols (0 = all)")
+	cmd.Flags().StringVar(&sortBy, "sort", "size", "sort by: size, name or stale")
+	return cmd
+}
+
+func newCachePruneCmd() *cobra.Command {
+	var (
+		staleDays    int
+		keepYears    float64
+		intradayDays int
+		dryRun       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Drop stale symbols, old daily bars and old intraday files",
+		Long: `Remove data that is no longer useful:
+  --stale-days     delete symbols whose last daily bar is older than N days (delisted/renamed; off unless set,
+                   since long-held or rarely traded symbols also go quiet)
+  --keep-years     trim daily bars older than N years
+  --intraday-days  delete intraday files older than N days`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			store := provider.NewCandleStore(resolveDataDir())
+			syms, err := store.Symbols()
+			if err != nil {
+				return err
+			}
+			verb := "Removed"
+			if dryRun {
+				verb = "Would remove"
+			}
+
+			now := time.Now()
+			var removedSyms, trimmedBars int
+			for _, sym := range syms {
+				candles, err := store.Load(sym)
+				if err != nil || len(candles) == 0 {
+					continue // 손상 파일은 verify에서 처리
+				}
+				if staleDays > 0 && now.Sub(candles[len(candles)-1].Time) > time.Duration(staleDays)*24*time.Hour {
+					fmt.Printf("  %-10s stale (last bar %s)\n", sym, cacheDate(candles[len(candles)-1].Time))
+					removedSyms++
+					if !dryRun {
+						store.Remove(sym)
+					}
+					continue
+				}
+				if keepYears > 0 {
+					cutoff := now.AddDate(0, 0, -int(keepYears*365))
+					i := sort.Search(len(candles), func(i int) bool { return !candles[i].Time.Before(cutoff) })
+					if i > 0 {
+						trimmedBars += i
+						if !dryRun {
+							if err := store.Replace(sym, candles[i:]); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+
+			var removedFiles int
+			var freed int64
+			if intradayDays > 0 {
+				cutoff := now.AddDate(0, 0, -intradayDays).Format("2006-01-02")
+				filepath.Walk(store.IntradayDir(), func(path string, info os.FileInfo, err error) error {
+					if err != nil || info.IsDir() || len(info.Name()) < 10 {
+						return nil
+					}
+					if info.Name()[:10] < cutoff {
+						removedFiles++
+						freed += info.Size()
+						if !dryRun {
+							os.Remove(path)
+						}
+					}
+					return nil
+				})
+			}
+
+			fmt.Printf("%s %d stale symbols, %d daily bars older than %.1f years, %d intraday files (%s)\n",
+				verb, removedSyms, trimmedBars, keepYears, removedFiles, formatBytes(freed))
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&staleDays, "stale-days", 0, "remove symbols with no new bar for N days (0 = keep, must be set explicitly)")
+	cmd.Flags().Float64Var(&keepYears, "keep-years", 0, "trim daily bars older than N years (0 = keep all)")
+	cmd.Flags().IntVar(&intradayDays, "intraday-days", 30, "remove intraday files older than N days (0 = keep)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only report what would be removed")
+	return cmd
+}
+
+func newCacheVerifyCmd() *cobra.Command {
+	var (
+		fix     bool
+		refetch bool
+		years   float64
+	)
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Re-validate cached files and repair corrupted entries",
+		Long: `Check every cached file: unreadable JSON, non-positive prices, high < low,
+open/close outside the range and duplicate/out-of-order dates.
+With --fix, bad bars are dropped and unreadable files removed; with --refetch,
+symbols whose daily file was unreadable are downloaded again.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			store := provider.NewCandleStore(resolveDataDir())
+			syms, err := store.Symbols()
+			if err != nil {
+				return err
+			}
+
+			var ok, repaired, corrupt int
+			var toRefetch []string
+			for _, sym := range syms {
+				candles, err := store.Load(sym)
+				if err != nil {
+					corrupt++
+					fmt.Printf("  ✗ %-10s %v\n", sym, err)
+					if fix || refetch {
+						store.Remove(sym)
+					}
+					toRefetch = append(toRefetch, sym)
+					continue
+				}
+				sort.SliceStable(candles, func(i, j int) bool { return candles[i].Time.Before(candles[j].Time) })
+				issues := provider.ValidateCandles(candles)
+				if len(issues) == 0 {
+					ok++
+					continue
+				}
+				fmt.Printf("  ! %-10s %d bad bars (%s)\n", sym, len(issues), summarizeIssues(issues))
+				if fix {
+					clean, _ := provider.DropInvalid(candles)
+					if err := store.Replace(sym, clean); err != nil {
+						return err
+					}
+					repaired++
+				}
+			}
+
+			var badIntraday int
+			filepath.Walk(store.IntradayDir(), func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return nil
+				}
+				data, err := os.ReadFile(path)
+				var day model.IntradayData
+				if err == nil {
+					err = json.Unmarshal(data, &day)
+				}
+				if err != nil {
+					badIntraday++
+					fmt.Printf("  ✗ %s: %v\n", path, err)
+					if fix {
+						os.Remove(path)
+					}
+				}
+				return nil
+			})
+
+			fmt.Printf("\n%d ok, %d with bad bars (%d repaired), %d unreadable, %d bad intraday files\n",
+				ok, len(syms)-ok-corrupt, repaired, corrupt, badIntraday)
+
+			if refetch && len(toRefetch) > 0 {
+				cfg, err := config.Load(cfgFile)
+				if err != nil {
+					return fmt.Errorf("loading config: %w", err)
+				}
+				p := provider.NewFallbackProvider(createProviders(cfg)...)
+				fmt.Printf("Re-downloading %d symbols...\n", len(toRefetch))
+				var results []fetchResult
+				for _, sym := range toRefetch {
+					results = append(results, fetchSymbol(context.Background(), p, store, sym, int(years*252), true, 2))
+				}
+				printFetchSummary(results)
+			} else if len(toRefetch) > 0 && !fix {
+				fmt.Println("Run with --fix to clean up or --refetch to download unreadable symbols again.")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&fix, "fix", false, "drop bad bars and remove unreadable files")
+	cmd.Flags().BoolVar(&refetch, "refetch", false, "re-download symbols whose daily file is unreadable")
+	cmd.Flags().Float64Var(&years, "years", 5, "history to re-download with --refetch")
+	return cmd
+}
+
+// scanCache 종목별 일봉/분봉 캐시 현황
+func scanCache(store *provider.CandleStore) ([]cacheEntry, error) {
+	syms, err := store.Symbols()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]cacheEntry, 0, len(syms))
+	for _, sym := range syms {
+		e := cacheEntry{Symbol: sym}
+		if info, err := os.Stat(store.Path(sym)); err == nil {
+			e.DailyBytes = info.Size()
+		}
+		if candles, err := store.Load(sym); err != nil {
+			e.Err = err
+		} else if len(candles) > 0 {
+			e.Candles = len(candles)
+			e.First, e.Last = candles[0].Time, candles[len(candles)-1].Time
+		}
+		dir := filepath.Join(store.IntradayDir(), sym)
+		if files, err := os.ReadDir(dir); err == nil {
+			for _, f := range files {
+				if info, err := f.Info(); err == nil && !f.IsDir() {
+					e.IntradayDays++
+					e.IntradayBytes += info.Size()
+				}
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func summarizeIssues(issues map[int]string) string {
+	counts := make(map[string]int)
+	for _, reason := range issues {
+		counts[reason]++
+	}
+	parts := make([]string, 0, len(counts))
+	for reason, n := range counts {
+		parts = append(parts, fmt.Sprintf("%d %s", n, reason))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func cacheDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+// formatBytes 1536 → "1.5 KB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
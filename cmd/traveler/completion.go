@@ -0,0 +1,182 @@
+package main
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/backtest"
+	"traveler/internal/daemon"
+	"traveler/internal/strategy"
+	"traveler/internal/symbols"
+)
+
+// newCompletionCmd `traveler completion` 셸 자동완성 스크립트 (cobra 기본 명령 대신 설치 안내 포함)
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate the shell completion script",
+		Long: `Print a completion script for subcommands, flags and flag values
+(strategies, universes, markets, saved backtests, watchlists, report IDs).
+
+Load it in the current shell or install it once:
+
+  bash        source <(traveler completion bash)
+              traveler completion bash > /etc/bash_completion.d/traveler
+  zsh         traveler completion zsh > "${fpath[1]}/_traveler"   (needs compinit)
+  fish        traveler completion fish > ~/.config/fish/completions/traveler.fish
+  powershell  traveler completion powershell | Out-String | Invoke-Expression`,
+		Example: `  source <(traveler completion bash)
+  traveler completion zsh > ~/.zsh/completions/_traveler`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			default:
+				return root.GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+		},
+	}
+}
+
+// flagValues 플래그 이름 → 자동완성 후보 (같은 이름이면 서브커맨드 플래그에도 적용)
+var flagValues = map[string]func() []string{
+	"strategy":           strategyCompletions,
+	"market":             fixedCompletions("us", "kr", "crypto"),
+	"universe":           universeCompletions,
+	"format":             fixedCompletions("table", "json", "ndjson"),
+	"data-source":        fixedCompletions("network", "auto", "cache"),
+	"scenario":           func() []string { return append(backtest.ScenarioNames(), "all") },
+	"contribution-every": fixedCompletions("weekly", "monthly", "quarterly", "yearly"),
+	"stop-model":         fixedCompletions("atr", "swing", "ma", "default"),
+	"benchmark":          fixedCompletions("SPY", "QQQ", "069500", "none"),
+	"hedge-instrument":   fixedCompletions("SH", "SQQQ", "114800"),
+	"instrument":         fixedCompletions("SH", "SQQQ", "114800"),
+	"period":             fixedCompletions("week", "month"),
+	"sort":               fixedCompletions("size", "name", "stale"),
+	"bt-save":            savedBacktestNames,
+}
+
+// flagFileExts 파일 경로를 받는 플래그 → 확장자 필터
+var flagFileExts = map[string][]string{
+	"config":           {"yaml", "yml"},
+	"pattern-config":   {"json"},
+	"signals":          {"json", "csv"},
+	"universe-history": {"csv"},
+}
+
+// registerCompletions 모든 명령의 플래그 값과 인자 자동완성 등록
+func registerCompletions(cmd *cobra.Command) {
+	// persistent 플래그는 선언한 명령에서 한 번만 등록 (하위 명령은 상속)
+	local, persistent := cmd.LocalNonPersistentFlags(), cmd.PersistentFlags()
+	declared := func(name string) bool { return local.Lookup(name) != nil || persistent.Lookup(name) != nil }
+	for name, values := range flagValues {
+		if !declared(name) {
+			continue
+		}
+		values := values
+		cmd.RegisterFlagCompletionFunc(name, func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return values(), cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+	for name, exts := range flagFileExts {
+		if declared(name) {
+			cmd.MarkFlagFilename(name, exts...)
+		}
+	}
+	for _, name := range []string{"data-dir", "reports-dir"} {
+		if declared(name) {
+			cmd.MarkFlagDirname(name)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+func fixedCompletions(values ...string) func() []string {
+	return func() []string { return values }
+}
+
+// strategyCompletions 레지스트리 전략 + all
+func strategyCompletions() []string {
+	names := strategy.List()
+	sort.Strings(names)
+	return append(names, "all")
+}
+
+// universeCompletions 내장 유니버스 + watchlist:<이름>
+func universeCompletions() []string {
+	var out []string
+	for _, u := range symbols.AvailableUniverses() {
+		out = append(out, string(u.ID))
+	}
+	for _, name := range watchlistNames() {
+		out = append(out, "watchlist:"+name)
+	}
+	return out
+}
+
+func watchlistNames() []string {
+	w, err := symbols.NewWatchlists(resolveDataDir())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, wl := range w.List() {
+		names = append(names, wl.Name)
+	}
+	return names
+}
+
+// savedBacktestNames <data-dir>/backtests에 저장된 결과 이름
+func savedBacktestNames() []string {
+	runs, err := backtest.ListRuns(backtest.RunsDir(resolveDataDir()))
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(runs))
+	for _, r := range runs {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// runReportIDs 세션 리포트 ID (latest 포함)
+func runReportIDs() []string {
+	ids := []string{"latest"}
+	infos, err := daemon.ListRunReports(resolveDataDir(), "")
+	if err != nil {
+		return ids
+	}
+	for _, info := range infos {
+		ids = append(ids, info.ID)
+	}
+	return ids
+}
+
+// completeArgs 위치 인자 자동완성 (n개까지 values 후보, 이후는 없음)
+func completeArgs(n int, values func() []string) cobra.CompletionFunc {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if n > 0 && len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, v := range values() {
+			if strings.HasPrefix(v, toComplete) {
+				out = append(out, v)
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/schollz/progressbar/v3"
+	"github.com/spf13/cobra"
+
+	"traveler/internal/config"
+	"traveler/internal/provider"
+	"traveler/pkg/model"
+)
+
+// fetchResult 종목별 다운로드 결과
+type fetchResult struct {
+	Symbol   string
+	Status   string // fetched, cached, failed
+	Candles  int
+	Dropped  int
+	Intraday int
+	Err      error
+}
+
+// newFetchCmd `traveler fetch` 과거 데이터 일괄 다운로드 → 로컬 캐시
+func newFetchCmd() *cobra.Command {
+	var (
+		universeName string
+		symbolCSV    string
+		years        float64
+		intradayDays int
+		interval     int
+		retries      int
+		force        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "Bulk-download daily (and optional intraday) history into the local candle cache",
+		Long: `Download history once and reuse it from scans and backtests
+(--data-source auto|cache). Daily candles are merged into
+<data-dir>/candles/daily/<SYMBOL>.json; intraday bars go to
+<data-dir>/candles/intraday/<SYMBOL>/<date>_<interval>m.json.
+
+Each symbol is retried with backoff on failure, and bars that fail the
+integrity check (non-positive prices, high < low, open/close outside the
+range, duplicate dates) are dropped before saving.`,
+		Example: `  traveler fetch --universe sp500 --years 5
+  traveler fetch --symbols AAPL,MSFT --years 2 --intraday-days 5 --interval 5`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			var syms []string
+			switch {
+			case symbolCSV != "":
+				for _, s := range strings.Split(symbolCSV, ",") {
+					if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+						syms = append(syms, s)
+					}
+				}
+			case universeName != "":
+				var err error
+				if syms, err = resolveUniverse(universeName); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("specify --universe or --symbols")
+			}
+			if years <= 0 {
+				return fmt.Errorf("--years must be positive")
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			p := provider.NewFallbackProvider(createProviders(cfg)...)
+			store := provider.NewCandleStore(resolveDataDir())
+			days := int(years * 252)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigChan
+				fmt.Println("\nInterrupted. Already saved symbols stay cached.")
+				cancel()
+			}()
+
+			fmt.Printf("Fetching %d symbols: %d trading days (%.1f years)", len(syms), days, years)
+			if intradayDays > 0 {
+				fmt.Printf(" + %d days of %dm bars", intradayDays, interval)
+			}
+			fmt.Printf("\nCache: %s\n\n", store.Dir())
+
+			bar := progressbar.NewOptions(len(syms),
+				progressbar.OptionEnableColorCodes(true),
+				progressbar.OptionShowCount(),
+				progressbar.OptionShowIts(),
+				progressbar.OptionSetWidth(40),
+				progressbar.OptionSetDescription("Fetching"),
+				progressbar.OptionSetTheme(progressbar.Theme{
+					Saucer:        "[green]█[reset]",
+					SaucerHead:    "[green]█[reset]",
+					SaucerPadding: "░",
+					BarStart:      "[",
+					BarEnd:        "]",
+				}),
+			)
+
+			var results []fetchResult
+			for _, sym := range syms {
+				if ctx.Err() != nil {
+					break
+				}
+				bar.Describe(fmt.Sprintf("Fetching %-8s", sym))
+				res := fetchSymbol(ctx, p, store, sym, days, force, retries)
+				if res.Err == nil && intradayDays > 0 {
+					res.Intraday = fetchIntraday(ctx, p, store, sym, intradayDays, interval, retries)
+				}
+				results = append(results, res)
+				bar.Add(1)
+			}
+			bar.Finish()
+			fmt.Println()
+
+			printFetchSummary(results)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&universeName, "universe", "", "universe to download (sp500, nasdaq100, kospi, watchlist:<name>, ...)")
+	cmd.Flags().StringVar(&symbolCSV, "symbols", "", "comma-separated symbols (overrides --universe)")
+	cmd.Flags().Float64Var(&years, "years", 5, "years of daily history")
+	cmd.Flags().IntVar(&intradayDays, "intraday-days", 0, "also download this many recent days of intraday bars")
+	cmd.Flags().IntVar(&interval, "interval", 5, "intraday bar interval in minutes")
+	cmd.Flags().IntVar(&retries, "retries", 3, "retries per symbol on failure (exponential backoff)")
+	cmd.Flags().BoolVar(&force, "force", false, "re-download even if the cache is fresh and long enough")
+	return cmd
+}
+
+// fetchSymbol 일봉 다운로드 → 검증 → 병합 저장 → 재로드 확인
+func fetchSymbol(ctx context.Context, p provider.Provider, store *provider.CandleStore, sym string, days int, force bool, retries int) fetchResult {
+	res := fetchResult{Symbol: sym}
+
+	if !force {
+		if cached, err := store.Load(sym); err == nil && len(cached) >= days && time.Since(cached[len(cached)-1].Time) < 4*24*time.Hour {
+			res.Status, res.Candles = "cached", len(cached)
+			return res
+		}
+	}
+
+	var candles []model.Candle
+	err := withRetry(ctx, retries, func() error {
+		var err error
+		candles, err = p.GetDailyCandles(ctx, sym, days)
+		return err
+	})
+	if err == nil && len(candles) == 0 {
+		err = fmt.Errorf("no data")
+	}
+	if err != nil {
+		res.Status, res.Err = "failed", err
+		return res
+	}
+
+	candles, res.Dropped = provider.DropInvalid(candles)
+	candles, _ = provider.SplitForming(candles, time.Now()) // 장중 봉은 저장하지 않음
+	if len(candles) == 0 {
+		res.Status, res.Err = "failed", fmt.Errorf("no completed bars")
+		return res
+	}
+	merged, err := store.Merge(sym, candles)
+	if err != nil {
+		res.Status, res.Err = "failed", err
+		return res
+	}
+
+	// 무결성: 디스크에서 다시 읽어 개수 확인
+	reloaded, err := store.Load(sym)
+	if err != nil {
+		res.Status, res.Err = "failed", fmt.Errorf("verify after write: %w", err)
+		return res
+	}
+	if len(reloaded) != len(merged) {
+		res.Status, res.Err = "failed", fmt.Errorf("verify after write: %d bars on disk, want %d", len(reloaded), len(merged))
+		return res
+	}
+	res.Status, res.Candles = "fetched", len(reloaded)
+	return res
+}
+
+// fetchIntraday 최근 N일 분봉 저장 → 저장한 일수
+func fetchIntraday(ctx context.Context, p provider.Provider, store *provider.CandleStore, sym string, days, interval, retries int) int {
+	var data []model.IntradayData
+	if err := withRetry(ctx, retries, func() error {
+		var err error
+		data, err = p.GetMultiDayIntraday(ctx, sym, days, interval)
+		return err
+	}); err != nil {
+		return 0
+	}
+	saved := 0
+	for _, day := range data {
+		if err := store.SaveIntraday(sym, interval, day); err == nil && len(day.Candles) > 0 {
+			saved++
+		}
+	}
+	return saved
+}
+
+// withRetry 실패 시 1s, 2s, 4s... 백오프 재시도
+// 재시도 불가로 표시된 ProviderError(404, 상장폐지 등)는 바로 반환
+func withRetry(ctx context.Context, retries int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		var pe *provider.ProviderError
+		if errors.As(err, &pe) && !pe.Retryable {
+			return err
+		}
+		if attempt == retries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(1<<attempt) * time.Second):
+		}
+	}
+	return err
+}
+
+func printFetchSummary(results []fetchResult) {
+	var fetched, cached, failed, dropped, intraday int
+	for _, r := range results {
+		switch r.Status {
+		case "fetched":
+			fetched++
+		case "cached":
+			cached++
+		case "failed":
+			failed++
+		}
+		dropped += r.Dropped
+		intraday += r.Intraday
+	}
+
+	fmt.Printf("Fetched %d, already cached %d, failed %d", fetched, cached, failed)
+	if intraday > 0 {
+		fmt.Printf(", %d intraday days saved", intraday)
+	}
+	fmt.Println()
+	if dropped > 0 {
+		fmt.Printf("Dropped %d bars that failed integrity checks\n", dropped)
+	}
+	for _, r := range results {
+		if r.Status == "failed" {
+			fmt.Printf("  ✗ %-8s %v\n", r.Symbol, r.Err)
+		} else if r.Dropped > 0 {
+			fmt.Printf("  ! %-8s %d bad bars dropped\n", r.Symbol, r.Dropped)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/config"
+	"traveler/internal/money"
+	"traveler/internal/trader"
+)
+
+// newHedgeCmd `traveler hedge` 현재 롱 익스포저 기준 인버스 ETF 헤지 계산 (주문 없음)
+func newHedgeCmd() *cobra.Command {
+	var (
+		market     string
+		instrument string
+		ratio      float64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "hedge",
+		Short: "Size an inverse-ETF hedge for current long exposure",
+		Long: `Estimate each holding's beta against the hedge benchmark (SPY for SH, QQQ for SQQQ,
+KODEX 200 for KODEX 인버스) and size the inverse ETF that offsets ratio × beta-weighted exposure.
+
+Calculation only. The daemon applies it automatically when the regime turns bearish
+if started with --auto-hedge.`,
+		Example: `  traveler hedge
+  traveler hedge --instrument SQQQ --ratio 0.3
+  traveler hedge --market kr`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			b, p, err := connectKISMarket(cfg, market)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			positions, err := b.GetPositions(ctx)
+			if err != nil {
+				return fmt.Errorf("get positions: %w", err)
+			}
+
+			if instrument == "" {
+				instrument = trader.DefaultHedgeInstrument(market)
+			}
+			state := trader.LoadHedgeState(resolveDataDir(), market)
+			held := 0.0
+			if state.Instrument == strings.ToUpper(instrument) {
+				held = state.Quantity
+			}
+			sug, err := trader.NewHedgeCalculator(p).Suggest(ctx, positions, strings.ToUpper(instrument), ratio, held)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println()
+			fmt.Println(strings.Repeat("=", 60))
+			fmt.Printf(" HEDGE CALCULATOR (%s, %.0fx inverse, benchmark %s)\n",
+				sug.Instrument.Symbol, sug.Instrument.Leverage, sug.Instrument.Benchmark)
+			fmt.Println(strings.Repeat("=", 60))
+			syms := make([]string, 0, len(sug.Betas))
+			for sym := range sug.Betas {
+				syms = append(syms, sym)
+			}
+			sort.Strings(syms)
+			for _, sym := range syms {
+				fmt.Printf("  %-10s beta %.2f\n", sym, sug.Betas[sym])
+			}
+			fmt.Println(strings.Repeat("-", 60))
+			cur := money.ForMarket(market)
+			fmt.Printf("  Long exposure:       %s\n", money.Format(sug.LongExposure, cur))
+			fmt.Printf("  Beta-weighted:       %s\n", money.Format(sug.BetaExposure, cur))
+			fmt.Printf("  Hedge ratio:         %.0f%%\n", sug.Ratio*100)
+			fmt.Printf("  Target notional:     %s\n", money.Format(sug.TargetNotional, cur))
+			fmt.Printf("  %s @ %s:  target %.0f, held %.0f → %+.0f\n",
+				sug.Instrument.Symbol, money.Price(sug.Price, cur), sug.TargetQty, sug.CurrentQty, sug.DeltaQty)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr")
+	cmd.Flags().StringVar(&instrument, "instrument", "", "hedge ETF: SH, SQQQ (us) or 114800 (kr)")
+	cmd.Flags().Float64Var(&ratio, "ratio", trader.DefaultHedgeRatio, "fraction of beta-weighted exposure to hedge")
+	return cmd
+}
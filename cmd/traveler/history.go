@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/dailystate"
+	"traveler/internal/money"
+	"traveler/internal/trader"
+)
+
+// newHistoryCmd `traveler history` 매매 성과 조회
+func newHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Trading performance history",
+		Example: `  traveler history summary --period month --limit 6
+  traveler history r --market kr --strategy breakout
+  traveler history exits --strategy pullback --json`,
+	}
+	cmd.AddCommand(newHistorySummaryCmd())
+	cmd.AddCommand(newHistoryRCmd())
+	cmd.AddCommand(newHistoryExitsCmd())
+	return cmd
+}
+
+// newHistoryExitsCmd `traveler history exits` 청산 사유별/보유 기간별 실거래 성과
+func newHistoryExitsCmd() *cobra.Command {
+	var (
+		market   string
+		strategy string
+		jsonOut  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "exits",
+		Short: "Average P&L and win rate by exit reason and holding period",
+		Long: `Break closed trades in trade_history.json down by exit reason (stop_loss, target1,
+target2, time_stop, invalidation, ...) and by holding period, to guide per-strategy
+max hold day tuning. Use --strategy to compare against that strategy's current limit.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			h, err := trader.NewTradeHistory(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			exits := trader.AnalyzeExits(trader.ExitSamplesFromHistory(h.GetAll(market), strategy))
+
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(exits)
+			}
+			if exits == nil {
+				fmt.Printf("No closed %s trades found.\n", market)
+				return nil
+			}
+			cur := money.ForMarket(market)
+			label := strings.ToUpper(market)
+			if strategy != "" {
+				label += " " + strategy
+			}
+			fmt.Printf("\n%s EXITS (%d closed trades)\n", label, exits.Trades)
+			fmt.Println(strings.Repeat("=", 64))
+			fmt.Print(exits.Format(func(v float64) string { return money.Signed(v, cur) }))
+			if strategy != "" {
+				fmt.Printf("\n Current max hold for %s: %d trading days\n", strategy, trader.GetMaxHoldDays(strategy))
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "us", "market: us, kr or crypto")
+	cmd.Flags().StringVar(&strategy, "strategy", "", "only trades from this strategy")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "print JSON instead of tables")
+	return cmd
+}
+
+// newHistoryRCmd `traveler history r` 실거래 R 배수 분포
+func newHistoryRCmd() *cobra.Command {
+	var (
+		market   string
+		strategy string
+		jsonOut  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "r",
+		Short: "R-multiple distribution of closed trades (histogram)",
+		Long: `Histogram of realized R multiples from trade_history.json, so the shape of the
+distribution (fat left tail, clusters at -1R and the targets) is visible, not just averages.
+R is measured against the stop at entry; sells recorded before entry stops were journaled are skipped.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			h, err := trader.NewTradeHistory(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			var rs []float64
+			skipped := 0
+			for _, rec := range h.GetAll(market) {
+				if rec.Side != "sell" || (strategy != "" && rec.Strategy != strategy) {
+					continue
+				}
+				if r, ok := rec.RMultiple(); ok {
+					rs = append(rs, r)
+				} else {
+					skipped++
+				}
+			}
+			dist := trader.NewRDistribution(rs)
+
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(dist)
+			}
+			if dist == nil {
+				fmt.Printf("No %s sells with a recorded entry stop.\n", market)
+				return nil
+			}
+			label := strings.ToUpper(market)
+			if strategy != "" {
+				label += " " + strategy
+			}
+			fmt.Printf("\n%s R-MULTIPLE DISTRIBUTION\n", label)
+			fmt.Println(strings.Repeat("=", 60))
+			fmt.Print(dist.Format(30))
+			if skipped > 0 {
+				fmt.Printf(" (%d sells without an entry stop skipped)\n", skipped)
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "us", "market: us, kr or crypto")
+	cmd.Flags().StringVar(&strategy, "strategy", "", "only trades from this strategy")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "print JSON instead of a histogram")
+	return cmd
+}
+
+// newHistorySummaryCmd `traveler history summary` 주간/월간 집계
+func newHistorySummaryCmd() *cobra.Command {
+	var (
+		market  string
+		period  string
+		limit   int
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Weekly or monthly performance rollups (equity, best/worst trades, hit rate by strategy, fees)",
+		Long: `Aggregate daily tracker files (daily_<market>_*.json) and trade_history.json
+into weekly or monthly rollups. The daemon refreshes rollups_<market>_<period>.json
+on every shutdown; this command always recomputes from the source files.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			p, err := dailystate.NormalizePeriod(period)
+			if err != nil {
+				return err
+			}
+			dataDir := resolveDataDir()
+			states, err := dailystate.Load(dataDir, market)
+			if err != nil {
+				return err
+			}
+			var records []trader.TradeRecord
+			if h, err := trader.NewTradeHistory(dataDir); err == nil {
+				records = h.GetAll(market)
+			}
+
+			rollups := dailystate.BuildRollups(states, records, p, briefMarketLocation(market))
+			if limit > 0 && len(rollups) > limit {
+				rollups = rollups[len(rollups)-limit:]
+			}
+
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(rollups)
+			}
+			printRollups(rollups, market, p)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "us", "market: us, kr or crypto")
+	cmd.Flags().StringVar(&period, "period", "week", "rollup period: week or month")
+	cmd.Flags().IntVar(&limit, "limit", 8, "show the most recent N periods (0 = all)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "print JSON instead of a table")
+	return cmd
+}
+
+func printRollups(rollups []dailystate.PeriodRollup, market, period string) {
+	if len(rollups) == 0 {
+		fmt.Printf("No %s history found for %s.\n", period, market)
+		return
+	}
+	cur := money.ForMarket(market)
+
+	fmt.Printf("\n%s %sLY PERFORMANCE\n", strings.ToUpper(market), strings.ToUpper(period))
+	fmt.Println(strings.Repeat("=", 96))
+	fmt.Printf("%-9s %-23s %4s %14s %8s %12s %6s %7s %12s\n",
+		"Period", "Range", "Days", "Net P&L", "Return", "Fees", "Trades", "Hit%", "Cash Flow")
+	fmt.Println(strings.Repeat("-", 96))
+	for _, r := range rollups {
+		fmt.Printf("%-9s %-23s %4d %14s %7.2f%% %12s %6d %6.1f%% %12s\n",
+			r.Period, r.Start+" ~ "+r.End, r.TradingDays,
+			money.Signed(r.NetPnL, cur), r.NetPnLPct, money.Format(r.Fees, cur),
+			r.Trades, r.HitRate, money.Signed(r.NetCashFlow, cur))
+	}
+
+	// 최근 기간 상세
+	last := rollups[len(rollups)-1]
+	fmt.Printf("\nLatest %s (%s)\n", period, last.Period)
+	if last.Best != nil {
+		fmt.Printf("  Best:  %-8s %s (%s)\n", last.Best.Symbol, money.Signed(last.Best.PnL, cur), last.Best.Reason)
+	}
+	if last.Worst != nil {
+		fmt.Printf("  Worst: %-8s %s (%s)\n", last.Worst.Symbol, money.Signed(last.Worst.PnL, cur), last.Worst.Reason)
+	}
+	if len(last.ByStrategy) > 0 {
+		names := make([]string, 0, len(last.ByStrategy))
+		for name := range last.ByStrategy {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("  By strategy:")
+		for _, name := range names {
+			s := last.ByStrategy[name]
+			fmt.Printf("    %-16s %3d trades  %5.1f%% hit  %s\n", name, s.Trades, s.HitRate, money.Signed(s.NetPnL, cur))
+		}
+	}
+	if len(last.Equity) > 0 {
+		fmt.Println("  Equity:")
+		for _, e := range last.Equity {
+			fmt.Printf("    %s  %14s  %s\n", e.Date, money.Format(e.Equity, cur), money.Signed(e.PnL, cur))
+		}
+	}
+	fmt.Println()
+}
@@ -25,11 +25,15 @@ import (
 	"traveler/internal/broker"
 	binanceBroker "traveler/internal/broker/binance"
 	"traveler/internal/dca"
+	"traveler/internal/broker/ibkr"
 	"traveler/internal/broker/kis"
 	"traveler/internal/broker/sim"
 	"traveler/internal/broker/upbit"
 	"traveler/internal/config"
 	"traveler/internal/daemon"
+	"traveler/internal/fees"
+	"traveler/internal/money"
+	"traveler/internal/notify"
 	"traveler/internal/provider"
 	"traveler/internal/scanner"
 	"traveler/internal/strategy"
@@ -57,6 +61,7 @@ var (
 	outputFile     string
 	webMode        bool
 	webPort        int
+	webReadOnly    bool
 
 	// Auto-trade flags
 	autoTrade    bool
@@ -76,6 +81,7 @@ var (
 	forceScan       bool    // 강제 스캔 (이미 매매했어도)
 	simMode         bool    // 모의투자 모드
 	simCapital      float64 // 모의투자 가상 자본
+	rehearsalMode   bool    // 데몬 리허설 (실계좌 잔고로 모의 브로커 주문)
 	dcaMode         bool    // DCA 장기 투자 모드
 	dcaAmount       float64 // DCA 1회 매수 금액 (KRW)
 	scalpMode       bool    // 스캘핑 모드
@@ -88,6 +94,36 @@ var (
 	binanceArbCap   float64 // 차익거래 최대 자본 (USDT)
 	btcFuturesMode  bool    // BTC Futures 펀딩레이트 롱 전략
 	btcFuturesAmt   float64 // BTC Futures 1회 매매 금액 (USDT)
+	kellyRiskCap    bool    // 전략별 half-Kelly 리스크 캡
+	liquiditySlip   bool    // 백테스트 종목별 슬리피지 (1분봉 유동성 추정)
+	btDividends     bool    // 백테스트 배당 반영 (배당락일 보유 시 배당금 수령)
+	btSave          string  // 백테스트 결과 저장 이름/경로 (backtest compare용)
+	btContribution  float64 // 포트폴리오 백테스트 정기 입금(+)/출금(-) 금액
+	btContribEvery  string  // 정기 입출금 주기 (weekly, monthly, quarterly, yearly)
+	btMaxNewPerDay  int     // 포트폴리오 백테스트 하루 최대 신규 진입 (0 = 제한 없음)
+	btAuditLook     bool    // 백테스트 룩어헤드 감사 (위반 시 종목/날짜와 함께 실패)
+	btUniverseAsOf  string  // 시점별 구성종목 파일 (이름 또는 경로) — 생존 편향 완화
+	btBenchmark     string  // 포트폴리오 백테스트 상대 낙폭 벤치마크 (빈 값 = 마켓 기본, none = 끔)
+	btScenario      string  // 스트레스 구간 프리셋 (쉼표 구분 또는 all) — 구간별 결과 비교
+	mcSizing        bool    // 리스크/최대 포지션 조합별 Monte Carlo
+	btCostSens      bool    // 슬리피지/수수료 0.5x~3x 민감도
+	btStopModel     string  // 백테스트 손절 모델 (atr:2, swing:10, ma:20:1 — 비우면 config trader.stop_models의 pullback)
+	btRiskPct       float64 // 백테스트 거래당 리스크 (%)
+	btStopPct       float64 // 백테스트 고정 손절 (%)
+	btTargetR       float64 // 백테스트 목표가 R 배수
+	btMaxHold       int     // 백테스트 최대 보유 거래일
+	btMaxPositions  int     // 포트폴리오 백테스트 동시 보유 한도
+	btFromStr       string  // 백테스트 구간 시작일 (YYYY-MM-DD, --backtest-days 대신)
+	btToStr         string  // 백테스트 구간 종료일 (비우면 최신)
+	btFrom, btTo    time.Time
+	btDataSource    string  // 백테스트 일봉 소스: network, auto, cache(오프라인)
+	autoHedge       bool    // 약세장 인버스 ETF 헤지 자동 주문
+	hedgeRatio      float64 // 헤지 비율 (베타 가중 익스포저 대비)
+	hedgeInstrument string  // 헤지 수단 (SH, SQQQ, 114800)
+	minProb         float64 // 스캔 출력 최소 승률 (%, 0 = 제한 없음)
+	minStrength     float64 // 스캔 출력 최소 강도 (0-100, 0 = 제한 없음)
+	patternConfig   string  // 이번 실행용 패턴/전략 파라미터 JSON 파일
+	quiet           bool    // 스크립트 모드: 진행 표시줄/배너 생략, 결과별 종료 코드
 )
 
 func main() {
@@ -100,32 +136,45 @@ Strategies:
   pullback        - Buy uptrending stocks pulling back to MA20 (trend-following)
   mean-reversion  - Buy oversold stocks bouncing off Bollinger lower band (counter-trend)
   breakout        - Buy 20-day high breakouts with volume confirmation (momentum)
-  all             - Run all strategies and keep best signal per stock
-
-Examples:
-  traveler --strategy pullback --symbols AAPL,MSFT,GOOGL
-  traveler --strategy all --universe nasdaq100 --capital 5000`,
+  all             - Run all strategies and keep best signal per stock`,
+		Example: `  traveler --strategy pullback --symbols AAPL,MSFT,GOOGL
+  traveler --strategy all --universe nasdaq100 --capital 5000
+  traveler --strategy all --universe sp500 --min-prob 55 --format json
+  traveler --backtest --strategy breakout --universe sp500 --bt-save breakout-base
+  traveler --backtest --universe sp500 --bt-risk 0.5 --bt-target-r 3 --bt-max-positions 8
+  traveler --backtest --universe sp500 --from 2022-01-03 --to 2022-12-30
+  traveler --daemon --market kr --trading-capital 5000000
+  traveler --web --port 8080 --web-readonly
+  traveler completion bash > /etc/bash_completion.d/traveler`,
 		RunE: run,
 	}
 
 	// Flags
-	rootCmd.Flags().StringVar(&cfgFile, "config", "config.yaml", "config file path")
-	rootCmd.Flags().StringVar(&strategyName, "strategy", "pullback", "strategy: pullback, mean-reversion, breakout, all")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "config.yaml", "config file path")
+	rootCmd.Flags().StringVar(&strategyName, "strategy", "pullback", "strategy: pullback, mean-reversion, breakout, bb-squeeze, sector-rotation, pairs, all")
 	rootCmd.Flags().IntVar(&days, "days", 1, "minimum consecutive days with pattern (morning-dip)")
+	rootCmd.Flags().StringVar(&patternConfig, "pattern-config", "", "JSON file with morning-dip pattern and strategy parameters for this run (overrides config.yaml)")
 	rootCmd.Flags().IntVar(&workers, "workers", 10, "number of parallel workers")
 	rootCmd.Flags().Float64Var(&dropPct, "drop", -1.0, "minimum morning drop percentage (negative value)")
 	rootCmd.Flags().Float64Var(&risePct, "rise", 0.5, "minimum close rise percentage")
 	rootCmd.Flags().Float64Var(&reboundPct, "rebound", 2.0, "minimum rebound from morning low percentage")
 	rootCmd.Flags().StringVar(&symbolList, "symbols", "", "comma-separated list of symbols to scan (default: all US stocks)")
-	rootCmd.Flags().StringVar(&format, "format", "table", "output format: table, json")
+	rootCmd.Flags().StringVar(&format, "format", "table", "output format: table, json, ndjson (stream)")
 	rootCmd.Flags().BoolVar(&verbose, "verbose", false, "show detailed output")
-	rootCmd.Flags().Float64Var(&accountBalance, "capital", 100000, "account balance in USD for position sizing")
+	rootCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "scripting mode: no progress bars or banners; exit code 0 = signals found, 2 = no signals, 3 = data provider failure")
+	rootCmd.Flags().Float64Var(&accountBalance, "capital", 100000, "account balance for position sizing (USD, KRW with --market kr/crypto)")
 	rootCmd.Flags().BoolVar(&runBacktest, "backtest", false, "run backtest on historical data")
 	rootCmd.Flags().IntVar(&backtestDays, "backtest-days", 365, "number of days for backtest")
-	rootCmd.Flags().StringVar(&universe, "universe", "", "stock universe: test, dow30, nasdaq100, sp500, midcap, russell")
-	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "save report to file (auto-generates filename if empty)")
+	rootCmd.Flags().StringVar(&universe, "universe", "", "stock universe: test, dow30, nasdaq100, sp500, midcap, russell, us-sectors, or watchlist:<name>")
+	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "save the text report to this file (JSON report goes to --reports-dir)")
+	rootCmd.Flags().BoolVar(&saveReports, "save-report", false, "save TXT/JSON scan reports to --reports-dir when there are signals")
+	rootCmd.Flags().BoolVar(&noReport, "no-report", false, "never save scan reports (overrides config save_reports)")
+	rootCmd.Flags().StringVar(&reportsDir, "reports-dir", "", "directory for scan reports, in dated subfolders (default: <data-dir>/reports)")
+	rootCmd.Flags().Float64Var(&minProb, "min-prob", 0, "only keep signals with at least this win probability (%, 0 = all)")
+	rootCmd.Flags().Float64Var(&minStrength, "min-strength", 0, "only keep signals with at least this strength (0-100, 0 = all)")
 	rootCmd.Flags().BoolVar(&webMode, "web", false, "start web UI server")
 	rootCmd.Flags().IntVar(&webPort, "port", 8080, "web server port")
+	rootCmd.Flags().BoolVar(&webReadOnly, "web-readonly", false, "web UI read-only: scans and viewing only, no orders or position changes (web.read_only)")
 
 	// Auto-trade flags
 	rootCmd.Flags().BoolVar(&autoTrade, "auto-trade", false, "enable auto-trading via KIS API")
@@ -137,12 +186,13 @@ Examples:
 	rootCmd.Flags().Float64Var(&dailyTargetPct, "daily-target", 1.0, "daily target profit percentage")
 	rootCmd.Flags().Float64Var(&dailyLossLimit, "daily-loss-limit", -2.0, "daily loss limit percentage")
 	rootCmd.Flags().BoolVar(&sleepOnExit, "sleep-on-exit", true, "sleep PC when daemon exits")
-	rootCmd.Flags().StringVar(&dataDir, "data-dir", "", "data directory for plans, logs, reports (default: ~/.traveler)")
+	rootCmd.PersistentFlags().StringVar(&dataDir, "data-dir", "", "data directory for plans, logs, reports (default: ~/.traveler)")
 	rootCmd.Flags().StringVar(&marketFlag, "market", "us", "market: us, kr, crypto")
 	rootCmd.Flags().Float64Var(&tradingCapital, "trading-capital", 0, "earmarked trading capital for daemon (0=use full balance)")
 	rootCmd.Flags().BoolVar(&forceScan, "force-scan", false, "force scan even if already traded today")
 	rootCmd.Flags().BoolVar(&simMode, "sim", false, "simulation mode: paper trading with virtual capital")
 	rootCmd.Flags().Float64Var(&simCapital, "sim-capital", 0, "virtual capital for sim mode (default: US $100000, KR ₩50000000)")
+	rootCmd.Flags().BoolVar(&rehearsalMode, "rehearsal", false, "daemon rehearsal: full scan/size/order/monitor loop against a paper broker seeded from the live balance")
 	rootCmd.Flags().BoolVar(&dcaMode, "dca", false, "DCA long-term investment mode (crypto)")
 	rootCmd.Flags().Float64Var(&dcaAmount, "dca-amount", 10000, "DCA base amount per cycle in KRW")
 	rootCmd.Flags().BoolVar(&scalpMode, "scalp", false, "crypto scalping mode (RSI mean-reversion)")
@@ -155,22 +205,102 @@ Examples:
 	rootCmd.Flags().Float64Var(&binanceArbCap, "binance-arb-cap", 150, "Max USDT capital for funding rate arb")
 	rootCmd.Flags().BoolVar(&btcFuturesMode, "btc-futures", false, "BTC Futures funding-rate long strategy")
 	rootCmd.Flags().Float64Var(&btcFuturesAmt, "btc-futures-amount", 80, "BTC Futures order amount in USDT")
-
-	if err := rootCmd.Execute(); err != nil {
+	rootCmd.Flags().BoolVar(&liquiditySlip, "liquidity-slippage", false, "estimate per-symbol slippage from recent 1-minute bars (backtests, and daemon sizing of scan signals)")
+	rootCmd.Flags().BoolVar(&btDividends, "dividends", false, "backtest: credit dividends for positions held through ex-dividend dates (Yahoo history)")
+	rootCmd.Flags().Float64Var(&btContribution, "contribution", 0, "portfolio backtest: amount added each period (negative = withdrawal); reports money-weighted IRR")
+	rootCmd.Flags().StringVar(&btContribEvery, "contribution-every", "monthly", "portfolio backtest: contribution frequency: weekly, monthly, quarterly, yearly")
+	rootCmd.Flags().IntVar(&btMaxNewPerDay, "max-new-per-day", 0, "portfolio backtest: max new positions opened per day, highest-scoring signals first (0 = no limit)")
+	rootCmd.Flags().BoolVar(&btAuditLook, "audit-lookahead", false, "backtest: verify every signal uses only data up to its decision day and fills happen after it; fail on the first violation")
+	rootCmd.Flags().StringVar(&btUniverseAsOf, "universe-history", "", "portfolio backtest: point-in-time index membership CSV (symbol,start,end) — name under <data-dir>/universes or file path; only members on each date can be entered")
+	rootCmd.Flags().StringVar(&btBenchmark, "benchmark", "", "portfolio backtest: benchmark for relative drawdown (default SPY for US, 069500 for KR; none to disable)")
+	rootCmd.Flags().StringVar(&btScenario, "scenario", "", "backtest: run through named stress periods and compare side by side: "+strings.Join(backtest.ScenarioNames(), ", ")+", or all")
+	rootCmd.Flags().StringVar(&btSave, "bt-save", "", "backtest: save result as JSON (name under <data-dir>/backtests, or file path) for 'traveler backtest compare'")
+	rootCmd.Flags().StringVar(&btDataSource, "data-source", "network", "backtest: daily candle source: network (live APIs), auto (local cache, fetch missing/stale), cache (offline, no network)")
+	rootCmd.Flags().BoolVar(&mcSizing, "mc-sizing", false, "backtest: Monte Carlo stress test of risk per trade (0.5-3%) x max positions")
+	rootCmd.Flags().StringVar(&btStopModel, "stop-model", "", "backtest: stop model: atr[:mult], swing[:days[:buffer%]], ma[:period[:buffer%]], default (fixed --bt-stop); default from config trader.stop_models.pullback")
+	rootCmd.Flags().Float64Var(&btRiskPct, "bt-risk", 1, "backtest: risk per trade in % of equity (config backtest.risk_pct)")
+	rootCmd.Flags().Float64Var(&btStopPct, "bt-stop", 2, "backtest: fixed stop loss in % below entry, also the fallback for --stop-model (config backtest.stop_pct)")
+	rootCmd.Flags().Float64Var(&btTargetR, "bt-target-r", 2, "backtest: profit target as a multiple of initial risk (config backtest.target_r)")
+	rootCmd.Flags().IntVar(&btMaxHold, "bt-maxhold", 5, "backtest: max holding period in trading days (config backtest.max_hold_days)")
+	rootCmd.Flags().IntVar(&btMaxPositions, "bt-max-positions", 5, "portfolio backtest: max simultaneous positions (config backtest.max_positions)")
+	rootCmd.Flags().StringVar(&btFromStr, "from", "", "backtest: start date YYYY-MM-DD; replaces --backtest-days so results don't depend on the run date")
+	rootCmd.Flags().StringVar(&btToStr, "to", "", "backtest: end date YYYY-MM-DD (requires --from; default latest data)")
+	rootCmd.Flags().BoolVar(&btCostSens, "cost-sensitivity", false, "backtest: re-price the trade list at 0.5x-3x slippage and commission; flags strategies whose edge disappears")
+	rootCmd.Flags().BoolVar(&kellyRiskCap, "kelly-risk-cap", false, "cap per-strategy risk at half-Kelly from trade journal (recalculated weekly)")
+	rootCmd.Flags().BoolVar(&autoHedge, "auto-hedge", false, "daemon: buy an inverse ETF hedge when the market regime turns bearish (otherwise only logged)")
+	rootCmd.Flags().Float64Var(&hedgeRatio, "hedge-ratio", 0, "fraction of beta-weighted long exposure to hedge (default 0.5)")
+	rootCmd.Flags().StringVar(&hedgeInstrument, "hedge-instrument", "", "hedge ETF: SH, SQQQ (us) or 114800 (kr); default by market")
+
+	rootCmd.AddCommand(newSymbolsCmd())
+	rootCmd.AddCommand(newWatchlistCmd())
+	rootCmd.AddCommand(newRebalanceCmd())
+	rootCmd.AddCommand(newHedgeCmd())
+	rootCmd.AddCommand(newBriefCmd())
+	rootCmd.AddCommand(newBacktestCmd())
+	rootCmd.AddCommand(newHistoryCmd())
+	rootCmd.AddCommand(newFetchCmd())
+	rootCmd.AddCommand(newCacheCmd())
+	rootCmd.AddCommand(newTradeCmd())
+	rootCmd.AddCommand(newAlertCmd())
+	rootCmd.AddCommand(newPositionCmd())
+	rootCmd.AddCommand(newReconcileCmd())
+	rootCmd.AddCommand(newTuneCmd())
+	rootCmd.AddCommand(newReportsCmd())
+	rootCmd.AddCommand(newCompletionCmd())
+	registerCompletions(rootCmd)
+
+	err := rootCmd.Execute()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	}
+	if code := exitCode(err); code != 0 {
+		os.Exit(code)
 	}
 }
 
 func run(cmd *cobra.Command, args []string) error {
+	if quiet {
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true // 에러는 main에서 stderr 한 줄로
+	}
 	// Load configuration
 	cfg, err := config.Load(cfgFile)
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	displayCurrency = money.ForMarket(marketFlag)
+	if reportsDir == "" {
+		reportsDir = cfg.ReportsDir
+	}
+	if noReport && (outputFile != "" || saveReports) {
+		return fmt.Errorf("--no-report cannot be combined with --output or --save-report")
+	}
+	if cfg.SaveReports && !cmd.Flags().Changed("save-report") {
+		saveReports = true
+	}
+	reportsRetentionDays = cfg.ReportsRetentionDays
+	if runBacktest {
+		if err := resolveBacktestParams(cmd, cfg.Backtest); err != nil {
+			return err
+		}
+	}
+	if minProb < 0 || minProb > 100 || minStrength < 0 || minStrength > 100 {
+		return fmt.Errorf("--min-prob and --min-strength must be between 0 and 100")
+	}
+	switch format {
+	case "table", "json", "ndjson":
+	default:
+		return fmt.Errorf("--format must be table, json or ndjson (got %q)", format)
+	}
+
+	// 이번 실행용 파라미터 파일 (config.yaml 위, 명시한 CLI 플래그 아래)
+	if patternConfig != "" {
+		if err := applyPatternConfig(patternConfig, cfg); err != nil {
+			return fmt.Errorf("--pattern-config: %w", err)
+		}
+	}
 
 	// Override config with CLI flags
-	if days > 0 {
+	if days > 0 && (patternConfig == "" || cmd.Flags().Changed("days")) {
 		cfg.Pattern.ConsecutiveDays = days
 	}
 	if workers > 0 {
@@ -186,15 +316,49 @@ func run(cmd *cobra.Command, args []string) error {
 		cfg.Pattern.ReboundThreshold = reboundPct
 	}
 
+	// 전략별 손절 모델 (스캔 가이드, 모니터 플랜, 백테스트가 공유)
+	if err := strategy.SetStopModels(cfg.Trader.StopModels); err != nil {
+		return fmt.Errorf("config trader.stop_models: %w", err)
+	}
+	// 시그널 필터 체인 (CLI 스캔과 데몬이 공유, 웹은 config로 직접 만든다)
+	if scanFilters, err = trader.NewSignalFilterChain(signalFilterSpecs(cfg)); err != nil {
+		return fmt.Errorf("config trader.signal_filters: %w", err)
+	}
+	// 사이징 전 후보 선택 (CLI 스캔, 웹 스캔, 데몬이 공유)
+	if err := trader.SetTopNPolicy(trader.TopNPolicy{Count: cfg.Trader.TopN.Count, RankBy: cfg.Trader.TopN.RankBy}); err != nil {
+		return fmt.Errorf("config trader.top_n: %w", err)
+	}
+	// 3분할 익절은 잘못된 설정이면 스캔/데몬 시작 전에 중단 (값은 trancheExit로 각자 받는다)
+	if _, err := trancheExit(cfg); err != nil {
+		return err
+	}
+
+	// 섹터 로테이션 (레지스트리 전략, 모니터 리밸런싱, 데몬이 공유)
+	sr := cfg.Trader.SectorRotation
+	if err := strategy.SetSectorRotationConfig(strategy.SectorRotationConfig{
+		Sectors: sr.Sectors, TopN: sr.TopN, ShortLookback: sr.ShortLookback, LongLookback: sr.LongLookback,
+		ShortWeight: sr.ShortWeight, RequirePositive: !sr.AllowNegative, StopPct: sr.StopPct,
+	}); err != nil {
+		return fmt.Errorf("config trader.sector_rotation: %w", err)
+	}
+
+	// 멀티 전략 스캔의 전략별 유니버스 (CLI all/adaptive, 웹, 데몬 메타전략)
+	if err := strategy.SetStrategyUniverses(cfg.Scanner.StrategyUniverses); err != nil {
+		return fmt.Errorf("config scanner.strategy_universes: %w", err)
+	}
+	if err := strategy.SetEnabledStrategies(cfg.Scanner.EnabledStrategies); err != nil {
+		return fmt.Errorf("config scanner.enabled_strategies: %w", err)
+	}
+
 	// Create providers with fallback
 	providers := createProviders(cfg)
 	if len(providers) == 0 {
-		return fmt.Errorf("no API providers available. Set FINNHUB_API_KEY or ALPHAVANTAGE_API_KEY environment variable")
+		return providerFailure(fmt.Errorf("no API providers available. Set FINNHUB_API_KEY or ALPHAVANTAGE_API_KEY environment variable"))
 	}
 
 	fallbackProvider := provider.NewFallbackProvider(providers...)
 	if !fallbackProvider.IsAvailable() {
-		return fmt.Errorf("no available data providers")
+		return providerFailure(fmt.Errorf("no available data providers"))
 	}
 
 	if verbose {
@@ -253,21 +417,21 @@ func run(cmd *cobra.Command, args []string) error {
 		return runMonitorMode(cfg)
 	}
 
+	// 스캔 진행 로그 (적응형 스캐너, 심볼 필터)도 --quiet이면 버린다. 에러는 반환값으로 나간다.
+	if quiet {
+		log.SetOutput(io.Discard)
+	}
+
 	// Setup context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Auto-trade mode: fetch account balance before scanning
-	if autoTrade && cfg.KIS.AppKey != "" {
-		creds := kis.Credentials{
-			AppKey:    cfg.KIS.AppKey,
-			AppSecret: cfg.KIS.AppSecret,
-			AccountNo: cfg.KIS.AccountNo,
-		}
-		kisBroker := kis.NewClient(creds)
-		if kisBroker.IsReady() {
-			if balance, err := kisBroker.GetBalance(ctx); err == nil && balance.TotalEquity > 0 {
+	if autoTrade && marketFlag != "crypto" && usBrokerConfigured(cfg) {
+		usBroker := newUSBroker(cfg)
+		if usBroker.IsReady() {
+			if balance, err := usBroker.GetBalance(ctx); err == nil && balance.TotalEquity > 0 {
 				accountBalance = balance.TotalEquity
-				fmt.Printf("KIS Account Balance: %s\n", formatUSD(accountBalance))
+				infof("%s Account Balance: %s\n", strings.ToUpper(usBroker.Name()), formatMoney(accountBalance))
 			}
 		}
 	}
@@ -278,7 +442,7 @@ func run(cmd *cobra.Command, args []string) error {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigChan
-		fmt.Println("\nInterrupted. Stopping scan...")
+		infoln("\nInterrupted. Stopping scan...")
 		cancel()
 	}()
 
@@ -291,28 +455,48 @@ func run(cmd *cobra.Command, args []string) error {
 		syms := strings.Split(symbolList, ",")
 		stocks, err = loader.LoadSymbols(ctx, syms)
 		if err != nil {
-			return fmt.Errorf("loading symbols: %w", err)
+			return providerFailure(fmt.Errorf("loading symbols: %w", err))
 		}
 	} else if universe != "" {
 		// Use predefined universe
-		universeSymbols := symbols.GetUniverse(symbols.Universe(universe))
-		if universeSymbols == nil {
-			return fmt.Errorf("unknown universe: %s (use: test, dow30, nasdaq100, sp500, midcap, russell)", universe)
+		universeSymbols, err := resolveUniverse(universe)
+		if err != nil {
+			return err
 		}
-		fmt.Printf("Loading %s universe (%d stocks)...\n", universe, len(universeSymbols))
+		infof("Loading %s universe (%d stocks)...\n", universe, len(universeSymbols))
 		stocks, err = loader.LoadSymbols(ctx, universeSymbols)
 		if err != nil {
-			return fmt.Errorf("loading universe symbols: %w", err)
+			return providerFailure(fmt.Errorf("loading universe symbols: %w", err))
+		}
+	} else if marketFlag == "crypto" {
+		// 크립토: Upbit 거래량 상위 30 코인
+		universeSymbols := symbols.GetUniverse(symbols.UniverseCryptoTop30)
+		infof("Loading %s universe (%d coins)...\n", symbols.UniverseCryptoTop30, len(universeSymbols))
+		stocks, err = loader.LoadSymbols(ctx, universeSymbols)
+		if err != nil {
+			return providerFailure(fmt.Errorf("loading crypto symbols: %w", err))
 		}
 	} else {
 		// Load all US stocks
-		fmt.Println("Loading US stock list...")
+		infoln("Loading US stock list...")
 		stocks, err = loader.LoadUSStocks(ctx)
 		if err != nil {
-			return fmt.Errorf("loading US stocks: %w", err)
+			return providerFailure(fmt.Errorf("loading US stocks: %w", err))
 		}
 	}
 
+	// 반복 조회 실패로 자동 제외된 종목은 건너뜀 (--symbols로 직접 지정한 경우 제외)
+	if symbolList == "" {
+		stocks = skipPrunedStocks(stocks)
+	}
+	setDisplayCurrency(stocks)
+	setScanMarket(stocks)
+	candleCacheMinutes := cfg.Scanner.CandleCacheMinutes
+	if runBacktest {
+		candleCacheMinutes = 0 // 백테스트는 --data-source가 캐시 사용을 정한다
+	}
+	fallbackProvider = scanProvider(fallbackProvider, candleCacheMinutes)
+
 	// Adaptive mode: auto-select universe based on balance
 	if adaptiveMode {
 		return runAdaptiveScan(ctx, fallbackProvider, cfg, loader)
@@ -326,7 +510,7 @@ func run(cmd *cobra.Command, args []string) error {
 	switch strategyName {
 	case "all":
 		return runAllStrategies(ctx, stocks, fallbackProvider, cfg)
-	case "pullback", "mean-reversion", "breakout":
+	case "pullback", "mean-reversion", "breakout", "bb-squeeze", "sector-rotation", "pairs":
 		return runSingleStrategy(ctx, strategyName, stocks, fallbackProvider, cfg)
 	default:
 		return runSingleStrategy(ctx, "pullback", stocks, fallbackProvider, cfg)
@@ -461,11 +645,7 @@ func runDaemonMode(cfg *config.Config, p *provider.FallbackProvider) error {
 		daemonProvider = simProvider
 		resolvedDir = simDataDir // plans, history, logs 모두 sim 디렉토리로
 		fmt.Printf(" Mode:            SIMULATION (paper trading)\n")
-		if marketFlag == "kr" {
-			fmt.Printf(" Virtual Capital: ₩%.0f\n", simCapital)
-		} else {
-			fmt.Printf(" Virtual Capital: $%.0f\n", simCapital)
-		}
+		fmt.Printf(" Virtual Capital: %s\n", money.Format(simCapital, money.ForMarket(marketFlag)))
 	} else if isCrypto {
 		// 크립토 시장 모드
 		loadEnvFile()
@@ -489,20 +669,42 @@ func runDaemonMode(cfg *config.Config, p *provider.FallbackProvider) error {
 		daemonProvider = provider.NewKISProvider(krCreds)
 	} else {
 		// 해외 시장 모드
-		if cfg.KIS.AppKey == "" {
-			return fmt.Errorf("KIS API credentials required for daemon mode")
-		}
-		creds := kis.Credentials{
-			AppKey:    cfg.KIS.AppKey,
-			AppSecret: cfg.KIS.AppSecret,
-			AccountNo: cfg.KIS.AccountNo,
+		if !usBrokerConfigured(cfg) {
+			return fmt.Errorf("KIS API credentials (or ibkr.enabled) required for daemon mode")
 		}
-		daemonBroker = kis.NewClient(creds)
+		daemonBroker = newUSBroker(cfg)
 	}
 
 	if !daemonBroker.IsReady() {
 		return fmt.Errorf("broker not ready")
 	}
+
+	if rehearsalMode {
+		// 리허설: 실계좌는 잔고 조회만, 주문은 모의 브로커 (데이터 디렉토리 분리 → 실전 plans/history 보존)
+		if simMode {
+			return fmt.Errorf("--rehearsal and --sim are exclusive (--sim already paper-trades with virtual capital)")
+		}
+		rehearsalCapital := simCapital
+		if rehearsalCapital <= 0 {
+			rehearsalCapital = tradingCapital
+		}
+		if rehearsalCapital <= 0 {
+			bal, err := daemonBroker.GetBalance(context.Background())
+			if err != nil {
+				return fmt.Errorf("rehearsal: read live balance: %w", err)
+			}
+			rehearsalCapital = bal.TotalEquity
+		}
+		rehearsalDir := filepath.Join(resolvedDir, "rehearsal_"+marketFlag)
+		if err := os.MkdirAll(rehearsalDir, 0755); err != nil {
+			return fmt.Errorf("create rehearsal data dir: %w", err)
+		}
+		liveName := daemonBroker.Name()
+		daemonBroker = sim.NewSimBroker(marketFlag, rehearsalCapital, daemonProvider, rehearsalDir)
+		resolvedDir = rehearsalDir
+		fmt.Printf(" Mode:            REHEARSAL (paper broker, live account %s is not traded)\n", liveName)
+		fmt.Printf(" Paper Capital:   %s\n", money.Format(rehearsalCapital, money.ForMarket(marketFlag)))
+	}
 	_ = daemonProvider // used below
 	logFile, err := setupLogging(resolvedDir)
 	if err != nil {
@@ -521,10 +723,37 @@ func runDaemonMode(cfg *config.Config, p *provider.FallbackProvider) error {
 	daemonCfg.ForceScan = forceScan
 	daemonCfg.DataDir = resolvedDir
 	daemonCfg.TradingCapital = tradingCapital
+	daemonCfg.KellyRiskCap = kellyRiskCap || cfg.Trader.KellyRiskCap
+	daemonCfg.LiquiditySlippage = liquiditySlip || cfg.Trader.LiquiditySlippage
+	daemonCfg.AutoHedge = autoHedge || cfg.Trader.AutoHedge
+	daemonCfg.HedgeRatio = cfg.Trader.HedgeRatio
+	if hedgeRatio > 0 {
+		daemonCfg.HedgeRatio = hedgeRatio
+	}
+	daemonCfg.HedgeInstrument = cfg.Trader.HedgeInstrument
+	daemonCfg.Throttle = orderThrottle(cfg)
+	daemonCfg.Caps = orderCaps(cfg)
+	daemonCfg.PriceCheck = priceCheck(cfg)
+	daemonCfg.EODClose = eodClosePolicy(cfg)
+	daemonCfg.Tranches, _ = trancheExit(cfg) // run()에서 검증됨
+	daemonCfg.SignalFilters = scanFilters
+	daemonCfg.VolTarget = cfg.Trader.VolTargetPct / 100
+	daemonCfg.MaxADV = cfg.Trader.MaxADVPct / 100
+	daemonCfg.EarningsBlackoutDays = cfg.Trader.PreTrade.EarningsBlackoutDays
+	daemonCfg.MaxHeat = cfg.Trader.PreTrade.MaxHeatPct / 100
+	daemonCfg.SectorRotation = cfg.Trader.SectorRotation.Enabled
+	daemonCfg.MaxDataLagDays = cfg.Trader.MaxDataLagDays
+	daemonCfg.RunReportRetentionDays = cfg.Trader.RunReportRetentionDays
+	daemonCfg.WatchdogTimeout = time.Duration(cfg.Trader.WatchdogMinutes) * time.Minute
+	daemonCfg.AnomalyCashDrop = cfg.Trader.AnomalyCashDropPct / 100
+	daemonCfg.Rehearsal = rehearsalMode
+	if hedgeInstrument != "" {
+		daemonCfg.HedgeInstrument = strings.ToUpper(hedgeInstrument)
+	}
 
 	fmt.Printf(" Sleep on Exit:   %v\n", sleepOnExit)
 	if tradingCapital > 0 {
-		fmt.Printf(" Trading Capital: ₩%.0f (earmarked)\n", tradingCapital)
+		fmt.Printf(" Trading Capital: %s (earmarked)\n", money.Format(tradingCapital, money.KRW))
 	}
 	fmt.Println()
 
@@ -545,24 +774,25 @@ func runDaemonMode(cfg *config.Config, p *provider.FallbackProvider) error {
 		d.SetAIClient(aiClient)
 		log.Printf("[AI] Gemini AI signal filter enabled (model: gemini-2.5-flash-lite)")
 	}
+	webhooks := newWebhooks(cfg)
+	d.SetWebhooks(webhooks)
 
 	// --web 플래그가 함께 있으면 웹 서버를 백그라운드로 시작
 	if webMode {
 		log.Printf("[DAEMON] Starting web server on port %d", webPort)
 		// US broker for web (may be nil if running crypto-only mode)
 		var webKISBroker broker.Broker
-		if cfg.KIS.AppKey != "" {
-			creds := kis.Credentials{
-				AppKey:    cfg.KIS.AppKey,
-				AppSecret: cfg.KIS.AppSecret,
-				AccountNo: cfg.KIS.AccountNo,
-			}
-			client := kis.NewClient(creds)
+		if usBrokerConfigured(cfg) {
+			client := newUSBroker(cfg)
 			if client.IsReady() {
 				webKISBroker = client
 			}
 		}
 		server := web.NewServer(cfg, p, accountBalance, universe, webKISBroker, resolvedDir)
+		if webReadOnly {
+			server.SetReadOnly(true)
+		}
+		server.SetWebhooks(webhooks)
 		// KR market
 		var daemonKRProvider provider.Provider
 		if cfg.KIS.Domestic.AppKey != "" {
@@ -644,7 +874,7 @@ func runDCAMode() error {
 		dcaCfg.BaseDCAAmount = dcaAmount
 	}
 
-	fmt.Printf(" Base DCA Amount: ₩%.0f\n", dcaCfg.BaseDCAAmount)
+	fmt.Printf(" Base DCA Amount: %s\n", money.Format(dcaCfg.BaseDCAAmount, money.KRW))
 	fmt.Printf(" Interval:        daily\n")
 	fmt.Printf(" Targets:         ")
 	for i, t := range dcaCfg.Targets {
@@ -665,7 +895,7 @@ func runDCAMode() error {
 	if err != nil {
 		log.Printf("[DCA] Warning: could not get balance: %v", err)
 	} else {
-		log.Printf("[DCA] Account balance: ₩%.0f", bal.TotalEquity)
+		log.Printf("[DCA] Account balance: %s", money.Format(bal.TotalEquity, money.KRW))
 	}
 
 	dcaDaemon := daemon.NewDCADaemon(dcaCfg, upbitBroker, upbitProvider, resolvedDir)
@@ -737,7 +967,7 @@ func runKRDCAMode(cfg *config.Config) error {
 	if err != nil {
 		log.Printf("[KR-DCA] Warning: could not get balance: %v", err)
 	} else {
-		log.Printf("[KR-DCA] Account balance: ₩%.0f", bal.TotalEquity)
+		log.Printf("[KR-DCA] Account balance: %s", money.Format(bal.TotalEquity, money.KRW))
 	}
 
 	krDCADaemon := daemon.NewKRDCADaemon(krDCACfg, krBroker, krProvider, resolvedDir)
@@ -788,7 +1018,7 @@ func runScalpMode() error {
 		scalpCfg.OrderAmountKRW = scalpAmount
 	}
 
-	fmt.Printf(" Order Amount:    ₩%.0f per trade\n", scalpCfg.OrderAmountKRW)
+	fmt.Printf(" Order Amount:    %s per trade\n", money.Format(scalpCfg.OrderAmountKRW, money.KRW))
 	fmt.Printf(" Max Positions:   %d\n", scalpCfg.MaxPositions)
 	fmt.Printf(" Candle Interval: %d min\n", scalpCfg.CandleInterval)
 	fmt.Printf(" Entry:           RSI(%d) < %.0f\n", scalpCfg.RSIPeriod, scalpCfg.RSIEntry)
@@ -810,7 +1040,7 @@ func runScalpMode() error {
 	if err != nil {
 		log.Printf("[SCALP] Warning: could not get balance: %v", err)
 	} else {
-		log.Printf("[SCALP] Account balance: ₩%.0f (cash: ₩%.0f)", bal.TotalEquity, bal.CashBalance)
+		log.Printf("[SCALP] Account balance: %s (cash: %s)", money.Format(bal.TotalEquity, money.KRW), money.Format(bal.CashBalance, money.KRW))
 	}
 
 	scalpDaemon := daemon.NewScalpDaemon(scalpCfg, upbitBroker, upbitProvider, resolvedDir)
@@ -860,7 +1090,7 @@ func runBinanceScalpMode() error {
 	bProvider := provider.NewBinanceProvider()
 
 	fmt.Printf(" Exchange:        Binance USDT-M Futures\n")
-	fmt.Printf(" Order Amount:    $%.0f per trade\n", cfg.OrderAmountUSDT)
+	fmt.Printf(" Order Amount:    %s per trade\n", money.Format(cfg.OrderAmountUSDT, money.USDT))
 	fmt.Printf(" Leverage:        %dx\n", cfg.Leverage)
 	fmt.Printf(" Max Positions:   %d\n", cfg.MaxPositions)
 	fmt.Printf(" Candle Interval: %d min\n", cfg.CandleInterval)
@@ -922,7 +1152,7 @@ func runBTCFuturesMode() error {
 	bProvider := provider.NewBinanceProvider()
 
 	fmt.Printf(" Symbol:          %s\n", cfg.Symbol)
-	fmt.Printf(" Order Amount:    $%.0f per trade\n", cfg.OrderAmountUSDT)
+	fmt.Printf(" Order Amount:    %s per trade\n", money.Format(cfg.OrderAmountUSDT, money.USDT))
 	fmt.Printf(" Leverage:        %dx\n", cfg.Leverage)
 	fmt.Printf(" Entry:           Funding < %.4f%%, RSI > %.0f\n", cfg.FundingThreshold*100, cfg.RSIMin)
 	fmt.Printf(" Exit:            TP=ATR*%.1f, SL=ATR*%.1f, MaxBars=%d\n",
@@ -974,7 +1204,7 @@ func runBinanceArbMode() error {
 	}
 
 	fmt.Printf(" Strategy:        Funding Rate Arbitrage (Spot long + Futures short)\n")
-	fmt.Printf(" Max Capital:     $%.0f USDT\n", cfg.MaxCapitalUSDT)
+	fmt.Printf(" Max Capital:     %s\n", money.Format(cfg.MaxCapitalUSDT, money.USDT))
 	fmt.Printf(" Min Funding:     %.4f%%\n", cfg.MinFundingRate*100)
 	fmt.Printf(" Pairs:           %v\n", cfg.Pairs)
 	fmt.Printf(" Check Interval:  %d min\n", cfg.CheckIntervalMin)
@@ -1003,22 +1233,21 @@ func runWebServer(cfg *config.Config, p *provider.FallbackProvider) error {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Create KIS broker if credentials available
-	var kisBroker *kis.Client
-	if cfg.KIS.AppKey != "" {
-		creds := kis.Credentials{
-			AppKey:    cfg.KIS.AppKey,
-			AppSecret: cfg.KIS.AppSecret,
-			AccountNo: cfg.KIS.AccountNo,
-		}
-		client := kis.NewClient(creds)
+	// Create US broker if credentials available
+	var kisBroker broker.Broker
+	if usBrokerConfigured(cfg) {
+		client := newUSBroker(cfg)
 		if client.IsReady() {
 			kisBroker = client
-			fmt.Println("KIS broker connected for position monitoring")
+			fmt.Printf("%s broker connected for position monitoring\n", strings.ToUpper(client.Name()))
 		}
 	}
 
 	server := web.NewServer(cfg, p, accountBalance, universe, kisBroker, resolveDataDir())
+	if webReadOnly {
+		server.SetReadOnly(true)
+	}
+	server.SetWebhooks(newWebhooks(cfg))
 
 	// Create Korean market broker/provider if domestic credentials available
 	var krProvider provider.Provider
@@ -1157,13 +1386,14 @@ func runPullbackStrategy(ctx context.Context, stocks []model.Stock, fallbackProv
 	}
 
 	fmt.Printf("Scanning %d stocks for pullback opportunities...\n", len(stocks))
-	fmt.Printf("Account: %s\n\n", formatUSD(accountBalance))
+	fmt.Printf("Account: %s\n\n", formatMoney(accountBalance))
 
 	// Get strategy from registry
 	strat, err := strategy.Get("pullback", fallbackProvider)
 	if err != nil {
 		return fmt.Errorf("strategy not found: %w", err)
 	}
+	strat = withTranches(cfg, fallbackProvider, strat)[0]
 
 	// Setup progress bar
 	bar := progressbar.NewOptions(len(stocks),
@@ -1211,10 +1441,10 @@ func runPullbackStrategy(ctx context.Context, stocks []model.Stock, fallbackProv
 
 	// Calculate position sizing using the new Sizer
 	if len(signals) > 0 {
-		// Sort by probability first to prioritize best signals
-		sort.Slice(signals, func(i, j int) bool {
-			return signals[i].Probability > signals[j].Probability
-		})
+		signals = scanFilters.Apply(ctx, signals, signalFilterSources(ctx, fallbackProvider))
+		signals = applyScanThresholds(signals)
+		// 공통 순위 기준으로 상위 N개만 사이징 (trader.top_n)
+		signals = trader.SelectTopN(signals)
 
 		// Use balance-adjusted sizer config
 		sizerCfg := trader.AdjustConfigForBalance(accountBalance)
@@ -1224,7 +1454,7 @@ func runPullbackStrategy(ctx context.Context, stocks []model.Stock, fallbackProv
 		signals = sizer.ApplyToSignals(signals)
 
 		if len(signals) == 0 {
-			fmt.Printf("\nNo affordable signals found (max position value: %s)\n", formatUSD(accountBalance*0.2))
+			fmt.Printf("\nNo affordable signals found (max position value: %s)\n", formatMoney(accountBalance*0.2))
 		}
 	}
 
@@ -1257,60 +1487,54 @@ func runSingleStrategy(ctx context.Context, name string, stocks []model.Stock, f
 	if err != nil {
 		return fmt.Errorf("strategy not found: %w", err)
 	}
+	strat = withTranches(cfg, fallbackProvider, strat)[0]
 
-	fmt.Printf("Scanning %d stocks with %s strategy...\n", len(stocks), name)
-	fmt.Printf("Account: %s\n\n", formatUSD(accountBalance))
+	infof("Scanning %d stocks with %s strategy...\n", len(stocks), name)
+	infof("Account: %s\n\n", formatMoney(accountBalance))
 
-	bar := progressbar.NewOptions(len(stocks),
-		progressbar.OptionEnableColorCodes(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionSetDescription("Scanning"),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "[green]█[reset]",
-			SaucerHead:    "[green]█[reset]",
-			SaucerPadding: "░",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
+	bar := newScanBar(len(stocks), "Scanning")
 
 	var signals []strategy.Signal
+	var failed int // 분석 에러로 끝난 종목 수 (전부면 제공자 장애)
+	var lastErr error
+	scanIssues = strategy.NewScanIssues()
 	startTime := time.Now()
 
 	for i, stock := range stocks {
 		select {
 		case <-ctx.Done():
 			bar.Finish()
-			fmt.Println("\nScan interrupted")
+			infoln("\nScan interrupted")
 			break
 		default:
 		}
 
 		signal, err := strat.Analyze(ctx, stock)
-		if err == nil && signal != nil {
+		if err != nil {
+			failed, lastErr = failed+1, err
+			scanIssues.AddError(stock.Symbol, err)
+		} else if signal != nil {
+			emitSignal(signal)
 			signals = append(signals, *signal)
 		}
 		bar.Set(i + 1)
 	}
 
 	bar.Finish()
-	fmt.Println()
-
-	if len(signals) > 0 {
-		sort.Slice(signals, func(i, j int) bool {
-			return signals[i].Probability > signals[j].Probability
-		})
-		sizerCfg := trader.AdjustConfigForBalance(accountBalance)
-		sizer := trader.NewPositionSizer(sizerCfg)
-		signals = sizer.ApplyToSignals(signals)
+	infoln()
+	if err := scanFailure(failed, len(stocks), lastErr); err != nil {
+		return err
 	}
 
+	signals = filterScanSignals(ctx, signals, fallbackProvider)
+
 	scanTime := time.Since(startTime)
 
-	if format == "json" {
+	switch format {
+	case "json":
 		return outputSignalsJSON(signals, len(stocks), scanTime)
+	case "ndjson":
+		return outputSignalsNDJSON(signals, len(stocks), scanTime)
 	}
 
 	if err := outputSignalsTable(signals, len(stocks), scanTime, accountBalance); err != nil {
@@ -1326,71 +1550,79 @@ func runSingleStrategy(ctx context.Context, name string, stocks []model.Stock, f
 
 // runAllStrategies runs all registered strategies on each stock, keeps best signal per stock
 func runAllStrategies(ctx context.Context, stocks []model.Stock, fallbackProvider *provider.FallbackProvider, cfg *config.Config) error {
-	strategies := strategy.GetAll(fallbackProvider)
+	strategies := withTranches(cfg, fallbackProvider, strategy.GetAll(fallbackProvider)...)
 	stratNames := strategy.List()
 
-	fmt.Printf("Scanning %d stocks with %d strategies (%v)...\n", len(stocks), len(strategies), stratNames)
-	fmt.Printf("Account: %s\n\n", formatUSD(accountBalance))
+	infof("Scanning %d stocks with %d strategies (%v)...\n", len(stocks), len(strategies), stratNames)
+	if bound := strategy.StrategyUniverses(); len(bound) > 0 {
+		infof("Strategy universes: %s (other strategies scan every stock)\n", strings.Join(bound, " "))
+	}
+	if enabled := strategy.EnabledStrategies(); len(enabled) > 0 {
+		infof("Enabled strategies: %s (scanner.enabled_strategies)\n", strings.Join(enabled, ", "))
+	}
+	infof("Account: %s\n\n", formatMoney(accountBalance))
 
-	bar := progressbar.NewOptions(len(stocks),
-		progressbar.OptionEnableColorCodes(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionSetDescription("Multi-scan"),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "[green]█[reset]",
-			SaucerHead:    "[green]█[reset]",
-			SaucerPadding: "░",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
+	bar := newScanBar(len(stocks), "Multi-scan")
 
 	var signals []strategy.Signal
+	var failed int // 분석 에러로 끝난 종목 수 (전부면 제공자 장애)
+	var lastErr error
+	scanIssues = strategy.NewScanIssues()
 	startTime := time.Now()
 
 	for i, stock := range stocks {
 		select {
 		case <-ctx.Done():
 			bar.Finish()
-			fmt.Println("\nScan interrupted")
+			infoln("\nScan interrupted")
 			break
 		default:
 		}
 
 		// Run all strategies, keep best signal per stock
 		var best *strategy.Signal
+		var stockErr error
+		analyzed, errs := 0, 0
 		for _, strat := range strategies {
+			if !strategy.CoversSymbol(strat.Name(), stock.Symbol) {
+				continue
+			}
+			analyzed++
 			sig, err := strat.Analyze(ctx, stock)
-			if err == nil && sig != nil {
+			if err != nil {
+				errs, lastErr, stockErr = errs+1, err, err
+			} else if sig != nil {
 				if best == nil || sig.Strength > best.Strength {
 					best = sig
 				}
 			}
 		}
+		if analyzed > 0 && errs == analyzed {
+			failed++
+			scanIssues.AddError(stock.Symbol, stockErr)
+		}
 		if best != nil {
+			emitSignal(best)
 			signals = append(signals, *best)
 		}
 		bar.Set(i + 1)
 	}
 
 	bar.Finish()
-	fmt.Println()
-
-	if len(signals) > 0 {
-		sort.Slice(signals, func(i, j int) bool {
-			return signals[i].Probability > signals[j].Probability
-		})
-		sizerCfg := trader.AdjustConfigForBalance(accountBalance)
-		sizer := trader.NewPositionSizer(sizerCfg)
-		signals = sizer.ApplyToSignals(signals)
+	infoln()
+	if err := scanFailure(failed, len(stocks), lastErr); err != nil {
+		return err
 	}
 
+	signals = filterScanSignals(ctx, signals, fallbackProvider)
+
 	scanTime := time.Since(startTime)
 
-	if format == "json" {
+	switch format {
+	case "json":
 		return outputSignalsJSON(signals, len(stocks), scanTime)
+	case "ndjson":
+		return outputSignalsNDJSON(signals, len(stocks), scanTime)
 	}
 
 	if err := outputSignalsTable(signals, len(stocks), scanTime, accountBalance); err != nil {
@@ -1418,20 +1650,20 @@ func (l *adaptiveStockLoader) LoadUniverse(ctx context.Context, u symbols.Univer
 }
 
 func runAdaptiveScan(ctx context.Context, fallbackProvider *provider.FallbackProvider, cfg *config.Config, loader *symbols.Loader) error {
-	fmt.Println("=" + strings.Repeat("=", 59))
-	fmt.Println(" ADAPTIVE SCAN - Auto Universe Selection")
-	fmt.Println("=" + strings.Repeat("=", 59))
-	fmt.Printf("\n Account Balance: %s\n", formatUSD(accountBalance))
+	infoln("=" + strings.Repeat("=", 59))
+	infoln(" ADAPTIVE SCAN - Auto Universe Selection")
+	infoln("=" + strings.Repeat("=", 59))
+	infof("\n Account Balance: %s\n", formatMoney(accountBalance))
 
 	// 모든 전략 가져오기
-	strategies := strategy.GetAll(fallbackProvider)
+	strategies := withTranches(cfg, fallbackProvider, strategy.GetAll(fallbackProvider)...)
 
 	// Create sizer config based on balance
 	sizerCfg := trader.AdjustConfigForBalance(accountBalance)
-	fmt.Printf(" Risk per Trade:  %.1f%%\n", sizerCfg.RiskPerTrade*100)
-	fmt.Printf(" Max Positions:   %d\n", sizerCfg.MaxPositions)
-	fmt.Printf(" Min R/R:         %.1f\n", sizerCfg.MinRiskReward)
-	fmt.Println()
+	infof(" Risk per Trade:  %.1f%%\n", sizerCfg.RiskPerTrade*100)
+	infof(" Max Positions:   %d\n", sizerCfg.MaxPositions)
+	infof(" Min R/R:         %.1f\n", sizerCfg.MinRiskReward)
+	infoln()
 
 	// Determine universe tiers
 	tiers := trader.GetUniverseTiers(accountBalance)
@@ -1441,31 +1673,20 @@ func runAdaptiveScan(ctx context.Context, fallbackProvider *provider.FallbackPro
 			tierNames = append(tierNames, t.Name)
 		}
 	}
-	fmt.Printf(" 1st Tier:        %s\n", strings.Join(tierNames, ", "))
-	fmt.Println()
+	infof(" 1st Tier:        %s\n", strings.Join(tierNames, ", "))
+	infoln()
 
 	// Create adaptive scanner
 	adaptiveCfg := trader.DefaultAdaptiveConfig()
 	adaptiveCfg.Verbose = verbose
 
 	// Create scan function
+	scanIssues = strategy.NewScanIssues()
 	scanFunc := func(ctx context.Context, stocks []model.Stock) ([]strategy.Signal, error) {
 		var signals []strategy.Signal
 
 		// Progress bar
-		bar := progressbar.NewOptions(len(stocks),
-			progressbar.OptionEnableColorCodes(true),
-			progressbar.OptionShowCount(),
-			progressbar.OptionSetWidth(30),
-			progressbar.OptionSetDescription("Scanning"),
-			progressbar.OptionSetTheme(progressbar.Theme{
-				Saucer:        "[green]█[reset]",
-				SaucerHead:    "[green]█[reset]",
-				SaucerPadding: "░",
-				BarStart:      "[",
-				BarEnd:        "]",
-			}),
-		)
+		bar := newScanBar(len(stocks), "Scanning", progressbar.OptionSetWidth(30))
 
 		for i, stock := range stocks {
 			select {
@@ -1475,23 +1696,35 @@ func runAdaptiveScan(ctx context.Context, fallbackProvider *provider.FallbackPro
 			default:
 			}
 
-			// 모든 전략 실행, 가장 강한 신호 유지
+			// 모든 전략 실행 (전략별 유니버스 밖이면 건너뜀), 가장 강한 신호 유지
 			var best *strategy.Signal
+			var stockErr error
+			analyzed, errs := 0, 0
 			for _, strat := range strategies {
+				if !strategy.CoversSymbol(strat.Name(), stock.Symbol) {
+					continue
+				}
+				analyzed++
 				sig, err := strat.Analyze(ctx, stock)
-				if err == nil && sig != nil {
+				if err != nil {
+					errs, stockErr = errs+1, err
+				} else if sig != nil {
 					if best == nil || sig.Strength > best.Strength {
 						best = sig
 					}
 				}
 			}
+			if analyzed > 0 && errs == analyzed {
+				scanIssues.AddError(stock.Symbol, stockErr)
+			}
 			if best != nil {
+				emitSignal(best)
 				signals = append(signals, *best)
 			}
 			bar.Set(i + 1)
 		}
 		bar.Finish()
-		fmt.Println()
+		infoln()
 
 		return signals, nil
 	}
@@ -1499,40 +1732,55 @@ func runAdaptiveScan(ctx context.Context, fallbackProvider *provider.FallbackPro
 	scanner := trader.NewAdaptiveScanner(adaptiveCfg, sizerCfg, scanFunc)
 
 	// Run adaptive scan
+	scanner.SetSymbolLists(loadSymbolLists())
+	scanner.SetSignalFilters(scanFilters, signalFilterSources(ctx, fallbackProvider))
 	result, err := scanner.Scan(ctx, &adaptiveStockLoader{loader: loader})
 	if err != nil {
 		return fmt.Errorf("adaptive scan failed: %w", err)
 	}
 
 	// Print results
-	fmt.Println()
-	fmt.Printf("Scan Complete:\n")
-	fmt.Printf("  Universes:    %s\n", strings.Join(result.UniversesUsed, " → "))
-	fmt.Printf("  Stocks:       %d scanned\n", result.ScannedCount)
-	fmt.Printf("  Signals:      %d found\n", result.Quality.SignalCount)
-	fmt.Printf("  Avg Prob:     %.1f%%\n", result.Quality.AvgProb)
-	fmt.Printf("  Expansions:   %d\n", result.Expansions)
-	fmt.Printf("  Decision:     %s\n", result.Decision)
-	fmt.Println()
-
+	infoln()
+	infof("Scan Complete:\n")
+	infof("  Universes:    %s\n", strings.Join(result.UniversesUsed, " → "))
+	infof("  Stocks:       %d scanned\n", result.ScannedCount)
+	infof("  Signals:      %d found\n", result.Quality.SignalCount)
+	infof("  Avg Prob:     %.1f%%\n", result.Quality.AvgProb)
+	infof("  Expansions:   %d\n", result.Expansions)
+	infof("  Decision:     %s\n", result.Decision)
+	infoln()
+
+	result.Signals = selectScanSignals(result.Signals)
 	if len(result.Signals) == 0 {
-		fmt.Println("No trading opportunities found today.")
+		infoln("No trading opportunities found today.")
+		printScanIssues()
+		if streamSignals() {
+			return outputSignalsNDJSON(nil, result.ScannedCount, 0)
+		}
+		scanSignalCount = 0
 		return nil
 	}
 
 	// Apply position sizing
-	sizer := trader.NewPositionSizer(sizerCfg)
-	signals := sizer.ApplyToSignals(result.Signals)
+	signals := sizeScanSignals(result.Signals, sizerCfg)
 
 	if len(signals) == 0 {
-		fmt.Println("No affordable signals after sizing.")
+		infoln("No affordable signals after sizing.")
+		printScanIssues()
+		if streamSignals() {
+			return outputSignalsNDJSON(nil, result.ScannedCount, 0)
+		}
+		scanSignalCount = 0
 		return nil
 	}
 
 	// Output results
 	scanTime := time.Duration(0) // Already shown in adaptive output
-	if format == "json" {
+	switch format {
+	case "json":
 		return outputSignalsJSON(signals, result.ScannedCount, scanTime)
+	case "ndjson":
+		return outputSignalsNDJSON(signals, result.ScannedCount, scanTime)
 	}
 
 	if err := outputSignalsTable(signals, result.ScannedCount, scanTime, accountBalance); err != nil {
@@ -1548,34 +1796,75 @@ func runAdaptiveScan(ctx context.Context, fallbackProvider *provider.FallbackPro
 }
 
 func runPullbackBacktest(ctx context.Context, symbol string, p provider.Provider) error {
+	p, err := backtestProvider(p)
+	if err != nil {
+		return err
+	}
+
+	// Point-in-time universe: every symbol that was ever a member, filtered by date during simulation
+	if btUniverseAsOf != "" {
+		m, err := symbols.LoadMembership(symbols.MembershipPath(resolveDataDir(), btUniverseAsOf))
+		if err != nil {
+			return fmt.Errorf("universe history: %w", err)
+		}
+		return runPortfolioBacktest(ctx, m.Symbols(), p, m)
+	}
+
 	// Check for universe-based backtest
 	if universe != "" {
-		universeSymbols := symbols.GetUniverse(symbols.Universe(universe))
-		if universeSymbols == nil {
-			return fmt.Errorf("unknown universe: %s (use: test, dow30, nasdaq100, sp500, midcap, russell)", universe)
+		universeSymbols, err := resolveUniverse(universe)
+		if err != nil {
+			return err
 		}
-		return runPortfolioBacktest(ctx, universeSymbols, p)
+		return runPortfolioBacktest(ctx, universeSymbols, p, nil)
 	}
 
 	// If multiple symbols specified, use portfolio backtest
 	if symbolList != "" {
 		syms := strings.Split(symbolList, ",")
 		if len(syms) > 1 {
-			return runPortfolioBacktest(ctx, syms, p)
+			return runPortfolioBacktest(ctx, syms, p, nil)
 		}
 	}
 
-	fmt.Printf("Running single-stock backtest for %s (%d days)...\n", symbol, backtestDays)
+	// Stress scenarios run through the portfolio simulator (single symbol = 1-stock portfolio)
+	if btScenario != "" {
+		return runPortfolioBacktest(ctx, []string{symbol}, p, nil)
+	}
+
+	if btFrom.IsZero() {
+		fmt.Printf("Running single-stock backtest for %s (%d days)...\n", symbol, backtestDays)
+	} else {
+		fmt.Printf("Running single-stock backtest for %s (%s)...\n", symbol, backtestRangeLabel())
+	}
 	fmt.Println("TIP: Use --universe sp500 for full portfolio simulation with automatic stock discovery")
 
-	cfg := backtest.DefaultBacktestConfig()
+	cfg := backtest.DefaultBacktestConfig(backtestFeeMarket([]string{symbol}))
 	cfg.InitialCapital = accountBalance
+	cfg.RiskPerTrade, cfg.StopLossPct, cfg.TargetRMultiple, cfg.MaxHoldDays = btRiskPct/100, btStopPct/100, btTargetR, btMaxHold
+	cfg.From, cfg.To = btFrom, btTo
+	cfg.AuditLookahead = btAuditLook
+	if cfg.StopModel, err = backtestStopModel(); err != nil {
+		return err
+	}
+	if btDividends && !backtestOffline() {
+		cfg.Dividends = loadDividendHistory(ctx, []string{symbol})
+	}
+	if liquiditySlip && !backtestOffline() {
+		cfg.SymbolSlippage = trader.NewLiquidityModel(p, 3).SlippageMap(ctx, []string{symbol})
+		if s, ok := cfg.SymbolSlippage[symbol]; ok {
+			fmt.Printf("Liquidity model: %s slippage %.2f%% (default %.2f%%)\n", symbol, s*100, cfg.Slippage*100)
+		}
+	}
 
 	bt := backtest.NewBacktester(cfg, p)
 	result, err := bt.RunPullbackBacktest(ctx, symbol, backtestDays)
 	if err != nil {
 		return fmt.Errorf("backtest failed: %w", err)
 	}
+	if btAuditLook {
+		fmt.Println("Lookahead audit: passed")
+	}
 
 	if result == nil || result.TotalTrades == 0 {
 		fmt.Println("No trades generated in backtest period.")
@@ -1583,41 +1872,117 @@ func runPullbackBacktest(ctx context.Context, symbol string, p provider.Provider
 	}
 
 	outputSingleBacktest(result, cfg.InitialCapital)
+	if mcSizing && len(result.Trades) >= 10 {
+		// 단일 종목은 동시 포지션이 없으므로 리스크 수준만 비교
+		stress := backtest.DefaultSizingStressConfig()
+		stress.MaxPositions = []int{1}
+		printSizingStress(backtest.RunSizingStressTest(result.Trades, stress))
+	}
+	if btCostSens {
+		printFrictionReport(backtest.AnalyzeFriction(result.Trades, cfg.FrictionCosts(), backtest.DefaultFrictionMultipliers))
+	}
+	recordBacktestRun(backtest.NewSingleRun(btSave, cfg, backtest.RunParams{Days: backtestDays, Symbols: []string{symbol}}, result))
 	return nil
 }
 
-func runPortfolioBacktest(ctx context.Context, syms []string, p provider.Provider) error {
+func runPortfolioBacktest(ctx context.Context, syms []string, p provider.Provider, membership *symbols.Membership) error {
 	fmt.Println("=" + strings.Repeat("=", 59))
 	fmt.Println(" PORTFOLIO BACKTEST - Full Strategy Simulation")
 	fmt.Println("=" + strings.Repeat("=", 59))
 
-	universeLabel := "custom"
+	universeLabel, runUniverse := "custom", universe
 	if universe != "" {
 		universeLabel = universe
 	}
+	if membership != nil {
+		universeLabel = "point-in-time " + membership.Name
+		runUniverse = "history:" + membership.Name
+	}
 
 	fmt.Printf("\n Universe:      %s (%d symbols)\n", universeLabel, len(syms))
-	fmt.Printf(" Period:        %d trading days\n", backtestDays)
-	fmt.Printf(" Capital:       %s\n", formatUSD(accountBalance))
-	fmt.Printf(" Max Positions: 5 simultaneous\n")
-	fmt.Printf(" Risk/Trade:    1%%\n")
-	fmt.Printf(" Stop Loss:     2%%\n")
-	fmt.Printf(" Target:        2R (4%%)\n\n")
+	if btScenario != "" {
+		fmt.Printf(" Period:        stress scenarios (%s)\n", btScenario)
+	} else if !btFrom.IsZero() {
+		fmt.Printf(" Period:        %s (~%d trading days)\n", backtestRangeLabel(), backtestDays)
+	} else {
+		fmt.Printf(" Period:        %d trading days\n", backtestDays)
+	}
+	fmt.Printf(" Capital:       %s\n", formatMoney(accountBalance))
+	fmt.Printf(" Max Positions: %d simultaneous\n", btMaxPositions)
+	if btMaxNewPerDay > 0 {
+		fmt.Printf(" New Per Day:   %d (best signals first)\n", btMaxNewPerDay)
+	}
+	fmt.Printf(" Risk/Trade:    %g%%\n", btRiskPct)
+	if m, _ := backtestStopModel(); m.IsSet() {
+		fmt.Printf(" Stop Loss:     %s (%g%% when not computable)\n", m, btStopPct)
+		fmt.Printf(" Target:        %gR\n", btTargetR)
+	} else {
+		fmt.Printf(" Stop Loss:     %g%%\n", btStopPct)
+		fmt.Printf(" Target:        %gR (%g%%)\n", btTargetR, btTargetR*btStopPct)
+	}
+	fmt.Printf(" Max Hold:      %d trading days\n\n", btMaxHold)
 
 	fmt.Println(" This backtest simulates:")
 	fmt.Println("   1. Daily scan of ALL symbols in universe")
 	fmt.Println("   2. Automatic signal detection (no look-ahead)")
 	fmt.Println("   3. Position entry on next day's open")
-	fmt.Println("   4. Portfolio management with max 5 positions")
+	fmt.Printf("   4. Portfolio management with max %d positions\n", btMaxPositions)
 	fmt.Println()
 
-	cfg := backtest.DefaultPortfolioConfig()
+	feeMarket := backtestFeeMarket(syms)
+	cfg := backtest.DefaultPortfolioConfig(feeMarket)
 	cfg.InitialCapital = accountBalance
+	cfg.RiskPerTrade, cfg.StopLossPct, cfg.TargetRMultiple, cfg.MaxHoldDays = btRiskPct/100, btStopPct/100, btTargetR, btMaxHold
+	cfg.MaxPositions = btMaxPositions
+	cfg.From, cfg.To = btFrom, btTo
+	cfg.MaxNewPerDay = btMaxNewPerDay
+	cfg.AuditLookahead = btAuditLook
+	cfg.Membership = membership
+	cfg.Benchmark = backtestBenchmark(feeMarket)
+	stopModel, err := backtestStopModel()
+	if err != nil {
+		return err
+	}
+	cfg.StopModel = stopModel
+	if btContribution != 0 {
+		every, err := backtest.ParseContributionEvery(btContribEvery)
+		if err != nil {
+			return err
+		}
+		cfg.Contributions = backtest.ContributionSchedule{Amount: btContribution, Every: every}
+		verb, amount := "add", btContribution
+		if amount < 0 {
+			verb, amount = "withdraw", -amount
+		}
+		fmt.Printf(" Contributions: %s %s %s\n", verb, formatMoney(amount), every)
+	}
+	fee := fees.ForMarket(feeMarket)
+	fmt.Printf(" Fees:          %s (%.3f%% + %.3f%% sell tax)\n\n", fee.Name, fee.Commission*100, fee.SellTax*100)
+	if (liquiditySlip || btDividends) && backtestOffline() {
+		fmt.Println(" Offline data source: skipping --liquidity-slippage/--dividends (network only)")
+		fmt.Println()
+	}
+	if liquiditySlip && !backtestOffline() {
+		fmt.Println(" Estimating per-symbol slippage from 1-minute bars...")
+		cfg.SymbolSlippage = trader.NewLiquidityModel(p, 3).SlippageMap(ctx, syms)
+		fmt.Printf(" Liquidity model: %d/%d symbols estimated (default %.2f%%)\n\n",
+			len(cfg.SymbolSlippage), len(syms), cfg.Slippage*100)
+	}
+	if btDividends && !backtestOffline() {
+		fmt.Println(" Fetching dividend history...")
+		cfg.Dividends = loadDividendHistory(ctx, syms)
+		fmt.Printf(" Dividends: %d/%d symbols paid dividends in period\n\n", len(cfg.Dividends), len(syms))
+	}
+
+	if btScenario != "" {
+		return runScenarioBacktests(ctx, syms, p, cfg)
+	}
 
 	bt := backtest.NewPortfolioBacktester(cfg, p)
 
 	// Progress bar for loading
 	bar := progressbar.NewOptions(len(syms),
+		progressbar.OptionSetVisibility(!quiet),
 		progressbar.OptionEnableColorCodes(true),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(40),
@@ -1639,6 +2004,13 @@ func runPortfolioBacktest(ctx context.Context, syms []string, p provider.Provide
 	if err != nil {
 		return fmt.Errorf("portfolio backtest failed: %w", err)
 	}
+	if btAuditLook {
+		fmt.Println(" Lookahead audit: passed (signals use data through the decision day, entries fill at the next open)")
+	}
+	if membership != nil && len(result.MissingSymbols) > 0 {
+		fmt.Printf(" Warning: %d/%d historical members have no price data (likely delisted) — survivorship bias remains for them\n",
+			len(result.MissingSymbols), len(syms))
+	}
 
 	if result == nil || result.TotalTrades == 0 {
 		fmt.Println("No trades generated in backtest period.")
@@ -1646,6 +2018,7 @@ func runPortfolioBacktest(ctx context.Context, syms []string, p provider.Provide
 	}
 
 	outputPortfolioBacktest(result)
+	recordBacktestRun(backtest.NewPortfolioRun(btSave, cfg, backtest.RunParams{Days: backtestDays, Universe: runUniverse, Symbols: syms}, result))
 
 	// Monte Carlo
 	if len(result.Trades) >= 10 {
@@ -1658,6 +2031,14 @@ func runPortfolioBacktest(ctx context.Context, syms []string, p provider.Provide
 			fmt.Printf(" Ruin Probability: %.1f%%\n", mc.RuinProbability)
 		}
 	}
+	if mcSizing && len(result.Trades) >= 10 {
+		stress := backtest.DefaultSizingStressConfig()
+		stress.Rounds, stress.SignalsPerRound = backtest.SizingRoundsFromPortfolio(result)
+		printSizingStress(backtest.RunSizingStressTest(result.Trades, stress))
+	}
+	if btCostSens {
+		printFrictionReport(backtest.AnalyzeFriction(result.Trades, cfg.FrictionCosts(), backtest.DefaultFrictionMultipliers))
+	}
 
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	return nil
@@ -1669,26 +2050,37 @@ func outputSingleBacktest(result *backtest.BacktestResult, initialCapital float6
 	fmt.Println("=" + strings.Repeat("=", 59))
 
 	fmt.Printf("\n Period: %s\n", result.Period)
-	fmt.Printf(" Initial Capital: %s\n", formatUSD(initialCapital))
-	fmt.Printf(" Final Capital:   %s\n", formatUSD(initialCapital+result.TotalReturn))
+	fmt.Printf(" Initial Capital: %s\n", formatMoney(initialCapital))
+	fmt.Printf(" Final Capital:   %s\n", formatMoney(initialCapital+result.TotalReturn))
 
 	fmt.Println("\n--- Performance ---")
 	fmt.Printf(" Total Trades:    %d\n", result.TotalTrades)
 	fmt.Printf(" Win Rate:        %.1f%% (%d wins / %d losses)\n",
 		result.WinRate, result.WinningTrades, result.LosingTrades)
 	fmt.Printf(" Total Return:    %s (%.1f%%)\n",
-		formatUSD(result.TotalReturn), result.TotalReturnPct)
+		formatMoney(result.TotalReturn), result.TotalReturnPct)
 
 	fmt.Println("\n--- Risk Metrics ---")
-	fmt.Printf(" Avg Win:         %s (%.2f%%)\n", formatUSD(result.AvgWin), result.AvgWinPct)
-	fmt.Printf(" Avg Loss:        %s (%.2f%%)\n", formatUSD(result.AvgLoss), result.AvgLossPct)
+	fmt.Printf(" Avg Win:         %s (%.2f%%)\n", formatMoney(result.AvgWin), result.AvgWinPct)
+	fmt.Printf(" Avg Loss:        %s (%.2f%%)\n", formatMoney(result.AvgLoss), result.AvgLossPct)
 	fmt.Printf(" Risk/Reward:     1:%.2f\n", result.RiskRewardRatio)
 	fmt.Printf(" Profit Factor:   %.2f\n", result.ProfitFactor)
 	fmt.Printf(" Max Drawdown:    %.1f%%\n", result.MaxDrawdown)
 
 	fmt.Println("\n--- Expectancy ---")
-	fmt.Printf(" Per Trade:       %s\n", formatUSD(result.Expectancy))
+	fmt.Printf(" Per Trade:       %s\n", formatMoney(result.Expectancy))
 	fmt.Printf(" Per Trade (R):   %.2fR\n", result.ExpectancyR)
+	if result.RDistribution != nil {
+		fmt.Println("\n--- R-Multiple Distribution ---")
+		fmt.Print(result.RDistribution.Format(30))
+	}
+	if result.Exits != nil {
+		fmt.Println("\n--- Exits ---")
+		fmt.Print(result.Exits.Format(formatMoney))
+	}
+	if result.Gaps != nil {
+		printGapStats(result.Gaps)
+	}
 
 	fmt.Println("\n--- Kelly Criterion ---")
 	fmt.Printf(" Optimal Size:    %.1f%% of capital\n", result.KellyOptimal*100)
@@ -1713,22 +2105,53 @@ func outputSingleBacktest(result *backtest.BacktestResult, initialCapital float6
 	fmt.Println("\n" + strings.Repeat("=", 60))
 }
 
+// loadDividendHistory 백테스트 기간을 덮는 종목별 배당 이력 (배당 없는 종목은 제외)
+func loadDividendHistory(ctx context.Context, syms []string) map[string][]provider.DividendEvent {
+	lookback := backtestDays
+	if !btFrom.IsZero() {
+		lookback = backtest.TradingDaysBetween(btFrom, time.Now())
+	}
+	years := lookback/252 + 1
+	yahoo := provider.NewYahooProvider()
+	result := make(map[string][]provider.DividendEvent)
+	for _, sym := range syms {
+		history, err := yahoo.GetDividends(ctx, sym, years)
+		if err != nil || len(history) == 0 {
+			continue
+		}
+		result[sym] = history
+	}
+	return result
+}
+
 func outputPortfolioBacktest(result *backtest.PortfolioBacktestResult) {
 	fmt.Println("\n--- RESULTS ---")
 	fmt.Printf(" Period:          %s (%d trading days)\n", result.Period, result.TradingDays)
-	fmt.Printf(" Initial Capital: %s\n", formatUSD(result.InitialCapital))
-	fmt.Printf(" Final Equity:    %s\n", formatUSD(result.FinalEquity))
-	fmt.Printf(" Total Return:    %s (%.1f%%)\n", formatUSD(result.TotalReturn), result.TotalReturnPct)
+	fmt.Printf(" Initial Capital: %s\n", formatMoney(result.InitialCapital))
+	fmt.Printf(" Final Equity:    %s\n", formatMoney(result.FinalEquity))
+	fmt.Printf(" Total Return:    %s (%.1f%%)\n", formatMoney(result.TotalReturn), result.TotalReturnPct)
 	fmt.Printf(" CAGR:            %.1f%%\n", result.CAGR)
+	if len(result.CashFlows) > 0 {
+		fmt.Printf(" Net Contributed: %s (excluded from return)\n", formatMoney(result.NetContributions))
+		fmt.Printf(" CAGR is time-weighted (strategy); IRR is money-weighted (your account)\n")
+		fmt.Printf(" IRR:             %.1f%%\n", result.IRR)
+	}
+	var dividends float64
+	for _, t := range result.Trades {
+		dividends += t.Dividends
+	}
+	if dividends > 0 {
+		fmt.Printf(" Dividends:       %s (included in P&L)\n", formatMoney(dividends))
+	}
 
 	fmt.Println("\n--- Trade Statistics ---")
 	fmt.Printf(" Total Trades:    %d\n", result.TotalTrades)
 	fmt.Printf(" Win Rate:        %.1f%% (%d W / %d L)\n",
 		result.WinRate, result.WinningTrades, result.LosingTrades)
-	fmt.Printf(" Avg Win:         %s (+%.2f%%)\n", formatUSD(result.AvgWin), result.AvgWinPct)
-	fmt.Printf(" Avg Loss:        %s (%.2f%%)\n", formatUSD(result.AvgLoss), result.AvgLossPct)
-	fmt.Printf(" Largest Win:     %s\n", formatUSD(result.LargestWin))
-	fmt.Printf(" Largest Loss:    %s\n", formatUSD(result.LargestLoss))
+	fmt.Printf(" Avg Win:         %s (+%.2f%%)\n", formatMoney(result.AvgWin), result.AvgWinPct)
+	fmt.Printf(" Avg Loss:        %s (%.2f%%)\n", formatMoney(result.AvgLoss), result.AvgLossPct)
+	fmt.Printf(" Largest Win:     %s\n", formatMoney(result.LargestWin))
+	fmt.Printf(" Largest Loss:    %s\n", formatMoney(result.LargestLoss))
 
 	fmt.Println("\n--- Risk Metrics ---")
 	fmt.Printf(" Risk/Reward:     1:%.2f\n", result.RiskRewardRatio)
@@ -1736,15 +2159,37 @@ func outputPortfolioBacktest(result *backtest.PortfolioBacktestResult) {
 	fmt.Printf(" Max Drawdown:    %.1f%% (%d days)\n", result.MaxDrawdown, result.MaxDrawdownDays)
 	fmt.Printf(" Sharpe Ratio:    %.2f\n", result.SharpeRatio)
 	fmt.Printf(" Sortino Ratio:   %.2f\n", result.SortinoRatio)
+	if result.Drawdown != nil {
+		fmt.Println("\n--- Drawdown & Recovery ---")
+		printDrawdownStats("Strategy", result.Drawdown)
+		if result.RelativeDrawdown != nil {
+			fmt.Printf(" %s buy & hold: %+.1f%%\n", result.Benchmark, result.BenchmarkReturnPct)
+			printDrawdownStats("vs "+result.Benchmark, result.RelativeDrawdown)
+		}
+	}
 
 	fmt.Println("\n--- Expectancy ---")
-	fmt.Printf(" Per Trade:       %s\n", formatUSD(result.Expectancy))
+	fmt.Printf(" Per Trade:       %s\n", formatMoney(result.Expectancy))
 	fmt.Printf(" Per Trade (R):   %.2fR\n", result.ExpectancyR)
+	if result.RDistribution != nil {
+		fmt.Println("\n--- R-Multiple Distribution ---")
+		fmt.Print(result.RDistribution.Format(30))
+	}
+	if result.Exits != nil {
+		fmt.Println("\n--- Exits ---")
+		fmt.Print(result.Exits.Format(formatMoney))
+	}
+	if result.Gaps != nil {
+		printGapStats(result.Gaps)
+	}
 
 	fmt.Println("\n--- Position Management ---")
 	fmt.Printf(" Avg Positions:   %.1f\n", result.AvgPositions)
 	fmt.Printf(" Max Pos Days:    %d\n", result.MaxPositionsHit)
 	fmt.Printf(" Signals Skipped: %d (due to max positions)\n", result.SignalsSkipped)
+	if result.SignalsCapped > 0 {
+		fmt.Printf(" Signals Capped:  %d (due to max new per day)\n", result.SignalsCapped)
+	}
 
 	fmt.Println("\n--- Kelly Criterion ---")
 	if result.KellyOptimal > 0 {
@@ -1757,9 +2202,11 @@ func outputPortfolioBacktest(result *backtest.PortfolioBacktestResult) {
 }
 
 func outputSignalsTable(signals []strategy.Signal, totalScanned int, scanTime time.Duration, capital float64) error {
+	scanSignalCount = len(signals)
 	if len(signals) == 0 {
-		fmt.Println("No trading opportunities found.")
-		fmt.Printf("Scanned %d stocks in %s\n", totalScanned, scanTime.Round(time.Second))
+		infoln("No trading opportunities found.")
+		infof("Scanned %d stocks in %s\n", totalScanned, scanTime.Round(time.Second))
+		printScanIssues()
 		return nil
 	}
 
@@ -1774,17 +2221,17 @@ func outputSignalsTable(signals []strategy.Signal, totalScanned int, scanTime ti
 	cashRemaining := capital - totalInvest
 
 	// Portfolio Summary Header
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println(" PORTFOLIO ALLOCATION SUMMARY")
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Printf(" Total Capital:     %s\n", formatUSD(capital))
-	fmt.Printf(" Recommended Picks: %d stocks\n", len(signals))
-	fmt.Printf(" Total Investment:  %s (%.1f%%)\n", formatUSD(totalInvest), totalInvest/capital*100)
-	fmt.Printf(" Total Risk:        %s (%.2f%%)\n", formatUSD(totalRisk), totalRisk/capital*100)
-	fmt.Printf(" Cash Remaining:    %s (%.1f%%)\n", formatUSD(cashRemaining), cashRemaining/capital*100)
-	fmt.Println(strings.Repeat("=", 60))
-
-	fmt.Printf("\nFound %d pullback opportunities (sorted by probability):\n\n", len(signals))
+	infoln(strings.Repeat("=", 60))
+	infoln(" PORTFOLIO ALLOCATION SUMMARY")
+	infoln(strings.Repeat("=", 60))
+	infof(" Total Capital:     %s\n", formatMoney(capital))
+	infof(" Recommended Picks: %d stocks\n", len(signals))
+	infof(" Total Investment:  %s (%.1f%%)\n", formatMoney(totalInvest), totalInvest/capital*100)
+	infof(" Total Risk:        %s (%.2f%%)\n", formatMoney(totalRisk), totalRisk/capital*100)
+	infof(" Cash Remaining:    %s (%.1f%%)\n", formatMoney(cashRemaining), cashRemaining/capital*100)
+	infoln(strings.Repeat("=", 60))
+
+	infof("\nFound %d pullback opportunities (sorted by probability):\n\n", len(signals))
 
 	table := tablewriter.NewTable(os.Stdout,
 		tablewriter.WithHeader([]string{"#", "Symbol", "Price", "Shares", "Amount", "Alloc%", "Risk$"}),
@@ -1799,16 +2246,64 @@ func outputSignalsTable(signals []strategy.Signal, totalScanned int, scanTime ti
 		table.Append([]string{
 			fmt.Sprintf("%d", i+1),
 			s.Stock.Symbol,
-			fmt.Sprintf("$%.2f", g.EntryPrice),
+			formatPrice(g.EntryPrice),
 			fmt.Sprintf("%.0f", g.PositionSize),
-			formatUSD(g.InvestAmount),
+			formatMoney(g.InvestAmount),
 			fmt.Sprintf("%.1f%%", g.AllocationPct),
-			formatUSD(g.RiskAmount),
+			formatMoney(g.RiskAmount),
 		})
 	}
 
 	table.Render()
 
+	// --quiet: 종목 표만 (상세 가이드·면책 문구 생략, 요청한 리포트 파일은 그대로 저장)
+	if !quiet {
+		printTradeGuides(signals, totalScanned, scanTime)
+		printScanIssues()
+	}
+
+	// Save report to file if requested
+	// Save report only when requested (-o, --save-report, config save_reports)
+	if shouldSaveReport() {
+		now := time.Now()
+		filename := outputFile
+		var err error
+		if filename == "" {
+			// <reports-dir>/<date>/report_<date_time>.txt
+			filename, err = scanReportPath(now, "txt")
+		}
+		if err == nil {
+			err = saveReport(filename, signals, capital, totalScanned, scanTime)
+		}
+		if err != nil {
+			warnf("Warning: failed to save report: %v\n", err)
+		} else {
+			infof("Report saved to: %s\n", filename)
+		}
+
+		// Also save JSON report for web UI
+		jsonFilename, err := scanReportPath(now, "json")
+		if err == nil {
+			err = saveJSONReport(jsonFilename, signals, capital, totalScanned, scanTime)
+		}
+		if err != nil {
+			warnf("Warning: failed to save JSON report: %v\n", err)
+		} else {
+			infof("JSON report saved to: %s (for Web UI)\n", jsonFilename)
+		}
+
+		if n, err := pruneScanReports(now); err != nil {
+			warnf("Warning: failed to prune old reports: %v\n", err)
+		} else if n > 0 {
+			infof("Pruned %d report folder(s) older than retention\n", n)
+		}
+	}
+
+	return nil
+}
+
+// printTradeGuides 시그널별 진입/청산 가이드와 면책 문구
+func printTradeGuides(signals []strategy.Signal, totalScanned int, scanTime time.Duration) {
 	// Print detailed trade guides
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Println(" DETAILED TRADE GUIDE")
@@ -1819,30 +2314,38 @@ func outputSignalsTable(signals []strategy.Signal, totalScanned int, scanTime ti
 		fmt.Println(strings.Repeat("-", 50))
 
 		// Signal info
-		fmt.Printf("  Signal: %s\n", s.Reason)
+		fmt.Printf("  Signal: %s\n", strings.ReplaceAll(s.Explain(), "\n", "\n  "))
 		fmt.Printf("  Win Probability: %.0f%%\n", s.Probability)
+		if s.Technical != nil && s.Technical.Score > 0 {
+			fmt.Printf("  Score: %.0f/100 (RSI %.0f, volume %.1fx, %s)\n",
+				s.Technical.Score, s.Technical.RSI, s.Technical.VolumeRatio, s.Technical.TrendSignal)
+		}
 
 		if s.Guide != nil {
 			g := s.Guide
 
 			// Entry/Exit Guide
 			fmt.Println("\n  [ENTRY]")
-			fmt.Printf("    Buy %.0f shares @ $%.2f = %s\n", g.PositionSize, g.EntryPrice, formatUSD(g.InvestAmount))
+			fmt.Printf("    Buy %.0f shares @ %s = %s\n", g.PositionSize, formatPrice(g.EntryPrice), formatMoney(g.InvestAmount))
+			if p := g.Pair; p != nil {
+				fmt.Printf("    Short %.0f %s @ %s (hedge %.2f, z %+.2f → exit ±%.1f, stop ±%.1f)\n",
+					p.ShortQuantity, p.ShortSymbol, formatPrice(p.ShortPrice), p.HedgeRatio, p.ZScore, p.ExitZ, p.StopZ)
+			}
 			fmt.Printf("    Allocation: %.1f%% of portfolio\n", g.AllocationPct)
 
 			fmt.Println("\n  [EXIT - Stop Loss]")
-			fmt.Printf("    Sell @ $%.2f (%.1f%% loss)\n", g.StopLoss, g.StopLossPct)
-			fmt.Printf("    Max Loss: %s (%.2f%% of portfolio)\n", formatUSD(g.RiskAmount), g.RiskPct)
+			fmt.Printf("    Sell @ %s (%.1f%% loss)\n", formatPrice(g.StopLoss), g.StopLossPct)
+			fmt.Printf("    Max Loss: %s (%.2f%% of portfolio)\n", formatMoney(g.RiskAmount), g.RiskPct)
 
 			fmt.Println("\n  [EXIT - Take Profit]")
-			fmt.Printf("    Target 1: $%.2f (+%.1f%%) - Sell 50%%\n", g.Target1, g.Target1Pct)
-			fmt.Printf("    Target 2: $%.2f (+%.1f%%) - Sell remaining\n", g.Target2, g.Target2Pct)
+			fmt.Printf("    Target 1: %s (+%.1f%%) - Sell 50%%\n", formatPrice(g.Target1), g.Target1Pct)
+			fmt.Printf("    Target 2: %s (+%.1f%%) - Sell remaining\n", formatPrice(g.Target2), g.Target2Pct)
 		}
 
 		// Technical Details
 		fmt.Println("\n  [TECHNICALS]")
-		fmt.Printf("    Close: $%.2f | MA20: $%.2f | MA50: $%.2f\n",
-			s.Details["close"], s.Details["ma20"], s.Details["ma50"])
+		fmt.Printf("    Close: %s | MA20: %s | MA50: %s\n",
+			formatPrice(s.Details["close"]), formatPrice(s.Details["ma20"]), formatPrice(s.Details["ma50"]))
 		if rsi, ok := s.Details["rsi14"]; ok && rsi > 0 {
 			rsiStatus := "neutral"
 			if rsi < 30 {
@@ -1860,33 +2363,39 @@ func outputSignalsTable(signals []strategy.Signal, totalScanned int, scanTime ti
 	fmt.Println(strings.Repeat("=", 60))
 
 	fmt.Printf("\nScanned %d stocks in %s\n", totalScanned, scanTime.Round(time.Second))
+}
 
-	// Save report to file if requested
-	if outputFile != "" || len(signals) > 0 {
-		filename := outputFile
-		if filename == "" {
-			// Auto-generate filename with date
-			filename = fmt.Sprintf("report_%s.txt", time.Now().Format("2006-01-02_150405"))
-		}
-		if err := saveReport(filename, signals, capital, totalScanned, scanTime); err != nil {
-			fmt.Printf("Warning: failed to save report: %v\n", err)
-		} else {
-			fmt.Printf("Report saved to: %s\n", filename)
-		}
-
-		// Also save JSON report for web UI
-		jsonFilename := fmt.Sprintf("report_%s.json", time.Now().Format("2006-01-02_150405"))
-		if err := saveJSONReport(jsonFilename, signals, capital, totalScanned, scanTime); err != nil {
-			fmt.Printf("Warning: failed to save JSON report: %v\n", err)
-		} else {
-			fmt.Printf("JSON report saved to: %s (for Web UI)\n", jsonFilename)
+// applyScanThresholds --min-prob/--min-strength 미만 시그널 제외 (사이징 전이라 표/JSON/리포트에 똑같이 반영)
+func applyScanThresholds(signals []strategy.Signal) []strategy.Signal {
+	if minProb <= 0 && minStrength <= 0 {
+		return signals
+	}
+	kept := signals[:0]
+	for _, s := range signals {
+		if s.Probability >= minProb && s.Strength >= minStrength {
+			kept = append(kept, s)
 		}
 	}
+	if dropped := len(signals) - len(kept); dropped > 0 && format != "json" {
+		infof("Dropped %d signal(s) below %s\n", dropped, scanThresholdLabel())
+	}
+	return kept
+}
 
-	return nil
+// scanThresholdLabel 적용 중인 스캔 기준 표시 ("prob >= 60%, strength >= 50")
+func scanThresholdLabel() string {
+	var parts []string
+	if minProb > 0 {
+		parts = append(parts, fmt.Sprintf("prob >= %.0f%%", minProb))
+	}
+	if minStrength > 0 {
+		parts = append(parts, fmt.Sprintf("strength >= %.0f", minStrength))
+	}
+	return strings.Join(parts, ", ")
 }
 
 func outputSignalsJSON(signals []strategy.Signal, totalScanned int, scanTime time.Duration) error {
+	scanSignalCount = len(signals)
 	// Calculate totals
 	var totalInvest, totalRisk float64
 	for _, s := range signals {
@@ -1906,6 +2415,9 @@ func outputSignalsJSON(signals []strategy.Signal, totalScanned int, scanTime tim
 		TotalInvest:  totalInvest,
 		TotalRisk:    totalRisk,
 		GeneratedAt:  time.Now().Format(time.RFC3339),
+		MinProb:      minProb,
+		MinStrength:  minStrength,
+		Issues:       scanIssues,
 	}
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -1936,12 +2448,15 @@ func saveReport(filename string, signals []strategy.Signal, capital float64, tot
 	// Portfolio Summary
 	fmt.Fprintf(f, "PORTFOLIO ALLOCATION SUMMARY\n")
 	fmt.Fprintf(f, "%s\n", strings.Repeat("-", 40))
-	fmt.Fprintf(f, "Total Capital:     %s\n", formatUSD(capital))
+	fmt.Fprintf(f, "Total Capital:     %s\n", formatMoney(capital))
 	fmt.Fprintf(f, "Stocks Scanned:    %d\n", totalScanned)
+	if label := scanThresholdLabel(); label != "" {
+		fmt.Fprintf(f, "Signal Filter:     %s\n", label)
+	}
 	fmt.Fprintf(f, "Recommended Picks: %d\n", len(signals))
-	fmt.Fprintf(f, "Total Investment:  %s (%.1f%%)\n", formatUSD(totalInvest), totalInvest/capital*100)
-	fmt.Fprintf(f, "Total Risk:        %s (%.2f%%)\n", formatUSD(totalRisk), totalRisk/capital*100)
-	fmt.Fprintf(f, "Cash Remaining:    %s (%.1f%%)\n", formatUSD(capital-totalInvest), (capital-totalInvest)/capital*100)
+	fmt.Fprintf(f, "Total Investment:  %s (%.1f%%)\n", formatMoney(totalInvest), totalInvest/capital*100)
+	fmt.Fprintf(f, "Total Risk:        %s (%.2f%%)\n", formatMoney(totalRisk), totalRisk/capital*100)
+	fmt.Fprintf(f, "Cash Remaining:    %s (%.1f%%)\n", formatMoney(capital-totalInvest), (capital-totalInvest)/capital*100)
 	fmt.Fprintf(f, "Scan Duration:     %s\n\n", scanTime.Round(time.Second))
 
 	// Quick Reference Table
@@ -1951,9 +2466,9 @@ func saveReport(filename string, signals []strategy.Signal, capital float64, tot
 	fmt.Fprintf(f, "%s\n", strings.Repeat("-", 60))
 	for i, s := range signals {
 		if s.Guide != nil {
-			fmt.Fprintf(f, "%-6d %-10s $%-7.2f %-8.0f %-10s %-10s\n",
-				i+1, s.Stock.Symbol, s.Guide.EntryPrice, s.Guide.PositionSize,
-				formatUSD(s.Guide.InvestAmount), formatUSD(s.Guide.RiskAmount))
+			fmt.Fprintf(f, "%-6d %-10s %-8s %-8.0f %-10s %-10s\n",
+				i+1, s.Stock.Symbol, formatPrice(s.Guide.EntryPrice), s.Guide.PositionSize,
+				formatMoney(s.Guide.InvestAmount), formatMoney(s.Guide.RiskAmount))
 		}
 	}
 	fmt.Fprintf(f, "\n")
@@ -1965,27 +2480,31 @@ func saveReport(filename string, signals []strategy.Signal, capital float64, tot
 	for i, s := range signals {
 		fmt.Fprintf(f, "[%d] %s (%s)\n", i+1, s.Stock.Symbol, s.Stock.Name)
 		fmt.Fprintf(f, "%s\n", strings.Repeat("-", 50))
-		fmt.Fprintf(f, "Signal: %s\n", s.Reason)
+		fmt.Fprintf(f, "Signal: %s\n", s.Explain())
 		fmt.Fprintf(f, "Win Probability: %.0f%%\n\n", s.Probability)
 
 		if s.Guide != nil {
 			g := s.Guide
 			fmt.Fprintf(f, "[ENTRY]\n")
-			fmt.Fprintf(f, "  Buy %.0f shares @ $%.2f = %s\n", g.PositionSize, g.EntryPrice, formatUSD(g.InvestAmount))
+			fmt.Fprintf(f, "  Buy %.0f shares @ %s = %s\n", g.PositionSize, formatPrice(g.EntryPrice), formatMoney(g.InvestAmount))
+			if p := g.Pair; p != nil {
+				fmt.Fprintf(f, "  Short %.0f %s @ %s (hedge %.2f, z %+.2f → exit ±%.1f, stop ±%.1f)\n",
+					p.ShortQuantity, p.ShortSymbol, formatPrice(p.ShortPrice), p.HedgeRatio, p.ZScore, p.ExitZ, p.StopZ)
+			}
 			fmt.Fprintf(f, "  Allocation: %.1f%% of portfolio\n\n", g.AllocationPct)
 
 			fmt.Fprintf(f, "[STOP LOSS]\n")
-			fmt.Fprintf(f, "  Sell @ $%.2f (%.1f%% loss)\n", g.StopLoss, g.StopLossPct)
-			fmt.Fprintf(f, "  Max Loss: %s (%.2f%% of portfolio)\n\n", formatUSD(g.RiskAmount), g.RiskPct)
+			fmt.Fprintf(f, "  Sell @ %s (%.1f%% loss)\n", formatPrice(g.StopLoss), g.StopLossPct)
+			fmt.Fprintf(f, "  Max Loss: %s (%.2f%% of portfolio)\n\n", formatMoney(g.RiskAmount), g.RiskPct)
 
 			fmt.Fprintf(f, "[TAKE PROFIT]\n")
-			fmt.Fprintf(f, "  Target 1: $%.2f (+%.1f%%) - Sell 50%%\n", g.Target1, g.Target1Pct)
-			fmt.Fprintf(f, "  Target 2: $%.2f (+%.1f%%) - Sell remaining\n\n", g.Target2, g.Target2Pct)
+			fmt.Fprintf(f, "  Target 1: %s (+%.1f%%) - Sell 50%%\n", formatPrice(g.Target1), g.Target1Pct)
+			fmt.Fprintf(f, "  Target 2: %s (+%.1f%%) - Sell remaining\n\n", formatPrice(g.Target2), g.Target2Pct)
 		}
 
 		fmt.Fprintf(f, "[TECHNICALS]\n")
-		fmt.Fprintf(f, "  Close: $%.2f | MA20: $%.2f | MA50: $%.2f\n",
-			s.Details["close"], s.Details["ma20"], s.Details["ma50"])
+		fmt.Fprintf(f, "  Close: %s | MA20: %s | MA50: %s\n",
+			formatPrice(s.Details["close"]), formatPrice(s.Details["ma20"]), formatPrice(s.Details["ma50"]))
 		if rsi, ok := s.Details["rsi14"]; ok && rsi > 0 {
 			fmt.Fprintf(f, "  RSI(14): %.1f | Volume: %.1fx avg\n", rsi, s.Details["volume_ratio"])
 		}
@@ -2020,6 +2539,7 @@ func saveJSONReport(filename string, signals []strategy.Signal, capital float64,
 		TotalInvest:  totalInvest,
 		TotalRisk:    totalRisk,
 		GeneratedAt:  time.Now().Format(time.RFC3339),
+		Issues:       scanIssues,
 	}
 
 	f, err := os.Create(filename)
@@ -2033,13 +2553,117 @@ func saveJSONReport(filename string, signals []strategy.Signal, capital float64,
 	return encoder.Encode(result)
 }
 
-func formatUSD(amount float64) string {
-	if amount >= 1000000 {
-		return fmt.Sprintf("$%.2fM", amount/1000000)
-	} else if amount >= 1000 {
-		return fmt.Sprintf("$%.1fK", amount/1000)
+// newWebhooks config.webhooks → notifier (설정 오류는 경고만, 매매는 계속)
+func newWebhooks(cfg *config.Config) *notify.WebhookNotifier {
+	targets := make([]notify.WebhookTarget, 0, len(cfg.Webhooks))
+	for _, w := range cfg.Webhooks {
+		targets = append(targets, notify.WebhookTarget{URL: w.URL, Secret: w.Secret, Events: w.Events})
+	}
+	n, err := notify.NewWebhookNotifier(targets)
+	if err != nil {
+		log.Printf("[WEBHOOK] Disabled: %v", err)
+		return nil
+	}
+	if n != nil {
+		log.Printf("[WEBHOOK] %d webhook target(s) configured", len(targets))
+	}
+	return n
+}
+
+// orderThrottle config.trader.throttle → 자동매매 주문 속도 제한
+func orderThrottle(cfg *config.Config) trader.ThrottleConfig {
+	t := cfg.Trader.Throttle
+	return trader.ThrottleConfig{
+		MinInterval:  time.Duration(t.MinIntervalMs) * time.Millisecond,
+		Jitter:       time.Duration(t.JitterMs) * time.Millisecond,
+		MaxPerMinute: t.MaxPerMinute,
+		MaxRetries:   t.MaxRetries,
+		RetryBackoff: trader.DefaultThrottleConfig().RetryBackoff,
+	}
+}
+
+// eodClosePolicy config.trader.eod_close → 전략별 마감 전 청산 (모니터와 백테스트 공용)
+func eodClosePolicy(cfg *config.Config) trader.EODPolicy {
+	return trader.DefaultEODPolicy().WithOverrides(cfg.Trader.EODClose)
+}
+
+// trancheExit config.trader.tranches → 3분할 익절 (0인 값은 기본값, 잘못된 목표/비율은 에러)
+func trancheExit(cfg *config.Config) (strategy.TrancheExit, error) {
+	tr := cfg.Trader.Tranches
+	t, err := strategy.TrancheExit{
+		Enabled: tr.Enabled, T1R: tr.T1R, T2R: tr.T2R, T3R: tr.T3R,
+		T1Fraction: tr.T1Fraction, T2Fraction: tr.T2Fraction, TrailATR: tr.TrailATR,
+	}.Normalized()
+	if err != nil {
+		return strategy.TrancheExit{}, fmt.Errorf("config trader.tranches: %w", err)
+	}
+	return t, nil
+}
+
+// withTranches 레지스트리 전략에 config 3분할 익절 적용 (설정은 run()에서 검증됨)
+func withTranches(cfg *config.Config, p provider.Provider, strats ...strategy.Strategy) []strategy.Strategy {
+	t, _ := trancheExit(cfg)
+	for i, s := range strats {
+		strats[i] = strategy.WithTrancheExit(s, t, p)
+	}
+	return strats
+}
+
+// newKISClient KIS 해외주식 클라이언트 (config.kis 인증 + 원화 환전 헤어컷)
+func newKISClient(cfg *config.Config) *kis.Client {
+	c := kis.NewClient(kis.Credentials{AppKey: cfg.KIS.AppKey, AppSecret: cfg.KIS.AppSecret, AccountNo: cfg.KIS.AccountNo})
+	if cfg.KIS.FXHaircutPct != 0 {
+		c.SetFXHaircut(cfg.KIS.FXHaircutPct / 100)
+	}
+	if err := c.SetExchanges(cfg.KIS.Exchanges); err != nil {
+		log.Printf("[KIS] config kis.exchanges: %v", err)
+	}
+	return c
+}
+
+// usBrokerConfigured US 주식 브로커 설정 여부 (IBKR 또는 KIS 해외)
+func usBrokerConfigured(cfg *config.Config) bool {
+	return cfg.IBKR.Enabled || cfg.KIS.AppKey != ""
+}
+
+// newUSBroker US 주식 브로커 (ibkr.enabled면 IBKR Client Portal Gateway, 아니면 KIS 해외)
+func newUSBroker(cfg *config.Config) broker.Broker {
+	if cfg.IBKR.Enabled {
+		return ibkr.NewClient(ibkr.Config{
+			GatewayURL:  cfg.IBKR.GatewayURL,
+			AccountID:   cfg.IBKR.AccountID,
+			InsecureTLS: cfg.IBKR.InsecureTLS,
+
+			ConfirmWarnings: cfg.IBKR.ConfirmWarnings,
+		})
+	}
+	return newKISClient(cfg)
+}
+
+// orderCaps config.trader.caps → 주문/종목 최대 금액
+func orderCaps(cfg *config.Config) trader.ValueCaps {
+	c := cfg.Trader.Caps
+	return trader.ValueCaps{
+		MaxOrderUSD:  c.MaxOrderUSD,
+		MaxSymbolUSD: c.MaxSymbolUSD,
+		MaxOrderKRW:  c.MaxOrderKRW,
+		MaxSymbolKRW: c.MaxSymbolKRW,
+		MaxOrderJPY:  c.MaxOrderJPY,
+		MaxSymbolJPY: c.MaxSymbolJPY,
+		MaxOrderHKD:  c.MaxOrderHKD,
+		MaxSymbolHKD: c.MaxSymbolHKD,
+		MaxOrderCNY:  c.MaxOrderCNY,
+		MaxSymbolCNY: c.MaxSymbolCNY,
+	}
+}
+
+// priceCheck config.trader.price_check → 주문 직전 시세 확인
+func priceCheck(cfg *config.Config) trader.PriceCheckConfig {
+	c := cfg.Trader.PriceCheck
+	return trader.PriceCheckConfig{
+		MaxDeviation: c.MaxDeviationPct / 100,
+		MaxQuoteAge:  time.Duration(c.MaxQuoteAgeSec) * time.Second,
 	}
-	return fmt.Sprintf("$%.2f", amount)
 }
 
 func createProviders(cfg *config.Config) []provider.Provider {
@@ -2074,15 +2698,22 @@ func outputTable(result *model.ScanResult, minDays int) error {
 		// First by recommendation strength
 		recOrder := map[string]int{"strong": 4, "moderate": 3, "weak": 2, "avoid": 1, "": 0}
 		ri, rj := "", ""
+		var si, sj float64
 		if results[i].Technical != nil {
 			ri = results[i].Technical.Recommendation
+			si = results[i].Technical.Score
 		}
 		if results[j].Technical != nil {
 			rj = results[j].Technical.Recommendation
+			sj = results[j].Technical.Score
 		}
 		if recOrder[ri] != recOrder[rj] {
 			return recOrder[ri] > recOrder[rj]
 		}
+		// Then by composite score (same scale as strategy signals)
+		if si != sj {
+			return si > sj
+		}
 		// Then by consecutive days
 		if results[i].ConsecutiveDays != results[j].ConsecutiveDays {
 			return results[i].ConsecutiveDays > results[j].ConsecutiveDays
@@ -2094,7 +2725,7 @@ func outputTable(result *model.ScanResult, minDays int) error {
 
 	// Main table
 	table := tablewriter.NewTable(os.Stdout,
-		tablewriter.WithHeader([]string{"Symbol", "Name", "Days", "Avg Dip", "Avg Rise", "Prob", "Signal"}),
+		tablewriter.WithHeader([]string{"Symbol", "Name", "Days", "Avg Dip", "Avg Rise", "Prob", "Score", "Signal"}),
 	)
 
 	for _, r := range results {
@@ -2104,9 +2735,11 @@ func outputTable(result *model.ScanResult, minDays int) error {
 		}
 
 		prob := "-"
+		score := "-"
 		signal := "-"
 		if r.Technical != nil {
 			prob = fmt.Sprintf("%.0f%%", r.Technical.ContinuationProb)
+			score = fmt.Sprintf("%.0f", r.Technical.Score)
 			signal = r.Technical.Recommendation
 		}
 
@@ -2117,6 +2750,7 @@ func outputTable(result *model.ScanResult, minDays int) error {
 			fmt.Sprintf("%.1f%%", r.AvgMorningDipPct),
 			fmt.Sprintf("+%.1f%%", r.AvgCloseRisePct),
 			prob,
+			score,
 			signal,
 		})
 	}
@@ -2146,8 +2780,8 @@ func outputTable(result *model.ScanResult, minDays int) error {
 		}
 		fmt.Printf("  Trend: %s (MA5: %+.1f%%, MA20: %+.1f%%)\n",
 			r.Technical.TrendSignal, r.Technical.PriceVsMA5, r.Technical.PriceVsMA20)
-		fmt.Printf("  >> Continuation Probability: %.0f%% [%s]\n",
-			r.Technical.ContinuationProb, strings.ToUpper(r.Technical.Recommendation))
+		fmt.Printf("  >> Continuation Probability: %.0f%% [%s] | Score: %.0f/100\n",
+			r.Technical.ContinuationProb, strings.ToUpper(r.Technical.Recommendation), r.Technical.Score)
 
 		count++
 	}
@@ -2163,12 +2797,19 @@ func outputJSON(result *model.ScanResult) error {
 }
 
 func executeAutoTrade(ctx context.Context, signals []strategy.Signal, cfg *config.Config) error {
-	// Check KIS config
-	if cfg.KIS.AppKey == "" || cfg.KIS.AppSecret == "" {
-		return fmt.Errorf("KIS API credentials not configured. Set KIS_APP_KEY, KIS_APP_SECRET, KIS_ACCOUNT_NO environment variables or add to config.yaml")
+	if scanMarket == "crypto" {
+		cryptoAutoTradeNotice(len(signals))
+		return nil
 	}
-	if cfg.KIS.AccountNo == "" {
-		return fmt.Errorf("KIS account number not configured")
+
+	// Check broker config (IBKR은 게이트웨이 세션으로 인증)
+	if !cfg.IBKR.Enabled {
+		if cfg.KIS.AppKey == "" || cfg.KIS.AppSecret == "" {
+			return fmt.Errorf("KIS API credentials not configured. Set KIS_APP_KEY, KIS_APP_SECRET, KIS_ACCOUNT_NO environment variables or add to config.yaml")
+		}
+		if cfg.KIS.AccountNo == "" {
+			return fmt.Errorf("KIS account number not configured")
+		}
 	}
 
 	fmt.Println()
@@ -2195,17 +2836,13 @@ func executeAutoTrade(ctx context.Context, signals []strategy.Signal, cfg *confi
 		}
 	}
 
-	// Create KIS client
-	creds := kis.Credentials{
-		AppKey:    cfg.KIS.AppKey,
-		AppSecret: cfg.KIS.AppSecret,
-		AccountNo: cfg.KIS.AccountNo,
-	}
-	kisBroker := kis.NewClient(creds)
+	// Create US broker (KIS or IBKR)
+	kisBroker := newUSBroker(cfg)
+	brokerName := strings.ToUpper(kisBroker.Name())
 
-	fmt.Println("\nConnecting to KIS API...")
+	fmt.Printf("\nConnecting to %s API...\n", brokerName)
 	if !kisBroker.IsReady() {
-		return fmt.Errorf("failed to connect to KIS API - check your credentials")
+		return fmt.Errorf("failed to connect to %s API - check your credentials", brokerName)
 	}
 	fmt.Println("Connected successfully!")
 
@@ -2214,15 +2851,15 @@ func executeAutoTrade(ctx context.Context, signals []strategy.Signal, cfg *confi
 	balance, err := kisBroker.GetBalance(ctx)
 	if err != nil {
 		fmt.Printf("Warning: failed to get balance: %v\n", err)
-		fmt.Printf("Using CLI capital: %s\n", formatUSD(accountBalance))
+		fmt.Printf("Using CLI capital: %s\n", formatMoney(accountBalance))
 	} else {
 		// 실제 잔고 사용
 		actualCapital := balance.TotalEquity
 		if actualCapital > 0 {
-			fmt.Printf("Account Balance: %s\n", formatUSD(actualCapital))
+			fmt.Printf("Account Balance: %s\n", formatMoney(actualCapital))
 			accountBalance = actualCapital
 		} else {
-			fmt.Printf("Using CLI capital: %s\n", formatUSD(accountBalance))
+			fmt.Printf("Using CLI capital: %s\n", formatMoney(accountBalance))
 		}
 
 		// 현재 보유 포지션 표시
@@ -2247,9 +2884,16 @@ func executeAutoTrade(ctx context.Context, signals []strategy.Signal, cfg *confi
 		TotalCapital:    accountBalance,
 		RiskPerTrade:    cfg.Trader.RiskPerTrade,
 		MonitorInterval: time.Duration(cfg.Trader.MonitorInterval) * time.Second,
+		Throttle:        orderThrottle(cfg),
+		EODClose:        eodClosePolicy(cfg),
+		Caps:            orderCaps(cfg),
+		PriceCheck:      priceCheck(cfg),
 	}
 
 	autoTrader := trader.NewAutoTrader(traderCfg, kisBroker, marketOrder)
+	if !dryRun {
+		autoTrader.SetOrderJournal(trader.NewOrderJournal(resolveDataDir(), "us"))
+	}
 
 	// Execute signals
 	fmt.Printf("\nExecuting %d signals...\n", len(signals))
@@ -2271,8 +2915,8 @@ func executeAutoTrade(ctx context.Context, signals []strategy.Signal, cfg *confi
 			status = "OK"
 			successCount++
 		}
-		fmt.Printf(" [%s] %s: %s %.0f @ $%.2f",
-			status, r.Signal.Stock.Symbol, r.Order.Side, r.Order.Quantity, r.Order.LimitPrice)
+		fmt.Printf(" [%s] %s: %s %.0f @ %s",
+			status, r.Signal.Stock.Symbol, r.Order.Side, r.Order.Quantity, money.Price(r.Order.LimitPrice, money.ForSymbol(r.Signal.Stock.Symbol)))
 		if r.Result != nil {
 			fmt.Printf(" (Order: %s)", r.Result.OrderID)
 		}
@@ -2324,16 +2968,11 @@ func runMonitorMode(cfg *config.Config) error {
 		cancel()
 	}()
 
-	// Create KIS client
-	creds := kis.Credentials{
-		AppKey:    cfg.KIS.AppKey,
-		AppSecret: cfg.KIS.AppSecret,
-		AccountNo: cfg.KIS.AccountNo,
-	}
-	broker := kis.NewClient(creds)
+	// Create US broker (KIS or IBKR)
+	broker := newUSBroker(cfg)
 
 	if !broker.IsReady() {
-		return fmt.Errorf("failed to connect to KIS API")
+		return fmt.Errorf("failed to connect to %s API", strings.ToUpper(broker.Name()))
 	}
 
 	// Show current positions
@@ -2353,8 +2992,9 @@ func runMonitorMode(cfg *config.Config) error {
 			if p.UnrealizedPnL < 0 {
 				pnlSign = ""
 			}
-			fmt.Printf("  %s: %.0f shares @ $%.2f (P&L: %s$%.2f / %s%.1f%%)\n",
-				p.Symbol, p.Quantity, p.AvgCost, pnlSign, p.UnrealizedPnL, pnlSign, p.UnrealizedPct)
+			cur := money.ForSymbol(p.Symbol)
+			fmt.Printf("  %s: %.0f shares @ %s (P&L: %s / %s%.1f%%)\n",
+				p.Symbol, p.Quantity, money.Price(p.AvgCost, cur), money.Signed(p.UnrealizedPnL, cur), pnlSign, p.UnrealizedPct)
 		}
 	}
 	fmt.Println(strings.Repeat("-", 60))
@@ -2365,6 +3005,10 @@ func runMonitorMode(cfg *config.Config) error {
 		MaxPositions:    cfg.Trader.MaxPositions,
 		TotalCapital:    accountBalance,
 		MonitorInterval: time.Duration(cfg.Trader.MonitorInterval) * time.Second,
+		Throttle:        orderThrottle(cfg),
+		EODClose:        eodClosePolicy(cfg),
+		Caps:            orderCaps(cfg),
+		PriceCheck:      priceCheck(cfg),
 	}
 
 	autoTrader := trader.NewAutoTrader(traderCfg, broker, false)
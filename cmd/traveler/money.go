@@ -0,0 +1,36 @@
+package main
+
+import (
+	"traveler/internal/money"
+	"traveler/pkg/model"
+)
+
+// displayCurrency CLI 금액 표시 통화 (--market, 스캔 종목으로 결정)
+var displayCurrency = money.USD
+
+// formatMoney 요약 금액 ($12.3K, ₩1,234,567)
+func formatMoney(amount float64) string {
+	return money.Compact(amount, displayCurrency)
+}
+
+// formatPrice 단가 ($12.34, ₩12,340)
+func formatPrice(price float64) string {
+	return money.Price(price, displayCurrency)
+}
+
+// setDisplayCurrency 종목 구성으로 표시 통화 결정 (--market kr/crypto, 또는 과반을 차지하는 비USD 통화: 한국 종목·원화 코인 → KRW, 도쿄 → JPY, 홍콩 → HKD)
+func setDisplayCurrency(stocks []model.Stock) {
+	displayCurrency = money.ForMarket(marketFlag)
+	if displayCurrency != money.USD || len(stocks) == 0 {
+		return
+	}
+	counts := make(map[money.Currency]int)
+	for _, s := range stocks {
+		counts[money.ForSymbol(s.Symbol)]++
+	}
+	for c, n := range counts {
+		if c != money.USD && n*2 > len(stocks) {
+			displayCurrency = c
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"traveler/internal/strategy"
+)
+
+// --format ndjson: 시그널을 찾는 즉시 stdout에 한 줄씩, 스캔이 끝나면 summary 한 줄.
+// 배너와 진행 표시줄은 stderr로 보내 stdout은 JSON 줄만 남긴다.
+
+// ndjsonSignal 발견 즉시 내보내는 줄 (필터·top-N·사이징 전, guide 수량은 0)
+type ndjsonSignal struct {
+	Event string `json:"event"` // "signal"
+	*strategy.Signal
+}
+
+// ndjsonPick summary의 최종 후보 (필터·top-N·사이징 후)
+type ndjsonPick struct {
+	Symbol      string  `json:"symbol"`
+	Strategy    string  `json:"strategy"`
+	Probability float64 `json:"probability"`
+	Shares      float64 `json:"shares"`
+	Entry       float64 `json:"entry"`
+	StopLoss    float64 `json:"stop_loss"`
+	Target1     float64 `json:"target_1"`
+	Invest      float64 `json:"invest"`
+}
+
+// ndjsonSummary 마지막 줄
+type ndjsonSummary struct {
+	Event        string               `json:"event"` // "summary"
+	TotalScanned int                  `json:"total_scanned"`
+	SignalsFound int                  `json:"signals_found"` // 최종 후보 수
+	Selected     []ndjsonPick         `json:"selected"`
+	ScanTime     string               `json:"scan_time"`
+	Capital      float64              `json:"capital"`
+	GeneratedAt  string               `json:"generated_at"`
+	Issues       *strategy.ScanIssues `json:"issues,omitempty"`
+}
+
+var ndjsonEncoder = json.NewEncoder(os.Stdout)
+
+// streamSignals --format ndjson이면 true
+func streamSignals() bool {
+	return format == "ndjson"
+}
+
+// emitSignal 스캔 루프에서 시그널을 찾자마자 한 줄로 내보낸다
+func emitSignal(sig *strategy.Signal) {
+	if !streamSignals() || sig == nil {
+		return
+	}
+	if sig.ID == "" {
+		sig.ID = strategy.SignalID(sig.Stock.Symbol, sig.Strategy, strategy.SignalDate(sig, time.Now()))
+	}
+	ndjsonEncoder.Encode(ndjsonSignal{Event: "signal", Signal: sig})
+}
+
+// outputSignalsNDJSON 스캔 끝 summary 줄 (시그널 줄은 emitSignal이 이미 냈다)
+func outputSignalsNDJSON(signals []strategy.Signal, totalScanned int, scanTime time.Duration) error {
+	scanSignalCount = len(signals)
+	picks := make([]ndjsonPick, 0, len(signals))
+	for _, s := range signals {
+		p := ndjsonPick{Symbol: s.Stock.Symbol, Strategy: s.Strategy, Probability: s.Probability}
+		if g := s.Guide; g != nil {
+			p.Shares, p.Entry, p.StopLoss, p.Target1, p.Invest = g.PositionSize, g.EntryPrice, g.StopLoss, g.Target1, g.InvestAmount
+		}
+		picks = append(picks, p)
+	}
+	return ndjsonEncoder.Encode(ndjsonSummary{
+		Event:        "summary",
+		TotalScanned: totalScanned,
+		SignalsFound: len(signals),
+		Selected:     picks,
+		ScanTime:     scanTime.String(),
+		Capital:      accountBalance,
+		GeneratedAt:  time.Now().Format(time.RFC3339),
+		Issues:       scanIssues,
+	})
+}
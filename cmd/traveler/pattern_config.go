@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"traveler/internal/config"
+	"traveler/internal/strategy"
+)
+
+// patternConfigFile --pattern-config: 이번 실행에만 쓰는 패턴/전략 파라미터 JSON
+//
+//	{
+//	  "pattern":    {"consecutive_days": 2, "morning_drop_threshold": -1.5, "morning_window": 60},
+//	  "strategies": {"pullback": {"MinVolumeRatio": 1.0, "MaxRSI": 55}, "breakout": {"HighPeriod": 55}}
+//	}
+//
+// 적은 값만 config.yaml(패턴)과 전략 기본값 위에 덮어쓰고, 알 수 없는 키는 에러로 멈춘다.
+type patternConfigFile struct {
+	Pattern    json.RawMessage            `json:"pattern"`
+	Strategies map[string]json.RawMessage `json:"strategies"`
+}
+
+// applyPatternConfig --pattern-config 파일을 cfg.Pattern과 전략 레지스트리에 적용
+func applyPatternConfig(path string, cfg *config.Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var f patternConfigFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if len(f.Pattern) > 0 {
+		pattern := cfg.Pattern
+		dec := json.NewDecoder(bytes.NewReader(f.Pattern))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&pattern); err != nil {
+			return fmt.Errorf("pattern: %w", err)
+		}
+		cfg.Pattern = pattern
+	}
+	return strategy.OverrideConfigs(f.Strategies)
+}
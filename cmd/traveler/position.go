@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/broker"
+	"traveler/internal/config"
+	"traveler/internal/money"
+	"traveler/internal/provider"
+	"traveler/internal/trader"
+	"traveler/pkg/model"
+)
+
+// newPositionCmd `traveler position` 외부에서 매수한 포지션의 플랜 관리
+func newPositionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "position",
+		Short: "Register positions bought outside traveler for monitoring",
+		Long: `Create position plans (stop loss, targets, max hold days) for shares bought
+outside traveler. Plans are stored in plans.json; running daemons pick them up on
+their next monitor cycle and from then on manage stops, targets and time exits,
+and include the position in risk and rebalance calculations.`,
+		Example: `  traveler position add AAPL --qty 10 --entry 182.5 --stop 175
+  traveler position list
+  traveler position rm AAPL`,
+	}
+	cmd.AddCommand(newPositionAddCmd(), newPositionListCmd(), newPositionRemoveCmd())
+	return cmd
+}
+
+func newPositionAddCmd() *cobra.Command {
+	var (
+		market   string
+		qty      float64
+		entry    float64
+		strat    string
+		stop     float64
+		target1  float64
+		target2  float64
+		date     string
+		noBroker bool
+	)
+	cmd := &cobra.Command{
+		Use:   "add SYMBOL",
+		Short: "Create a monitored plan for an existing position",
+		Example: `  traveler position add AAPL --strategy pullback
+  traveler position add NVDA --qty 10 --entry 118.5 --stop 112 --target1 126
+  traveler position add 005930 --market kr --date 2026-09-01`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			symbol := strings.ToUpper(strings.TrimSpace(args[0]))
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			ctx := context.Background()
+
+			var b broker.Broker
+			var p provider.Provider
+			if !noBroker {
+				if b, p, err = connectKISMarket(cfg, market); err != nil {
+					fmt.Printf("Broker unavailable (%v) — using --qty/--entry as given\n", err)
+				}
+			}
+			if b != nil {
+				pos, err := findPosition(ctx, b, symbol)
+				if err != nil {
+					return err
+				}
+				switch {
+				case pos == nil:
+					fmt.Printf("Warning: %s is not held in the %s account — the daemon registers it once it is\n", symbol, market)
+				default:
+					if qty == 0 {
+						qty = pos.Quantity
+					}
+					if entry == 0 {
+						entry = pos.AvgCost
+					}
+				}
+			}
+			if qty <= 0 || entry <= 0 {
+				return fmt.Errorf("--qty and --entry are required when the broker position is unavailable")
+			}
+			if p == nil && market == "us" {
+				p = provider.NewFallbackProvider(createProviders(cfg)...)
+			}
+
+			var candles []model.Candle
+			if p != nil && (stop == 0 || target1 == 0 || strat == "") {
+				if candles, err = p.GetDailyCandles(ctx, symbol, 50); err != nil {
+					fmt.Printf("Candles unavailable (%v) — using -2%%/+2%%/+4%% fallback levels\n", err)
+				}
+			}
+
+			mp := trader.ManualPosition{
+				Symbol:     symbol,
+				Quantity:   qty,
+				EntryPrice: entry,
+				Strategy:   strat,
+				StopLoss:   stop,
+				Target1:    target1,
+				Target2:    target2,
+			}
+			if mp.Tranches, err = trancheExit(cfg); err != nil {
+				return err
+			}
+			if date != "" {
+				if mp.EntryTime, err = time.ParseInLocation("2006-01-02", date, time.Local); err != nil {
+					return fmt.Errorf("invalid --date %q (use YYYY-MM-DD)", date)
+				}
+			}
+			plan, err := trader.BuildManualPlan(mp, candles)
+			if err != nil {
+				return err
+			}
+
+			ps, err := trader.NewPlanStore(resolveDataDir())
+			if err != nil {
+				return fmt.Errorf("open plan store: %w", err)
+			}
+			if old := ps.Get(symbol); old != nil {
+				fmt.Printf("Replacing existing %s plan for %s\n", old.Strategy, symbol)
+			}
+			if err := ps.Save(plan); err != nil {
+				return fmt.Errorf("save plan: %w", err)
+			}
+
+			cur := money.ForSymbol(symbol)
+			fmt.Printf("\n%s (%s) %g @ %s\n", plan.Symbol, plan.Strategy, plan.Quantity, money.Price(plan.EntryPrice, cur))
+			fmt.Printf("  Stop:    %s (%.1f%%)\n", money.Price(plan.StopLoss, cur), (plan.StopLoss/plan.EntryPrice-1)*100)
+			fmt.Printf("  Target1: %s (%+.1f%%)\n", money.Price(plan.Target1, cur), (plan.Target1/plan.EntryPrice-1)*100)
+			fmt.Printf("  Target2: %s (%+.1f%%)\n", money.Price(plan.Target2, cur), (plan.Target2/plan.EntryPrice-1)*100)
+			fmt.Printf("  Max hold: %d trading days from %s\n", plan.MaxHoldDays, plan.EntryTime.Format("2006-01-02"))
+			fmt.Println("\nPlan saved — running daemons start monitoring it on their next cycle.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr")
+	cmd.Flags().Float64Var(&qty, "qty", 0, "quantity (default: broker position)")
+	cmd.Flags().Float64Var(&entry, "entry", 0, "entry price (default: broker average cost)")
+	cmd.Flags().StringVar(&strat, "strategy", "", "strategy: pullback, breakout, mean-reversion (default: inferred from chart)")
+	cmd.Flags().Float64Var(&stop, "stop", 0, "stop loss (default: from strategy)")
+	cmd.Flags().Float64Var(&target1, "target1", 0, "first target (default: from strategy)")
+	cmd.Flags().Float64Var(&target2, "target2", 0, "second target (default: from strategy)")
+	cmd.Flags().StringVar(&date, "date", "", "entry date YYYY-MM-DD for max-hold counting (default: today)")
+	cmd.Flags().BoolVar(&noBroker, "offline", false, "don't contact the broker (requires --qty and --entry)")
+	return cmd
+}
+
+func findPosition(ctx context.Context, b broker.Broker, symbol string) (*broker.Position, error) {
+	positions, err := b.GetPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get positions: %w", err)
+	}
+	for i := range positions {
+		if positions[i].Symbol == symbol {
+			return &positions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func newPositionListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List stored position plans",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ps, err := trader.NewPlanStore(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			plans := ps.GetAll()
+			if len(plans) == 0 {
+				fmt.Println("No position plans.")
+				return nil
+			}
+			sort.Slice(plans, func(i, j int) bool { return plans[i].Symbol < plans[j].Symbol })
+			fmt.Printf("%-10s %-16s %10s %12s %12s %12s %12s  %s\n",
+				"Symbol", "Strategy", "Qty", "Entry", "Stop", "T1", "T2", "Source")
+			fmt.Println(strings.Repeat("-", 104))
+			for _, p := range plans {
+				cur := money.ForSymbol(p.Symbol)
+				fmt.Printf("%-10s %-16s %10g %12s %12s %12s %12s  %s\n",
+					p.Symbol, p.Strategy, p.Quantity, money.Price(p.EntryPrice, cur), money.Price(p.StopLoss, cur),
+					money.Price(p.Target1, cur), money.Price(p.Target2, cur), p.Source)
+			}
+			return nil
+		},
+	}
+}
+
+func newPositionRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm SYMBOL",
+		Aliases: []string{"remove"},
+		Short:   "Delete a position plan (shares are not sold)",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			symbol := strings.ToUpper(args[0])
+			ps, err := trader.NewPlanStore(resolveDataDir())
+			if err != nil {
+				return err
+			}
+			if ps.Get(symbol) == nil {
+				return fmt.Errorf("no plan for %s", symbol)
+			}
+			if err := ps.Delete(symbol); err != nil {
+				return err
+			}
+			fmt.Printf("Removed plan for %s (running daemons keep monitoring it until restart)\n", symbol)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/schollz/progressbar/v3"
+
+	"traveler/internal/daemon"
+)
+
+// --quiet 종료 코드 (스캔 결과로 분기하는 cron/스크립트용)
+const (
+	exitNoSignals       = 2 // 스캔은 끝났지만 시그널 없음
+	exitProviderFailure = 3 // 데이터 제공자 없음, 종목 로드 실패, 전 종목 조회 실패
+)
+
+// errProviderFailure 데이터 제공자 장애 (errors.Is로 판별)
+var errProviderFailure = errors.New("data provider failure")
+
+// scanSignalCount 마지막 스캔의 최종 시그널 수 (스캔하지 않은 실행이면 -1)
+var scanSignalCount = -1
+
+// providerFailure 제공자 장애로 표시
+func providerFailure(err error) error {
+	return fmt.Errorf("%w: %v", errProviderFailure, err)
+}
+
+// scanFailure 분석한 종목이 전부 에러면 제공자 장애로 본다 (--quiet은 종료 코드 3, 아니면 경고만).
+// 데이터 부족·티커 제외 같은 개별 에러는 일부 종목이라도 성공하면 정상 스캔이다.
+func scanFailure(failed, total int, lastErr error) error {
+	if total == 0 || failed < total || lastErr == nil {
+		return nil
+	}
+	err := fmt.Errorf("all %d symbols failed (last: %v)", total, lastErr)
+	if !quiet {
+		warnf("Warning: %v\n", err)
+		return nil
+	}
+	return providerFailure(err)
+}
+
+// exitCode 명령 결과 → 프로세스 종료 코드
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, daemon.ErrStalled):
+		return daemon.ExitCodeStalled // 워치독 종료: 외부 감시가 재시작
+	case err != nil && quiet && errors.Is(err, errProviderFailure):
+		return exitProviderFailure
+	case err != nil:
+		return 1
+	case quiet && scanSignalCount == 0:
+		return exitNoSignals
+	}
+	return 0
+}
+
+// infof 배너·진행 메시지 (--quiet이면 생략, --format ndjson이면 stderr)
+func infof(format string, args ...interface{}) {
+	if !quiet {
+		fmt.Fprintf(infoWriter(), format, args...)
+	}
+}
+
+// infoln fmt.Println과 같되 --quiet이면 생략
+func infoln(args ...interface{}) {
+	if !quiet {
+		fmt.Fprintln(infoWriter(), args...)
+	}
+}
+
+// infoWriter ndjson 스트림이면 stdout은 JSON 줄 전용
+func infoWriter() io.Writer {
+	if streamSignals() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// warnf 경고 (--quiet, --format ndjson이면 결과와 섞이지 않게 stderr)
+func warnf(format string, args ...interface{}) {
+	if quiet || streamSignals() {
+		fmt.Fprintf(os.Stderr, format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// newScanBar 스캔 진행 표시줄 (--quiet이면 숨김, --format ndjson이면 stderr). opts로 기본값을 덮는다.
+func newScanBar(total int, desc string, opts ...progressbar.Option) *progressbar.ProgressBar {
+	base := []progressbar.Option{
+		progressbar.OptionSetVisibility(!quiet),
+		progressbar.OptionSetWriter(infoWriter()),
+		progressbar.OptionEnableColorCodes(true),
+		progressbar.OptionShowCount(),
+		progressbar.OptionShowIts(),
+		progressbar.OptionSetWidth(40),
+		progressbar.OptionSetDescription(desc),
+		progressbar.OptionSetTheme(progressbar.Theme{
+			Saucer:        "[green]█[reset]",
+			SaucerHead:    "[green]█[reset]",
+			SaucerPadding: "░",
+			BarStart:      "[",
+			BarEnd:        "]",
+		}),
+	}
+	return progressbar.NewOptions(total, append(base, opts...)...)
+}
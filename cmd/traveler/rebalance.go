@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/broker"
+	"traveler/internal/broker/kis"
+	"traveler/internal/config"
+	"traveler/internal/money"
+	"traveler/internal/provider"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+	"traveler/pkg/model"
+)
+
+// newRebalanceCmd `traveler rebalance` 보유 포지션 점검 및 리밸런싱 제안 (주문 없음)
+func newRebalanceCmd() *cobra.Command {
+	var (
+		market     string
+		targetHeat float64
+		noAdds     bool
+		asJSON     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "Review current broker holdings and suggest trims/adds",
+		Long: `Analyze current broker positions (not new signals):
+  - flags holdings whose strategy thesis is invalidated (stop hit, max hold exceeded,
+    pullback below MA20, failed breakout, mean-reversion recovery failure)
+  - computes portfolio heat (total risk to stops) and concentration
+  - suggests exits/trims/adds to return to the target risk
+
+Suggestions only — no orders are placed.`,
+		Example: `  traveler rebalance
+  traveler rebalance --market kr --no-adds
+  traveler rebalance --target-heat 6 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			b, p, err := connectKISMarket(cfg, market)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			balance, err := b.GetBalance(ctx)
+			if err != nil {
+				return fmt.Errorf("get balance: %w", err)
+			}
+			positions := balance.Positions
+			if len(positions) == 0 {
+				if positions, err = b.GetPositions(ctx); err != nil {
+					return fmt.Errorf("get positions: %w", err)
+				}
+			}
+
+			plans := map[string]*trader.PositionPlan{}
+			if ps, err := trader.NewPlanStore(resolveDataDir()); err == nil {
+				plans = ps.All()
+			}
+
+			candles := make(map[string][]model.Candle, len(positions))
+			for _, pos := range positions {
+				if c, err := p.GetDailyCandles(ctx, pos.Symbol, 40); err == nil {
+					candles[pos.Symbol] = c
+				}
+			}
+
+			sizerCfg := trader.AdjustConfigForBalance(balance.TotalEquity)
+			if market == "kr" {
+				sizerCfg = trader.AdjustConfigForKRBalance(balance.TotalEquity)
+			}
+			rcfg := trader.RebalanceConfigFromSizer(sizerCfg)
+			if targetHeat > 0 {
+				rcfg.TargetHeat = targetHeat / 100
+			}
+			rcfg.AllowAdds = !noAdds
+
+			report := trader.AnalyzeRebalance(positions, balance.CashBalance, plans, candles, rcfg)
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			printRebalanceReport(report, market)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr")
+	cmd.Flags().Float64Var(&targetHeat, "target-heat", 0, "target portfolio heat in % of equity (default: risk per trade × max positions)")
+	cmd.Flags().BoolVar(&noAdds, "no-adds", false, "only suggest exits and trims")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "output JSON")
+	return cmd
+}
+
+// connectKISMarket 마켓별 KIS 브로커와 시세 provider (us: 해외, kr: 국내)
+func connectKISMarket(cfg *config.Config, market string) (broker.Broker, provider.Provider, error) {
+	var b broker.Broker
+	var p provider.Provider
+	switch market {
+	case "us":
+		if !usBrokerConfigured(cfg) {
+			return nil, nil, fmt.Errorf("KIS API credentials not configured")
+		}
+		b = newUSBroker(cfg)
+		p = provider.NewFallbackProvider(createProviders(cfg)...)
+	case "kr":
+		if cfg.KIS.Domestic.AppKey == "" {
+			return nil, nil, fmt.Errorf("KIS domestic credentials not configured")
+		}
+		krCreds := kis.Credentials{AppKey: cfg.KIS.Domestic.AppKey, AppSecret: cfg.KIS.Domestic.AppSecret, AccountNo: cfg.KIS.Domestic.AccountNo}
+		b = kis.NewDomesticClient(krCreds)
+		p = provider.NewKISProvider(krCreds)
+	default:
+		return nil, nil, fmt.Errorf("unsupported market: %s (use us or kr)", market)
+	}
+	if !b.IsReady() {
+		return nil, nil, fmt.Errorf("failed to connect to broker")
+	}
+	return b, p, nil
+}
+
+func printRebalanceReport(r *trader.RebalanceReport, market string) {
+	cur := money.ForMarket(market)
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("=", 78))
+	fmt.Println(" PORTFOLIO REBALANCE REVIEW")
+	fmt.Println(strings.Repeat("=", 78))
+	fmt.Printf("  Equity: %s   Cash: %s\n", money.Format(r.Equity, cur), money.Format(r.Cash, cur))
+	fmt.Printf("  Heat:   %.2f%% → %.2f%% (target %.2f%%)\n", r.Heat*100, r.HeatAfter*100, r.TargetHeat*100)
+	if r.MaxWeightSym != "" {
+		fmt.Printf("  Concentration: HHI %.2f, largest %s %.1f%%\n", r.Concentration, r.MaxWeightSym, r.MaxWeight*100)
+	}
+	fmt.Println(strings.Repeat("-", 78))
+	fmt.Printf("  %-10s %-16s %8s %7s %7s  %-6s %8s  %s\n", "Symbol", "Strategy", "Qty", "Weight", "Risk", "Action", "Δ Qty", "Reason")
+	for _, h := range r.Holdings {
+		name := h.Symbol
+		if symbols.IsKoreanSymbol(h.Symbol) {
+			name = symbols.GetKRSymbolName(h.Symbol)
+		}
+		fmt.Printf("  %-10s %-16s %8.0f %6.1f%% %6.2f%%  %-6s %+8.0f  %s\n",
+			name, h.Strategy, h.Quantity, h.Weight*100, h.RiskPct*100, strings.ToUpper(h.Action), h.DeltaQty, h.Reason)
+		for _, f := range h.Flags {
+			fmt.Printf("  %10s ! %s\n", "", f)
+		}
+	}
+	fmt.Println(strings.Repeat("=", 78))
+	fmt.Println("  Suggestions only — no orders were placed.")
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/broker"
+	"traveler/internal/broker/upbit"
+	"traveler/internal/config"
+	"traveler/internal/trader"
+)
+
+// newReconcileCmd `traveler reconcile` 브로커 ↔ 플랜 ↔ 주문 저널 대조 및 확인
+func newReconcileCmd() *cobra.Command {
+	var (
+		market string
+		asJSON bool
+	)
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare broker positions and orders against plans and the order journal",
+		Long: `Compare live broker positions and pending orders against plans.json and the
+order intent journal, and report unknown positions, orphaned plans, quantity
+mismatches and orders whose outcome is unknown.
+
+Daemons run the same check at startup. When it finds issues they keep monitoring
+stops and targets but pause new entries until the report is acknowledged with
+'traveler reconcile ack' (or POST /api/reconcile/ack).`,
+		Example: `  traveler reconcile --market us
+  traveler reconcile --market kr --json
+  traveler reconcile ack --market kr`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			b, err := connectReconcileBroker(cfg, market)
+			if err != nil {
+				return err
+			}
+			dir := resolveDataDir()
+			ps, err := trader.NewPlanStore(dir)
+			if err != nil {
+				return fmt.Errorf("open plan store: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+			report, err := trader.Reconcile(ctx, b, ps, trader.NewOrderJournal(dir, market), market)
+			if err != nil {
+				return err
+			}
+			if err := trader.NewReconcileStore(dir, market).Save(report); err != nil {
+				return fmt.Errorf("save report: %w", err)
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			printReconcileReport(report)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&market, "market", "us", "market: us, kr or crypto")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "output JSON")
+	cmd.AddCommand(newReconcileAckCmd())
+	return cmd
+}
+
+func newReconcileAckCmd() *cobra.Command {
+	var market string
+	cmd := &cobra.Command{
+		Use:   "ack",
+		Short: "Acknowledge the latest reconciliation report and resume new entries",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			report, err := trader.NewReconcileStore(resolveDataDir(), market).Acknowledge()
+			if os.IsNotExist(err) {
+				return fmt.Errorf("no reconciliation report for %s (run 'traveler reconcile --market %s')", market, market)
+			}
+			if err != nil {
+				return err
+			}
+			printReconcileReport(report)
+			fmt.Println("\nAcknowledged — running daemons resume new entries on their next monitor cycle.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&market, "market", "us", "market: us, kr or crypto")
+	return cmd
+}
+
+func connectReconcileBroker(cfg *config.Config, market string) (broker.Broker, error) {
+	if market != "crypto" {
+		b, _, err := connectKISMarket(cfg, market)
+		return b, err
+	}
+	b := upbit.NewClient()
+	if !b.IsReady() {
+		return nil, fmt.Errorf("Upbit API credentials not configured")
+	}
+	return b, nil
+}
+
+func printReconcileReport(r *trader.ReconcileReport) {
+	fmt.Println()
+	fmt.Printf("Reconciliation (%s) at %s\n", strings.ToUpper(r.Market), r.Time.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Broker positions: %d   Pending orders: %d   Plans: %d\n", r.BrokerPositions, r.PendingOrders, r.Plans)
+	if len(r.Issues) == 0 {
+		fmt.Println("  No issues.")
+		return
+	}
+	fmt.Println(strings.Repeat("-", 78))
+	for _, is := range r.Issues {
+		fmt.Printf("  %-18s %-10s %s\n", is.Kind, is.Symbol, is.Detail)
+	}
+	fmt.Println(strings.Repeat("-", 78))
+	if r.Acknowledged {
+		fmt.Printf("  Acknowledged at %s\n", r.AckedAt.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("  Not acknowledged — daemons pause new entries (traveler reconcile ack --market %s)\n", r.Market)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultReportsRetentionDays 스캔 리포트 보관 기간 기본값 (reports_retention_days = 0)
+const defaultReportsRetentionDays = 30
+
+var (
+	reportsDir           string // --reports-dir, 비우면 config reports_dir, 그것도 비우면 <data-dir>/reports
+	reportsRetentionDays int    // config reports_retention_days (0 = 기본 30일, 음수 = 삭제 안 함)
+	saveReports          bool   // --save-report 또는 config save_reports: 시그널이 있으면 리포트 저장
+	noReport             bool   // --no-report: config save_reports를 무시하고 저장 안 함
+)
+
+// shouldSaveReport 스캔 리포트 저장 여부 (-o, --save-report, save_reports로 요청했을 때만, --no-report가 우선).
+// 탐색용으로 잠깐 돌린 스캔이 파일을 남기지 않게 한다.
+func shouldSaveReport() bool {
+	return !noReport && (outputFile != "" || saveReports)
+}
+
+// resolveReportsDir 스캔 리포트 루트 (날짜별 하위 폴더가 생긴다)
+func resolveReportsDir() string {
+	if reportsDir == "" {
+		return filepath.Join(resolveDataDir(), "reports")
+	}
+	if reportsDir == "~" || strings.HasPrefix(reportsDir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(reportsDir, "~"))
+		}
+	}
+	return reportsDir
+}
+
+// scanReportPath <reports>/<YYYY-MM-DD>/report_<YYYY-MM-DD_HHMMSS>.<ext> (날짜 폴더 생성)
+func scanReportPath(now time.Time, ext string) (string, error) {
+	dir := filepath.Join(resolveReportsDir(), now.Format("2006-01-02"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("report_%s.%s", now.Format("2006-01-02_150405"), ext)), nil
+}
+
+// pruneScanReports 보관 기간이 지난 날짜 폴더의 리포트 삭제 (지운 폴더 수)
+// reports_dir을 다른 용도 디렉토리로 지정해도 안전하게 report_* 파일만 지우고, 빈 폴더만 없앤다.
+func pruneScanReports(now time.Time) (int, error) {
+	days := reportsRetentionDays
+	if days < 0 {
+		return 0, nil
+	}
+	if days == 0 {
+		days = defaultReportsRetentionDays
+	}
+	root := resolveReportsDir()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	cutoff := now.AddDate(0, 0, -days).Format("2006-01-02")
+	removed := 0
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", e.Name()); err != nil || e.Name() >= cutoff {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		files, _ := filepath.Glob(filepath.Join(dir, "report_*"))
+		for _, f := range files {
+			os.Remove(f)
+		}
+		if os.Remove(dir) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
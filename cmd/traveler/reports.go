@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/daemon"
+	"traveler/internal/money"
+)
+
+// newReportsCmd `traveler reports` 데몬 세션 리포트 (runs/*.json) 조회
+func newReportsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reports",
+		Short: "Browse past daemon session reports",
+		Long: `Each daemon session saves a structured report to <data-dir>/runs/<market>_<date>_<time>.json
+with the scan summary, trades, errors and phase timings. Reports older than
+trader.run_report_retention_days (default 90) are pruned when a session ends.`,
+		Example: `  traveler reports list --market us --limit 10
+  traveler reports show latest`,
+	}
+	cmd.AddCommand(newReportsListCmd())
+	cmd.AddCommand(newReportsShowCmd())
+	return cmd
+}
+
+// newReportsListCmd `traveler reports list`
+func newReportsListCmd() *cobra.Command {
+	var (
+		market  string
+		limit   int
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List daemon session reports (newest first)",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			infos, err := daemon.ListRunReports(resolveDataDir(), market)
+			if err != nil {
+				return err
+			}
+			if limit > 0 && len(infos) > limit {
+				infos = infos[:limit]
+			}
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(infos)
+			}
+			if len(infos) == 0 {
+				fmt.Println("No daemon session reports found.")
+				return nil
+			}
+			fmt.Printf("\n %-28s %-6s %-10s %8s %-16s %7s %7s %6s  %s\n",
+				"ID", "Market", "Date", "Duration", "Reason", "P&L", "Signals", "Trades", "Errors")
+			fmt.Println(" " + strings.Repeat("─", 106))
+			for _, r := range infos {
+				id := r.ID
+				if r.Rehearsal {
+					id += " (R)"
+				}
+				fmt.Printf(" %-28s %-6s %-10s %8s %-16s %+6.2f%% %7d %6d  %d\n",
+					id, strings.ToUpper(r.Market), r.Date, r.EndedAt.Sub(r.StartedAt).Round(time.Minute),
+					r.Reason, r.TotalPnLPct, r.Signals, r.Trades, r.Errors)
+			}
+			fmt.Println("\n (R) = rehearsal. Details: traveler reports show <id>")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "", "only this market: us, kr or crypto (default all)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "show at most this many reports (0 = all)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "print JSON instead of a table")
+	return cmd
+}
+
+// newReportsShowCmd `traveler reports show <id|latest>`
+func newReportsShowCmd() *cobra.Command {
+	var (
+		market  string
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "show <id|latest>",
+		Short: "Show one daemon session report",
+		Example: `  traveler reports show latest --market us
+  traveler reports show us_2026-10-15_092003 --json`,
+		ValidArgsFunction: completeArgs(1, runReportIDs),
+		Args:              cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			dir := resolveDataDir()
+			id := args[0]
+			if id == "latest" {
+				infos, err := daemon.ListRunReports(dir, market)
+				if err != nil {
+					return err
+				}
+				if len(infos) == 0 {
+					return fmt.Errorf("no daemon session reports found")
+				}
+				id = infos[0].ID
+			}
+			rep, err := daemon.LoadRunReport(dir, id)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(rep)
+			}
+			printRunReport(rep)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&market, "market", "", "market for 'latest': us, kr or crypto (default any)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "print the raw JSON report")
+	return cmd
+}
+
+// printRunReport 세션 리포트 텍스트 출력
+func printRunReport(rep *daemon.RunReport) {
+	cur := money.ForMarket(rep.Market)
+	title := fmt.Sprintf("%s SESSION %s", strings.ToUpper(rep.Market), rep.ID)
+	if rep.Rehearsal {
+		title += " (REHEARSAL)"
+	}
+	fmt.Printf("\n%s\n%s\n", title, strings.Repeat("=", 64))
+	fmt.Printf(" Broker:    %s\n", rep.Broker)
+	fmt.Printf(" Started:   %s\n", rep.StartedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf(" Ended:     %s (%s, %s)\n", rep.EndedAt.Format("2006-01-02 15:04:05"),
+		rep.EndedAt.Sub(rep.StartedAt).Round(time.Second), rep.Reason)
+
+	s := rep.Summary
+	fmt.Println("\n P&L")
+	fmt.Printf("  Balance:   %s → %s\n", money.Format(s.StartingBalance, cur), money.Format(s.EndingBalance, cur))
+	fmt.Printf("  Net P&L:   %s (%+.2f%%), realized %s, unrealized %s, fees %s\n",
+		money.Signed(s.TotalPnL, cur), s.TotalPnLPct, money.Signed(s.RealizedPnL, cur),
+		money.Signed(s.UnrealizedPnL, cur), money.Format(s.Commission, cur))
+	fmt.Printf("  Trades:    %d (%dW/%dL)\n", s.Trades, s.Wins, s.Losses)
+
+	if sc := rep.Scan; sc != nil {
+		fmt.Println("\n SCAN")
+		fmt.Printf("  Scanned %d symbols in %.0fs, %d signal(s)", sc.Scanned, sc.Seconds, len(sc.Signals))
+		if sc.Regime != "" {
+			fmt.Printf(", regime %s", sc.Regime)
+		}
+		fmt.Println()
+		if len(sc.Universes) > 0 {
+			fmt.Printf("  Universes: %s\n", strings.Join(sc.Universes, ", "))
+		}
+		if sc.FundamentalsFiltered > 0 || sc.AIFiltered > 0 {
+			fmt.Printf("  Filtered:  %d fundamentals, %d AI\n", sc.FundamentalsFiltered, sc.AIFiltered)
+		}
+		for _, sig := range sc.Signals {
+			fmt.Printf("   - %s\n", sig)
+		}
+	}
+
+	if len(rep.Trades) > 0 {
+		fmt.Println("\n TRADES")
+		for _, t := range rep.Trades {
+			fmt.Printf("  %s %-4s %-10s %8.2f @ %s  %s\n", t.Timestamp.Format("15:04:05"), strings.ToUpper(t.Side),
+				t.Symbol, t.Quantity, money.Price(t.Price, cur), t.Reason)
+		}
+	}
+
+	if len(rep.RoundTrips) > 0 {
+		fmt.Println("\n ROUND TRIPS")
+		for _, rt := range rep.RoundTrips {
+			r := "    -"
+			if rt.HasR {
+				r = fmt.Sprintf("%+5.2fR", rt.RMultiple)
+			}
+			held := time.Duration(rt.HoldingSec * float64(time.Second)).Round(time.Minute)
+			fmt.Printf("  %-10s %s → %s  held %-8s %s  %s  %s\n", rt.Symbol, money.Price(rt.EntryPrice, cur),
+				money.Price(rt.ExitPrice, cur), held, r, money.Signed(rt.PnL, cur), rt.Reason)
+		}
+	}
+
+	if len(rep.Errors) > 0 {
+		fmt.Println("\n ERRORS")
+		for _, e := range rep.Errors {
+			sym := ""
+			if e.Symbol != "" {
+				sym = " " + e.Symbol
+			}
+			fmt.Printf("  %s [%s%s] %s\n", e.Time.Format("15:04:05"), e.Phase, sym, e.Message)
+		}
+	}
+
+	if len(rep.Timings) > 0 {
+		fmt.Println("\n TIMINGS")
+		for _, t := range rep.Timings {
+			fmt.Printf("  %-16s %s\n", t.Phase, (time.Duration(t.Seconds * float64(time.Second))).Round(time.Second))
+		}
+	}
+	if rep.TextReport != "" {
+		fmt.Printf("\n Text report: %s\n", rep.TextReport)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
+)
+
+// scanIssuesMaxSymbols 분류별 요약 줄에 보여 줄 종목 수 (전체 목록은 JSON issues.symbols)
+const scanIssuesMaxSymbols = 8
+
+// 시그널 제외 사유 (ScanIssue.Reason)
+const (
+	filteredBySignalFilter = "signal filter"
+	filteredByThreshold    = "below --min-prob/--min-strength"
+	filteredByTopN         = "outside trader.top_n"
+	filteredBySizing       = "position sizing (not affordable or below minimum)"
+)
+
+// scanIssues 마지막 스캔의 종목별 실패/제외 사유 (스캔하지 않은 실행이면 nil)
+var scanIssues *strategy.ScanIssues
+
+// filterScanSignals 필터 → 임계값 → 상위 N → 사이징. 단계별로 빠진 종목은 scanIssues에 남긴다.
+func filterScanSignals(ctx context.Context, signals []strategy.Signal, p *provider.FallbackProvider) []strategy.Signal {
+	if len(signals) == 0 {
+		return signals
+	}
+	before := strategy.SignalSymbols(signals)
+	signals = scanFilters.Apply(ctx, signals, signalFilterSources(ctx, p))
+	scanIssues.TrackFiltered(before, signals, filteredBySignalFilter)
+	signals = selectScanSignals(signals)
+	return sizeScanSignals(signals, scanSizerConfig())
+}
+
+// selectScanSignals --min-prob/--min-strength → 상위 N (적응형 스캔은 필터를 스캐너 안에서 이미 적용)
+func selectScanSignals(signals []strategy.Signal) []strategy.Signal {
+	before := strategy.SignalSymbols(signals)
+	signals = applyScanThresholds(signals)
+	scanIssues.TrackFiltered(before, signals, filteredByThreshold)
+
+	before = strategy.SignalSymbols(signals)
+	signals = trader.SelectTopN(signals)
+	scanIssues.TrackFiltered(before, signals, filteredByTopN)
+	return signals
+}
+
+// sizeScanSignals 포지션 사이징 (살 수 없는 시그널 제외)
+func sizeScanSignals(signals []strategy.Signal, sizerCfg trader.SizerConfig) []strategy.Signal {
+	before := strategy.SignalSymbols(signals)
+	signals = trader.NewPositionSizer(sizerCfg).ApplyToSignals(signals)
+	scanIssues.TrackFiltered(before, signals, filteredBySizing)
+	return signals
+}
+
+// printScanIssues 분류별 이슈 요약 (시그널 없음과 데이터 문제 구분용)
+func printScanIssues() {
+	if scanIssues.Empty() {
+		return
+	}
+	infof("\nScan issues: %d failed, %d filtered\n", scanIssues.Failed, scanIssues.Filtered)
+	for _, line := range scanIssues.Lines(scanIssuesMaxSymbols) {
+		infof("  %s\n", line)
+	}
+}
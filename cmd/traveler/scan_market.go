@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"traveler/internal/provider"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+	"traveler/pkg/model"
+)
+
+// scanMarket 단발 스캔 대상 마켓 (us, kr, crypto). 시세 provider와 사이징 설정을 고른다
+var scanMarket = "us"
+
+// setScanMarket --market과 종목 구성으로 스캔 마켓 결정 (과반이 KRW-XXX 코인이면 crypto)
+func setScanMarket(stocks []model.Stock) {
+	scanMarket = marketFlag
+	if scanMarket == "crypto" || len(stocks) == 0 {
+		return
+	}
+	coins := 0
+	for _, s := range stocks {
+		if symbols.IsCryptoSymbol(s.Symbol) {
+			coins++
+		}
+	}
+	if coins*2 > len(stocks) {
+		scanMarket = "crypto"
+	}
+}
+
+// scanProvider 스캔 시세 provider. 크립토는 Finnhub/Yahoo가 KRW-XXX를 모르므로 Upbit 일봉 (24/7, KST 09:00 기준)
+// scanner.candle_cache_minutes > 0이면 최근 갱신된 영구 일봉 캐시(백테스트/직전 스캔이 받은 데이터)를 API 대신 쓰고,
+// 새로 받은 일봉은 캐시에 병합한다. 캐시에는 완성된 봉만 있으므로 장중 스캔에는 켜지 않는다.
+func scanProvider(p *provider.FallbackProvider, cacheMinutes int) *provider.FallbackProvider {
+	if scanMarket == "crypto" {
+		p = provider.NewFallbackProvider(provider.NewUpbitProvider())
+	}
+	if cacheMinutes <= 0 {
+		return p
+	}
+	sp := provider.NewStoreProvider(p, provider.NewCandleStore(resolveDataDir()), provider.DataSourceAuto)
+	sp.SetMaxAge(time.Duration(cacheMinutes) * time.Minute)
+	return provider.NewFallbackProvider(sp)
+}
+
+// scanSizerConfig 스캔 결과 사이징 설정 (마켓별 수수료/리스크 티어)
+func scanSizerConfig() trader.SizerConfig {
+	return trader.SizerConfigForMarket(scanMarket, accountBalance)
+}
+
+// cryptoAutoTradeNotice 단발 스캔의 크립토 시그널은 주문하지 않음 (US 브로커 경유 방지)
+// 크립토 자동매매는 --daemon --market crypto (Upbit)로 돌린다.
+func cryptoAutoTradeNotice(signals int) {
+	fmt.Printf("\n[DRY-RUN] %d crypto signal(s) not ordered: one-shot auto-trade only routes to the US broker.\n", signals)
+	fmt.Println("          Use --daemon --market crypto (add --sim for paper trading) to trade on Upbit.")
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/config"
+	"traveler/internal/provider"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+	"traveler/pkg/model"
+)
+
+// loadSymbolLists 데이터 디렉토리의 블랙/화이트리스트 로드 (실패 시 nil → 필터 미적용)
+func loadSymbolLists() *symbols.SymbolLists {
+	lists, err := symbols.NewSymbolLists(resolveDataDir())
+	if err != nil {
+		fmt.Printf("Warning: could not load symbol lists: %v\n", err)
+		return nil
+	}
+	return lists
+}
+
+// scanFilters config trader.signal_filters 체인 (run()이 스캔 전에 만든다)
+var scanFilters trader.SignalFilterChain
+
+// signalFilterSpecs config.trader.signal_filters → 필터 설정 목록
+func signalFilterSpecs(cfg *config.Config) []trader.SignalFilterSpec {
+	specs := make([]trader.SignalFilterSpec, 0, len(cfg.Trader.SignalFilters))
+	for _, f := range cfg.Trader.SignalFilters {
+		specs = append(specs, trader.SignalFilterSpec{Type: f.Type, Value: f.Value, Sectors: f.Sectors, Markets: f.Markets})
+	}
+	return specs
+}
+
+// signalFilterSources 시그널 필터 조회 대상 (업종/실적 필터가 설정됐을 때만 Yahoo 세션 초기화)
+func signalFilterSources(ctx context.Context, p provider.Provider) trader.SignalFilterSources {
+	src := trader.SignalFilterSources{Lists: loadSymbolLists(), Candles: p}
+	if scanFilters.Needs(trader.FilterExcludeSectors, trader.FilterEarningsWindow) {
+		fc := provider.NewFundamentalsChecker(resolveDataDir(), nil)
+		if err := fc.Init(ctx); err != nil {
+			fmt.Printf("Warning: sector/earnings signal filters skipped (Yahoo init failed): %v\n", err)
+		} else {
+			src.Earnings, src.Sectors = fc, fc
+		}
+	}
+	return src
+}
+
+// skipPrunedStocks 자동 제외(반복 조회 실패) 종목을 스캔 대상에서 제거
+func skipPrunedStocks(stocks []model.Stock) []model.Stock {
+	health, err := provider.NewSymbolHealthStore(resolveDataDir())
+	if err != nil {
+		return stocks
+	}
+	kept := stocks[:0]
+	skipped := 0
+	for _, s := range stocks {
+		if health.IsPruned(s.Symbol) {
+			skipped++
+			continue
+		}
+		kept = append(kept, s)
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipping %d pruned symbols (see 'traveler symbols pruned')\n", skipped)
+	}
+	return kept
+}
+
+// newSymbolsCmd `traveler symbols` 블랙/화이트리스트 관리
+func newSymbolsCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "symbols",
+		Short: "Manage symbol blacklist / whitelist",
+		Long: `Manage symbols that are never traded (blacklist) or the only ones allowed (whitelist).
+Lists are stored in <data-dir>/symbol_lists.json and enforced on every strategy's signals
+(CLI scans, daemon, web). An empty whitelist allows all symbols.`,
+		Example: `  traveler symbols block XYZ --reason "earnings blowup"
+  traveler symbols unblock XYZ
+  traveler symbols allow AAPL MSFT
+  traveler symbols list
+  traveler symbols pruned`,
+	}
+
+	// modify 심볼 목록에 대해 동일 작업 반복
+	modify := func(verb string, fn func(l *symbols.SymbolLists, sym string) error) func(*cobra.Command, []string) error {
+		return func(_ *cobra.Command, args []string) error {
+			lists, err := symbols.NewSymbolLists(resolveDataDir())
+			if err != nil {
+				return fmt.Errorf("loading symbol lists: %w", err)
+			}
+			for _, sym := range args {
+				if err := fn(lists, sym); err != nil {
+					return fmt.Errorf("%s %s: %w", verb, sym, err)
+				}
+				fmt.Printf("%s: %s\n", verb, symbols.NormalizeSymbol(sym))
+			}
+			return nil
+		}
+	}
+
+	blockCmd := &cobra.Command{
+		Use:   "block SYMBOL...",
+		Short: "Add symbols to the blacklist",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: modify("blocked", func(l *symbols.SymbolLists, sym string) error {
+			return l.Block(sym, reason)
+		}),
+	}
+	blockCmd.Flags().StringVar(&reason, "reason", "", "why the symbol is blocked")
+
+	allowCmd := &cobra.Command{
+		Use:   "allow SYMBOL...",
+		Short: "Add symbols to the whitelist",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: modify("allowed", func(l *symbols.SymbolLists, sym string) error {
+			return l.Allow(sym, reason)
+		}),
+	}
+	allowCmd.Flags().StringVar(&reason, "reason", "", "note for the whitelist entry")
+
+	cmd.AddCommand(
+		blockCmd,
+		&cobra.Command{
+			Use:   "unblock SYMBOL...",
+			Short: "Remove symbols from the blacklist",
+			Args:  cobra.MinimumNArgs(1),
+			RunE:  modify("unblocked", (*symbols.SymbolLists).Unblock),
+		},
+		allowCmd,
+		&cobra.Command{
+			Use:   "disallow SYMBOL...",
+			Short: "Remove symbols from the whitelist",
+			Args:  cobra.MinimumNArgs(1),
+			RunE:  modify("disallowed", (*symbols.SymbolLists).Disallow),
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "Show blacklist and whitelist",
+			Args:  cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				lists, err := symbols.NewSymbolLists(resolveDataDir())
+				if err != nil {
+					return fmt.Errorf("loading symbol lists: %w", err)
+				}
+				printListEntries("Blacklist", lists.Blacklist())
+				printListEntries("Whitelist (empty = all allowed)", lists.Whitelist())
+				return nil
+			},
+		},
+		newSymbolsPrunedCmd(),
+	)
+	return cmd
+}
+
+func printListEntries(title string, entries []symbols.ListEntry) {
+	fmt.Printf("%s (%d)\n", title, len(entries))
+	fmt.Println(strings.Repeat("-", 50))
+	for _, e := range entries {
+		fmt.Printf("  %-12s %s  %s\n", e.Symbol, e.AddedAt.Format("2006-01-02"), e.Reason)
+	}
+	fmt.Println()
+}
+
+// newSymbolsPrunedCmd `traveler symbols pruned` 자동 제외 종목 리포트/해제
+func newSymbolsPrunedCmd() *cobra.Command {
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "pruned [SYMBOL...]",
+		Short: "Report symbols auto-skipped after repeated data failures",
+		Long: fmt.Sprintf(`Symbols whose daily candles fail permanently (delisted, renamed, invalid ticker)
+on %d different days are skipped by scans for %d days, then retried once.
+
+With --clear, the given symbols (or all, if none given) are un-pruned immediately.`,
+			provider.PruneFailureDays, int(provider.PruneDuration.Hours()/24)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			health, err := provider.NewSymbolHealthStore(resolveDataDir())
+			if err != nil {
+				return fmt.Errorf("loading symbol health: %w", err)
+			}
+
+			if clear {
+				if len(args) == 0 {
+					for _, h := range health.Pruned() {
+						args = append(args, h.Symbol)
+					}
+				}
+				for _, sym := range args {
+					sym = symbols.NormalizeSymbol(sym)
+					health.Unprune(sym)
+					fmt.Printf("unpruned: %s\n", sym)
+				}
+				return nil
+			}
+
+			printHealthEntries("Pruned", health.Pruned(), true)
+			printHealthEntries(fmt.Sprintf("Failing (pruned at %d days)", provider.PruneFailureDays), health.Failing(), false)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&clear, "clear", false, "un-prune the given symbols (all if none given)")
+	return cmd
+}
+
+func printHealthEntries(title string, entries []provider.SymbolHealth, pruned bool) {
+	fmt.Printf("%s (%d)\n", title, len(entries))
+	fmt.Println(strings.Repeat("-", 70))
+	for _, h := range entries {
+		until := ""
+		if pruned {
+			until = "until " + h.PrunedUntil.Format("2006-01-02")
+		}
+		fmt.Printf("  %-12s %dd  last %s  %-16s %s\n",
+			h.Symbol, h.FailureDays, h.LastFailure.Format("2006-01-02"), until, truncateError(h.LastError))
+	}
+	fmt.Println()
+}
+
+func truncateError(s string) string {
+	if len(s) > 60 {
+		return s[:57] + "..."
+	}
+	return s
+}
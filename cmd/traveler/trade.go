@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/config"
+	"traveler/internal/money"
+	"traveler/internal/trader"
+)
+
+// newTradeCmd `traveler trade --signals file.json` 외부 시그널 실행
+func newTradeCmd() *cobra.Command {
+	var (
+		signalsFile string
+		market      string
+		live        bool
+		useMarket   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "trade",
+		Short: "Execute externally generated signals from a JSON file",
+		Long: `Import signals in the strategy.Signal schema (an array, {"signals": [...]} as saved
+by the web scanner, or a single signal) and run them through the same sizing and
+risk checks as scanner signals before handing them to the auto-trader.
+
+Each signal needs stock.symbol and guide.entry_price / stop_loss / target_1.
+Quantities in the file are ignored and re-sized from the account balance.
+Dry-run by default; --live places real orders and saves position plans for the daemon.`,
+		Example: `  traveler trade --signals picks.json
+  traveler trade --signals picks.json --market kr --live
+  cat picks.json | traveler trade --signals -`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			var data []byte
+			if signalsFile == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(signalsFile)
+			}
+			if err != nil {
+				return fmt.Errorf("read signals: %w", err)
+			}
+			signals, err := trader.ParseSignals(data)
+			if err != nil {
+				return err
+			}
+			if len(signals) == 0 {
+				return fmt.Errorf("no signals in %s", signalsFile)
+			}
+
+			b, _, err := connectKISMarket(cfg, market)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			balance, err := b.GetBalance(ctx)
+			if err != nil {
+				return fmt.Errorf("get balance: %w", err)
+			}
+			cur := money.ForMarket(market)
+			fmt.Printf("Account Balance: %s\n", money.Format(balance.TotalEquity, cur))
+
+			if live {
+				fmt.Println()
+				fmt.Println(strings.Repeat("!", 60))
+				fmt.Println("  WARNING: LIVE TRADING MODE")
+				fmt.Printf("  This will execute up to %d REAL orders with REAL money!\n", len(signals))
+				fmt.Println(strings.Repeat("!", 60))
+				fmt.Print("\nType 'CONFIRM' to proceed: ")
+
+				confirm, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				if strings.TrimSpace(confirm) != "CONFIRM" {
+					fmt.Println("Trading cancelled by user")
+					return nil
+				}
+			}
+
+			sizerCfg := trader.SizerConfigForMarket(market, balance.TotalEquity)
+			traderCfg := trader.Config{
+				DryRun:          !live,
+				MaxPositions:    sizerCfg.MaxPositions,
+				MaxPositionPct:  sizerCfg.MaxPositionPct,
+				TotalCapital:    balance.TotalEquity,
+				RiskPerTrade:    sizerCfg.RiskPerTrade,
+				MonitorInterval: trader.DefaultConfig().MonitorInterval,
+				Throttle:        orderThrottle(cfg),
+				EODClose:        eodClosePolicy(cfg),
+				Caps:            orderCaps(cfg),
+				PriceCheck:      priceCheck(cfg),
+			}
+			var ps *trader.PlanStore
+			if live {
+				if ps, err = trader.NewPlanStore(resolveDataDir()); err != nil {
+					return fmt.Errorf("open plan store: %w", err)
+				}
+			}
+			at := trader.NewAutoTraderWithPlanStore(traderCfg, b, useMarket, ps)
+			if live {
+				at.SetOrderJournal(trader.NewOrderJournal(resolveDataDir(), market))
+			}
+
+			fmt.Printf("\nImporting %d signals...\n", len(signals))
+			res, err := at.ImportSignals(ctx, signals, sizerCfg)
+			if err != nil {
+				return fmt.Errorf("import signals: %w", err)
+			}
+
+			fmt.Println()
+			fmt.Println(strings.Repeat("-", 60))
+			fmt.Println(" EXECUTION RESULTS")
+			fmt.Println(strings.Repeat("-", 60))
+			ok := 0
+			for _, r := range res.Executed {
+				status := "FAILED"
+				if r.Success {
+					status = "OK"
+					ok++
+				}
+				fmt.Printf(" [%s] %s (%s): BUY %.0f @ %s",
+					status, r.Signal.Stock.Symbol, r.Signal.Strategy, r.Signal.Guide.PositionSize,
+					money.Price(r.Signal.Guide.EntryPrice, money.ForSymbol(r.Signal.Stock.Symbol)))
+				if r.Result != nil {
+					fmt.Printf(" (Order: %s)", r.Result.OrderID)
+				}
+				if r.Error != "" {
+					fmt.Printf(" - %s", r.Error)
+				}
+				fmt.Println()
+			}
+			for _, r := range res.Rejected {
+				fmt.Printf(" [SKIP] %s: %s\n", r.Signal.Stock.Symbol, r.Reason)
+			}
+			fmt.Printf("\nTotal: %d/%d orders executed, %d rejected\n", ok, len(signals), len(res.Rejected))
+			if live && ok > 0 {
+				fmt.Println("Position plans saved — the daemon monitors stops and targets.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&signalsFile, "signals", "", "signals JSON file (- for stdin)")
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr")
+	cmd.Flags().BoolVar(&live, "live", false, "place real orders (default: dry-run)")
+	cmd.Flags().BoolVar(&useMarket, "market-order", false, "use market orders instead of limit orders")
+	cmd.MarkFlagRequired("signals")
+	return cmd
+}
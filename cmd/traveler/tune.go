@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/backtest"
+	"traveler/internal/config"
+	"traveler/internal/money"
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
+)
+
+// newTuneCmd `traveler tune` 등록 전략을 같은 유니버스/기간으로 백테스트해 활성 전략 목록 추천
+func newTuneCmd() *cobra.Command {
+	var (
+		universeName string
+		market       string
+		days         int
+		capital      float64
+		crit         = backtest.DefaultTuneCriteria()
+		noCache      bool
+		outFile      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tune",
+		Short: "Backtest every registered strategy and recommend scanner.enabled_strategies",
+		Long: `Run each registered strategy on its own over the same universe, period and
+capital, compare trades, expectancy and drawdown, and print a config block
+to paste into config.yaml:
+
+  scanner:
+    enabled_strategies: [...]
+
+A strategy is enabled when it has at least --min-trades trades, positive
+expectancy (R per trade) and a max drawdown within --max-dd. Strategies this
+simulator cannot evaluate (pairs, crypto-meta) are kept enabled; use
+backtest-pairs for pairs. The daemon and multi-strategy scans read the list
+on start-up.`,
+		Example: `  traveler tune --universe sp500 --days 250
+  traveler tune --universe kospi --market kr --out tuned.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if market != "us" && market != "kr" {
+				return fmt.Errorf("--market must be us or kr")
+			}
+			if days <= 0 {
+				return fmt.Errorf("--days must be positive")
+			}
+			syms, err := resolveUniverse(universeName)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			// 전략 공통 정책은 스캔/데몬과 같게 (손절 모델, 3분할 익절, 섹터 로테이션)
+			if err := strategy.SetStopModels(cfg.Trader.StopModels); err != nil {
+				return fmt.Errorf("config trader.stop_models: %w", err)
+			}
+			tranches, err := trancheExit(cfg)
+			if err != nil {
+				return err
+			}
+			sr := cfg.Trader.SectorRotation
+			if err := strategy.SetSectorRotationConfig(strategy.SectorRotationConfig{
+				Sectors: sr.Sectors, TopN: sr.TopN, ShortLookback: sr.ShortLookback, LongLookback: sr.LongLookback,
+				ShortWeight: sr.ShortWeight, RequirePositive: !sr.AllowNegative, StopPct: sr.StopPct,
+			}); err != nil {
+				return fmt.Errorf("config trader.sector_rotation: %w", err)
+			}
+
+			displayCurrency = money.ForMarket(market)
+			if capital == 0 {
+				capital = backtest.DefaultStockSimConfig(market).InitialCapital
+			}
+			sizerCfg := trader.AdjustConfigForBalance(capital)
+			if market == "kr" {
+				sizerCfg = trader.AdjustConfigForKRBalance(capital)
+			}
+			simCfg := backtest.StockSimConfig{
+				Market:         market,
+				Days:           days,
+				InitialCapital: capital,
+				MaxPositions:   sizerCfg.MaxPositions,
+				Commission:     sizerCfg.CommissionRate,
+				EODClose:       eodClosePolicy(cfg),
+				Tranches:       tranches,
+			}
+
+			// 벤치마크(거래일 기준)와 섹터 ETF(sector-rotation 전용)도 함께 받는다
+			benchmark := "SPY"
+			extra := map[string][]string{}
+			if market == "kr" {
+				benchmark = "069500"
+			} else {
+				extra["sector-rotation"] = strategy.CurrentSectorRotationConfig().Sectors
+			}
+			syms = dedupSymbols(append([]string{benchmark}, syms...))
+			fetchSyms := syms
+			for _, s := range extra {
+				fetchSyms = dedupSymbols(append(fetchSyms, s...))
+			}
+
+			lookback := days + 260 // MA200 + 여유
+			fmt.Printf("Tuning %d strategies on %s (%d symbols), %d trading days, capital %s\n",
+				len(strategy.List()), universeName, len(syms), days, formatMoney(capital))
+
+			ctx := context.Background()
+			p := provider.NewFallbackProvider(createProviders(cfg)...)
+			allCandles, err := backtest.FetchStockData(ctx, p, fetchSyms, lookback, resolveDataDir(), noCache)
+			if err != nil {
+				return fmt.Errorf("fetch data: %w", err)
+			}
+			if _, ok := allCandles[benchmark]; !ok {
+				return fmt.Errorf("no data for benchmark %s", benchmark)
+			}
+			var valid []string
+			for _, s := range syms {
+				if _, ok := allCandles[s]; ok {
+					valid = append(valid, s)
+				}
+			}
+			fmt.Printf("%d symbols with data\n\n", len(valid))
+
+			results := backtest.TuneStrategies(ctx, allCandles, simCfg, sizerCfg, valid, extra, crit)
+			backtest.PrintTuneResults(results, market, days)
+
+			block := backtest.TuneConfigBlock(results, universeName, days, time.Now())
+			fmt.Println("Recommended config (paste into config.yaml):")
+			fmt.Println()
+			fmt.Print(block)
+			if outFile != "" {
+				if err := os.WriteFile(outFile, []byte(block), 0644); err != nil {
+					return fmt.Errorf("write %s: %w", outFile, err)
+				}
+				fmt.Printf("\nSaved to %s\n", outFile)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&universeName, "universe", "sp500", "universe to backtest (sp500, nasdaq100, kospi, watchlist:<name>, ...)")
+	cmd.Flags().StringVar(&market, "market", "us", "market: us or kr (benchmark, fees, sizing)")
+	cmd.Flags().IntVar(&days, "days", 250, "backtest period in trading days")
+	cmd.Flags().Float64Var(&capital, "capital", 0, "initial capital (0 = $5000 US, ₩5M KR)")
+	cmd.Flags().IntVar(&crit.MinTrades, "min-trades", crit.MinTrades, "minimum trades for a strategy to be enabled")
+	cmd.Flags().Float64Var(&crit.MinExpectancyR, "min-expectancy", crit.MinExpectancyR, "expectancy (R per trade) must exceed this")
+	cmd.Flags().Float64Var(&crit.MaxDrawdown, "max-dd", crit.MaxDrawdown, "maximum drawdown % (0 = no limit)")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "skip the backtest cache and fetch fresh data")
+	cmd.Flags().StringVar(&outFile, "out", "", "also write the recommended config block to this file")
+	return cmd
+}
+
+// dedupSymbols 순서를 유지하며 중복 제거
+func dedupSymbols(syms []string) []string {
+	seen := make(map[string]bool, len(syms))
+	out := syms[:0:0]
+	for _, s := range syms {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
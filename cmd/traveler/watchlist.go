@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"traveler/internal/symbols"
+)
+
+// resolveUniverse --universe 값을 심볼 목록으로 (고정 유니버스 또는 watchlist:<name>)
+func resolveUniverse(u string) ([]string, error) {
+	syms, err := symbols.ResolveUniverse(symbols.Universe(u), resolveDataDir())
+	if err != nil && !symbols.IsWatchlistUniverse(symbols.Universe(u)) {
+		return nil, fmt.Errorf("unknown universe: %s (use: test, dow30, nasdaq100, sp500, midcap, russell, crypto-top10, crypto-top30, jp-top30, hk-top30, or watchlist:<name>)", u)
+	}
+	return syms, err
+}
+
+// newWatchlistCmd `traveler watchlist` 사용자 관심종목 관리
+func newWatchlistCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watchlist",
+		Short: "Manage personal watchlists",
+		Long: `Create and edit personal watchlists stored in <data-dir>/watchlists.json.
+A watchlist can be scanned or backtested like any universe with --universe watchlist:<name>.`,
+		Example: `  traveler watchlist create semis
+  traveler watchlist add semis NVDA AMD AVGO 000660
+  traveler watchlist remove semis AMD
+  traveler watchlist list
+  traveler --universe watchlist:semis --strategy all`,
+	}
+
+	// withLists 저장소 로드 후 작업 실행
+	withLists := func(fn func(w *symbols.Watchlists, args []string) error) func(*cobra.Command, []string) error {
+		return func(_ *cobra.Command, args []string) error {
+			w, err := symbols.NewWatchlists(resolveDataDir())
+			if err != nil {
+				return fmt.Errorf("loading watchlists: %w", err)
+			}
+			return fn(w, args)
+		}
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "create NAME",
+			Short: "Create an empty watchlist",
+			Args:  cobra.ExactArgs(1),
+			RunE: withLists(func(w *symbols.Watchlists, args []string) error {
+				if err := w.Create(args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("created: %s\n", args[0])
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:               "delete NAME",
+			Short:             "Delete a watchlist",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: completeArgs(1, watchlistNames),
+			RunE: withLists(func(w *symbols.Watchlists, args []string) error {
+				if err := w.Delete(args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("deleted: %s\n", args[0])
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:               "add NAME SYMBOL...",
+			Short:             "Add symbols to a watchlist (created if missing)",
+			Args:              cobra.MinimumNArgs(2),
+			ValidArgsFunction: completeArgs(1, watchlistNames),
+			RunE: withLists(func(w *symbols.Watchlists, args []string) error {
+				if err := w.Add(args[0], args[1:]...); err != nil {
+					return err
+				}
+				return printWatchlist(w, args[0])
+			}),
+		},
+		&cobra.Command{
+			Use:               "remove NAME SYMBOL...",
+			Short:             "Remove symbols from a watchlist",
+			Args:              cobra.MinimumNArgs(2),
+			ValidArgsFunction: completeArgs(1, watchlistNames),
+			RunE: withLists(func(w *symbols.Watchlists, args []string) error {
+				if err := w.Remove(args[0], args[1:]...); err != nil {
+					return err
+				}
+				return printWatchlist(w, args[0])
+			}),
+		},
+		&cobra.Command{
+			Use:               "list [NAME]",
+			Short:             "Show all watchlists, or the symbols of one",
+			Args:              cobra.MaximumNArgs(1),
+			ValidArgsFunction: completeArgs(1, watchlistNames),
+			RunE: withLists(func(w *symbols.Watchlists, args []string) error {
+				if len(args) == 1 {
+					return printWatchlist(w, args[0])
+				}
+				all := w.List()
+				fmt.Printf("Watchlists (%d)\n", len(all))
+				fmt.Println(strings.Repeat("-", 50))
+				for _, wl := range all {
+					fmt.Printf("  %-16s %3d symbols  updated %s\n", wl.Name, len(wl.Symbols), wl.UpdatedAt.Format("2006-01-02"))
+				}
+				return nil
+			}),
+		},
+	)
+	return cmd
+}
+
+func printWatchlist(w *symbols.Watchlists, name string) error {
+	wl, ok := w.Get(name)
+	if !ok {
+		return fmt.Errorf("watchlist %q not found", name)
+	}
+	fmt.Printf("%s (%d)\n", wl.Name, len(wl.Symbols))
+	fmt.Println(strings.Repeat("-", 50))
+	for _, sym := range wl.Symbols {
+		if symbols.IsKoreanSymbol(sym) {
+			fmt.Printf("  %-12s %s\n", sym, symbols.GetKRSymbolName(sym))
+		} else {
+			fmt.Printf("  %s\n", sym)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/fsutil"
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/pkg/model"
+)
+
+// DefaultEvalInterval 평가 최소 간격 (모니터 주기가 더 짧아도 API 호출을 늘리지 않음)
+const DefaultEvalInterval = time.Minute
+
+// Alert 발생한 알림
+type Alert struct {
+	RuleID  string    `json:"rule_id"`
+	Rule    string    `json:"rule"`
+	Kind    string    `json:"kind"`
+	Market  string    `json:"market"`
+	Symbol  string    `json:"symbol,omitempty"`
+	Value   float64   `json:"value"`
+	Level   float64   `json:"level"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// Store 규칙 저장소 ({dataDir}/alert_rules.json, 전 마켓 공용)
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore 생성자
+func NewStore(dataDir string) *Store {
+	return &Store{path: filepath.Join(dataDir, "alert_rules.json")}
+}
+
+// List 저장된 규칙 (파일 없으면 빈 목록)
+func (s *Store) List() ([]Rule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Add 규칙 추가 후 ID가 채워진 규칙 반환
+func (s *Store) Add(r Rule) (Rule, error) {
+	if err := r.Validate(); err != nil {
+		return r, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := fsutil.Update(s.path, func() error {
+		rules, err := s.load()
+		if err != nil {
+			return err
+		}
+		next := 1
+		for _, existing := range rules {
+			var n int
+			if _, err := fmt.Sscanf(existing.ID, "a%d", &n); err == nil && n >= next {
+				next = n + 1
+			}
+		}
+		r.ID = fmt.Sprintf("a%d", next)
+		if r.Created.IsZero() {
+			r.Created = time.Now()
+		}
+		return s.save(append(rules, r))
+	})
+	return r, err
+}
+
+// Remove 규칙 삭제
+func (s *Store) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fsutil.Update(s.path, func() error {
+		rules, err := s.load()
+		if err != nil {
+			return err
+		}
+		for i, r := range rules {
+			if r.ID == id {
+				return s.save(append(rules[:i], rules[i+1:]...))
+			}
+		}
+		return fmt.Errorf("alert rule %s not found", id)
+	})
+}
+
+func (s *Store) load() ([]Rule, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return rules, nil
+}
+
+func (s *Store) save(rules []Rule) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(s.path, data, 0644)
+}
+
+// engineState 마켓별 평가 상태 (crosses 판정용 직전값, 재무장 여부, 마지막 발송 시각)
+type engineState struct {
+	Last       map[string]float64   `json:"last"`
+	Active     map[string]bool      `json:"active"`
+	Fired      map[string]time.Time `json:"fired"`
+	PeakEquity float64              `json:"peak_equity"`
+}
+
+// Engine 마켓 하나의 규칙 평가기 (주문 없음, 알림만)
+type Engine struct {
+	market    string
+	store     *Store
+	statePath string
+	broker    broker.Broker
+	provider  provider.Provider // RSI용 일봉 (nil이면 RSI 규칙 건너뜀)
+	interval  time.Duration
+	notifiers []func(Alert)
+
+	mu      sync.Mutex
+	lastRun time.Time
+	state   engineState
+}
+
+// NewEngine 생성자. 상태는 {dataDir}/alert_state_{market}.json에 유지된다.
+func NewEngine(dataDir, market string, b broker.Broker, p provider.Provider) *Engine {
+	e := &Engine{
+		market:    market,
+		store:     NewStore(dataDir),
+		statePath: filepath.Join(dataDir, fmt.Sprintf("alert_state_%s.json", market)),
+		broker:    b,
+		provider:  p,
+		interval:  DefaultEvalInterval,
+		state: engineState{
+			Last:   make(map[string]float64),
+			Active: make(map[string]bool),
+			Fired:  make(map[string]time.Time),
+		},
+	}
+	if data, err := os.ReadFile(e.statePath); err == nil {
+		json.Unmarshal(data, &e.state)
+	}
+	return e
+}
+
+// SetInterval 평가 최소 간격 변경 (0이면 매 호출 평가)
+func (e *Engine) SetInterval(d time.Duration) {
+	e.interval = d
+}
+
+// OnAlert 알림 발생 시 호출할 함수 추가 (텔레그램, 웹훅 등)
+func (e *Engine) OnAlert(fn func(Alert)) {
+	e.notifiers = append(e.notifiers, fn)
+}
+
+// observation 규칙 하나에서 나온 관측값 (보유 전 종목 RSI는 종목별로 여러 개)
+type observation struct {
+	symbol string
+	value  float64
+}
+
+// Evaluate 이 마켓의 규칙을 평가해 발생한 알림을 반환하고 notifier에 전달한다.
+// 간격 안에서 다시 호출되면 아무것도 하지 않는다.
+func (e *Engine) Evaluate(ctx context.Context) ([]Alert, error) {
+	if e == nil {
+		return nil, nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	if !e.lastRun.IsZero() && now.Sub(e.lastRun) < e.interval {
+		return nil, nil
+	}
+	e.lastRun = now
+
+	all, err := e.store.List()
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	needBalance := false
+	for _, r := range all {
+		if r.Market != e.market {
+			continue
+		}
+		rules = append(rules, r)
+		if r.Kind == KindDrawdown || r.Symbol == HeldSymbols {
+			needBalance = true
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	var balance *broker.AccountBalance
+	var held []string
+	if needBalance {
+		if balance, err = e.broker.GetBalance(ctx); err != nil {
+			log.Printf("[ALERT] Balance fetch failed: %v", err)
+		} else {
+			positions := balance.Positions
+			if len(positions) == 0 {
+				positions, _ = e.broker.GetPositions(ctx)
+			}
+			for _, p := range positions {
+				held = append(held, p.Symbol)
+			}
+			sort.Strings(held)
+		}
+	}
+
+	candles := make(map[string][]model.Candle)
+	var fired []Alert
+	for _, r := range rules {
+		for _, o := range e.observe(ctx, r, balance, held, candles) {
+			if a, ok := e.check(r, o, now); ok {
+				fired = append(fired, a)
+			}
+		}
+	}
+	e.saveState()
+
+	for _, a := range fired {
+		log.Printf("[ALERT] %s", a.Message)
+		for _, fn := range e.notifiers {
+			fn(a)
+		}
+	}
+	return fired, nil
+}
+
+// observe 규칙의 현재 값 조회 (실패한 종목은 로그 후 생략)
+func (e *Engine) observe(ctx context.Context, r Rule, balance *broker.AccountBalance, held []string, candles map[string][]model.Candle) []observation {
+	switch r.Kind {
+	case KindPrice:
+		q, err := e.broker.GetQuote(ctx, r.Symbol)
+		if err != nil || q <= 0 {
+			log.Printf("[ALERT] %s: quote unavailable: %v", r.Symbol, err)
+			return nil
+		}
+		return []observation{{symbol: r.Symbol, value: q}}
+
+	case KindRSI:
+		if e.provider == nil {
+			return nil
+		}
+		syms := []string{r.Symbol}
+		if r.Symbol == HeldSymbols {
+			syms = held
+		}
+		var obs []observation
+		for _, sym := range syms {
+			c, ok := candles[sym]
+			if !ok {
+				var err error
+				if c, err = e.provider.GetDailyCandles(ctx, sym, 100); err != nil {
+					log.Printf("[ALERT] %s: candles unavailable: %v", sym, err)
+				}
+				candles[sym] = c
+			}
+			if len(c) < r.Period+1 {
+				continue
+			}
+			obs = append(obs, observation{symbol: sym, value: strategy.CalculateRSI(c, r.Period)})
+		}
+		return obs
+
+	case KindDrawdown:
+		if balance == nil || balance.TotalEquity <= 0 {
+			return nil
+		}
+		if balance.TotalEquity > e.state.PeakEquity {
+			e.state.PeakEquity = balance.TotalEquity
+		}
+		dd := (e.state.PeakEquity - balance.TotalEquity) / e.state.PeakEquity * 100
+		return []observation{{value: dd}}
+	}
+	return nil
+}
+
+// check 관측값으로 규칙 판정 + 상태 갱신
+func (e *Engine) check(r Rule, o observation, now time.Time) (Alert, bool) {
+	key := r.ID + "|" + o.symbol
+	prev, hasPrev := e.state.Last[key]
+	e.state.Last[key] = o.value
+
+	var hit bool
+	switch r.Op {
+	case OpAbove, OpBelow:
+		cond := o.value > r.Value
+		if r.Op == OpBelow {
+			cond = o.value < r.Value
+		}
+		hit = cond && !e.state.Active[key] // 거짓→참 전환 시에만
+		e.state.Active[key] = cond
+	case OpCrossesAbove:
+		hit = hasPrev && prev <= r.Value && o.value > r.Value
+	case OpCrossesBelow:
+		hit = hasPrev && prev >= r.Value && o.value < r.Value
+	}
+	if !hit || now.Sub(e.state.Fired[key]) < r.cooldown() {
+		return Alert{}, false
+	}
+	e.state.Fired[key] = now
+
+	subject := o.symbol
+	switch r.Kind {
+	case KindRSI:
+		subject = fmt.Sprintf("RSI(%d) of %s", r.Period, o.symbol)
+	case KindDrawdown:
+		subject = "portfolio drawdown"
+	}
+	msg := fmt.Sprintf("%s is %.2f — rule %s [%s]", subject, o.value, r.String(), r.ID)
+	if r.Note != "" {
+		msg += ": " + r.Note
+	}
+	return Alert{
+		RuleID:  r.ID,
+		Rule:    r.String(),
+		Kind:    r.Kind,
+		Market:  r.Market,
+		Symbol:  o.symbol,
+		Value:   o.value,
+		Level:   r.Value,
+		Message: msg,
+		Time:    now,
+	}, true
+}
+
+func (e *Engine) saveState() {
+	data, err := json.MarshalIndent(e.state, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := fsutil.WriteFile(e.statePath, data, 0644); err != nil {
+		log.Printf("[ALERT] Failed to save state: %v", err)
+	}
+}
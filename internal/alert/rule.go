@@ -0,0 +1,210 @@
+// Package alert evaluates user-defined price/indicator/portfolio alert rules
+// and fires notifications without placing any orders.
+package alert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"traveler/internal/symbols"
+)
+
+// Rule kinds
+const (
+	KindPrice    = "price"    // 종목 현재가
+	KindRSI      = "rsi"      // 종목(또는 보유 전 종목) 일봉 RSI
+	KindDrawdown = "drawdown" // 계좌 평가액 고점 대비 하락률 (%)
+)
+
+// Comparison operators
+const (
+	OpAbove        = "above"
+	OpBelow        = "below"
+	OpCrossesAbove = "crosses_above"
+	OpCrossesBelow = "crosses_below"
+)
+
+// HeldSymbols RSI 규칙에서 보유 전 종목을 뜻하는 심볼
+const HeldSymbols = "held"
+
+// DefaultCooldown 같은 규칙이 다시 울리기까지 최소 간격
+const DefaultCooldown = 30 * time.Minute
+
+// Rule 알림 규칙 하나
+//
+// above/below는 조건이 거짓→참으로 바뀔 때 한 번 울리고, 다시 거짓이 되면 재무장된다.
+// crosses_*는 직전 관측값이 반대편에 있었을 때만 울린다 (첫 관측은 기준값만 기록).
+type Rule struct {
+	ID       string        `json:"id"`
+	Kind     string        `json:"kind"`
+	Market   string        `json:"market"` // us, kr, crypto
+	Symbol   string        `json:"symbol,omitempty"`
+	Period   int           `json:"period,omitempty"` // RSI 기간
+	Op       string        `json:"op"`
+	Value    float64       `json:"value"`
+	Cooldown time.Duration `json:"cooldown,omitempty"`
+	Note     string        `json:"note,omitempty"`
+	Created  time.Time     `json:"created"`
+}
+
+var rsiSubject = regexp.MustCompile(`^(?i)rsi(?:\((\d+)\))?$`)
+
+// ParseRule 문자열 규칙 파싱
+//
+//	"AAPL crosses above 200"   → price
+//	"005930 < 65000"           → price (마켓 자동 추론)
+//	"RSI(14) held > 75"        → rsi (보유 전 종목)
+//	"RSI NVDA below 30"        → rsi (기본 14)
+//	"drawdown > 3%"            → drawdown
+//
+// market이 비어 있으면 심볼로 추론한다 (6자리 숫자 kr, KRW- crypto, 그 외 us).
+func ParseRule(expr, market string) (Rule, error) {
+	fields := strings.Fields(strings.NewReplacer(">=", " > ", "<=", " < ", ">", " > ", "<", " < ").Replace(expr))
+	if len(fields) < 3 {
+		return Rule{}, fmt.Errorf("rule %q: expected <subject> <op> <value>", expr)
+	}
+
+	var r Rule
+	rest := fields
+	switch m := rsiSubject.FindStringSubmatch(fields[0]); {
+	case m != nil:
+		r.Kind = KindRSI
+		r.Period = 14
+		if m[1] != "" {
+			r.Period, _ = strconv.Atoi(m[1])
+		}
+		if len(fields) < 4 {
+			return Rule{}, fmt.Errorf("rule %q: RSI needs a symbol or %q", expr, HeldSymbols)
+		}
+		r.Symbol = normalizeSymbol(fields[1])
+		rest = fields[2:]
+	case strings.EqualFold(fields[0], KindDrawdown):
+		r.Kind = KindDrawdown
+		rest = fields[1:]
+	default:
+		r.Kind = KindPrice
+		r.Symbol = normalizeSymbol(fields[0])
+		rest = fields[1:]
+	}
+	if r.Symbol == strings.ToUpper(HeldSymbols) {
+		if r.Kind != KindRSI {
+			return Rule{}, fmt.Errorf("rule %q: %q is only supported for RSI rules", expr, HeldSymbols)
+		}
+		r.Symbol = HeldSymbols
+	}
+
+	op, rest, err := parseOp(rest)
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %q: %w", expr, err)
+	}
+	r.Op = op
+	if len(rest) != 1 {
+		return Rule{}, fmt.Errorf("rule %q: expected a single value after the operator", expr)
+	}
+	v, err := strconv.ParseFloat(strings.NewReplacer("$", "", "₩", "", ",", "", "%", "").Replace(rest[0]), 64)
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %q: invalid value %q", expr, rest[0])
+	}
+	r.Value = v
+
+	r.Market = market
+	if r.Market == "" {
+		r.Market = inferMarket(r.Symbol)
+	}
+	return r, r.Validate()
+}
+
+func parseOp(fields []string) (string, []string, error) {
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("missing operator")
+	}
+	first := strings.ToLower(fields[0])
+	if (first == "crosses" || first == "cross") && len(fields) > 1 {
+		switch strings.ToLower(fields[1]) {
+		case "above", "over", "up":
+			return OpCrossesAbove, fields[2:], nil
+		case "below", "under", "down":
+			return OpCrossesBelow, fields[2:], nil
+		}
+	}
+	switch first {
+	case ">", "above", "over":
+		return OpAbove, fields[1:], nil
+	case "<", "below", "under":
+		return OpBelow, fields[1:], nil
+	}
+	return "", nil, fmt.Errorf("unknown operator %q (use >, <, above, below, crosses above, crosses below)", fields[0])
+}
+
+// Validate 규칙 필드 검증
+func (r Rule) Validate() error {
+	switch r.Kind {
+	case KindPrice:
+		if r.Symbol == "" || r.Value <= 0 {
+			return fmt.Errorf("price rule needs a symbol and a positive price")
+		}
+	case KindRSI:
+		if r.Symbol == "" || r.Period < 2 || r.Value <= 0 || r.Value >= 100 {
+			return fmt.Errorf("RSI rule needs a symbol, period >= 2 and a level between 0 and 100")
+		}
+	case KindDrawdown:
+		if r.Value <= 0 || r.Value >= 100 {
+			return fmt.Errorf("drawdown rule needs a percentage between 0 and 100")
+		}
+	default:
+		return fmt.Errorf("unknown rule kind %q", r.Kind)
+	}
+	switch r.Op {
+	case OpAbove, OpBelow, OpCrossesAbove, OpCrossesBelow:
+	default:
+		return fmt.Errorf("unknown operator %q", r.Op)
+	}
+	switch r.Market {
+	case "us", "kr", "crypto":
+	default:
+		return fmt.Errorf("unsupported market %q (use us, kr or crypto)", r.Market)
+	}
+	return nil
+}
+
+// String 사람이 읽는 규칙 표현 (ParseRule로 다시 파싱 가능)
+func (r Rule) String() string {
+	op := strings.ReplaceAll(r.Op, "_", " ")
+	switch r.Kind {
+	case KindRSI:
+		return fmt.Sprintf("RSI(%d) %s %s %g", r.Period, r.Symbol, op, r.Value)
+	case KindDrawdown:
+		return fmt.Sprintf("drawdown %s %g%%", op, r.Value)
+	default:
+		return fmt.Sprintf("%s %s %g", r.Symbol, op, r.Value)
+	}
+}
+
+// cooldown 규칙별 재알림 간격 (0이면 기본값)
+func (r Rule) cooldown() time.Duration {
+	if r.Cooldown > 0 {
+		return r.Cooldown
+	}
+	return DefaultCooldown
+}
+
+func normalizeSymbol(s string) string {
+	if symbols.IsKoreanSymbol(s) {
+		return s
+	}
+	return strings.ToUpper(s)
+}
+
+func inferMarket(symbol string) string {
+	switch {
+	case strings.HasPrefix(symbol, "KRW-"):
+		return "crypto"
+	case symbols.IsKoreanSymbol(symbol):
+		return "kr"
+	default:
+		return "us"
+	}
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"math"
 
+	"traveler/internal/scoring"
 	"traveler/pkg/model"
 )
 
@@ -24,24 +25,24 @@ func (t *TechnicalAnalyzer) Analyze(dayPatterns []model.DayPattern, dailyCandles
 
 	// Calculate RSI if we have enough daily data
 	if len(dailyCandles) >= 14 {
-		analysis.RSI = t.calculateRSI(dailyCandles, 14)
-		analysis.RSISignal = t.getRSISignal(analysis.RSI)
+		analysis.RSI = scoring.RSI(dailyCandles, 14)
+		analysis.RSISignal = scoring.RSISignal(analysis.RSI)
 	}
 
 	// Calculate volume ratio
 	if len(dailyCandles) >= 20 {
-		analysis.VolumeRatio = t.calculateVolumeRatio(dailyCandles, 20)
-		analysis.VolumeSignal = t.getVolumeSignal(analysis.VolumeRatio)
+		analysis.VolumeRatio = scoring.VolumeRatio(dailyCandles, 20)
+		analysis.VolumeSignal = scoring.VolumeSignal(analysis.VolumeRatio)
 	}
 
 	// Calculate price vs moving averages
 	if len(dailyCandles) >= 5 {
-		analysis.PriceVsMA5 = t.calculatePriceVsMA(dailyCandles, 5)
+		analysis.PriceVsMA5 = scoring.PriceVsMA(dailyCandles, 5)
 	}
 	if len(dailyCandles) >= 20 {
-		analysis.PriceVsMA20 = t.calculatePriceVsMA(dailyCandles, 20)
+		analysis.PriceVsMA20 = scoring.PriceVsMA(dailyCandles, 20)
 	}
-	analysis.TrendSignal = t.getTrendSignal(analysis.PriceVsMA5, analysis.PriceVsMA20)
+	analysis.TrendSignal = scoring.TrendSignal(analysis.PriceVsMA5, analysis.PriceVsMA20)
 
 	// Calculate pattern-specific metrics
 	analysis.PatternStrength = t.calculatePatternStrength(dayPatterns)
@@ -49,108 +50,12 @@ func (t *TechnicalAnalyzer) Analyze(dayPatterns []model.DayPattern, dailyCandles
 
 	// Calculate continuation probability
 	analysis.ContinuationProb = t.calculateContinuationProbability(analysis, dayPatterns)
-	analysis.Recommendation = t.getRecommendation(analysis.ContinuationProb)
+	analysis.Recommendation = scoring.Recommendation(analysis.ContinuationProb)
 
-	return analysis
-}
-
-// calculateRSI calculates the Relative Strength Index
-func (t *TechnicalAnalyzer) calculateRSI(candles []model.Candle, period int) float64 {
-	if len(candles) < period+1 {
-		return 50 // neutral
-	}
-
-	var gains, losses float64
-	for i := len(candles) - period; i < len(candles); i++ {
-		change := candles[i].Close - candles[i-1].Close
-		if change > 0 {
-			gains += change
-		} else {
-			losses -= change
-		}
-	}
-
-	avgGain := gains / float64(period)
-	avgLoss := losses / float64(period)
-
-	if avgLoss == 0 {
-		return 100
-	}
-
-	rs := avgGain / avgLoss
-	rsi := 100 - (100 / (1 + rs))
-
-	return math.Round(rsi*100) / 100
-}
-
-// getRSISignal interprets RSI value
-func (t *TechnicalAnalyzer) getRSISignal(rsi float64) string {
-	if rsi < 30 {
-		return "oversold"
-	} else if rsi > 70 {
-		return "overbought"
-	}
-	return "neutral"
-}
-
-// calculateVolumeRatio calculates today's volume vs average
-func (t *TechnicalAnalyzer) calculateVolumeRatio(candles []model.Candle, period int) float64 {
-	if len(candles) < period {
-		return 1.0
-	}
-
-	var sum int64
-	for i := len(candles) - period; i < len(candles)-1; i++ {
-		sum += candles[i].Volume
-	}
-	avgVolume := float64(sum) / float64(period-1)
-
-	if avgVolume == 0 {
-		return 1.0
-	}
-
-	todayVolume := float64(candles[len(candles)-1].Volume)
-	return math.Round(todayVolume/avgVolume*100) / 100
-}
-
-// getVolumeSignal interprets volume ratio
-func (t *TechnicalAnalyzer) getVolumeSignal(ratio float64) string {
-	if ratio < 0.7 {
-		return "low"
-	} else if ratio > 1.5 {
-		return "high"
-	}
-	return "normal"
-}
-
-// calculatePriceVsMA calculates price position vs moving average
-func (t *TechnicalAnalyzer) calculatePriceVsMA(candles []model.Candle, period int) float64 {
-	if len(candles) < period {
-		return 0
-	}
-
-	var sum float64
-	for i := len(candles) - period; i < len(candles); i++ {
-		sum += candles[i].Close
-	}
-	ma := sum / float64(period)
-
-	if ma == 0 {
-		return 0
-	}
+	// 전략 시그널과 같은 척도의 통합 점수 (패턴 강도+일관성을 셋업 강도로)
+	analysis.Score = scoring.Composite((analysis.PatternStrength+analysis.ConsistencyScore)/2, analysis)
 
-	currentPrice := candles[len(candles)-1].Close
-	return math.Round((currentPrice-ma)/ma*10000) / 100 // percentage with 2 decimals
-}
-
-// getTrendSignal determines trend based on MA positions
-func (t *TechnicalAnalyzer) getTrendSignal(priceVsMA5, priceVsMA20 float64) string {
-	if priceVsMA5 > 1 && priceVsMA20 > 1 {
-		return "uptrend"
-	} else if priceVsMA5 < -1 && priceVsMA20 < -1 {
-		return "downtrend"
-	}
-	return "neutral"
+	return analysis
 }
 
 // calculatePatternStrength calculates how strong the pattern is
@@ -217,28 +122,10 @@ func (t *TechnicalAnalyzer) calculateContinuationProbability(analysis *model.Tec
 	score += analysis.ConsistencyScore * 0.25
 
 	// Factor 3: RSI - oversold is favorable (0-20 points)
-	if analysis.RSI > 0 {
-		if analysis.RSI < 30 {
-			score += 20 // Oversold - good for bounce
-		} else if analysis.RSI < 50 {
-			score += 15
-		} else if analysis.RSI < 70 {
-			score += 10
-		} else {
-			score += 5 // Overbought - less favorable
-		}
-	}
+	score += scoring.RSIPoints(analysis.RSI)
 
 	// Factor 4: Volume - high volume confirms pattern (0-15 points)
-	if analysis.VolumeRatio > 0 {
-		if analysis.VolumeRatio > 1.5 {
-			score += 15
-		} else if analysis.VolumeRatio > 1.0 {
-			score += 10
-		} else {
-			score += 5
-		}
-	}
+	score += scoring.VolumePoints(analysis.VolumeRatio)
 
 	// Factor 5: Consecutive days (0-15 points)
 	consecutiveDays := len(patterns)
@@ -259,18 +146,6 @@ func (t *TechnicalAnalyzer) calculateContinuationProbability(analysis *model.Tec
 	return math.Round(probability*100) / 100
 }
 
-// getRecommendation provides a recommendation based on probability
-func (t *TechnicalAnalyzer) getRecommendation(probability float64) string {
-	if probability >= 70 {
-		return "strong"
-	} else if probability >= 50 {
-		return "moderate"
-	} else if probability >= 30 {
-		return "weak"
-	}
-	return "avoid"
-}
-
 // AnalyzeFromIntraday performs analysis using intraday data when daily data is limited
 func (t *TechnicalAnalyzer) AnalyzeFromIntraday(dayPatterns []model.DayPattern, intradayData []model.IntradayData) *model.TechnicalAnalysis {
 	if len(dayPatterns) == 0 {
@@ -0,0 +1,103 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ContributionSchedule 정기 입금/출금 (적립식 계좌 성장 시뮬레이션)
+// Amount > 0 입금, < 0 출금. 기간이 바뀐 첫 거래일(시작일 제외)에 현금으로 반영된다.
+type ContributionSchedule struct {
+	Amount float64
+	Every  string // "weekly", "monthly", "quarterly", "yearly"
+}
+
+// ParseContributionEvery 주기 문자열 검증 (빈 값 = monthly)
+func ParseContributionEvery(s string) (string, error) {
+	switch every := strings.ToLower(strings.TrimSpace(s)); every {
+	case "":
+		return "monthly", nil
+	case "weekly", "monthly", "quarterly", "yearly":
+		return every, nil
+	default:
+		return "", fmt.Errorf("invalid contribution frequency %q (weekly, monthly, quarterly, yearly)", s)
+	}
+}
+
+// Enabled 입출금 금액이 설정됐는지
+func (c ContributionSchedule) Enabled() bool {
+	return c.Amount != 0
+}
+
+// due prev 거래일과 date 거래일이 서로 다른 기간이면 true (해당 기간 첫 거래일)
+func (c ContributionSchedule) due(prev, date time.Time) bool {
+	if !c.Enabled() || prev.IsZero() {
+		return false
+	}
+	switch c.Every {
+	case "weekly":
+		py, pw := prev.ISOWeek()
+		y, w := date.ISOWeek()
+		return py != y || pw != w
+	case "quarterly":
+		return prev.Year() != date.Year() || (prev.Month()-1)/3 != (date.Month()-1)/3
+	case "yearly":
+		return prev.Year() != date.Year()
+	default:
+		return prev.Year() != date.Year() || prev.Month() != date.Month()
+	}
+}
+
+// CashFlow 투자자 관점 현금흐름 (입금 음수, 출금/최종 평가액 양수)
+type CashFlow struct {
+	Date   time.Time `json:"date"`
+	Amount float64   `json:"amount"`
+}
+
+// XIRR 날짜별 현금흐름의 연환산 내부수익률 (금액가중 수익률, 0.12 = 12%)
+// 부호가 한 번도 바뀌지 않으면 해가 없으므로 false.
+func XIRR(flows []CashFlow) (float64, bool) {
+	if len(flows) < 2 {
+		return 0, false
+	}
+	var pos, neg bool
+	for _, f := range flows {
+		pos = pos || f.Amount > 0
+		neg = neg || f.Amount < 0
+	}
+	if !pos || !neg {
+		return 0, false
+	}
+
+	start := flows[0].Date
+	npv := func(rate float64) float64 {
+		var sum float64
+		for _, f := range flows {
+			years := f.Date.Sub(start).Hours() / 24 / 365
+			sum += f.Amount / math.Pow(1+rate, years)
+		}
+		return sum
+	}
+
+	// NPV는 rate에 대해 단조 감소 (입금이 먼저 오는 일반적인 경우) → 이분법
+	lo, hi := -0.9999, 10.0
+	flo, fhi := npv(lo), npv(hi)
+	if flo*fhi > 0 {
+		return 0, false
+	}
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		fm := npv(mid)
+		if math.Abs(fm) < 1e-7 || hi-lo < 1e-10 {
+			return mid, true
+		}
+		if fm*flo > 0 {
+			lo, flo = mid, fm
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2, true
+}
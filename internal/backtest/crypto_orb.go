@@ -6,6 +6,7 @@ import (
 	"sort"
 	"time"
 
+	"traveler/internal/trader"
 	"traveler/pkg/model"
 )
 
@@ -641,9 +642,7 @@ func (bt *CryptoBacktester) buildResult(trades []CryptoTrade, equity []float64,
 
 	// Kelly
 	if result.AvgLoss > 0 {
-		winProb := result.WinRate / 100
-		b := result.AvgWin / result.AvgLoss
-		result.KellyOptimal = math.Max(0, (winProb*b-(1-winProb))/b)
+		result.KellyOptimal = trader.KellyFraction(result.WinRate/100, result.AvgWin, result.AvgLoss)
 		result.KellyHalf = result.KellyOptimal / 2
 	}
 
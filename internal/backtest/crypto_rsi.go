@@ -9,6 +9,7 @@ import (
 
 	"traveler/internal/provider"
 	"traveler/internal/strategy"
+	"traveler/internal/trader"
 	"traveler/pkg/model"
 )
 
@@ -479,9 +480,7 @@ func (bt *CryptoRSIBacktester) buildResult(trades []CryptoTrade, equity []float6
 	}
 
 	if result.AvgLoss > 0 {
-		winProb := result.WinRate / 100
-		b := result.AvgWin / result.AvgLoss
-		result.KellyOptimal = math.Max(0, (winProb*b-(1-winProb))/b)
+		result.KellyOptimal = trader.KellyFraction(result.WinRate/100, result.AvgWin, result.AvgLoss)
 		result.KellyHalf = result.KellyOptimal / 2
 	}
 
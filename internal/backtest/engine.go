@@ -2,11 +2,14 @@ package backtest
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"sort"
 	"time"
 
 	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
 	"traveler/pkg/model"
 )
 
@@ -24,7 +27,42 @@ type Trade struct {
 	PnLPct     float64   `json:"pnl_pct"`    // Profit/Loss percentage
 	RMultiple  float64   `json:"r_multiple"` // Return in R (risk units)
 	IsWin      bool      `json:"is_win"`
-	ExitReason string    `json:"exit_reason"` // "target", "stop", "timeout"
+	ExitReason string    `json:"exit_reason"` // "target", "stop", "gap_stop", "timeout"
+	Dividends  float64   `json:"dividends,omitempty"` // Dividends received (held through ex-date), included in PnL
+	GapLoss    float64   `json:"gap_loss,omitempty"`  // Extra loss from opening below the stop (filled at the open)
+}
+
+// tradeRDistribution R-multiple histogram of completed trades
+func tradeRDistribution(trades []Trade) *trader.RDistribution {
+	rs := make([]float64, len(trades))
+	for i, t := range trades {
+		rs[i] = t.RMultiple
+	}
+	return trader.NewRDistribution(rs)
+}
+
+// tradeExitAnalytics P&L and win rate by exit reason and holding period (trading days)
+func tradeExitAnalytics(trades []Trade) *trader.ExitAnalytics {
+	samples := make([]trader.ExitSample, len(trades))
+	for i, t := range trades {
+		samples[i] = trader.ExitSample{
+			Reason:   t.ExitReason,
+			HoldDays: trader.TradingDaysBetween(t.EntryDate, t.ExitDate),
+			PnL:      t.PnL,
+			PnLPct:   t.PnLPct,
+			R:        t.RMultiple,
+			HasR:     true,
+		}
+	}
+	return trader.AnalyzeExits(samples)
+}
+
+// initialStop 진입 손절가: 손절 모델이 있으면 결정일까지의 캔들로 계산, 없거나 계산 불가면 고정 비율
+func initialStop(m strategy.StopModel, history []model.Candle, entryPrice, stopLossPct float64) float64 {
+	if stop := m.StopPrice(history, entryPrice); stop > 0 {
+		return stop
+	}
+	return entryPrice * (1 - stopLossPct)
 }
 
 // BacktestResult contains the complete backtest results
@@ -65,6 +103,11 @@ type BacktestResult struct {
 	MaxLoseStreak   int           `json:"max_lose_streak"`
 	CurrentStreak   int           `json:"current_streak"`
 
+	// R-multiple distribution, exit reason / holding period breakdown
+	RDistribution   *trader.RDistribution `json:"r_distribution,omitempty"`
+	Exits           *trader.ExitAnalytics `json:"exit_analytics,omitempty"`
+	Gaps            *GapStats             `json:"gap_stats,omitempty"`
+
 	// Individual trades
 	Trades          []Trade       `json:"trades"`
 
@@ -80,19 +123,28 @@ type BacktestConfig struct {
 	TargetRMultiple float64   // e.g., 2.0 = 2R target
 	MaxHoldDays     int       // Maximum days to hold
 	Commission      float64   // Per trade commission rate
+	SellTax         float64   // Transaction tax on sells (e.g., KR securities tax)
 	Slippage        float64   // Expected slippage
+	SymbolSlippage  map[string]float64 // Per-symbol slippage (liquidity model), overrides Slippage
+	Dividends       map[string][]provider.DividendEvent // Per-symbol dividend history, credited when held through ex-date
+	AuditLookahead  bool      // 시그널/체결이 결정일 이후 데이터를 쓰면 즉시 실패
+	StopModel       strategy.StopModel // 손절 모델 (비어 있으면 StopLossPct 고정 손절)
+	From            time.Time // 시뮬레이션 시작일 (zero = 최근 days 일봉)
+	To              time.Time // 시뮬레이션 종료일 (zero = 최신)
 }
 
-// DefaultBacktestConfig returns default configuration
-func DefaultBacktestConfig() BacktestConfig {
+// DefaultBacktestConfig returns default configuration; costs come from the market's active fee preset.
+func DefaultBacktestConfig(market string) BacktestConfig {
+	costs := trader.CostModelForMarket(market)
 	return BacktestConfig{
 		InitialCapital:  10000000, // 1000만원
 		RiskPerTrade:    0.01,     // 1%
 		StopLossPct:     0.02,     // 2%
 		TargetRMultiple: 2.0,      // 2R target
 		MaxHoldDays:     5,        // 5 trading days max
-		Commission:      0.00015,  // 0.015%
-		Slippage:        0.001,    // 0.1%
+		Commission:      costs.Commission,
+		SellTax:         costs.SellTax,
+		Slippage:        costs.Slippage,
 	}
 }
 
@@ -112,29 +164,60 @@ func NewBacktester(cfg BacktestConfig, p provider.Provider) *Backtester {
 
 // RunPullbackBacktest backtests the pullback strategy
 func (b *Backtester) RunPullbackBacktest(ctx context.Context, symbol string, days int) (*BacktestResult, error) {
-	// Get historical daily data
-	candles, err := b.provider.GetDailyCandles(ctx, symbol, days)
+	// Get historical daily data (과거 구간 지정 시 시작일 전 지표 계산용 60봉까지)
+	fetchDays := days
+	if !b.config.From.IsZero() {
+		if n := tradingDaysSince(b.config.From, time.Now()) + 60; n > fetchDays {
+			fetchDays = n
+		}
+	}
+	candles, err := b.provider.GetDailyCandles(ctx, symbol, fetchDays)
 	if err != nil {
 		return nil, err
 	}
+	if !b.config.To.IsZero() {
+		end := b.config.To.Format("2006-01-02")
+		for len(candles) > 0 && candles[len(candles)-1].Time.Format("2006-01-02") > end {
+			candles = candles[:len(candles)-1]
+		}
+	}
 
 	if len(candles) < 60 {
 		return nil, nil // Not enough data
 	}
 
+	// 첫 결정일: 지표용 60봉 이후, 구간 지정 시 시작일 이후
+	start, periodStart := 60, 0
+	if !b.config.From.IsZero() {
+		from := b.config.From.Format("2006-01-02")
+		for start < len(candles) && candles[start].Time.Format("2006-01-02") < from {
+			start++
+		}
+		if start >= len(candles) {
+			return nil, nil // 구간에 데이터 없음
+		}
+		periodStart = start
+	}
+
 	result := &BacktestResult{
 		Strategy: "pullback",
-		Period:   candles[0].Time.Format("2006-01-02") + " ~ " + candles[len(candles)-1].Time.Format("2006-01-02"),
+		Period:   candles[periodStart].Time.Format("2006-01-02") + " ~ " + candles[len(candles)-1].Time.Format("2006-01-02"),
 		Trades:   make([]Trade, 0),
 	}
 
 	capital := b.config.InitialCapital
 	equity := []float64{capital}
+	slippage := b.slippageFor(symbol)
 	peakEquity := capital
 
 	// Simulate trading
-	for i := 60; i < len(candles)-b.config.MaxHoldDays; i++ {
+	for i := start; i < len(candles)-b.config.MaxHoldDays; i++ {
 		// Check for pullback signal
+		if b.config.AuditLookahead {
+			if err := auditDecision(symbol, candles, i, candles[i].Time, b.decisionKey); err != nil {
+				return nil, err
+			}
+		}
 		signal := b.checkPullbackSignal(candles[:i+1])
 		if !signal {
 			equity = append(equity, capital)
@@ -143,11 +226,16 @@ func (b *Backtester) RunPullbackBacktest(ctx context.Context, symbol string, day
 
 		// Entry next day at open
 		entryCandle := candles[i+1]
-		entryPrice := entryCandle.Open * (1 + b.config.Slippage) // Add slippage
+		if b.config.AuditLookahead {
+			if err := auditFill(symbol, candles[i].Time, entryCandle, entryCandle.Open); err != nil {
+				return nil, err
+			}
+		}
+		entryPrice := entryCandle.Open * (1 + slippage) // Add slippage
 
 		// Calculate position size
 		riskAmount := capital * b.config.RiskPerTrade
-		stopLoss := entryPrice * (1 - b.config.StopLossPct)
+		stopLoss := initialStop(b.config.StopModel, candles[:i+1], entryPrice, b.config.StopLossPct)
 		riskPerShare := entryPrice - stopLoss
 		shares := int(riskAmount / riskPerShare)
 
@@ -172,18 +260,23 @@ func (b *Backtester) RunPullbackBacktest(ctx context.Context, symbol string, day
 		for j := i + 1; j <= i+b.config.MaxHoldDays && j < len(candles); j++ {
 			dayCandle := candles[j]
 
-			// Check stop loss (using low)
+			// Check stop loss (using low); gapping below the stop fills at the open
 			if dayCandle.Low <= stopLoss {
+				fill, gapped := gapStopFill(dayCandle.Open, stopLoss)
 				trade.ExitDate = dayCandle.Time
-				trade.ExitPrice = stopLoss * (1 - b.config.Slippage)
+				trade.ExitPrice = fill * (1 - slippage)
 				trade.ExitReason = "stop"
+				if gapped {
+					trade.ExitReason = "gap_stop"
+					trade.GapLoss = float64(shares) * (stopLoss - fill)
+				}
 				break
 			}
 
 			// Check target (using high)
 			if dayCandle.High >= target {
 				trade.ExitDate = dayCandle.Time
-				trade.ExitPrice = target * (1 - b.config.Slippage)
+				trade.ExitPrice = target * (1 - slippage)
 				trade.ExitReason = "target"
 				break
 			}
@@ -191,7 +284,7 @@ func (b *Backtester) RunPullbackBacktest(ctx context.Context, symbol string, day
 			// Timeout - exit at close on last day
 			if j == i+b.config.MaxHoldDays || j == len(candles)-1 {
 				trade.ExitDate = dayCandle.Time
-				trade.ExitPrice = dayCandle.Close * (1 - b.config.Slippage)
+				trade.ExitPrice = dayCandle.Close * (1 - slippage)
 				trade.ExitReason = "timeout"
 				break
 			}
@@ -199,8 +292,10 @@ func (b *Backtester) RunPullbackBacktest(ctx context.Context, symbol string, day
 
 		// Calculate P&L
 		grossPnL := float64(trade.Shares) * (trade.ExitPrice - trade.EntryPrice)
-		commission := float64(trade.Shares) * trade.EntryPrice * b.config.Commission * 2
-		trade.PnL = grossPnL - commission
+		commission := float64(trade.Shares)*trade.EntryPrice*b.config.Commission +
+			float64(trade.Shares)*trade.ExitPrice*(b.config.Commission+b.config.SellTax)
+		trade.Dividends = dividendIncome(b.config.Dividends[symbol], trade.Shares, trade.EntryDate, trade.ExitDate)
+		trade.PnL = grossPnL - commission + trade.Dividends
 		trade.PnLPct = trade.PnL / (float64(trade.Shares) * trade.EntryPrice) * 100
 		trade.RMultiple = (trade.ExitPrice - trade.EntryPrice) / riskPerShare
 		trade.IsWin = trade.PnL > 0
@@ -225,6 +320,24 @@ func (b *Backtester) RunPullbackBacktest(ctx context.Context, symbol string, day
 	return result, nil
 }
 
+// dividendIncome returns dividends received for shares held from entry through exit.
+// Ex-date on the entry day is excluded (bought ex-dividend), on the exit day included.
+func dividendIncome(history []provider.DividendEvent, shares int, entry, exit time.Time) float64 {
+	var total float64
+	for _, ev := range provider.DividendsBetween(history, entry, exit) {
+		total += ev.Amount * float64(shares)
+	}
+	return total
+}
+
+// slippageFor returns per-symbol slippage if estimated, else the global default
+func (b *Backtester) slippageFor(symbol string) float64 {
+	if s, ok := b.config.SymbolSlippage[symbol]; ok {
+		return s
+	}
+	return b.config.Slippage
+}
+
 // checkPullbackSignal checks for pullback entry signal
 func (b *Backtester) checkPullbackSignal(candles []model.Candle) bool {
 	if len(candles) < 50 {
@@ -268,6 +381,11 @@ func (b *Backtester) checkPullbackSignal(candles []model.Candle) bool {
 	return true
 }
 
+// decisionKey summarizes the entry decision for the lookahead audit
+func (b *Backtester) decisionKey(candles []model.Candle) string {
+	return fmt.Sprintf("signal=%v", b.checkPullbackSignal(candles))
+}
+
 func calculateMA(candles []model.Candle, period int) float64 {
 	if len(candles) < period {
 		return 0
@@ -298,6 +416,9 @@ func (b *Backtester) calculateStats(result *BacktestResult, equity []float64, in
 
 	result.TotalTrades = len(result.Trades)
 	result.EquityCurve = equity
+	result.RDistribution = tradeRDistribution(result.Trades)
+	result.Exits = tradeExitAnalytics(result.Trades)
+	result.Gaps = tradeGapStats(result.Trades)
 
 	var totalWin, totalLoss float64
 	var winCount, lossCount int
@@ -383,10 +504,7 @@ func (b *Backtester) calculateStats(result *BacktestResult, equity []float64, in
 
 	// Kelly Criterion
 	if result.AvgLoss > 0 {
-		winProb := result.WinRate / 100
-		b := result.AvgWin / result.AvgLoss
-		result.KellyOptimal = (winProb*b - (1 - winProb)) / b
-		result.KellyOptimal = math.Max(0, result.KellyOptimal)
+		result.KellyOptimal = trader.KellyFraction(result.WinRate/100, result.AvgWin, result.AvgLoss)
 		result.KellyHalf = result.KellyOptimal / 2
 	}
 
@@ -0,0 +1,176 @@
+package backtest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Experiment 백테스트 실행 기록 (실험 레지스트리 항목)
+type Experiment struct {
+	ID         string    `json:"id"`          // git 스타일 짧은 해시 (실행마다 고유)
+	ConfigHash string    `json:"config_hash"` // 같은 설정이면 같은 값
+	CreatedAt  time.Time `json:"created_at"`
+	Kind       string    `json:"kind"`
+	Strategy   string    `json:"strategy"`
+	Period     string    `json:"period"`
+	SavedAs    string    `json:"saved_as,omitempty"` // --bt-save 경로
+	Note       string    `json:"note,omitempty"`
+	Params     RunParams `json:"params"`
+	Stats      RunStats  `json:"stats"`
+}
+
+// ConfigHash 실행 설정 해시 (종목 순서 무관, 12자리)
+func ConfigHash(kind, strategy string, p RunParams) string {
+	syms := append([]string(nil), p.Symbols...)
+	sort.Strings(syms)
+	p.Symbols = syms
+	raw, _ := json.Marshal(struct {
+		Kind     string    `json:"kind"`
+		Strategy string    `json:"strategy"`
+		Params   RunParams `json:"params"`
+	}{kind, strategy, p})
+	sum := sha1.Sum(raw)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// ExperimentStore 실험 기록 저장소 (dataDir/experiments.json, 최신 항목이 뒤)
+type ExperimentStore struct {
+	mu          sync.Mutex
+	path        string
+	experiments []Experiment
+}
+
+// NewExperimentStore 생성자
+func NewExperimentStore(dataDir string) (*ExperimentStore, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	s := &ExperimentStore{path: filepath.Join(dataDir, "experiments.json")}
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &s.experiments); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// Record 실행 결과 기록. 같은 설정의 이전 실행이 있으면 함께 반환 (최신순).
+func (s *ExperimentStore) Record(run *SavedRun, savedAs string) (Experiment, []Experiment, error) {
+	hash := ConfigHash(run.Kind, run.Strategy, run.Params)
+	exp := Experiment{
+		ConfigHash: hash,
+		CreatedAt:  run.CreatedAt,
+		Kind:       run.Kind,
+		Strategy:   run.Strategy,
+		Period:     run.Period,
+		SavedAs:    savedAs,
+		Params:     run.Params,
+		Stats:      run.Stats,
+	}
+	if exp.CreatedAt.IsZero() {
+		exp.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prior := s.byHashLocked(hash)
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d|%d", hash, exp.CreatedAt.UnixNano(), len(s.experiments))))
+	exp.ID = hex.EncodeToString(sum[:])[:7]
+
+	s.experiments = append(s.experiments, exp)
+	if err := s.persist(); err != nil {
+		s.experiments = s.experiments[:len(s.experiments)-1]
+		return Experiment{}, nil, err
+	}
+	return exp, prior, nil
+}
+
+// All 전체 기록 (최신순)
+func (s *ExperimentStore) All() []Experiment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Experiment, len(s.experiments))
+	for i, e := range s.experiments {
+		out[len(out)-1-i] = e
+	}
+	return out
+}
+
+// Find ID 접두어로 조회 (git처럼 4자 이상 고유 접두어 허용)
+func (s *ExperimentStore) Find(prefix string) (*Experiment, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if len(prefix) < 4 {
+		return nil, fmt.Errorf("experiment id must be at least 4 characters")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found *Experiment
+	for i := range s.experiments {
+		if strings.HasPrefix(s.experiments[i].ID, prefix) {
+			if found != nil {
+				return nil, fmt.Errorf("experiment id %s is ambiguous", prefix)
+			}
+			e := s.experiments[i]
+			found = &e
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("experiment %s not found", prefix)
+	}
+	return found, nil
+}
+
+// ByHash 같은 설정 해시(접두어)의 실행 기록 (최신순)
+func (s *ExperimentStore) ByHash(hash string) []Experiment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.byHashLocked(hash)
+}
+
+// SetNote 실험 메모 설정
+func (s *ExperimentStore) SetNote(id, note string) error {
+	exp, err := s.Find(id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.experiments {
+		if s.experiments[i].ID == exp.ID {
+			s.experiments[i].Note = note
+		}
+	}
+	return s.persist()
+}
+
+func (s *ExperimentStore) byHashLocked(hash string) []Experiment {
+	var out []Experiment
+	for i := len(s.experiments) - 1; i >= 0; i-- {
+		if strings.HasPrefix(s.experiments[i].ConfigHash, hash) {
+			out = append(out, s.experiments[i])
+		}
+	}
+	return out
+}
+
+func (s *ExperimentStore) persist() error {
+	raw, err := json.MarshalIndent(s.experiments, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, raw, 0644)
+}
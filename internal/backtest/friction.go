@@ -0,0 +1,126 @@
+package backtest
+
+// FrictionCosts 백테스트에 쓰인 거래 비용 (거래 목록에서 슬리피지를 되돌리는 기준)
+type FrictionCosts struct {
+	Commission     float64
+	SellTax        float64
+	Slippage       float64
+	SymbolSlippage map[string]float64
+}
+
+func (c FrictionCosts) slippageFor(symbol string) float64 {
+	if s, ok := c.SymbolSlippage[symbol]; ok {
+		return s
+	}
+	return c.Slippage
+}
+
+// FrictionLevel 비용 배수 하나에 대한 재계산 결과 (손익은 수수료/세금/배당 반영 순손익)
+type FrictionLevel struct {
+	Multiplier   float64 `json:"multiplier"`
+	Slippage     float64 `json:"slippage"`   // 기본 슬리피지 × 배수
+	Commission   float64 `json:"commission"` // 수수료 × 배수
+	TotalPnL     float64 `json:"total_pnl"`
+	Expectancy   float64 `json:"expectancy"`   // 거래당 평균 순손익
+	ExpectancyR  float64 `json:"expectancy_r"` // 거래당 평균 순손익 / 진입 리스크
+	WinRate      float64 `json:"win_rate"`
+	ProfitFactor float64 `json:"profit_factor"`
+}
+
+// FrictionReport 슬리피지/수수료 민감도
+type FrictionReport struct {
+	Trades    int             `json:"trades"`
+	Levels    []FrictionLevel `json:"levels"`
+	Breakeven float64         `json:"breakeven"` // 기대값이 0이 되는 비용 배수 (0 = 비용 0에서도 손실, -1 = 10배에서도 유지)
+	Fragile   bool            `json:"fragile"`   // 2배 비용 이내에서 엣지가 사라짐
+}
+
+// DefaultFrictionMultipliers 0.5x ~ 3x
+var DefaultFrictionMultipliers = []float64{0.5, 1, 1.5, 2, 3}
+
+// fragileMultiplier 현실적인 비용 상한 (백테스트 가정의 2배): 이 안에서 엣지가 사라지면 취약
+const fragileMultiplier = 2.0
+
+// AnalyzeFriction 완료된 거래 목록을 비용 배수별로 다시 계산
+// 체결가에서 원래 슬리피지를 걷어낸 뒤 배수만큼 다시 적용하고, 수수료도 배수만큼 늘린다 (세금은 고정).
+func AnalyzeFriction(trades []Trade, costs FrictionCosts, multipliers []float64) *FrictionReport {
+	if len(trades) == 0 {
+		return nil
+	}
+	r := &FrictionReport{Trades: len(trades)}
+	for _, m := range multipliers {
+		r.Levels = append(r.Levels, frictionLevel(trades, costs, m))
+	}
+
+	// 기대값은 배수에 대해 단조 감소 → 이분 탐색으로 손익분기 배수
+	expectancyAt := func(m float64) float64 { return frictionLevel(trades, costs, m).Expectancy }
+	switch {
+	case expectancyAt(0) <= 0:
+		r.Breakeven = 0
+	case expectancyAt(10) > 0:
+		r.Breakeven = -1
+	default:
+		lo, hi := 0.0, 10.0
+		for i := 0; i < 40; i++ {
+			mid := (lo + hi) / 2
+			if expectancyAt(mid) > 0 {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		r.Breakeven = (lo + hi) / 2
+	}
+	r.Fragile = r.Breakeven >= 0 && r.Breakeven <= fragileMultiplier
+	return r
+}
+
+func frictionLevel(trades []Trade, costs FrictionCosts, m float64) FrictionLevel {
+	lvl := FrictionLevel{Multiplier: m, Slippage: costs.Slippage * m, Commission: costs.Commission * m}
+	var grossWin, grossLoss, sumR float64
+	var wins, rTrades int
+	for _, t := range trades {
+		slip := costs.slippageFor(t.Symbol)
+		rawEntry := t.EntryPrice / (1 + slip)
+		rawExit := t.ExitPrice / (1 - slip)
+		entry := rawEntry * (1 + slip*m)
+		exit := rawExit * (1 - slip*m)
+
+		shares := float64(t.Shares)
+		pnl := shares*(exit-entry) -
+			shares*entry*costs.Commission*m -
+			shares*exit*(costs.Commission*m+costs.SellTax) +
+			t.Dividends
+		lvl.TotalPnL += pnl
+		if pnl > 0 {
+			wins++
+			grossWin += pnl
+		} else {
+			grossLoss -= pnl
+		}
+		if risk := shares * (t.EntryPrice - t.StopLoss); risk > 0 {
+			sumR += pnl / risk
+			rTrades++
+		}
+	}
+	n := float64(len(trades))
+	lvl.Expectancy = lvl.TotalPnL / n
+	lvl.WinRate = float64(wins) / n * 100
+	if rTrades > 0 {
+		lvl.ExpectancyR = sumR / float64(rTrades)
+	}
+	if grossLoss > 0 {
+		lvl.ProfitFactor = grossWin / grossLoss
+	}
+	return lvl
+}
+
+// FrictionCosts 단일 백테스트 비용 가정
+func (c BacktestConfig) FrictionCosts() FrictionCosts {
+	return FrictionCosts{Commission: c.Commission, SellTax: c.SellTax, Slippage: c.Slippage, SymbolSlippage: c.SymbolSlippage}
+}
+
+// FrictionCosts 포트폴리오 백테스트 비용 가정
+func (c PortfolioBacktestConfig) FrictionCosts() FrictionCosts {
+	return FrictionCosts{Commission: c.Commission, SellTax: c.SellTax, Slippage: c.Slippage, SymbolSlippage: c.SymbolSlippage}
+}
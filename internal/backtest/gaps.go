@@ -0,0 +1,61 @@
+package backtest
+
+// 손절가 아래로 갭 하락 출발하면 손절가가 아니라 시가에 체결된다.
+// 손절가 체결 가정은 꼬리 손실을 과소평가하므로 갭 손절을 따로 집계한다.
+
+// GapStats 갭 손절 통계 (손실은 손절가 체결 대비 추가 손실)
+type GapStats struct {
+	Stops       int     `json:"stops"`         // 전체 손절 (갭 포함)
+	GapStops    int     `json:"gap_stops"`     // 시가가 손절가 아래라 시가에 체결된 손절
+	GapStopPct  float64 `json:"gap_stop_pct"`  // 손절 중 갭 손절 비율 (%)
+	GapLoss     float64 `json:"gap_loss"`      // 손절가 대비 추가 손실 합계
+	AvgGapPct   float64 `json:"avg_gap_pct"`   // 시가가 손절가보다 낮았던 폭 평균 (%)
+	WorstGapPct float64 `json:"worst_gap_pct"` // 최대 갭 폭 (%)
+	WorstR      float64 `json:"worst_r"`       // 갭 손절 중 최악 R
+	AvgR        float64 `json:"avg_r"`         // 갭 손절 평균 R (-1R보다 얼마나 나쁜지)
+}
+
+// gapStopFill 손절 체결가 (슬리피지 전): 시가가 손절가 이하면 시가, 아니면 손절가
+func gapStopFill(open, stop float64) (float64, bool) {
+	if open > 0 && open < stop {
+		return open, true
+	}
+	return stop, false
+}
+
+// tradeGapStats 거래 목록 → 갭 손절 통계 (손절이 없으면 nil)
+func tradeGapStats(trades []Trade) *GapStats {
+	st := &GapStats{}
+	var sumGap, sumR float64
+	for _, t := range trades {
+		switch t.ExitReason {
+		case "stop":
+			st.Stops++
+		case "gap_stop":
+			st.Stops++
+			st.GapStops++
+			st.GapLoss += t.GapLoss
+			gapPct := 0.0
+			if t.StopLoss > 0 && t.Shares > 0 {
+				gapPct = t.GapLoss / float64(t.Shares) / t.StopLoss * 100
+			}
+			sumGap += gapPct
+			if gapPct > st.WorstGapPct {
+				st.WorstGapPct = gapPct
+			}
+			sumR += t.RMultiple
+			if st.GapStops == 1 || t.RMultiple < st.WorstR {
+				st.WorstR = t.RMultiple
+			}
+		}
+	}
+	if st.Stops == 0 {
+		return nil
+	}
+	st.GapStopPct = float64(st.GapStops) / float64(st.Stops) * 100
+	if st.GapStops > 0 {
+		st.AvgGapPct = sumGap / float64(st.GapStops)
+		st.AvgR = sumR / float64(st.GapStops)
+	}
+	return st
+}
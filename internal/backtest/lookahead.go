@@ -0,0 +1,72 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"traveler/pkg/model"
+)
+
+// LookaheadError 룩어헤드 감사 실패: 결정 시점 이후 데이터가 시그널/체결에 쓰임
+type LookaheadError struct {
+	Symbol string
+	Date   time.Time // 결정일 (시그널 기준일)
+	Check  string    // "history", "signal", "entry"
+	Detail string
+}
+
+func (e *LookaheadError) Error() string {
+	return fmt.Sprintf("lookahead audit failed [%s] %s @ %s: %s",
+		e.Check, e.Symbol, e.Date.Format("2006-01-02"), e.Detail)
+}
+
+// auditDecision candles[:idx+1] (결정일 종가까지)만으로 결정이 내려지는지 검증
+//  1. 히스토리가 시간순이고 마지막 캔들이 결정일
+//  2. idx 이후 캔들을 오염시킨 복사본으로 다시 계산해도 결과가 같음
+//     (슬라이스 capacity 너머의 미래 캔들을 읽거나 전체 시계열로 지표를 계산하는 버그 탐지)
+//
+// eval은 결정 결과를 비교 가능한 문자열로 돌려준다 (시그널 여부, 점수 등).
+func auditDecision(symbol string, candles []model.Candle, idx int, date time.Time, eval func([]model.Candle) string) error {
+	hist := candles[:idx+1]
+	for i := 1; i < len(hist); i++ {
+		if !hist[i].Time.After(hist[i-1].Time) {
+			return &LookaheadError{Symbol: symbol, Date: date, Check: "history",
+				Detail: fmt.Sprintf("candles out of order at %s", hist[i].Time.Format("2006-01-02"))}
+		}
+	}
+	if last := hist[len(hist)-1].Time; !sameDay(last, date) {
+		return &LookaheadError{Symbol: symbol, Date: date, Check: "history",
+			Detail: fmt.Sprintf("last candle is %s, not the decision day", last.Format("2006-01-02"))}
+	}
+
+	poisoned := make([]model.Candle, len(candles))
+	copy(poisoned, candles)
+	for i := idx + 1; i < len(poisoned); i++ {
+		c := &poisoned[i]
+		c.Open, c.High, c.Low, c.Close = c.Open*10, c.High*10, c.Low*10, c.Close*10
+		c.Volume *= 10
+	}
+	if want, got := eval(hist), eval(poisoned[:idx+1]); want != got {
+		return &LookaheadError{Symbol: symbol, Date: date, Check: "signal",
+			Detail: fmt.Sprintf("decision changes when future candles change (%s vs %s)", want, got)}
+	}
+	return nil
+}
+
+// auditFill 진입 체결이 결정일 다음 거래일 이후이고 그날 가격 범위 안인지 검증 (price는 슬리피지 전 가격)
+func auditFill(symbol string, decided time.Time, fill model.Candle, price float64) error {
+	if fill.Time.Format("2006-01-02") <= decided.Format("2006-01-02") {
+		return &LookaheadError{Symbol: symbol, Date: decided, Check: "entry",
+			Detail: fmt.Sprintf("filled on %s, not after the decision day", fill.Time.Format("2006-01-02"))}
+	}
+	if price < fill.Low || price > fill.High {
+		return &LookaheadError{Symbol: symbol, Date: decided, Check: "entry",
+			Detail: fmt.Sprintf("fill price %.4f outside %s range %.4f-%.4f",
+				price, fill.Time.Format("2006-01-02"), fill.Low, fill.High)}
+	}
+	return nil
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,243 @@
+package backtest
+
+import (
+	"math"
+	"time"
+
+	"traveler/internal/fees"
+	"traveler/internal/strategy"
+	"traveler/pkg/model"
+)
+
+// PairSimConfig 페어(롱 A / 숏 B) 백테스트 설정
+type PairSimConfig struct {
+	Params        strategy.PairsConfig // 진입/청산 z, 회귀 기간, 상관/반감기 필터 (Pairs는 무시)
+	Capital       float64
+	AllocPct      float64 // 거래당 롱+숏 총액 (자본 대비, 0 = 0.5)
+	MaxHoldDays   int     // 0 = 20 거래일
+	BorrowRatePct float64 // 숏 대차 비용 연율 % (0 = 1%, 음수 = 없음)
+	Market        string  // 수수료 스케줄 (기본 us)
+}
+
+// PairTrade 페어 거래 하나 (두 레그 합산 순손익)
+type PairTrade struct {
+	LongSymbol  string
+	ShortSymbol string
+	EntryDate   time.Time
+	ExitDate    time.Time
+	EntryZ      float64
+	ExitZ       float64
+	LongQty     float64
+	LongEntry   float64
+	LongExit    float64
+	ShortQty    float64
+	ShortEntry  float64
+	ShortExit   float64
+	PnL         float64 // 수수료·대차 비용 차감 후
+	PnLPct      float64 // 롱+숏 총액 대비 %
+	Costs       float64
+	HoldDays    int
+	ExitReason  string // "revert", "stop", "timeout", "end"
+}
+
+// PairSimResult 페어 백테스트 결과
+type PairSimResult struct {
+	SymbolA        string
+	SymbolB        string
+	Days           int
+	Trades         []PairTrade
+	TotalPnL       float64
+	ReturnPct      float64
+	WinRate        float64
+	MaxDrawdownPct float64
+	AvgHoldDays    float64
+}
+
+// pairPosition 보유 중인 페어 (진입 시점 회귀식으로 z를 계속 잰다)
+type pairPosition struct {
+	longA     bool // true: A 롱 / B 숏
+	stat      strategy.PairStat
+	entryIdx  int
+	entryZ    float64
+	longQty   float64
+	longPx    float64
+	shortQty  float64
+	shortPx   float64
+	entryCost float64
+}
+
+// PairSimulator 두 종목 일봉으로 페어 전략을 날짜별로 재현
+// 진입 조건은 PairsStrategy와 같고, 보유 중에는 진입 시점의 α/β/평균/표준편차로 z를 계산해
+// |z|가 ExitZ 안으로 돌아오면 청산, StopZ 밖으로 벌어지면 손절한다.
+type PairSimulator struct {
+	config PairSimConfig
+}
+
+// NewPairSimulator 생성자
+func NewPairSimulator(cfg PairSimConfig) *PairSimulator {
+	if cfg.AllocPct == 0 {
+		cfg.AllocPct = 0.5
+	}
+	if cfg.MaxHoldDays == 0 {
+		cfg.MaxHoldDays = 20
+	}
+	if cfg.BorrowRatePct == 0 {
+		cfg.BorrowRatePct = 1
+	}
+	if cfg.Market == "" {
+		cfg.Market = "us"
+	}
+	return &PairSimulator{config: cfg}
+}
+
+// Run A/B 일봉(날짜가 겹치는 봉만 사용)으로 마지막 days 거래일 시뮬레이션
+func (s *PairSimulator) Run(symA, symB string, candlesA, candlesB []model.Candle, days int) *PairSimResult {
+	a, b := alignCandles(candlesA, candlesB)
+	p := s.config.Params
+	res := &PairSimResult{SymbolA: symA, SymbolB: symB}
+	if len(a) <= p.Lookback {
+		return res
+	}
+	start := len(a) - days
+	if start < p.Lookback {
+		start = p.Lookback
+	}
+	res.Days = len(a) - start
+
+	fee := fees.ForMarket(s.config.Market)
+	capital := s.config.Capital
+	peak := capital
+	var pos *pairPosition
+
+	closePos := func(i int, z float64, reason string) {
+		longExit, shortExit := b[i].Close, a[i].Close
+		if pos.longA {
+			longExit, shortExit = a[i].Close, b[i].Close
+		}
+		held := i - pos.entryIdx
+		gross := pos.longQty*(longExit-pos.longPx) + pos.shortQty*(pos.shortPx-shortExit)
+		costs := pos.entryCost + fee.SellCost(pos.longQty*longExit) + fee.BuyCost(pos.shortQty*shortExit)
+		if s.config.BorrowRatePct > 0 {
+			costs += pos.shortQty * pos.shortPx * s.config.BorrowRatePct / 100 * float64(held) / 252
+		}
+		pnl := gross - costs
+		invested := pos.longQty*pos.longPx + pos.shortQty*pos.shortPx
+		t := PairTrade{
+			LongSymbol: symB, ShortSymbol: symA,
+			EntryDate: a[pos.entryIdx].Time, ExitDate: a[i].Time,
+			EntryZ: pos.entryZ, ExitZ: z,
+			LongQty: pos.longQty, LongEntry: pos.longPx, LongExit: longExit,
+			ShortQty: pos.shortQty, ShortEntry: pos.shortPx, ShortExit: shortExit,
+			PnL: pnl, PnLPct: pnl / invested * 100, Costs: costs,
+			HoldDays: held, ExitReason: reason,
+		}
+		if pos.longA {
+			t.LongSymbol, t.ShortSymbol = symA, symB
+		}
+		res.Trades = append(res.Trades, t)
+		capital += pnl
+		pos = nil
+	}
+
+	for i := start; i < len(a); i++ {
+		if pos != nil {
+			st := pos.stat
+			spread := math.Log(a[i].Close) - st.Alpha - st.Beta*math.Log(b[i].Close)
+			z := (spread - st.Mean) / st.StdDev
+			// 롱 A(진입 z<0)는 z가 올라와야 이익, 롱 B(진입 z>0)는 z가 내려와야 이익
+			dirZ := z
+			if !pos.longA {
+				dirZ = -z
+			}
+			switch {
+			case dirZ >= -p.ExitZ:
+				closePos(i, z, "revert")
+			case dirZ <= -p.StopZ:
+				closePos(i, z, "stop")
+			case i-pos.entryIdx >= s.config.MaxHoldDays:
+				closePos(i, z, "timeout")
+			case i == len(a)-1:
+				closePos(i, z, "end")
+			}
+		} else if i < len(a)-1 {
+			pos = s.tryEnter(a[:i+1], b[:i+1], i, capital, fee)
+		}
+
+		// 일별 평가금액으로 낙폭 계산
+		equity := capital
+		if pos != nil {
+			longPx, shortPx := b[i].Close, a[i].Close
+			if pos.longA {
+				longPx, shortPx = a[i].Close, b[i].Close
+			}
+			equity += pos.longQty*(longPx-pos.longPx) + pos.shortQty*(pos.shortPx-shortPx) - pos.entryCost
+		}
+		if equity > peak {
+			peak = equity
+		}
+		if dd := (peak - equity) / peak * 100; dd > res.MaxDrawdownPct {
+			res.MaxDrawdownPct = dd
+		}
+	}
+
+	res.TotalPnL = capital - s.config.Capital
+	if s.config.Capital > 0 {
+		res.ReturnPct = res.TotalPnL / s.config.Capital * 100
+	}
+	if n := len(res.Trades); n > 0 {
+		wins, hold := 0, 0
+		for _, t := range res.Trades {
+			if t.PnL > 0 {
+				wins++
+			}
+			hold += t.HoldDays
+		}
+		res.WinRate = float64(wins) / float64(n) * 100
+		res.AvgHoldDays = float64(hold) / float64(n)
+	}
+	return res
+}
+
+// tryEnter PairsStrategy와 같은 조건이면 롱+숏 총액이 자본 × AllocPct가 되도록 진입
+func (s *PairSimulator) tryEnter(a, b []model.Candle, i int, capital float64, fee fees.Schedule) *pairPosition {
+	p := s.config.Params
+	st, err := strategy.AnalyzePair(a, b, p.Lookback)
+	if err != nil || st.Beta <= 0 || st.Correlation < p.MinCorrelation || st.HalfLife > p.MaxHalfLife {
+		return nil
+	}
+	if math.Abs(st.ZScore) < p.EntryZ || math.Abs(st.ZScore) >= p.StopZ {
+		return nil
+	}
+
+	pos := &pairPosition{stat: *st, entryIdx: i, entryZ: st.ZScore, longA: st.ZScore < 0}
+	hedge := st.Beta
+	pos.longPx, pos.shortPx = st.PriceA, st.PriceB
+	if !pos.longA {
+		hedge = 1 / st.Beta
+		pos.longPx, pos.shortPx = st.PriceB, st.PriceA
+	}
+	gross := capital * s.config.AllocPct
+	pos.longQty = math.Floor(gross / (1 + hedge) / pos.longPx)
+	pos.shortQty = math.Floor(pos.longQty * pos.longPx * hedge / pos.shortPx)
+	if pos.longQty < 1 || pos.shortQty < 1 {
+		return nil
+	}
+	pos.entryCost = fee.BuyCost(pos.longQty*pos.longPx) + fee.SellCost(pos.shortQty*pos.shortPx)
+	return pos
+}
+
+// alignCandles 두 종목에서 날짜가 같은 봉만 남김 (순서 유지)
+func alignCandles(a, b []model.Candle) ([]model.Candle, []model.Candle) {
+	byDate := make(map[string]model.Candle, len(b))
+	for _, c := range b {
+		byDate[c.Time.Format("2006-01-02")] = c
+	}
+	var outA, outB []model.Candle
+	for _, c := range a {
+		if cb, ok := byDate[c.Time.Format("2006-01-02")]; ok {
+			outA = append(outA, c)
+			outB = append(outB, cb)
+		}
+	}
+	return outA, outB
+}
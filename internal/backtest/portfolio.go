@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
 	"traveler/pkg/model"
 )
 
@@ -30,7 +33,8 @@ type DailySnapshot struct {
 	PositionValue float64
 	Positions     int
 	DayPnL        float64
-	DayReturn     float64
+	DayReturn     float64 // 입출금 제외 수익률 (%)
+	Flow          float64 // 당일 입금(+)/출금(-)
 }
 
 // PortfolioBacktestResult contains full portfolio simulation results
@@ -40,12 +44,19 @@ type PortfolioBacktestResult struct {
 	Period          string  `json:"period"`
 	InitialCapital  float64 `json:"initial_capital"`
 	MaxPositions    int     `json:"max_positions"`
+	PointInTime     string  `json:"point_in_time,omitempty"`   // 시점별 유니버스 이름
+	MissingSymbols  []string `json:"missing_symbols,omitempty"` // 데이터 없는 종목 (시점별 유니버스에서는 대개 상장폐지 → 생존 편향 잔존)
 
 	// Summary
 	FinalEquity     float64 `json:"final_equity"`
 	TotalReturn     float64 `json:"total_return"`
 	TotalReturnPct  float64 `json:"total_return_pct"`
-	CAGR            float64 `json:"cagr"` // Compound Annual Growth Rate
+	CAGR            float64 `json:"cagr"` // Compound Annual Growth Rate (time-weighted when contributions are scheduled)
+
+	// Contributions (정기 입출금이 있을 때만)
+	NetContributions float64    `json:"net_contributions,omitempty"` // 입금 - 출금 (초기 자본 제외)
+	IRR              float64    `json:"irr,omitempty"`               // 금액가중 연수익률 (XIRR, %)
+	CashFlows        []CashFlow `json:"cash_flows,omitempty"`        // 투자자 관점 현금흐름 (초기 자본, 입출금, 최종 평가액)
 
 	// Trades
 	TotalTrades     int     `json:"total_trades"`
@@ -71,6 +82,13 @@ type PortfolioBacktestResult struct {
 	SharpeRatio     float64 `json:"sharpe_ratio"`
 	SortinoRatio    float64 `json:"sortino_ratio"`
 
+	// Drawdown over time (absolute and relative to the benchmark)
+	Benchmark          string            `json:"benchmark,omitempty"`
+	BenchmarkReturnPct float64           `json:"benchmark_return_pct,omitempty"`
+	Drawdown           *DrawdownStats    `json:"drawdown_stats,omitempty"`
+	RelativeDrawdown   *DrawdownStats    `json:"relative_drawdown_stats,omitempty"`
+	Underwater         []UnderwaterPoint `json:"underwater,omitempty"`
+
 	// Kelly
 	KellyOptimal    float64 `json:"kelly_optimal"`
 	KellyHalf       float64 `json:"kelly_half"`
@@ -80,6 +98,12 @@ type PortfolioBacktestResult struct {
 	AvgPositions    float64 `json:"avg_positions"`
 	MaxPositionsHit int     `json:"max_positions_hit"`
 	SignalsSkipped  int     `json:"signals_skipped"` // Due to max positions
+	SignalsCapped   int     `json:"signals_capped,omitempty"` // Due to max new positions per day
+
+	// R-multiple distribution, exit reason / holding period breakdown
+	RDistribution   *trader.RDistribution `json:"r_distribution,omitempty"`
+	Exits           *trader.ExitAnalytics `json:"exit_analytics,omitempty"`
+	Gaps            *GapStats             `json:"gap_stats,omitempty"`
 
 	// Details
 	Trades          []Trade         `json:"trades"`
@@ -91,15 +115,27 @@ type PortfolioBacktestConfig struct {
 	InitialCapital  float64
 	RiskPerTrade    float64 // e.g., 0.01 = 1%
 	MaxPositions    int     // Maximum simultaneous positions
+	MaxNewPerDay    int     // Maximum new entries per day, best signals first (0 = no limit)
 	StopLossPct     float64 // e.g., 0.02 = 2%
 	TargetRMultiple float64 // e.g., 2.0 = 2R target
 	MaxHoldDays     int     // Maximum days to hold
 	Commission      float64 // Per trade commission rate
+	SellTax         float64 // Transaction tax on sells (e.g., KR securities tax)
 	Slippage        float64 // Expected slippage
+	SymbolSlippage  map[string]float64 // Per-symbol slippage (liquidity model), overrides Slippage
+	Dividends       map[string][]provider.DividendEvent // Per-symbol dividend history, credited when held through ex-date
+	Contributions   ContributionSchedule // 정기 입금/출금 (Amount 0 = 없음)
+	AuditLookahead  bool                 // 시그널/체결이 결정일 이후 데이터를 쓰면 즉시 실패
+	Membership      *symbols.Membership  // 시점별 구성종목: 그날 구성종목만 신규 진입 (nil = 전 기간 동일 유니버스)
+	Benchmark       string               // 상대 낙폭 비교 종목 (SPY, 069500 등, 빈 값 = 비교 안 함)
+	From            time.Time            // 시뮬레이션 시작일 (zero = 최근 days 거래일)
+	To              time.Time            // 시뮬레이션 종료일 (zero = 최신)
+	StopModel       strategy.StopModel   // 손절 모델 (비어 있으면 StopLossPct 고정 손절)
 }
 
-// DefaultPortfolioConfig returns default configuration
-func DefaultPortfolioConfig() PortfolioBacktestConfig {
+// DefaultPortfolioConfig returns default configuration with the market's active fee preset.
+func DefaultPortfolioConfig(market string) PortfolioBacktestConfig {
+	costs := trader.CostModelForMarket(market)
 	return PortfolioBacktestConfig{
 		InitialCapital:  10000000, // 1000만원
 		RiskPerTrade:    0.01,     // 1%
@@ -107,8 +143,9 @@ func DefaultPortfolioConfig() PortfolioBacktestConfig {
 		StopLossPct:     0.02,     // 2%
 		TargetRMultiple: 2.0,      // 2R target
 		MaxHoldDays:     5,        // 5 trading days
-		Commission:      0.00015,  // 0.015%
-		Slippage:        0.001,    // 0.1%
+		Commission:      costs.Commission,
+		SellTax:         costs.SellTax,
+		Slippage:        costs.Slippage,
 	}
 }
 
@@ -126,6 +163,13 @@ func NewPortfolioBacktester(cfg PortfolioBacktestConfig, p provider.Provider) *P
 	}
 }
 
+// portfolioSignal is a signal decided at a day's close, entered at the next day's open
+type portfolioSignal struct {
+	Symbol string
+	Date   time.Time // decision day
+	Score  float64
+}
+
 // symbolData holds historical data for one symbol
 type symbolData struct {
 	Symbol  string
@@ -145,7 +189,16 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 	// Fetch historical data for all symbols
 	fmt.Printf("Loading historical data for %d symbols...\n", len(symbols))
 
+	// 과거 구간 지정 시 오늘부터 구간 시작일까지 거슬러 올라갈 만큼 받는다
+	fetchDays := days
+	if !pb.config.From.IsZero() {
+		if n := tradingDaysSince(pb.config.From, time.Now()); n > fetchDays {
+			fetchDays = n
+		}
+	}
+
 	allData := make(map[string][]model.Candle)
+	var missing []string
 	for i, sym := range symbols {
 		select {
 		case <-ctx.Done():
@@ -153,8 +206,9 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 		default:
 		}
 
-		candles, err := pb.provider.GetDailyCandles(ctx, sym, days+60) // Extra for MA calculation
+		candles, err := pb.provider.GetDailyCandles(ctx, sym, fetchDays+60) // Extra for MA calculation
 		if err != nil || len(candles) < 60 {
+			missing = append(missing, sym)
 			if progress != nil {
 				progress(i+1, len(symbols), sym+" (skipped)")
 			}
@@ -179,6 +233,15 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 		return nil, fmt.Errorf("insufficient common trading days: %d", len(dates))
 	}
 
+	var benchCandles []model.Candle
+	if pb.config.Benchmark != "" {
+		if candles, err := pb.provider.GetDailyCandles(ctx, pb.config.Benchmark, fetchDays+60); err == nil {
+			benchCandles = candles
+		} else {
+			fmt.Printf("Benchmark %s unavailable: %v\n", pb.config.Benchmark, err)
+		}
+	}
+
 	fmt.Printf("Simulating %d trading days...\n\n", len(dates))
 
 	// Initialize portfolio
@@ -188,15 +251,84 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 		MaxPositions:   pb.config.MaxPositions,
 		Trades:         make([]Trade, 0),
 		DailySnapshots: make([]DailySnapshot, 0),
+		MissingSymbols: missing,
+	}
+	if pb.config.Membership != nil {
+		result.PointInTime = pb.config.Membership.Name
 	}
 
 	cash := pb.config.InitialCapital
 	positions := make(map[string]*PortfolioPosition)
 	peakEquity := cash
+	contrib := pb.config.Contributions
+	if contrib.Enabled() {
+		result.CashFlows = append(result.CashFlows, CashFlow{Date: dates[0], Amount: -pb.config.InitialCapital})
+	}
+
+	var pending []portfolioSignal // signals from the previous close, filled at today's open
 
 	// Simulate each trading day
 	for dayIdx, date := range dates {
-		// 1. Check exits for existing positions
+		// 0. Scheduled contribution/withdrawal on the first trading day of each period
+		var flow float64
+		if dayIdx > 0 && contrib.due(dates[dayIdx-1], date) {
+			flow = contrib.Amount
+			if flow < 0 && -flow > cash {
+				flow = -cash // 현금 범위 내에서만 출금 (포지션은 강제 청산하지 않음)
+			}
+			if flow != 0 {
+				cash += flow
+				result.NetContributions += flow
+				result.CashFlows = append(result.CashFlows, CashFlow{Date: date, Amount: -flow})
+			}
+		}
+
+		// 1. Enter yesterday's signals at today's open (sized on yesterday's closing equity)
+		if len(pending) > 0 {
+			riskBase := cash + pb.calcPositionValue(positions, allData, dates[dayIdx-1])
+			for _, sig := range pending {
+				dayCandle := pb.findCandle(allData[sig.Symbol], date)
+				if dayCandle == nil {
+					continue
+				}
+				if pb.config.AuditLookahead {
+					if err := auditFill(sig.Symbol, sig.Date, *dayCandle, dayCandle.Open); err != nil {
+						return nil, err
+					}
+				}
+
+				entryPrice := dayCandle.Open * (1 + pb.slippageFor(sig.Symbol))
+				stopLoss := initialStop(pb.config.StopModel, candlesThrough(allData[sig.Symbol], sig.Date), entryPrice, pb.config.StopLossPct)
+				riskPerShare := entryPrice - stopLoss
+				shares := int(riskBase * pb.config.RiskPerTrade / riskPerShare)
+				if shares <= 0 {
+					continue
+				}
+
+				cost := float64(shares)*entryPrice + pb.calcCommission(shares, entryPrice)
+				if cost > cash {
+					shares = int((cash - 1000) / entryPrice) // Leave some buffer
+					if shares <= 0 {
+						continue
+					}
+					cost = float64(shares)*entryPrice + pb.calcCommission(shares, entryPrice)
+				}
+
+				positions[sig.Symbol] = &PortfolioPosition{
+					Symbol:     sig.Symbol,
+					EntryDate:  date,
+					EntryPrice: entryPrice,
+					StopLoss:   stopLoss,
+					Target:     entryPrice + riskPerShare*pb.config.TargetRMultiple,
+					Shares:     shares,
+					DaysHeld:   0,
+				}
+				cash -= cost
+			}
+			pending = nil
+		}
+
+		// 2. Check exits (positions entered at today's open can hit stop/target intraday)
 		closedPositions := make([]string, 0)
 
 		for sym, pos := range positions {
@@ -208,32 +340,38 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 
 			pos.DaysHeld++
 
-			// Check stop loss
+			// Check stop loss (gap below the stop fills at the open)
 			if dayCandle.Low <= pos.StopLoss {
-				exitPrice := pos.StopLoss * (1 - pb.config.Slippage)
-				trade := pb.closeTrade(pos, date, exitPrice, "stop")
+				fill, gapped := gapStopFill(dayCandle.Open, pos.StopLoss)
+				exitPrice := fill * (1 - pb.slippageFor(sym))
+				reason := "stop"
+				if gapped {
+					reason = "gap_stop"
+				}
+				trade := pb.closeTrade(pos, date, exitPrice, reason)
+				trade.GapLoss = float64(pos.Shares) * (pos.StopLoss - fill)
 				result.Trades = append(result.Trades, trade)
-				cash += float64(pos.Shares)*exitPrice - pb.calcCommission(pos.Shares, exitPrice)
+				cash += float64(pos.Shares)*exitPrice - pb.calcSellCost(pos.Shares, exitPrice) + trade.Dividends
 				closedPositions = append(closedPositions, sym)
 				continue
 			}
 
 			// Check target
 			if dayCandle.High >= pos.Target {
-				exitPrice := pos.Target * (1 - pb.config.Slippage)
+				exitPrice := pos.Target * (1 - pb.slippageFor(sym))
 				trade := pb.closeTrade(pos, date, exitPrice, "target")
 				result.Trades = append(result.Trades, trade)
-				cash += float64(pos.Shares)*exitPrice - pb.calcCommission(pos.Shares, exitPrice)
+				cash += float64(pos.Shares)*exitPrice - pb.calcSellCost(pos.Shares, exitPrice) + trade.Dividends
 				closedPositions = append(closedPositions, sym)
 				continue
 			}
 
 			// Check timeout
 			if pos.DaysHeld >= pb.config.MaxHoldDays {
-				exitPrice := dayCandle.Close * (1 - pb.config.Slippage)
+				exitPrice := dayCandle.Close * (1 - pb.slippageFor(sym))
 				trade := pb.closeTrade(pos, date, exitPrice, "timeout")
 				result.Trades = append(result.Trades, trade)
-				cash += float64(pos.Shares)*exitPrice - pb.calcCommission(pos.Shares, exitPrice)
+				cash += float64(pos.Shares)*exitPrice - pb.calcSellCost(pos.Shares, exitPrice) + trade.Dividends
 				closedPositions = append(closedPositions, sym)
 			}
 		}
@@ -242,59 +380,35 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 			delete(positions, sym)
 		}
 
-		// 2. Scan for new signals (if we have capacity)
-		if len(positions) < pb.config.MaxPositions {
-			signals := pb.scanForSignals(allData, date, dayIdx)
+		// 3. Scan for new signals at the close (if we have capacity); filled at the next open
+		if slots := pb.config.MaxPositions - len(positions); slots > 0 && dayIdx < len(dates)-1 {
+			signals, err := pb.scanForSignals(allData, date)
+			if err != nil {
+				return nil, err
+			}
 
-			for _, sig := range signals {
-				if len(positions) >= pb.config.MaxPositions {
+			for i, sig := range signals {
+				if len(pending) >= slots {
 					result.SignalsSkipped++
 					break
 				}
+				// 하루 신규 진입 제한: 점수 높은 시그널부터 채우고 나머지는 버림 (다음 날 다시 시그널이 나오면 재검토)
+				if pb.config.MaxNewPerDay > 0 && len(pending) >= pb.config.MaxNewPerDay {
+					result.SignalsCapped += len(signals) - i
+					break
+				}
 
 				// Skip if already holding this symbol
 				if _, exists := positions[sig.Symbol]; exists {
 					continue
 				}
-
-				// Calculate position size
-				riskAmount := (cash + pb.calcPositionValue(positions, allData, date)) * pb.config.RiskPerTrade
-				entryPrice := sig.EntryPrice * (1 + pb.config.Slippage)
-				stopLoss := entryPrice * (1 - pb.config.StopLossPct)
-				riskPerShare := entryPrice - stopLoss
-				shares := int(riskAmount / riskPerShare)
-
-				if shares <= 0 {
-					continue
-				}
-
-				cost := float64(shares)*entryPrice + pb.calcCommission(shares, entryPrice)
-				if cost > cash {
-					shares = int((cash - 1000) / entryPrice) // Leave some buffer
-					if shares <= 0 {
-						continue
-					}
-					cost = float64(shares)*entryPrice + pb.calcCommission(shares, entryPrice)
-				}
-
-				// Open position
-				positions[sig.Symbol] = &PortfolioPosition{
-					Symbol:     sig.Symbol,
-					EntryDate:  date,
-					EntryPrice: entryPrice,
-					StopLoss:   stopLoss,
-					Target:     entryPrice + riskPerShare*pb.config.TargetRMultiple,
-					Shares:     shares,
-					DaysHeld:   0,
-				}
-
-				cash -= cost
+				pending = append(pending, sig)
 			}
 		} else if len(positions) == pb.config.MaxPositions {
 			result.MaxPositionsHit++
 		}
 
-		// 3. Record daily snapshot
+		// 4. Record daily snapshot
 		posValue := pb.calcPositionValue(positions, allData, date)
 		equity := cash + posValue
 
@@ -305,10 +419,11 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 			prevEquity = pb.config.InitialCapital
 		}
 
-		dayPnL := equity - prevEquity
+		// 입출금은 손익이 아니므로 제외 (입금은 장 시작 전 반영된 것으로 간주)
+		dayPnL := equity - prevEquity - flow
 		dayReturn := 0.0
-		if prevEquity > 0 {
-			dayReturn = dayPnL / prevEquity * 100
+		if base := prevEquity + flow; base > 0 {
+			dayReturn = dayPnL / base * 100
 		}
 
 		result.DailySnapshots = append(result.DailySnapshots, DailySnapshot{
@@ -319,6 +434,7 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 			Positions:     len(positions),
 			DayPnL:        dayPnL,
 			DayReturn:     dayReturn,
+			Flow:          flow,
 		})
 
 		// Track drawdown
@@ -335,48 +451,77 @@ func (pb *PortfolioBacktester) RunWithProgress(ctx context.Context, symbols []st
 		if dayCandle == nil {
 			continue
 		}
-		exitPrice := dayCandle.Close * (1 - pb.config.Slippage)
+		exitPrice := dayCandle.Close * (1 - pb.slippageFor(sym))
 		trade := pb.closeTrade(pos, lastDate, exitPrice, "end")
 		result.Trades = append(result.Trades, trade)
-		cash += float64(pos.Shares)*exitPrice - pb.calcCommission(pos.Shares, exitPrice)
+		cash += float64(pos.Shares)*exitPrice - pb.calcSellCost(pos.Shares, exitPrice) + trade.Dividends
 	}
 
 	// Calculate final statistics
 	result.Period = dates[0].Format("2006-01-02") + " ~ " + dates[len(dates)-1].Format("2006-01-02")
 	result.FinalEquity = cash
-	result.TotalReturn = cash - pb.config.InitialCapital
-	result.TotalReturnPct = result.TotalReturn / pb.config.InitialCapital * 100
+	result.TotalReturn = cash - pb.config.InitialCapital - result.NetContributions
+	result.TotalReturnPct = result.TotalReturn / pb.investedCapital(result) * 100
 	result.TradingDays = len(dates)
 
 	// CAGR
 	years := float64(len(dates)) / 252.0
-	if years > 0 && result.FinalEquity > 0 {
+	if contrib.Enabled() {
+		// 입출금이 있으면 자산 증가율은 수익률이 아님 → 일별 수익률 연결(시간가중) + 금액가중 IRR
+		if growth := pb.timeWeightedGrowth(result); years > 0 && growth > 0 {
+			result.CAGR = (math.Pow(growth, 1/years) - 1) * 100
+		}
+		result.CashFlows = append(result.CashFlows, CashFlow{Date: lastDate, Amount: cash})
+		if irr, ok := XIRR(result.CashFlows); ok {
+			result.IRR = irr * 100
+		}
+	} else if years > 0 && result.FinalEquity > 0 {
 		result.CAGR = (math.Pow(result.FinalEquity/pb.config.InitialCapital, 1/years) - 1) * 100
 	}
 
 	pb.calculateTradeStats(result)
 	pb.calculateRiskMetrics(result)
+	pb.calculateUnderwater(result, benchCandles)
 
 	return result, nil
 }
 
-// scanForSignals finds pullback signals for a given date
-func (pb *PortfolioBacktester) scanForSignals(allData map[string][]model.Candle, date time.Time, dayIdx int) []struct {
-	Symbol     string
-	EntryPrice float64
-	Score      float64
-} {
-	var signals []struct {
-		Symbol     string
-		EntryPrice float64
-		Score      float64
+// investedCapital 초기 자본 + 누적 입금 (출금은 차감하지 않음, 수익률 분모)
+func (pb *PortfolioBacktester) investedCapital(result *PortfolioBacktestResult) float64 {
+	invested := pb.config.InitialCapital
+	for _, snap := range result.DailySnapshots {
+		if snap.Flow > 0 {
+			invested += snap.Flow
+		}
 	}
+	return invested
+}
+
+// timeWeightedGrowth 입출금을 제외한 기간 누적 성장 배수 (일별 수익률 연결 + 마지막 날 잔여 포지션 청산 비용)
+func (pb *PortfolioBacktester) timeWeightedGrowth(result *PortfolioBacktestResult) float64 {
+	if len(result.DailySnapshots) == 0 {
+		return 0
+	}
+	growth := 1.0
+	for _, snap := range result.DailySnapshots {
+		growth *= 1 + snap.DayReturn/100
+	}
+	if last := result.DailySnapshots[len(result.DailySnapshots)-1].Equity; last > 0 {
+		growth *= result.FinalEquity / last
+	}
+	return growth
+}
+
+// scanForSignals finds pullback signals at the close of a given date, best first.
+// With AuditLookahead, every decision is re-checked against poisoned future candles.
+func (pb *PortfolioBacktester) scanForSignals(allData map[string][]model.Candle, date time.Time) ([]portfolioSignal, error) {
+	var signals []portfolioSignal
 
 	for sym, candles := range allData {
 		// Find index for this date
 		idx := -1
 		for i, c := range candles {
-			if c.Time.Year() == date.Year() && c.Time.YearDay() == date.YearDay() {
+			if sameDay(c.Time, date) {
 				idx = i
 				break
 			}
@@ -385,29 +530,45 @@ func (pb *PortfolioBacktester) scanForSignals(allData map[string][]model.Candle,
 		if idx < 50 { // Need enough history
 			continue
 		}
+		// Point-in-time universe: only symbols in the index on this date are candidates
+		if pb.config.Membership != nil && !pb.config.Membership.IsMember(sym, date) {
+			continue
+		}
+
+		if pb.config.AuditLookahead {
+			if err := auditDecision(sym, candles, idx, date, pb.decisionKey); err != nil {
+				return nil, err
+			}
+		}
 
 		// Check pullback signal using data up to this day
 		historyCandles := candles[:idx+1]
 		if pb.checkPullbackSignal(historyCandles) {
-			// Entry next day at open (we'll use close as proxy)
-			signals = append(signals, struct {
-				Symbol     string
-				EntryPrice float64
-				Score      float64
-			}{
-				Symbol:     sym,
-				EntryPrice: candles[idx].Close,
-				Score:      1.0,
+			signals = append(signals, portfolioSignal{
+				Symbol: sym,
+				Date:   date,
+				Score:  pullbackScore(historyCandles),
 			})
 		}
 	}
 
-	// Sort by score (for now all equal, but can be enhanced)
+	// Best signals first; symbol tie-break keeps runs deterministic (map order is random)
 	sort.Slice(signals, func(i, j int) bool {
-		return signals[i].Score > signals[j].Score
+		if signals[i].Score != signals[j].Score {
+			return signals[i].Score > signals[j].Score
+		}
+		return signals[i].Symbol < signals[j].Symbol
 	})
 
-	return signals
+	return signals, nil
+}
+
+// decisionKey summarizes the entry decision for the lookahead audit
+func (pb *PortfolioBacktester) decisionKey(candles []model.Candle) string {
+	if !pb.checkPullbackSignal(candles) {
+		return "no signal"
+	}
+	return fmt.Sprintf("signal score=%.6f", pullbackScore(candles))
 }
 
 // checkPullbackSignal checks if pullback conditions are met
@@ -443,6 +604,20 @@ func (pb *PortfolioBacktester) checkPullbackSignal(candles []model.Candle) bool
 	return bullish || longShadow
 }
 
+// pullbackScore ranks pullback signals: stronger uptrend (close above MA50) and a tighter
+// touch of MA20 score higher. Only uses candles up to the signal day.
+func pullbackScore(candles []model.Candle) float64 {
+	ma20 := calcMA(candles, 20)
+	ma50 := calcMA(candles, 50)
+	if ma20 <= 0 || ma50 <= 0 {
+		return 0
+	}
+	latest := candles[len(candles)-1]
+	trend := (latest.Close/ma50 - 1) * 100
+	touch := math.Abs(latest.Low/ma20-1) * 100
+	return trend - touch
+}
+
 func calcMA(candles []model.Candle, period int) float64 {
 	if len(candles) < period {
 		return 0
@@ -471,6 +646,12 @@ func (pb *PortfolioBacktester) findCommonDates(allData map[string][]model.Candle
 	for dateStr, count := range dateSet {
 		if count >= minCoverage {
 			t, _ := time.Parse("2006-01-02", dateStr)
+			if !pb.config.From.IsZero() && t.Before(pb.config.From) {
+				continue
+			}
+			if !pb.config.To.IsZero() && t.After(pb.config.To) {
+				continue
+			}
 			dates = append(dates, t)
 		}
 	}
@@ -479,14 +660,24 @@ func (pb *PortfolioBacktester) findCommonDates(allData map[string][]model.Candle
 		return dates[i].Before(dates[j])
 	})
 
-	// Return most recent N days
-	if len(dates) > maxDays {
+	// Return most recent N days (구간 지정 시 구간 전체)
+	if pb.config.From.IsZero() && len(dates) > maxDays {
 		dates = dates[len(dates)-maxDays:]
 	}
 
 	return dates
 }
 
+// candlesThrough date(결정일)까지의 캔들 (없으면 nil)
+func candlesThrough(candles []model.Candle, date time.Time) []model.Candle {
+	for i := range candles {
+		if sameDay(candles[i].Time, date) {
+			return candles[:i+1]
+		}
+	}
+	return nil
+}
+
 func (pb *PortfolioBacktester) findCandle(candles []model.Candle, date time.Time) *model.Candle {
 	for i := range candles {
 		if candles[i].Time.Year() == date.Year() && candles[i].Time.YearDay() == date.YearDay() {
@@ -496,10 +687,23 @@ func (pb *PortfolioBacktester) findCandle(candles []model.Candle, date time.Time
 	return nil
 }
 
+// slippageFor returns per-symbol slippage if estimated, else the global default
+func (pb *PortfolioBacktester) slippageFor(symbol string) float64 {
+	if s, ok := pb.config.SymbolSlippage[symbol]; ok {
+		return s
+	}
+	return pb.config.Slippage
+}
+
 func (pb *PortfolioBacktester) calcCommission(shares int, price float64) float64 {
 	return float64(shares) * price * pb.config.Commission
 }
 
+// calcSellCost 매도 수수료 + 거래세
+func (pb *PortfolioBacktester) calcSellCost(shares int, price float64) float64 {
+	return float64(shares) * price * (pb.config.Commission + pb.config.SellTax)
+}
+
 func (pb *PortfolioBacktester) calcPositionValue(positions map[string]*PortfolioPosition, allData map[string][]model.Candle, date time.Time) float64 {
 	var total float64
 	for sym, pos := range positions {
@@ -514,7 +718,8 @@ func (pb *PortfolioBacktester) calcPositionValue(positions map[string]*Portfolio
 
 func (pb *PortfolioBacktester) closeTrade(pos *PortfolioPosition, exitDate time.Time, exitPrice float64, reason string) Trade {
 	riskPerShare := pos.EntryPrice - pos.StopLoss
-	pnl := float64(pos.Shares) * (exitPrice - pos.EntryPrice)
+	dividends := dividendIncome(pb.config.Dividends[pos.Symbol], pos.Shares, pos.EntryDate, exitDate)
+	pnl := float64(pos.Shares)*(exitPrice-pos.EntryPrice) + dividends
 
 	return Trade{
 		Symbol:     pos.Symbol,
@@ -530,6 +735,7 @@ func (pb *PortfolioBacktester) closeTrade(pos *PortfolioPosition, exitDate time.
 		RMultiple:  (exitPrice - pos.EntryPrice) / riskPerShare,
 		IsWin:      pnl > 0,
 		ExitReason: reason,
+		Dividends:  dividends,
 	}
 }
 
@@ -539,6 +745,9 @@ func (pb *PortfolioBacktester) calculateTradeStats(result *PortfolioBacktestResu
 	}
 
 	result.TotalTrades = len(result.Trades)
+	result.RDistribution = tradeRDistribution(result.Trades)
+	result.Exits = tradeExitAnalytics(result.Trades)
+	result.Gaps = tradeGapStats(result.Trades)
 
 	var totalWin, totalLoss float64
 	var winPcts, lossPcts []float64
@@ -589,10 +798,7 @@ func (pb *PortfolioBacktester) calculateTradeStats(result *PortfolioBacktestResu
 
 	// Kelly
 	if result.AvgLoss > 0 {
-		w := result.WinRate / 100
-		b := result.AvgWin / result.AvgLoss
-		result.KellyOptimal = (w*b - (1 - w)) / b
-		result.KellyOptimal = math.Max(0, result.KellyOptimal)
+		result.KellyOptimal = trader.KellyFraction(result.WinRate/100, result.AvgWin, result.AvgLoss)
 		result.KellyHalf = result.KellyOptimal / 2
 	}
 
@@ -611,17 +817,21 @@ func (pb *PortfolioBacktester) calculateRiskMetrics(result *PortfolioBacktestRes
 		return
 	}
 
-	// Max Drawdown
-	peak := result.DailySnapshots[0].Equity
+	// Max Drawdown (일별 수익률 연결 지수 기준 → 입출금으로 인한 자산 변동은 낙폭이 아님)
+	nav := 1 + result.DailySnapshots[0].DayReturn/100
+	peak := nav
 	var maxDD float64
 	var ddStart, ddEnd int
 
 	for i, snap := range result.DailySnapshots {
-		if snap.Equity > peak {
-			peak = snap.Equity
+		if i > 0 {
+			nav *= 1 + snap.DayReturn/100
+		}
+		if nav > peak {
+			peak = nav
 			ddStart = i
 		}
-		dd := (peak - snap.Equity) / peak * 100
+		dd := (peak - nav) / peak * 100
 		if dd > maxDD {
 			maxDD = dd
 			ddEnd = i
@@ -655,6 +865,43 @@ func (pb *PortfolioBacktester) calculateRiskMetrics(result *PortfolioBacktestRes
 	}
 }
 
+// calculateUnderwater 날짜별 낙폭 시계열과 회복 기간 (입출금 제외 지수 기준, 벤치마크가 있으면 상대 성과도)
+func (pb *PortfolioBacktester) calculateUnderwater(result *PortfolioBacktestResult, benchCandles []model.Candle) {
+	n := len(result.DailySnapshots)
+	if n < 2 {
+		return
+	}
+	dates := make([]time.Time, n)
+	nav := make([]float64, n)
+	v := 1.0
+	for i, snap := range result.DailySnapshots {
+		v *= 1 + snap.DayReturn/100
+		dates[i], nav[i] = snap.Date, v
+	}
+	dd, stats := drawdownSeries(dates, nav)
+	result.Drawdown = stats
+	result.Underwater = make([]UnderwaterPoint, n)
+	for i := range dd {
+		result.Underwater[i] = UnderwaterPoint{Date: dates[i].Format("2006-01-02"), Drawdown: dd[i]}
+	}
+
+	bench := benchmarkNav(benchCandles, dates)
+	if bench == nil {
+		return
+	}
+	result.Benchmark = pb.config.Benchmark
+	result.BenchmarkReturnPct = (bench[n-1]/bench[0] - 1) * 100
+	rel := make([]float64, n)
+	for i := range nav {
+		rel[i] = nav[i] / (bench[i] / bench[0])
+	}
+	relDD, relStats := drawdownSeries(dates, rel)
+	result.RelativeDrawdown = relStats
+	for i := range relDD {
+		result.Underwater[i].RelDrawdown = relDD[i]
+	}
+}
+
 func avg(vals []float64) float64 {
 	if len(vals) == 0 {
 		return 0
@@ -0,0 +1,391 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/trader"
+)
+
+// RunParams 백테스트 실행 파라미터 (비교 시 무엇이 달라졌는지 표시용)
+type RunParams struct {
+	Days            int      `json:"days"`
+	Universe        string   `json:"universe,omitempty"`
+	Symbols         []string `json:"symbols,omitempty"`
+	RiskPerTrade    float64  `json:"risk_per_trade"`
+	StopLossPct     float64  `json:"stop_loss_pct"`
+	TargetRMultiple float64  `json:"target_r_multiple"`
+	MaxHoldDays     int      `json:"max_hold_days"`
+	MaxPositions    int      `json:"max_positions,omitempty"`
+	MaxNewPerDay    int      `json:"max_new_per_day,omitempty"`
+	Commission      float64  `json:"commission"`
+	Slippage        float64  `json:"slippage"`
+	Dividends       bool     `json:"dividends,omitempty"`
+	Contribution    float64  `json:"contribution,omitempty"` // 정기 입금(+)/출금(-)
+	ContributeEvery string   `json:"contribute_every,omitempty"`
+	Scenario        string   `json:"scenario,omitempty"` // 스트레스 구간 프리셋 (--scenario)
+	From            string   `json:"from,omitempty"`     // --from 구간 시작일 (YYYY-MM-DD)
+	To              string   `json:"to,omitempty"`       // --to 구간 종료일
+	StopModel       string   `json:"stop_model,omitempty"`
+}
+
+// RunStats 단일/포트폴리오 백테스트 공통 지표
+type RunStats struct {
+	InitialCapital  float64 `json:"initial_capital"`
+	FinalEquity     float64 `json:"final_equity"`
+	TotalReturnPct  float64 `json:"total_return_pct"`
+	CAGR            float64 `json:"cagr"`
+	TotalTrades     int     `json:"total_trades"`
+	WinRate         float64 `json:"win_rate"`
+	AvgWinPct       float64 `json:"avg_win_pct"`
+	AvgLossPct      float64 `json:"avg_loss_pct"`
+	ProfitFactor    float64 `json:"profit_factor"`
+	ExpectancyR     float64 `json:"expectancy_r"`
+	MaxDrawdown     float64 `json:"max_drawdown"`
+	MaxDrawdownDays int     `json:"max_drawdown_days"`
+	SharpeRatio     float64 `json:"sharpe_ratio"`
+	SortinoRatio    float64 `json:"sortino_ratio"`
+	Dividends       float64 `json:"dividends,omitempty"`
+	Contributions   float64 `json:"net_contributions,omitempty"` // 입금 - 출금
+	IRR             float64 `json:"irr,omitempty"`               // 금액가중 연수익률 (%)
+}
+
+// EquityPoint 날짜별 자산
+type EquityPoint struct {
+	Date   string  `json:"date"`
+	Equity float64 `json:"equity"`
+}
+
+// SavedRun 저장된 백테스트 결과 (backtest compare 입력)
+type SavedRun struct {
+	Label     string        `json:"label"`
+	Kind      string        `json:"kind"` // "single" or "portfolio"
+	Strategy  string        `json:"strategy"`
+	Period    string        `json:"period"`
+	CreatedAt time.Time     `json:"created_at"`
+	Params    RunParams     `json:"params"`
+	Stats     RunStats      `json:"stats"`
+	Equity    []EquityPoint `json:"equity"`
+	Trades    []Trade       `json:"trades,omitempty"`
+
+	RDistribution *trader.RDistribution `json:"r_distribution,omitempty"`
+	Exits         *trader.ExitAnalytics `json:"exit_analytics,omitempty"`
+	Underwater    []UnderwaterPoint     `json:"underwater,omitempty"`
+}
+
+// NewSingleRun 단일 종목 백테스트 결과 → SavedRun
+// 단일 백테스트는 일별 스냅샷이 없으므로 자산 곡선은 시작일 + 청산일 기준.
+func NewSingleRun(label string, cfg BacktestConfig, params RunParams, result *BacktestResult) *SavedRun {
+	params.RiskPerTrade = cfg.RiskPerTrade
+	params.StopLossPct = cfg.StopLossPct
+	params.TargetRMultiple = cfg.TargetRMultiple
+	params.MaxHoldDays = cfg.MaxHoldDays
+	params.Commission = cfg.Commission
+	params.Slippage = cfg.Slippage
+	params.Dividends = len(cfg.Dividends) > 0
+	if cfg.StopModel.IsSet() {
+		params.StopModel = cfg.StopModel.String()
+	}
+	params.From, params.To = runDate(cfg.From), runDate(cfg.To)
+
+	final := cfg.InitialCapital + result.TotalReturn
+	run := &SavedRun{
+		Label:     label,
+		Kind:      "single",
+		Strategy:  result.Strategy,
+		Period:    result.Period,
+		CreatedAt: time.Now(),
+		Params:    params,
+		Stats: RunStats{
+			InitialCapital:  cfg.InitialCapital,
+			FinalEquity:     final,
+			TotalReturnPct:  result.TotalReturnPct,
+			TotalTrades:     result.TotalTrades,
+			WinRate:         result.WinRate,
+			AvgWinPct:       result.AvgWinPct,
+			AvgLossPct:      result.AvgLossPct,
+			ProfitFactor:    result.ProfitFactor,
+			ExpectancyR:     result.ExpectancyR,
+			MaxDrawdown:     result.MaxDrawdown,
+			MaxDrawdownDays: result.MaxDrawdownDays,
+			SharpeRatio:     result.SharpeRatio,
+		},
+		Trades:        result.Trades,
+		RDistribution: result.RDistribution,
+		Exits:         result.Exits,
+	}
+
+	start := strings.TrimSpace(strings.Split(result.Period, "~")[0])
+	run.Equity = append(run.Equity, EquityPoint{Date: start, Equity: cfg.InitialCapital})
+	equity := cfg.InitialCapital
+	for _, t := range result.Trades {
+		equity += t.PnL
+		run.Stats.Dividends += t.Dividends
+		run.Equity = append(run.Equity, EquityPoint{Date: t.ExitDate.Format("2006-01-02"), Equity: equity})
+	}
+	if days := periodDays(result.Period); days > 0 && final > 0 {
+		run.Stats.CAGR = (math.Pow(final/cfg.InitialCapital, 365/float64(days)) - 1) * 100
+	}
+	return run
+}
+
+// NewPortfolioRun 포트폴리오 백테스트 결과 → SavedRun (일별 스냅샷 자산 곡선)
+func NewPortfolioRun(label string, cfg PortfolioBacktestConfig, params RunParams, result *PortfolioBacktestResult) *SavedRun {
+	params.RiskPerTrade = cfg.RiskPerTrade
+	params.StopLossPct = cfg.StopLossPct
+	params.TargetRMultiple = cfg.TargetRMultiple
+	params.MaxHoldDays = cfg.MaxHoldDays
+	params.MaxPositions = cfg.MaxPositions
+	params.MaxNewPerDay = cfg.MaxNewPerDay
+	params.Commission = cfg.Commission
+	params.Slippage = cfg.Slippage
+	params.Dividends = len(cfg.Dividends) > 0
+	if cfg.StopModel.IsSet() {
+		params.StopModel = cfg.StopModel.String()
+	}
+	if cfg.Contributions.Enabled() {
+		params.Contribution = cfg.Contributions.Amount
+		params.ContributeEvery = cfg.Contributions.Every
+	}
+	if params.Scenario == "" {
+		params.From, params.To = runDate(cfg.From), runDate(cfg.To)
+	}
+
+	run := &SavedRun{
+		Label:     label,
+		Kind:      "portfolio",
+		Strategy:  result.Strategy,
+		Period:    result.Period,
+		CreatedAt: time.Now(),
+		Params:    params,
+		Stats: RunStats{
+			InitialCapital:  result.InitialCapital,
+			FinalEquity:     result.FinalEquity,
+			TotalReturnPct:  result.TotalReturnPct,
+			CAGR:            result.CAGR,
+			TotalTrades:     result.TotalTrades,
+			WinRate:         result.WinRate,
+			AvgWinPct:       result.AvgWinPct,
+			AvgLossPct:      result.AvgLossPct,
+			ProfitFactor:    result.ProfitFactor,
+			ExpectancyR:     result.ExpectancyR,
+			MaxDrawdown:     result.MaxDrawdown,
+			MaxDrawdownDays: result.MaxDrawdownDays,
+			SharpeRatio:     result.SharpeRatio,
+			SortinoRatio:    result.SortinoRatio,
+			Contributions:   result.NetContributions,
+			IRR:             result.IRR,
+		},
+		Trades:        result.Trades,
+		RDistribution: result.RDistribution,
+		Exits:         result.Exits,
+		Underwater:    result.Underwater,
+	}
+	for _, t := range result.Trades {
+		run.Stats.Dividends += t.Dividends
+	}
+	for _, snap := range result.DailySnapshots {
+		run.Equity = append(run.Equity, EquityPoint{Date: snap.Date.Format("2006-01-02"), Equity: snap.Equity})
+	}
+	return run
+}
+
+// runDate 구간 경계일 (zero = 빈 문자열)
+func runDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// periodDays "2024-01-02 ~ 2024-12-31" 기간 일수
+func periodDays(period string) int {
+	parts := strings.Split(period, "~")
+	if len(parts) != 2 {
+		return 0
+	}
+	from, err1 := time.Parse("2006-01-02", strings.TrimSpace(parts[0]))
+	to, err2 := time.Parse("2006-01-02", strings.TrimSpace(parts[1]))
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// RunsDir 저장된 백테스트 결과 디렉토리
+func RunsDir(dataDir string) string {
+	return filepath.Join(dataDir, "backtests")
+}
+
+// SaveRun JSON 저장 (상위 디렉토리 자동 생성)
+func SaveRun(path string, run *SavedRun) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadRun 저장된 백테스트 결과 로드
+func LoadRun(path string) (*SavedRun, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run SavedRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if run.Label == "" {
+		run.Label = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	return &run, nil
+}
+
+// RunInfo 저장 목록 항목
+type RunInfo struct {
+	Name           string    `json:"name"`
+	Label          string    `json:"label"`
+	Kind           string    `json:"kind"`
+	Period         string    `json:"period"`
+	CreatedAt      time.Time `json:"created_at"`
+	TotalReturnPct float64   `json:"total_return_pct"`
+	TotalTrades    int       `json:"total_trades"`
+}
+
+// ListRuns dir 내 저장된 결과 (최신순, 읽을 수 없는 파일은 건너뜀)
+func ListRuns(dir string) ([]RunInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]RunInfo, 0, len(matches))
+	for _, path := range matches {
+		run, err := LoadRun(path)
+		if err != nil {
+			continue
+		}
+		runs = append(runs, RunInfo{
+			Name:           strings.TrimSuffix(filepath.Base(path), ".json"),
+			Label:          run.Label,
+			Kind:           run.Kind,
+			Period:         run.Period,
+			CreatedAt:      run.CreatedAt,
+			TotalReturnPct: run.Stats.TotalReturnPct,
+			TotalTrades:    run.Stats.TotalTrades,
+		})
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].CreatedAt.After(runs[j].CreatedAt) })
+	return runs, nil
+}
+
+// MetricDiff 지표 비교 한 줄
+type MetricDiff struct {
+	Name   string  `json:"name"`
+	Format string  `json:"format"` // "pct", "ratio", "r", "int", "days"
+	A      float64 `json:"a"`
+	B      float64 `json:"b"`
+	Delta  float64 `json:"delta"`
+	Better string  `json:"better,omitempty"` // "a", "b", "" (동일)
+}
+
+// ParamDiff 파라미터 차이
+type ParamDiff struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+// RunComparison 두 결과 비교
+type RunComparison struct {
+	A       *SavedRun     `json:"a"`
+	B       *SavedRun     `json:"b"`
+	Metrics []MetricDiff  `json:"metrics"`
+	Params  []ParamDiff   `json:"params"`
+	EquityA []EquityPoint `json:"equity_a"` // 시작 자산 100 기준 정규화
+	EquityB []EquityPoint `json:"equity_b"`
+}
+
+// CompareRuns 두 백테스트 결과의 지표/파라미터 차이와 정규화 자산 곡선
+func CompareRuns(a, b *SavedRun) *RunComparison {
+	cmp := &RunComparison{A: a, B: b, EquityA: NormalizeEquity(a), EquityB: NormalizeEquity(b)}
+
+	add := func(name, format string, va, vb float64, higherBetter bool) {
+		d := MetricDiff{Name: name, Format: format, A: va, B: vb, Delta: vb - va}
+		if math.Abs(d.Delta) > 1e-9 {
+			if (vb > va) == higherBetter {
+				d.Better = "b"
+			} else {
+				d.Better = "a"
+			}
+		}
+		cmp.Metrics = append(cmp.Metrics, d)
+	}
+	sa, sb := a.Stats, b.Stats
+	add("Total Return", "pct", sa.TotalReturnPct, sb.TotalReturnPct, true)
+	add("CAGR", "pct", sa.CAGR, sb.CAGR, true)
+	if sa.IRR != 0 || sb.IRR != 0 {
+		add("IRR", "pct", sa.IRR, sb.IRR, true)
+	}
+	add("Trades", "int", float64(sa.TotalTrades), float64(sb.TotalTrades), true)
+	add("Win Rate", "pct", sa.WinRate, sb.WinRate, true)
+	add("Avg Win", "pct", sa.AvgWinPct, sb.AvgWinPct, true)
+	// 단일 백테스트는 손실을 양수, 포트폴리오는 음수로 기록하므로 음수로 통일
+	add("Avg Loss", "pct", -math.Abs(sa.AvgLossPct), -math.Abs(sb.AvgLossPct), true)
+	add("Profit Factor", "ratio", sa.ProfitFactor, sb.ProfitFactor, true)
+	add("Expectancy", "r", sa.ExpectancyR, sb.ExpectancyR, true)
+	add("Max Drawdown", "pct", sa.MaxDrawdown, sb.MaxDrawdown, false)
+	add("Max DD Duration", "days", float64(sa.MaxDrawdownDays), float64(sb.MaxDrawdownDays), false)
+	add("Sharpe", "ratio", sa.SharpeRatio, sb.SharpeRatio, true)
+	add("Sortino", "ratio", sa.SortinoRatio, sb.SortinoRatio, true)
+
+	pa, pb := a.Params, b.Params
+	param := func(name, va, vb string) {
+		if va != vb {
+			cmp.Params = append(cmp.Params, ParamDiff{Name: name, A: va, B: vb})
+		}
+	}
+	param("kind", a.Kind, b.Kind)
+	param("strategy", a.Strategy, b.Strategy)
+	param("period", a.Period, b.Period)
+	param("scenario", pa.Scenario, pb.Scenario)
+	param("universe", pa.Universe, pb.Universe)
+	param("symbols", fmt.Sprintf("%d", len(pa.Symbols)), fmt.Sprintf("%d", len(pb.Symbols)))
+	param("risk/trade", fmt.Sprintf("%.2f%%", pa.RiskPerTrade*100), fmt.Sprintf("%.2f%%", pb.RiskPerTrade*100))
+	param("stop", fmt.Sprintf("%.2f%%", pa.StopLossPct*100), fmt.Sprintf("%.2f%%", pb.StopLossPct*100))
+	param("stop model", pa.StopModel, pb.StopModel)
+	param("target", fmt.Sprintf("%.1fR", pa.TargetRMultiple), fmt.Sprintf("%.1fR", pb.TargetRMultiple))
+	param("max hold", fmt.Sprintf("%dd", pa.MaxHoldDays), fmt.Sprintf("%dd", pb.MaxHoldDays))
+	param("max positions", fmt.Sprintf("%d", pa.MaxPositions), fmt.Sprintf("%d", pb.MaxPositions))
+	param("max new/day", fmt.Sprintf("%d", pa.MaxNewPerDay), fmt.Sprintf("%d", pb.MaxNewPerDay))
+	param("commission", fmt.Sprintf("%.3f%%", pa.Commission*100), fmt.Sprintf("%.3f%%", pb.Commission*100))
+	param("slippage", fmt.Sprintf("%.3f%%", pa.Slippage*100), fmt.Sprintf("%.3f%%", pb.Slippage*100))
+	param("dividends", fmt.Sprintf("%v", pa.Dividends), fmt.Sprintf("%v", pb.Dividends))
+	param("contribution", fmt.Sprintf("%.0f %s", pa.Contribution, pa.ContributeEvery), fmt.Sprintf("%.0f %s", pb.Contribution, pb.ContributeEvery))
+	return cmp
+}
+
+// NormalizeEquity 시작 자산을 100으로 맞춘 곡선 (자본금이 다른 실행도 겹쳐 볼 수 있게)
+func NormalizeEquity(run *SavedRun) []EquityPoint {
+	base := run.Stats.InitialCapital
+	if base <= 0 && len(run.Equity) > 0 {
+		base = run.Equity[0].Equity
+	}
+	if base <= 0 {
+		return nil
+	}
+	out := make([]EquityPoint, len(run.Equity))
+	for i, p := range run.Equity {
+		out[i] = EquityPoint{Date: p.Date, Equity: p.Equity / base * 100}
+	}
+	return out
+}
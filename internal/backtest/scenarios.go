@@ -0,0 +1,92 @@
+package backtest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scenario 이름 붙은 스트레스 구간 (급락/약세장). 하락 전 고점부터 저점 이후 반등 초입까지 포함
+type Scenario struct {
+	Name  string
+	Title string
+	Start time.Time
+	End   time.Time
+}
+
+func scenarioDate(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+// Scenarios 기본 제공 스트레스 구간 (오래된 순)
+var Scenarios = []Scenario{
+	{Name: "2008-gfc", Title: "Global financial crisis", Start: scenarioDate("2007-10-01"), End: scenarioDate("2009-06-30")},
+	{Name: "2011-debt", Title: "US downgrade / euro debt crisis", Start: scenarioDate("2011-04-01"), End: scenarioDate("2011-12-30")},
+	{Name: "2015-china", Title: "China devaluation selloff", Start: scenarioDate("2015-07-01"), End: scenarioDate("2016-03-31")},
+	{Name: "2018-q4", Title: "Q4 2018 rate-hike selloff", Start: scenarioDate("2018-09-04"), End: scenarioDate("2019-02-28")},
+	{Name: "2020-crash", Title: "COVID crash and rebound", Start: scenarioDate("2020-01-02"), End: scenarioDate("2020-06-30")},
+	{Name: "2022-bear", Title: "2022 inflation bear market", Start: scenarioDate("2022-01-03"), End: scenarioDate("2022-12-30")},
+}
+
+// ParseScenarios "all" 또는 쉼표 구분 이름 → 시나리오 목록
+func ParseScenarios(s string) ([]Scenario, error) {
+	if strings.EqualFold(strings.TrimSpace(s), "all") {
+		return Scenarios, nil
+	}
+	var out []Scenario
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		sc, ok := FindScenario(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q (available: %s, all)", name, strings.Join(ScenarioNames(), ", "))
+		}
+		out = append(out, sc)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no scenario given")
+	}
+	return out, nil
+}
+
+// FindScenario 이름으로 검색
+func FindScenario(name string) (Scenario, bool) {
+	for _, sc := range Scenarios {
+		if sc.Name == name {
+			return sc, true
+		}
+	}
+	return Scenario{}, false
+}
+
+// ScenarioNames 기본 시나리오 이름 목록
+func ScenarioNames() []string {
+	names := make([]string, len(Scenarios))
+	for i, sc := range Scenarios {
+		names[i] = sc.Name
+	}
+	return names
+}
+
+// Period "2020-01-02 ~ 2020-06-30"
+func (sc Scenario) Period() string {
+	return sc.Start.Format("2006-01-02") + " ~ " + sc.End.Format("2006-01-02")
+}
+
+// TradingDays 구간 거래일 수 (근사)
+func (sc Scenario) TradingDays() int {
+	return tradingDaysSince(sc.Start, sc.End)
+}
+
+// TradingDaysBetween from → to 사이 거래일 수 근사 (--from/--to 구간 길이)
+func TradingDaysBetween(from, to time.Time) int {
+	return tradingDaysSince(from, to)
+}
+
+// tradingDaysSince from → to 사이 거래일 수 근사 (연 252일)
+func tradingDaysSince(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24*252/365) + 1
+}
@@ -0,0 +1,181 @@
+package backtest
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// SizingStressConfig 리스크/최대 포지션 조합별 Monte Carlo 설정
+//
+// 백테스트 거래의 R-multiple을 복원추출해 "라운드" 단위로 시뮬레이션한다.
+// 한 라운드 = 평균 보유기간 동안 동시에 열린 포지션 묶음. 라운드마다
+// min(MaxPositions, 시그널 수)개 거래를 동시에 진입하고, 각 거래 리스크는
+// 라운드 시작 자산 × RiskPerTrade (복리).
+// 동시 포지션 간 상관관계는 반영하지 않으므로 낙폭은 다소 낙관적이다.
+type SizingStressConfig struct {
+	RiskLevels      []float64 // 예: 0.005, 0.01, 0.02, 0.03
+	MaxPositions    []int     // 예: 3, 5, 8
+	Simulations     int
+	Rounds          int     // 시뮬레이션 기간 (라운드 수)
+	SignalsPerRound float64 // 라운드당 평균 시그널 수 (포지션 한도 이상이면 스킵)
+	RuinDrawdown    float64 // 이 낙폭 이상이면 파산으로 간주 (예: 0.5 = -50%)
+	MaxAcceptableDD float64 // 추천 기준: 95퍼센타일 MDD 상한 (예: 0.25)
+	Seed            int64
+}
+
+// DefaultSizingStressConfig 0.5%~3% 리스크 × 3/5/8 포지션
+func DefaultSizingStressConfig() SizingStressConfig {
+	return SizingStressConfig{
+		RiskLevels:      []float64{0.005, 0.01, 0.015, 0.02, 0.025, 0.03},
+		MaxPositions:    []int{3, 5, 8},
+		Simulations:     1000,
+		RuinDrawdown:    0.5,
+		MaxAcceptableDD: 0.25,
+	}
+}
+
+// SizingScenario 한 조합의 결과 (수익률/낙폭은 %)
+type SizingScenario struct {
+	RiskPerTrade    float64 `json:"risk_per_trade"`
+	MaxPositions    int     `json:"max_positions"`
+	Heat            float64 `json:"heat"` // 최대 동시 리스크 (RiskPerTrade × MaxPositions)
+	MedianReturn    float64 `json:"median_return"`
+	WorstCase       float64 `json:"worst_case"` // 5th percentile
+	BestCase        float64 `json:"best_case"`  // 95th percentile
+	MedianDrawdown  float64 `json:"median_drawdown"`
+	P95Drawdown     float64 `json:"p95_drawdown"`
+	RuinProbability float64 `json:"ruin_probability"`
+	ReturnToDD      float64 `json:"return_to_dd"` // 중앙 수익률 / 95퍼센타일 MDD
+}
+
+// SizingStressResult 전체 결과
+type SizingStressResult struct {
+	Trades          int              `json:"trades"`
+	Rounds          int              `json:"rounds"`
+	SignalsPerRound float64          `json:"signals_per_round"`
+	Scenarios       []SizingScenario `json:"scenarios"`
+	Recommended     *SizingScenario  `json:"recommended,omitempty"`
+}
+
+// SizingRoundsFromPortfolio 포트폴리오 백테스트에서 라운드 수/라운드당 시그널 추정
+// (평균 동시 포지션으로 거래를 나눠 기간을 맞추고, 한도 때문에 스킵된 시그널도 공급량에 포함)
+func SizingRoundsFromPortfolio(result *PortfolioBacktestResult) (int, float64) {
+	avg := result.AvgPositions
+	if avg < 1 {
+		avg = 1
+	}
+	rounds := int(math.Ceil(float64(result.TotalTrades) / avg))
+	if rounds < 1 {
+		rounds = 1
+	}
+	return rounds, float64(result.TotalTrades+result.SignalsSkipped) / float64(rounds)
+}
+
+// RunSizingStressTest 리스크 × 최대 포지션 조합별 Monte Carlo
+func RunSizingStressTest(trades []Trade, cfg SizingStressConfig) *SizingStressResult {
+	if len(trades) == 0 {
+		return nil
+	}
+	if cfg.Simulations <= 0 {
+		cfg.Simulations = 1000
+	}
+	if cfg.Rounds <= 0 {
+		cfg.Rounds = len(trades)
+	}
+	if cfg.SignalsPerRound <= 0 {
+		cfg.SignalsPerRound = 1
+	}
+	if cfg.RuinDrawdown <= 0 {
+		cfg.RuinDrawdown = 0.5
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	rMultiples := make([]float64, len(trades))
+	for i, t := range trades {
+		rMultiples[i] = t.RMultiple
+	}
+
+	result := &SizingStressResult{Trades: len(trades), Rounds: cfg.Rounds, SignalsPerRound: cfg.SignalsPerRound}
+	for _, maxPos := range cfg.MaxPositions {
+		for _, risk := range cfg.RiskLevels {
+			// 조합마다 같은 시드 → 같은 표본 경로로 비교
+			rng := rand.New(rand.NewSource(cfg.Seed))
+			result.Scenarios = append(result.Scenarios, simulateSizing(rMultiples, risk, maxPos, cfg, rng))
+		}
+	}
+
+	for i := range result.Scenarios {
+		s := &result.Scenarios[i]
+		if cfg.MaxAcceptableDD > 0 && s.P95Drawdown > cfg.MaxAcceptableDD*100 {
+			continue
+		}
+		if s.RuinProbability >= 1 {
+			continue
+		}
+		if result.Recommended == nil || s.MedianReturn > result.Recommended.MedianReturn {
+			result.Recommended = s
+		}
+	}
+	return result
+}
+
+func simulateSizing(rMultiples []float64, risk float64, maxPos int, cfg SizingStressConfig, rng *rand.Rand) SizingScenario {
+	returns := make([]float64, cfg.Simulations)
+	drawdowns := make([]float64, cfg.Simulations)
+	ruin := 0
+
+	whole, frac := math.Modf(cfg.SignalsPerRound)
+	for sim := 0; sim < cfg.Simulations; sim++ {
+		equity, peak, maxDD := 1.0, 1.0, 0.0
+		for round := 0; round < cfg.Rounds; round++ {
+			signals := int(whole)
+			if rng.Float64() < frac {
+				signals++
+			}
+			n := signals
+			if n > maxPos {
+				n = maxPos
+			}
+			var pnl float64
+			for i := 0; i < n; i++ {
+				pnl += equity * risk * rMultiples[rng.Intn(len(rMultiples))]
+			}
+			equity += pnl
+			if equity > peak {
+				peak = equity
+			}
+			if dd := (peak - equity) / peak; dd > maxDD {
+				maxDD = dd
+			}
+			if equity <= 0 || maxDD >= cfg.RuinDrawdown {
+				ruin++
+				break
+			}
+		}
+		returns[sim] = (equity - 1) * 100
+		drawdowns[sim] = maxDD * 100
+	}
+
+	sort.Float64s(returns)
+	sort.Float64s(drawdowns)
+	n := cfg.Simulations
+	s := SizingScenario{
+		RiskPerTrade:    risk,
+		MaxPositions:    maxPos,
+		Heat:            risk * float64(maxPos),
+		MedianReturn:    returns[n/2],
+		WorstCase:       returns[n/20],
+		BestCase:        returns[n*19/20],
+		MedianDrawdown:  drawdowns[n/2],
+		P95Drawdown:     drawdowns[n*19/20],
+		RuinProbability: float64(ruin) / float64(n) * 100,
+	}
+	if s.P95Drawdown > 0 {
+		s.ReturnToDD = s.MedianReturn / s.P95Drawdown
+	}
+	return s
+}
@@ -129,6 +129,7 @@ func RunOptimization(ctx context.Context, allCandles map[string][]model.Candle,
 		btProvider := NewBacktestProvider(allCandles)
 
 		// Create meta strategy with this config
+		cfg.Tranches = simCfg.Tranches
 		meta := strategy.NewStockMetaStrategy(cfg, btProvider)
 		strats := []strategy.Strategy{meta}
 
@@ -87,11 +87,13 @@ func (p *BacktestProvider) GetSymbols(_ context.Context, _ string) ([]model.Stoc
 
 // StockSimConfig holds backtest configuration
 type StockSimConfig struct {
-	Market         string  // "us" or "kr"
-	Days           int     // backtest period in trading days
+	Market         string // "us" or "kr"
+	Days           int    // backtest period in trading days
 	InitialCapital float64
 	MaxPositions   int
-	Commission     float64 // round-trip (e.g., 0.005 = 0.5%)
+	Commission     float64          // round-trip (e.g., 0.005 = 0.5%)
+	EODClose       trader.EODPolicy     // close-before-EOD strategies (nil = trader.DefaultEODPolicy)
+	Tranches       strategy.TrancheExit // three-tranche exits for the strategies tune/optimize build
 	Verbose        bool
 }
 
@@ -101,12 +103,11 @@ func DefaultStockSimConfig(market string) StockSimConfig {
 		Market:       market,
 		Days:         120,
 		MaxPositions: 5,
-		Commission:   0.005, // 0.5% round-trip
+		Commission:   trader.CostModelForMarket(market).RoundTripRate(), // round-trip incl. sell tax (fees preset)
 		Verbose:      false,
 	}
 	if market == "kr" {
 		cfg.InitialCapital = 5000000 // ₩500만
-		cfg.Commission = 0.005       // 세금+수수료
 	} else {
 		cfg.InitialCapital = 5000 // $5,000
 	}
@@ -129,7 +130,7 @@ type StockTrade struct {
 	PnLPct     float64
 	Commission float64
 	IsWin      bool
-	ExitReason string // "stop", "target1", "target2", "timeout"
+	ExitReason string // "stop", "gap_stop", "target1", "target2", "target3", "trailing_stop", "timeout", "eod_close"
 	Regime     string // "bull", "sideways", "bear"
 	HoldDays   int
 }
@@ -153,6 +154,12 @@ type activePosition struct {
 	trailingATR        float64
 	trailingMultiplier float64
 	highestSinceT1     float64
+
+	// 3분할 익절 (t2Fraction > 0): T2에서 일부 매도, 러너는 트레일링 또는 T3
+	target3    float64
+	t2Hit      bool
+	t1Fraction float64
+	t2Fraction float64
 }
 
 // regimeResetter is implemented by strategies with regime cache
@@ -203,6 +210,9 @@ func (s *StockSimulator) Run(ctx context.Context) *StockBacktestResult {
 		tradingDates = tradingDates[len(tradingDates)-s.config.Days:]
 	}
 
+	// 섹터 로테이션 월간 순위는 시뮬레이션 날짜 기준으로 다시 계산
+	strategy.ResetSectorRankingCache()
+
 	log.Printf("[BACKTEST] Simulation: %d trading days (%s ~ %s), %d symbols",
 		len(tradingDates),
 		tradingDates[0].Format("2006-01-02"),
@@ -228,6 +238,9 @@ func (s *StockSimulator) Run(ctx context.Context) *StockBacktestResult {
 			s.scanAndEnter(ctx, date)
 		}
 
+		// 3-1. End-of-day policy strategies don't hold overnight (daemon Monitor.CloseBeforeEOD)
+		s.closeEndOfDay(date)
+
 		// 4. Record equity
 		equity := s.calculateEquity(date)
 		s.equity = append(s.equity, equity)
@@ -283,13 +296,17 @@ func (s *StockSimulator) checkExits(date time.Time) {
 
 		// Priority: stop loss > target > timeout (conservative: stop first)
 
-		// Stop loss check (includes trailing stop from previous day's ratchet)
+		// Stop loss check (includes trailing stop from previous day's ratchet); gapping below the stop fills at the open
 		if candle.Low <= pos.stopLoss {
+			fill, gapped := gapStopFill(candle.Open, pos.stopLoss)
 			reason := "stop"
-			if pos.t1Hit && pos.useTrailing && pos.stopLoss > pos.entryPrice {
+			switch {
+			case gapped:
+				reason = "gap_stop"
+			case pos.t1Hit && pos.useTrailing && pos.stopLoss > pos.entryPrice:
 				reason = "trailing_stop"
 			}
-			s.closePosition(pos, pos.stopLoss, date, reason, holdDays)
+			s.closePosition(pos, fill, date, reason, holdDays)
 			delete(s.positions, sym)
 			continue
 		}
@@ -297,11 +314,8 @@ func (s *StockSimulator) checkExits(date time.Time) {
 		// Target1 partial exit (if not yet hit)
 		if !pos.t1Hit && candle.High >= pos.target1 {
 			if pos.quantity > 1 {
-				// Partial sell: 50%
-				sellQty := math.Floor(pos.quantity / 2)
-				if sellQty < 1 {
-					sellQty = 1
-				}
+				// Partial sell: 50% (or the T1 tranche)
+				sellQty := trader.TrancheQty(pos.quantity, pos.t1Fraction)
 				s.recordPartialSell(pos, pos.target1, date, sellQty, "target1", holdDays)
 				pos.quantity -= sellQty
 				pos.t1Hit = true
@@ -317,8 +331,27 @@ func (s *StockSimulator) checkExits(date time.Time) {
 
 		// Post-T1: T2 profit taking + trailing stop update
 		if pos.t1Hit {
-			// T2 check always applies (fixed profit target)
-			if candle.High >= pos.target2 {
+			if pos.t2Fraction > 0 {
+				// Tranches: sell the T2 slice, keep a runner for the trailing stop / T3
+				if !pos.t2Hit && candle.High >= pos.target2 {
+					if pos.quantity <= 1 {
+						s.closePosition(pos, pos.target2, date, "target2", holdDays)
+						delete(s.positions, sym)
+						continue
+					}
+					sellQty := trader.TrancheQty(pos.quantity, pos.t2Fraction/(1-pos.t1Fraction))
+					s.recordPartialSell(pos, pos.target2, date, sellQty, "target2", holdDays)
+					pos.quantity -= sellQty
+					pos.t2Hit = true
+					pos.stopLoss = math.Max(pos.stopLoss, pos.target1) // runner stop at least T1
+				}
+				if pos.t2Hit && pos.target3 > 0 && candle.High >= pos.target3 {
+					s.closePosition(pos, pos.target3, date, "target3", holdDays)
+					delete(s.positions, sym)
+					continue
+				}
+			} else if candle.High >= pos.target2 {
+				// T2 check always applies (fixed profit target)
 				s.closePosition(pos, pos.target2, date, "target2", holdDays)
 				delete(s.positions, sym)
 				continue
@@ -348,6 +381,22 @@ func (s *StockSimulator) checkExits(date time.Time) {
 	}
 }
 
+// closeEndOfDay flattens positions whose strategy has a close-before-EOD policy at the day's close.
+// Daily bars can't place the exit N minutes before the bell, so the close is the proxy.
+func (s *StockSimulator) closeEndOfDay(date time.Time) {
+	for sym, pos := range s.positions {
+		if s.config.EODClose.Minutes(pos.strategy) == 0 {
+			continue
+		}
+		candle := s.getCandle(sym, date)
+		if candle == nil {
+			continue
+		}
+		s.closePosition(pos, candle.Close, date, "eod_close", s.countTradingDays(pos.entryDate, date))
+		delete(s.positions, sym)
+	}
+}
+
 // scanAndEnter scans universe and enters new positions.
 // Regime detection and strategy selection are handled internally by StockMetaStrategy.
 func (s *StockSimulator) scanAndEnter(ctx context.Context, date time.Time) {
@@ -372,7 +421,8 @@ func (s *StockSimulator) scanAndEnter(ctx context.Context, date time.Time) {
 			}
 		}
 
-		if best != nil {
+		// 페어(2-leg)는 롱 전용 시뮬레이터로 재현할 수 없음 → PairSimulator
+		if best != nil && (best.Guide == nil || best.Guide.Pair == nil) {
 			signals = append(signals, *best)
 		}
 	}
@@ -446,6 +496,10 @@ func (s *StockSimulator) scanAndEnter(ctx context.Context, date time.Time) {
 			useTrailing:        sig.Guide.UseTrailingStop,
 			trailingATR:        sig.Guide.EntryATR,
 			trailingMultiplier: sig.Guide.TrailingMultiplier,
+
+			target3:    sig.Guide.Target3,
+			t1Fraction: sig.Guide.T1Fraction,
+			t2Fraction: sig.Guide.T2Fraction,
 		}
 
 		s.positions[sig.Stock.Symbol] = pos
@@ -501,7 +555,7 @@ func (s *StockSimulator) closePosition(pos *activePosition, exitPrice float64, d
 	}
 }
 
-// recordPartialSell records a T1 partial sell
+// recordPartialSell records a T1 (or tranche T2) partial sell
 func (s *StockSimulator) recordPartialSell(pos *activePosition, exitPrice float64, date time.Time, qty float64, reason string, holdDays int) {
 	commission := qty * exitPrice * s.config.Commission
 	pnl := qty*(exitPrice-pos.entryPrice) - commission
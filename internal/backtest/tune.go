@@ -0,0 +1,202 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
+	"traveler/pkg/model"
+)
+
+// TuneCriteria 전략을 활성 목록에 넣을 최소 기준
+type TuneCriteria struct {
+	MinTrades      int     // 이보다 거래가 적으면 판단 보류 (비활성)
+	MinExpectancyR float64 // 거래당 기대값 (R) 하한 (초과해야 활성)
+	MaxDrawdown    float64 // 최대 낙폭 % 상한
+}
+
+// DefaultTuneCriteria 기본 기준: 거래 10건 이상, 기대값 > 0R, MDD 20% 이하
+func DefaultTuneCriteria() TuneCriteria {
+	return TuneCriteria{MinTrades: 10, MinExpectancyR: 0, MaxDrawdown: 20}
+}
+
+// TuneResult 전략 하나의 단독 백테스트 결과와 판정
+type TuneResult struct {
+	Strategy     string
+	Trades       int
+	WinRate      float64
+	ProfitFactor float64
+	Expectancy   float64 // 거래당 손익 (통화)
+	ExpectancyR  float64 // 거래당 손익 (R)
+	MaxDrawdown  float64
+	TotalRetPct  float64
+	Evaluated    bool // false: 이 시뮬레이터로 평가할 수 없는 전략 (활성 여부는 유지)
+	Enabled      bool
+	Reason       string
+}
+
+// tuneSkipped StockSimulator로 평가하지 않는 전략과 이유
+var tuneSkipped = map[string]string{
+	"crypto-meta": "crypto only (not evaluated)",
+	"pairs":       "two-leg trades (use backtest-pairs)",
+}
+
+// TuneStrategies 레지스트리 전략을 하나씩 같은 데이터/기간/자본으로 단독 시뮬레이션하고 기준으로 판정
+// extraSyms: 전략별로 스캔 유니버스에 더할 종목 (예: sector-rotation → 섹터 ETF).
+// 결과는 활성 전략 먼저, 그 안에서 기대값(R) 내림차순.
+func TuneStrategies(ctx context.Context, allCandles map[string][]model.Candle,
+	simCfg StockSimConfig, sizerCfg trader.SizerConfig, syms []string,
+	extraSyms map[string][]string, crit TuneCriteria) []TuneResult {
+
+	names := strategy.List()
+	sort.Strings(names)
+	simCfg.Verbose = false
+
+	results := make([]TuneResult, 0, len(names))
+	for i, name := range names {
+		if reason, ok := tuneSkipped[name]; ok {
+			results = append(results, TuneResult{Strategy: name, Enabled: true, Reason: reason})
+			continue
+		}
+		fmt.Printf("  [%d/%d] Testing: %-20s ", i+1, len(names), name)
+
+		// 전략마다 새 provider (상태 격리)
+		btProvider := NewBacktestProvider(allCandles)
+		strat, err := strategy.Get(name, btProvider)
+		if err != nil {
+			fmt.Printf("→ skipped: %v\n", err)
+			results = append(results, TuneResult{Strategy: name, Enabled: true, Reason: "not evaluated: " + err.Error()})
+			continue
+		}
+		strat = strategy.WithTrancheExit(strat, simCfg.Tranches, btProvider)
+
+		runSyms := syms
+		if extra := extraSyms[name]; len(extra) > 0 {
+			runSyms = mergeSymbols(syms, extra, allCandles)
+		}
+		sim := NewStockSimulator(simCfg, btProvider, []strategy.Strategy{strat}, sizerCfg, runSyms)
+		res := sim.Run(ctx)
+
+		r := TuneResult{
+			Strategy:     name,
+			Trades:       res.TotalTrades,
+			WinRate:      res.WinRate,
+			ProfitFactor: res.ProfitFactor,
+			Expectancy:   res.Expectancy,
+			ExpectancyR:  res.ExpectancyR,
+			MaxDrawdown:  res.MaxDrawdown,
+			TotalRetPct:  res.TotalReturnPct,
+			Evaluated:    true,
+		}
+		r.Enabled, r.Reason = crit.judge(r)
+		results = append(results, r)
+		fmt.Printf("→ %d trades, %+.2fR, MDD %.1f%%\n", r.Trades, r.ExpectancyR, r.MaxDrawdown)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Evaluated != b.Evaluated {
+			return a.Evaluated
+		}
+		if a.Enabled != b.Enabled {
+			return a.Enabled
+		}
+		return a.ExpectancyR > b.ExpectancyR
+	})
+	return results
+}
+
+// judge 기준 통과 여부와 사유
+func (c TuneCriteria) judge(r TuneResult) (bool, string) {
+	switch {
+	case r.Trades < c.MinTrades:
+		return false, fmt.Sprintf("too few trades (%d < %d)", r.Trades, c.MinTrades)
+	case r.ExpectancyR <= c.MinExpectancyR:
+		return false, fmt.Sprintf("expectancy %+.2fR ≤ %+.2fR", r.ExpectancyR, c.MinExpectancyR)
+	case c.MaxDrawdown > 0 && r.MaxDrawdown > c.MaxDrawdown:
+		return false, fmt.Sprintf("drawdown %.1f%% > %.1f%%", r.MaxDrawdown, c.MaxDrawdown)
+	}
+	return true, "passes"
+}
+
+// mergeSymbols 스캔 종목에 데이터가 있는 추가 종목을 중복 없이 붙임
+func mergeSymbols(syms, extra []string, allCandles map[string][]model.Candle) []string {
+	seen := make(map[string]bool, len(syms)+len(extra))
+	out := make([]string, 0, len(syms)+len(extra))
+	for _, s := range syms {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	for _, s := range extra {
+		if _, ok := allCandles[s]; ok && !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// TuneEnabledStrategies 추천 활성 전략 이름 (이름순)
+func TuneEnabledStrategies(results []TuneResult) []string {
+	var names []string
+	for _, r := range results {
+		if r.Enabled {
+			names = append(names, r.Strategy)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TuneConfigBlock config.yaml에 붙여 넣을 scanner.enabled_strategies 블록
+func TuneConfigBlock(results []TuneResult, universe string, days int, asOf time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# traveler tune: %s, %d trading days, %s\n", universe, days, asOf.Format("2006-01-02"))
+	for _, r := range results {
+		if r.Evaluated && !r.Enabled {
+			fmt.Fprintf(&b, "# disabled %s: %s\n", r.Strategy, r.Reason)
+		}
+	}
+	b.WriteString("scanner:\n  enabled_strategies:\n")
+	for _, name := range TuneEnabledStrategies(results) {
+		fmt.Fprintf(&b, "    - %s\n", name)
+	}
+	return b.String()
+}
+
+// PrintTuneResults 전략별 비교표
+func PrintTuneResults(results []TuneResult, market string, days int) {
+	currSign := "$"
+	if market == "kr" {
+		currSign = "₩"
+	}
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("  Strategy Tuning (%s, %d days)\n", strings.ToUpper(market), days)
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf("  %-3s %-20s %6s %8s %6s %10s %8s %7s %8s  %s\n",
+		"", "Strategy", "Trades", "WinRate", "PF", "Expect", "ExpR", "MDD", "Return", "Verdict")
+	fmt.Println("  " + strings.Repeat("─", 100))
+	for _, r := range results {
+		mark := "✗"
+		if r.Enabled {
+			mark = "✓"
+		}
+		if !r.Evaluated {
+			fmt.Printf("  %-3s %-20s %6s %8s %6s %10s %8s %7s %8s  %s\n",
+				mark, r.Strategy, "-", "-", "-", "-", "-", "-", "-", r.Reason)
+			continue
+		}
+		fmt.Printf("  %-3s %-20s %6d %7.1f%% %6.2f %10s %+7.2fR %6.1f%% %+7.1f%%  %s\n",
+			mark, r.Strategy, r.Trades, r.WinRate, r.ProfitFactor, fmt.Sprintf("%s%.2f", currSign, r.Expectancy),
+			r.ExpectancyR, r.MaxDrawdown, r.TotalRetPct, r.Reason)
+	}
+	fmt.Println()
+}
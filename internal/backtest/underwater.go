@@ -0,0 +1,97 @@
+package backtest
+
+import (
+	"time"
+
+	"traveler/pkg/model"
+)
+
+// UnderwaterPoint 날짜별 고점 대비 낙폭 (%, 0 = 신고점)
+type UnderwaterPoint struct {
+	Date        string  `json:"date"`
+	Drawdown    float64 `json:"drawdown"`               // 전략 자산 (입출금 제외 지수) 기준
+	RelDrawdown float64 `json:"rel_drawdown,omitempty"` // 벤치마크 대비 상대 성과 기준
+}
+
+// DrawdownStats 낙폭과 회복 기간 (거래일 기준)
+type DrawdownStats struct {
+	MaxDrawdown           float64 `json:"max_drawdown"` // %
+	PeakDate              string  `json:"peak_date"`
+	TroughDate            string  `json:"trough_date"`
+	RecoveryDate          string  `json:"recovery_date,omitempty"` // 비어 있으면 기간 내 미회복
+	PeakToTroughDays      int     `json:"peak_to_trough_days"`
+	RecoveryDays          int     `json:"recovery_days"` // 저점 → 전고점 회복, 미회복이면 -1
+	LongestUnderwaterDays int     `json:"longest_underwater_days"`
+	UnderwaterPct         float64 `json:"underwater_pct"` // 신고점 아래에 있던 날 비율
+	CurrentUnderwaterDays int     `json:"current_underwater_days"`
+}
+
+// drawdownSeries 지수(nav) → 날짜별 낙폭(%)과 회복 통계
+func drawdownSeries(dates []time.Time, nav []float64) ([]float64, *DrawdownStats) {
+	if len(nav) == 0 || len(nav) != len(dates) {
+		return nil, nil
+	}
+	dd := make([]float64, len(nav))
+	st := &DrawdownStats{RecoveryDays: -1}
+
+	peak, peakIdx := nav[0], 0
+	maxPeakIdx, troughIdx := 0, 0
+	underwater, below := 0, 0
+	for i, v := range nav {
+		if v >= peak {
+			peak, peakIdx = v, i
+			underwater = 0
+		} else {
+			underwater++
+			below++
+		}
+		if peak > 0 {
+			dd[i] = (peak - v) / peak * 100
+		}
+		if dd[i] > st.MaxDrawdown {
+			st.MaxDrawdown = dd[i]
+			maxPeakIdx, troughIdx = peakIdx, i
+		}
+		if underwater > st.LongestUnderwaterDays {
+			st.LongestUnderwaterDays = underwater
+		}
+	}
+	st.CurrentUnderwaterDays = underwater
+	st.UnderwaterPct = float64(below) / float64(len(nav)) * 100
+
+	st.PeakDate = dates[maxPeakIdx].Format("2006-01-02")
+	st.TroughDate = dates[troughIdx].Format("2006-01-02")
+	st.PeakToTroughDays = troughIdx - maxPeakIdx
+	if st.MaxDrawdown > 0 {
+		for i := troughIdx + 1; i < len(nav); i++ {
+			if nav[i] >= nav[maxPeakIdx] {
+				st.RecoveryDate = dates[i].Format("2006-01-02")
+				st.RecoveryDays = i - troughIdx
+				break
+			}
+		}
+	} else {
+		st.RecoveryDays = 0
+	}
+	return dd, st
+}
+
+// benchmarkNav 날짜별 벤치마크 종가 (없는 날은 직전 종가 유지, 시작 전 데이터가 없으면 nil)
+func benchmarkNav(candles []model.Candle, dates []time.Time) []float64 {
+	closes := make(map[string]float64, len(candles))
+	for _, c := range candles {
+		closes[c.Time.Format("2006-01-02")] = c.Close
+	}
+	nav := make([]float64, len(dates))
+	last := 0.0
+	for i, d := range dates {
+		if c, ok := closes[d.Format("2006-01-02")]; ok && c > 0 {
+			last = c
+		}
+		if last <= 0 {
+			return nil
+		}
+		nav[i] = last
+	}
+	return nav
+}
@@ -65,6 +65,17 @@ func NewClient(leverage int) *Client {
 func (c *Client) Name() string  { return "binance-futures" }
 func (c *Client) IsReady() bool { return c.apiKey != "" && c.secretKey != "" }
 
+// Capabilities 시장가 전용 선물 (USDT 금액 → 레버리지 수량 환산), 숏 가능
+func (c *Client) Capabilities() broker.Capabilities {
+	return broker.Capabilities{
+		MarketOrders:     true,
+		AmountOrders:     true,
+		FractionalShares: true,
+		ExtendedHours:    true,
+		Shorting:         true,
+	}
+}
+
 // Init loads exchange info and sets leverage for given symbols.
 // Must be called before trading.
 func (c *Client) Init(ctx context.Context, symbols []string) error {
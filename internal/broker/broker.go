@@ -58,6 +58,7 @@ type Position struct {
 	MarketValue   float64
 	UnrealizedPnL float64
 	UnrealizedPct float64
+	Currency      string // 평가 통화 (빈 값이면 심볼로 추정 — 해외 계좌의 도쿄/홍콩/중국 종목은 현지 통화)
 }
 
 // AccountBalance 계좌 잔고
@@ -67,6 +68,11 @@ type AccountBalance struct {
 	BuyingPower float64
 	TotalEquity float64
 	Positions   []Position
+
+	// 원화 결제 해외 계좌(통합증거금): 매수가능금액 중 원화 환전분과 적용 환율
+	// BuyingPower에는 원화 환전분이 환율 변동 헤어컷을 뺀 금액으로 포함된다.
+	FXRate    float64 // KRW per USD (0 = 해당 없음)
+	KRWBacked float64 // 원화 예수금으로 살 수 있는 USD (헤어컷 전)
 }
 
 // PendingOrder 미체결 주문
@@ -82,6 +88,18 @@ type PendingOrder struct {
 	CreatedAt time.Time
 }
 
+// Capabilities 브로커가 지원하는 주문 기능
+// AutoTrader/Executor는 이 값으로 주문 형태(시장가/지정가, 금액/수량, 소수점 수량)를 정한다.
+type Capabilities struct {
+	MarketOrders     bool // 시장가 주문 접수 (브로커 내부에서 지정가로 대신해도 true, false면 Executor가 지정가로)
+	LimitOrders      bool // 지정가 주문 (false면 시장가만)
+	AmountOrders     bool // 금액 기준 시장가 매수 (Order.Amount)
+	FractionalShares bool // 소수점 수량
+	ExtendedHours    bool // 정규장 외 주문
+	OCO              bool // 서버측 손절/익절 OCO 주문
+	Shorting         bool // 공매도/숏 포지션
+}
+
 // Broker 브로커 인터페이스
 type Broker interface {
 	// Name 브로커 이름
@@ -90,6 +108,9 @@ type Broker interface {
 	// IsReady 연결 및 인증 상태 확인
 	IsReady() bool
 
+	// Capabilities 지원 주문 기능
+	Capabilities() Capabilities
+
 	// 주문 관련
 	PlaceOrder(ctx context.Context, order Order) (*OrderResult, error)
 	CancelOrder(ctx context.Context, orderID string) error
@@ -103,3 +124,26 @@ type Broker interface {
 	// 시세
 	GetQuote(ctx context.Context, symbol string) (float64, error)
 }
+
+// Fill 체결 내역 (브로커 체결 확인 기준)
+type Fill struct {
+	OrderID  string
+	Symbol   string
+	Side     OrderSide
+	Quantity float64
+	Price    float64 // 체결 평균가
+	Amount   float64 // 체결 금액
+	FilledAt time.Time
+}
+
+// QuoteTimer 시세와 그 체결 시각을 함께 주는 브로커 (선택 인터페이스, 시세 신선도 확인용)
+type QuoteTimer interface {
+	// GetQuoteTime 현재가와 마지막 체결 시각 (시각을 모르면 zero)
+	GetQuoteTime(ctx context.Context, symbol string) (float64, time.Time, error)
+}
+
+// FillHistory 체결내역 조회를 지원하는 브로커 (선택 인터페이스)
+type FillHistory interface {
+	// GetFills from~to 날짜(포함)의 체결 내역, 시간순
+	GetFills(ctx context.Context, from, to time.Time) ([]Fill, error)
+}
@@ -0,0 +1,43 @@
+package broker
+
+import "errors"
+
+// Rejection 브로커가 주문을 처리하지 않았음이 확실한 에러 (선택 인터페이스)
+//
+// 브로커 API가 응답으로 명시적으로 거절했거나 제출 전에 막힌 경우에만 구현한다.
+// 전송 실패, 응답 읽기 실패, 타임아웃처럼 접수 여부를 모르는 에러는 구현하지 않는다.
+type Rejection interface {
+	error
+	// Rejected 주문이 접수되지 않음 (같은 주문을 다시 내도 중복 위험 없음)
+	Rejected() bool
+	// RateLimited 일시적 호출 한도 초과 (잠시 후 재시도하면 된다)
+	RateLimited() bool
+}
+
+// IsRejection err 체인에 명시적 주문 거절이 있는지
+func IsRejection(err error) bool {
+	var r Rejection
+	return errors.As(err, &r) && r.Rejected()
+}
+
+// IsRateLimited err 체인에 호출 한도 초과 거절이 있는지
+func IsRateLimited(err error) bool {
+	var r Rejection
+	return errors.As(err, &r) && r.RateLimited()
+}
+
+// OrderRejectedError 브로커 응답 또는 제출 전 검증에서 거절된 주문
+type OrderRejectedError struct {
+	Reason    string
+	RateLimit bool // 호출 한도 초과로 거절
+}
+
+func (e *OrderRejectedError) Error() string {
+	return "order failed: " + e.Reason
+}
+
+// Rejected 항상 true
+func (e *OrderRejectedError) Rejected() bool { return true }
+
+// RateLimited 호출 한도 초과 여부
+func (e *OrderRejectedError) RateLimited() bool { return e.RateLimit }
@@ -0,0 +1,665 @@
+// Package ibkr Interactive Brokers Client Portal Web API 브로커
+//
+// 로컬에서 Client Portal Gateway(https://localhost:5000)를 띄우고 브라우저로 로그인한 세션을 쓴다.
+// 세션은 1분마다 /tickle로 유지하고, 인증이 풀리면 /iserver/reauthenticate로 복구를 시도한다.
+package ibkr
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/broker"
+)
+
+// DefaultGatewayURL Client Portal Gateway 기본 주소
+const DefaultGatewayURL = "https://localhost:5000/v1/api"
+
+// keepAliveInterval 세션 유지 주기 (게이트웨이는 약 5분 무요청이면 세션 만료)
+const keepAliveInterval = time.Minute
+
+// Config 게이트웨이 접속 설정
+type Config struct {
+	GatewayURL  string // 비우면 DefaultGatewayURL
+	AccountID   string // 비우면 /iserver/accounts의 첫 계좌
+	InsecureTLS bool   // 인증서 검증 생략 (localhost 게이트웨이는 자체 서명이라 항상 생략)
+
+	// ConfirmWarnings 주문 확인 질문(가격 괴리, 장외 시간 등 precautionary message)을 자동 승인 (기본: 거절)
+	ConfirmWarnings bool
+}
+
+// Client IBKR Client Portal API 클라이언트 (broker.Broker 구현)
+type Client struct {
+	baseURL         string
+	httpClient      *http.Client
+	confirmWarnings bool
+
+	mu        sync.Mutex
+	accountID string
+	conids    map[string]int64 // 심볼 → 계약 ID 캐시
+	lastReq   time.Time
+
+	keepAliveOnce sync.Once
+	stop          chan struct{}
+}
+
+// NewClient 생성자
+func NewClient(cfg Config) *Client {
+	base := strings.TrimRight(cfg.GatewayURL, "/")
+	if base == "" {
+		base = DefaultGatewayURL
+	}
+	insecure := cfg.InsecureTLS
+	if u, err := url.Parse(base); err == nil {
+		if h := u.Hostname(); h == "localhost" || h == "127.0.0.1" {
+			insecure = true
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &Client{
+		baseURL:    base,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: transport},
+		accountID:  cfg.AccountID,
+		conids:     make(map[string]int64),
+		stop:       make(chan struct{}),
+
+		confirmWarnings: cfg.ConfirmWarnings,
+	}
+}
+
+// Name 브로커 이름
+func (c *Client) Name() string {
+	return "ibkr"
+}
+
+// Capabilities 정수 수량, 시장가/지정가 (정규장 DAY 주문만 낸다)
+func (c *Client) Capabilities() broker.Capabilities {
+	return broker.Capabilities{
+		MarketOrders: true,
+		LimitOrders:  true,
+	}
+}
+
+// IsReady 게이트웨이 세션 인증 확인. 처음 성공하면 세션 유지 고루틴을 시작한다.
+func (c *Client) IsReady() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	st, err := c.authStatus(ctx)
+	if err != nil {
+		log.Printf("[IBKR] Gateway not reachable at %s: %v", c.baseURL, err)
+		return false
+	}
+	if !st.Authenticated {
+		log.Printf("[IBKR] Gateway session not authenticated — log in at %s", strings.TrimSuffix(c.baseURL, "/v1/api"))
+		return false
+	}
+	if _, err := c.account(ctx); err != nil {
+		log.Printf("[IBKR] Account lookup failed: %v", err)
+		return false
+	}
+	c.keepAliveOnce.Do(func() { go c.keepAlive() })
+	return true
+}
+
+// Close 세션 유지 중지
+func (c *Client) Close() {
+	select {
+	case <-c.stop:
+	default:
+		close(c.stop)
+	}
+}
+
+// keepAlive 1분마다 /tickle, 인증이 풀렸으면 재인증 요청
+func (c *Client) keepAlive() {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		var resp struct {
+			Iserver struct {
+				AuthStatus authStatus `json:"authStatus"`
+			} `json:"iserver"`
+		}
+		err := c.do(ctx, http.MethodPost, "/tickle", nil, &resp)
+		switch {
+		case err != nil:
+			log.Printf("[IBKR] Keep-alive failed: %v", err)
+		case !resp.Iserver.AuthStatus.Authenticated:
+			log.Printf("[IBKR] Session lost, requesting re-authentication")
+			if err := c.do(ctx, http.MethodPost, "/iserver/reauthenticate", nil, nil); err != nil {
+				log.Printf("[IBKR] Re-authentication failed: %v", err)
+			}
+		}
+		cancel()
+	}
+}
+
+type authStatus struct {
+	Authenticated bool   `json:"authenticated"`
+	Connected     bool   `json:"connected"`
+	Competing     bool   `json:"competing"`
+	Message       string `json:"message"`
+}
+
+func (c *Client) authStatus(ctx context.Context) (authStatus, error) {
+	var st authStatus
+	err := c.do(ctx, http.MethodPost, "/iserver/auth/status", nil, &st)
+	return st, err
+}
+
+// account 주문 계좌 ID (설정이 없으면 첫 계좌). /iserver/accounts는 주문 전에 한 번 호출해야 한다.
+func (c *Client) account(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	id := c.accountID
+	c.mu.Unlock()
+
+	var resp struct {
+		Accounts []string `json:"accounts"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/iserver/accounts", nil, &resp); err != nil {
+		return "", err
+	}
+	if id == "" {
+		if len(resp.Accounts) == 0 {
+			return "", fmt.Errorf("no accounts on this gateway session")
+		}
+		id = resp.Accounts[0]
+		c.mu.Lock()
+		c.accountID = id
+		c.mu.Unlock()
+	}
+	// 포트폴리오 엔드포인트도 계좌 목록 조회가 선행돼야 한다
+	if err := c.do(ctx, http.MethodGet, "/portfolio/accounts", nil, nil); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// accountID 캐시된 계좌 ID (없으면 조회)
+func (c *Client) accountIDFor(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	id := c.accountID
+	c.mu.Unlock()
+	if id != "" {
+		return id, nil
+	}
+	return c.account(ctx)
+}
+
+// conid 심볼 → 미국 주식 계약 ID (캐시)
+func (c *Client) conid(ctx context.Context, symbol string) (int64, error) {
+	c.mu.Lock()
+	id, ok := c.conids[symbol]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var results []struct {
+		Conid       json.Number `json:"conid"`
+		Symbol      string      `json:"symbol"`
+		Description string      `json:"description"` // 상장 거래소 (NASDAQ, NYSE 등)
+	}
+	q := url.Values{"symbol": {symbol}, "secType": {"STK"}}
+	if err := c.do(ctx, http.MethodGet, "/iserver/secdef/search?"+q.Encode(), nil, &results); err != nil {
+		return 0, fmt.Errorf("contract search %s: %w", symbol, err)
+	}
+	for _, r := range results {
+		if !strings.EqualFold(r.Symbol, symbol) {
+			continue
+		}
+		if id, err := r.Conid.Int64(); err == nil && id > 0 {
+			c.mu.Lock()
+			c.conids[symbol] = id
+			c.mu.Unlock()
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("no IBKR stock contract for %s", symbol)
+}
+
+// PlaceOrder 주문 (DAY). 게이트웨이 확인 질문(precautionary message)은 ConfirmWarnings가 켜져 있을 때만 승인하고,
+// 아니면 거절한 뒤 경고 문구를 담은 broker.OrderRejectedError를 돌려준다.
+//
+// 전송/응답 읽기 실패는 게이트웨이가 주문을 받았을 수 있으므로 거절로 감싸지 않는다.
+func (c *Client) PlaceOrder(ctx context.Context, order broker.Order) (*broker.OrderResult, error) {
+	acct, err := c.accountIDFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conid, err := c.conid(ctx, order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := orderRequest{
+		AcctID:   acct,
+		Conid:    conid,
+		Side:     strings.ToUpper(string(order.Side)),
+		Quantity: order.Quantity,
+		TIF:      "DAY",
+	}
+	if order.Type == broker.OrderTypeMarket {
+		req.OrderType = "MKT"
+	} else {
+		req.OrderType = "LMT"
+		req.Price = order.LimitPrice
+	}
+
+	var replies []orderReply
+	if err := c.do(ctx, http.MethodPost, "/iserver/account/"+acct+"/orders",
+		map[string][]orderRequest{"orders": {req}}, &replies); err != nil {
+		return nil, fmt.Errorf("place order: %w", err)
+	}
+
+	// 확인 질문 (최대 5회 연쇄)
+	for i := 0; i < 5 && len(replies) > 0 && replies[0].ID != "" && replies[0].OrderID == ""; i++ {
+		warning := strings.Join(replies[0].Message, " / ")
+		if !c.confirmWarnings {
+			log.Printf("[IBKR] %s: declining order warning: %s", order.Symbol, warning)
+			if err := c.do(ctx, http.MethodPost, "/iserver/reply/"+replies[0].ID,
+				map[string]bool{"confirmed": false}, nil); err != nil {
+				log.Printf("[IBKR] %s: decline reply failed: %v", order.Symbol, err)
+			}
+			return nil, &broker.OrderRejectedError{Reason: "IBKR order warning not confirmed: " + warning}
+		}
+		log.Printf("[IBKR] %s: confirming order warning: %s", order.Symbol, warning)
+		var next []orderReply
+		if err := c.do(ctx, http.MethodPost, "/iserver/reply/"+replies[0].ID,
+			map[string]bool{"confirmed": true}, &next); err != nil {
+			return nil, fmt.Errorf("confirm order warning: %w", err)
+		}
+		replies = next
+	}
+	if len(replies) > 0 && replies[0].Error != "" {
+		return nil, &broker.OrderRejectedError{Reason: replies[0].Error}
+	}
+	if len(replies) == 0 || replies[0].OrderID == "" {
+		return nil, fmt.Errorf("place order: no order id in response")
+	}
+
+	r := replies[0]
+	return &broker.OrderResult{
+		OrderID:     r.OrderID,
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		Type:        order.Type,
+		Quantity:    order.Quantity,
+		Status:      mapOrderStatus(r.OrderStatus, 0),
+		Message:     r.OrderStatus,
+		SubmittedAt: time.Now(),
+	}, nil
+}
+
+// CancelOrder 주문 취소
+func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
+	acct, err := c.accountIDFor(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.do(ctx, http.MethodDelete, "/iserver/account/"+acct+"/order/"+orderID, nil, nil); err != nil {
+		return fmt.Errorf("cancel failed: %w", err)
+	}
+	return nil
+}
+
+// GetOrder 주문 상태 조회
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*broker.OrderResult, error) {
+	var st struct {
+		OrderID      json.Number `json:"order_id"`
+		Symbol       string      `json:"symbol"`
+		Side         string      `json:"side"` // "B" / "S"
+		OrderType    string      `json:"order_type"`
+		TotalSize    json.Number `json:"total_size"`
+		CumFill      json.Number `json:"cum_fill"`
+		AveragePrice json.Number `json:"average_price"`
+		OrderStatus  string      `json:"order_status"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/iserver/account/order/status/"+orderID, nil, &st); err != nil {
+		return nil, fmt.Errorf("order status: %w", err)
+	}
+	filled := numberFloat(st.CumFill)
+	res := &broker.OrderResult{
+		OrderID:   orderID,
+		Symbol:    st.Symbol,
+		Side:      broker.OrderSideBuy,
+		Type:      broker.OrderTypeLimit,
+		Quantity:  numberFloat(st.TotalSize),
+		FilledQty: filled,
+		AvgPrice:  numberFloat(st.AveragePrice),
+		Status:    mapOrderStatus(st.OrderStatus, filled),
+		Message:   st.OrderStatus,
+	}
+	if strings.HasPrefix(strings.ToUpper(st.Side), "S") {
+		res.Side = broker.OrderSideSell
+	}
+	if strings.HasPrefix(strings.ToUpper(st.OrderType), "M") {
+		res.Type = broker.OrderTypeMarket
+	}
+	return res, nil
+}
+
+// GetBalance 계좌 요약 (순자산, 현금, 주문가능금액) + 포지션
+func (c *Client) GetBalance(ctx context.Context) (*broker.AccountBalance, error) {
+	acct, err := c.accountIDFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var summary map[string]struct {
+		Amount   float64 `json:"amount"`
+		Currency string  `json:"currency"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/portfolio/"+acct+"/summary", nil, &summary); err != nil {
+		return nil, fmt.Errorf("balance query failed: %w", err)
+	}
+	positions, err := c.GetPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &broker.AccountBalance{
+		Currency:    "USD",
+		CashBalance: summary["totalcashvalue"].Amount,
+		BuyingPower: summary["availablefunds"].Amount, // 마진 배수를 쓰지 않도록 buyingpower 대신 가용 자금
+		TotalEquity: summary["netliquidation"].Amount,
+		Positions:   positions,
+	}, nil
+}
+
+// GetPositions 보유 포지션 (100개 단위 페이지)
+func (c *Client) GetPositions(ctx context.Context) ([]broker.Position, error) {
+	acct, err := c.accountIDFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	positions := []broker.Position{}
+	for page := 0; page < 20; page++ {
+		var rows []positionRow
+		if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/portfolio/%s/positions/%d", acct, page), nil, &rows); err != nil {
+			return nil, fmt.Errorf("positions query failed: %w", err)
+		}
+		for _, r := range rows {
+			if r.Position == 0 {
+				continue
+			}
+			sym := r.Ticker
+			if sym == "" {
+				sym = r.ContractDesc
+			}
+			p := broker.Position{
+				Symbol:        sym,
+				Name:          r.Name,
+				Quantity:      r.Position,
+				AvgCost:       r.AvgPrice,
+				CurrentPrice:  r.MktPrice,
+				MarketValue:   r.MktValue,
+				UnrealizedPnL: r.UnrealizedPnl,
+			}
+			if cost := r.Position * r.AvgPrice; cost != 0 {
+				p.UnrealizedPct = r.UnrealizedPnl / cost * 100
+			}
+			positions = append(positions, p)
+		}
+		if len(rows) < 100 {
+			break
+		}
+	}
+	return positions, nil
+}
+
+// GetPendingOrders 미체결 주문
+func (c *Client) GetPendingOrders(ctx context.Context) ([]broker.PendingOrder, error) {
+	var resp struct {
+		Orders []struct {
+			OrderID        json.Number `json:"orderId"`
+			Ticker         string      `json:"ticker"`
+			Side           string      `json:"side"`
+			OrderType      string      `json:"orderType"`
+			TotalSize      json.Number `json:"totalSize"`
+			FilledQuantity json.Number `json:"filledQuantity"`
+			Price          json.Number `json:"price"`
+			Status         string      `json:"status"`
+			LastExecution  int64       `json:"lastExecutionTime_r"` // ms
+		} `json:"orders"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/iserver/account/orders", nil, &resp); err != nil {
+		return nil, fmt.Errorf("pending query failed: %w", err)
+	}
+	orders := []broker.PendingOrder{}
+	for _, o := range resp.Orders {
+		switch o.Status {
+		case "PreSubmitted", "Submitted", "PendingSubmit":
+		default:
+			continue
+		}
+		side := broker.OrderSideBuy
+		if strings.EqualFold(o.Side, "SELL") {
+			side = broker.OrderSideSell
+		}
+		typ := broker.OrderTypeLimit
+		if strings.EqualFold(o.OrderType, "market") || strings.EqualFold(o.OrderType, "MKT") {
+			typ = broker.OrderTypeMarket
+		}
+		p := broker.PendingOrder{
+			OrderID:   o.OrderID.String(),
+			Symbol:    o.Ticker,
+			Side:      side,
+			Type:      typ,
+			Quantity:  numberFloat(o.TotalSize),
+			FilledQty: numberFloat(o.FilledQuantity),
+			Price:     numberFloat(o.Price),
+			Status:    strings.ToLower(o.Status),
+		}
+		if o.LastExecution > 0 {
+			p.CreatedAt = time.UnixMilli(o.LastExecution)
+		}
+		orders = append(orders, p)
+	}
+	return orders, nil
+}
+
+// GetQuote 현재가 (스냅샷 필드 31 = 마지막 체결가, 없으면 매수/매도 호가 중간)
+func (c *Client) GetQuote(ctx context.Context, symbol string) (float64, error) {
+	price, _, err := c.GetQuoteTime(ctx, symbol)
+	return price, err
+}
+
+// GetQuoteTime 현재가와 스냅샷 갱신 시각 (broker.QuoteTimer, "_updated" 필드가 없으면 zero)
+// 첫 스냅샷 요청은 구독만 시작하고 값이 비어 오는 경우가 많아 몇 번 다시 묻는다.
+func (c *Client) GetQuoteTime(ctx context.Context, symbol string) (float64, time.Time, error) {
+	conid, err := c.conid(ctx, symbol)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	path := fmt.Sprintf("/iserver/marketdata/snapshot?conids=%d&fields=31,84,86", conid)
+	for attempt := 0; attempt < 4; attempt++ {
+		var rows []map[string]interface{}
+		if err := c.do(ctx, http.MethodGet, path, nil, &rows); err != nil {
+			return 0, time.Time{}, fmt.Errorf("quote query failed: %w", err)
+		}
+		if len(rows) > 0 {
+			var at time.Time
+			if ms, ok := rows[0]["_updated"].(float64); ok && ms > 0 {
+				at = time.UnixMilli(int64(ms))
+			}
+			if last := snapshotPrice(rows[0]["31"]); last > 0 {
+				return last, at, nil
+			}
+			bid, ask := snapshotPrice(rows[0]["84"]), snapshotPrice(rows[0]["86"])
+			if bid > 0 && ask > 0 {
+				return (bid + ask) / 2, at, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return 0, time.Time{}, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	return 0, time.Time{}, fmt.Errorf("no market data for %s (check market data subscriptions)", symbol)
+}
+
+// snapshotPrice 스냅샷 가격 문자열 파싱 ("C189.50" = 전일 종가, "H" = 거래 정지 접두사)
+func snapshotPrice(v interface{}) float64 {
+	s, ok := v.(string)
+	if !ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+		return 0
+	}
+	s = strings.TrimLeft(s, "CH")
+	f, _ := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	return f
+}
+
+// do 게이트웨이 JSON 요청 (초당 10회 제한 아래로 간격 유지). out이 nil이면 응답 본문 무시
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	c.mu.Lock()
+	if wait := 120*time.Millisecond - time.Since(c.lastReq); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.lastReq = time.Now()
+	c.mu.Unlock()
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", "traveler")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &APIError{Status: resp.StatusCode, Msg: "gateway session not authenticated (log in to the Client Portal Gateway)"}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &APIError{Status: resp.StatusCode, Msg: "too many requests"}
+	case resp.StatusCode != http.StatusOK:
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error != "" {
+			return &APIError{Status: resp.StatusCode, Msg: e.Error}
+		}
+		return &APIError{Status: resp.StatusCode, Msg: strings.TrimSpace(string(data))}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// APIError 게이트웨이 HTTP 오류 응답 (4xx는 요청이 처리되지 않은 것, 5xx는 처리 여부를 모른다)
+type APIError struct {
+	Status int
+	Msg    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.Status, e.Msg)
+}
+
+// Rejected 4xx 응답 (주문이 접수되지 않음)
+func (e *APIError) Rejected() bool {
+	return e.Status >= 400 && e.Status < 500
+}
+
+// RateLimited 호출 한도 초과
+func (e *APIError) RateLimited() bool {
+	return e.Status == http.StatusTooManyRequests
+}
+
+type orderRequest struct {
+	AcctID    string  `json:"acctId"`
+	Conid     int64   `json:"conid"`
+	OrderType string  `json:"orderType"` // MKT, LMT
+	Side      string  `json:"side"`      // BUY, SELL
+	Quantity  float64 `json:"quantity"`
+	Price     float64 `json:"price,omitempty"`
+	TIF       string  `json:"tif"`
+}
+
+// orderReply 주문 응답: 확인 질문(id + message) 또는 접수 결과(order_id)
+type orderReply struct {
+	ID          string   `json:"id"`
+	Message     []string `json:"message"`
+	OrderID     string   `json:"order_id"`
+	OrderStatus string   `json:"order_status"`
+	Error       string   `json:"error"`
+}
+
+type positionRow struct {
+	Conid         int64   `json:"conid"`
+	ContractDesc  string  `json:"contractDesc"`
+	Ticker        string  `json:"ticker"`
+	Name          string  `json:"name"`
+	Position      float64 `json:"position"`
+	MktPrice      float64 `json:"mktPrice"`
+	MktValue      float64 `json:"mktValue"`
+	AvgPrice      float64 `json:"avgPrice"`
+	UnrealizedPnl float64 `json:"unrealizedPnl"`
+}
+
+// mapOrderStatus IBKR 주문 상태 → broker 상태
+func mapOrderStatus(status string, filled float64) string {
+	switch strings.ToLower(status) {
+	case "filled":
+		return "filled"
+	case "cancelled", "pendingcancel":
+		return "cancelled"
+	case "inactive", "rejected":
+		return "rejected"
+	}
+	if filled > 0 {
+		return "partial"
+	}
+	return "submitted"
+}
+
+func numberFloat(n json.Number) float64 {
+	f, _ := n.Float64()
+	return f
+}
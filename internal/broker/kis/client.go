@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -24,8 +27,13 @@ type Client struct {
 	httpClient *http.Client
 	limiter    *ratelimit.Limiter
 	market     Market
+	fxHaircut  float64 // 원화 환전분 매수가능금액 할인율
+	exchanges  []OverseasExchange // 잔고에 함께 조회할 미국 외 거래소 (도쿄, 홍콩 등)
 }
 
+// DefaultFXHaircut 원화 결제 해외 매수가능금액 할인율 (가환율 → 결제 환율 변동 대비)
+const DefaultFXHaircut = 0.02
+
 // NewClient KIS 해외주식 클라이언트 생성
 func NewClient(creds Credentials) *Client {
 	return &Client{
@@ -34,6 +42,7 @@ func NewClient(creds Credentials) *Client {
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 		limiter:    ratelimit.NewLimiter("kis", 300),
 		market:     MarketOverseas,
+		fxHaircut:  DefaultFXHaircut,
 	}
 }
 
@@ -48,6 +57,29 @@ func NewDomesticClient(creds Credentials) *Client {
 	}
 }
 
+// SetFXHaircut 원화 환전분 매수가능금액 할인율 변경 (0.02 = 2%, 0 이하면 할인 없음)
+func (c *Client) SetFXHaircut(h float64) {
+	if h < 0 {
+		h = 0
+	}
+	c.fxHaircut = h
+}
+
+// SetExchanges 잔고/포지션에 함께 조회할 미국 외 거래소 (TSE, HKS, SHS, SZS 또는 TKSE, SEHK 등)
+func (c *Client) SetExchanges(codes []string) error {
+	c.exchanges = nil
+	for _, code := range codes {
+		ex, ok := LookupExchange(code)
+		if !ok {
+			return fmt.Errorf("unknown KIS overseas exchange: %s", code)
+		}
+		if ex.Suffix != "" {
+			c.exchanges = append(c.exchanges, ex)
+		}
+	}
+	return nil
+}
+
 // IsDomestic 국내 클라이언트 여부
 func (c *Client) IsDomestic() bool {
 	return c.market == MarketDomestic
@@ -58,6 +90,14 @@ func (c *Client) Name() string {
 	return "kis"
 }
 
+// Capabilities 정수 수량, 정규장만. 해외 시장가는 현재가 ±5% 지정가로 대신 낸다.
+func (c *Client) Capabilities() broker.Capabilities {
+	return broker.Capabilities{
+		MarketOrders: true,
+		LimitOrders:  true,
+	}
+}
+
 // IsReady 연결 상태 확인
 func (c *Client) IsReady() bool {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -69,35 +109,49 @@ func (c *Client) IsReady() bool {
 
 // doRequest 공통 HTTP 요청 메서드 (토큰 만료 시 자동 재발급 + 재시도)
 func (c *Client) doRequest(ctx context.Context, method, path string, trID string, body interface{}) ([]byte, error) {
-	respBody, err := c.doRequestOnce(ctx, method, path, trID, body)
-	if err != nil && isTokenExpiredError(respBody) {
+	respBody, _, err := c.doRequestCont(ctx, method, path, trID, body, "")
+	return respBody, err
+}
+
+// doRequestCont 연속조회 요청 (trCont: 요청 tr_cont 헤더, 반환값은 응답 tr_cont 헤더)
+func (c *Client) doRequestCont(ctx context.Context, method, path string, trID string, body interface{}, trCont string) ([]byte, string, error) {
+	respBody, respCont, err := c.doRequestOnce(ctx, method, path, trID, body, trCont)
+	switch ErrorKindOf(err) {
+	case ErrTokenExpired:
 		// 토큰 만료: 무효화 후 재시도 1회
 		log.Printf("[KIS] Token expired, refreshing and retrying...")
 		c.tokenMgr.Invalidate()
 		// 캐시 파일도 삭제
 		os.Remove(c.tokenMgr.GetCacheFile())
-		return c.doRequestOnce(ctx, method, path, trID, body)
+		return c.doRequestOnce(ctx, method, path, trID, body, trCont)
+	case ErrRateLimited:
+		// 조회만 잠시 후 1회 재시도 (주문은 Executor가 백오프·주문 저널과 함께 재시도)
+		if method != "GET" {
+			break
+		}
+		log.Printf("[KIS] Rate limited on %s, retrying in %s", path, rateLimitRetryDelay)
+		select {
+		case <-ctx.Done():
+			return respBody, respCont, err
+		case <-time.After(rateLimitRetryDelay):
+		}
+		return c.doRequestOnce(ctx, method, path, trID, body, trCont)
 	}
-	return respBody, err
+	return respBody, respCont, err
 }
 
-// isTokenExpiredError 토큰 만료 에러 감지 (EGW00123)
-func isTokenExpiredError(respBody []byte) bool {
-	if len(respBody) == 0 {
-		return false
-	}
-	return strings.Contains(string(respBody), "EGW00123")
-}
+// rateLimitRetryDelay 호출 한도 초과 조회 재시도 대기
+const rateLimitRetryDelay = time.Second
 
 // doRequestOnce 단일 HTTP 요청 실행
-func (c *Client) doRequestOnce(ctx context.Context, method, path string, trID string, body interface{}) ([]byte, error) {
+func (c *Client) doRequestOnce(ctx context.Context, method, path string, trID string, body interface{}, trCont string) ([]byte, string, error) {
 	if err := c.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit: %w", err)
+		return nil, "", fmt.Errorf("rate limit: %w", err)
 	}
 
 	token, err := c.tokenMgr.GetToken(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("get token: %w", err)
+		return nil, "", fmt.Errorf("get token: %w", err)
 	}
 
 	url := BaseURL + path
@@ -106,14 +160,14 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, trID st
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("marshal body: %w", err)
+			return nil, "", fmt.Errorf("marshal body: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, "", fmt.Errorf("create request: %w", err)
 	}
 
 	// KIS 필수 헤더
@@ -122,25 +176,40 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, trID st
 	req.Header.Set("appkey", c.creds.AppKey)
 	req.Header.Set("appsecret", c.creds.AppSecret)
 	req.Header.Set("tr_id", trID)
+	if trCont != "" {
+		req.Header.Set("tr_cont", trCont)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+		return nil, "", fmt.Errorf("send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, "", fmt.Errorf("read response: %w", err)
 	}
+	respCont := resp.Header.Get("tr_cont")
 
 	if resp.StatusCode != http.StatusOK {
-		return respBody, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		if apiErr := httpAPIError(resp.StatusCode, respBody); apiErr != nil {
+			return respBody, respCont, apiErr
+		}
+		return respBody, respCont, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
 	}
 
-	return respBody, nil
+	return respBody, respCont, nil
 }
 
+// hasNextPage 응답 tr_cont가 다음 페이지 있음("F"/"M")을 나타내는지
+func hasNextPage(trCont string) bool {
+	return trCont == "F" || trCont == "M"
+}
+
+// maxFillPages 체결내역 연속조회 최대 페이지 (응답 이상 시 무한 루프 방지)
+const maxFillPages = 50
+
 // getAccountParts 계좌번호를 앞 8자리와 뒤 2자리로 분리
 func (c *Client) getAccountParts() (string, string, error) {
 	parts := strings.Split(c.creds.AccountNo, "-")
@@ -194,15 +263,22 @@ func (c *Client) placeOverseasOrder(ctx context.Context, order broker.Order) (*b
 		return nil, err
 	}
 
-	var trID string
-	if order.Side == broker.OrderSideBuy {
-		trID = TrIDBuyReal
-	} else {
-		trID = TrIDSellReal
+	// 거래소별 TR ID/주문 코드 (7203.T → 도쿄, 그 외 미국)
+	ex, pdno, ok := ExchangeForSymbol(order.Symbol)
+	if !ok {
+		ex, pdno = overseasExchanges[c.detectExchange(order.Symbol)], order.Symbol
+	}
+	trID := ex.BuyTrID
+	if order.Side == broker.OrderSideSell {
+		trID = ex.SellTrID
+	}
+	qty, err := ex.lotQuantity(order.Quantity)
+	if err != nil {
+		return nil, &broker.OrderRejectedError{Reason: err.Error()}
 	}
 
 	ordDvsn := "00" // 해외주식은 지정가만 지원
-	price := fmt.Sprintf("%.2f", order.LimitPrice)
+	price := ex.formatPrice(ex.roundPrice(order.LimitPrice, order.Side == broker.OrderSideSell))
 	if order.Type == broker.OrderTypeMarket {
 		// 해외주식 시장가 미지원 → 현재가 기준 공격적 지정가로 변환
 		currentPrice, err := c.GetQuote(ctx, order.Symbol)
@@ -210,25 +286,18 @@ func (c *Client) placeOverseasOrder(ctx context.Context, order broker.Order) (*b
 			return nil, fmt.Errorf("get quote for market order: %w", err)
 		}
 		if order.Side == broker.OrderSideBuy {
-			price = fmt.Sprintf("%.2f", currentPrice*1.05) // 5% 위
+			price = ex.formatPrice(ex.roundPrice(currentPrice*1.05, true)) // 5% 위
 		} else {
-			price = fmt.Sprintf("%.2f", currentPrice*0.95) // 5% 아래
+			price = ex.formatPrice(ex.roundPrice(currentPrice*0.95, false)) // 5% 아래
 		}
 	}
 
-	exchange := c.detectExchange(order.Symbol)
-	// 주문 API는 4자리 거래소 코드 사용 (NASD, NYSE, AMEX)
-	orderExch := exchangeOrderCode[exchange]
-	if orderExch == "" {
-		orderExch = "NASD"
-	}
-
 	req := orderRequest{
 		CANO:            cano,
 		ACNT:            acnt,
-		OVRS_EXCG_CD:    orderExch,
-		PDNO:            order.Symbol,
-		ORD_QTY:         fmt.Sprintf("%.0f", order.Quantity),
+		OVRS_EXCG_CD:    ex.OrderCode,
+		PDNO:            pdno,
+		ORD_QTY:         fmt.Sprintf("%.0f", qty),
 		OVRS_ORD_UNPR:   price,
 		ORD_SVR_DVSN_CD: "0",
 		ORD_DVSN:        ordDvsn,
@@ -245,7 +314,7 @@ func (c *Client) placeOverseasOrder(ctx context.Context, order broker.Order) (*b
 	}
 
 	if resp.RtCd != "0" {
-		return nil, fmt.Errorf("order failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return nil, newAPIError("order", resp.MsgCd, resp.Msg1)
 	}
 
 	return &broker.OrderResult{
@@ -253,7 +322,7 @@ func (c *Client) placeOverseasOrder(ctx context.Context, order broker.Order) (*b
 		Symbol:      order.Symbol,
 		Side:        order.Side,
 		Type:        order.Type,
-		Quantity:    order.Quantity,
+		Quantity:    qty,
 		Status:      "submitted",
 		Message:     resp.Msg1,
 		SubmittedAt: time.Now(),
@@ -275,7 +344,10 @@ func (c *Client) placeDomesticOrder(ctx context.Context, order broker.Order) (*b
 	}
 
 	ordDvsn := "00" // 지정가
-	price := fmt.Sprintf("%d", int(order.LimitPrice))
+	// 호가단위 미준수 시 주문 거부 → 매수 내림, 매도 올림 (ETF 여부는 가격이 아니라 종목으로 판단)
+	// Executor를 거치지 않는 주문(SIM-SL 매도, DCA, 헤지)도 여기서 맞춘다.
+	limit := broker.RoundKRXPrice(order.LimitPrice, broker.IsKRETF(order.Symbol), order.Side == broker.OrderSideSell)
+	price := fmt.Sprintf("%.0f", limit)
 	if order.Type == broker.OrderTypeMarket {
 		ordDvsn = "01"
 		price = "0"
@@ -301,7 +373,7 @@ func (c *Client) placeDomesticOrder(ctx context.Context, order broker.Order) (*b
 	}
 
 	if resp.RtCd != "0" {
-		return nil, fmt.Errorf("order failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return nil, newAPIError("order", resp.MsgCd, resp.Msg1)
 	}
 
 	return &broker.OrderResult{
@@ -346,7 +418,7 @@ func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
 	}
 
 	if resp.RtCd != "0" {
-		return fmt.Errorf("cancel failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return newAPIError("cancel", resp.MsgCd, resp.Msg1)
 	}
 
 	return nil
@@ -391,7 +463,7 @@ func (c *Client) getOverseasBalance(ctx context.Context) (*broker.AccountBalance
 	}
 
 	if resp.RtCd != "0" {
-		return nil, fmt.Errorf("balance query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return nil, newAPIError("balance query", resp.MsgCd, resp.Msg1)
 	}
 
 	balance := &broker.AccountBalance{
@@ -423,6 +495,7 @@ func (c *Client) getOverseasBalance(ctx context.Context) (*broker.AccountBalance
 			MarketValue:   marketValue,
 			UnrealizedPnL: unrealizedPnL,
 			UnrealizedPct: unrealizedPct,
+			Currency:      "USD",
 		}
 
 		balance.Positions = append(balance.Positions, pos)
@@ -434,11 +507,25 @@ func (c *Client) getOverseasBalance(ctx context.Context) (*broker.AccountBalance
 		totalPositionValue += pos.MarketValue
 	}
 
-	// 매수가능금액(외화예수금) 조회
-	buyingPower, err := c.getBuyingPower(ctx)
-	if err == nil && buyingPower > 0 {
-		balance.BuyingPower = buyingPower
-		balance.CashBalance = buyingPower
+	// 미국 외 거래소 포지션 (현지 통화 평가금액이라 USD 총자산에는 합산하지 않음)
+	for _, ex := range c.exchanges {
+		positions, err := c.getExchangePositions(ctx, cano, acnt, ex)
+		if err != nil {
+			log.Printf("[KIS] %s positions query failed: %v", ex.Name, err)
+			continue
+		}
+		balance.Positions = append(balance.Positions, positions...)
+	}
+
+	// 매수가능금액 조회 (외화예수금 + 통합증거금 원화 환전분)
+	bp, err := c.getBuyingPower(ctx)
+	if err == nil {
+		if power := bp.power(c.fxHaircut); power > 0 {
+			balance.BuyingPower = power
+			balance.CashBalance = power
+			balance.FXRate = bp.rate
+			balance.KRWBacked = bp.krwBacked()
+		}
 	}
 
 	// 총 자산 = 보유 주식 평가금액 + 현금
@@ -447,11 +534,74 @@ func (c *Client) getOverseasBalance(ctx context.Context) (*broker.AccountBalance
 	return balance, nil
 }
 
-// getBuyingPower 매수가능금액(외화예수금) 조회
-func (c *Client) getBuyingPower(ctx context.Context) (float64, error) {
+// getExchangePositions 미국 외 거래소 보유 종목 (심볼은 7203.T 형식, 금액은 현지 통화)
+func (c *Client) getExchangePositions(ctx context.Context, cano, acnt string, ex OverseasExchange) ([]broker.Position, error) {
+	params := fmt.Sprintf("?CANO=%s&ACNT_PRDT_CD=%s&OVRS_EXCG_CD=%s&TR_CRCY_CD=%s&CTX_AREA_FK200=&CTX_AREA_NK200=",
+		cano, acnt, ex.OrderCode, ex.Currency)
+
+	respBody, err := c.doRequest(ctx, "GET", "/uapi/overseas-stock/v1/trading/inquire-present-balance"+params, TrIDBalanceReal, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp balanceResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+	if resp.RtCd != "0" {
+		return nil, newAPIError("balance query", resp.MsgCd, resp.Msg1)
+	}
+
+	positions := make([]broker.Position, 0, len(resp.Output1))
+	for _, p := range resp.Output1 {
+		qty := parseFloat(p.OVRS_CBLC_QTY)
+		if qty <= 0 {
+			continue
+		}
+		avgCost := parseFloat(p.PCHS_AVG_PRIC)
+		pnl := parseFloat(p.FRCR_EVLU_PFLS_AMT)
+		var pct float64
+		if avgCost > 0 {
+			pct = pnl / (avgCost * qty) * 100
+		}
+		positions = append(positions, broker.Position{
+			Symbol:        ex.YahooSymbol(p.OVRS_PDNO),
+			Name:          p.OVRS_ITEM_NAME,
+			Quantity:      qty,
+			AvgCost:       avgCost,
+			CurrentPrice:  parseFloat(p.NOW_PRIC2),
+			MarketValue:   parseFloat(p.OVRS_STCK_EVLU_AMT),
+			UnrealizedPnL: pnl,
+			UnrealizedPct: pct,
+			Currency:      ex.Currency,
+		})
+	}
+	return positions, nil
+}
+
+// overseasBuyingPower 해외 매수가능금액 구성
+type overseasBuyingPower struct {
+	usd   float64 // 외화(USD) 주문가능금액
+	total float64 // 통합증거금 원화 환전분 포함 주문가능금액 (USD 환산, 가환율 기준)
+	rate  float64 // 가환율 (KRW/USD)
+}
+
+// krwBacked 원화 예수금으로 충당되는 USD
+func (b overseasBuyingPower) krwBacked() float64 {
+	return math.Max(0, b.total-b.usd)
+}
+
+// power 실제 사이징에 쓸 매수가능금액: 외화 전액 + 원화 환전분 × (1 - haircut)
+// 원화 결제분은 가환율로 계산되고 결제일 환율로 정산되므로 장중 환율이 오르면 부족해질 수 있다.
+func (b overseasBuyingPower) power(haircut float64) float64 {
+	return b.usd + b.krwBacked()*(1-haircut)
+}
+
+// getBuyingPower 매수가능금액(외화예수금 + 원화 환전분) 조회
+func (c *Client) getBuyingPower(ctx context.Context) (overseasBuyingPower, error) {
+	var bp overseasBuyingPower
 	cano, acnt, err := c.getAccountParts()
 	if err != nil {
-		return 0, err
+		return bp, err
 	}
 
 	// 매수가능금액 조회 - AAPL 기준으로 조회 (종목 지정 필요)
@@ -460,7 +610,7 @@ func (c *Client) getBuyingPower(ctx context.Context) (float64, error) {
 
 	respBody, err := c.doRequest(ctx, "GET", "/uapi/overseas-stock/v1/trading/inquire-psamount"+params, TrIDBuyingPower, nil)
 	if err != nil {
-		return 0, err
+		return bp, err
 	}
 
 	// 디버그: raw 응답 출력 (필요시 주석 해제)
@@ -468,14 +618,17 @@ func (c *Client) getBuyingPower(ctx context.Context) (float64, error) {
 
 	var resp buyingPowerResponse
 	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return 0, err
+		return bp, err
 	}
 
 	if resp.RtCd != "0" {
-		return 0, fmt.Errorf("buying power query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return bp, newAPIError("buying power query", resp.MsgCd, resp.Msg1)
 	}
 
-	return parseFloat(resp.Output.ORD_PSBL_FRCR_AMT), nil
+	bp.usd = parseFloat(resp.Output.ORD_PSBL_FRCR_AMT)
+	bp.total = math.Max(bp.usd, math.Max(parseFloat(resp.Output.OVRS_ORD_PSBL_AMT), parseFloat(resp.Output.FRCR_ORD_PSBL_AMT1)))
+	bp.rate = parseFloat(resp.Output.EXRT)
+	return bp, nil
 }
 
 // GetPositions 보유 포지션 조회
@@ -543,6 +696,162 @@ func (c *Client) getOverseasPendingOrders(ctx context.Context) ([]broker.Pending
 	return orders, nil
 }
 
+// GetFills 체결내역 조회 (broker.FillHistory, 연속조회로 기간 전체)
+func (c *Client) GetFills(ctx context.Context, from, to time.Time) ([]broker.Fill, error) {
+	cano, acnt, err := c.getAccountParts()
+	if err != nil {
+		return nil, err
+	}
+	var fills []broker.Fill
+	if c.market == MarketDomestic {
+		fills, err = c.getDomesticFills(ctx, cano, acnt, from, to)
+	} else {
+		fills, err = c.getOverseasFills(ctx, cano, acnt, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// API는 최신순 → 시간순 정렬
+	sort.SliceStable(fills, func(i, j int) bool { return fills[i].FilledAt.Before(fills[j].FilledAt) })
+	return fills, nil
+}
+
+// getOverseasFills 해외주식 주문체결내역 (체결분만)
+func (c *Client) getOverseasFills(ctx context.Context, cano, acnt string, from, to time.Time) ([]broker.Fill, error) {
+	var fills []broker.Fill
+	trCont, fk, nk := "", "", ""
+	for page := 0; page < maxFillPages; page++ {
+		params := fmt.Sprintf("?CANO=%s&ACNT_PRDT_CD=%s&PDNO=%%25&ORD_STRT_DT=%s&ORD_END_DT=%s&SLL_BUY_DVSN=00&CCLD_NCCS_DVSN=01&OVRS_EXCG_CD=%%25&SORT_SQN=DS&ORD_DT=&ORD_GNO_BRNO=&ODNO=&CTX_AREA_NK200=%s&CTX_AREA_FK200=%s",
+			cano, acnt, from.Format("20060102"), to.Format("20060102"), neturl.QueryEscape(nk), neturl.QueryEscape(fk))
+
+		respBody, respCont, err := c.doRequestCont(ctx, "GET", "/uapi/overseas-stock/v1/trading/inquire-ccnl"+params, TrIDFillsReal, nil, trCont)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp fillsResponse
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response: %w", err)
+		}
+		if resp.RtCd != "0" {
+			return nil, newAPIError("fills query", resp.MsgCd, resp.Msg1)
+		}
+		fills = append(fills, overseasFills(resp, from.Location())...)
+
+		if !hasNextPage(respCont) || resp.CtxAreaNK == "" {
+			return fills, nil
+		}
+		trCont, fk, nk = "N", strings.TrimSpace(resp.CtxAreaFK), strings.TrimSpace(resp.CtxAreaNK)
+	}
+	log.Printf("[KIS] fills query stopped after %d pages", maxFillPages)
+	return fills, nil
+}
+
+// overseasFills 해외 체결내역 응답 한 페이지 → 체결 목록
+func overseasFills(resp fillsResponse, loc *time.Location) []broker.Fill {
+	fills := make([]broker.Fill, 0, len(resp.Output))
+	for _, o := range resp.Output {
+		qty := parseFloat(o.FT_CCLD_QTY)
+		if qty <= 0 {
+			continue
+		}
+		price := parseFloat(o.FT_CCLD_UNPR3)
+		amount := parseFloat(o.FT_CCLD_AMT3)
+		if amount == 0 {
+			amount = qty * price
+		}
+		fills = append(fills, broker.Fill{
+			OrderID:  o.ODNO,
+			Symbol:   o.PDNO,
+			Side:     fillSide(o.SLL_BUY_DVSN_CD),
+			Quantity: qty,
+			Price:    price,
+			Amount:   amount,
+			FilledAt: parseOrderTime(o.ORD_DT, o.ORD_TMD, loc),
+		})
+	}
+	return fills
+}
+
+// getDomesticFills 국내주식 일별주문체결 (체결분만)
+func (c *Client) getDomesticFills(ctx context.Context, cano, acnt string, from, to time.Time) ([]broker.Fill, error) {
+	var fills []broker.Fill
+	trCont, fk, nk := "", "", ""
+	for page := 0; page < maxFillPages; page++ {
+		params := fmt.Sprintf("?CANO=%s&ACNT_PRDT_CD=%s&INQR_STRT_DT=%s&INQR_END_DT=%s&SLL_BUY_DVSN_CD=00&INQR_DVSN=00&PDNO=&CCLD_DVSN=01&ORD_GNO_BRNO=&ODNO=&INQR_DVSN_3=00&INQR_DVSN_1=&CTX_AREA_FK100=%s&CTX_AREA_NK100=%s",
+			cano, acnt, from.Format("20060102"), to.Format("20060102"), neturl.QueryEscape(fk), neturl.QueryEscape(nk))
+
+		respBody, respCont, err := c.doRequestCont(ctx, "GET", "/uapi/domestic-stock/v1/trading/inquire-daily-ccld"+params, TrIDDomFillsReal, nil, trCont)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp domFillsResponse
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response: %w", err)
+		}
+		if resp.RtCd != "0" {
+			return nil, newAPIError("fills query", resp.MsgCd, resp.Msg1)
+		}
+		fills = append(fills, domesticFills(resp, from.Location())...)
+
+		if !hasNextPage(respCont) || resp.CtxAreaNK == "" {
+			return fills, nil
+		}
+		trCont, fk, nk = "N", strings.TrimSpace(resp.CtxAreaFK), strings.TrimSpace(resp.CtxAreaNK)
+	}
+	log.Printf("[KIS] fills query stopped after %d pages", maxFillPages)
+	return fills, nil
+}
+
+// domesticFills 국내 체결내역 응답 한 페이지 → 체결 목록
+func domesticFills(resp domFillsResponse, loc *time.Location) []broker.Fill {
+	fills := make([]broker.Fill, 0, len(resp.Output1))
+	for _, o := range resp.Output1 {
+		qty := parseFloat(o.TOT_CCLD_QTY)
+		if qty <= 0 {
+			continue
+		}
+		price := parseFloat(o.AVG_PRVS)
+		amount := parseFloat(o.TOT_CCLD_AMT)
+		if amount == 0 {
+			amount = qty * price
+		}
+		fills = append(fills, broker.Fill{
+			OrderID:  o.ODNO,
+			Symbol:   o.PDNO,
+			Side:     fillSide(o.SLL_BUY_DVSN_CD),
+			Quantity: qty,
+			Price:    price,
+			Amount:   amount,
+			FilledAt: parseOrderTime(o.ORD_DT, o.ORD_TMD, loc),
+		})
+	}
+	return fills
+}
+
+// fillSide KIS 매도매수구분 코드 ("01"=매도)
+func fillSide(code string) broker.OrderSide {
+	if code == "01" {
+		return broker.OrderSideSell
+	}
+	return broker.OrderSideBuy
+}
+
+// parseOrderTime YYYYMMDD + HHMMSS → time (시각 없으면 자정)
+func parseOrderTime(date, tmd string, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	if len(tmd) >= 6 {
+		if t, err := time.ParseInLocation("20060102150405", date+tmd[:6], loc); err == nil {
+			return t
+		}
+	}
+	t, _ := time.ParseInLocation("20060102", date, loc)
+	return t
+}
+
 // GetQuote 현재가 조회
 func (c *Client) GetQuote(ctx context.Context, symbol string) (float64, error) {
 	if c.market == MarketDomestic {
@@ -553,11 +862,23 @@ func (c *Client) GetQuote(ctx context.Context, symbol string) (float64, error) {
 
 // getOverseasQuote 해외주식 현재가 (자동 거래소 탐지)
 func (c *Client) getOverseasQuote(ctx context.Context, symbol string) (float64, error) {
+	price, _, _, err := c.getOverseasQuoteExchange(ctx, symbol)
+	return price, err
+}
+
+// getOverseasQuoteExchange 해외주식 현재가와 시세를 받은 KIS 종목코드/거래소 코드
+func (c *Client) getOverseasQuoteExchange(ctx context.Context, symbol string) (float64, string, string, error) {
+	// 미국 외 거래소는 접미사로 확정
+	if ex, code, ok := ExchangeForSymbol(symbol); ok {
+		price, err := c.GetQuoteWithExchange(ctx, code, ex.Code)
+		return price, code, ex.Code, err
+	}
+
 	// 먼저 추측 기반으로 시도
 	exchange := c.detectExchange(symbol)
 	price, err := c.GetQuoteWithExchange(ctx, symbol, exchange)
 	if err == nil && price > 0 {
-		return price, nil
+		return price, symbol, exchange, nil
 	}
 
 	// 실패시 모든 거래소 시도
@@ -568,11 +889,11 @@ func (c *Client) getOverseasQuote(ctx context.Context, symbol string) (float64,
 		}
 		price, err := c.GetQuoteWithExchange(ctx, symbol, excd)
 		if err == nil && price > 0 {
-			return price, nil
+			return price, symbol, excd, nil
 		}
 	}
 
-	return 0, fmt.Errorf("could not get quote for %s from any exchange", symbol)
+	return 0, "", "", fmt.Errorf("could not get quote for %s from any exchange", symbol)
 }
 
 // GetQuoteWithExchange 거래소 지정 현재가 조회
@@ -590,7 +911,7 @@ func (c *Client) GetQuoteWithExchange(ctx context.Context, symbol, exchange stri
 	}
 
 	if resp.RtCd != "0" {
-		return 0, fmt.Errorf("quote query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return 0, newAPIError("quote query", resp.MsgCd, resp.Msg1)
 	}
 
 	return parseFloat(resp.Output.LAST), nil
@@ -619,7 +940,7 @@ func (c *Client) getDomesticBalance(ctx context.Context) (*broker.AccountBalance
 	}
 
 	if resp.RtCd != "0" {
-		return nil, fmt.Errorf("balance query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return nil, newAPIError("balance query", resp.MsgCd, resp.Msg1)
 	}
 
 	balance := &broker.AccountBalance{
@@ -690,7 +1011,7 @@ func (c *Client) getDomesticPendingOrders(ctx context.Context) ([]broker.Pending
 	}
 
 	if resp.RtCd != "0" {
-		return nil, fmt.Errorf("pending query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return nil, newAPIError("pending query", resp.MsgCd, resp.Msg1)
 	}
 
 	orders := make([]broker.PendingOrder, 0, len(resp.Output))
@@ -733,7 +1054,7 @@ func (c *Client) getDomesticQuote(ctx context.Context, symbol string) (float64,
 	}
 
 	if resp.RtCd != "0" {
-		return 0, fmt.Errorf("quote query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+		return 0, newAPIError("quote query", resp.MsgCd, resp.Msg1)
 	}
 
 	return parseFloat(resp.Output.STCK_PRPR), nil
@@ -774,7 +1095,7 @@ func (c *Client) GetDailyCandles(ctx context.Context, symbol string, days int) (
 
 		if resp.RtCd != "0" {
 			if page == 0 {
-				return nil, fmt.Errorf("candle query failed: [%s] %s", resp.MsgCd, resp.Msg1)
+				return nil, newAPIError("candle query", resp.MsgCd, resp.Msg1)
 			}
 			break
 		}
@@ -819,6 +1140,14 @@ type domCandleItem = struct {
 	ACML_VOL       string `json:"acml_vol"`
 }
 
+// formatUSPrice $1 미만은 소수 4자리(서브페니), 이상은 2자리
+func formatUSPrice(price float64) string {
+	if price < 1 {
+		return fmt.Sprintf("%.4f", price)
+	}
+	return fmt.Sprintf("%.2f", price)
+}
+
 // parseFloat 문자열을 float64로 변환
 func parseFloat(s string) float64 {
 	s = strings.TrimSpace(s)
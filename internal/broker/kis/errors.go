@@ -0,0 +1,149 @@
+package kis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind KIS 에러 분류
+type ErrorKind string
+
+const (
+	ErrUnknown             ErrorKind = "unknown"
+	ErrTokenExpired        ErrorKind = "token_expired"        // 접근토큰 만료/무효 → 재발급 후 재시도
+	ErrRateLimited         ErrorKind = "rate_limited"         // 초당 거래건수/토큰 발급 제한 → 잠시 후 재시도
+	ErrMarketClosed        ErrorKind = "market_closed"        // 장 운영시간 아님 → 다음 장까지 재시도 무의미
+	ErrInsufficientBalance ErrorKind = "insufficient_balance" // 주문가능금액/잔고 부족
+	ErrInvalidTick         ErrorKind = "invalid_tick"         // 호가단위 미준수 가격
+	ErrInvalidOrder        ErrorKind = "invalid_order"        // 수량/종목/계좌 등 주문 내용 오류
+)
+
+// APIError 분류된 KIS 응답 에러 (rt_cd != "0" 또는 HTTP 오류 본문의 msg_cd/msg1)
+//
+// Error()는 "order failed: [MSGCD] 메시지" 형식에 영문 안내를 덧붙인다.
+// broker.Rejection을 구현하므로 trader는 errors.As로 거절/호출 한도 초과를 판정한다.
+type APIError struct {
+	Op         string // "order", "balance query" 등 (HTTP 오류면 빈 값)
+	HTTPStatus int    // HTTP 오류 응답이면 상태 코드
+	Code       string // msg_cd
+	Msg        string // msg1 (한국어 원문)
+	Kind       ErrorKind
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	if e.HTTPStatus != 0 {
+		fmt.Fprintf(&sb, "API error %d", e.HTTPStatus)
+	} else {
+		sb.WriteString(e.Op + " failed")
+	}
+	fmt.Fprintf(&sb, ": [%s] %s", e.Code, strings.TrimSpace(e.Msg))
+	if hint := e.Hint("en"); hint != "" {
+		sb.WriteString(" — " + hint)
+	}
+	return sb.String()
+}
+
+// Retryable 같은 요청을 곧바로(또는 잠시 후) 다시 보내도 되는지
+func (e *APIError) Retryable() bool {
+	return e.Kind == ErrTokenExpired || e.Kind == ErrRateLimited
+}
+
+// Rejected KIS가 응답 코드로 거절한 요청 (5xx는 분류된 코드가 있을 때만 — 그 외는 접수 여부를 모른다)
+func (e *APIError) Rejected() bool {
+	return e.HTTPStatus < 500 || e.Kind != ErrUnknown
+}
+
+// RateLimited 초당 거래건수/토큰 발급 제한
+func (e *APIError) RateLimited() bool {
+	return e.Kind == ErrRateLimited
+}
+
+// Hint 사용자 안내 문구 ("ko" = 한국어, 그 외 영어). 분류되지 않은 에러는 빈 문자열
+func (e *APIError) Hint(lang string) string {
+	h, ok := errorHints[e.Kind]
+	if !ok {
+		return ""
+	}
+	if lang == "ko" {
+		return h[1]
+	}
+	return h[0]
+}
+
+var errorHints = map[ErrorKind][2]string{
+	ErrTokenExpired:        {"access token expired; it is reissued automatically", "접근토큰 만료 — 자동으로 재발급합니다"},
+	ErrRateLimited:         {"KIS rate limit hit; retrying after a short wait", "KIS 호출 한도 초과 — 잠시 후 재시도합니다"},
+	ErrMarketClosed:        {"market is closed; the order will not fill until the next session", "장 운영시간이 아닙니다 — 다음 장에 다시 주문하세요"},
+	ErrInsufficientBalance: {"insufficient buying power or holdings; reduce the size or add funds", "주문가능금액/잔고 부족 — 수량을 줄이거나 입금하세요"},
+	ErrInvalidTick:         {"price does not match the tick size; round to a valid tick", "호가단위에 맞지 않는 가격 — 호가단위로 맞춰 주문하세요"},
+	ErrInvalidOrder:        {"order rejected as invalid; check symbol, quantity and account", "주문 내용 오류 — 종목/수량/계좌를 확인하세요"},
+}
+
+// codeKinds 확인된 msg_cd
+var codeKinds = map[string]ErrorKind{
+	"EGW00121": ErrTokenExpired, // 유효하지 않은 token
+	"EGW00123": ErrTokenExpired, // 기간이 만료된 token
+	"EGW00133": ErrRateLimited,  // 접근토큰 발급 잠시 후 다시 시도 (1분당 1회)
+	"EGW00201": ErrRateLimited,  // 초당 거래건수를 초과하였습니다
+}
+
+// messageKinds msg_cd가 화면/상품마다 달라서 메시지 문구로 분류 (순서대로 첫 일치)
+var messageKinds = []struct {
+	kind     ErrorKind
+	keywords []string
+}{
+	{ErrTokenExpired, []string{"만료된 token", "유효하지 않은 token"}},
+	{ErrRateLimited, []string{"초당 거래건수", "잠시 후 다시"}},
+	{ErrMarketClosed, []string{"장운영시간", "장 운영시간", "장종료", "장마감", "장시작전", "주문가능시간", "휴장"}},
+	{ErrInsufficientBalance, []string{"주문가능금액", "매수가능금액", "잔고부족", "잔고가 부족", "증거금", "매도가능수량"}},
+	{ErrInvalidTick, []string{"호가단위", "호가 단위"}},
+	{ErrInvalidOrder, []string{"주문수량", "종목코드", "계좌번호", "주문단가"}},
+}
+
+// classifyError msg_cd와 메시지로 에러 분류
+func classifyError(code, msg string) ErrorKind {
+	if k, ok := codeKinds[code]; ok {
+		return k
+	}
+	for _, m := range messageKinds {
+		for _, kw := range m.keywords {
+			if strings.Contains(msg, kw) {
+				return m.kind
+			}
+		}
+	}
+	return ErrUnknown
+}
+
+// newAPIError rt_cd != "0" 응답 → 분류된 에러
+func newAPIError(op, code, msg string) *APIError {
+	return &APIError{Op: op, Code: code, Msg: msg, Kind: classifyError(code, msg)}
+}
+
+// httpAPIError HTTP 오류 응답 본문에 msg_cd가 있거나 429면 분류된 에러, 그 외는 nil
+func httpAPIError(status int, body []byte) *APIError {
+	var r struct {
+		MsgCd string `json:"msg_cd"`
+		Msg1  string `json:"msg1"`
+	}
+	if json.Unmarshal(body, &r) != nil || r.MsgCd == "" {
+		if status == http.StatusTooManyRequests {
+			return &APIError{HTTPStatus: status, Msg: strings.TrimSpace(string(body)), Kind: ErrRateLimited}
+		}
+		return nil
+	}
+	return &APIError{HTTPStatus: status, Code: r.MsgCd, Msg: r.Msg1, Kind: classifyError(r.MsgCd, r.Msg1)}
+}
+
+// ErrorKindOf err 체인에서 KIS 에러 분류 (KIS 에러가 아니면 ErrUnknown)
+func ErrorKindOf(err error) ErrorKind {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	return ErrUnknown
+}
@@ -0,0 +1,138 @@
+package kis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"traveler/internal/broker"
+)
+
+// OverseasExchange KIS 해외 거래소별 코드/TR ID
+// 미국 외 종목은 Yahoo 심볼 접미사(7203.T, 0700.HK, 600519.SS)로 거래소를 구분한다.
+type OverseasExchange struct {
+	Name       string
+	Code       string // 시세 조회용 3자리 (NAS, TSE, HKS ...)
+	OrderCode  string // 주문/잔고용 4자리 (NASD, TKSE, SEHK ...)
+	Currency   string
+	Suffix     string // Yahoo 심볼 접미사 (미국은 "")
+	BuyTrID    string
+	SellTrID   string
+	CancelTrID string
+	LotSize    float64 // 고정 매매 단위 (0 = 1주 또는 종목마다 다름)
+}
+
+// 거래소 코드 — 미국 외 시세 조회용 (3자리)
+const (
+	ExchangeTokyo    = "TSE" // 도쿄
+	ExchangeHongKong = "HKS" // 홍콩
+	ExchangeShanghai = "SHS" // 상해
+	ExchangeShenzhen = "SZS" // 심천
+)
+
+// overseasExchanges 지원 거래소 (시세 코드 → 정보)
+var overseasExchanges = map[string]OverseasExchange{
+	ExchangeNASDAQ: {Name: "NASDAQ", Code: ExchangeNASDAQ, OrderCode: "NASD", Currency: "USD",
+		BuyTrID: TrIDBuyReal, SellTrID: TrIDSellReal, CancelTrID: TrIDCancelReal},
+	ExchangeNYSE: {Name: "NYSE", Code: ExchangeNYSE, OrderCode: "NYSE", Currency: "USD",
+		BuyTrID: TrIDBuyReal, SellTrID: TrIDSellReal, CancelTrID: TrIDCancelReal},
+	ExchangeAMEX: {Name: "AMEX", Code: ExchangeAMEX, OrderCode: "AMEX", Currency: "USD",
+		BuyTrID: TrIDBuyReal, SellTrID: TrIDSellReal, CancelTrID: TrIDCancelReal},
+	ExchangeTokyo: {Name: "Tokyo", Code: ExchangeTokyo, OrderCode: "TKSE", Currency: "JPY", Suffix: ".T",
+		BuyTrID: "TTTS0308U", SellTrID: "TTTS0307U", CancelTrID: "TTTS0309U", LotSize: 100},
+	ExchangeHongKong: {Name: "Hong Kong", Code: ExchangeHongKong, OrderCode: "SEHK", Currency: "HKD", Suffix: ".HK",
+		BuyTrID: "TTTS1002U", SellTrID: "TTTS1001U", CancelTrID: "TTTS1003U"},
+	ExchangeShanghai: {Name: "Shanghai", Code: ExchangeShanghai, OrderCode: "SHAA", Currency: "CNY", Suffix: ".SS",
+		BuyTrID: "TTTS0202U", SellTrID: "TTTS1005U", CancelTrID: "TTTS0302U", LotSize: 100},
+	ExchangeShenzhen: {Name: "Shenzhen", Code: ExchangeShenzhen, OrderCode: "SZAA", Currency: "CNY", Suffix: ".SZ",
+		BuyTrID: "TTTS0305U", SellTrID: "TTTS0304U", CancelTrID: "TTTS0306U", LotSize: 100},
+}
+
+// LookupExchange 시세 코드(TSE) 또는 주문 코드(TKSE)로 거래소 조회
+func LookupExchange(code string) (OverseasExchange, bool) {
+	code = strings.ToUpper(code)
+	if ex, ok := overseasExchanges[code]; ok {
+		return ex, true
+	}
+	for _, ex := range overseasExchanges {
+		if ex.OrderCode == code {
+			return ex, true
+		}
+	}
+	return OverseasExchange{}, false
+}
+
+// ExchangeForSymbol 접미사 심볼(7203.T) → 거래소와 KIS 종목코드. 미국 심볼이면 ok=false
+func ExchangeForSymbol(symbol string) (ex OverseasExchange, code string, ok bool) {
+	i := strings.LastIndex(symbol, ".")
+	if i <= 0 {
+		return OverseasExchange{}, "", false
+	}
+	suffix := strings.ToUpper(symbol[i:])
+	for _, e := range overseasExchanges {
+		if e.Suffix != "" && e.Suffix == suffix {
+			return e, kisProductCode(e, symbol[:i]), true
+		}
+	}
+	return OverseasExchange{}, "", false
+}
+
+// kisProductCode Yahoo 종목코드 → KIS 종목코드 (홍콩은 5자리: 0700 → 00700)
+func kisProductCode(ex OverseasExchange, code string) string {
+	if ex.Code == ExchangeHongKong && len(code) < 5 {
+		return strings.Repeat("0", 5-len(code)) + code
+	}
+	return code
+}
+
+// YahooSymbol KIS 잔고의 종목코드 → 스캐너/시세용 심볼 (홍콩 00700 → 0700.HK, 미국은 그대로)
+func (ex OverseasExchange) YahooSymbol(code string) string {
+	if ex.Suffix == "" {
+		return code
+	}
+	if ex.Code == ExchangeHongKong {
+		code = strings.TrimLeft(code, "0")
+		if len(code) < 4 {
+			code = strings.Repeat("0", 4-len(code)) + code
+		}
+	}
+	return code + ex.Suffix
+}
+
+// roundPrice 거래소 호가단위로 맞춤 (매도 올림, 매수 내림)
+func (ex OverseasExchange) roundPrice(price float64, up bool) float64 {
+	switch ex.Code {
+	case ExchangeTokyo:
+		return broker.RoundJPXPrice(price, up)
+	case ExchangeHongKong:
+		return broker.RoundHKEXPrice(price, up)
+	case ExchangeShanghai, ExchangeShenzhen:
+		return broker.RoundToTick(price, broker.CNTickSize(price), up)
+	}
+	return broker.RoundUSPrice(price, up)
+}
+
+// formatPrice 주문 단가 문자열 (엔화는 정수, 홍콩은 소수 3자리)
+func (ex OverseasExchange) formatPrice(price float64) string {
+	switch ex.Code {
+	case ExchangeTokyo:
+		return fmt.Sprintf("%.0f", price)
+	case ExchangeHongKong:
+		return fmt.Sprintf("%.3f", price)
+	case ExchangeShanghai, ExchangeShenzhen:
+		return fmt.Sprintf("%.2f", price)
+	}
+	return formatUSPrice(price)
+}
+
+// lotQuantity 매매 단위로 내림 (도쿄/중국 100주). 한 단위도 안 되면 에러
+func (ex OverseasExchange) lotQuantity(qty float64) (float64, error) {
+	if ex.LotSize <= 1 {
+		return qty, nil
+	}
+	lots := math.Floor(qty/ex.LotSize+1e-9) * ex.LotSize
+	if lots <= 0 {
+		return 0, fmt.Errorf("quantity %.0f is below the %s board lot of %.0f shares", qty, ex.Name, ex.LotSize)
+	}
+	return lots, nil
+}
@@ -0,0 +1,94 @@
+package kis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+var kstZone = time.FixedZone("KST", 9*60*60)
+
+// GetQuoteTime 현재가와 마지막 체결 시각 (broker.QuoteTimer)
+// 현재가 API는 체결 시각을 주지 않아 가장 최근 1분봉의 시각을 쓴다. 시각을 모르면 zero.
+func (c *Client) GetQuoteTime(ctx context.Context, symbol string) (float64, time.Time, error) {
+	if c.market == MarketDomestic {
+		price, err := c.getDomesticQuote(ctx, symbol)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		at, _ := c.domesticLastTradeTime(ctx, symbol)
+		return price, at, nil
+	}
+
+	price, code, excd, err := c.getOverseasQuoteExchange(ctx, symbol)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	at, _ := c.overseasLastTradeTime(ctx, code, excd)
+	return price, at, nil
+}
+
+// domesticLastTradeTime 국내 당일 마지막 분봉 시각 (KST)
+func (c *Client) domesticLastTradeTime(ctx context.Context, symbol string) (time.Time, error) {
+	params := fmt.Sprintf("?FID_ETC_CLS_CODE=&FID_COND_MRKT_DIV_CODE=J&FID_INPUT_ISCD=%s&FID_INPUT_HOUR_1=%s&FID_PW_DATA_INCU_YN=N",
+		symbol, time.Now().In(kstZone).Format("150405"))
+
+	respBody, err := c.doRequest(ctx, "GET", "/uapi/domestic-stock/v1/quotations/inquire-time-itemchartprice"+params, TrIDDomMinuteReal, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var resp struct {
+		RtCd    string `json:"rt_cd"`
+		MsgCd   string `json:"msg_cd"`
+		Msg1    string `json:"msg1"`
+		Output2 []struct {
+			STCK_BSOP_DATE string `json:"stck_bsop_date"` // 영업일자 (YYYYMMDD)
+			STCK_CNTG_HOUR string `json:"stck_cntg_hour"` // 체결시간 (HHMMSS)
+		} `json:"output2"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal response: %w", err)
+	}
+	if resp.RtCd != "0" {
+		return time.Time{}, newAPIError("minute chart query", resp.MsgCd, resp.Msg1)
+	}
+	if len(resp.Output2) == 0 {
+		return time.Time{}, fmt.Errorf("no minute bars for %s", symbol)
+	}
+	// 최신순
+	last := resp.Output2[0]
+	return time.ParseInLocation("20060102150405", last.STCK_BSOP_DATE+last.STCK_CNTG_HOUR, kstZone)
+}
+
+// overseasLastTradeTime 해외 마지막 분봉 시각 (한국 기준 일시로 받아 KST)
+func (c *Client) overseasLastTradeTime(ctx context.Context, code, excd string) (time.Time, error) {
+	params := fmt.Sprintf("?AUTH=&EXCD=%s&SYMB=%s&NMIN=1&PINC=0&NEXT=&NREC=1&FILL=&KEYB=", excd, code)
+
+	respBody, err := c.doRequest(ctx, "GET", "/uapi/overseas-price/v1/quotations/inquire-time-itemchartprice"+params, TrIDMinuteReal, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var resp struct {
+		RtCd    string `json:"rt_cd"`
+		MsgCd   string `json:"msg_cd"`
+		Msg1    string `json:"msg1"`
+		Output2 []struct {
+			KYMD string `json:"kymd"` // 한국기준일자 (YYYYMMDD)
+			KHMS string `json:"khms"` // 한국기준시간 (HHMMSS)
+		} `json:"output2"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal response: %w", err)
+	}
+	if resp.RtCd != "0" {
+		return time.Time{}, newAPIError("minute chart query", resp.MsgCd, resp.Msg1)
+	}
+	if len(resp.Output2) == 0 {
+		return time.Time{}, fmt.Errorf("no minute bars for %s", code)
+	}
+	last := resp.Output2[0]
+	return time.ParseInLocation("20060102150405", last.KYMD+last.KHMS, kstZone)
+}
@@ -18,6 +18,8 @@ const (
 	TrIDOrderReal     = "TTTS3001R" // 주문내역 조회
 	TrIDPriceReal     = "HHDFS00000300" // 해외주식 현재가
 	TrIDBuyingPower   = "TTTS3007R" // 해외주식 매수가능금액조회
+	TrIDFillsReal     = "TTTS3035R" // 해외주식 주문체결내역
+	TrIDMinuteReal    = "HHDFS76950200" // 해외주식 분봉
 )
 
 // 국내주식 거래 ID (실전투자)
@@ -29,6 +31,8 @@ const (
 	TrIDDomPriceReal   = "FHKST01010100" // 국내 현재가
 	TrIDDomCandleReal  = "FHKST03010100" // 국내 일봉
 	TrIDDomBuyPower    = "TTTC8908R"     // 국내 매수가능금액
+	TrIDDomFillsReal   = "TTTC8001R"     // 국내 일별주문체결조회 (3개월 이내)
+	TrIDDomMinuteReal  = "FHKST03010200" // 국내 당일분봉
 )
 
 // 거래소 코드 — 시세 조회용 (3자리)
@@ -38,12 +42,7 @@ const (
 	ExchangeAMEX   = "AMS" // 아멕스
 )
 
-// 거래소 코드 — 주문용 (4자리)
-var exchangeOrderCode = map[string]string{
-	"NYS": "NYSE",
-	"NAS": "NASD",
-	"AMS": "AMEX",
-}
+// 주문용 4자리 코드(NASD, NYSE, AMEX)와 미국 외 거래소는 exchanges.go
 
 // tokenRequest 토큰 발급 요청
 type tokenRequest struct {
@@ -160,8 +159,8 @@ type buyingPowerResponse struct {
 	Msg1   string `json:"msg1"`
 	Output struct {
 		ORD_PSBL_FRCR_AMT  string `json:"ord_psbl_frcr_amt"`  // 외화주문가능금액 (USD)
-		OVRS_ORD_PSBL_AMT  string `json:"ovrs_ord_psbl_amt"`  // 해외주문가능금액
-		FRCR_ORD_PSBL_AMT1 string `json:"frcr_ord_psbl_amt1"` // (참고용)
+		OVRS_ORD_PSBL_AMT  string `json:"ovrs_ord_psbl_amt"`  // 해외주문가능금액 (통합증거금 원화 환전분 포함)
+		FRCR_ORD_PSBL_AMT1 string `json:"frcr_ord_psbl_amt1"` // 외화주문가능금액1 (통합증거금 포함)
 		MAX_ORD_PSBL_QTY   string `json:"max_ord_psbl_qty"`   // 최대주문가능수량
 		EXRT               string `json:"exrt"`               // 환율
 	} `json:"output"`
@@ -250,6 +249,44 @@ type domPendingResponse struct {
 	} `json:"output"`
 }
 
+// fillsResponse 해외 주문체결내역 응답 (TTTS3035R)
+type fillsResponse struct {
+	RtCd   string `json:"rt_cd"`
+	MsgCd  string `json:"msg_cd"`
+	Msg1   string `json:"msg1"`
+	Output []struct {
+		ORD_DT          string `json:"ord_dt"`          // 주문일자 (현지, YYYYMMDD)
+		ODNO            string `json:"odno"`            // 주문번호
+		PDNO            string `json:"pdno"`            // 종목코드
+		SLL_BUY_DVSN_CD string `json:"sll_buy_dvsn_cd"` // "01"=매도, "02"=매수
+		FT_CCLD_QTY     string `json:"ft_ccld_qty"`     // 체결수량
+		FT_CCLD_UNPR3   string `json:"ft_ccld_unpr3"`   // 체결단가
+		FT_CCLD_AMT3    string `json:"ft_ccld_amt3"`    // 체결금액
+		ORD_TMD         string `json:"ord_tmd"`         // 주문시각 (HHMMSS)
+	} `json:"output"`
+	CtxAreaFK string `json:"ctx_area_fk200"` // 연속조회검색조건
+	CtxAreaNK string `json:"ctx_area_nk200"` // 연속조회키
+}
+
+// domFillsResponse 국내 일별주문체결 응답 (TTTC8001R)
+type domFillsResponse struct {
+	RtCd    string `json:"rt_cd"`
+	MsgCd   string `json:"msg_cd"`
+	Msg1    string `json:"msg1"`
+	Output1 []struct {
+		ORD_DT          string `json:"ord_dt"`          // 주문일자
+		ORD_TMD         string `json:"ord_tmd"`         // 주문시각
+		ODNO            string `json:"odno"`            // 주문번호
+		PDNO            string `json:"pdno"`            // 종목코드
+		SLL_BUY_DVSN_CD string `json:"sll_buy_dvsn_cd"` // "01"=매도, "02"=매수
+		TOT_CCLD_QTY    string `json:"tot_ccld_qty"`    // 총체결수량
+		AVG_PRVS        string `json:"avg_prvs"`        // 체결평균가
+		TOT_CCLD_AMT    string `json:"tot_ccld_amt"`    // 총체결금액
+	} `json:"output1"`
+	CtxAreaFK string `json:"ctx_area_fk100"` // 연속조회검색조건
+	CtxAreaNK string `json:"ctx_area_nk100"` // 연속조회키
+}
+
 // domCandleResponse 국내 일봉 응답 (FHKST03010100)
 type domCandleResponse struct {
 	RtCd   string `json:"rt_cd"`
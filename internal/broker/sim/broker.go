@@ -107,6 +107,14 @@ func (sb *SimBroker) IsReady() bool {
 	return true
 }
 
+// Capabilities 지정가는 그 가격, 시장가는 provider 시세로 즉시 체결 (수량 기준)
+func (sb *SimBroker) Capabilities() broker.Capabilities {
+	return broker.Capabilities{
+		MarketOrders: true,
+		LimitOrders:  true,
+	}
+}
+
 func (sb *SimBroker) PlaceOrder(ctx context.Context, order broker.Order) (*broker.OrderResult, error) {
 	sb.mu.Lock()
 	defer sb.mu.Unlock()
@@ -296,6 +304,12 @@ func (sb *SimBroker) GetQuote(ctx context.Context, symbol string) (float64, erro
 	return sb.getQuoteFromProvider(ctx, symbol)
 }
 
+// GetQuoteTime 시뮬레이션은 조회한 시세 그대로 체결하므로 시각은 항상 지금
+func (sb *SimBroker) GetQuoteTime(ctx context.Context, symbol string) (float64, time.Time, error) {
+	price, err := sb.getQuoteFromProvider(ctx, symbol)
+	return price, time.Now(), err
+}
+
 // --- helpers ---
 
 // getQuoteFromProvider fetches latest close price via provider's GetDailyCandles.
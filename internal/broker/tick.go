@@ -0,0 +1,151 @@
+package broker
+
+import "math"
+
+// KRXTickSize 한국거래소 호가단위 (2023년 개편 기준, 코스피/코스닥 동일)
+// ETF/ETN은 2,000원 이상 5원 단일 호가
+func KRXTickSize(price float64, etf bool) float64 {
+	if etf {
+		if price < 2000 {
+			return 1
+		}
+		return 5
+	}
+	switch {
+	case price < 2000:
+		return 1
+	case price < 5000:
+		return 5
+	case price < 20000:
+		return 10
+	case price < 50000:
+		return 50
+	case price < 200000:
+		return 100
+	case price < 500000:
+		return 500
+	default:
+		return 1000
+	}
+}
+
+// USTickSize 미국 주식 호가단위 (Reg NMS Rule 612: $1 이상 1센트, 미만 0.01센트)
+func USTickSize(price float64) float64 {
+	if price < 1 {
+		return 0.0001
+	}
+	return 0.01
+}
+
+// JPXTickSize 도쿄증권거래소 호가단위 (일반 종목 기준)
+// TOPIX100 종목은 더 촘촘하지만 일반 종목 호가가 항상 그 배수라 주문에는 그대로 유효
+func JPXTickSize(price float64) float64 {
+	switch {
+	case price <= 3000:
+		return 1
+	case price <= 5000:
+		return 5
+	case price <= 30000:
+		return 10
+	case price <= 50000:
+		return 50
+	case price <= 300000:
+		return 100
+	case price <= 500000:
+		return 500
+	default:
+		return 1000
+	}
+}
+
+// HKEXTickSize 홍콩거래소 호가단위 (주식 스프레드 테이블 Part A)
+func HKEXTickSize(price float64) float64 {
+	switch {
+	case price < 0.25:
+		return 0.001
+	case price < 0.5:
+		return 0.005
+	case price < 10:
+		return 0.01
+	case price < 20:
+		return 0.02
+	case price < 100:
+		return 0.05
+	case price < 200:
+		return 0.1
+	case price < 500:
+		return 0.2
+	case price < 1000:
+		return 0.5
+	case price < 2000:
+		return 1
+	case price < 5000:
+		return 2
+	default:
+		return 5
+	}
+}
+
+// CNTickSize 상해/심천 A주 호가단위 (0.01위안 단일)
+func CNTickSize(price float64) float64 {
+	return 0.01
+}
+
+// RoundToTick 가격을 호가단위에 맞춤 (up=true면 올림, false면 내림)
+func RoundToTick(price, tick float64, up bool) float64 {
+	if tick <= 0 || price <= 0 {
+		return price
+	}
+	// 부동소수 오차 보정 (예: 100.10/0.01 = 10009.999...)
+	n := price / tick
+	if up {
+		n = math.Ceil(n - 1e-9)
+	} else {
+		n = math.Floor(n + 1e-9)
+	}
+	// 소수 호가(USD)는 반올림으로 표현 오차 제거
+	return math.Round(n*tick*1e4) / 1e4
+}
+
+// RoundKRXPrice KRX 호가단위로 맞춘 가격
+// 밴드 경계(2,000/5,000/20,000...)는 상위 밴드 호가의 배수라 올림 후에도 유효
+func RoundKRXPrice(price float64, etf, up bool) float64 {
+	return RoundToTick(price, KRXTickSize(price, etf), up)
+}
+
+// KRETFCodes 매매 대상 한국 ETF (ETF는 별도 호가단위, symbols.KRETFSymbols 유니버스도 이 목록)
+var KRETFCodes = []string{
+	"069500", // KODEX 200 (KOSPI 200 추종)
+	"122630", // KODEX 레버리지 (KOSPI 200 2x)
+	"114800", // KODEX 인버스 (KOSPI 200 -1x)
+}
+
+// IsKRETF 한국 ETF 여부
+func IsKRETF(symbol string) bool {
+	for _, s := range KRETFCodes {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// OnKRXTick 가격이 이미 KRX 호가단위에 맞는지
+func OnKRXTick(price float64, etf bool) bool {
+	return price > 0 && RoundKRXPrice(price, etf, false) == price
+}
+
+// RoundUSPrice 미국 주식 호가단위로 맞춘 가격
+func RoundUSPrice(price float64, up bool) float64 {
+	return RoundToTick(price, USTickSize(price), up)
+}
+
+// RoundJPXPrice 도쿄 호가단위로 맞춘 가격
+func RoundJPXPrice(price float64, up bool) float64 {
+	return RoundToTick(price, JPXTickSize(price), up)
+}
+
+// RoundHKEXPrice 홍콩 호가단위로 맞춘 가격
+func RoundHKEXPrice(price float64, up bool) float64 {
+	return RoundToTick(price, HKEXTickSize(price), up)
+}
@@ -0,0 +1,114 @@
+package broker
+
+import "testing"
+
+func TestRoundKRXPrice(t *testing.T) {
+	tests := []struct {
+		price float64
+		etf   bool
+		up    bool
+		want  float64
+	}{
+		{1999, false, false, 1999},
+		{4997, false, false, 4995},
+		{4997, false, true, 5000},
+		{15234, false, false, 15230},
+		{48760, false, true, 48800},
+		{72340, false, false, 72300},
+		{312700, false, true, 313000},
+		{812345, false, false, 812000},
+		{35432, true, false, 35430}, // ETF: 5원 단위
+		{35432, true, true, 35435},
+		{35435, false, false, 35400}, // 일반 주식: 5원 단위 가격도 50원 호가로 맞춤
+		{35435, false, true, 35450},
+	}
+	for _, tt := range tests {
+		got := RoundKRXPrice(tt.price, tt.etf, tt.up)
+		if got != tt.want {
+			t.Errorf("RoundKRXPrice(%v, etf=%v, up=%v) = %v, want %v", tt.price, tt.etf, tt.up, got, tt.want)
+		}
+	}
+}
+
+func TestOnKRXTick(t *testing.T) {
+	tests := []struct {
+		price float64
+		etf   bool
+		want  bool
+	}{
+		{35435, true, true},
+		{35435, false, false},
+		{35450, false, true},
+		{35450, true, true}, // 주식 호가는 ETF 호가의 배수
+		{1999, false, true},
+		{0, true, false},
+	}
+	for _, tt := range tests {
+		if got := OnKRXTick(tt.price, tt.etf); got != tt.want {
+			t.Errorf("OnKRXTick(%v, etf=%v) = %v, want %v", tt.price, tt.etf, got, tt.want)
+		}
+	}
+}
+
+func TestRoundUSPrice(t *testing.T) {
+	tests := []struct {
+		price float64
+		up    bool
+		want  float64
+	}{
+		{100.10, false, 100.10},
+		{187.456, false, 187.45},
+		{187.451, true, 187.46},
+		{0.53219, false, 0.5321},
+		{0.53211, true, 0.5322},
+	}
+	for _, tt := range tests {
+		got := RoundUSPrice(tt.price, tt.up)
+		if got != tt.want {
+			t.Errorf("RoundUSPrice(%v, up=%v) = %v, want %v", tt.price, tt.up, got, tt.want)
+		}
+	}
+}
+
+func TestRoundJPXPrice(t *testing.T) {
+	tests := []struct {
+		price float64
+		up    bool
+		want  float64
+	}{
+		{2874.6, false, 2874},
+		{2874.2, true, 2875},
+		{4312, false, 4310},
+		{4312, true, 4315},
+		{12345, false, 12340},
+		{41230, true, 41250},
+	}
+	for _, tt := range tests {
+		got := RoundJPXPrice(tt.price, tt.up)
+		if got != tt.want {
+			t.Errorf("RoundJPXPrice(%v, up=%v) = %v, want %v", tt.price, tt.up, got, tt.want)
+		}
+	}
+}
+
+func TestRoundHKEXPrice(t *testing.T) {
+	tests := []struct {
+		price float64
+		up    bool
+		want  float64
+	}{
+		{0.237, false, 0.237},
+		{0.333, false, 0.33},
+		{7.456, true, 7.46},
+		{15.33, false, 15.32},
+		{372.5, false, 372.4},
+		{372.5, true, 372.6},
+		{9.995, true, 10},
+	}
+	for _, tt := range tests {
+		got := RoundHKEXPrice(tt.price, tt.up)
+		if got != tt.want {
+			t.Errorf("RoundHKEXPrice(%v, up=%v) = %v, want %v", tt.price, tt.up, got, tt.want)
+		}
+	}
+}
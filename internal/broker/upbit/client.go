@@ -57,6 +57,17 @@ func (c *Client) Name() string {
 	return "upbit"
 }
 
+// Capabilities KRW amount market buys, fractional volumes, 24/7 trading
+func (c *Client) Capabilities() broker.Capabilities {
+	return broker.Capabilities{
+		MarketOrders:     true,
+		LimitOrders:      true,
+		AmountOrders:     true,
+		FractionalShares: true,
+		ExtendedHours:    true,
+	}
+}
+
 // IsReady checks if API keys are configured
 func (c *Client) IsReady() bool {
 	return c.accessKey != "" && c.secretKey != ""
@@ -138,7 +149,7 @@ func (c *Client) doGet(ctx context.Context, path string, params url.Values) ([]b
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return body, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return body, &APIError{Status: resp.StatusCode, Body: string(body)}
 	}
 
 	return body, nil
@@ -174,7 +185,7 @@ func (c *Client) doPost(ctx context.Context, path string, params url.Values) ([]
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return body, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return body, &APIError{Status: resp.StatusCode, Body: string(body)}
 	}
 
 	return body, nil
@@ -210,12 +221,32 @@ func (c *Client) doDelete(ctx context.Context, path string, params url.Values) (
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return body, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return body, &APIError{Status: resp.StatusCode, Body: string(body)}
 	}
 
 	return body, nil
 }
 
+// APIError Upbit HTTP 오류 응답 (4xx는 주문 거절, 429/too_many_requests는 호출 한도 초과)
+type APIError struct {
+	Status int
+	Body   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.Status, e.Body)
+}
+
+// Rejected 4xx 응답은 주문이 접수되지 않은 것 (5xx는 접수 여부를 모른다)
+func (e *APIError) Rejected() bool {
+	return e.Status >= 400 && e.Status < 500
+}
+
+// RateLimited 호출 한도 초과
+func (e *APIError) RateLimited() bool {
+	return e.Status == http.StatusTooManyRequests || strings.Contains(e.Body, "too_many_requests")
+}
+
 // ========== Upbit API response types ==========
 
 type accountEntry struct {
@@ -231,6 +262,8 @@ type tickerEntry struct {
 	TradePrice float64 `json:"trade_price"`
 	Change     string  `json:"change"`
 	ChangeRate float64 `json:"change_rate"`
+
+	TradeTimestamp int64 `json:"trade_timestamp"` // 최근 체결 시각 (ms)
 }
 
 type orderEntry struct {
@@ -371,24 +404,34 @@ func (c *Client) GetBalance(ctx context.Context) (*broker.AccountBalance, error)
 
 // GetQuote returns current trade price for a market (e.g. "KRW-BTC")
 func (c *Client) GetQuote(ctx context.Context, symbol string) (float64, error) {
+	price, _, err := c.GetQuoteTime(ctx, symbol)
+	return price, err
+}
+
+// GetQuoteTime returns the last trade price and its trade time
+func (c *Client) GetQuoteTime(ctx context.Context, symbol string) (float64, time.Time, error) {
 	params := url.Values{}
 	params.Set("markets", symbol)
 
 	body, err := c.doGet(ctx, "/ticker", params)
 	if err != nil {
-		return 0, fmt.Errorf("get ticker: %w", err)
+		return 0, time.Time{}, fmt.Errorf("get ticker: %w", err)
 	}
 
 	var tickers []tickerEntry
 	if err := json.Unmarshal(body, &tickers); err != nil {
-		return 0, fmt.Errorf("unmarshal ticker: %w", err)
+		return 0, time.Time{}, fmt.Errorf("unmarshal ticker: %w", err)
 	}
 
 	if len(tickers) == 0 {
-		return 0, fmt.Errorf("no ticker data for %s", symbol)
+		return 0, time.Time{}, fmt.Errorf("no ticker data for %s", symbol)
 	}
 
-	return tickers[0].TradePrice, nil
+	var at time.Time
+	if ts := tickers[0].TradeTimestamp; ts > 0 {
+		at = time.UnixMilli(ts)
+	}
+	return tickers[0].TradePrice, at, nil
 }
 
 // GetPositions returns non-KRW positions
@@ -6,16 +6,63 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"traveler/internal/fees"
+	"traveler/internal/i18n"
 )
 
 // Config represents the application configuration
 type Config struct {
 	API     APIConfig     `yaml:"api"`
 	KIS     KISConfig     `yaml:"kis"`
+	IBKR    IBKRConfig    `yaml:"ibkr"`
 	Trader  TraderConfig  `yaml:"trader"`
 	Daemon  DaemonConfig  `yaml:"daemon"`
 	Scanner ScannerConfig `yaml:"scanner"`
+	Backtest BacktestConfig `yaml:"backtest"`
 	Pattern PatternConfig `yaml:"pattern"`
+	Fees    FeesConfig    `yaml:"fees"`
+
+	Webhooks []WebhookConfig `yaml:"webhooks"`
+
+	Web WebConfig `yaml:"web"`
+
+	Language string `yaml:"language"` // 리포트/알림/웹 라벨 언어: en (기본), ko — TRAVELER_LANG 환경변수로 덮어쓰기
+
+	// CLI 스캔 리포트(report_*.txt/json) 저장 위치, 날짜별 하위 폴더 (비우면 <data-dir>/reports, --reports-dir로 덮어쓰기)
+	ReportsDir string `yaml:"reports_dir"`
+	// 스캔 리포트 보관 기간 (일, 0 = 기본 30일, 음수 = 삭제 안 함). 저장할 때 지난 날짜 폴더를 지운다
+	ReportsRetentionDays int `yaml:"reports_retention_days"`
+	// 시그널이 있으면 스캔 리포트 저장 (--save-report와 같음, --no-report로 끄기). 기본은 저장 안 함
+	SaveReports bool `yaml:"save_reports"`
+}
+
+// WebhookConfig 외부 웹훅 대상 (scan.completed, order.filled, stop.hit, daily.report 이벤트)
+type WebhookConfig struct {
+	URL    string   `yaml:"url"`
+	Secret string   `yaml:"secret"` // HMAC-SHA256 서명 키 (${ENV} 형식 환경변수 참조 가능)
+	Events []string `yaml:"events"` // 비우면 전체, "order.*" 접두 와일드카드 허용
+}
+
+// WebConfig 웹 서버 설정
+type WebConfig struct {
+	ReadOnly bool `yaml:"read_only"` // 조회/스캔만 허용 (매도·수동 포지션·시그널 실행·목록 수정 차단) — LAN 모니터링용
+}
+
+// FeesConfig 마켓별 수수료 프리셋 (kis-us, kis-kr, alpaca, ibkr, upbit, binance)
+// 비워두면 us=kis-us (ibkr.enabled면 ibkr), kr=kis-kr, crypto=upbit, binance=binance
+type FeesConfig struct {
+	US        string                 `yaml:"us"`
+	KR        string                 `yaml:"kr"`
+	Crypto    string                 `yaml:"crypto"`
+	Binance   string                 `yaml:"binance"`
+	Overrides map[string]FeeOverride `yaml:"overrides"` // 마켓별 수수료율 덮어쓰기 (us, kr, crypto, binance)
+}
+
+// FeeOverride 프리셋 값 덮어쓰기 (0이면 프리셋 값 유지)
+type FeeOverride struct {
+	Commission float64 `yaml:"commission"` // 편도 수수료율
+	SellTax    float64 `yaml:"sell_tax"`   // 매도 거래세율
 }
 
 // DaemonConfig holds daemon mode settings
@@ -44,10 +91,26 @@ type KISConfig struct {
 	AppSecret string `yaml:"app_secret"`
 	AccountNo string `yaml:"account_no"` // XXXXXXXX-XX 형식
 
+	// 원화로 결제되는 해외 매수(통합증거금)의 매수가능금액 할인율 (%, 장중 환율 변동 대비, 0 = 기본 2%, 음수 = 할인 없음)
+	FXHaircutPct float64 `yaml:"fx_haircut_pct"`
+
+	// 잔고/포지션에 함께 조회할 미국 외 해외 거래소 (TSE, HKS, SHS, SZS). 주문/시세는 심볼 접미사(.T, .HK, .SS, .SZ)로 자동 라우팅
+	Exchanges []string `yaml:"exchanges"`
+
 	// 국내 계좌 (별도 AppKey)
 	Domestic KISAccountConfig `yaml:"domestic"`
 }
 
+// IBKRConfig Interactive Brokers Client Portal Gateway (enabled면 US 자동매매를 KIS 대신 IBKR로)
+type IBKRConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	GatewayURL  string `yaml:"gateway_url"`  // 기본 https://localhost:5000/v1/api
+	AccountID   string `yaml:"account_id"`   // 비우면 게이트웨이 세션의 첫 계좌
+	InsecureTLS bool   `yaml:"insecure_tls"` // 원격 게이트웨이의 자체 서명 인증서 허용 (localhost는 항상 허용)
+
+	ConfirmWarnings bool `yaml:"confirm_warnings"` // 주문 확인 질문 자동 승인 (기본 false = 거절하고 주문 실패 처리)
+}
+
 // TraderConfig holds auto-trading settings
 type TraderConfig struct {
 	DryRun            bool    `yaml:"dry_run"`
@@ -55,8 +118,130 @@ type TraderConfig struct {
 	MaxPositionPct    float64 `yaml:"max_position_pct"`
 	RiskPerTrade      float64 `yaml:"risk_per_trade"`
 	MonitorInterval   int     `yaml:"monitor_interval_sec"`
-	CommissionRate    float64 `yaml:"commission_rate"`     // 수수료율 (편도, 예: 0.0025 = 0.25%)
+	CommissionRate    float64 `yaml:"commission_rate"`     // (구버전) US 편도 수수료율 — fees.overrides.us.commission 권장
 	MinExpectedReturn float64 `yaml:"min_expected_return"` // 최소 기대수익률 (예: 0.01 = 1%)
+	KellyRiskCap      bool    `yaml:"kelly_risk_cap"`      // 전략별 리스크를 저널 half-Kelly로 제한
+	LiquiditySlippage bool    `yaml:"liquidity_slippage"`  // 시그널마다 1분봉을 받아 종목별 슬리피지를 사이징에 반영 (호출량 증가)
+	AutoHedge         bool    `yaml:"auto_hedge"`          // 약세장 인버스 ETF 헤지 자동 주문
+	HedgeRatio        float64 `yaml:"hedge_ratio"`         // 베타 가중 익스포저 중 헤지 비율 (기본 0.5)
+	HedgeInstrument   string  `yaml:"hedge_instrument"`    // SH, SQQQ, 114800
+
+	Throttle OrderThrottleConfig `yaml:"throttle"`
+	Caps     OrderCapsConfig     `yaml:"caps"`
+
+	PriceCheck PriceCheckConfig `yaml:"price_check"`
+
+	// 포트폴리오 일간 변동성 예산 (자본 대비 %, 예: 1.5, 0 = 비활성). 보유+신규 ATR 기준으로 신규 수량 축소
+	VolTargetPct float64 `yaml:"vol_target_pct"`
+
+	// 주문 수량 상한 (20일 평균 거래량 대비 %, 0 = 기본 0.5, 음수 = 비활성). 소형주 주문이 하루 유동성을 잡아먹지 않도록
+	MaxADVPct float64 `yaml:"max_adv_pct"`
+
+	// 주문 직전 검사 (장 운영/블랙리스트/금액 한도/시세/중복 검사는 항상 적용)
+	PreTrade PreTradeConfig `yaml:"pretrade"`
+
+	// 데몬 스캔 전 일봉 최신성: 마지막 거래일 대비 허용 지연 (거래일, 0 = 지연 불허, 음수 = 검사 안 함). 넘기면 매매 중단 + 알림
+	MaxDataLagDays int `yaml:"max_data_lag_days"`
+
+	// 데몬 세션 리포트(runs/*.json) 보관 기간 (일, 0 = 기본 90일, 음수 = 삭제 안 함)
+	RunReportRetentionDays int `yaml:"run_report_retention_days"`
+
+	// 데몬 워치독: 모니터 사이클이 N분 넘게 멈추면 종료 코드 3으로 종료 (0 = 기본 10분, 음수 = 끔)
+	WatchdogMinutes int `yaml:"watchdog_minutes"`
+
+	// 계좌 이상 알림: 매수 가능 금액이 데몬 거래 없이 직전 모니터 사이클 대비 N% 이상 줄면 알림 (0 = 기본 10, 음수 = 끔)
+	// 데몬이 열지 않은 포지션 등장, 주문 없는 수량 변화/청산은 항상 알린다
+	AnomalyCashDropPct float64 `yaml:"anomaly_cash_drop_pct"`
+
+	// 전략별 장 마감 N분 전 전량 청산 (예: intraday_orb: 30, 0 = 정책 해제)
+	EODClose map[string]int `yaml:"eod_close"`
+
+	// 전략별 손절 모델 (예: pullback: "atr:2", breakout: "swing:10:0.5", mean-reversion: "ma:20:1", default = 전략 기본)
+	StopModels map[string]string `yaml:"stop_models"`
+
+	// 3분할 익절 (T1/T2 부분 매도 + 트레일링 러너)
+	Tranches TrancheConfig `yaml:"tranches"`
+
+	// 전략 후·사이징 전 시그널 필터 (CLI/웹/데몬 공통, 적은 순서대로 적용)
+	SignalFilters []SignalFilterConfig `yaml:"signal_filters"`
+
+	// 사이징 전 후보 선택 (CLI/웹/데몬 공통)
+	TopN TopNConfig `yaml:"top_n"`
+
+	// 섹터 모멘텀 로테이션 (sector-rotation 전략, 월간 리밸런싱)
+	SectorRotation SectorRotationConfig `yaml:"sector_rotation"`
+}
+
+// SectorRotationConfig 섹터 ETF 로테이션 (0 = 기본값: 상위 3개, 3개월/6개월 모멘텀 반반, 손절 8%)
+type SectorRotationConfig struct {
+	Enabled       bool     `yaml:"enabled"`        // US 데몬이 개별주 대신 섹터 ETF만 스캔
+	TopN          int      `yaml:"top_n"`          // 보유할 상위 섹터 수 (같은 비중)
+	ShortLookback int      `yaml:"short_lookback"` // 단기 모멘텀 거래일 (기본 63)
+	LongLookback  int      `yaml:"long_lookback"`  // 장기 모멘텀 거래일 (기본 126)
+	ShortWeight   float64  `yaml:"short_weight"`   // 단기 모멘텀 가중치 (0-1, 기본 0.5)
+	AllowNegative bool     `yaml:"allow_negative"` // 모멘텀이 음수여도 상위면 보유 (기본: 현금 대기)
+	StopPct       float64  `yaml:"stop_pct"`       // 안전망 손절 % (기본 8)
+	Sectors       []string `yaml:"sectors"`        // 후보 ETF (비우면 SPDR 11종)
+}
+
+// TopNConfig 사이징·매매 후보 개수와 순위 기준
+type TopNConfig struct {
+	Count  int      `yaml:"count"`   // 0 = 기본 10, 음수 = 제한 없음
+	RankBy []string `yaml:"rank_by"` // probability, rr, rs, strength, score (앞 기준이 같으면 다음 기준, 기본 probability → rr → rs)
+}
+
+// SignalFilterConfig 시그널 필터 하나
+// type: min_probability(%), min_rr, max_price(시그널 통화), rs_rank(백분위), exclude_sectors, earnings_window(일)
+type SignalFilterConfig struct {
+	Type    string   `yaml:"type"`
+	Value   float64  `yaml:"value"`
+	Sectors []string `yaml:"sectors"` // exclude_sectors: Yahoo 업종명 (Energy, Real Estate ...)
+	Markets []string `yaml:"markets"` // 적용 마켓 (us, kr, crypto, jp, hk, cn — 비우면 전체)
+}
+
+// TrancheConfig 3분할 익절 (0 = 기본값: 1/3 @ 1R, 1/3 @ 2R, 러너 ATR×2.5 트레일링)
+type TrancheConfig struct {
+	Enabled    bool    `yaml:"enabled"`
+	T1R        float64 `yaml:"t1_r"`        // 1차 목표 (R 배수)
+	T2R        float64 `yaml:"t2_r"`        // 2차 목표 (R 배수)
+	T3R        float64 `yaml:"t3_r"`        // 러너 목표 (R 배수, 0 = 트레일링만)
+	T1Fraction float64 `yaml:"t1_fraction"` // T1 매도 비율 (원래 수량 대비)
+	T2Fraction float64 `yaml:"t2_fraction"` // T2 매도 비율 (원래 수량 대비)
+	TrailATR   float64 `yaml:"trail_atr"`   // 러너 트레일링 ATR 배수
+}
+
+// PriceCheckConfig 주문 직전 시세 확인 (0 = 기본값, 음수 = 비활성)
+type PriceCheckConfig struct {
+	MaxDeviationPct float64 `yaml:"max_deviation_pct"` // 주문가와 현재가 최대 괴리 (%, 기본 5)
+	MaxQuoteAgeSec  int     `yaml:"max_quote_age_sec"` // 시세 최대 경과 (초, 기본 600, 체결 시각을 모르면 거부)
+}
+
+// PreTradeConfig 선택 주문 직전 검사 (0 = 비활성)
+type PreTradeConfig struct {
+	EarningsBlackoutDays int     `yaml:"earnings_blackout_days"` // 실적 발표 N일 이내면 신규 매수 거부
+	MaxHeatPct           float64 `yaml:"max_heat_pct"`           // 보유 플랜 + 신규 주문 오픈 리스크 상한 (자본 대비 %)
+}
+
+// OrderCapsConfig 주문/종목 최대 금액 (사이징과 무관한 최종 한도, 0 = 기본값, 음수 = 비활성)
+type OrderCapsConfig struct {
+	MaxOrderUSD  float64 `yaml:"max_order_usd"`  // US 주문 1건 최대 ($)
+	MaxSymbolUSD float64 `yaml:"max_symbol_usd"` // US 종목당 최대 보유 ($)
+	MaxOrderKRW  float64 `yaml:"max_order_krw"`  // KR/크립토 주문 1건 최대 (₩)
+	MaxSymbolKRW float64 `yaml:"max_symbol_krw"` // KR/크립토 종목당 최대 보유 (₩)
+	MaxOrderJPY  float64 `yaml:"max_order_jpy"`  // 도쿄 종목 (¥)
+	MaxSymbolJPY float64 `yaml:"max_symbol_jpy"`
+	MaxOrderHKD  float64 `yaml:"max_order_hkd"` // 홍콩 종목 (HK$)
+	MaxSymbolHKD float64 `yaml:"max_symbol_hkd"`
+	MaxOrderCNY  float64 `yaml:"max_order_cny"` // 상해/심천 종목 (¥)
+	MaxSymbolCNY float64 `yaml:"max_symbol_cny"`
+}
+
+// OrderThrottleConfig 자동매매 주문 속도 제한 (KIS 초당 거래건수 제한 대응)
+type OrderThrottleConfig struct {
+	MinIntervalMs int `yaml:"min_interval_ms"` // 주문 간 최소 간격
+	JitterMs      int `yaml:"jitter_ms"`       // 0~jitter 랜덤 추가 지연
+	MaxPerMinute  int `yaml:"max_per_minute"`  // 분당 최대 주문 수 (0 = 제한 없음)
+	MaxRetries    int `yaml:"max_retries"`     // rate 에러 재시도 횟수
 }
 
 // APIConfig holds API provider configurations
@@ -75,16 +260,36 @@ type ProviderConfig struct {
 type ScannerConfig struct {
 	Workers int           `yaml:"workers"`
 	Timeout time.Duration `yaml:"timeout"`
+
+	// 멀티 전략 스캔에서 전략별로 분석할 유니버스 (예: breakout: [russell, midcap]). 없는 전략은 스캔 대상 전체
+	StrategyUniverses map[string][]string `yaml:"strategy_universes"`
+
+	// 멀티 전략 스캔에서 돌릴 전략 (비우면 전부). `traveler tune`이 추천 목록을 출력한다
+	EnabledStrategies []string `yaml:"enabled_strategies"`
+
+	// 영구 일봉 캐시(<data-dir>/candles/daily)가 이 시간(분) 안에 갱신됐으면 API 대신 사용 (0 = 끔, 기본).
+	// 방금 돌린 백테스트나 스캔이 받은 일봉을 재사용해 같은 유니버스 재스캔이 호출 한도를 다시 쓰지 않는다.
+	// 캐시에는 오늘 형성 중인 봉이 없으므로 장 마감 후 재스캔용이다
+	CandleCacheMinutes int `yaml:"candle_cache_minutes"`
+}
+
+// BacktestConfig 단일/포트폴리오 백테스트 기본 파라미터 (0 = 내장 기본값, --bt-* 플래그가 우선)
+type BacktestConfig struct {
+	RiskPct      float64 `yaml:"risk_pct"`      // 거래당 리스크 (자산 대비 %, 기본 1)
+	StopPct      float64 `yaml:"stop_pct"`      // 고정 손절 (진입가 대비 %, 기본 2). 손절 모델이 계산 못 할 때도 이 값
+	TargetR      float64 `yaml:"target_r"`      // 목표가 (초기 리스크의 R 배수, 기본 2)
+	MaxHoldDays  int     `yaml:"max_hold_days"` // 최대 보유 (거래일, 기본 5)
+	MaxPositions int     `yaml:"max_positions"` // 포트폴리오 동시 보유 한도 (기본 5)
 }
 
-// PatternConfig holds pattern detection settings
+// PatternConfig holds pattern detection settings (json 태그는 --pattern-config 파일용)
 type PatternConfig struct {
-	ConsecutiveDays       int     `yaml:"consecutive_days"`
-	MorningDropThreshold  float64 `yaml:"morning_drop_threshold"`  // percent (negative value)
-	CloseRiseThreshold    float64 `yaml:"close_rise_threshold"`    // percent (positive value)
-	ReboundThreshold      float64 `yaml:"rebound_threshold"`       // percent from morning low
-	MorningWindowMinutes  int     `yaml:"morning_window"`          // minutes after open
-	ClosingWindowMinutes  int     `yaml:"closing_window"`          // minutes before close
+	ConsecutiveDays      int     `yaml:"consecutive_days" json:"consecutive_days"`
+	MorningDropThreshold float64 `yaml:"morning_drop_threshold" json:"morning_drop_threshold"` // percent (negative value)
+	CloseRiseThreshold   float64 `yaml:"close_rise_threshold" json:"close_rise_threshold"`     // percent (positive value)
+	ReboundThreshold     float64 `yaml:"rebound_threshold" json:"rebound_threshold"`           // percent from morning low
+	MorningWindowMinutes int     `yaml:"morning_window" json:"morning_window"`                 // minutes after open
+	ClosingWindowMinutes int     `yaml:"closing_window" json:"closing_window"`                 // minutes before close
 }
 
 // DefaultConfig returns the default configuration
@@ -111,8 +316,13 @@ func DefaultConfig() *Config {
 			MaxPositionPct:    0.20,
 			RiskPerTrade:      0.01,
 			MonitorInterval:   30,
-			CommissionRate:    0.0025, // 0.25% (KIS 해외주식 기본)
 			MinExpectedReturn: 0.01,   // 1% (수수료 0.5% + 마진 0.5%)
+			Throttle: OrderThrottleConfig{
+				MinIntervalMs: 1000,
+				JitterMs:      500,
+				MaxPerMinute:  20,
+				MaxRetries:    3,
+			},
 		},
 		Daemon: DaemonConfig{
 			DailyTargetPct:       1.0,
@@ -183,9 +393,62 @@ func Load(path string) (*Config, error) {
 		cfg.KIS.Domestic.AccountNo = key
 	}
 
+	// IBKR 환경변수
+	if v := os.Getenv("IBKR_GATEWAY_URL"); v != "" {
+		cfg.IBKR.GatewayURL = v
+	}
+	if v := os.Getenv("IBKR_ACCOUNT_ID"); v != "" {
+		cfg.IBKR.AccountID = v
+	}
+
+	for i := range cfg.Webhooks {
+		cfg.Webhooks[i].Secret = os.ExpandEnv(cfg.Webhooks[i].Secret)
+	}
+
+	if err := cfg.ApplyFees(); err != nil {
+		return nil, err
+	}
+
+	if v := os.Getenv("TRAVELER_LANG"); v != "" {
+		cfg.Language = v
+	}
+	if err := i18n.Use(cfg.Language); err != nil {
+		return nil, fmt.Errorf("language: %w", err)
+	}
+
 	return cfg, nil
 }
 
+// ApplyFees fees 설정을 마켓별 활성 수수료 프리셋에 반영
+func (c *Config) ApplyFees() error {
+	if c.IBKR.Enabled && c.Fees.US == "" {
+		if err := fees.Use("us", "ibkr"); err != nil {
+			return fmt.Errorf("fees.us: %w", err)
+		}
+	}
+	for market, preset := range map[string]string{
+		"us": c.Fees.US, "kr": c.Fees.KR, "crypto": c.Fees.Crypto, "binance": c.Fees.Binance,
+	} {
+		if preset == "" {
+			continue
+		}
+		if err := fees.Use(market, preset); err != nil {
+			return fmt.Errorf("fees.%s: %w", market, err)
+		}
+	}
+	if c.Trader.CommissionRate > 0 {
+		if err := fees.Override("us", c.Trader.CommissionRate, 0); err != nil {
+			return fmt.Errorf("trader.commission_rate: %w", err)
+		}
+	}
+	for market, o := range c.Fees.Overrides {
+		if err := fees.Override(market, o.Commission, o.SellTax); err != nil {
+			return fmt.Errorf("fees.overrides: %w", err)
+		}
+	}
+	return nil
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.API.Finnhub.Key == "" && c.API.AlphaVantage.Key == "" {
@@ -0,0 +1,25 @@
+package daemon
+
+import (
+	"context"
+	"log"
+
+	"traveler/internal/alert"
+	"traveler/internal/notify"
+)
+
+// initAlerts 사용자 알림 규칙 엔진 (alert_rules.json에 이 마켓 규칙이 없으면 평가 생략)
+func (d *Daemon) initAlerts(dataDir string) {
+	d.alerts = alert.NewEngine(dataDir, d.config.Market, d.broker, d.provider)
+	d.alerts.OnAlert(func(a alert.Alert) {
+		d.notifyTelegram(context.Background(), "🔔 "+a.Message)
+		d.webhooks.Emit(notify.EventAlert, a)
+	})
+}
+
+// evaluateAlerts 모니터 사이클마다 호출 (엔진 내부에서 1분 간격으로 제한)
+func (d *Daemon) evaluateAlerts() {
+	if _, err := d.alerts.Evaluate(d.ctx); err != nil {
+		log.Printf("[ALERT] Evaluate failed: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/i18n"
+	"traveler/internal/money"
+	"traveler/internal/trader"
+)
+
+// DefaultAnomalyCashDrop 매수 가능 금액 급감 알림 기준 (직전 관측 대비, trader.anomaly_cash_drop_pct = 0)
+const DefaultAnomalyCashDrop = 0.10
+
+// accountSnapshot 직전 모니터 사이클의 브로커 계좌 상태
+type accountSnapshot struct {
+	at        time.Time
+	positions map[string]float64 // 종목 → 수량
+	cash      float64            // 매수 가능 금액 (hasCash일 때만)
+	hasCash   bool
+}
+
+// checkAccountAnomalies 모니터 사이클 사이에 데몬이 내지 않은 계좌 변화 감지 → 알림
+// 직전 관측 이후 매매 기록으로 설명되는 종목은 건너뛴다 (부분 체결과 구분할 수 없음).
+// 최근 거래가 잔고에 아직 반영 안 됐을 수 있으므로 settle window 동안은 판정을 미루고 기준도 유지한다.
+func (d *Daemon) checkAccountAnomalies(positions []broker.Position, cash float64, hasCash bool) {
+	cur := &accountSnapshot{at: time.Now(), positions: make(map[string]float64), cash: cash, hasCash: hasCash}
+	for _, p := range positions {
+		if p.Quantity > 0 {
+			cur.positions[p.Symbol] += p.Quantity
+		}
+	}
+	prev := d.account
+	if prev == nil {
+		d.account = cur
+		return
+	}
+
+	traded := make(map[string]bool)
+	var expectedCash float64
+	for _, tr := range d.accountTrades() {
+		if !tr.Timestamp.After(prev.at) {
+			continue
+		}
+		if time.Since(tr.Timestamp) < cashFlowSettleWindow {
+			return
+		}
+		traded[tr.Symbol] = true
+		if strings.EqualFold(tr.Side, "sell") {
+			expectedCash += tr.Amount - tr.Commission
+		} else {
+			expectedCash -= tr.Amount + tr.Commission
+		}
+	}
+	d.account = cur
+
+	known := make(map[string]bool)
+	if d.autoTrader != nil {
+		for _, pos := range d.autoTrader.GetMonitor().GetActivePositions() {
+			known[pos.Symbol] = true
+		}
+	}
+
+	var lines []string
+	for _, sym := range sortedKeys(cur.positions) {
+		qty := cur.positions[sym]
+		before, held := prev.positions[sym]
+		switch {
+		case traded[sym]:
+		case !held && !known[sym]:
+			lines = append(lines, i18n.T("alert.anomaly_new", sym, qty))
+		case held && quantityChanged(before, qty):
+			lines = append(lines, i18n.T("alert.anomaly_qty", sym, before, qty))
+		}
+	}
+	for _, sym := range sortedKeys(prev.positions) {
+		if _, still := cur.positions[sym]; !still && !traded[sym] {
+			lines = append(lines, i18n.T("alert.anomaly_gone", sym, prev.positions[sym]))
+		}
+	}
+
+	if drop := d.cashDropThreshold(); drop > 0 && prev.hasCash && cur.hasCash && prev.cash > 0 {
+		unexplained := prev.cash + expectedCash - cur.cash
+		if unexplained >= prev.cash*drop {
+			c := money.ForMarket(d.config.Market)
+			lines = append(lines, i18n.T("alert.anomaly_cash", money.Format(unexplained, c),
+				unexplained/prev.cash*100, money.Format(prev.cash, c), money.Format(cur.cash, c)))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	for _, l := range lines {
+		log.Printf("[ANOMALY] %s", strings.TrimPrefix(l, "• "))
+		d.run.addError("account_anomaly", "", fmt.Errorf("%s", strings.TrimPrefix(l, "• ")))
+	}
+	d.notifyTelegram(d.ctx, i18n.T("alert.anomaly", strings.ToUpper(d.config.Market))+"\n"+strings.Join(lines, "\n"))
+}
+
+// accountTrades 이 마켓 매매 기록. trade_history.json은 웹 매도·장중 매수까지 담으므로 디스크에서 다시 읽고,
+// 기록 저장소가 없으면 일일 트래커 거래로 대신한다.
+func (d *Daemon) accountTrades() []trader.TradeRecord {
+	if d.history != nil {
+		d.history.Reload()
+		return d.history.GetAll(d.config.Market)
+	}
+	trades := d.tracker.GetState().Trades
+	out := make([]trader.TradeRecord, 0, len(trades))
+	for _, tr := range trades {
+		out = append(out, trader.TradeRecord{Timestamp: tr.Timestamp, Symbol: tr.Symbol, Side: tr.Side,
+			Quantity: tr.Quantity, Price: tr.Price, Amount: tr.Amount, Commission: tr.Commission})
+	}
+	return out
+}
+
+// cashDropThreshold 매수 가능 금액 급감 기준 (비율, 0 이하 = 검사 안 함)
+func (d *Daemon) cashDropThreshold() float64 {
+	switch {
+	case d.config.AnomalyCashDrop < 0:
+		return 0
+	case d.config.AnomalyCashDrop == 0:
+		return DefaultAnomalyCashDrop
+	}
+	return d.config.AnomalyCashDrop
+}
+
+// sortedKeys 알림 순서 고정용
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quantityChanged 수량 비교 (크립토 소수점 오차 허용)
+func quantityChanged(a, b float64) bool {
+	return math.Abs(a-b) > 1e-9+1e-6*math.Max(a, b)
+}
@@ -0,0 +1,167 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/dailystate"
+	"traveler/internal/fsutil"
+	"traveler/internal/trader"
+)
+
+// dailystate.DailyState.BaselineSource 값
+const (
+	baselineSessionStart = "session_start" // 그날 첫 세션 시작 시 잔고
+	baselineFromFile     = "baseline_file" // 상태 파일이 없거나 기준 잔고 없이 저장돼 baseline 파일에서 복원
+)
+
+// SessionBaseline 거래일 기준 잔고 (baseline_<market>.json)
+// 일일 상태 파일과 따로 두어, 상태 파일이 유실되거나 잔고 조회 전에 저장된 채로 재시작해도
+// 장중 현재 잔고를 시작 잔고로 잡아 그날 손익이 0으로 리셋되는 일을 막는다.
+type SessionBaseline struct {
+	Market string    `json:"market"`
+	Date   string    `json:"date"` // 마켓 기준 날짜 — 다른 날짜면 무시
+	Equity float64   `json:"equity"`
+	At     time.Time `json:"at"`
+}
+
+// baselineFilePath baseline 파일 경로
+func (t *DailyTracker) baselineFilePath() string {
+	if t.market != "" {
+		return filepath.Join(t.dataDir, fmt.Sprintf("baseline_%s.json", t.market))
+	}
+	return filepath.Join(t.dataDir, "baseline.json")
+}
+
+func (t *DailyTracker) saveBaseline(b SessionBaseline) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(t.baselineFilePath(), data, 0644)
+}
+
+func (t *DailyTracker) loadBaseline() (*SessionBaseline, error) {
+	data, err := os.ReadFile(t.baselineFilePath())
+	if err != nil {
+		return nil, err
+	}
+	var b SessionBaseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// BackfillTrades 기준 잔고를 baseline 파일에서 복원했을 때, 재시작 전 오늘 거래를 trade_history에서 채운다
+// (기준 시각 이후 이 마켓 기록만). 복원하지 않았거나 이미 채웠으면 아무것도 하지 않는다. 추가한 건수 반환.
+func (t *DailyTracker) BackfillTrades(records []trader.TradeRecord) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.backfill {
+		return 0, nil
+	}
+	t.backfill = false
+
+	added := 0
+	for _, r := range records {
+		if r.Timestamp.Before(t.state.BaselineAt) {
+			continue
+		}
+		if r.Market != "" && t.market != "" && r.Market != t.market {
+			continue
+		}
+		t.addTrade(tradeLogFromRecord(r))
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	sort.SliceStable(t.state.Trades, func(i, j int) bool {
+		return t.state.Trades[i].Timestamp.Before(t.state.Trades[j].Timestamp)
+	})
+	return added, t.saveState()
+}
+
+// tradeMatchWindow 같은 주문의 트래커 기록과 trade_history 기록 시각 차 허용 범위
+const tradeMatchWindow = 2 * time.Minute
+
+// SyncTrades 오늘 세션의 trade_history 기록 중 트래커에 없는 거래를 추가한다 (웹 수동 매도 등 다른 경로의 주문).
+// 입출금 판정 전에 호출해 거래로 생긴 현금 변화가 입출금으로 잡히지 않게 한다.
+// 같은 종목·방향·수량이고 시각이 tradeMatchWindow 안이면 이미 기록된 거래로 본다. 추가한 건수 반환.
+func (t *DailyTracker) SyncTrades(records []trader.TradeRecord) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	since := t.state.BaselineAt
+	if since.IsZero() {
+		since = t.state.StartTime
+	}
+	matched := make([]bool, len(t.state.Trades))
+	added := 0
+	for _, r := range records {
+		if r.Timestamp.Before(since) {
+			continue
+		}
+		if r.Market != "" && t.market != "" && r.Market != t.market {
+			continue
+		}
+		if t.matchTrade(r, matched) {
+			continue
+		}
+		tl := tradeLogFromRecord(r)
+		if tl.Amount == 0 {
+			tl.Amount = tl.Quantity * tl.Price
+		}
+		if tl.Commission == 0 {
+			tl.Commission = t.fee(tl.Symbol).Cost(tl.Side, tl.Amount)
+		}
+		t.addTrade(tl)
+		matched = append(matched, true)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, t.saveState()
+}
+
+// matchTrade r과 같은 주문으로 보이는 아직 짝이 없는 트래커 거래를 찾아 표시
+func (t *DailyTracker) matchTrade(r trader.TradeRecord, matched []bool) bool {
+	for i, tr := range t.state.Trades {
+		if matched[i] || tr.Symbol != r.Symbol || !strings.EqualFold(tr.Side, r.Side) || tr.Quantity != r.Quantity {
+			continue
+		}
+		if d := tr.Timestamp.Sub(r.Timestamp); d > tradeMatchWindow || d < -tradeMatchWindow {
+			continue
+		}
+		matched[i] = true
+		return true
+	}
+	return false
+}
+
+// tradeLogFromRecord 매매 기록 → 일일 트래커 거래 로그
+func tradeLogFromRecord(r trader.TradeRecord) dailystate.TradeLog {
+	return dailystate.TradeLog{
+		Timestamp:  r.Timestamp,
+		Symbol:     r.Symbol,
+		Side:       r.Side,
+		Quantity:   r.Quantity,
+		Price:      r.Price,
+		Amount:     r.Amount,
+		Commission: r.Commission,
+		Reason:     r.Reason,
+		PnL:        r.PnL,
+		TradeID:    r.TradeID,
+		EntryPrice: r.EntryPrice,
+		EntryTime:  r.EntryTime,
+		EntryStop:  r.EntryStop,
+	}
+}
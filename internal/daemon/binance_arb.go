@@ -13,6 +13,7 @@ import (
 
 	"traveler/internal/broker"
 	binanceBroker "traveler/internal/broker/binance"
+	"traveler/internal/fsutil"
 	"traveler/internal/strategy"
 )
 
@@ -465,7 +466,7 @@ func (d *BinanceArbDaemon) saveState() {
 		return
 	}
 
-	if err := os.WriteFile(d.statePath(), data, 0644); err != nil {
+	if err := fsutil.WriteFile(d.statePath(), data, 0644); err != nil {
 		log.Printf("[ARB] Failed to save state: %v", err)
 	}
 }
@@ -503,7 +504,7 @@ func (d *BinanceArbDaemon) saveStatusJSON() {
 		return
 	}
 
-	if err := os.WriteFile(d.statusPath(), data, 0644); err != nil {
+	if err := fsutil.WriteFile(d.statusPath(), data, 0644); err != nil {
 		log.Printf("[ARB] Failed to save status: %v", err)
 	}
 }
@@ -12,6 +12,7 @@ import (
 
 	"traveler/internal/broker"
 	binanceBroker "traveler/internal/broker/binance"
+	"traveler/internal/fsutil"
 	"traveler/internal/notify"
 	"traveler/internal/strategy"
 )
@@ -739,7 +740,7 @@ func (d *BinanceScalpDaemon) saveState() {
 		return
 	}
 
-	if err := os.WriteFile(d.statePath(), data, 0644); err != nil {
+	if err := fsutil.WriteFile(d.statePath(), data, 0644); err != nil {
 		log.Printf("[BSCALP] Failed to save state: %v", err)
 	}
 }
@@ -812,7 +813,7 @@ func (d *BinanceScalpDaemon) saveStatusJSON() {
 		return
 	}
 
-	if err := os.WriteFile(d.statusPath(), data, 0644); err != nil {
+	if err := fsutil.WriteFile(d.statusPath(), data, 0644); err != nil {
 		log.Printf("[BSCALP] Failed to save status: %v", err)
 	}
 }
@@ -13,6 +13,7 @@ import (
 
 	"traveler/internal/broker"
 	binanceBroker "traveler/internal/broker/binance"
+	"traveler/internal/fsutil"
 	"traveler/internal/strategy"
 )
 
@@ -583,7 +584,7 @@ func (d *BTCFuturesDaemon) saveState() {
 	if err != nil {
 		return
 	}
-	os.WriteFile(d.statePath(), data, 0644)
+	fsutil.WriteFile(d.statePath(), data, 0644)
 }
 
 func (d *BTCFuturesDaemon) saveStatusJSON() {
@@ -627,5 +628,5 @@ func (d *BTCFuturesDaemon) saveStatusJSON() {
 	}
 
 	data, _ := json.MarshalIndent(status, "", "  ")
-	os.WriteFile(d.statusPath(), data, 0644)
+	fsutil.WriteFile(d.statusPath(), data, 0644)
 }
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+
+	"traveler/internal/fsutil"
 )
 
 // CapitalState 자동매매 전용 자본 상태 (persistent)
@@ -114,7 +116,7 @@ func (ct *CapitalTracker) save() {
 		log.Printf("[CAPITAL] Failed to marshal: %v", err)
 		return
 	}
-	if err := os.WriteFile(ct.filepath, data, 0644); err != nil {
+	if err := fsutil.WriteFile(ct.filepath, data, 0644); err != nil {
 		log.Printf("[CAPITAL] Failed to save: %v", err)
 	}
 }
@@ -8,10 +8,18 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"traveler/internal/ai"
+	"traveler/internal/alert"
 	"traveler/internal/broker"
+	"traveler/internal/dailystate"
+	"traveler/internal/fsutil"
+	"traveler/internal/i18n"
+	"traveler/internal/money"
+	"traveler/internal/notify"
 	"traveler/internal/provider"
 	"traveler/internal/strategy"
 	"traveler/internal/symbols"
@@ -40,6 +48,61 @@ type Config struct {
 	// 자본 설정
 	TradingCapital   float64 // 자동매매 전용 자본 (0이면 전체 잔고 사용)
 
+	// 리스크 설정
+	KellyRiskCap      bool // 전략별 리스크를 min(설정값, 저널 half-Kelly)로 제한 (주 1회 재계산)
+	LiquiditySlippage bool // 시그널 종목의 1분봉으로 슬리피지를 추정해 사이징에 반영 (시그널마다 분봉 조회)
+
+	// 헤지 설정 (시장 필터 약세 시 인버스 ETF)
+	AutoHedge        bool    // 헤지 자동 주문 (false면 제안만 로그)
+	HedgeRatio       float64 // 베타 가중 익스포저 중 헤지 비율 (0이면 기본 0.5)
+	HedgeInstrument  string  // SH, SQQQ, 114800 (비어있으면 마켓 기본값)
+
+	// 주문 속도 제한 (zero 값이면 trader 기본값)
+	Throttle trader.ThrottleConfig
+
+	// 주문/종목 최대 금액 (0인 항목은 trader 기본값)
+	Caps trader.ValueCaps
+
+	// 주문 직전 시세 확인 (0인 항목은 trader 기본값)
+	PriceCheck trader.PriceCheckConfig
+
+	// 전략별 마감 전 청산 (nil이면 trader 기본값)
+	EODClose trader.EODPolicy
+
+	// 3분할 익절 (Enabled=false면 전략 기본 목표가)
+	Tranches strategy.TrancheExit
+
+	// 스캔 시그널 필터 체인 (nil이면 블랙/화이트리스트만)
+	SignalFilters trader.SignalFilterChain
+
+	// 포트폴리오 일간 변동성 예산 (자본 대비, 0 = 비활성)
+	VolTarget float64
+
+	// 주문 수량 상한 (20일 평균 거래량 대비 비율, 0 = 기본 0.5%, 음수 = 비활성)
+	MaxADV float64
+
+	// 주문 직전 검사: 실적 발표 N일 전 매수 금지 (0 = 비활성), 오픈 리스크 총량 (자본 대비, 0 = 비활성)
+	EarningsBlackoutDays int
+	MaxHeat              float64
+
+	// US 섹터 로테이션 모드: 개별주 대신 섹터 ETF 상위 N개를 월간 리밸런싱 (trader.sector_rotation.enabled)
+	SectorRotation bool
+
+	// 스캔 전 일봉 최신성: 마지막 거래일 대비 허용 지연 (거래일, 0 = 지연 불허, 음수 = 검사 안 함)
+	MaxDataLagDays int
+
+	// 세션 리포트(runs/*.json) 보관 기간 (일, 0 = 기본 90일, 음수 = 삭제 안 함)
+	RunReportRetentionDays int
+
+	// 워치독: 모니터 사이클이 이 시간 넘게 멈추면 종료 (0 = 기본 10분, 음수 = 끔)
+	WatchdogTimeout time.Duration
+
+	// 계좌 이상 알림: 매수 가능 금액이 거래 없이 직전 사이클 대비 이 비율 이상 줄면 알림 (0 = 기본 10%, 음수 = 끔)
+	AnomalyCashDrop float64
+
+	// 리허설: 모의 브로커로 스캔→사이징→주문→모니터링 전 과정을 돌리고 리포트/알림에 표시 (--rehearsal)
+	Rehearsal bool
+
 	// 종료 설정
 	SleepOnExit      bool // 종료시 PC 절전
 	DataDir          string
@@ -60,12 +123,15 @@ func DefaultConfig() Config {
 // Daemon 자동 매매 데몬
 type Daemon struct {
 	config     Config
+	dataDir    string // Config.DataDir, 비어 있으면 ~/.traveler (NewDaemon에서 한 번 결정)
 	broker     broker.Broker
 	provider   provider.Provider
 	tracker    *DailyTracker
 	autoTrader *trader.AutoTrader
 	history    *trader.TradeHistory
 	capital    *CapitalTracker // 자동매매 전용 자본 추적
+	dividends  *provider.DividendCalendar // 배당락일 (주식만)
+	kelly      *trader.KellyRiskStore     // 전략별 Kelly 리스크 캡 (KellyRiskCap일 때만)
 
 	ctx            context.Context
 	cancel         context.CancelFunc
@@ -90,14 +156,49 @@ type Daemon struct {
 
 	// Monitor-only mode: KR low-balance → monitor existing positions, no new scans
 	monitorOnly bool
+
+	webhooks *notify.WebhookNotifier // 외부 웹훅 (nil이면 비활성)
+	fillMu   sync.Mutex
+	unfilled []trader.TradeRecord // 체결 확인 전 매매 기록 (order.filled 대기)
+	alerts   *alert.Engine           // 사용자 알림 규칙 (주문 없음)
+
+	// 시작 시 대조 미확인 → 안전 모드 (신규 매수 보류, 손절/익절 감시는 계속)
+	reconcile *trader.ReconcileStore
+	safeMode  atomic.Bool
+
+	// 스캔 전 일봉 최신성 검사 실패 사유 (비어 있지 않으면 신규 매수 중단)
+	staleData atomic.Value
+
+	// 블랙/화이트리스트 로드 실패 사유 (비어 있지 않으면 신규 매수 중단)
+	listsDown atomic.Value
+
+	rehearsalDay int // 리허설 N일째 (Config.Rehearsal일 때만)
+
+	run runRecorder // 세션 리포트용 스캔 요약/오류/소요 시간
+
+	// 생존 신호: 마지막 모니터 사이클 완료 시각 (UnixNano), 사이클 수, 워치독 발동 여부
+	lastCycle atomic.Int64
+	cycles    atomic.Int64
+	stalled   atomic.Bool
+
+	account *accountSnapshot // 직전 모니터 사이클의 브로커 포지션/현금 (계좌 이상 감지)
 }
 
 // NewDaemon 생성자
 func NewDaemon(cfg Config, b broker.Broker, p provider.Provider) *Daemon {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	dataDir := cfg.DataDir
+	if dataDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataDir = filepath.Join(home, ".traveler")
+		} else {
+			dataDir = "."
+		}
+	}
+
 	// Sizer config는 나중에 잔고 확인 후 설정
-	tracker := NewDailyTracker(cfg.Daily, cfg.DataDir)
+	tracker := NewDailyTracker(cfg.Daily, dataDir)
 	tracker.SetMarket(cfg.Market) // 파일명 분리: daily_us_*.json vs daily_kr_*.json
 
 	// 마켓 타임존 설정 (US=ET, KR/Crypto=KST)
@@ -113,6 +214,7 @@ func NewDaemon(cfg Config, b broker.Broker, p provider.Provider) *Daemon {
 
 	return &Daemon{
 		config:   cfg,
+		dataDir:  dataDir,
 		broker:   b,
 		provider: p,
 		tracker:  tracker,
@@ -126,6 +228,11 @@ func (d *Daemon) SetAIClient(c *ai.GeminiClient) {
 	d.aiClient = c
 }
 
+// sectorRotation US 데몬이 섹터 로테이션 모드로 스캔하는지
+func (d *Daemon) sectorRotation() bool {
+	return d.config.SectorRotation && !d.isKR() && !d.isCrypto()
+}
+
 // isKR 한국 시장 모드 여부
 func (d *Daemon) isKR() bool {
 	return d.config.Market == "kr"
@@ -136,15 +243,23 @@ func (d *Daemon) isCrypto() bool {
 	return d.config.Market == "crypto"
 }
 
+// currency 마켓 표시 통화 (로그 금액 포맷용)
+func (d *Daemon) currency() money.Currency {
+	return money.ForMarket(d.config.Market)
+}
+
+// sizerConfig 사이징용 설정 복사본 (Kelly 캡 사용 시 주기가 지났으면 재계산된 캡 반영)
+func (d *Daemon) sizerConfig() trader.SizerConfig {
+	cfg := d.config.Sizer
+	if d.kelly != nil {
+		cfg.StrategyRisk = d.kelly.RiskCaps(d.history, d.config.Market)
+	}
+	return cfg
+}
+
 // getMarketStatus 현재 시장에 맞는 마켓 상태 조회
 func (d *Daemon) getMarketStatus() MarketStatus {
-	if d.isCrypto() {
-		return GetCryptoMarketStatus()
-	}
-	if d.isKR() {
-		return GetKRMarketStatus(KRMarketSchedule())
-	}
-	return GetMarketStatus(DefaultMarketSchedule())
+	return MarketStatusFor(d.config.Market)
 }
 
 // Run 데몬 실행
@@ -171,6 +286,7 @@ func (d *Daemon) Run() error {
 	defer func() {
 		d.isRunning = false
 	}()
+	d.beat("startup", 30*time.Minute)
 
 	// 1. 마켓 상태 확인
 	status := d.getMarketStatus()
@@ -179,6 +295,9 @@ func (d *Daemon) Run() error {
 		tzLabel = "KST"
 	}
 	log.Printf("[DAEMON] Market status: %s (%s: %s)", status.Reason, tzLabel, status.CurrentTimeET.Format("15:04"))
+	if !d.isCrypto() {
+		log.Printf("[DAEMON] Next open: %s | next close: %s", FormatETKST(status.NextOpen), FormatETKST(status.NextClose))
+	}
 
 	isSim := strings.HasPrefix(d.broker.Name(), "sim-")
 
@@ -209,27 +328,18 @@ func (d *Daemon) Run() error {
 	balance, err := d.broker.GetBalance(d.ctx)
 	if err != nil {
 		log.Printf("[DAEMON] Failed to get balance: %v", err)
+		d.run.addError("balance", "", err)
 		return d.shutdown("balance_error")
 	}
-	if d.isKR() || d.isCrypto() {
-		log.Printf("[DAEMON] Account balance: ₩%.0f", balance.TotalEquity)
-	} else {
-		log.Printf("[DAEMON] Account balance: $%.2f", balance.TotalEquity)
-	}
+	log.Printf("[DAEMON] Account balance: %s", money.Format(balance.TotalEquity, d.currency()))
 
 	// KR 소액 계좌: KR DCA 데몬이 KODEX 200을 처리
 	// → 기존 포지션이 없으면 즉시 종료, 있으면 모니터링만 하고 신규 매수 안 함
 	if d.isKR() && balance.TotalEquity < 500000 {
-		log.Printf("[DAEMON] KR balance ₩%.0f < ₩500,000 — KR DCA handles KODEX 200", balance.TotalEquity)
+		log.Printf("[DAEMON] KR balance %s < ₩500,000 — KR DCA handles KODEX 200", money.Format(balance.TotalEquity, money.KRW))
 		// plans.json에 기존 KR 포지션이 있는지 확인
-		checkDir := d.config.DataDir
-		if checkDir == "" {
-			if home, err := os.UserHomeDir(); err == nil {
-				checkDir = filepath.Join(home, ".traveler")
-			}
-		}
 		hasKRPositions := false
-		if ps, perr := trader.NewPlanStore(checkDir); perr == nil {
+		if ps, perr := trader.NewPlanStore(d.dataDir); perr == nil {
 			for _, plan := range ps.GetAll() {
 				// KR 심볼: 6자리 숫자 (005930, 069500 등)
 				if len(plan.Symbol) == 6 && plan.Symbol[0] >= '0' && plan.Symbol[0] <= '9' {
@@ -251,23 +361,21 @@ func (d *Daemon) Run() error {
 	tradingCapital := balance.TotalEquity
 	if d.config.TradingCapital > 0 {
 		// CapitalTracker로 자본 추적 (저장된 상태가 있으면 복원)
-		dataDir := d.config.DataDir
-		if dataDir == "" {
-			if home, err := os.UserHomeDir(); err == nil {
-				dataDir = filepath.Join(home, ".traveler")
-			}
-		}
-		d.capital = NewCapitalTracker(dataDir, d.config.TradingCapital)
+		d.capital = NewCapitalTracker(d.dataDir, d.config.TradingCapital)
 		capState := d.capital.GetState()
 		tradingCapital = capState.CurrentCapital + capState.TotalInvested
-		log.Printf("[DAEMON] Trading capital: ₩%.0f (cash=₩%.0f, invested=₩%.0f, account=₩%.0f)",
-			tradingCapital, capState.CurrentCapital, capState.TotalInvested, balance.TotalEquity)
+		cur := d.currency()
+		log.Printf("[DAEMON] Trading capital: %s (cash=%s, invested=%s, account=%s)",
+			money.Format(tradingCapital, cur), money.Format(capState.CurrentCapital, cur),
+			money.Format(capState.TotalInvested, cur), money.Format(balance.TotalEquity, cur))
 	}
 
 	// 3. 일일 트래커 시작
 	if err := d.tracker.Start(tradingCapital); err != nil {
 		log.Printf("[DAEMON] Failed to start tracker: %v", err)
+		d.run.addError("tracker", "", err)
 	}
+	d.startRehearsal()
 
 	// 4. Sizer 설정 (자동매매 자본 기반)
 	if d.isCrypto() {
@@ -278,49 +386,95 @@ func (d *Daemon) Run() error {
 		d.config.Sizer = trader.AdjustConfigForBalance(tradingCapital)
 	}
 
-	// 5. PlanStore 초기화 (~/.traveler/ 고정 경로)
-	dataDir := d.config.DataDir
-	if dataDir == "" {
-		if home, err := os.UserHomeDir(); err == nil {
-			dataDir = filepath.Join(home, ".traveler")
-		} else {
-			dataDir = "."
-		}
-	}
-	planStore, err := trader.NewPlanStore(dataDir)
+	// 5. PlanStore 초기화
+	planStore, err := trader.NewPlanStore(d.dataDir)
 	if err != nil {
 		log.Printf("[DAEMON] Warning: could not init plan store: %v", err)
 	}
 
+	// 5-1. 알림 규칙 엔진
+	d.initAlerts(d.dataDir)
+
 	// 6. TradeHistory 초기화
-	history, err := trader.NewTradeHistory(dataDir)
+	history, err := trader.NewTradeHistory(d.dataDir)
 	if err != nil {
 		log.Printf("[DAEMON] Warning: could not init trade history: %v", err)
 	} else {
 		d.history = history
+		if d.webhooks != nil {
+			history.SetOnAppend(d.queueFillEvent)
+		}
+	}
+
+	// 재시작 전 오늘 거래 복원 (일일 상태 파일 없이 baseline으로 재시작한 경우)
+	if d.history != nil {
+		if n, err := d.tracker.BackfillTrades(d.history.GetAll(d.config.Market)); err != nil {
+			log.Printf("[DAEMON] Failed to backfill today's trades: %v", err)
+		} else if n > 0 {
+			log.Printf("[DAEMON] Backfilled %d trade(s) made before the restart from trade history", n)
+		}
+	}
+
+	// 6-1. 전략별 Kelly 리스크 캡 (저널 기반, 사이징 때마다 sizerConfig가 주 1회 재계산)
+	if d.config.KellyRiskCap && d.history != nil {
+		d.kelly = trader.NewKellyRiskStore(d.dataDir, d.config.Market)
+		d.config.Sizer.StrategyRisk = d.kelly.RiskCaps(d.history, d.config.Market)
 	}
 
 	// 7. AutoTrader 생성 (PlanStore 포함)
 	traderCfg := trader.Config{
-		DryRun:          false, // 실전 모드
+		DryRun:          false, // 실전 모드 (리허설은 모의 브로커가 주문을 받는다)
 		MaxPositions:    d.config.Sizer.MaxPositions,
 		MaxPositionPct:  d.config.Sizer.MaxPositionPct,
 		TotalCapital:    tradingCapital,
 		RiskPerTrade:    d.config.Sizer.RiskPerTrade,
 		MonitorInterval: d.config.MonitorInterval,
+		Throttle:        d.config.Throttle,
+		Caps:            d.config.Caps,
+		PriceCheck:      d.config.PriceCheck,
+		EODClose:        d.config.EODClose,
 	}
 	d.autoTrader = trader.NewAutoTraderWithPlanStore(traderCfg, d.broker, d.isCrypto(), planStore)
 
+	// 배당락일 캘린더 (주식만 — 신규 플랜에 배당락일 기록)
+	if !d.isCrypto() {
+		d.dividends = provider.NewDividendCalendar(d.dataDir)
+		d.autoTrader.SetDividendCalendar(d.dividends)
+	}
+
 	// Monitor에 TradeHistory 연결
 	if d.history != nil {
 		d.autoTrader.GetMonitor().SetTradeHistory(d.history, d.config.Market)
 	}
 
-	// Monitor에 Provider 연결 (ETF 시그널 역전 체크용)
+	// 중복 주문 방지 저널 (재시도·재시작 후 같은 매수 재전송 차단)
+	journal := trader.NewOrderJournal(d.dataDir, d.config.Market)
+	d.autoTrader.SetOrderJournal(journal)
+	d.autoTrader.SetEntryGate(d.entryGate)
+	d.autoTrader.AddPreTradeChecks(d.preTradeChecks(d.dataDir, planStore, tradingCapital)...)
+
+	// Monitor에 포지션 P&L 시계열 연결 (웹 포지션 차트)
+	d.autoTrader.GetMonitor().SetPositionHistory(trader.NewPositionHistoryStore(d.dataDir))
+
+	// 시세 조회 장애로 모니터링 일시 중지/재개 시 텔레그램 알림
+	d.autoTrader.GetMonitor().SetOnAlert(func(msg string) {
+		d.notifyTelegram(d.ctx, msg)
+	})
+
+	// Monitor에 Provider 연결 (ETF 시그널 역전 체크용, 시세 조회 실패 시 대체 시세)
 	if d.provider != nil {
 		d.autoTrader.GetMonitor().SetProvider(d.provider)
 	}
 
+	// 청산 체결 → 일일 트래커 (승/패, 입출금 감지용 현금 흐름)
+	d.autoTrader.GetMonitor().SetOnClose(func(rec trader.TradeRecord) {
+		tl := tradeLogFromRecord(rec)
+		tl.Amount = rec.Quantity * rec.Price
+		if err := d.tracker.RecordTrade(tl); err != nil {
+			d.run.addError("tracker", rec.Symbol, err)
+		}
+	})
+
 	// 자본 추적 콜백 등록
 	if d.capital != nil {
 		d.autoTrader.GetMonitor().SetOnSell(func(investedAmount, sellAmount float64) {
@@ -328,6 +482,9 @@ func (d *Daemon) Run() error {
 		})
 	}
 
+	// 7-1. 브로커 ↔ 플랜 ↔ 주문 저널 대조 (플랜 자동 생성 전 상태 기준)
+	d.reconcileAtStartup(d.dataDir, planStore, journal)
+
 	// 8. 기존 포지션 확인 및 모니터 등록
 	// 크립토: PlanStore에 플랜이 있는(=데몬이 진입한) 포지션만 모니터 등록
 	//         수동 매수한 기존 포지션은 건드리지 않음
@@ -335,43 +492,21 @@ func (d *Daemon) Run() error {
 	positions, err := d.broker.GetPositions(d.ctx)
 	if err != nil {
 		log.Printf("[DAEMON] Failed to get positions: %v", err)
+		d.run.addError("positions", "", err)
 	} else {
 		log.Printf("[DAEMON] Current positions: %d", len(positions))
+		cur := d.currency()
 		for _, p := range positions {
-			log.Printf("  - %s: %.0f shares @ $%.2f (P&L: $%.2f)",
-				p.Symbol, p.Quantity, p.AvgCost, p.UnrealizedPnL)
+			log.Printf("  - %s: %.0f shares @ %s (P&L: %s)",
+				p.Symbol, p.Quantity, money.Price(p.AvgCost, cur), money.Signed(p.UnrealizedPnL, cur))
 
 			// PlanStore에서 원래 플랜 복원
 			if planStore != nil {
 				if plan := planStore.Get(p.Symbol); plan != nil {
-					log.Printf("  → Restored plan: strategy=%s, stop=$%.2f, T1=$%.2f, T2=$%.2f, maxDays=%d",
-						plan.Strategy, plan.StopLoss, plan.Target1, plan.Target2, plan.MaxHoldDays)
-					mon := d.autoTrader.GetMonitor()
-					mon.RegisterPositionWithPlan(
-						p.Symbol, p.Quantity, plan.EntryPrice,
-						plan.StopLoss, plan.Target1, plan.Target2,
-						plan.Strategy, plan.MaxHoldDays, plan.EntryTime,
-					)
-					// Restore trailing stop state
-					if plan.UseTrailingStop {
-						mon.SetTrailingStop(p.Symbol, true, plan.TrailingATR, plan.TrailingMultiplier)
-						if plan.HighestSinceT1 > 0 {
-							// Also restore HighestSinceT1 for T1-already-hit positions
-							mon.SetHighestSinceT1(p.Symbol, plan.HighestSinceT1)
-						}
-					}
-					// Restore T1 hit state
-					if plan.Target1Hit {
-						mon.SetTarget1Hit(p.Symbol, true)
-					}
-					// Restore Intraday flag for force close
-					if plan.Strategy == "intraday_orb" || plan.Strategy == "intraday_dip" {
-						for _, pos := range mon.GetActivePositions() {
-							if pos.Symbol == p.Symbol {
-								pos.Intraday = true
-							}
-						}
-					}
+					log.Printf("  → Restored plan: strategy=%s, stop=%s, T1=%s, T2=%s, maxDays=%d",
+						plan.Strategy, money.Price(plan.StopLoss, cur), money.Price(plan.Target1, cur),
+						money.Price(plan.Target2, cur), plan.MaxHoldDays)
+					d.autoTrader.GetMonitor().RegisterPlan(plan, p.Quantity)
 					continue
 				}
 			}
@@ -391,13 +526,10 @@ func (d *Daemon) Run() error {
 			// 주식(US/KR): 플랜 없으면 기술 분석 기반으로 플랜 자동 생성
 			plan := d.generatePlanFromAnalysis(p.Symbol, p.AvgCost, p.Quantity)
 			if plan != nil {
-				log.Printf("  → Generated plan: strategy=%s, stop=$%.2f, T1=$%.2f, T2=$%.2f, maxDays=%d",
-					plan.Strategy, plan.StopLoss, plan.Target1, plan.Target2, plan.MaxHoldDays)
-				d.autoTrader.GetMonitor().RegisterPositionWithPlan(
-					p.Symbol, p.Quantity, plan.EntryPrice,
-					plan.StopLoss, plan.Target1, plan.Target2,
-					plan.Strategy, plan.MaxHoldDays, plan.EntryTime,
-				)
+				log.Printf("  → Generated plan: strategy=%s, stop=%s, T1=%s, T2=%s, maxDays=%d",
+					plan.Strategy, money.Price(plan.StopLoss, cur), money.Price(plan.Target1, cur),
+					money.Price(plan.Target2, cur), plan.MaxHoldDays)
+				d.autoTrader.GetMonitor().RegisterPlan(plan, p.Quantity)
 				if planStore != nil {
 					planStore.Save(plan)
 				}
@@ -420,6 +552,11 @@ func (d *Daemon) Run() error {
 	// 9. 전략 무효화 체크 (전일 데이터 기반, 프리마켓에서 가능)
 	d.runInvalidationCheck()
 
+	// 9.5. 보유기간 중 배당락일 점검 (경고만)
+	d.runDividendCheck()
+
+	d.run.addTiming("startup", d.startedAt)
+
 	// 10. 프리마켓 스캔 (전일 일봉 데이터 사용, 장 열기 전에 시그널 준비)
 	if d.monitorOnly {
 		log.Printf("[DAEMON] Monitor-only mode: skipping scan, will only watch existing positions")
@@ -431,18 +568,27 @@ func (d *Daemon) Run() error {
 		if d.config.ForceScan {
 			log.Printf("[DAEMON] Force scan enabled (existing trades: %d). Running scan...", state.TradeCount)
 		}
-		scanStart := time.Now()
-		scanResult, err := d.adaptiveScan()
-		if err != nil {
-			log.Printf("[DAEMON] Scan error: %v", err)
-		} else {
-			scanResult.ScanTime = time.Since(scanStart)
-			d.saveScanResultForWeb(scanResult)
-			d.preMarketSigs = scanResult.Signals
-			log.Printf("[DAEMON] Scan complete: %d signals found in %s",
-				len(d.preMarketSigs), scanResult.ScanTime.Round(time.Second))
+		// 오래된 일봉으로 시그널을 만들지 않도록 스캔 전에 최신성 확인 (실패 시 스캔 안 함, 재시작하면 다시 검사)
+		if d.checkDataFreshness() {
+			d.beat("scan", 2*time.Hour)
+			scanStart := time.Now()
+			scanResult, err := d.adaptiveScan()
+			d.run.addTiming("scan", scanStart)
+			if err != nil {
+				log.Printf("[DAEMON] Scan error: %v", err)
+				d.run.addError("scan", "", err)
+			} else {
+				scanResult.ScanTime = time.Since(scanStart)
+				d.run.setScan(scanResult)
+				d.recordScanMetric(scanResult)
+				d.saveScanResultForWeb(scanResult)
+				d.emitScanCompleted(scanResult)
+				d.preMarketSigs = scanResult.Signals
+				log.Printf("[DAEMON] Scan complete: %d signals found in %s",
+					len(d.preMarketSigs), scanResult.ScanTime.Round(time.Second))
+			}
+			d.tracker.MarkScanDone()
 		}
-		d.tracker.MarkScanDone()
 	}
 	} // end !monitorOnly
 
@@ -452,22 +598,32 @@ func (d *Daemon) Run() error {
 		remaining := d.getMarketStatus()
 		if !remaining.IsOpen && remaining.TimeToOpen > 0 {
 			log.Printf("[DAEMON] Scan done. Waiting %s for market open...", FormatDuration(remaining.TimeToOpen))
+			waitStart := time.Now()
+			d.beat("waiting", remaining.TimeToOpen+30*time.Minute)
 			select {
 			case <-time.After(remaining.TimeToOpen):
 				log.Println("[DAEMON] Market should be open now.")
+				d.run.addTiming("market_wait", waitStart)
 			case <-d.ctx.Done():
 				return d.shutdown("cancelled")
 			}
 		}
 	}
 
+	// 11.5. 약세장 헤지 점검 (인버스 ETF, 장중 시세 기준)
+	d.runHedgeCheck()
+
 	// 12. 장 열림 → 프리마켓 시그널 즉시 실행
 	if len(d.preMarketSigs) > 0 {
 		log.Printf("[DAEMON] Executing %d pre-scanned signals...", len(d.preMarketSigs))
+		execStart := time.Now()
 		results, err := d.autoTrader.ExecuteSignals(d.ctx, d.preMarketSigs)
+		d.run.addTiming("execution", execStart)
 		if err != nil {
 			log.Printf("[DAEMON] Execution error: %v", err)
+			d.run.addError("execution", "", err)
 		} else {
+			d.run.addOrderErrors(results)
 			for _, r := range results {
 				if r.Success {
 					orderID := ""
@@ -483,7 +639,7 @@ func (d *Daemon) Run() error {
 					if r.Order.Amount > 0 {
 						investAmount = r.Order.Amount // 시장가 매수: KRW 금액
 					}
-					d.tracker.RecordTrade(TradeLog{
+					d.tracker.RecordTrade(dailystate.TradeLog{
 						Symbol:   r.Order.Symbol,
 						Side:     string(r.Order.Side),
 						Quantity: r.Order.Quantity,
@@ -491,6 +647,7 @@ func (d *Daemon) Run() error {
 						Amount:   investAmount,
 						OrderID:  orderID,
 						Reason:   "signal",
+						TradeID:  r.TradeID,
 					})
 					if d.capital != nil {
 						d.capital.RecordBuy(investAmount)
@@ -504,8 +661,11 @@ func (d *Daemon) Run() error {
 							Price:    actualPrice,
 							Strategy: r.Signal.Strategy,
 							Reason:   "signal",
+							TradeID:  r.TradeID,
+							OrderID:  orderID,
 						})
 					}
+					d.notifyEntry(r, actualPrice)
 				}
 			}
 		}
@@ -539,15 +699,26 @@ func (d *Daemon) mainLoop() error {
 		d.runIntradayLoop()
 	}()
 
+	// 워치독: 모니터 사이클이 멈추면 종료 (하트비트는 사이클마다 갱신)
+	loopDone := make(chan struct{})
+	defer close(loopDone)
+	d.cycleDone()
+	go d.runWatchdog(loopDone)
+
 	for {
 		select {
 		case <-d.ctx.Done():
 			<-intradayDone
+			if d.stalled.Load() {
+				d.shutdown("watchdog_stalled")
+				return ErrStalled
+			}
 			return d.shutdown("cancelled")
 
 		case <-monitorTicker.C:
 			// 포지션 모니터링 (30초 간격)
 			d.runMonitorCycle()
+			d.cycleDone()
 		}
 
 		// 종료 조건 체크
@@ -567,10 +738,21 @@ func (d *Daemon) mainLoop() error {
 
 // runMonitorCycle 모니터링 사이클
 func (d *Daemon) runMonitorCycle() {
-	// 개별 종목 손절/익절 체크
+	// 개별 종목 손절/익절 체크 (수동 등록된 플랜 먼저 반영)
 	if d.autoTrader != nil {
+		d.autoTrader.GetMonitor().AdoptPlans(d.ctx)
 		d.autoTrader.GetMonitor().CheckPositions(d.ctx)
+
+		// 마감 전 청산 정책 전략 (장중 전략 등) — 크립토는 24시간이라 해당 없음
+		if !d.isCrypto() {
+			if status := d.getMarketStatus(); status.IsOpen {
+				d.autoTrader.GetMonitor().CloseBeforeEOD(d.ctx, status.TimeToClose)
+			}
+		}
 	}
+	d.checkSafeMode()
+	d.evaluateAlerts()
+	d.confirmFillEvents()
 
 	// P&L 계산: CapitalTracker 모드 vs 전체 계좌 모드
 	if d.capital != nil {
@@ -587,6 +769,7 @@ func (d *Daemon) runMonitorCycle() {
 		if err != nil {
 			return
 		}
+		d.checkAccountAnomalies(positions, 0, false)
 
 		var unrealizedPnL float64
 		for _, p := range positions {
@@ -607,6 +790,7 @@ func (d *Daemon) runMonitorCycle() {
 	if err != nil {
 		return
 	}
+	d.checkAccountAnomalies(balance.Positions, balance.BuyingPower, true)
 
 	var unrealizedPnL float64
 	for _, p := range balance.Positions {
@@ -619,11 +803,38 @@ func (d *Daemon) runMonitorCycle() {
 	// 안 됐을 수 있음. pendingValue를 더하면 이중 계산 → 허위 PnL 발생
 	// (Bug #009: SMCI $30.43 주문 → 19.5% 허위 PnL → 22초 만에 강제청산)
 	totalEquity := balance.TotalEquity
+
+	// 입출금 감지: 거래로 설명 안 되는 현금 변화는 P&L에서 제외
+	d.observeCashFlow(balance.BuyingPower)
+
 	state := d.tracker.GetState()
-	realizedPnL := totalEquity - state.StartingBalance - unrealizedPnL
+	realizedPnL := totalEquity - state.StartingBalance - state.NetCashFlow - unrealizedPnL
 	d.tracker.UpdatePnL(realizedPnL, unrealizedPnL, totalEquity)
 }
 
+// observeCashFlow 현금 변화 중 거래로 설명 안 되는 부분을 입출금으로 기록
+// 트래커를 거치지 않은 주문(웹 수동 매도, 다른 프로세스)은 trade_history에서 먼저 반영하고,
+// 미체결 주문이 있으면 매수가능금액에서 빠진 예약금이 출금으로 잡히지 않도록 판정을 미룬다.
+func (d *Daemon) observeCashFlow(cash float64) {
+	if d.history != nil {
+		d.history.Reload()
+		if n, err := d.tracker.SyncTrades(d.history.GetAll(d.config.Market)); err != nil {
+			d.run.addError("tracker", "", err)
+		} else if n > 0 {
+			log.Printf("[DAEMON] Added %d trade(s) from trade history to the daily tracker", n)
+		}
+	}
+	pending, err := d.broker.GetPendingOrders(d.ctx)
+	if err != nil || len(pending) > 0 {
+		return
+	}
+	if flow, ok := d.tracker.ObserveCash(cash); ok {
+		cur := money.ForMarket(d.config.Market)
+		log.Printf("[DAEMON] External cash flow detected: %s (cash %s -> %s), excluded from P&L",
+			money.Signed(flow.Amount, cur), money.Format(flow.CashBefore, cur), money.Format(flow.CashAfter, cur))
+	}
+}
+
 // daemonScanResult 데몬 스캔 결과 (웹 저장용 메타데이터 포함)
 type daemonScanResult struct {
 	Signals              []strategy.Signal
@@ -632,6 +843,7 @@ type daemonScanResult struct {
 	Decision             string
 	Expansions           int
 	AvgProb              float64
+	Quality              trader.QualityScore // 적응형 품질 평가 시점 (AI/VIX 필터 전)
 	FundamentalsFiltered int
 	AIFiltered           int
 	AIRejections         []ai.AIRejection
@@ -657,9 +869,29 @@ func (d *Daemon) adaptiveScan() (*daemonScanResult, error) {
 	capitalTier := strategy.GetCapitalTier(d.config.Market, tradingCap)
 	log.Printf("[DAEMON] Capital tier: %s (capital=%.0f, market=%s)", capitalTier, tradingCap, d.config.Market)
 
+	// 스캔용 캐싱 provider — 반복 실패 종목(상장폐지/잘못된 심볼)은 자동 제외
+	cacheDays := 250
+	if d.isCrypto() {
+		cacheDays = 50
+	}
+	scanProvider := provider.NewCachingProvider(d.provider, cacheDays)
+	health, err := provider.NewSymbolHealthStore(d.dataDir)
+	if err != nil {
+		log.Printf("[DAEMON] Symbol health load failed (no auto-prune): %v", err)
+	} else {
+		scanProvider.SetHealthStore(health)
+		if pruned := health.Pruned(); len(pruned) > 0 {
+			names := make([]string, len(pruned))
+			for i, h := range pruned {
+				names[i] = h.Symbol
+			}
+			log.Printf("[DAEMON] Skipping %d pruned symbols: %v", len(pruned), names)
+		}
+	}
+
 	if d.isCrypto() {
 		// 크립토: 레짐 인식 메타전략 — capital tier에 따라 BTC-only 또는 full
-		meta := strategy.NewCryptoMetaStrategy(d.provider, tradingCap)
+		meta := strategy.NewCryptoMetaStrategy(scanProvider, tradingCap)
 		strategies = []strategy.Strategy{meta}
 		regimeInfo = meta.GetRegimeInfo(d.ctx)
 		switch regimeInfo.Regime {
@@ -676,12 +908,18 @@ func (d *Daemon) adaptiveScan() (*daemonScanResult, error) {
 	} else {
 		// 주식 (US/KR): 레짐 인식 메타전략 — capital tier에 따라 ETF 또는 개별주
 		metaCfg := strategy.DefaultStockMetaConfig(d.config.Market, tradingCap)
-		meta := strategy.NewStockMetaStrategy(metaCfg, d.provider)
+		metaCfg.Tranches = d.config.Tranches
+		meta := strategy.NewStockMetaStrategy(metaCfg, scanProvider)
 		strategies = []strategy.Strategy{meta}
 		regimeInfo = meta.GetRegimeInfo(d.ctx)
 		activeStrats = meta.GetActiveStrategyNames(d.ctx)
 		log.Printf("[DAEMON] Stock meta strategy: %s (bull=%v, sideways=%v, bear=%v)",
 			metaCfg.Name, metaCfg.Bull, metaCfg.Sideways, metaCfg.Bear)
+		if d.sectorRotation() {
+			// 섹터 로테이션: 레짐과 무관하게 모멘텀 상위 섹터만 (레짐 정보는 로깅/장중 게이트용)
+			strategies = []strategy.Strategy{strategy.MustGet("sector-rotation", scanProvider)}
+			activeStrats = []string{"sector-rotation"}
+		}
 	}
 	log.Printf("[DAEMON] Regime: %s (benchmark=%s, price=%.2f, MA20=%.2f, RSI=%.1f, dayChg=%.1f%%), active=%v",
 		regimeInfo.Regime, regimeInfo.Symbol, regimeInfo.Price, regimeInfo.MA20, regimeInfo.RSI14, regimeInfo.DayChangePct, activeStrats)
@@ -751,7 +989,11 @@ func (d *Daemon) adaptiveScan() (*daemonScanResult, error) {
 	scanner := trader.NewAdaptiveScanner(adaptiveCfg, d.config.Sizer, scanFunc)
 
 	// 마켓별 유니버스 티어 — capital tier에 따라 ETF 또는 기존 유니버스
-	if capitalTier == "etf" || capitalTier == "btc-only" {
+	if d.sectorRotation() {
+		scanner.SetTierFunc(func(balance float64) []trader.UniverseTier {
+			return trader.GetUSSectorTiers(balance)
+		})
+	} else if capitalTier == "etf" || capitalTier == "btc-only" {
 		if d.isCrypto() {
 			// BTC-only: crypto-top10에서 BTC만 스캔
 			scanner.SetTierFunc(func(balance float64) []trader.UniverseTier {
@@ -776,50 +1018,54 @@ func (d *Daemon) adaptiveScan() (*daemonScanResult, error) {
 		})
 	}
 
+	// 블랙/화이트리스트 (모든 전략 시그널에 적용, 로드 실패 시 스캔 중단)
+	lists, err := d.loadSymbolLists(d.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	scanner.SetSymbolLists(lists)
+
 	// 펀더멘탈 필터를 스캐너에 주입 (품질 평가 전에 적용) — 크립토는 사용 안 함
 	var fundamentalsFiltered int
+	filterSources := trader.SignalFilterSources{Candles: d.provider}
 	if !d.isCrypto() {
-		fundDataDir := d.config.DataDir
-		if fundDataDir == "" {
-			if home, err := os.UserHomeDir(); err == nil {
-				fundDataDir = filepath.Join(home, ".traveler")
+		var kosdaqSet map[string]bool
+		if d.isKR() {
+			kosdaqSet = make(map[string]bool)
+			for _, s := range symbols.Kosdaq30Symbols {
+				kosdaqSet[s] = true
 			}
 		}
-		if fundDataDir != "" {
-			var kosdaqSet map[string]bool
-			if d.isKR() {
-				kosdaqSet = make(map[string]bool)
-				for _, s := range symbols.Kosdaq30Symbols {
-					kosdaqSet[s] = true
+		checker := provider.NewFundamentalsChecker(d.dataDir, kosdaqSet)
+		if err := checker.Init(d.ctx); err != nil {
+			log.Printf("[DAEMON] Fundamentals checker init failed (skipping): %v", err)
+		} else {
+			filterSources.Earnings, filterSources.Sectors = checker, checker
+			scanner.SetFilterFunc(func(ctx context.Context, signals []strategy.Signal) []strategy.Signal {
+				syms := make([]string, 0, len(signals))
+				for _, sig := range signals {
+					syms = append(syms, sig.Stock.Symbol)
 				}
-			}
-			checker := provider.NewFundamentalsChecker(fundDataDir, kosdaqSet)
-			if err := checker.Init(d.ctx); err != nil {
-				log.Printf("[DAEMON] Fundamentals checker init failed (skipping): %v", err)
-			} else {
-				scanner.SetFilterFunc(func(ctx context.Context, signals []strategy.Signal) []strategy.Signal {
-					syms := make([]string, 0, len(signals))
-					for _, sig := range signals {
-						syms = append(syms, sig.Stock.Symbol)
-					}
-					rejected := checker.FilterSymbols(ctx, syms)
-					if len(rejected) == 0 {
-						return signals
-					}
-					var filtered []strategy.Signal
-					for _, sig := range signals {
-						if _, rej := rejected[sig.Stock.Symbol]; !rej {
-							filtered = append(filtered, sig)
-						}
+				rejected := checker.FilterSymbols(ctx, syms)
+				if len(rejected) == 0 {
+					return signals
+				}
+				var filtered []strategy.Signal
+				for _, sig := range signals {
+					if _, rej := rejected[sig.Stock.Symbol]; !rej {
+						filtered = append(filtered, sig)
 					}
-					fundamentalsFiltered += len(signals) - len(filtered)
-					log.Printf("[DAEMON] Fundamentals filter: %d → %d signals", len(signals), len(filtered))
-					return filtered
-				})
-			}
+				}
+				fundamentalsFiltered += len(signals) - len(filtered)
+				log.Printf("[DAEMON] Fundamentals filter: %d → %d signals", len(signals), len(filtered))
+				return filtered
+			})
 		}
 	}
 
+	// 설정된 시그널 필터 (trader.signal_filters) — 업종/실적 필터는 펀더멘탈 조회기를 같이 쓴다
+	scanner.SetSignalFilters(d.config.SignalFilters, filterSources)
+
 	// 스캔 실행
 	loader := &daemonStockLoader{provider: d.provider, korean: d.isKR(), crypto: d.isCrypto()}
 	result, err := scanner.Scan(d.ctx, loader)
@@ -877,9 +1123,45 @@ func (d *Daemon) adaptiveScan() (*daemonScanResult, error) {
 		}
 	}
 
-	// 포지션 사이징 적용
-	sizer := trader.NewPositionSizer(d.config.Sizer)
-	sized := sizer.ApplyToSignals(result.Signals)
+	// 포지션 사이징 적용 (설정 시 1분봉 기반 종목별 슬리피지로 비유동 종목 페널티)
+	sizerCfg := d.sizerConfig()
+	if d.config.LiquiditySlippage && d.provider != nil && len(result.Signals) > 0 {
+		syms := make([]string, 0, len(result.Signals))
+		for _, sig := range result.Signals {
+			syms = append(syms, sig.Stock.Symbol)
+		}
+		sizerCfg.Slippage = trader.NewLiquidityModel(d.provider, 3).SlippageMap(d.ctx, syms)
+		if len(sizerCfg.Slippage) > 0 {
+			log.Printf("[DAEMON] Liquidity model: slippage estimated for %d/%d signals", len(sizerCfg.Slippage), len(syms))
+		}
+	}
+	// ADV 상한: 시그널에 일봉이 부족한 종목만 20일 평균 거래량을 따로 조회
+	if d.config.MaxADV != 0 {
+		sizerCfg.MaxADVPct = d.config.MaxADV
+	}
+	if d.provider != nil && d.config.Market != "crypto" && sizerCfg.MaxADVPct >= 0 {
+		var missing []string
+		for _, sig := range result.Signals {
+			if len(sig.Candles) < 20 {
+				missing = append(missing, sig.Stock.Symbol)
+			}
+		}
+		if len(missing) > 0 {
+			sizerCfg.ADV = trader.ADVMap(d.ctx, d.provider, missing)
+			log.Printf("[DAEMON] ADV cap: average volume fetched for %d/%d signals", len(sizerCfg.ADV), len(missing))
+		}
+	}
+	// 포트폴리오 변동성 예산: 보유 포지션 ATR 합산 후 신규 포지션 축소
+	if d.config.VolTarget > 0 && d.provider != nil && len(result.Signals) > 0 {
+		if positions, err := d.broker.GetPositions(d.ctx); err == nil {
+			sizerCfg.VolTarget = d.config.VolTarget
+			sizerCfg.HeldVol = trader.HeldPositionVols(d.ctx, d.provider, positions)
+		} else {
+			log.Printf("[DAEMON] Vol target skipped: %v", err)
+		}
+	}
+	sizer := trader.NewPositionSizer(sizerCfg)
+	sized := sizer.ApplyToSignals(trader.SelectTopN(result.Signals))
 
 	return &daemonScanResult{
 		Signals:              sized,
@@ -888,6 +1170,7 @@ func (d *Daemon) adaptiveScan() (*daemonScanResult, error) {
 		Decision:             result.Decision,
 		Expansions:           result.Expansions,
 		AvgProb:              result.Quality.AvgProb,
+		Quality:              result.Quality,
 		FundamentalsFiltered: fundamentalsFiltered,
 		AIFiltered:           aiFiltered,
 		AIRejections:         aiRejections,
@@ -923,14 +1206,7 @@ func (d *Daemon) checkStopConditions() (bool, string) {
 // processSimStopLosses checks sim positions for SL breaches and closes them.
 // Called when market is closed but positions may have breached SL during unmonitored time.
 func (d *Daemon) processSimStopLosses() {
-	dataDir := d.config.DataDir
-	if dataDir == "" {
-		if home, err := os.UserHomeDir(); err == nil {
-			dataDir = filepath.Join(home, ".traveler")
-		}
-	}
-
-	planStore, err := trader.NewPlanStore(dataDir)
+	planStore, err := trader.NewPlanStore(d.dataDir)
 	if err != nil {
 		log.Printf("[SIM-SL] Could not load plan store: %v", err)
 		return
@@ -942,7 +1218,7 @@ func (d *Daemon) processSimStopLosses() {
 		return
 	}
 
-	history, _ := trader.NewTradeHistory(dataDir)
+	history, _ := trader.NewTradeHistory(d.dataDir)
 
 	log.Printf("[SIM-SL] Found %d positions, %d plans", len(positions), len(planStore.GetAll()))
 	for _, pos := range positions {
@@ -995,16 +1271,19 @@ func (d *Daemon) processSimStopLosses() {
 
 		planStore.Delete(pos.Symbol)
 
+		// 트래커는 아직 시작 전 — 같은 거래일에 다시 뜨면 SyncTrades가 이 기록으로 현금 변화를 설명한다
 		if history != nil {
 			history.Append(trader.TradeRecord{
 				Market:   d.config.Market,
 				Symbol:   pos.Symbol,
 				Side:     "sell",
 				Quantity: pos.Quantity,
-				Price:    pos.CurrentPrice,
+				Price:    currentPrice,
 				Strategy: plan.Strategy,
 				Reason:   "stop_loss",
-				PnL:     pnl,
+				PnL:      pnl,
+				TradeID:  plan.GetTradeID(),
+				OrderID:  result.OrderID,
 			})
 		}
 	}
@@ -1019,20 +1298,45 @@ func (d *Daemon) shutdown(reason string) error {
 	// 상태 저장
 	d.tracker.SetStatus(reason)
 
+	// 체결내역 기반 실현손익 대조 (지원 브로커만)
+	d.reconcileRealizedPnL()
+
 	// 리포트 생성
 	reportPath, err := d.tracker.SaveReport()
 	if err != nil {
 		log.Printf("[DAEMON] Failed to save report: %v", err)
+		d.run.addError("report", "", err)
 	} else {
 		log.Printf("[DAEMON] Report saved: %s", reportPath)
 	}
 
+	// 구조화 세션 리포트 (runs/*.json, traveler reports / /api/reports)
+	d.saveRunReport(reason, reportPath)
+
+	// 주간/월간 집계 갱신
+	if d.tracker.market != "" {
+		if err := dailystate.SaveRollups(d.tracker.dataDir, d.tracker.market, d.tracker.tz); err != nil {
+			log.Printf("[DAEMON] Failed to update rollups: %v", err)
+		}
+	}
+
 	// 리포트 출력
 	fmt.Println(d.tracker.GenerateReport())
 
-	// PC 절전
-	// wake timer는 영구 예약 작업(TravelerDaemon, TravelerDaemonKR)이 관리함
-	// setup-daemon.ps1에서 WakeToRun 설정 완료 → 여기서 별도 등록 불필요
+	// 리포트 이메일 발송 (SMTP 설정 시)
+	d.emailSessionReport(reportPath)
+
+	// 웹훅 (마지막 체결 확인 후 daily.report)
+	d.confirmFillEvents()
+	d.emitDailyReport(reportPath)
+
+	// 하트비트: 정상 종료 (워치독 발동이면 stalled 유지)
+	if !d.stalled.Load() {
+		d.beat("stopped", 0)
+	}
+
+	// PC 절전 (다음 세션 깨우기 예약 후)
+	// Windows: 예약 작업 WakeToRun, macOS: pmset schedule, Linux: systemd 타이머/rtcwake
 	// 크립토는 24/7이므로 절전 안 함
 	if d.config.SleepOnExit && !d.isCrypto() {
 		// 어떤 시장이든 장중이면 절전하지 않음
@@ -1053,14 +1357,12 @@ func (d *Daemon) shutdown(reason string) error {
 				// 장중이 아닌 이상 PC를 켜둘 이유 없음 → 바로 절전
 				log.Printf("[DAEMON] Short run (%s), idle unreliable (%ds). Entering sleep mode...",
 					FormatDuration(runtime), idle)
-				time.Sleep(3 * time.Second)
-				sleepPC()
+				d.sleepUntilNextSession()
 			} else if idle < 300 { // 5분 이내 활동 있으면 사용 중
 				log.Printf("[DAEMON] User active (idle %ds < 300s). Skipping sleep.", idle)
 			} else {
 				log.Printf("[DAEMON] User idle %ds. Entering sleep mode...", idle)
-				time.Sleep(3 * time.Second)
-				sleepPC()
+				d.sleepUntilNextSession()
 			}
 		}
 	}
@@ -1077,15 +1379,6 @@ func (d *Daemon) Stop() {
 
 // saveScanResultForWeb 데몬 스캔 결과를 웹 UI에서 읽을 수 있는 JSON으로 저장
 func (d *Daemon) saveScanResultForWeb(sr *daemonScanResult) {
-	dataDir := d.config.DataDir
-	if dataDir == "" {
-		if home, err := os.UserHomeDir(); err == nil {
-			dataDir = filepath.Join(home, ".traveler")
-		} else {
-			return
-		}
-	}
-
 	// 웹 ScanResponse와 동일한 JSON 구조
 	type signalWithChart struct {
 		strategy.Signal
@@ -1103,7 +1396,7 @@ func (d *Daemon) saveScanResultForWeb(sr *daemonScanResult) {
 				kosdaqSet[s] = true
 			}
 		}
-		fundChecker = provider.NewFundamentalsChecker(dataDir, kosdaqSet)
+		fundChecker = provider.NewFundamentalsChecker(d.dataDir, kosdaqSet)
 		if err := fundChecker.Init(context.Background()); err != nil {
 			log.Printf("[DAEMON] Fundamentals checker init for web save: %v", err)
 			fundChecker = nil
@@ -1171,8 +1464,8 @@ func (d *Daemon) saveScanResultForWeb(sr *daemonScanResult) {
 	} else if d.isKR() {
 		market = "kr"
 	}
-	path := filepath.Join(dataDir, fmt.Sprintf("last_scan_%s.json", market))
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	path := filepath.Join(d.dataDir, fmt.Sprintf("last_scan_%s.json", market))
+	if err := fsutil.WriteFile(path, data, 0644); err != nil {
 		log.Printf("[DAEMON] Failed to save scan result: %v", err)
 	} else {
 		log.Printf("[DAEMON] Scan result saved to %s (%d signals)", filepath.Base(path), len(sigs))
@@ -1467,13 +1760,25 @@ func (d *Daemon) executeIntradaySignals() {
 
 	log.Printf("[INTRADAY] Found %d intraday signals", len(signals))
 
+	// 블랙/화이트리스트 + 설정된 시그널 필터 (업종/실적 조회 없이 가격·확률·R/R·RS만)
+	lists, err := d.loadSymbolLists(d.dataDir)
+	if err != nil {
+		log.Printf("[INTRADAY] %v — skipping %d signals", err, len(signals))
+		return
+	}
+	filterSources := trader.SignalFilterSources{Candles: d.provider, Lists: lists}
+	signals = d.config.SignalFilters.Apply(d.ctx, signals, filterSources)
+	if len(signals) == 0 {
+		return
+	}
+
 	// 포지션 사이징 적용 (intraday는 R/R 기준 완화 — 단기 매매 특성)
-	intradaySizerCfg := d.config.Sizer
+	intradaySizerCfg := d.sizerConfig()
 	if intradaySizerCfg.MinRiskReward > 1.2 {
 		intradaySizerCfg.MinRiskReward = 1.2
 	}
 	sizer := trader.NewPositionSizer(intradaySizerCfg)
-	sized := sizer.ApplyToSignals(signals)
+	sized := sizer.ApplyToSignals(trader.SelectTopN(signals))
 
 	if len(sized) == 0 {
 		// 디버깅: 탈락 원인 로그
@@ -1522,8 +1827,10 @@ func (d *Daemon) executeIntradaySignals() {
 	results, err := d.autoTrader.ExecuteSignals(d.ctx, sized)
 	if err != nil {
 		log.Printf("[INTRADAY] Execution error: %v", err)
+		d.run.addError("intraday_execution", "", err)
 		return
 	}
+	d.run.addOrderErrors(results)
 
 	for _, r := range results {
 		if r.Success {
@@ -1544,7 +1851,7 @@ func (d *Daemon) executeIntradaySignals() {
 				monitor := d.autoTrader.GetMonitor()
 				// Monitor에 Intraday 플래그 설정
 				for _, pos := range monitor.GetActivePositions() {
-					if pos.Symbol == sym && (pos.Strategy == "intraday_orb" || pos.Strategy == "intraday_dip") {
+					if pos.Symbol == sym && d.config.EODClose.Minutes(pos.Strategy) > 0 {
 						pos.Intraday = true
 					}
 				}
@@ -1553,6 +1860,24 @@ func (d *Daemon) executeIntradaySignals() {
 			// 중복 진입 방지
 			d.intradayScanner.MarkExecuted(sym, r.Signal.Strategy)
 
+			// 일일 트래커 (거래 수, 입출금 감지용 현금 흐름)
+			orderID := ""
+			if r.Result != nil {
+				orderID = r.Result.OrderID
+			}
+			if err := d.tracker.RecordTrade(dailystate.TradeLog{
+				Symbol:   sym,
+				Side:     string(r.Order.Side),
+				Quantity: r.Order.Quantity,
+				Price:    actualPrice,
+				Amount:   investAmount,
+				OrderID:  orderID,
+				Reason:   "intraday_signal",
+				TradeID:  r.TradeID,
+			}); err != nil {
+				d.run.addError("tracker", sym, err)
+			}
+
 			// 자본 추적
 			if d.capital != nil {
 				d.capital.RecordBuy(investAmount)
@@ -1568,12 +1893,22 @@ func (d *Daemon) executeIntradaySignals() {
 					Price:    actualPrice,
 					Strategy: r.Signal.Strategy,
 					Reason:   "intraday_signal",
+					TradeID:  r.TradeID,
+					OrderID:  orderID,
 				})
 			}
+			d.notifyEntry(r, actualPrice)
 		}
 	}
 }
 
+// notifyEntry 시그널 매수 체결 알림 (전략 근거 포함)
+func (d *Daemon) notifyEntry(r trader.ExecutionResult, price float64) {
+	cur := money.ForSymbol(r.Order.Symbol)
+	d.notifyTelegram(d.ctx, i18n.T("alert.entry", d.config.Market, r.Order.Symbol, r.Order.Quantity,
+		money.Price(price, cur), r.Signal.Strategy, r.Signal.Explain()))
+}
+
 // recalculateTargets 기존 포지션의 T1/T2를 구조적 레벨로 재계산
 func (d *Daemon) recalculateTargets(planStore *trader.PlanStore) {
 	if planStore == nil {
@@ -1591,6 +1926,9 @@ func (d *Daemon) recalculateTargets(planStore *trader.PlanStore) {
 		if plan.Target1Hit {
 			continue // T1 이미 도달한 포지션은 건드리지 않음
 		}
+		if plan.T2Fraction > 0 {
+			continue // 3분할 익절 플랜은 R 배수 목표 유지
+		}
 
 		// 현재 마켓과 다른 종목은 스킵 (plans.json은 전 마켓 공유)
 		isKRSym := symbols.IsKoreanSymbol(plan.Symbol)
@@ -1756,8 +2094,10 @@ func (d *Daemon) recalculateTargets(planStore *trader.PlanStore) {
 		if plan.Target1 != oldT1 || plan.Target2 != oldT2 {
 			planStore.Save(plan)
 			mon.UpdateTargets(plan.Symbol, plan.Target1, plan.Target2)
-			log.Printf("[RECALC] %s (%s): T1 $%.2f→$%.2f, T2 $%.2f→$%.2f",
-				plan.Symbol, plan.Strategy, oldT1, plan.Target1, oldT2, plan.Target2)
+			cur := d.currency()
+			log.Printf("[RECALC] %s (%s): T1 %s→%s, T2 %s→%s",
+				plan.Symbol, plan.Strategy, money.Price(oldT1, cur), money.Price(plan.Target1, cur),
+				money.Price(oldT2, cur), money.Price(plan.Target2, cur))
 		}
 	}
 }
@@ -1769,120 +2109,5 @@ func (d *Daemon) generatePlanFromAnalysis(symbol string, avgCost float64, quanti
 		log.Printf("[DAEMON] Cannot generate plan for %s: insufficient candle data", symbol)
 		return nil
 	}
-
-	ind := strategy.CalculateIndicators(candles)
-	lastClose := candles[len(candles)-1].Close
-
-	// 전략 추정: 현재 기술적 상태를 기반으로 가장 적합한 전략 배정
-	strategyName := inferStrategy(lastClose, avgCost, ind)
-
-	// R 기반 손절/익절 계산
-	var stopLoss, target1, target2 float64
-
-	switch strategyName {
-	case "mean-reversion":
-		// 손절: 최근 저점 아래 or 진입가 -3%
-		recentLow := candles[len(candles)-1].Low
-		for i := len(candles) - 5; i < len(candles); i++ {
-			if i >= 0 && candles[i].Low < recentLow {
-				recentLow = candles[i].Low
-			}
-		}
-		stopLoss = recentLow * 0.99
-		if stopLoss > avgCost*0.97 {
-			stopLoss = avgCost * 0.97
-		}
-		// 타겟: MA20(평균 회귀), BB상단
-		target1 = ind.MA20
-		target2 = ind.BBUpper
-		if target1 <= avgCost {
-			target1 = avgCost * 1.02
-		}
-		if target2 <= target1 {
-			target2 = avgCost * 1.04
-		}
-
-	case "breakout":
-		// 손절: 돌파 레벨 아래 or 진입가 -3%
-		breakoutLevel := strategy.CalculateHighestHigh(candles, 20)
-		stopLoss = breakoutLevel * 0.97
-		if stopLoss > avgCost*0.97 {
-			stopLoss = avgCost * 0.97
-		}
-		riskPerShare := avgCost - stopLoss
-		// Measured move T1, Fib 1.618 T2
-		swingLow, _ := strategy.FindSwingLow(candles, 20)
-		if swingLow > 0 && breakoutLevel > swingLow {
-			target1 = breakoutLevel + (breakoutLevel - swingLow)
-			target2 = strategy.FibonacciExtension(swingLow, breakoutLevel, 0.618)
-		}
-		if target1 <= 0 || (target1-avgCost) < riskPerShare*0.5 {
-			target1 = avgCost + riskPerShare*1.5
-		}
-		if target2 <= target1 {
-			target2 = avgCost + riskPerShare*3.0
-		}
-
-	default: // pullback
-		// 손절: MA20 아래 or 진입가 -3%
-		stopLoss = ind.MA20 * 0.98
-		if stopLoss > avgCost*0.97 {
-			stopLoss = avgCost * 0.97
-		}
-		riskPerShare := avgCost - stopLoss
-		if riskPerShare <= 0 {
-			riskPerShare = avgCost * 0.02
-		}
-		// Swing high T1, Fib 1.272 T2
-		swingHigh, _ := strategy.FindSwingHigh(candles, 20)
-		swingLow, _ := strategy.FindSwingLow(candles, 20)
-		target1 = swingHigh
-		if target1 <= 0 || (target1-avgCost) < riskPerShare*0.5 {
-			target1 = avgCost + riskPerShare*1.5
-		}
-		if swingLow > 0 && swingHigh > swingLow {
-			target2 = strategy.FibonacciExtension(swingLow, swingHigh, 0.272)
-		}
-		if target2 <= target1 {
-			target2 = avgCost + riskPerShare*2.5
-		}
-	}
-
-	maxDays := trader.GetMaxHoldDays(strategyName)
-
-	plan := &trader.PositionPlan{
-		Symbol:      symbol,
-		Strategy:    strategyName,
-		EntryPrice:  avgCost,
-		Quantity:    quantity,
-		StopLoss:    stopLoss,
-		Target1:     target1,
-		Target2:     target2,
-		Target1Hit:  false,
-		EntryTime:   time.Now(), // 기존 종목은 진입 시점 불명 → 지금부터 카운트
-		MaxHoldDays: maxDays,
-	}
-
-	// Breakout: 돌파 레벨 저장
-	if strategyName == "breakout" {
-		plan.BreakoutLevel = strategy.CalculateHighestHigh(candles, 20)
-	}
-
-	return plan
-}
-
-// inferStrategy 현재 기술적 상태로 전략 추정
-func inferStrategy(lastClose, avgCost float64, ind *strategy.Indicators) string {
-	// RSI < 40이고 BB 하단 근처 → mean-reversion
-	if ind.RSI14 < 40 && ind.BBLower > 0 && lastClose < ind.MA20 {
-		return "mean-reversion"
-	}
-
-	// 20일 고점 근처이고 MA50 위 → breakout
-	if ind.MA50 > 0 && lastClose > ind.MA50 && lastClose > ind.MA20*1.02 {
-		return "breakout"
-	}
-
-	// 기본: pullback
-	return "pullback"
+	return trader.PlanFromCandles(symbol, candles, avgCost, quantity, "", d.config.Tranches)
 }
@@ -0,0 +1,56 @@
+//go:build darwin
+
+package daemon
+
+import (
+	"log"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// wakeMonitor 디스플레이 켜기 (macOS: caffeinate -u, 사용자 활동으로 간주)
+func wakeMonitor() {
+	if err := exec.Command("caffeinate", "-u", "-t", "5").Run(); err != nil {
+		log.Printf("[DAEMON] wakeMonitor: %v", err)
+		return
+	}
+	log.Println("[DAEMON] Display wake signal sent")
+}
+
+// sleepPC 절전 (macOS: pmset sleepnow)
+func sleepPC() {
+	if out, err := exec.Command("pmset", "sleepnow").CombinedOutput(); err != nil {
+		log.Printf("[DAEMON] Failed to sleep: %v", commandError([]string{"pmset"}, out, err))
+	}
+}
+
+var hidIdleRe = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// getUserIdleSeconds 마지막 입력 이후 경과 시간 (IOHIDSystem HIDIdleTime, ns)
+func getUserIdleSeconds() int {
+	out, err := exec.Command("ioreg", "-c", "IOHIDSystem", "-d", "4").Output()
+	if err != nil {
+		return 9999 // 실패 시 유휴로 간주
+	}
+	m := hidIdleRe.FindSubmatch(out)
+	if m == nil {
+		return 9999
+	}
+	ns, err := strconv.ParseInt(string(m[1]), 10, 64)
+	if err != nil {
+		return 9999
+	}
+	return int(time.Duration(ns) / time.Second)
+}
+
+// scheduleWakeAt pmset 1회성 깨우기 예약 (root 권한 필요: sudoers에 pmset NOPASSWD 또는 root로 실행)
+// 고정 시각으로 매일 깨우려면 `sudo pmset repeat wakeorpoweron MTWRF 08:40:00`을 대신 쓸 수 있다.
+func scheduleWakeAt(at time.Time) (string, error) {
+	args := pmsetWakeArgs(at)
+	if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+		return "", commandError(args, out, err)
+	}
+	return "pmset schedule", nil
+}
@@ -0,0 +1,72 @@
+//go:build linux
+
+package daemon
+
+import (
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wakeMonitor 데스크톱이면 DPMS로 화면 켜기 (Pi 등 헤드리스는 생략)
+func wakeMonitor() {
+	if os.Getenv("DISPLAY") == "" {
+		log.Println("[DAEMON] wakeMonitor: no display, skipping")
+		return
+	}
+	if err := exec.Command("xset", "dpms", "force", "on").Run(); err != nil {
+		log.Printf("[DAEMON] wakeMonitor: %v", err)
+		return
+	}
+	log.Println("[DAEMON] Display wake signal sent")
+}
+
+// sleepPC 절전 (systemd: systemctl suspend, polkit 권한 필요)
+// Pi 서비스는 24/7 가동이라 --sleep-on-exit=false로 실행한다.
+func sleepPC() {
+	if out, err := exec.Command("systemctl", "suspend").CombinedOutput(); err != nil {
+		log.Printf("[DAEMON] Failed to sleep: %v", commandError([]string{"systemctl"}, out, err))
+	}
+}
+
+// getUserIdleSeconds X 세션 유휴 시간 (xprintidle, ms), 알 수 없으면 유휴로 간주
+func getUserIdleSeconds() int {
+	if os.Getenv("DISPLAY") == "" {
+		return 9999
+	}
+	out, err := exec.Command("xprintidle").Output()
+	if err != nil {
+		return 9999
+	}
+	ms, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return 9999
+	}
+	return int(time.Duration(ms) * time.Millisecond / time.Second)
+}
+
+// scheduleWakeAt systemd 타이머(WakeSystem=true)로 깨우기 예약, systemd가 없거나 실패하면 rtcwake
+// 둘 다 root 권한이 필요하다 (root 서비스로 실행하거나 sudoers 설정).
+func scheduleWakeAt(at time.Time) (string, error) {
+	var errs []string
+	if _, err := exec.LookPath("systemd-run"); err == nil {
+		exec.Command("systemctl", "stop", "traveler-wake.timer").Run() // 이전 예약 (없으면 무시)
+		args := systemdWakeArgs(at)
+		out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+		if err == nil {
+			return "systemd timer traveler-wake.timer", nil
+		}
+		errs = append(errs, commandError(args, out, err).Error())
+	}
+	args := rtcwakeArgs(at)
+	out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+	if err == nil {
+		return "rtcwake", nil
+	}
+	errs = append(errs, commandError(args, out, err).Error())
+	return "", errors.New(strings.Join(errs, "; "))
+}
@@ -1,20 +1,29 @@
-//go:build !windows
+//go:build !windows && !darwin && !linux
 
 package daemon
 
-import "log"
+import (
+	"errors"
+	"log"
+	"time"
+)
 
-// wakeMonitor no-op on non-Windows (Pi에는 모니터 없음)
+// wakeMonitor no-op (Windows/macOS/Linux 외 플랫폼)
 func wakeMonitor() {
 	log.Println("[DAEMON] wakeMonitor: no-op on this platform")
 }
 
-// sleepPC no-op on non-Windows (Pi는 24/7 가동)
+// sleepPC no-op (Windows/macOS/Linux 외 플랫폼)
 func sleepPC() {
 	log.Println("[DAEMON] sleepPC: disabled on this platform")
 }
 
-// getUserIdleSeconds 항상 유휴 상태 반환 (non-Windows)
+// getUserIdleSeconds 항상 유휴 상태 반환
 func getUserIdleSeconds() int {
 	return 9999
 }
+
+// scheduleWakeAt 지원하지 않는 플랫폼
+func scheduleWakeAt(time.Time) (string, error) {
+	return "", errors.New("wake scheduling not supported on this platform")
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os/exec"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
@@ -34,6 +35,12 @@ func sleepPC() {
 	}
 }
 
+// scheduleWakeAt Windows는 예약 작업(TravelerDaemon, TravelerDaemonKR)의 WakeToRun이 깨운다
+// scripts/register-tasks.ps1에서 등록 → 여기서 별도 등록 불필요
+func scheduleWakeAt(time.Time) (string, error) {
+	return "scheduled task WakeToRun (register-tasks.ps1)", nil
+}
+
 // getUserIdleSeconds 사용자 마지막 입력 이후 경과 시간 (초)
 func getUserIdleSeconds() int {
 	user32 := syscall.NewLazyDLL("user32.dll")
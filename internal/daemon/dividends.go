@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"log"
+
+	"traveler/internal/trader"
+)
+
+// runDividendCheck 보유 포지션의 다음 배당락일을 갱신하고 보유기간 중 배당락이면 경고
+// 배당락 갭은 손절가를 건드릴 수 있지만 배당으로 상쇄되므로 자동 청산하지 않는다.
+func (d *Daemon) runDividendCheck() {
+	if d.dividends == nil || d.autoTrader == nil {
+		return
+	}
+	planStore := d.autoTrader.GetPlanStore()
+	if planStore == nil {
+		return
+	}
+	positions := d.autoTrader.GetMonitor().GetActivePositions()
+	if len(positions) == 0 {
+		return
+	}
+
+	crossing := 0
+	for _, pos := range positions {
+		plan := planStore.Get(pos.Symbol)
+		if plan == nil {
+			continue
+		}
+		updated := *plan
+		if trader.AnnotateDividend(d.ctx, d.dividends, &updated) {
+			crossing++
+		}
+		if err := planStore.UpdateDividend(pos.Symbol, updated.ExDividendDate, updated.DividendAmount); err != nil {
+			log.Printf("[DIVIDEND] Failed to update plan for %s: %v", pos.Symbol, err)
+		}
+	}
+	if crossing > 0 {
+		log.Printf("[DIVIDEND] %d/%d positions hold through an ex-dividend date", crossing, len(positions))
+	}
+}
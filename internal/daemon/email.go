@@ -0,0 +1,54 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"traveler/internal/notify"
+)
+
+// emailSessionReport 세션 종료 시 일일 리포트 + 스캔 결과를 이메일로 발송 (SMTP 미설정이면 생략)
+// 본문: 일일 리포트 텍스트, 첨부: report_<date>.txt, daily_<market>_<date>.json, last_scan_<market>.json
+func (d *Daemon) emailSessionReport(reportPath string) {
+	mailer := notify.NewEmailNotifier()
+	if mailer == nil {
+		return
+	}
+
+	state := d.tracker.GetState()
+
+	paths := []string{
+		reportPath,
+		d.tracker.stateFilePath(state.Date),
+		filepath.Join(d.dataDir, fmt.Sprintf("last_scan_%s.json", d.config.Market)),
+	}
+	var attachments []notify.Attachment
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		a, err := notify.AttachFile(p)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("[EMAIL] Skipping attachment %s: %v", filepath.Base(p), err)
+			}
+			continue
+		}
+		attachments = append(attachments, a)
+	}
+
+	tag := "[traveler]"
+	if label := d.rehearsalLabel(); label != "" {
+		tag = "[traveler " + label + "]"
+	}
+	subject := fmt.Sprintf("%s %s daily report %s — %s, P&L %+.2f%% (%d trades)",
+		tag, strings.ToUpper(d.config.Market), state.Date, state.Status, state.TotalPnLPct, state.TradeCount)
+	if err := mailer.Send(subject, d.tracker.GenerateReport(), attachments); err != nil {
+		log.Printf("[EMAIL] Failed to send daily report: %v", err)
+		return
+	}
+	log.Printf("[EMAIL] Daily report sent to %s (%d attachments)", strings.Join(mailer.Recipients(), ", "), len(attachments))
+}
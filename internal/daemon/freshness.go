@@ -0,0 +1,182 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"traveler/internal/i18n"
+	"traveler/internal/notify"
+	"traveler/internal/provider"
+)
+
+// ProviderFreshness 데이터 공급자 하나의 일봉 최신성
+type ProviderFreshness struct {
+	Provider   string `json:"provider"`
+	LastCandle string `json:"last_candle,omitempty"` // 마지막 일봉 날짜 (YYYY-MM-DD)
+	LagDays    int    `json:"lag_days"`              // 기대 거래일보다 늦은 거래일 수
+	Error      string `json:"error,omitempty"`
+}
+
+// FreshnessReport 스캔 전 일봉 최신성 검사 결과
+type FreshnessReport struct {
+	Market   string              `json:"market"`
+	Symbol   string              `json:"symbol"`   // 기준 종목 (SPY, 069500)
+	Expected string              `json:"expected"` // 마지막으로 확정된 거래일
+	MaxLag   int                 `json:"max_lag_days"`
+	Checks   []ProviderFreshness `json:"providers"`
+	Stale    []string            `json:"stale,omitempty"` // 허용 지연을 넘긴 공급자
+}
+
+// OK 지연된 공급자가 없고, 적어도 하나는 데이터를 돌려줬는지
+func (r *FreshnessReport) OK() bool {
+	if len(r.Stale) > 0 {
+		return false
+	}
+	for _, c := range r.Checks {
+		if c.Error == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary 로그/알림용 한 줄 요약
+func (r *FreshnessReport) Summary() string {
+	parts := make([]string, 0, len(r.Checks))
+	for _, c := range r.Checks {
+		switch {
+		case c.Error != "":
+			parts = append(parts, fmt.Sprintf("%s: %s", c.Provider, c.Error))
+		case c.LagDays > 0:
+			parts = append(parts, fmt.Sprintf("%s: %s (%d trading day(s) behind)", c.Provider, c.LastCandle, c.LagDays))
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %s", c.Provider, c.LastCandle))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// isTradingDay 주말·휴장일이 아닌 날 (us, kr)
+func isTradingDay(market string, t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	if market == "kr" {
+		return !IsKRHoliday(t)
+	}
+	return !IsUSHoliday(t)
+}
+
+// LastCompletedSession 일봉이 확정된 마지막 거래일 (장 마감 전이면 전 거래일, 마켓 현지 날짜 자정)
+func LastCompletedSession(market string, now time.Time) time.Time {
+	loc, schedule := GetETLocation(), DefaultMarketSchedule()
+	if market == "kr" {
+		loc, schedule = GetKSTLocation(), KRMarketSchedule()
+	}
+	now = now.In(loc)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	closeAt := sessionAt(day, schedule.CloseHour, schedule.CloseMin, loc)
+	if isTradingDay(market, day) && !now.Before(closeAt) {
+		return day
+	}
+	for i := 0; i < 10; i++ {
+		day = day.AddDate(0, 0, -1)
+		if isTradingDay(market, day) {
+			return day
+		}
+	}
+	return day
+}
+
+// tradingDaysBehind last 이후 expected까지 거래일 수 (last가 expected 이후면 0)
+func tradingDaysBehind(market string, last, expected time.Time) int {
+	lag := 0
+	for d := last.AddDate(0, 0, 1); !d.After(expected); d = d.AddDate(0, 0, 1) {
+		if isTradingDay(market, d) {
+			lag++
+		}
+	}
+	return lag
+}
+
+// candleDate 일봉의 거래일 (현지 자정)
+// 공급자마다 타임스탬프가 현지 자정, UTC 자정(미국 기준 전날 20시), 개장 시각으로 달라 12시간을 더해 날짜를 정한다.
+func candleDate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc).Add(12 * time.Hour)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// CheckDataFreshness 공급자별로 기준 종목 일봉을 받아 마지막 봉이 마지막 거래일인지 검사
+// FallbackProvider면 공급자마다 따로 본다 (앞 공급자가 오래된 봉을 "성공"으로 돌려주면 폴백이 안 되므로).
+func CheckDataFreshness(ctx context.Context, p provider.Provider, market string, maxLag int, now time.Time) *FreshnessReport {
+	symbol, loc := "SPY", GetETLocation()
+	if market == "kr" {
+		symbol, loc = "069500", GetKSTLocation()
+	}
+	expected := LastCompletedSession(market, now)
+	report := &FreshnessReport{Market: market, Symbol: symbol, Expected: expected.Format("2006-01-02"), MaxLag: maxLag}
+
+	providers := []provider.Provider{p}
+	if fp, ok := p.(interface{ Providers() []provider.Provider }); ok && len(fp.Providers()) > 0 {
+		providers = fp.Providers()
+	}
+	for _, pr := range providers {
+		c := ProviderFreshness{Provider: pr.Name()}
+		candles, err := pr.GetDailyCandles(ctx, symbol, 10)
+		switch {
+		case err != nil:
+			c.Error = err.Error()
+		case len(candles) == 0:
+			c.Error = "no candles"
+		default:
+			last := candleDate(candles[len(candles)-1].Time, loc)
+			c.LastCandle = last.Format("2006-01-02")
+			c.LagDays = tradingDaysBehind(market, last, expected)
+			if c.LagDays > maxLag {
+				report.Stale = append(report.Stale, pr.Name())
+			}
+		}
+		report.Checks = append(report.Checks, c)
+	}
+	return report
+}
+
+// checkDataFreshness 스캔 직전 일봉 최신성 검사 → 오래된 데이터면 신규 매수 중단 + 알림
+// 손절/익절 감시는 브로커 시세로 계속한다. 크립토(24/7)와 max_data_lag_days < 0이면 건너뛴다.
+func (d *Daemon) checkDataFreshness() bool {
+	if d.isCrypto() || d.config.MaxDataLagDays < 0 || d.provider == nil {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, 2*time.Minute)
+	defer cancel()
+	start := time.Now()
+	report := CheckDataFreshness(ctx, d.provider, d.config.Market, d.config.MaxDataLagDays, start)
+	d.run.addTiming("data_freshness", start)
+	if report.OK() {
+		log.Printf("[FRESHNESS] %s daily data current through %s (%s)", report.Symbol, report.Expected, report.Summary())
+		return true
+	}
+
+	problem := "stale market data"
+	msgProblem := i18n.T("alert.data_stale")
+	if len(report.Stale) == 0 {
+		problem = "market data unavailable"
+		msgProblem = i18n.T("alert.data_unavailable")
+	}
+	reason := fmt.Sprintf("%s: expected %s candles for %s (%s)", problem, report.Expected, report.Symbol, report.Summary())
+	d.staleData.Store(reason)
+	d.run.addError("data_freshness", "", errors.New(reason))
+	log.Printf("[FRESHNESS] %s — skipping scan, new entries paused", reason)
+
+	msg := i18n.T("alert.data_aborted",
+		strings.ToUpper(d.config.Market), msgProblem, report.Expected, report.Symbol, strings.ReplaceAll(report.Summary(), ", ", "\n"))
+	d.notifyTelegram(context.Background(), msg)
+	if d.webhooks != nil {
+		d.webhooks.Emit(notify.EventDataStale, report)
+	}
+	return false
+}
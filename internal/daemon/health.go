@@ -0,0 +1,180 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"traveler/internal/fsutil"
+	"traveler/internal/i18n"
+)
+
+// DefaultWatchdogTimeout 모니터 사이클이 이보다 오래 멈추면 데몬을 종료 (trader.watchdog_minutes = 0)
+const DefaultWatchdogTimeout = 10 * time.Minute
+
+// ExitCodeStalled 워치독이 정상 종료도 못 하고 강제 종료할 때의 프로세스 종료 코드
+const ExitCodeStalled = 3
+
+// ErrStalled 모니터 사이클이 멈춰 워치독이 데몬을 중단시킴 (Run 반환값 → 0이 아닌 종료 코드)
+var ErrStalled = errors.New("daemon stalled: monitor cycle did not complete within the watchdog timeout")
+
+// watchdogGrace 멈춤 감지 후 정상 종료(리포트 저장)를 기다리는 시간
+const watchdogGrace = time.Minute
+
+// Heartbeat 데몬 생존 신호 (heartbeat_<market>.json, 단계 전환과 모니터 사이클마다 갱신)
+// 외부 감시(systemd, cron, 웹 /healthz)가 StaleAfter를 넘겨 갱신이 없으면 멈춘 데몬으로 본다.
+type Heartbeat struct {
+	Market     string    `json:"market"`
+	PID        int       `json:"pid"`
+	Broker     string    `json:"broker"`
+	StartedAt  time.Time `json:"started_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Phase      string    `json:"phase"` // startup, scan, waiting, monitoring, stalled, stopped
+	Cycles     int64     `json:"cycles"`
+	StaleAfter time.Time `json:"stale_after,omitempty"` // 이 시각까지 갱신이 없으면 멈춘 것
+}
+
+// Heartbeat 상태
+const (
+	HealthOK      = "ok"
+	HealthStalled = "stalled"
+	HealthStopped = "stopped"
+)
+
+// Status ok / stalled (갱신 지연 또는 워치독 발동) / stopped (정상 종료)
+func (h *Heartbeat) Status(now time.Time) string {
+	switch {
+	case h.Phase == "stopped":
+		return HealthStopped
+	case h.Phase == "stalled", now.After(h.StaleAfter):
+		return HealthStalled
+	}
+	return HealthOK
+}
+
+// HeartbeatPath 하트비트 파일 경로
+func HeartbeatPath(dataDir, market string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("heartbeat_%s.json", market))
+}
+
+// LoadHeartbeat 마켓 데몬 하트비트 읽기
+func LoadHeartbeat(dataDir, market string) (*Heartbeat, error) {
+	data, err := os.ReadFile(HeartbeatPath(dataDir, market))
+	if err != nil {
+		return nil, err
+	}
+	var h Heartbeat
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("parse heartbeat %s: %w", market, err)
+	}
+	return &h, nil
+}
+
+// LoadHeartbeats dataDir의 모든 데몬 하트비트 (마켓 이름순)
+func LoadHeartbeats(dataDir string) ([]*Heartbeat, error) {
+	matches, err := filepath.Glob(filepath.Join(dataDir, "heartbeat_*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var out []*Heartbeat
+	for _, path := range matches {
+		market := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "heartbeat_"), ".json")
+		if h, err := LoadHeartbeat(dataDir, market); err == nil {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+// watchdogTimeout 설정값 (0 = 기본 10분, 음수 = 워치독 끔)
+func (d *Daemon) watchdogTimeout() time.Duration {
+	if d.config.WatchdogTimeout == 0 {
+		return DefaultWatchdogTimeout
+	}
+	return d.config.WatchdogTimeout
+}
+
+// beat 하트비트 기록: staleIn 안에 다음 갱신이 없으면 멈춘 것으로 본다
+func (d *Daemon) beat(phase string, staleIn time.Duration) {
+	now := time.Now()
+	h := Heartbeat{
+		Market:    d.config.Market,
+		PID:       os.Getpid(),
+		Broker:    d.broker.Name(),
+		StartedAt: d.startedAt,
+		UpdatedAt: now,
+		Phase:     phase,
+		Cycles:    d.cycles.Load(),
+	}
+	if staleIn > 0 {
+		h.StaleAfter = now.Add(staleIn)
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := fsutil.WriteFile(HeartbeatPath(d.tracker.dataDir, d.config.Market), data, 0644); err != nil {
+		log.Printf("[HEALTH] Failed to write heartbeat: %v", err)
+	}
+}
+
+// cycleDone 모니터 사이클 완료 → 워치독 기준 시각 + 하트비트 갱신
+func (d *Daemon) cycleDone() {
+	d.lastCycle.Store(time.Now().UnixNano())
+	d.cycles.Add(1)
+	timeout := d.watchdogTimeout()
+	if timeout < 0 {
+		timeout = 2 * d.config.MonitorInterval // 워치독이 꺼져 있어도 외부 감시용 기한은 남긴다
+	}
+	d.beat("monitoring", timeout)
+}
+
+// runWatchdog 모니터 사이클이 timeout 넘게 완료되지 않으면 (예: 응답 없는 HTTP 호출) 데몬 중단
+// 먼저 컨텍스트를 취소해 정상 종료(리포트 저장, ErrStalled 반환)를 시도하고,
+// watchdogGrace 안에 끝나지 않으면 ExitCodeStalled로 프로세스를 끝낸다. 재시작은 외부 감시(systemd 등)가 맡는다.
+func (d *Daemon) runWatchdog(done <-chan struct{}) {
+	timeout := d.watchdogTimeout()
+	if timeout < 0 {
+		return
+	}
+	interval := d.config.MonitorInterval
+	if interval <= 0 || interval > timeout/2 {
+		interval = timeout / 2
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		last := time.Unix(0, d.lastCycle.Load())
+		stalled := time.Since(last)
+		if stalled <= timeout {
+			continue
+		}
+
+		log.Printf("[WATCHDOG] No completed monitor cycle for %s (timeout %s) — stopping daemon",
+			stalled.Round(time.Second), timeout)
+		d.stalled.Store(true)
+		d.beat("stalled", 0)
+		d.notifyTelegram(context.Background(), i18n.T("alert.stalled",
+			strings.ToUpper(d.config.Market), stalled.Round(time.Second), os.Getpid()))
+		d.cancel()
+
+		select {
+		case <-done:
+		case <-time.After(watchdogGrace):
+			log.Printf("[WATCHDOG] Graceful stop did not finish in %s — exiting with code %d", watchdogGrace, ExitCodeStalled)
+			os.Exit(ExitCodeStalled)
+		}
+		return
+	}
+}
@@ -0,0 +1,218 @@
+package daemon
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/dailystate"
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
+)
+
+// hedgeFillTimeout 헤지 주문 후 보유 수량 변화를 기다리는 최대 시간 (지나면 남은 주문 취소)
+const hedgeFillTimeout = time.Minute
+
+// hedgeFillPoll 헤지 체결 확인 간격
+const hedgeFillPoll = 5 * time.Second
+
+// runHedgeCheck 시장 필터가 약세(bear)로 바뀌면 인버스 ETF 헤지 수량 제안, AutoHedge면 직접 주문
+// 약세가 풀리면 자동 헤지분만 청산한다. 크립토는 대상 아님.
+// 장중에만 점검한다 (장 전에는 전일 종가 기준 주문이 되므로).
+func (d *Daemon) runHedgeCheck() {
+	if d.isCrypto() || d.broker == nil {
+		return
+	}
+	if !d.getMarketStatus().IsOpen {
+		log.Printf("[HEDGE] Market closed: hedge check skipped")
+		return
+	}
+
+	regime := d.regimeInfo.Regime
+	if regime == "" {
+		// 스캔을 건너뛴 경우 레짐만 별도로 판단
+		metaCfg := strategy.DefaultStockMetaConfig(d.config.Market, d.config.Sizer.TotalCapital)
+		regime = strategy.NewStockMetaStrategy(metaCfg, d.provider).GetRegimeInfo(d.ctx).Regime
+	}
+
+	instrument := d.config.HedgeInstrument
+	if instrument == "" {
+		instrument = trader.DefaultHedgeInstrument(d.config.Market)
+	}
+	state := trader.LoadHedgeState(d.dataDir, d.config.Market)
+	if state.Instrument != "" && state.Instrument != instrument {
+		log.Printf("[HEDGE] Open hedge is %s (configured %s) — managing existing hedge", state.Instrument, instrument)
+		instrument = state.Instrument
+	}
+
+	positions, err := d.broker.GetPositions(d.ctx)
+	if err != nil {
+		log.Printf("[HEDGE] Failed to get positions: %v", err)
+		return
+	}
+	held := heldQuantity(positions, instrument)
+
+	if regime != strategy.RegimeBear {
+		if state.Quantity > 0 {
+			log.Printf("[HEDGE] Regime %s: hedge no longer needed (%s x%.0f)", regime, instrument, state.Quantity)
+			if d.config.AutoHedge {
+				d.placeHedgeOrder(instrument, -state.Quantity, 0, held, state)
+			}
+		}
+		return
+	}
+
+	ratio := d.config.HedgeRatio
+	if ratio <= 0 {
+		ratio = trader.DefaultHedgeRatio
+	}
+	calc := trader.NewHedgeCalculator(d.provider)
+	sug, err := calc.Suggest(d.ctx, positions, instrument, ratio, state.Quantity)
+	if err != nil {
+		log.Printf("[HEDGE] Hedge calculation failed: %v", err)
+		return
+	}
+	log.Printf("[HEDGE] Bear regime: long=%.0f, beta-weighted=%.0f, ratio=%.0f%% → %s x%.0f (held %.0f, delta %+.0f @ %.2f)",
+		sug.LongExposure, sug.BetaExposure, ratio*100, instrument, sug.TargetQty, sug.CurrentQty, sug.DeltaQty, sug.Price)
+
+	if !d.config.AutoHedge || sug.DeltaQty == 0 {
+		return
+	}
+	if sug.DeltaQty > 0 && d.safeMode.Load() {
+		log.Printf("[HEDGE] Safe mode: hedge buy deferred until reconciliation is acknowledged")
+		return
+	}
+	d.placeHedgeOrder(instrument, sug.DeltaQty, sug.Price, held, state)
+}
+
+// placeHedgeOrder 헤지 수량 조정 주문 (delta > 0 매수, < 0 매도). held는 주문 전 브로커 보유 수량.
+// 헤지 상태, 매매 기록, 일일 트래커는 보유 수량 변화로 체결이 확인된 수량만큼만 갱신한다.
+func (d *Daemon) placeHedgeOrder(instrument string, delta, price, held float64, state trader.HedgeState) {
+	if price <= 0 {
+		candles, err := d.provider.GetDailyCandles(d.ctx, instrument, 2)
+		if err != nil || len(candles) == 0 {
+			log.Printf("[HEDGE] %s price unavailable: %v", instrument, err)
+			return
+		}
+		price = candles[len(candles)-1].Close
+	}
+
+	side := broker.OrderSideBuy
+	qty := delta
+	if delta < 0 {
+		side = broker.OrderSideSell
+		qty = -delta
+	}
+	// 체결 우선: 매수는 약간 위, 매도는 약간 아래 지정가 (호가 단위 정렬)
+	limit := price * 1.003
+	if side == broker.OrderSideSell {
+		limit = price * 0.997
+	}
+	if d.isKR() {
+		limit = broker.RoundKRXPrice(limit, true, side == broker.OrderSideBuy)
+	} else {
+		limit = broker.RoundUSPrice(limit, side == broker.OrderSideBuy)
+	}
+
+	result, err := d.broker.PlaceOrder(d.ctx, broker.Order{
+		Symbol:     instrument,
+		Side:       side,
+		Type:       broker.OrderTypeLimit,
+		Quantity:   qty,
+		LimitPrice: limit,
+	})
+	if err != nil {
+		log.Printf("[HEDGE] %s %s x%.0f failed: %v", side, instrument, qty, err)
+		return
+	}
+	log.Printf("[HEDGE] %s %s x%.0f @ %.2f submitted (order=%s)", side, instrument, qty, limit, result.OrderID)
+
+	filled := d.awaitHedgeFill(instrument, held, delta)
+	if filled < qty {
+		if err := d.broker.CancelOrder(d.ctx, result.OrderID); err != nil {
+			log.Printf("[HEDGE] Cancel of unfilled %s remainder failed: %v", instrument, err)
+		}
+	}
+	if filled <= 0 {
+		log.Printf("[HEDGE] %s %s not filled within %s — hedge state unchanged", side, instrument, hedgeFillTimeout)
+		return
+	}
+	log.Printf("[HEDGE] %s %s filled x%.0f of %.0f", side, instrument, filled, qty)
+
+	now := time.Now()
+	if state.Quantity <= 0 {
+		state.OpenedAt = now
+	}
+	state.Instrument = instrument
+	if side == broker.OrderSideSell {
+		state.Quantity -= filled
+	} else {
+		state.Quantity += filled
+	}
+	state.UpdatedAt = now
+	if err := trader.SaveHedgeState(d.dataDir, d.config.Market, state); err != nil {
+		log.Printf("[HEDGE] Failed to save hedge state: %v", err)
+	}
+
+	if err := d.tracker.RecordTrade(dailystate.TradeLog{
+		Symbol:   instrument,
+		Side:     string(side),
+		Quantity: filled,
+		Price:    limit,
+		Amount:   filled * limit,
+		OrderID:  result.OrderID,
+		Reason:   "hedge",
+	}); err != nil {
+		d.run.addError("tracker", instrument, err)
+	}
+	if d.history != nil {
+		d.history.Append(trader.TradeRecord{
+			Market:   d.config.Market,
+			Symbol:   instrument,
+			Side:     string(side),
+			Quantity: filled,
+			Price:    limit,
+			Strategy: "hedge",
+			Reason:   "hedge",
+			OrderID:  result.OrderID,
+		})
+	}
+}
+
+// awaitHedgeFill 보유 수량이 delta 방향으로 바뀔 때까지 기다려 체결 수량 반환 (최대 |delta|, 시간 초과 시 그때까지의 변화)
+func (d *Daemon) awaitHedgeFill(instrument string, held, delta float64) float64 {
+	want := math.Abs(delta)
+	var filled float64
+	deadline := time.Now().Add(hedgeFillTimeout)
+	for {
+		select {
+		case <-d.ctx.Done():
+			return filled
+		case <-time.After(hedgeFillPoll):
+		}
+		if positions, err := d.broker.GetPositions(d.ctx); err == nil {
+			change := heldQuantity(positions, instrument) - held
+			if delta < 0 {
+				change = -change
+			}
+			filled = math.Max(0, math.Min(change, want))
+			if filled >= want {
+				return filled
+			}
+		}
+		if time.Now().After(deadline) {
+			return filled
+		}
+	}
+}
+
+// heldQuantity 브로커 보유 수량 (없으면 0)
+func heldQuantity(positions []broker.Position, symbol string) float64 {
+	for _, p := range positions {
+		if p.Symbol == symbol {
+			return p.Quantity
+		}
+	}
+	return 0
+}
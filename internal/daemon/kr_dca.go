@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"log"
-	"os"
 	"path/filepath"
 	"time"
 
 	"traveler/internal/broker"
 	"traveler/internal/dca"
+	"traveler/internal/fsutil"
 	"traveler/internal/provider"
 )
 
@@ -103,7 +103,7 @@ func (d *KRDCADaemon) saveStatusJSON() {
 		return
 	}
 	fp := filepath.Join(d.dataDir, "kr_dca_status.json")
-	if err := os.WriteFile(fp, data, 0644); err != nil {
+	if err := fsutil.WriteFile(fp, data, 0644); err != nil {
 		log.Printf("[KR-DCA] Failed to save status: %v", err)
 	}
 }
@@ -2,7 +2,9 @@ package daemon
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	_ "time/tzdata" // zoneinfo 내장: Windows 등 시스템 tz DB가 없어도 America/New_York DST를 정확히 계산
 )
 
 // MarketSchedule 미장 스케줄
@@ -32,15 +34,16 @@ type MarketStatus struct {
 	CloseTime     time.Time
 	TimeToOpen    time.Duration
 	TimeToClose   time.Duration
-	Reason        string // "open", "closed", "weekend", "holiday", "pre-market", "after-hours"
+	NextOpen      time.Time // 다음 개장 (장중이면 다음 거래일 개장, 크립토는 zero)
+	NextClose     time.Time // 다음 폐장 (장중이면 오늘 폐장, 크립토는 zero)
+	Reason        string    // "open", "closed", "weekend", "holiday", "pre-market", "after-hours"
 }
 
 // GetETLocation US Eastern Time 로케이션
 func GetETLocation() *time.Location {
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
-		// Fallback: UTC-5 (EST) or UTC-4 (EDT)
-		// 간단히 EST로 가정
+		// time/tzdata를 내장하므로 도달하지 않음 (DST 미반영 EST 고정)
 		loc = time.FixedZone("EST", -5*60*60)
 	}
 	return loc
@@ -48,60 +51,103 @@ func GetETLocation() *time.Location {
 
 // GetMarketStatus 현재 마켓 상태 확인
 func GetMarketStatus(schedule MarketSchedule) MarketStatus {
-	loc := GetETLocation()
-	now := time.Now().In(loc)
+	return marketStatusAt(time.Now(), GetETLocation(), schedule, IsUSHoliday)
+}
+
+// sessionAt day(현지 날짜)의 hour:min 현지 시각
+// 자정에 Duration을 더하면 DST 전환일에 한 시간 어긋나므로 벽시계 시각으로 만든다.
+func sessionAt(day time.Time, hour, min int, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, min, 0, 0, loc)
+}
+
+// nextTradingDay day 다음 거래일 (주말/휴장일 스킵, 최대 10일 탐색)
+func nextTradingDay(day time.Time, loc *time.Location, isHoliday func(time.Time) bool) time.Time {
+	next := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < 10; i++ {
+		next = next.AddDate(0, 0, 1)
+		if wd := next.Weekday(); wd != time.Saturday && wd != time.Sunday && !isHoliday(next) {
+			return next
+		}
+	}
+	// 10일 이내 거래일 없음 (비정상)
+	return next
+}
 
+// marketStatusAt now 시점의 마켓 상태 (now의 타임존과 무관하게 loc 현지 시각으로 판단)
+func marketStatusAt(now time.Time, loc *time.Location, schedule MarketSchedule, isHoliday func(time.Time) bool) MarketStatus {
+	now = now.In(loc)
 	status := MarketStatus{
 		CurrentTimeET: now,
 	}
 
 	// 오늘 개장/폐장 시간
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-	status.OpenTime = today.Add(time.Duration(schedule.OpenHour)*time.Hour + time.Duration(schedule.OpenMin)*time.Minute)
-	status.CloseTime = today.Add(time.Duration(schedule.CloseHour)*time.Hour + time.Duration(schedule.CloseMin)*time.Minute)
+	status.OpenTime = sessionAt(now, schedule.OpenHour, schedule.OpenMin, loc)
+	status.CloseTime = sessionAt(now, schedule.CloseHour, schedule.CloseMin, loc)
+
+	next := nextTradingDay(now, loc, isHoliday)
+	nextOpen := sessionAt(next, schedule.OpenHour, schedule.OpenMin, loc)
+	nextClose := sessionAt(next, schedule.CloseHour, schedule.CloseMin, loc)
+
+	closedUntilNext := func(reason string) MarketStatus {
+		status.IsOpen = false
+		status.Reason = reason
+		status.NextOpen, status.NextClose = nextOpen, nextClose
+		status.TimeToOpen = nextOpen.Sub(now)
+		return status
+	}
 
 	// 주말 체크
 	weekday := now.Weekday()
 	if weekday == time.Saturday || weekday == time.Sunday {
-		status.IsOpen = false
-		status.Reason = "weekend"
-		status.TimeToOpen = timeToNextTradingDay(now, schedule)
-		return status
+		return closedUntilNext("weekend")
 	}
 
 	// 휴장일 체크
-	if IsUSHoliday(now) {
-		status.IsOpen = false
-		status.Reason = "holiday"
-		status.TimeToOpen = timeToNextTradingDay(now, schedule)
-		return status
+	if isHoliday(now) {
+		return closedUntilNext("holiday")
 	}
 
 	// 시간대 체크
-	currentMinutes := now.Hour()*60 + now.Minute()
-	openMinutes := schedule.OpenHour*60 + schedule.OpenMin
-	closeMinutes := schedule.CloseHour*60 + schedule.CloseMin
-
-	if currentMinutes < openMinutes {
+	switch {
+	case now.Before(status.OpenTime):
 		// 프리마켓 (장 시작 전)
 		status.IsOpen = false
 		status.Reason = "pre-market"
+		status.NextOpen, status.NextClose = status.OpenTime, status.CloseTime
 		status.TimeToOpen = status.OpenTime.Sub(now)
-	} else if currentMinutes >= closeMinutes {
+	case !now.Before(status.CloseTime):
 		// 애프터마켓 (장 종료 후)
-		status.IsOpen = false
-		status.Reason = "after-hours"
-		status.TimeToOpen = timeToNextTradingDay(now, schedule)
-	} else {
+		return closedUntilNext("after-hours")
+	default:
 		// 정규장
 		status.IsOpen = true
 		status.Reason = "open"
+		status.NextOpen, status.NextClose = nextOpen, status.CloseTime
 		status.TimeToClose = status.CloseTime.Sub(now)
 	}
 
 	return status
 }
 
+// FormatETKST 시각을 ET/KST 둘 다로 표시 ("Mon 09:30 ET / 22:30 KST")
+func FormatETKST(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s ET / %s KST", t.In(GetETLocation()).Format("Mon 15:04"), t.In(GetKSTLocation()).Format("15:04"))
+}
+
+// MarketStatusFor 마켓별 현재 상태 (us, kr, crypto; sim- 접두사 무시)
+func MarketStatusFor(market string) MarketStatus {
+	switch strings.TrimPrefix(market, "sim-") {
+	case "crypto":
+		return GetCryptoMarketStatus()
+	case "kr":
+		return GetKRMarketStatus(KRMarketSchedule())
+	}
+	return GetMarketStatus(DefaultMarketSchedule())
+}
+
 // IsMarketOpen 마켓 열림 여부
 func IsMarketOpen() bool {
 	return GetMarketStatus(DefaultMarketSchedule()).IsOpen
@@ -169,70 +215,9 @@ func KRMarketSchedule() MarketSchedule {
 	}
 }
 
-// GetKRMarketStatus 한국 마켓 상태 확인 (KST 기준)
+// GetKRMarketStatus 한국 마켓 상태 확인 (KST 기준, CurrentTimeET도 KST)
 func GetKRMarketStatus(schedule MarketSchedule) MarketStatus {
-	loc := GetKSTLocation()
-	now := time.Now().In(loc)
-
-	status := MarketStatus{
-		CurrentTimeET: now, // KST로 사용 (필드명은 ET이지만)
-	}
-
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-	status.OpenTime = today.Add(time.Duration(schedule.OpenHour)*time.Hour + time.Duration(schedule.OpenMin)*time.Minute)
-	status.CloseTime = today.Add(time.Duration(schedule.CloseHour)*time.Hour + time.Duration(schedule.CloseMin)*time.Minute)
-
-	weekday := now.Weekday()
-	if weekday == time.Saturday || weekday == time.Sunday {
-		status.IsOpen = false
-		status.Reason = "weekend"
-		status.TimeToOpen = timeToNextKRTradingDay(now, schedule)
-		return status
-	}
-
-	if IsKRHoliday(now) {
-		status.IsOpen = false
-		status.Reason = "holiday"
-		status.TimeToOpen = timeToNextKRTradingDay(now, schedule)
-		return status
-	}
-
-	currentMinutes := now.Hour()*60 + now.Minute()
-	openMinutes := schedule.OpenHour*60 + schedule.OpenMin
-	closeMinutes := schedule.CloseHour*60 + schedule.CloseMin
-
-	if currentMinutes < openMinutes {
-		status.IsOpen = false
-		status.Reason = "pre-market"
-		status.TimeToOpen = status.OpenTime.Sub(now)
-	} else if currentMinutes >= closeMinutes {
-		status.IsOpen = false
-		status.Reason = "after-hours"
-		status.TimeToOpen = timeToNextKRTradingDay(now, schedule)
-	} else {
-		status.IsOpen = true
-		status.Reason = "open"
-		status.TimeToClose = status.CloseTime.Sub(now)
-	}
-
-	return status
-}
-
-// timeToNextKRTradingDay 다음 한국 거래일 개장까지 시간
-func timeToNextKRTradingDay(now time.Time, schedule MarketSchedule) time.Duration {
-	loc := GetKSTLocation()
-	nextDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
-
-	for i := 0; i < 10; i++ {
-		weekday := nextDay.Weekday()
-		if weekday != time.Saturday && weekday != time.Sunday && !IsKRHoliday(nextDay) {
-			nextOpen := nextDay.Add(time.Duration(schedule.OpenHour)*time.Hour + time.Duration(schedule.OpenMin)*time.Minute)
-			return nextOpen.Sub(now)
-		}
-		nextDay = nextDay.AddDate(0, 0, 1)
-	}
-
-	return 24 * time.Hour
+	return marketStatusAt(time.Now(), GetKSTLocation(), schedule, IsKRHoliday)
 }
 
 // IsKRHoliday 한국 공휴일 체크
@@ -332,26 +317,6 @@ var usHolidays2026 = []string{
 	"2026-12-25", // Christmas
 }
 
-// timeToNextTradingDay 다음 거래일 개장까지 시간 계산 (주말/휴장일 스킵)
-func timeToNextTradingDay(now time.Time, schedule MarketSchedule) time.Duration {
-	loc := GetETLocation()
-	nextDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
-
-	// 최대 10일까지 탐색 (연휴 대비)
-	for i := 0; i < 10; i++ {
-		weekday := nextDay.Weekday()
-		if weekday != time.Saturday && weekday != time.Sunday && !IsUSHoliday(nextDay) {
-			// 거래일 발견
-			nextOpen := nextDay.Add(time.Duration(schedule.OpenHour)*time.Hour + time.Duration(schedule.OpenMin)*time.Minute)
-			return nextOpen.Sub(now)
-		}
-		nextDay = nextDay.AddDate(0, 0, 1)
-	}
-
-	// 10일 이내 거래일 없음 (비정상)
-	return 24 * time.Hour
-}
-
 // GetCryptoMarketStatus 크립토 마켓 상태 (24/7 항상 오픈)
 func GetCryptoMarketStatus() MarketStatus {
 	loc := GetKSTLocation()
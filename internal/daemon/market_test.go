@@ -0,0 +1,116 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUSMarketStatusAroundDST(t *testing.T) {
+	et, kst := GetETLocation(), GetKSTLocation()
+	tests := []struct {
+		name       string
+		now        time.Time
+		reason     string
+		nextOpen   string // UTC
+		openKST    string
+		timeToOpen time.Duration
+	}{
+		// 2026-03-08 (일) 02:00 EST → EDT: 금요일 장 마감 후 월요일 개장까지 한 시간 짧다
+		{"friday close before spring forward", time.Date(2026, 3, 6, 17, 0, 0, 0, et), "after-hours",
+			"2026-03-09T13:30:00Z", "22:30", 63*time.Hour + 30*time.Minute},
+		{"sunday after spring forward", time.Date(2026, 3, 8, 12, 0, 0, 0, et), "weekend",
+			"2026-03-09T13:30:00Z", "22:30", 21*time.Hour + 30*time.Minute},
+		// 2026-11-01 (일) 02:00 EDT → EST: 한 시간 길다
+		{"friday close before fall back", time.Date(2026, 10, 30, 17, 0, 0, 0, et), "after-hours",
+			"2026-11-02T14:30:00Z", "23:30", 65*time.Hour + 30*time.Minute},
+		{"monday pre-market after fall back", time.Date(2026, 11, 2, 8, 0, 0, 0, et), "pre-market",
+			"2026-11-02T14:30:00Z", "23:30", 90 * time.Minute},
+		// 한국 시간으로 동작하는 PC: 같은 순간이면 결과가 같아야 한다 (2026-03-07 07:00 KST = 2026-03-06 17:00 EST)
+		{"korean machine clock", time.Date(2026, 3, 7, 7, 0, 0, 0, kst), "after-hours",
+			"2026-03-09T13:30:00Z", "22:30", 63*time.Hour + 30*time.Minute},
+		// Good Friday 휴장 → 월요일
+		{"good friday holiday", time.Date(2026, 4, 3, 10, 0, 0, 0, et), "holiday",
+			"2026-04-06T13:30:00Z", "22:30", 3*24*time.Hour - 30*time.Minute},
+	}
+	for _, tt := range tests {
+		st := marketStatusAt(tt.now, et, DefaultMarketSchedule(), IsUSHoliday)
+		if st.IsOpen || st.Reason != tt.reason {
+			t.Errorf("%s: open=%v reason=%s, want closed %s", tt.name, st.IsOpen, st.Reason, tt.reason)
+		}
+		if got := st.NextOpen.UTC().Format(time.RFC3339); got != tt.nextOpen {
+			t.Errorf("%s: next open %s, want %s", tt.name, got, tt.nextOpen)
+		}
+		if got := st.NextOpen.In(kst).Format("15:04"); got != tt.openKST {
+			t.Errorf("%s: next open %s KST, want %s", tt.name, got, tt.openKST)
+		}
+		if st.TimeToOpen != tt.timeToOpen {
+			t.Errorf("%s: time to open %s, want %s", tt.name, st.TimeToOpen, tt.timeToOpen)
+		}
+		if !st.NextClose.After(st.NextOpen) || st.NextClose.Sub(st.NextOpen) != 390*time.Minute {
+			t.Errorf("%s: next close %s not 6h30m after open %s", tt.name, st.NextClose, st.NextOpen)
+		}
+	}
+}
+
+func TestUSMarketStatusOpen(t *testing.T) {
+	et := GetETLocation()
+	// DST 시작 다음 날 장중: 폐장은 16:00 EDT (20:00 UTC)
+	st := marketStatusAt(time.Date(2026, 3, 9, 15, 0, 0, 0, et), et, DefaultMarketSchedule(), IsUSHoliday)
+	if !st.IsOpen || st.Reason != "open" {
+		t.Fatalf("open=%v reason=%s, want open", st.IsOpen, st.Reason)
+	}
+	if got := st.NextClose.UTC().Format(time.RFC3339); got != "2026-03-09T20:00:00Z" {
+		t.Errorf("next close %s, want 2026-03-09T20:00:00Z", got)
+	}
+	if st.TimeToClose != time.Hour {
+		t.Errorf("time to close %s, want 1h", st.TimeToClose)
+	}
+	if got := st.NextOpen.UTC().Format(time.RFC3339); got != "2026-03-10T13:30:00Z" {
+		t.Errorf("next open %s, want 2026-03-10T13:30:00Z", got)
+	}
+}
+
+func TestKRMarketStatusIgnoresUSDST(t *testing.T) {
+	et, kst := GetETLocation(), GetKSTLocation()
+	tests := []struct {
+		name     string
+		now      time.Time
+		open     bool
+		reason   string
+		nextOpen string // KST
+	}{
+		{"pre-market", time.Date(2026, 3, 9, 8, 0, 0, 0, kst), false, "pre-market", "2026-03-09 09:00"},
+		{"open", time.Date(2026, 3, 9, 10, 0, 0, 0, kst), true, "open", "2026-03-10 09:00"},
+		{"after close", time.Date(2026, 3, 9, 15, 30, 0, 0, kst), false, "after-hours", "2026-03-10 09:00"},
+		// US 시계로 토요일 저녁 = KST 일요일 → 월요일 개장
+		{"us machine clock", time.Date(2026, 3, 7, 19, 0, 0, 0, et), false, "weekend", "2026-03-09 09:00"},
+		// 추석 연휴 (2026-09-24~26) → 다음 거래일 월요일
+		{"chuseok", time.Date(2026, 9, 24, 10, 0, 0, 0, kst), false, "holiday", "2026-09-28 09:00"},
+	}
+	for _, tt := range tests {
+		st := marketStatusAt(tt.now, kst, KRMarketSchedule(), IsKRHoliday)
+		if st.IsOpen != tt.open || st.Reason != tt.reason {
+			t.Errorf("%s: open=%v reason=%s, want open=%v %s", tt.name, st.IsOpen, st.Reason, tt.open, tt.reason)
+		}
+		if got := st.NextOpen.In(kst).Format("2006-01-02 15:04"); got != tt.nextOpen {
+			t.Errorf("%s: next open %s KST, want %s", tt.name, got, tt.nextOpen)
+		}
+	}
+}
+
+func TestFormatETKST(t *testing.T) {
+	et := GetETLocation()
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Date(2026, 3, 6, 9, 30, 0, 0, et), "Fri 09:30 ET / 23:30 KST"},
+		{time.Date(2026, 3, 9, 9, 30, 0, 0, et), "Mon 09:30 ET / 22:30 KST"},
+		{time.Time{}, "-"},
+	}
+	for _, tt := range tests {
+		if got := FormatETKST(tt.at); got != tt.want {
+			t.Errorf("FormatETKST(%v) = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+}
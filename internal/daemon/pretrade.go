@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"log"
+
+	"traveler/internal/provider"
+	"traveler/internal/trader"
+)
+
+// preTradeChecks 데몬 매수 주문 직전 검사 (금액 한도/시세/중복은 Executor 기본 검사)
+// 장 운영, 블랙리스트는 항상, 실적 발표 블랙아웃과 리스크 총량은 설정했을 때만
+func (d *Daemon) preTradeChecks(dataDir string, ps *trader.PlanStore, capital float64) []trader.PreTradeCheck {
+	checks := []trader.PreTradeCheck{
+		trader.MarketOpenCheck{Status: func() (bool, string) {
+			st := d.getMarketStatus()
+			return st.IsOpen, st.Reason
+		}},
+	}
+
+	if lists, err := d.loadSymbolLists(dataDir); err != nil {
+		log.Printf("[DAEMON] %v — new entries paused", err)
+	} else {
+		checks = append(checks, trader.BlacklistCheck{Lists: lists})
+	}
+
+	if d.config.EarningsBlackoutDays > 0 && !d.isCrypto() {
+		fc := provider.NewFundamentalsChecker(dataDir, nil)
+		if err := fc.Init(d.ctx); err != nil {
+			log.Printf("[DAEMON] Earnings blackout disabled (calendar init failed): %v", err)
+		} else {
+			checks = append(checks, trader.EarningsBlackoutCheck{Source: fc, Days: d.config.EarningsBlackoutDays})
+		}
+	}
+
+	if d.config.MaxHeat > 0 {
+		// 자본은 모니터 사이클이 갱신하는 현재 잔고 (아직 없으면 시작 시 자본)
+		liveCapital := func() float64 {
+			if bal := d.tracker.GetState().CurrentBalance; bal > 0 {
+				return bal
+			}
+			return capital
+		}
+		checks = append(checks, trader.HeatCapCheck{Plans: ps, Market: d.config.Market, Capital: liveCapital, MaxHeat: d.config.MaxHeat})
+	}
+	return checks
+}
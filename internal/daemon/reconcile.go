@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"log"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/dailystate"
+	"traveler/internal/money"
+	"traveler/internal/trader"
+)
+
+// fillLookbackDays 매수 원가를 찾기 위한 체결내역 조회 기간 (KIS 국내는 3개월 이내)
+const fillLookbackDays = 60
+
+// reconcileRealizedPnL 브로커 체결내역으로 오늘 실현손익을 계산해 equity 기반 추정치와 대조.
+// equity 기반 추정은 입출금이 있으면 틀어지므로 리포트에 둘 다 남긴다.
+// 체결내역 조회를 지원하지 않는 브로커는 건너뛴다.
+func (d *Daemon) reconcileRealizedPnL() {
+	fh, ok := d.broker.(broker.FillHistory)
+	if !ok {
+		return
+	}
+
+	state := d.tracker.GetState()
+	loc := d.tracker.tz
+	if loc == nil {
+		loc = time.Local
+	}
+	dayStart, err := time.ParseInLocation("2006-01-02", state.Date, loc)
+	if err != nil {
+		return
+	}
+
+	fills, err := fh.GetFills(d.ctx, dayStart.AddDate(0, 0, -fillLookbackDays), dayStart)
+	if err != nil {
+		log.Printf("[DAEMON] Fill reconciliation skipped: %v", err)
+		return
+	}
+
+	realized := trader.RealizedFromFills(fills, d.config.Market, dayStart)
+	r := dailystate.FillReconciliation{
+		CheckedAt:    time.Now(),
+		FillPnL:      realized.NetPnL,
+		EquityPnL:    state.RealizedPnL,
+		Difference:   state.RealizedPnL - realized.NetPnL,
+		ClosedTrades: len(realized.Trades),
+		Wins:         realized.Wins,
+		Losses:       realized.Losses,
+		Unmatched:    realized.Unmatched,
+	}
+	d.tracker.SetReconciliation(r)
+
+	cur := money.ForMarket(d.config.Market)
+	log.Printf("[DAEMON] Realized P&L (fills): %s over %d closed trades | equity estimate %s | diff %s",
+		money.Signed(r.FillPnL, cur), r.ClosedTrades, money.Signed(r.EquityPnL, cur), money.Signed(r.Difference, cur))
+	if len(r.Unmatched) > 0 {
+		log.Printf("[DAEMON] Sells without buy fill in %dd lookback: %v", fillLookbackDays, r.Unmatched)
+	}
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"traveler/internal/fsutil"
+	"traveler/internal/i18n"
+	"traveler/internal/notify"
+)
+
+// RehearsalLog 리허설 모드로 돌린 세션 날짜 (rehearsal_<market>.json)
+// 실전 전환 전에 며칠째 리허설인지 보여주는 용도.
+type RehearsalLog struct {
+	Market string   `json:"market"`
+	Days   []string `json:"days"` // 마켓 기준 날짜 (YYYY-MM-DD, 오름차순)
+}
+
+// rehearsalLogPath 리허설 기록 파일 경로
+func rehearsalLogPath(dataDir, market string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("rehearsal_%s.json", market))
+}
+
+// LoadRehearsalLog 리허설 기록 읽기 (없으면 빈 기록)
+func LoadRehearsalLog(dataDir, market string) (*RehearsalLog, error) {
+	rl := &RehearsalLog{Market: market}
+	data, err := os.ReadFile(rehearsalLogPath(dataDir, market))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rl, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, rl); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// Record 오늘 세션을 기록하고 리허설 N일째를 돌려줌 (같은 날 재시작은 한 번만 센다)
+func (rl *RehearsalLog) Record(date string) int {
+	i := sort.SearchStrings(rl.Days, date)
+	if i == len(rl.Days) || rl.Days[i] != date {
+		rl.Days = append(rl.Days, "")
+		copy(rl.Days[i+1:], rl.Days[i:])
+		rl.Days[i] = date
+	}
+	return i + 1
+}
+
+// Save 리허설 기록 저장
+func (rl *RehearsalLog) Save(dataDir string) error {
+	data, err := json.MarshalIndent(rl, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(rehearsalLogPath(dataDir, rl.Market), data, 0644)
+}
+
+// startRehearsal 리허설 세션 시작: N일째 기록 + 리포트 머리말
+func (d *Daemon) startRehearsal() {
+	if !d.config.Rehearsal {
+		return
+	}
+	d.tracker.EnsureDate()
+	date := d.tracker.GetState().Date
+	rl, err := LoadRehearsalLog(d.tracker.dataDir, d.config.Market)
+	if err != nil {
+		log.Printf("[REHEARSAL] Failed to read rehearsal log: %v", err)
+		rl = &RehearsalLog{Market: d.config.Market}
+	}
+	d.rehearsalDay = rl.Record(date)
+	if err := rl.Save(d.tracker.dataDir); err != nil {
+		log.Printf("[REHEARSAL] Failed to save rehearsal log: %v", err)
+	}
+	log.Printf("[REHEARSAL] Day %d (since %s): paper broker %s, no live orders", d.rehearsalDay, rl.Days[0], d.broker.Name())
+	d.tracker.SetBanner(i18n.T("report.rehearsal_banner", d.rehearsalLabel()))
+}
+
+// rehearsalLabel 알림/리포트 표시 ("REHEARSAL day 3", 리허설이 아니면 빈 문자열)
+func (d *Daemon) rehearsalLabel() string {
+	if !d.config.Rehearsal {
+		return ""
+	}
+	return i18n.T("report.rehearsal", d.rehearsalDay)
+}
+
+// notifyTelegram 데몬 텔레그램 알림 (리허설이면 머리말을 붙여 실전 알림과 구분)
+func (d *Daemon) notifyTelegram(ctx context.Context, msg string) {
+	if label := d.rehearsalLabel(); label != "" {
+		msg = "🧪 [" + label + "] " + msg
+	}
+	notify.NewTelegramNotifier().Send(ctx, msg)
+}
@@ -0,0 +1,102 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/dailystate"
+	"traveler/internal/trader"
+)
+
+// RoundTrip 진입부터 청산까지 한 포지션의 왕복 거래 (같은 TradeID의 매수/매도 묶음)
+type RoundTrip struct {
+	TradeID    string    `json:"trade_id"`
+	Symbol     string    `json:"symbol"`
+	EntryTime  time.Time `json:"entry_time"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitTime   time.Time `json:"exit_time"`  // 마지막 매도 시각
+	ExitPrice  float64   `json:"exit_price"` // 매도 수량 가중 평균
+	Quantity   float64   `json:"quantity"`   // 오늘 매도한 수량
+	Exits      int       `json:"exits"`      // 매도 횟수 (분할 매도 포함)
+	Reason     string    `json:"reason"`     // 마지막 매도 사유
+	PnL        float64   `json:"pnl"`        // 순손익 합계 (수수료 포함)
+	HoldingSec float64   `json:"holding_seconds"`
+	RMultiple  float64   `json:"r_multiple"`
+	HasR       bool      `json:"has_r"` // 진입 손절가를 알아 R 배수를 계산했는지
+}
+
+// BuildRoundTrips 오늘 거래 로그에서 청산된 왕복 거래 목록 (마지막 매도 시각순)
+// 진입 정보는 같은 TradeID의 매수 로그에서, 없으면 (전날 진입 등) 매도 로그에 남은 진입 정보에서 가져온다.
+// TradeID가 없는 예전 매도는 종목 + 진입 시각으로 묶는다.
+func BuildRoundTrips(trades []dailystate.TradeLog) []RoundTrip {
+	buys := make(map[string]dailystate.TradeLog)
+	for _, tr := range trades {
+		if !strings.EqualFold(tr.Side, "sell") && tr.TradeID != "" {
+			buys[tr.TradeID] = tr
+		}
+	}
+
+	byID := make(map[string]*RoundTrip)
+	var order []string
+	risk := make(map[string]float64) // 매도 수량 × (진입가 - 손절가)
+	for _, tr := range trades {
+		if !strings.EqualFold(tr.Side, "sell") {
+			continue
+		}
+		id := tr.TradeID
+		if id == "" {
+			id = trader.NewTradeID(tr.Symbol, tr.EntryTime)
+		}
+		rt, ok := byID[id]
+		if !ok {
+			rt = &RoundTrip{TradeID: id, Symbol: tr.Symbol, EntryTime: tr.EntryTime, EntryPrice: tr.EntryPrice, HasR: true}
+			if buy, ok := buys[id]; ok {
+				rt.EntryTime, rt.EntryPrice = buy.Timestamp, buy.Price
+			}
+			byID[id] = rt
+			order = append(order, id)
+		}
+		rt.ExitPrice = (rt.ExitPrice*rt.Quantity + tr.Price*tr.Quantity) / (rt.Quantity + tr.Quantity)
+		rt.Quantity += tr.Quantity
+		rt.Exits++
+		rt.PnL += tr.PnL
+		if tr.Timestamp.After(rt.ExitTime) {
+			rt.ExitTime, rt.Reason = tr.Timestamp, tr.Reason
+		}
+		if tr.EntryStop > 0 && tr.EntryStop < rt.EntryPrice {
+			risk[id] += tr.Quantity * (rt.EntryPrice - tr.EntryStop)
+		} else {
+			rt.HasR = false
+		}
+	}
+
+	out := make([]RoundTrip, 0, len(order))
+	for _, id := range order {
+		rt := byID[id]
+		if !rt.EntryTime.IsZero() {
+			rt.HoldingSec = rt.ExitTime.Sub(rt.EntryTime).Seconds()
+		}
+		if rt.HasR && risk[id] > 0 && rt.EntryPrice > 0 {
+			rt.RMultiple = rt.Quantity * (rt.ExitPrice - rt.EntryPrice) / risk[id] // 수수료 전
+		} else {
+			rt.HasR = false
+		}
+		out = append(out, *rt)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ExitTime.Before(out[j].ExitTime) })
+	return out
+}
+
+// formatHolding 보유 기간 (하루 이상이면 "2d 3h", 아니면 "3h 5m")
+func formatHolding(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	if d <= 0 {
+		return "-"
+	}
+	if days := int(d.Hours()) / 24; days > 0 {
+		return fmt.Sprintf("%dd %dh", days, int(d.Hours())%24)
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,306 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/dailystate"
+	"traveler/internal/fsutil"
+	"traveler/internal/trader"
+)
+
+// DefaultRunReportRetentionDays 세션 리포트 보관 기간 기본값 (trader.run_report_retention_days = 0)
+const DefaultRunReportRetentionDays = 90
+
+// RunReport 데몬 세션 하나의 구조화 리포트 (runs/<market>_<date>_<time>.json)
+// report_<date>.txt는 사람이 읽는 요약, 이쪽은 웹/CLI 조회용.
+type RunReport struct {
+	ID         string                `json:"id"`
+	Market     string                `json:"market"`
+	Date       string                `json:"date"` // 마켓 기준 날짜
+	Broker     string                `json:"broker"`
+	Rehearsal  bool                  `json:"rehearsal,omitempty"`
+	StartedAt  time.Time             `json:"started_at"`
+	EndedAt    time.Time             `json:"ended_at"`
+	Reason     string                `json:"reason"` // 종료 사유 (market_closed, cancelled, ...)
+	Summary    RunSummary            `json:"summary"`
+	Scan       *RunScan              `json:"scan,omitempty"`
+	Trades     []dailystate.TradeLog `json:"trades"`
+	RoundTrips []RoundTrip           `json:"round_trips,omitempty"` // 청산된 왕복 거래 (TradeID로 매수-매도 연결)
+	Errors     []RunError            `json:"errors,omitempty"`
+	Timings    []RunTiming           `json:"timings"`
+	TextReport string                `json:"text_report,omitempty"` // report_<date>.txt 경로
+}
+
+// RunSummary 세션 손익 요약 (일일 트래커 기준)
+type RunSummary struct {
+	StartingBalance float64 `json:"starting_balance"`
+	EndingBalance   float64 `json:"ending_balance"`
+	RealizedPnL     float64 `json:"realized_pnl"`
+	UnrealizedPnL   float64 `json:"unrealized_pnl"`
+	Commission      float64 `json:"commission"`
+	TotalPnL        float64 `json:"total_pnl"`
+	TotalPnLPct     float64 `json:"total_pnl_pct"`
+	Trades          int     `json:"trades"`
+	Wins            int     `json:"wins"`
+	Losses          int     `json:"losses"`
+}
+
+// RunScan 세션 스캔 요약
+type RunScan struct {
+	Scanned              int      `json:"scanned"`
+	Signals              []string `json:"signals"` // "SYMBOL strategy"
+	Universes            []string `json:"universes,omitempty"`
+	Regime               string   `json:"regime,omitempty"`
+	Strategies           []string `json:"strategies,omitempty"`
+	Decision             string   `json:"decision,omitempty"`
+	FundamentalsFiltered int      `json:"fundamentals_filtered,omitempty"`
+	AIFiltered           int      `json:"ai_filtered,omitempty"`
+	Seconds              float64  `json:"seconds"`
+}
+
+// RunError 세션 중 오류 (단계별)
+type RunError struct {
+	Time    time.Time `json:"time"`
+	Phase   string    `json:"phase"` // balance, data_freshness, scan, order, report, ...
+	Symbol  string    `json:"symbol,omitempty"`
+	Message string    `json:"message"`
+}
+
+// RunTiming 단계별 소요 시간
+type RunTiming struct {
+	Phase   string  `json:"phase"`
+	Seconds float64 `json:"seconds"`
+}
+
+// runRecorder 세션 동안 스캔/오류/소요 시간을 모은다 (장중 고루틴에서도 호출)
+type runRecorder struct {
+	mu      sync.Mutex
+	scan    *RunScan
+	errors  []RunError
+	timings []RunTiming
+}
+
+// addError 오류 기록 (err == nil이면 무시)
+func (r *runRecorder) addError(phase, symbol string, err error) {
+	if err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, RunError{Time: time.Now(), Phase: phase, Symbol: symbol, Message: err.Error()})
+}
+
+// addOrderErrors 실패/거부된 주문 기록
+func (r *runRecorder) addOrderErrors(results []trader.ExecutionResult) {
+	for _, res := range results {
+		if !res.Success && res.Error != "" {
+			r.addError("order", res.Signal.Stock.Symbol, errors.New(res.Error))
+		}
+	}
+}
+
+// addTiming start부터 지금까지를 phase 소요 시간으로 기록
+func (r *runRecorder) addTiming(phase string, start time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings = append(r.timings, RunTiming{Phase: phase, Seconds: time.Since(start).Round(time.Millisecond).Seconds()})
+}
+
+// setScan 스캔 결과 요약
+func (r *runRecorder) setScan(sr *daemonScanResult) {
+	sigs := make([]string, 0, len(sr.Signals))
+	for _, s := range sr.Signals {
+		sigs = append(sigs, s.Stock.Symbol+" "+s.Strategy)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scan = &RunScan{
+		Scanned:              sr.ScannedCount,
+		Signals:              sigs,
+		Universes:            sr.UniversesUsed,
+		Regime:               sr.Regime,
+		Strategies:           sr.ActiveStrategies,
+		Decision:             sr.Decision,
+		FundamentalsFiltered: sr.FundamentalsFiltered,
+		AIFiltered:           sr.AIFiltered,
+		Seconds:              sr.ScanTime.Round(time.Millisecond).Seconds(),
+	}
+}
+
+// RunsDir 세션 리포트 디렉토리
+func RunsDir(dataDir string) string {
+	return filepath.Join(dataDir, "runs")
+}
+
+// buildRunReport 종료 시점 세션 리포트
+func (d *Daemon) buildRunReport(reason, textReport string) *RunReport {
+	state := d.tracker.GetState()
+	ended := time.Now()
+	d.run.mu.Lock()
+	defer d.run.mu.Unlock()
+	timings := append(append([]RunTiming(nil), d.run.timings...),
+		RunTiming{Phase: "session", Seconds: ended.Sub(d.startedAt).Round(time.Millisecond).Seconds()})
+	trades := state.Trades
+	if trades == nil {
+		trades = []dailystate.TradeLog{}
+	}
+	return &RunReport{
+		ID:        fmt.Sprintf("%s_%s", d.config.Market, d.startedAt.Format("2006-01-02_150405")),
+		Market:    d.config.Market,
+		Date:      state.Date,
+		Broker:    d.broker.Name(),
+		Rehearsal: d.config.Rehearsal,
+		StartedAt: d.startedAt,
+		EndedAt:   ended,
+		Reason:    reason,
+		Summary: RunSummary{
+			StartingBalance: state.StartingBalance,
+			EndingBalance:   state.CurrentBalance,
+			RealizedPnL:     state.RealizedPnL,
+			UnrealizedPnL:   state.UnrealizedPnL,
+			Commission:      state.TotalCommission,
+			TotalPnL:        state.TotalPnL,
+			TotalPnLPct:     state.TotalPnLPct,
+			Trades:          state.TradeCount,
+			Wins:            state.WinCount,
+			Losses:          state.LossCount,
+		},
+		Scan:       d.run.scan,
+		Trades:     trades,
+		RoundTrips: BuildRoundTrips(trades),
+		Errors:     append([]RunError(nil), d.run.errors...),
+		Timings:    timings,
+		TextReport: textReport,
+	}
+}
+
+// SaveRunReport runs/<id>.json 저장
+func SaveRunReport(dataDir string, rep *RunReport) (string, error) {
+	dir := RunsDir(dataDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, rep.ID+".json")
+	return path, fsutil.WriteFile(path, data, 0644)
+}
+
+// LoadRunReport 저장된 세션 리포트 (id = 파일 이름에서 .json 제외)
+func LoadRunReport(dataDir, id string) (*RunReport, error) {
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return nil, fmt.Errorf("invalid run report id %q", id)
+	}
+	data, err := os.ReadFile(filepath.Join(RunsDir(dataDir), id+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var rep RunReport
+	if err := json.Unmarshal(data, &rep); err != nil {
+		return nil, fmt.Errorf("parse run report %s: %w", id, err)
+	}
+	return &rep, nil
+}
+
+// RunReportInfo 목록 항목
+type RunReportInfo struct {
+	ID          string    `json:"id"`
+	Market      string    `json:"market"`
+	Date        string    `json:"date"`
+	Rehearsal   bool      `json:"rehearsal,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	EndedAt     time.Time `json:"ended_at"`
+	Reason      string    `json:"reason"`
+	TotalPnL    float64   `json:"total_pnl"`
+	TotalPnLPct float64   `json:"total_pnl_pct"`
+	Trades      int       `json:"trades"`
+	Signals     int       `json:"signals"`
+	Errors      int       `json:"errors"`
+}
+
+// ListRunReports 세션 리포트 목록 (최신순, market이 비어 있으면 전체, 읽을 수 없는 파일은 건너뜀)
+func ListRunReports(dataDir, market string) ([]RunReportInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(RunsDir(dataDir), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]RunReportInfo, 0, len(matches))
+	for _, path := range matches {
+		rep, err := LoadRunReport(dataDir, strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil || (market != "" && rep.Market != market) {
+			continue
+		}
+		info := RunReportInfo{
+			ID:          rep.ID,
+			Market:      rep.Market,
+			Date:        rep.Date,
+			Rehearsal:   rep.Rehearsal,
+			StartedAt:   rep.StartedAt,
+			EndedAt:     rep.EndedAt,
+			Reason:      rep.Reason,
+			TotalPnL:    rep.Summary.TotalPnL,
+			TotalPnLPct: rep.Summary.TotalPnLPct,
+			Trades:      rep.Summary.Trades,
+			Errors:      len(rep.Errors),
+		}
+		if rep.Scan != nil {
+			info.Signals = len(rep.Scan.Signals)
+		}
+		out = append(out, info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartedAt.After(out[j].StartedAt) })
+	return out, nil
+}
+
+// PruneRunReports 보관 기간이 지난 세션 리포트 삭제 (retentionDays: 0 = 기본 90일, 음수 = 삭제 안 함)
+func PruneRunReports(dataDir string, retentionDays int, now time.Time) (int, error) {
+	if retentionDays < 0 {
+		return 0, nil
+	}
+	if retentionDays == 0 {
+		retentionDays = DefaultRunReportRetentionDays
+	}
+	matches, err := filepath.Glob(filepath.Join(RunsDir(dataDir), "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	cutoff := now.AddDate(0, 0, -retentionDays)
+	removed := 0
+	for _, path := range matches {
+		fi, err := os.Stat(path)
+		if err != nil || !fi.ModTime().Before(cutoff) {
+			continue
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// saveRunReport 세션 종료 시 구조화 리포트 저장 + 보관 기간 정리
+func (d *Daemon) saveRunReport(reason, textReport string) {
+	rep := d.buildRunReport(reason, textReport)
+	path, err := SaveRunReport(d.tracker.dataDir, rep)
+	if err != nil {
+		log.Printf("[DAEMON] Failed to save run report: %v", err)
+		return
+	}
+	log.Printf("[DAEMON] Run report saved: %s", path)
+	if n, err := PruneRunReports(d.tracker.dataDir, d.config.RunReportRetentionDays, time.Now()); err != nil {
+		log.Printf("[DAEMON] Failed to prune run reports: %v", err)
+	} else if n > 0 {
+		log.Printf("[DAEMON] Pruned %d run report(s) older than retention", n)
+	}
+}
@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"traveler/internal/i18n"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+)
+
+// reconcileAtStartup 브로커 포지션/미체결 주문을 plans.json·주문 저널과 대조
+// 확인되지 않은 불일치가 있으면 안전 모드: 손절/익절 감시는 계속하고 신규 매수만 보류한다.
+func (d *Daemon) reconcileAtStartup(dataDir string, ps *trader.PlanStore, journal *trader.OrderJournal) {
+	if strings.HasPrefix(d.broker.Name(), "sim-") {
+		return
+	}
+	d.reconcile = trader.NewReconcileStore(dataDir, d.config.Market)
+	report, err := trader.Reconcile(d.ctx, d.broker, ps, journal, d.config.Market)
+	if err != nil {
+		log.Printf("[RECONCILE] Skipped: %v", err)
+		return
+	}
+	if err := d.reconcile.Save(report); err != nil {
+		log.Printf("[RECONCILE] Failed to save report: %v", err)
+	}
+
+	log.Printf("[RECONCILE] %d positions, %d pending orders, %d plans → %d issue(s)",
+		report.BrokerPositions, report.PendingOrders, report.Plans, len(report.Issues))
+	for _, is := range report.Issues {
+		log.Printf("  - [%s] %s: %s", is.Kind, is.Symbol, is.Detail)
+	}
+	if !report.NeedsAck() {
+		if len(report.Issues) > 0 {
+			log.Printf("[RECONCILE] Same issues already acknowledged at %s", report.AckedAt.Format("2006-01-02 15:04"))
+		}
+		return
+	}
+
+	d.safeMode.Store(true)
+	log.Printf("[RECONCILE] Safe mode: new entries paused until acknowledged (traveler reconcile ack --market %s)", d.config.Market)
+	var b strings.Builder
+	b.WriteString(i18n.T("alert.reconcile", strings.ToUpper(d.config.Market), len(report.Issues)) + "\n")
+	for _, is := range report.Issues {
+		fmt.Fprintf(&b, "• %s %s: %s\n", is.Kind, is.Symbol, is.Detail)
+	}
+	b.WriteString(i18n.T("alert.reconcile_ack", d.config.Market))
+	d.notifyTelegram(context.Background(), b.String())
+}
+
+// checkSafeMode 모니터 사이클마다 확인 여부 점검 → 확인되면 신규 매수 재개
+func (d *Daemon) checkSafeMode() {
+	if !d.safeMode.Load() || d.reconcile == nil {
+		return
+	}
+	report, err := d.reconcile.Load()
+	if err != nil || report.NeedsAck() {
+		return
+	}
+	d.safeMode.Store(false)
+	log.Printf("[RECONCILE] Report acknowledged at %s — resuming new entries", report.AckedAt.Format("15:04:05"))
+}
+
+// entryGate AutoTrader 신규 매수 허용 여부
+func (d *Daemon) entryGate() error {
+	if d.safeMode.Load() {
+		return fmt.Errorf("safe mode: startup reconciliation not acknowledged")
+	}
+	if reason, _ := d.staleData.Load().(string); reason != "" {
+		return fmt.Errorf("%s", reason)
+	}
+	if reason, _ := d.listsDown.Load().(string); reason != "" {
+		return fmt.Errorf("%s", reason)
+	}
+	return nil
+}
+
+// loadSymbolLists 블랙/화이트리스트 로드
+// 실패하면 목록 없이 매수하지 않도록 사유를 남겨 이번 세션의 신규 매수를 막는다 (재시작하면 다시 로드)
+func (d *Daemon) loadSymbolLists(dataDir string) (*symbols.SymbolLists, error) {
+	lists, err := symbols.NewSymbolLists(dataDir)
+	if err != nil {
+		err = fmt.Errorf("symbol lists unavailable: %w", err)
+		d.listsDown.Store(err.Error())
+		return nil, err
+	}
+	return lists, nil
+}
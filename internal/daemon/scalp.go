@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"traveler/internal/broker"
+	"traveler/internal/fsutil"
 	"traveler/internal/notify"
 	"traveler/internal/strategy"
 )
@@ -598,7 +599,7 @@ func (d *ScalpDaemon) saveState() {
 		return
 	}
 
-	if err := os.WriteFile(d.statePath(), data, 0644); err != nil {
+	if err := fsutil.WriteFile(d.statePath(), data, 0644); err != nil {
 		log.Printf("[SCALP] Failed to save state: %v", err)
 	}
 }
@@ -653,7 +654,7 @@ func (d *ScalpDaemon) saveStatusJSON() {
 		return
 	}
 
-	if err := os.WriteFile(d.statusPath(), data, 0644); err != nil {
+	if err := fsutil.WriteFile(d.statusPath(), data, 0644); err != nil {
 		log.Printf("[SCALP] Failed to save status: %v", err)
 	}
 }
@@ -0,0 +1,185 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+)
+
+// maxScanMetricDays 마켓별 보관 일수 (초과분은 오래된 날부터 삭제)
+const maxScanMetricDays = 1000
+
+// ScanMetric 하루 스캔 품질 (scan_metrics_<market>.json의 한 항목)
+// 같은 날 다시 스캔하면 (--force-scan, 재시작) 마지막 스캔 값으로 덮고 Scans만 늘린다.
+type ScanMetric struct {
+	Date                 string    `json:"date"` // 마켓 기준 날짜
+	Market               string    `json:"market"`
+	Scans                int       `json:"scans"`
+	Scanned              int       `json:"scanned"`
+	Universes            []string  `json:"universes,omitempty"`
+	Expansions           int       `json:"expansions"`
+	Decision             string    `json:"decision"`   // trade, expanded, trade_low_quality, skip
+	Candidates           int       `json:"candidates"` // 적응형 품질 평가 시점 시그널 수 (AI/VIX 필터 전)
+	Signals              int       `json:"signals"`    // 최종 시그널 수 (사이징 후)
+	AvgProb              float64   `json:"avg_prob"`
+	MinProb              float64   `json:"min_prob"`
+	MaxProb              float64   `json:"max_prob"`
+	AvgRR                float64   `json:"avg_rr"`
+	FundamentalsFiltered int       `json:"fundamentals_filtered"`
+	AIFiltered           int       `json:"ai_filtered"`
+	Regime               string    `json:"regime,omitempty"`
+	Seconds              float64   `json:"seconds"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// ScanTrend 최근 구간과 그 직전 구간의 평균 비교 ("좋은 자리가 줄고 있나")
+type ScanTrend struct {
+	Window           int     `json:"window"` // 구간 길이 (스캔한 날 수)
+	RecentDays       int     `json:"recent_days"`
+	PriorDays        int     `json:"prior_days"`
+	RecentSignals    float64 `json:"recent_avg_signals"`
+	PriorSignals     float64 `json:"prior_avg_signals"`
+	RecentCandidates float64 `json:"recent_avg_candidates"`
+	PriorCandidates  float64 `json:"prior_avg_candidates"`
+	RecentProb       float64 `json:"recent_avg_prob"` // 시그널 있는 날만 평균
+	PriorProb        float64 `json:"prior_avg_prob"`
+	RecentSkipPct    float64 `json:"recent_skip_pct"` // skip 결정 비율
+	PriorSkipPct     float64 `json:"prior_skip_pct"`
+}
+
+var scanMetricsMu sync.Mutex
+
+// ScanMetricsPath 마켓별 스캔 품질 파일
+func ScanMetricsPath(dataDir, market string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("scan_metrics_%s.json", market))
+}
+
+// LoadScanMetrics 날짜순 스캔 품질 시계열 (파일이 없으면 빈 목록)
+func LoadScanMetrics(dataDir, market string) ([]ScanMetric, error) {
+	data, err := os.ReadFile(ScanMetricsPath(dataDir, market))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var metrics []ScanMetric
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return nil, err
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Date < metrics[j].Date })
+	return metrics, nil
+}
+
+// RecordScanMetric 오늘 항목 추가/갱신
+func RecordScanMetric(dataDir string, m ScanMetric) error {
+	scanMetricsMu.Lock()
+	defer scanMetricsMu.Unlock()
+
+	metrics, err := LoadScanMetrics(dataDir, m.Market)
+	if err != nil {
+		return err
+	}
+	m.Scans = 1
+	replaced := false
+	for i := range metrics {
+		if metrics[i].Date == m.Date {
+			m.Scans = metrics[i].Scans + 1
+			metrics[i] = m
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		metrics = append(metrics, m)
+		sort.Slice(metrics, func(i, j int) bool { return metrics[i].Date < metrics[j].Date })
+	}
+	if n := len(metrics); n > maxScanMetricDays {
+		metrics = metrics[n-maxScanMetricDays:]
+	}
+	data, err := json.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(ScanMetricsPath(dataDir, m.Market), data, 0644)
+}
+
+// BuildScanTrend 마지막 window일과 그 전 window일 비교 (window <= 0이면 20)
+func BuildScanTrend(metrics []ScanMetric, window int) ScanTrend {
+	if window <= 0 {
+		window = 20
+	}
+	t := ScanTrend{Window: window}
+	end := len(metrics)
+	mid := end - window
+	if mid < 0 {
+		mid = 0
+	}
+	start := mid - window
+	if start < 0 {
+		start = 0
+	}
+	t.RecentDays, t.RecentSignals, t.RecentCandidates, t.RecentProb, t.RecentSkipPct = scanAverages(metrics[mid:end])
+	t.PriorDays, t.PriorSignals, t.PriorCandidates, t.PriorProb, t.PriorSkipPct = scanAverages(metrics[start:mid])
+	return t
+}
+
+// scanAverages 구간 평균 (일수, 시그널, 후보, 확률, skip 비율 %)
+func scanAverages(ms []ScanMetric) (days int, signals, candidates, prob, skipPct float64) {
+	if len(ms) == 0 {
+		return 0, 0, 0, 0, 0
+	}
+	var probDays, skips int
+	for _, m := range ms {
+		signals += float64(m.Signals)
+		candidates += float64(m.Candidates)
+		if m.Candidates > 0 {
+			prob += m.AvgProb
+			probDays++
+		}
+		if m.Decision == "skip" {
+			skips++
+		}
+	}
+	n := float64(len(ms))
+	if probDays > 0 {
+		prob /= float64(probDays)
+	}
+	return len(ms), signals / n, candidates / n, prob, float64(skips) / n * 100
+}
+
+// recordScanMetric 프리마켓 스캔 결과를 일별 품질 시계열에 기록
+func (d *Daemon) recordScanMetric(sr *daemonScanResult) {
+	m := ScanMetric{
+		Date:                 d.tracker.GetState().Date,
+		Market:               d.config.Market,
+		Scanned:              sr.ScannedCount,
+		Universes:            sr.UniversesUsed,
+		Expansions:           sr.Expansions,
+		Decision:             sr.Decision,
+		Candidates:           sr.Quality.SignalCount,
+		Signals:              len(sr.Signals),
+		AvgProb:              sr.Quality.AvgProb,
+		MinProb:              sr.Quality.MinProb,
+		MaxProb:              sr.Quality.MaxProb,
+		AvgRR:                sr.Quality.AvgRR,
+		FundamentalsFiltered: sr.FundamentalsFiltered,
+		AIFiltered:           sr.AIFiltered,
+		Regime:               sr.Regime,
+		Seconds:              sr.ScanTime.Round(time.Millisecond).Seconds(),
+		UpdatedAt:            time.Now(),
+	}
+	if m.Date == "" {
+		m.Date = time.Now().Format("2006-01-02")
+	}
+	if err := RecordScanMetric(d.tracker.dataDir, m); err != nil {
+		log.Printf("[DAEMON] Failed to record scan metrics: %v", err)
+	}
+}
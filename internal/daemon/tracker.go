@@ -3,10 +3,19 @@ package daemon
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
+
+	"traveler/internal/dailystate"
+	"traveler/internal/fees"
+	"traveler/internal/fsutil"
+	"traveler/internal/i18n"
+	"traveler/internal/money"
+	"traveler/internal/trader"
 )
 
 // DailyConfig 일일 거래 설정
@@ -14,60 +23,31 @@ type DailyConfig struct {
 	TargetPct     float64 // 일일 목표 수익률 (예: 1.0 = 1%)
 	LossLimitPct  float64 // 일일 최대 손실 (예: -2.0 = -2%)
 	MaxTrades     int     // 일일 최대 거래 횟수
+	CashFlowThresholdPct float64 // 거래로 설명 안 되는 현금 변화가 시작 잔고의 이 % 이상이면 입출금으로 간주
 }
 
+// cashFlowSettleWindow 주문 직후 잔고 반영 지연 — 이 시간 안에 거래가 있었으면 판정 보류
+const cashFlowSettleWindow = 3 * time.Minute
+
 // DefaultDailyConfig 기본 설정
 func DefaultDailyConfig() DailyConfig {
 	return DailyConfig{
 		TargetPct:    1.0,  // 1% 목표
 		LossLimitPct: -2.0, // -2% 손절
 		MaxTrades:    10,   // 최대 10회
+		CashFlowThresholdPct: 5.0,
 	}
 }
 
-// TradeLog 거래 로그
-type TradeLog struct {
-	Timestamp   time.Time `json:"timestamp"`
-	Symbol      string    `json:"symbol"`
-	Side        string    `json:"side"` // "BUY" or "SELL"
-	Quantity    float64   `json:"quantity"`
-	Price       float64   `json:"price"`
-	Amount      float64   `json:"amount"`
-	Commission  float64   `json:"commission"` // 수수료
-	OrderID     string    `json:"order_id,omitempty"`
-	Reason      string    `json:"reason,omitempty"` // "signal", "stop_loss", "take_profit", "manual"
-}
-
-// DefaultCommissionRate 기본 수수료율 (config에서 덮어쓸 수 있음)
-var DefaultCommissionRate = 0.0025 // 0.25%
-
-// DailyState 일일 상태
-type DailyState struct {
-	Date            string      `json:"date"`
-	StartingBalance float64     `json:"starting_balance"`
-	CurrentBalance  float64     `json:"current_balance"`
-	RealizedPnL     float64     `json:"realized_pnl"`
-	UnrealizedPnL   float64     `json:"unrealized_pnl"`
-	TotalCommission float64     `json:"total_commission"` // 총 수수료
-	TotalPnL        float64     `json:"total_pnl"`        // 수수료 차감 후
-	TotalPnLPct     float64     `json:"total_pnl_pct"`
-	TradeCount      int         `json:"trade_count"`
-	ScanDone        bool        `json:"scan_done"`         // true if scan was already completed today
-	WinCount        int         `json:"win_count"`
-	LossCount       int         `json:"loss_count"`
-	Trades          []TradeLog  `json:"trades"`
-	Status          string      `json:"status"` // "running", "target_reached", "loss_limit", "market_closed", "error"
-	StartTime       time.Time   `json:"start_time"`
-	EndTime         time.Time   `json:"end_time,omitempty"`
-}
-
 // DailyTracker 일일 P&L 추적기
 type DailyTracker struct {
 	config   DailyConfig
-	state    DailyState
+	state    dailystate.DailyState
 	dataDir  string
 	market   string         // "us" or "kr" — 파일 분리용
 	tz       *time.Location // 마켓 타임존 (nil이면 로컬)
+	banner   string         // 리포트 머리말 (리허설 표시 등, 비어 있으면 생략)
+	backfill bool           // 기준 잔고를 baseline 파일에서 복원 → 재시작 전 거래를 trade_history에서 채워야 함
 	mu       sync.RWMutex
 }
 
@@ -95,6 +75,13 @@ func (t *DailyTracker) SetTimezone(tz *time.Location) {
 	t.tz = tz
 }
 
+// SetBanner 리포트 제목 아래에 붙일 한 줄 (예: 리허설 모드 표시)
+func (t *DailyTracker) SetBanner(banner string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.banner = banner
+}
+
 // marketDate 마켓 기준 오늘 날짜
 func (t *DailyTracker) marketDate() string {
 	now := time.Now()
@@ -110,27 +97,50 @@ func (t *DailyTracker) Start(startingBalance float64) error {
 	defer t.mu.Unlock()
 
 	today := t.marketDate()
+	now := time.Now()
 
-	// 이미 오늘 데이터가 있는지 확인
+	// 이미 오늘 데이터가 있는지 확인 (기준 잔고 없이 저장된 상태 = 잔고 조회 전에 끝난 세션 → 새로 잡는다)
 	existing, err := t.loadState(today)
-	if err == nil && existing != nil {
-		// 기존 상태 복원
+	if err == nil && existing != nil && existing.StartingBalance > 0 {
+		// 기존 상태 복원: 기준 잔고와 재시작 전 거래는 그대로 유지
 		t.state = *existing
+		if t.state.BaselineAt.IsZero() {
+			t.state.BaselineAt, t.state.BaselineSource = t.state.StartTime, baselineSessionStart
+		}
 		t.state.CurrentBalance = startingBalance // 현재 잔고 업데이트
 		t.state.Status = "running"               // 재시작 시 status 리셋
-		return nil
+		t.state.EndTime = time.Time{}
+		t.state.Restarts = append(t.state.Restarts, dailystate.SessionRestart{Time: now, Equity: startingBalance})
+		return t.saveState()
 	}
 
 	// 새로운 상태 시작
-	t.state = DailyState{
+	t.state = dailystate.DailyState{
 		Date:            today,
 		StartingBalance: startingBalance,
 		CurrentBalance:  startingBalance,
-		Trades:          make([]TradeLog, 0),
+		Trades:          make([]dailystate.TradeLog, 0),
 		Status:          "running",
-		StartTime:       time.Now(),
+		StartTime:       now,
+		BaselineAt:      now,
+		BaselineSource:  baselineSessionStart,
+	}
+	if existing != nil {
+		t.state.ScanDone = existing.ScanDone
 	}
 
+	// 상태 파일이 없어도 오늘 기준 잔고가 baseline 파일에 있으면 그것으로 복원 (재시작 전 거래는 BackfillTrades)
+	if b, err := t.loadBaseline(); err == nil && b.Date == today && b.Equity > 0 {
+		t.state.StartingBalance = b.Equity
+		t.state.StartTime, t.state.BaselineAt = b.At, b.At
+		t.state.BaselineSource = baselineFromFile
+		t.state.Restarts = []dailystate.SessionRestart{{Time: now, Equity: startingBalance}}
+		t.backfill = true
+		return t.saveState()
+	}
+	if err := t.saveBaseline(SessionBaseline{Market: t.market, Date: today, Equity: startingBalance, At: now}); err != nil {
+		return err
+	}
 	return t.saveState()
 }
 
@@ -146,7 +156,7 @@ func (t *DailyTracker) EnsureDate() {
 }
 
 // RecordTrade 거래 기록
-func (t *DailyTracker) RecordTrade(log TradeLog) error {
+func (t *DailyTracker) RecordTrade(log dailystate.TradeLog) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -154,14 +164,46 @@ func (t *DailyTracker) RecordTrade(log TradeLog) error {
 
 	// 수수료 계산 (설정 안 됐으면 자동 계산)
 	if log.Commission == 0 {
-		log.Commission = log.Amount * DefaultCommissionRate
+		log.Commission = t.fee(log.Symbol).Cost(log.Side, log.Amount)
 	}
 
+	t.addTrade(log)
+
+	return t.saveState()
+}
+
+// fee 거래 수수료 체계 (마켓 미설정 트래커는 심볼로 마켓 판별)
+func (t *DailyTracker) fee(symbol string) fees.Schedule {
+	if t.market != "" {
+		return fees.ForMarket(t.market)
+	}
+	return fees.ForMarket(trader.PlanMarket(symbol))
+}
+
+// addTrade 거래 추가 + 집계 (매도는 순손익 부호로 승/패, 본전은 어느 쪽도 아님)
+func (t *DailyTracker) addTrade(log dailystate.TradeLog) {
 	t.state.Trades = append(t.state.Trades, log)
 	t.state.TradeCount++
 	t.state.TotalCommission += log.Commission
+	if strings.EqualFold(log.Side, "sell") {
+		switch {
+		case log.PnL > 0:
+			t.state.WinCount++
+		case log.PnL < 0:
+			t.state.LossCount++
+		}
+	}
+}
 
-	return t.saveState()
+// entryCount 오늘 신규 진입(매수) 횟수
+func (t *DailyTracker) entryCount() int {
+	n := 0
+	for _, tr := range t.state.Trades {
+		if !strings.EqualFold(tr.Side, "sell") {
+			n++
+		}
+	}
+	return n
 }
 
 // UpdatePnL P&L 업데이트
@@ -176,13 +218,64 @@ func (t *DailyTracker) UpdatePnL(realizedPnL, unrealizedPnL, currentBalance floa
 	// 수수료 차감한 순 P&L
 	t.state.TotalPnL = realizedPnL + unrealizedPnL - t.state.TotalCommission
 
-	if t.state.StartingBalance > 0 {
-		t.state.TotalPnLPct = (t.state.TotalPnL / t.state.StartingBalance) * 100
+	// 입출금은 수익률 분모에 반영 (장중 입금이 +20% 수익일로 잡히지 않도록)
+	if base := t.state.StartingBalance + t.state.NetCashFlow; base > 0 {
+		t.state.TotalPnLPct = (t.state.TotalPnL / base) * 100
 	}
 
 	t.saveState()
 }
 
+// SetReconciliation 체결내역 대조 결과 저장
+func (t *DailyTracker) SetReconciliation(r dailystate.FillReconciliation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state.Reconciliation = &r
+	t.saveState()
+}
+
+// ObserveCash 현금 잔고 관측 → 거래로 설명되지 않는 변화를 입출금으로 기록.
+// 기대 변화 = 직전 관측 이후 기록된 거래의 현금 흐름 (매수 -금액-수수료, 매도 +금액-수수료).
+// 최근 거래가 잔고에 아직 반영 안 됐을 수 있으므로 settle window 동안은 판정을 미룬다.
+func (t *DailyTracker) ObserveCash(cash float64) (dailystate.CashFlow, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := &t.state
+	if s.LastCash == 0 || s.LastCashTrades > len(s.Trades) {
+		s.LastCash = cash
+		s.LastCashTrades = len(s.Trades)
+		return dailystate.CashFlow{}, false
+	}
+
+	var expected float64
+	for _, tr := range s.Trades[s.LastCashTrades:] {
+		if time.Since(tr.Timestamp) < cashFlowSettleWindow {
+			return dailystate.CashFlow{}, false
+		}
+		if strings.EqualFold(tr.Side, "sell") {
+			expected += tr.Amount - tr.Commission
+		} else {
+			expected -= tr.Amount + tr.Commission
+		}
+	}
+
+	unexplained := (cash - s.LastCash) - expected
+	threshold := s.StartingBalance * t.config.CashFlowThresholdPct / 100
+	flow := dailystate.CashFlow{Time: time.Now(), Amount: unexplained, CashBefore: s.LastCash, CashAfter: cash}
+	s.LastCash = cash
+	s.LastCashTrades = len(s.Trades)
+
+	if threshold <= 0 || math.Abs(unexplained) < threshold {
+		return dailystate.CashFlow{}, false
+	}
+	s.CashFlows = append(s.CashFlows, flow)
+	s.NetCashFlow += unexplained
+	t.saveState()
+	return flow, true
+}
+
 // CheckTargets 목표/한도 체크
 type TargetCheckResult struct {
 	TargetReached   bool
@@ -204,11 +297,11 @@ func (t *DailyTracker) CheckTargets() TargetCheckResult {
 	// 일일 목표/손실한도 제거됨 — 스윙 트레이딩에서는 개별 종목 TP/SL로 리스크 관리
 	// (소액 계좌에서 1% 목표는 한 틱에 도달하여 인트라데이 기회 차단)
 
-	// 최대 거래 횟수
-	if t.state.TradeCount >= t.config.MaxTrades {
+	// 최대 거래 횟수 (신규 진입 기준 — 청산 기록이 쌓여 모니터링이 멈추면 안 된다)
+	if entries := t.entryCount(); entries >= t.config.MaxTrades {
 		result.MaxTradesHit = true
 		result.ShouldStop = true
-		result.Reason = fmt.Sprintf("max trades reached: %d >= %d", t.state.TradeCount, t.config.MaxTrades)
+		result.Reason = fmt.Sprintf("max trades reached: %d >= %d", entries, t.config.MaxTrades)
 	}
 
 	return result
@@ -227,7 +320,7 @@ func (t *DailyTracker) SetStatus(status string) {
 }
 
 // GetState 현재 상태 조회
-func (t *DailyTracker) GetState() DailyState {
+func (t *DailyTracker) GetState() dailystate.DailyState {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 	return t.state
@@ -260,17 +353,17 @@ func (t *DailyTracker) saveState() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(t.stateFilePath(t.state.Date), data, 0644)
+	return fsutil.WriteFile(t.stateFilePath(t.state.Date), data, 0644) // 웹이 읽는 중에도 온전한 파일
 }
 
 // 상태 로드
-func (t *DailyTracker) loadState(date string) (*DailyState, error) {
+func (t *DailyTracker) loadState(date string) (*dailystate.DailyState, error) {
 	data, err := os.ReadFile(t.stateFilePath(date))
 	if err != nil {
 		return nil, err
 	}
 
-	var state DailyState
+	var state dailystate.DailyState
 	if err := json.Unmarshal(data, &state); err != nil {
 		return nil, err
 	}
@@ -284,63 +377,100 @@ func (t *DailyTracker) GenerateReport() string {
 	defer t.mu.RUnlock()
 
 	s := t.state
+	cur := money.ForMarket(t.market)
+
+	report := "\n" + reportRule + "\n" +
+		"                         " + i18n.T("report.title") + "\n" +
+		"                         " + s.Date + "\n" +
+		reportRule + "\n" + reportBanner(t.banner) + "\n"
+
+	report += reportSection("report.summary") +
+		reportRow("report.status", s.Status) +
+		reportRow("report.starting_balance", money.Format(s.StartingBalance, cur)) +
+		reportRow("report.current_balance", money.Format(s.CurrentBalance, cur)) +
+		reportRow("report.realized", money.Signed(s.RealizedPnL, cur)) +
+		reportRow("report.unrealized", money.Signed(s.UnrealizedPnL, cur)) +
+		reportRow("report.commission", fmt.Sprintf("%s (%.2f%%)",
+			money.Format(s.TotalCommission, cur), commissionPct(s.TotalCommission, s.StartingBalance))) +
+		reportRow("report.net_pnl", fmt.Sprintf("%s (%.2f%%)", money.Signed(s.TotalPnL, cur), s.TotalPnLPct)) +
+		reportRow("report.cash_flow", money.Signed(s.NetCashFlow, cur)+" "+i18n.T("report.cash_flow_note")) +
+		"\n"
+
+	report += reportSection("report.statistics") +
+		reportRow("report.total_trades", fmt.Sprintf("%d", s.TradeCount)) +
+		reportRow("report.wins", fmt.Sprintf("%d", s.WinCount)) +
+		reportRow("report.losses", fmt.Sprintf("%d", s.LossCount)) +
+		reportRow("report.win_rate", fmt.Sprintf("%.1f%%", winRate(s.WinCount, s.LossCount))) +
+		"\n"
+
+	report += reportSection("report.time") +
+		reportRow("report.start", s.StartTime.Format("15:04:05")) +
+		reportRow("report.end", formatEndTime(s.EndTime)) +
+		reportRow("report.duration", formatDuration(s.StartTime, s.EndTime)) +
+		"\n"
+
+	if r := s.Reconciliation; r != nil {
+		report += reportSection("report.reconciliation") +
+			reportRow("report.fill_realized", fmt.Sprintf("%s (%s)", money.Signed(r.FillPnL, cur),
+				i18n.T("report.fill_closed", r.ClosedTrades, r.Wins, r.Losses))) +
+			reportRow("report.equity_estimate", money.Signed(r.EquityPnL, cur)) +
+			reportRow("report.difference", money.Signed(r.Difference, cur))
+		if len(r.Unmatched) > 0 {
+			report += reportRow("report.unmatched", fmt.Sprintf("%v %s", r.Unmatched, i18n.T("report.unmatched_note")))
+		}
+		report += "\n"
+	}
+
+	if len(s.Restarts) > 0 {
+		report += reportTitle(i18n.T("report.restarts",
+			money.Format(s.StartingBalance, cur), s.BaselineAt.Format("15:04:05"), s.BaselineSource))
+		for _, r := range s.Restarts {
+			report += fmt.Sprintf("  [%s] %s\n", r.Time.Format("15:04:05"), i18n.T("report.restart_equity", money.Format(r.Equity, cur)))
+		}
+		report += "\n"
+	}
 
-	report := fmt.Sprintf(`
-================================================================================
-                         DAILY TRADING REPORT
-                         %s
-================================================================================
-
-SUMMARY
--------
-  Status:           %s
-  Starting Balance: $%.2f
-  Current Balance:  $%.2f
-  Realized P&L:     $%.2f
-  Unrealized P&L:   $%.2f
-  Commission:       $%.2f (%.2f%%)
-  Net P&L:          $%.2f (%.2f%%)
-
-STATISTICS
-----------
-  Total Trades:     %d
-  Winning Trades:   %d
-  Losing Trades:    %d
-  Win Rate:         %.1f%%
-
-TIME
-----
-  Start:            %s
-  End:              %s
-  Duration:         %s
-
-`, s.Date, s.Status,
-		s.StartingBalance, s.CurrentBalance,
-		s.RealizedPnL, s.UnrealizedPnL,
-		s.TotalCommission, commissionPct(s.TotalCommission, s.StartingBalance),
-		s.TotalPnL, s.TotalPnLPct,
-		s.TradeCount, s.WinCount, s.LossCount,
-		winRate(s.WinCount, s.LossCount),
-		s.StartTime.Format("15:04:05"),
-		formatEndTime(s.EndTime),
-		formatDuration(s.StartTime, s.EndTime))
+	if trips := BuildRoundTrips(s.Trades); len(trips) > 0 {
+		report += reportSection("report.round_trips")
+		for _, rt := range trips {
+			r := "    -"
+			if rt.HasR {
+				r = fmt.Sprintf("%+5.2fR", rt.RMultiple)
+			}
+			report += fmt.Sprintf("  %-10s %s → %s  %s %-7s %s  P&L %s  (%s, %s)\n",
+				rt.Symbol,
+				money.Price(rt.EntryPrice, cur),
+				money.Price(rt.ExitPrice, cur),
+				i18n.T("report.held"),
+				formatHolding(rt.HoldingSec),
+				r,
+				money.Signed(rt.PnL, cur),
+				rt.Reason,
+				rt.TradeID)
+		}
+		report += "\n"
+	}
 
 	if len(s.Trades) > 0 {
-		report += "TRADES\n------\n"
+		report += reportSection("report.trades")
 		for i, trade := range s.Trades {
-			report += fmt.Sprintf("  %d. [%s] %s %s x%.4g @ $%.2f = $%.2f (%s)\n",
+			report += fmt.Sprintf("  %d. [%s] %s %s x%.4g @ %s = %s (%s)",
 				i+1,
 				trade.Timestamp.Format("15:04:05"),
 				trade.Side,
 				trade.Symbol,
 				trade.Quantity,
-				trade.Price,
-				trade.Amount,
+				money.Price(trade.Price, cur),
+				money.Format(trade.Amount, cur),
 				trade.Reason)
+			if strings.EqualFold(trade.Side, "sell") {
+				report += " P&L " + money.Signed(trade.PnL, cur)
+			}
+			report += "\n"
 		}
 	}
 
-	report += "\n================================================================================"
+	report += "\n" + reportRule
 
 	return report
 }
@@ -359,6 +489,32 @@ func (t *DailyTracker) SaveReport() (string, error) {
 	return filepath, nil
 }
 
+// reportRule 리포트 머리/꼬리 구분선
+var reportRule = strings.Repeat("=", 80)
+
+// reportTitle 섹션 제목 + 밑줄 (표시 폭 기준)
+func reportTitle(title string) string {
+	return title + "\n" + strings.Repeat("-", i18n.Width(title)) + "\n"
+}
+
+// reportSection 카탈로그 키로 섹션 제목
+func reportSection(key string) string {
+	return reportTitle(i18n.T(key))
+}
+
+// reportRow "  라벨:            값" (라벨 열 18칸, 한글은 2칸으로 계산)
+func reportRow(key, value string) string {
+	return "  " + i18n.Pad(i18n.T(key)+":", 18) + value + "\n"
+}
+
+// reportBanner 머리말 블록 (비어 있으면 빈 문자열)
+func reportBanner(banner string) string {
+	if banner == "" {
+		return ""
+	}
+	return "  " + banner + "\n"
+}
+
 func winRate(wins, losses int) float64 {
 	total := wins + losses
 	if total == 0 {
@@ -376,7 +532,7 @@ func commissionPct(commission, balance float64) float64 {
 
 func formatEndTime(t time.Time) string {
 	if t.IsZero() {
-		return "(running)"
+		return i18n.T("report.running")
 	}
 	return t.Format("15:04:05")
 }
@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wakeLead 다음 개장 몇 분 전에 깨울지 (부팅 + 프리마켓 스캔 여유, KR 예약 작업 08:40 KST와 같은 간격)
+const wakeLead = 20 * time.Minute
+
+// NextWakeTime 절전 후 깨울 시각: US/KR 중 먼저 오는 정규장 개장 - wakeLead
+// 한 PC에서 두 데몬을 돌리므로 어느 마켓 데몬이 절전시키든 다음 세션에 맞춰 깨운다.
+// 개장이 wakeLead 안으로 다가왔으면 1분 뒤.
+func NextWakeTime(now time.Time) time.Time {
+	us := marketStatusAt(now, GetETLocation(), DefaultMarketSchedule(), IsUSHoliday)
+	kr := marketStatusAt(now, GetKSTLocation(), KRMarketSchedule(), IsKRHoliday)
+	next := us.NextOpen
+	if kr.NextOpen.Before(next) {
+		next = kr.NextOpen
+	}
+	wake := next.Add(-wakeLead)
+	if earliest := now.Add(time.Minute); wake.Before(earliest) {
+		wake = earliest
+	}
+	return wake
+}
+
+// pmsetWakeArgs macOS 1회성 깨우기 예약 (pmset schedule wake, 로컬 시각)
+func pmsetWakeArgs(at time.Time) []string {
+	return []string{"pmset", "schedule", "wake", at.Local().Format("01/02/06 15:04:05")}
+}
+
+// systemdWakeArgs Linux 일회성 systemd 타이머 (WakeSystem=true → RTC 알람으로 절전 해제)
+// 같은 유닛 이름을 재사용하므로 등록 전에 이전 타이머를 멈춘다.
+func systemdWakeArgs(at time.Time) []string {
+	return []string{"systemd-run", "--unit=traveler-wake",
+		"--on-calendar=" + at.UTC().Format("2006-01-02 15:04:05") + " UTC",
+		"--timer-property=WakeSystem=true", "--timer-property=AccuracySec=1s",
+		"/bin/true"}
+}
+
+// rtcwakeArgs systemd가 없을 때 RTC 알람만 설정 (-m no: 지금 절전하지 않음)
+func rtcwakeArgs(at time.Time) []string {
+	return []string{"rtcwake", "-m", "no", "-t", strconv.FormatInt(at.Unix(), 10)}
+}
+
+// sleepUntilNextSession 다음 세션 깨우기 예약 후 절전
+// 예약에 실패해도 절전은 한다 (Windows 예약 작업이나 사용자가 등록한 반복 예약이 있을 수 있음).
+func (d *Daemon) sleepUntilNextSession() {
+	at := NextWakeTime(time.Now())
+	if how, err := scheduleWakeAt(at); err != nil {
+		log.Printf("[DAEMON] Could not schedule wake for %s: %v", FormatETKST(at), err)
+	} else {
+		log.Printf("[DAEMON] Wake scheduled for %s via %s", FormatETKST(at), how)
+	}
+	time.Sleep(3 * time.Second)
+	sleepPC()
+}
+
+// commandError 외부 명령 실패 메시지 (출력 포함)
+func commandError(args []string, out []byte, err error) error {
+	if len(out) > 0 {
+		return fmt.Errorf("%s: %v (%s)", args[0], err, trimOutput(out))
+	}
+	return fmt.Errorf("%s: %v", args[0], err)
+}
+
+// trimOutput 명령 출력 첫 줄 (최대 200자)
+func trimOutput(out []byte) string {
+	s, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	if len(s) > 200 {
+		s = s[:200]
+	}
+	return s
+}
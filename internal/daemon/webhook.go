@@ -0,0 +1,194 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/notify"
+	"traveler/internal/trader"
+)
+
+// SetWebhooks 외부 웹훅 설정 (nil이면 발송 안 함, 리허설이면 source가 rehearsal-<market>)
+func (d *Daemon) SetWebhooks(n *notify.WebhookNotifier) {
+	source := "daemon-" + d.config.Market
+	if d.config.Rehearsal {
+		source = "rehearsal-" + d.config.Market
+	}
+	d.webhooks = n.WithSource(source)
+}
+
+// webhookSignal scan.completed 페이로드의 시그널 요약
+type webhookSignal struct {
+	Symbol      string  `json:"symbol"`
+	Name        string  `json:"name,omitempty"`
+	Strategy    string  `json:"strategy"`
+	Strength    float64 `json:"strength"`
+	Probability float64 `json:"probability"`
+	Entry       float64 `json:"entry,omitempty"`
+	StopLoss    float64 `json:"stop_loss,omitempty"`
+	Target1     float64 `json:"target1,omitempty"`
+	Quantity    float64 `json:"quantity,omitempty"`
+}
+
+// emitScanCompleted 스캔 완료 이벤트
+func (d *Daemon) emitScanCompleted(sr *daemonScanResult) {
+	if d.webhooks == nil {
+		return
+	}
+	sigs := make([]webhookSignal, 0, len(sr.Signals))
+	for _, s := range sr.Signals {
+		ws := webhookSignal{
+			Symbol:      s.Stock.Symbol,
+			Name:        s.Stock.Name,
+			Strategy:    s.Strategy,
+			Strength:    s.Strength,
+			Probability: s.Probability,
+		}
+		if s.Guide != nil {
+			ws.Entry, ws.StopLoss, ws.Target1, ws.Quantity = s.Guide.EntryPrice, s.Guide.StopLoss, s.Guide.Target1, s.Guide.PositionSize
+		}
+		sigs = append(sigs, ws)
+	}
+	d.webhooks.Emit(notify.EventScanCompleted, map[string]interface{}{
+		"market":         d.config.Market,
+		"total_scanned":  sr.ScannedCount,
+		"signals_found":  len(sr.Signals),
+		"universes_used": sr.UniversesUsed,
+		"regime":         sr.Regime,
+		"scan_time":      sr.ScanTime.Round(time.Second).String(),
+		"signals":        sigs,
+	})
+}
+
+// fillConfirmTimeout 미체결 목록에서 빠졌는데 체결내역이 보이지 않는 주문을 취소로 보는 시간
+const fillConfirmTimeout = 30 * time.Minute
+
+// queueFillEvent 매매 기록 → 체결 확인 대기 (주문 시점 기록이므로 바로 발송하지 않음)
+func (d *Daemon) queueFillEvent(rec trader.TradeRecord) {
+	if rec.Timestamp.IsZero() {
+		rec.Timestamp = time.Now()
+	}
+	d.fillMu.Lock()
+	d.unfilled = append(d.unfilled, rec)
+	d.fillMu.Unlock()
+}
+
+// confirmFillEvents 미체결 목록에서 빠진 주문의 실제 체결 수량/가격으로 order.filled 발송 (체결 없는 주문은 버림)
+func (d *Daemon) confirmFillEvents() {
+	d.fillMu.Lock()
+	queued := d.unfilled
+	d.unfilled = nil
+	d.fillMu.Unlock()
+	if len(queued) == 0 {
+		return
+	}
+
+	var waiting []trader.TradeRecord
+	defer func() {
+		d.fillMu.Lock()
+		d.unfilled = append(waiting, d.unfilled...)
+		d.fillMu.Unlock()
+	}()
+
+	pending, err := d.broker.GetPendingOrders(d.ctx)
+	if err != nil {
+		waiting = queued
+		return
+	}
+	open := make(map[string]bool, len(pending)*2)
+	for _, o := range pending {
+		open[o.OrderID] = true
+		open[o.Symbol+"|"+string(o.Side)] = true
+	}
+
+	var fills map[string]broker.Fill
+	for _, rec := range queued {
+		if (rec.OrderID != "" && open[rec.OrderID]) || (rec.OrderID == "" && open[rec.Symbol+"|"+rec.Side]) {
+			waiting = append(waiting, rec)
+			continue
+		}
+		qty, price, ok := d.filledQuantity(rec, &fills)
+		if !ok {
+			if time.Since(rec.Timestamp) < fillConfirmTimeout {
+				waiting = append(waiting, rec)
+			} else {
+				log.Printf("[WEBHOOK] %s %s order %s: no fill found, order.filled skipped", rec.Side, rec.Symbol, rec.OrderID)
+			}
+			continue
+		}
+		if rec.Amount > 0 {
+			rec.Amount = qty * price
+		}
+		rec.Quantity, rec.Price = qty, price
+		d.emitTradeEvent(rec)
+	}
+}
+
+// filledQuantity 주문의 실제 체결 수량과 평균가. 주문 조회 → 체결내역 순으로 확인하고,
+// 둘 다 없는 브로커(시뮬레이션)는 미체결이 아니면 기록대로 체결된 것으로 본다. 아직 모르면 ok=false
+func (d *Daemon) filledQuantity(rec trader.TradeRecord, fills *map[string]broker.Fill) (qty, price float64, ok bool) {
+	if rec.OrderID == "" {
+		return rec.Quantity, rec.Price, true
+	}
+	if res, err := d.broker.GetOrder(d.ctx, rec.OrderID); err == nil && res != nil {
+		if res.FilledQty <= 0 {
+			return 0, 0, false
+		}
+		price = rec.Price
+		if res.AvgPrice > 0 {
+			price = res.AvgPrice
+		}
+		return res.FilledQty, price, true
+	}
+	fh, isFH := d.broker.(broker.FillHistory)
+	if !isFH {
+		return rec.Quantity, rec.Price, true
+	}
+	if *fills == nil {
+		list, err := fh.GetFills(d.ctx, time.Now().AddDate(0, 0, -1), time.Now())
+		if err != nil {
+			return 0, 0, false
+		}
+		*fills = make(map[string]broker.Fill, len(list))
+		for _, f := range list {
+			sum := (*fills)[f.OrderID]
+			sum.Quantity += f.Quantity
+			sum.Amount += f.Amount
+			(*fills)[f.OrderID] = sum
+		}
+	}
+	f, found := (*fills)[rec.OrderID]
+	if !found || f.Quantity <= 0 {
+		return 0, 0, false
+	}
+	return f.Quantity, f.Amount / f.Quantity, true
+}
+
+// emitTradeEvent 체결 확인된 매매 기록 → order.filled (+ 손절이면 stop.hit)
+func (d *Daemon) emitTradeEvent(rec trader.TradeRecord) {
+	d.webhooks.Emit(notify.EventOrderFilled, rec)
+	if rec.Side == "sell" && (rec.Reason == "stop_loss" || rec.Reason == "trailing_stop") {
+		d.webhooks.Emit(notify.EventStopHit, rec)
+	}
+}
+
+// emitDailyReport 세션 종료 리포트 (종료 직후 프로세스가 끝날 수 있어 동기 발송)
+func (d *Daemon) emitDailyReport(reportPath string) {
+	if d.webhooks == nil {
+		return
+	}
+	state := d.tracker.GetState()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err := d.webhooks.EmitSync(ctx, notify.EventDailyReport, map[string]interface{}{
+		"market":      d.config.Market,
+		"report_path": reportPath,
+		"state":       state,
+		"report":      d.tracker.GenerateReport(),
+	})
+	if err != nil {
+		log.Printf("[WEBHOOK] daily.report: %v", err)
+	}
+}
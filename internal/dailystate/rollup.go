@@ -0,0 +1,200 @@
+package dailystate
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/fsutil"
+	"traveler/internal/trader"
+)
+
+// RollupEquityPoint 기간 내 일별 마감 자산
+type RollupEquityPoint struct {
+	Date   string  `json:"date"`
+	Equity float64 `json:"equity"`
+	PnL    float64 `json:"pnl"`
+}
+
+// RollupStrategy 전략별 적중률
+type RollupStrategy struct {
+	Trades  int     `json:"trades"`
+	Wins    int     `json:"wins"`
+	Losses  int     `json:"losses"`
+	HitRate float64 `json:"hit_rate"`
+	NetPnL  float64 `json:"net_pnl"`
+}
+
+// PeriodRollup 주간/월간 성과 집계
+type PeriodRollup struct {
+	Period          string                    `json:"period"` // "2026-W41" or "2026-10"
+	Start           string                    `json:"start"`
+	End             string                    `json:"end"`
+	TradingDays     int                       `json:"trading_days"`
+	StartingBalance float64                   `json:"starting_balance"`
+	EndingBalance   float64                   `json:"ending_balance"`
+	NetPnL          float64                   `json:"net_pnl"`     // 일별 순손익 합 (입출금 제외)
+	NetPnLPct       float64                   `json:"net_pnl_pct"` // 일별 수익률 복리
+	NetCashFlow     float64                   `json:"net_cash_flow"`
+	Fees            float64                   `json:"fees"`
+	Trades          int                       `json:"trades"` // 청산(매도) 건수
+	Wins            int                       `json:"wins"`
+	Losses          int                       `json:"losses"`
+	HitRate         float64                   `json:"hit_rate"`
+	Best            *trader.TradeRecord       `json:"best,omitempty"`
+	Worst           *trader.TradeRecord       `json:"worst,omitempty"`
+	ByStrategy      map[string]RollupStrategy `json:"by_strategy"`
+	Equity          []RollupEquityPoint       `json:"equity"`
+}
+
+// rollupKey 날짜 → 기간 키 ("week": ISO 주, "month": 월)
+func rollupKey(date time.Time, period string) string {
+	if period == "month" {
+		return date.Format("2006-01")
+	}
+	y, w := date.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", y, w)
+}
+
+// BuildRollups 일별 상태 + 매매 기록 → 기간별 집계 (오래된 순)
+// period: "week" 또는 "month". 매매 기록은 마켓으로 미리 필터링해서 넘긴다.
+func BuildRollups(states []DailyState, records []trader.TradeRecord, period string, loc *time.Location) []PeriodRollup {
+	if loc == nil {
+		loc = time.Local
+	}
+	byKey := make(map[string]*PeriodRollup)
+	var keys []string
+	get := func(key string) *PeriodRollup {
+		r, ok := byKey[key]
+		if !ok {
+			r = &PeriodRollup{Period: key, ByStrategy: make(map[string]RollupStrategy)}
+			byKey[key] = r
+			keys = append(keys, key)
+		}
+		return r
+	}
+	growth := make(map[string]float64)
+
+	for _, st := range states {
+		day, err := time.ParseInLocation("2006-01-02", st.Date, loc)
+		if err != nil {
+			continue
+		}
+		r := get(rollupKey(day, period))
+		if r.TradingDays == 0 {
+			r.Start = st.Date
+			r.StartingBalance = st.StartingBalance
+			growth[r.Period] = 1
+		}
+		r.End = st.Date
+		r.TradingDays++
+		r.EndingBalance = st.CurrentBalance
+		r.NetPnL += st.TotalPnL
+		r.NetCashFlow += st.NetCashFlow
+		r.Fees += st.TotalCommission
+		growth[r.Period] *= 1 + st.TotalPnLPct/100
+		r.Equity = append(r.Equity, RollupEquityPoint{Date: st.Date, Equity: st.CurrentBalance, PnL: st.TotalPnL})
+	}
+
+	for i := range records {
+		rec := records[i]
+		if rec.Side != "sell" {
+			continue
+		}
+		r := get(rollupKey(rec.Timestamp.In(loc), period))
+		if r.TradingDays == 0 {
+			// 일별 파일 없는 기간 (트래커 도입 전 기록): 매매 기록만 집계
+			day := rec.Timestamp.In(loc).Format("2006-01-02")
+			if r.Start == "" || day < r.Start {
+				r.Start = day
+			}
+			if day > r.End {
+				r.End = day
+			}
+			r.NetPnL += rec.PnL
+			r.Fees += rec.Commission
+		}
+		r.Trades++
+		if rec.PnL > 0 {
+			r.Wins++
+		} else if rec.PnL < 0 {
+			r.Losses++
+		}
+		if r.Best == nil || rec.PnL > r.Best.PnL {
+			r.Best = &rec
+		}
+		if r.Worst == nil || rec.PnL < r.Worst.PnL {
+			r.Worst = &rec
+		}
+
+		strat := rec.Strategy
+		if strat == "" {
+			strat = "unknown"
+		}
+		ss := r.ByStrategy[strat]
+		ss.Trades++
+		ss.NetPnL += rec.PnL
+		if rec.PnL > 0 {
+			ss.Wins++
+		} else if rec.PnL < 0 {
+			ss.Losses++
+		}
+		ss.HitRate = float64(ss.Wins) / float64(ss.Trades) * 100
+		r.ByStrategy[strat] = ss
+	}
+
+	sort.Strings(keys)
+	out := make([]PeriodRollup, 0, len(keys))
+	for _, k := range keys {
+		r := byKey[k]
+		if g, ok := growth[k]; ok {
+			r.NetPnLPct = (g - 1) * 100
+		}
+		if r.Trades > 0 {
+			r.HitRate = float64(r.Wins) / float64(r.Trades) * 100
+		}
+		out = append(out, *r)
+	}
+	return out
+}
+
+// RollupPath dataDir/rollups_{market}_{period}.json
+func RollupPath(dataDir, market, period string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("rollups_%s_%s.json", market, period))
+}
+
+// SaveRollups 주간/월간 집계를 다시 계산해 파일로 저장 (데몬 종료 시 자동 호출)
+func SaveRollups(dataDir, market string, loc *time.Location) error {
+	states, err := Load(dataDir, market)
+	if err != nil {
+		return err
+	}
+	var records []trader.TradeRecord
+	if h, err := trader.NewTradeHistory(dataDir); err == nil {
+		records = h.GetAll(market)
+	}
+	for _, period := range []string{"week", "month"} {
+		data, err := json.MarshalIndent(BuildRollups(states, records, period, loc), "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := fsutil.WriteFile(RollupPath(dataDir, market, period), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NormalizePeriod "weekly"/"w" → "week", "monthly"/"m" → "month"
+func NormalizePeriod(period string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case "", "week", "weekly", "w":
+		return "week", nil
+	case "month", "monthly", "m":
+		return "month", nil
+	}
+	return "", fmt.Errorf("unknown period %q (use week or month)", period)
+}
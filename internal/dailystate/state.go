@@ -0,0 +1,136 @@
+// Package dailystate 데몬이 남기는 일일 상태 파일(daily_{market}_*.json)과 주간/월간 집계
+//
+// 데몬이 쓰고 웹·CLI가 읽으므로 daemon 패키지 밖에 둔다.
+package dailystate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TradeLog 거래 로그
+type TradeLog struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Symbol     string    `json:"symbol"`
+	Side       string    `json:"side"` // "buy" or "sell"
+	Quantity   float64   `json:"quantity"`
+	Price      float64   `json:"price"`
+	Amount     float64   `json:"amount"`
+	Commission float64   `json:"commission"` // 수수료
+	OrderID    string    `json:"order_id,omitempty"`
+	Reason     string    `json:"reason,omitempty"`   // "signal", "stop_loss", "take_profit", "manual"
+	PnL        float64   `json:"pnl,omitempty"`      // 매도 시 체결가 기준 순손익 (수수료 포함) — 승/패 판정
+	TradeID    string    `json:"trade_id,omitempty"` // 진입-청산 짝 ID (매수와 그 포지션의 매도들이 공유)
+
+	// 매도 시 진입 정보 (진입이 전날이어도 왕복 거래를 만들 수 있게)
+	EntryPrice float64   `json:"entry_price,omitempty"`
+	EntryTime  time.Time `json:"entry_time,omitempty"`
+	EntryStop  float64   `json:"entry_stop,omitempty"` // 진입 당시 손절가 (R 배수 기준)
+}
+
+// DailyState 일일 상태
+type DailyState struct {
+	Date            string              `json:"date"`
+	StartingBalance float64             `json:"starting_balance"`
+	CurrentBalance  float64             `json:"current_balance"`
+	RealizedPnL     float64             `json:"realized_pnl"`
+	UnrealizedPnL   float64             `json:"unrealized_pnl"`
+	TotalCommission float64             `json:"total_commission"` // 총 수수료
+	TotalPnL        float64             `json:"total_pnl"`        // 수수료 차감 후
+	TotalPnLPct     float64             `json:"total_pnl_pct"`
+	TradeCount      int                 `json:"trade_count"`
+	ScanDone        bool                `json:"scan_done"` // true if scan was already completed today
+	WinCount        int                 `json:"win_count"`
+	LossCount       int                 `json:"loss_count"`
+	Trades          []TradeLog          `json:"trades"`
+	Status          string              `json:"status"` // "running", "target_reached", "loss_limit", "market_closed", "error"
+	StartTime       time.Time           `json:"start_time"`
+	EndTime         time.Time           `json:"end_time,omitempty"`
+	Reconciliation  *FillReconciliation `json:"reconciliation,omitempty"` // 체결내역 기반 실현손익 대조
+	NetCashFlow     float64             `json:"net_cash_flow"`            // 감지된 입금(+)/출금(-) 합계 — P&L에서 제외
+	CashFlows       []CashFlow          `json:"cash_flows,omitempty"`
+	LastCash        float64             `json:"last_cash,omitempty"`        // 직전 관측 현금 (입출금 감지 기준)
+	LastCashTrades  int                 `json:"last_cash_trades,omitempty"` // 직전 관측 시점의 거래 수
+	BaselineAt      time.Time           `json:"baseline_at,omitempty"`      // StartingBalance를 잡은 시각 (그날 첫 세션 시작)
+	BaselineSource  string              `json:"baseline_source,omitempty"`  // session_start, baseline_file (상태 파일 유실 후 복원)
+	Restarts        []SessionRestart    `json:"restarts,omitempty"`         // 같은 거래일 중 재시작
+}
+
+// CashFlow 외부 입출금 (거래로 설명되지 않는 현금 변화)
+type CashFlow struct {
+	Time       time.Time `json:"time"`
+	Amount     float64   `json:"amount"` // +입금, -출금
+	CashBefore float64   `json:"cash_before"`
+	CashAfter  float64   `json:"cash_after"`
+}
+
+// FillReconciliation 체결내역 기반 실현손익 vs 잔고(equity) 변화 기반 추정치
+type FillReconciliation struct {
+	CheckedAt    time.Time `json:"checked_at"`
+	FillPnL      float64   `json:"fill_pnl"`   // 체결 FIFO 매칭 순손익 (수수료 차감)
+	EquityPnL    float64   `json:"equity_pnl"` // 트래커 RealizedPnL (equity 변화 기반)
+	Difference   float64   `json:"difference"` // EquityPnL - FillPnL (입출금/환율/배당 등)
+	ClosedTrades int       `json:"closed_trades"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+	Unmatched    []string  `json:"unmatched,omitempty"`
+}
+
+// SessionRestart 같은 거래일 중 데몬 재시작 (재시작 시점 잔고)
+type SessionRestart struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"`
+}
+
+// Load dataDir의 daily_{market}_*.json 전체 (날짜순)
+func Load(dataDir, market string) ([]DailyState, error) {
+	pattern := filepath.Join(dataDir, fmt.Sprintf("daily_%s_*.json", market))
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]DailyState, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var st DailyState
+		if err := json.Unmarshal(data, &st); err != nil || st.Date == "" {
+			continue
+		}
+		states = append(states, st)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Date < states[j].Date })
+	return states, nil
+}
+
+// LoadLatest 가장 최근 daily_{market}_*.json과 수정 시각 (없으면 os.ErrNotExist)
+func LoadLatest(dataDir, market string) (*DailyState, time.Time, error) {
+	files, err := filepath.Glob(filepath.Join(dataDir, fmt.Sprintf("daily_%s_*.json", market)))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(files) == 0 {
+		return nil, time.Time{}, os.ErrNotExist
+	}
+	sort.Strings(files) // 파일명 날짜순
+	path := files[len(files)-1]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var st DailyState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, time.Time{}, err
+	}
+	var mod time.Time
+	if info, err := os.Stat(path); err == nil {
+		mod = info.ModTime()
+	}
+	return &st, mod, nil
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"traveler/internal/broker"
+	"traveler/internal/fsutil"
 	"traveler/internal/provider"
 	"traveler/internal/strategy"
 )
@@ -276,7 +277,7 @@ func (sm *KRDCAStateManager) save() {
 		log.Printf("[KR-DCA] Failed to marshal state: %v", err)
 		return
 	}
-	if err := os.WriteFile(sm.filepath, data, 0644); err != nil {
+	if err := fsutil.WriteFile(sm.filepath, data, 0644); err != nil {
 		log.Printf("[KR-DCA] Failed to save state: %v", err)
 	}
 }
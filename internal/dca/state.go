@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"traveler/internal/fsutil"
 )
 
 // State persists DCA progress to disk
@@ -254,7 +256,7 @@ func (sm *StateManager) save() {
 		log.Printf("[DCA] Failed to marshal state: %v", err)
 		return
 	}
-	if err := os.WriteFile(sm.filepath, data, 0644); err != nil {
+	if err := fsutil.WriteFile(sm.filepath, data, 0644); err != nil {
 		log.Printf("[DCA] Failed to save state: %v", err)
 	}
 }
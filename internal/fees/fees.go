@@ -0,0 +1,193 @@
+// Package fees 브로커별 수수료/세금 프리셋 (사이저, 트래커, 백테스트 공용)
+//
+// 마켓마다 활성 프리셋이 하나씩 있고 기본값은 KIS(us/kr), 업비트(crypto), 바이낸스(binance).
+// config의 fees 섹션으로 마켓별 프리셋을 고르거나 수수료율을 덮어쓴다.
+package fees
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Schedule 수수료 체계 (비율은 모두 편도, 0.0025 = 0.25%)
+type Schedule struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Commission  float64 `json:"commission"` // 매수/매도 각각
+	SellTax     float64 `json:"sell_tax"`   // 매도 시 거래세 (KR 증권거래세, US SEC fee 등)
+	Slippage    float64 `json:"slippage"`   // 예상 슬리피지 (체결가에 반영)
+}
+
+// RoundTripRate 왕복 수수료+세금 비율 (슬리피지 제외)
+func (s Schedule) RoundTripRate() float64 {
+	return s.Commission*2 + s.SellTax
+}
+
+// BuyCost 매수 금액 기준 비용
+func (s Schedule) BuyCost(amount float64) float64 {
+	return amount * s.Commission
+}
+
+// SellCost 매도 금액 기준 비용 (수수료 + 거래세)
+func (s Schedule) SellCost(amount float64) float64 {
+	return amount * (s.Commission + s.SellTax)
+}
+
+// Cost side("buy"/"sell", 대소문자 무관) 비용
+func (s Schedule) Cost(side string, amount float64) float64 {
+	if strings.EqualFold(side, "sell") {
+		return s.SellCost(amount)
+	}
+	return s.BuyCost(amount)
+}
+
+// presets 이름 → 수수료 체계
+var presets = map[string]Schedule{
+	"kis-us": {
+		Name:        "kis-us",
+		Description: "KIS overseas stocks (0.25% online, SEC fee on sells)",
+		Commission:  0.0025,
+		SellTax:     0.0000278,
+		Slippage:    0.001,
+	},
+	"kis-kr": {
+		Name:        "kis-kr",
+		Description: "KIS domestic stocks (0.015% online, 0.20% securities transaction tax on sells)",
+		Commission:  0.00015,
+		SellTax:     0.0020,
+		Slippage:    0.001,
+	},
+	"alpaca": {
+		Name:        "alpaca",
+		Description: "Alpaca US stocks (commission-free, SEC fee on sells)",
+		Commission:  0,
+		SellTax:     0.0000278,
+		Slippage:    0.001,
+	},
+	"ibkr": {
+		Name:        "ibkr",
+		Description: "Interactive Brokers US stocks (IBKR Pro tiered ~$0.0035/share, SEC fee on sells)",
+		Commission:  0.0003,
+		SellTax:     0.0000278,
+		Slippage:    0.001,
+	},
+	"upbit": {
+		Name:        "upbit",
+		Description: "Upbit KRW market (0.05%)",
+		Commission:  0.0005,
+		Slippage:    0.001,
+	},
+	"binance": {
+		Name:        "binance",
+		Description: "Binance USDT-M futures taker (0.05%)",
+		Commission:  0.0005,
+		Slippage:    0.0005,
+	},
+}
+
+// defaultPreset 마켓별 기본 프리셋
+var defaultPreset = map[string]string{
+	"us":      "kis-us",
+	"kr":      "kis-kr",
+	"crypto":  "upbit",
+	"binance": "binance",
+}
+
+var (
+	mu     sync.RWMutex
+	active = map[string]Schedule{}
+
+	fallbackLogged sync.Map // us로 대체한 마켓 값 (로그 1회)
+)
+
+// Get 프리셋 조회
+func Get(name string) (Schedule, bool) {
+	s, ok := presets[strings.ToLower(strings.TrimSpace(name))]
+	return s, ok
+}
+
+// Names 프리셋 이름 목록 (정렬)
+func Names() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForMarket 마켓의 활성 수수료 체계 (모르는 마켓은 us, 마켓 값마다 한 번 로그)
+func ForMarket(market string) Schedule {
+	key, err := normalizeMarket(market)
+	if err != nil {
+		if _, seen := fallbackLogged.LoadOrStore(market, true); !seen {
+			log.Printf("[FEES] %v — using us fee preset", err)
+		}
+		key = "us"
+	}
+	mu.RLock()
+	s, ok := active[key]
+	mu.RUnlock()
+	if ok {
+		return s
+	}
+	return presets[defaultPreset[key]]
+}
+
+// Use 마켓의 활성 프리셋 지정
+func Use(market, preset string) error {
+	market, err := normalizeMarket(market)
+	if err != nil {
+		return err
+	}
+	s, ok := Get(preset)
+	if !ok {
+		return fmt.Errorf("unknown fee preset %q (available: %s)", preset, strings.Join(Names(), ", "))
+	}
+	mu.Lock()
+	active[market] = s
+	mu.Unlock()
+	return nil
+}
+
+// Override 마켓의 활성 체계에서 0이 아닌 값만 덮어쓰기 (음수는 0으로 설정)
+func Override(market string, commission, sellTax float64) error {
+	market, err := normalizeMarket(market)
+	if err != nil {
+		return err
+	}
+	s := ForMarket(market)
+	if commission != 0 {
+		s.Commission = nonNegative(commission)
+		s.Name += "*"
+	}
+	if sellTax != 0 {
+		s.SellTax = nonNegative(sellTax)
+		if !strings.HasSuffix(s.Name, "*") {
+			s.Name += "*"
+		}
+	}
+	mu.Lock()
+	active[market] = s
+	mu.Unlock()
+	return nil
+}
+
+func nonNegative(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+// normalizeMarket 마켓 키 정규화 (us/kr/crypto/binance 외에는 에러)
+func normalizeMarket(market string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(market))
+	if _, ok := defaultPreset[key]; ok {
+		return key, nil
+	}
+	return "", fmt.Errorf("unknown fee market %q (available: us, kr, crypto, binance)", market)
+}
@@ -0,0 +1,103 @@
+// Package fsutil provides crash- and concurrency-safe file access for the
+// shared data directory (~/.traveler).
+//
+// Ownership model — several processes share one data dir (US/KR/crypto
+// daemons, the web server, one-off CLI commands):
+//
+//   - Shared, multi-writer files are only modified through Update: an
+//     exclusive lock on "<file>.lock", re-read from disk, mutate, atomic
+//     write. In-memory copies are caches; the file is the source of truth.
+//     plans.json, trade_history.json, alert_rules.json, symbol_lists.json,
+//     watchlists.json, symbol_health.json (merged per symbol),
+//     scan_settings.json (web, merged per market) and candles/{SYMBOL}.json
+//     (fetch, web and daemon merge by date).
+//   - Single-writer files belong to the one process that creates them;
+//     everyone else only reads. They are written with WriteFile.
+//     Daemon: daily_{market}_{date}.json, rollups_{market}_{period}.json,
+//     kelly_risk_{market}.json, hedge_{market}.json, rehearsal_{market}.json,
+//     capital/DCA/scalp state, alert_state_{market}.json, position_history/.
+//     Web: scan_jobs.json, scan_results/, scan checkpoints.
+//     Whoever replaces a whole cache entry: candles/intraday/.
+//   - last_scan_{market}.json may be written by both the daemon and the
+//     web scanner; last writer wins.
+//
+// Files listed above never change in place, so readers never observe a
+// half-written JSON document. Reports, backtest output and provider caches
+// outside this list are still written directly.
+package fsutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile atomically replaces path with data (temp file in the same dir + rename).
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // rename 성공 시 no-op
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
+
+// Lock exclusive advisory lock on "<path>.lock" across processes
+type Lock struct {
+	f *os.File
+}
+
+// LockFile blocks until the lock for path is acquired.
+func LockFile(path string) (*Lock, error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFD(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lock %s: %w", filepath.Base(path), err)
+	}
+	return &Lock{f: f}, nil
+}
+
+// Unlock releases the lock (lock file is left in place for reuse)
+func (l *Lock) Unlock() error {
+	if l == nil || l.f == nil {
+		return nil
+	}
+	unlockFD(l.f)
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// Update runs fn while holding the lock for path.
+// fn should re-read the file, apply its change and write it back with WriteFile.
+func Update(path string, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	l, err := LockFile(path)
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+	return fn()
+}
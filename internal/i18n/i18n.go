@@ -0,0 +1,122 @@
+// Package i18n 리포트/알림/웹 라벨 메시지 카탈로그 (ko, en)
+//
+// 언어는 프로세스 전체에 하나 (config.yaml language, 기본 en). 카탈로그에 없는 키는
+// 영어 → 키 자체 순서로 대체하므로 번역이 빠져도 출력이 비지는 않는다.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 지원 언어
+const (
+	English = "en"
+	Korean  = "ko"
+)
+
+// Default 설정이 없을 때의 언어
+const Default = English
+
+var (
+	mu      sync.RWMutex
+	current = Default
+)
+
+// Supported 지원 언어 목록 (정렬)
+func Supported() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Normalize "ko-KR", "KO", "korean" 같은 입력을 지원 언어 코드로 (모르면 false)
+func Normalize(lang string) (string, bool) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	switch {
+	case lang == "":
+		return Default, true
+	case lang == "korean" || strings.HasPrefix(lang, "ko"):
+		return Korean, true
+	case lang == "english" || strings.HasPrefix(lang, "en"):
+		return English, true
+	}
+	return "", false
+}
+
+// Use 프로세스 언어 지정 (빈 값 = 기본 en)
+func Use(lang string) error {
+	code, ok := Normalize(lang)
+	if !ok {
+		return fmt.Errorf("unknown language %q (available: %s)", lang, strings.Join(Supported(), ", "))
+	}
+	mu.Lock()
+	current = code
+	mu.Unlock()
+	return nil
+}
+
+// Language 현재 언어 코드
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T 현재 언어로 메시지 (args가 있으면 fmt.Sprintf 형식으로 채운다)
+func T(key string, args ...interface{}) string {
+	return TL(Language(), key, args...)
+}
+
+// TL 지정 언어로 메시지
+func TL(lang, key string, args ...interface{}) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		if msg, ok = catalogs[English][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Catalog 언어의 전체 메시지 (영어로 빈 키를 채운 사본, 웹 라벨용)
+func Catalog(lang string) map[string]string {
+	out := make(map[string]string, len(catalogs[English]))
+	for k, v := range catalogs[English] {
+		out[k] = v
+	}
+	for k, v := range catalogs[lang] {
+		out[k] = v
+	}
+	return out
+}
+
+// Width 터미널 표시 폭 (한글/한자 등 전각 문자는 2칸)
+func Width(s string) int {
+	w := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Hangul, r) || unicode.Is(unicode.Han, r) {
+			w += 2
+		} else {
+			w++
+		}
+	}
+	return w
+}
+
+// Pad 표시 폭 기준 오른쪽 공백 채우기 (리포트 라벨 정렬용)
+func Pad(s string, width int) string {
+	if n := width - Width(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
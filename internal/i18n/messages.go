@@ -0,0 +1,166 @@
+package i18n
+
+// catalogs 언어별 메시지. 키는 "영역.이름" (report = 일일 리포트, tg = 텔레그램, alert = 데몬 알림, web = 대시보드 라벨)
+// 새 키는 en에 먼저 추가한다 (없는 번역은 en으로 대체).
+var catalogs = map[string]map[string]string{
+	English: {
+		// 일일 리포트
+		"report.title":            "DAILY TRADING REPORT",
+		"report.summary":          "SUMMARY",
+		"report.status":           "Status",
+		"report.starting_balance": "Starting Balance",
+		"report.current_balance":  "Current Balance",
+		"report.realized":         "Realized P&L",
+		"report.unrealized":       "Unrealized P&L",
+		"report.commission":       "Commission",
+		"report.net_pnl":          "Net P&L",
+		"report.cash_flow":        "Deposits/Withdr.",
+		"report.cash_flow_note":   "(excluded from P&L)",
+		"report.statistics":       "STATISTICS",
+		"report.total_trades":     "Total Trades",
+		"report.wins":             "Winning Trades",
+		"report.losses":           "Losing Trades",
+		"report.win_rate":         "Win Rate",
+		"report.time":             "TIME",
+		"report.start":            "Start",
+		"report.end":              "End",
+		"report.duration":         "Duration",
+		"report.running":          "(running)",
+		"report.reconciliation":   "RECONCILIATION (broker fills)",
+		"report.fill_realized":    "Fill Realized",
+		"report.fill_closed":      "%d closed, %dW/%dL",
+		"report.equity_estimate":  "Equity Estimate",
+		"report.difference":       "Difference",
+		"report.unmatched":        "Unmatched sells",
+		"report.unmatched_note":   "(no buy fill in lookback)",
+		"report.restarts":         "RESTARTS (baseline %s at %s, %s)",
+		"report.restart_equity":   "equity %s",
+		"report.round_trips":      "ROUND TRIPS",
+		"report.held":             "held",
+		"report.trades":           "TRADES",
+		"report.rehearsal":        "REHEARSAL day %d",
+		"report.rehearsal_banner": "%s — paper broker, no live orders",
+
+		// 텔레그램 공통
+		"tg.pnl":           "PnL",
+		"tg.reason":        "Reason",
+		"tg.daily_summary": "Daily Summary",
+
+		// 데몬 알림
+		"alert.stalled":          "🛑 [%s] Daemon stalled: no monitor cycle for %s, stopping (pid %d)",
+		"alert.reconcile":        "⚠️ [%s] Startup reconciliation: %d issue(s), new entries paused",
+		"alert.reconcile_ack":    "Acknowledge: traveler reconcile ack --market %s",
+		"alert.data_stale":       "stale market data",
+		"alert.data_unavailable": "market data unavailable",
+		"alert.data_aborted":     "⚠️ [%s] %s, trading aborted\nExpected %s candles for %s\n%s\nExisting positions are still monitored.",
+		"alert.entry":            "🟢 [%s] BUY %s %g @ %s (%s)\n%s",
+		"alert.anomaly":          "⚠️ [%s] Account changed outside traveler (manual trade or broker issue?)",
+		"alert.anomaly_new":      "• New position %s: %g (not opened by traveler)",
+		"alert.anomaly_qty":      "• %s quantity %g → %g without an order",
+		"alert.anomaly_gone":     "• %s position (%g) closed without an order",
+		"alert.anomaly_cash":     "• Buying power down %s (%.1f%%) with no matching order: %s → %s",
+
+		// 대시보드
+		"web.tab.scanner":       "Scanner",
+		"web.tab.positions":     "Positions",
+		"web.tab.history":       "History",
+		"web.tab.strategy":      "Strategy",
+		"web.tab.portfolio":     "Portfolio",
+		"web.tab.backtests":     "Backtests",
+		"web.tab.reports":       "Reports",
+		"web.recommended":       "Recommended Positions",
+		"web.rejected_by_ai":    "Rejected by AI",
+		"web.pending_orders":    "Pending Orders",
+		"web.trade_history":     "Trade History",
+		"web.trading_system":    "Trading System",
+		"web.exit_system":       "Exit System",
+		"web.strategies":        "Strategies",
+		"web.position_sizing":   "Position Sizing",
+		"web.recent_trades":     "Recent Trades",
+		"web.active_positions":  "Active Positions",
+		"web.strategy_config":   "Strategy Config",
+		"web.lifetime":          "Lifetime",
+		"web.fundamentals":      "Fundamentals Filter",
+		"web.common_filters":    "Common Filters",
+		"web.regime_strategies": "Regime-Based Strategy Selection",
+		"web.scan_quality":      "Scan Quality",
+	},
+	Korean: {
+		"report.title":            "일일 매매 리포트",
+		"report.summary":          "요약",
+		"report.status":           "상태",
+		"report.starting_balance": "시작 잔고",
+		"report.current_balance":  "현재 잔고",
+		"report.realized":         "실현 손익",
+		"report.unrealized":       "평가 손익",
+		"report.commission":       "수수료",
+		"report.net_pnl":          "순손익",
+		"report.cash_flow":        "입출금",
+		"report.cash_flow_note":   "(손익에서 제외)",
+		"report.statistics":       "통계",
+		"report.total_trades":     "총 거래",
+		"report.wins":             "수익 거래",
+		"report.losses":           "손실 거래",
+		"report.win_rate":         "승률",
+		"report.time":             "시간",
+		"report.start":            "시작",
+		"report.end":              "종료",
+		"report.duration":         "가동 시간",
+		"report.running":          "(실행 중)",
+		"report.reconciliation":   "체결 대조 (브로커 체결내역)",
+		"report.fill_realized":    "체결 기준 실현",
+		"report.fill_closed":      "청산 %d건, %d승/%d패",
+		"report.equity_estimate":  "잔고 기준 추정",
+		"report.difference":       "차이",
+		"report.unmatched":        "짝 없는 매도",
+		"report.unmatched_note":   "(조회 기간 안에 매수 체결 없음)",
+		"report.restarts":         "재시작 (기준 잔고 %s, %s, %s)",
+		"report.restart_equity":   "잔고 %s",
+		"report.round_trips":      "왕복 거래",
+		"report.held":             "보유",
+		"report.trades":           "거래 내역",
+		"report.rehearsal":        "리허설 %d일차",
+		"report.rehearsal_banner": "%s — 모의 브로커, 실주문 없음",
+
+		"tg.pnl":           "손익",
+		"tg.reason":        "사유",
+		"tg.daily_summary": "일일 요약",
+
+		"alert.stalled":          "🛑 [%s] 데몬 멈춤: %s 동안 모니터 사이클 없음, 종료합니다 (pid %d)",
+		"alert.reconcile":        "⚠️ [%s] 시작 대조: 문제 %d건, 신규 매수 중지",
+		"alert.reconcile_ack":    "확인: traveler reconcile ack --market %s",
+		"alert.data_stale":       "시세 데이터가 오래됨",
+		"alert.data_unavailable": "시세 데이터를 받을 수 없음",
+		"alert.data_aborted":     "⚠️ [%s] %s, 매매 중단\n%s 일봉 필요 (%s)\n%s\n보유 포지션은 계속 감시합니다.",
+		"alert.entry":            "🟢 [%s] 매수 %s %g @ %s (%s)\n%s",
+		"alert.anomaly":          "⚠️ [%s] traveler 밖에서 계좌 변경 (수동 매매 또는 브로커 문제?)",
+		"alert.anomaly_new":      "• 새 포지션 %s: %g (traveler가 연 포지션 아님)",
+		"alert.anomaly_qty":      "• %s 수량 %g → %g (주문 없음)",
+		"alert.anomaly_gone":     "• %s 포지션 (%g) 주문 없이 사라짐",
+		"alert.anomaly_cash":     "• 매수 가능 금액 %s 감소 (%.1f%%), 해당 주문 없음: %s → %s",
+
+		"web.tab.scanner":       "스캐너",
+		"web.tab.positions":     "포지션",
+		"web.tab.history":       "거래 기록",
+		"web.tab.strategy":      "전략",
+		"web.tab.portfolio":     "포트폴리오",
+		"web.tab.backtests":     "백테스트",
+		"web.tab.reports":       "리포트",
+		"web.recommended":       "추천 포지션",
+		"web.rejected_by_ai":    "AI 제외",
+		"web.pending_orders":    "미체결 주문",
+		"web.trade_history":     "거래 기록",
+		"web.trading_system":    "매매 시스템",
+		"web.exit_system":       "청산 시스템",
+		"web.strategies":        "전략",
+		"web.position_sizing":   "포지션 사이징",
+		"web.recent_trades":     "최근 거래",
+		"web.active_positions":  "보유 포지션",
+		"web.strategy_config":   "전략 설정",
+		"web.lifetime":          "누적",
+		"web.fundamentals":      "펀더멘털 필터",
+		"web.common_filters":    "공통 필터",
+		"web.regime_strategies": "시장 국면별 전략 선택",
+		"web.scan_quality":      "스캔 품질",
+	},
+}
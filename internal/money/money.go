@@ -0,0 +1,195 @@
+// Package money 통화별 금액/가격 표시 (CLI, 리포트, 웹 API 공용)
+//
+// USD는 영문 관례($12.3K, $1.25M), KRW는 한국 관례(₩1,234,567, 1억 이상은 ₩1.23억)를 따른다.
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Currency 통화 코드
+type Currency string
+
+const (
+	USD  Currency = "USD"
+	KRW  Currency = "KRW"
+	USDT Currency = "USDT"
+	JPY  Currency = "JPY"
+	HKD  Currency = "HKD"
+	CNY  Currency = "CNY"
+)
+
+// ForMarket 마켓별 표시 통화 (kr, crypto(업비트) → KRW, binance → USDT, 그 외 USD)
+func ForMarket(market string) Currency {
+	switch strings.ToLower(market) {
+	case "kr", "crypto":
+		return KRW
+	case "binance":
+		return USDT
+	default:
+		return USD
+	}
+}
+
+// ForSymbol 심볼 형식으로 통화 추정 (6자리 숫자·KRW-XXX → KRW, XXXUSDT → USDT, .T → JPY, .HK → HKD, .SS/.SZ → CNY)
+func ForSymbol(symbol string) Currency {
+	upper := strings.ToUpper(symbol)
+	switch {
+	case isKRCode(symbol), strings.HasPrefix(symbol, "KRW-"):
+		return KRW
+	case strings.HasSuffix(symbol, "USDT") && len(symbol) > 4:
+		return USDT
+	case strings.HasSuffix(upper, ".T"):
+		return JPY
+	case strings.HasSuffix(upper, ".HK"):
+		return HKD
+	case strings.HasSuffix(upper, ".SS"), strings.HasSuffix(upper, ".SZ"):
+		return CNY
+	default:
+		return USD
+	}
+}
+
+// Parse 브로커 잔고 통화 문자열 → Currency (알 수 없으면 USD)
+func Parse(code string) Currency {
+	switch Currency(strings.ToUpper(strings.TrimSpace(code))) {
+	case KRW:
+		return KRW
+	case USDT:
+		return USDT
+	case JPY:
+		return JPY
+	case HKD:
+		return HKD
+	case CNY:
+		return CNY
+	default:
+		return USD
+	}
+}
+
+// Symbol 통화 기호
+func (c Currency) Symbol() string {
+	switch c {
+	case KRW:
+		return "₩"
+	case USDT:
+		return ""
+	case JPY:
+		return "¥"
+	case HKD:
+		return "HK$"
+	case CNY:
+		return "CN¥"
+	default:
+		return "$"
+	}
+}
+
+// Decimals 금액 표시 소수 자릿수
+func (c Currency) Decimals() int {
+	if c == KRW || c == JPY {
+		return 0
+	}
+	return 2
+}
+
+// Format 전체 금액 (천 단위 구분): $12,345.67, ₩1,234,567, 12,345.67 USDT
+func Format(amount float64, c Currency) string {
+	return withSymbol(Group(math.Abs(amount), c.Decimals()), amount < 0, c)
+}
+
+// Compact 요약 금액: USD $12.3K/$1.25M/$2.10B, KRW ₩1,234,567/₩1.23억/₩1.2조
+func Compact(amount float64, c Currency) string {
+	abs := math.Abs(amount)
+	var body string
+	switch c {
+	case KRW:
+		switch {
+		case abs >= 1e12:
+			body = fmt.Sprintf("%.1f조", abs/1e12)
+		case abs >= 1e8:
+			body = fmt.Sprintf("%.2f억", abs/1e8)
+		default:
+			body = Group(abs, 0)
+		}
+	default:
+		switch {
+		case abs >= 1e9:
+			body = fmt.Sprintf("%.2fB", abs/1e9)
+		case abs >= 1e6:
+			body = fmt.Sprintf("%.2fM", abs/1e6)
+		case abs >= 1e3:
+			body = fmt.Sprintf("%.1fK", abs/1e3)
+		default:
+			body = fmt.Sprintf("%.2f", abs)
+		}
+	}
+	return withSymbol(body, amount < 0, c)
+}
+
+// Price 단가 표시: $12.34 ($1 미만은 4자리), ₩12,340, ¥2,850
+func Price(price float64, c Currency) string {
+	decimals := c.Decimals()
+	if decimals > 0 && math.Abs(price) > 0 && math.Abs(price) < 1 {
+		decimals = 4
+	}
+	return withSymbol(Group(math.Abs(price), decimals), price < 0, c)
+}
+
+// Signed 부호 포함 요약 금액 (손익 표시용): +$12.3K, -₩50,000
+func Signed(amount float64, c Currency) string {
+	if amount > 0 {
+		return "+" + Compact(amount, c)
+	}
+	return Compact(amount, c)
+}
+
+// Group 천 단위 구분 숫자 (1234567.891, 2 → "1,234,567.89")
+func Group(v float64, decimals int) string {
+	neg := v < 0
+	s := fmt.Sprintf("%.*f", decimals, math.Abs(v))
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	for i, ch := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(ch)
+	}
+	b.WriteString(frac)
+	return b.String()
+}
+
+func withSymbol(body string, neg bool, c Currency) string {
+	sign := ""
+	if neg {
+		sign = "-"
+	}
+	if c == USDT {
+		return sign + body + " USDT"
+	}
+	return sign + c.Symbol() + body
+}
+
+// isKRCode 6자리 숫자 종목코드
+func isKRCode(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	for _, ch := range s {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
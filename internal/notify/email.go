@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// EmailNotifier sends reports via SMTP.
+// Set SMTP_HOST, SMTP_USER, SMTP_PASSWORD and REPORT_EMAIL_TO (comma-separated)
+// environment variables; SMTP_PORT (default 587) and SMTP_FROM (default SMTP_USER) are optional.
+// Port 465 uses implicit TLS, other ports STARTTLS when the server offers it.
+type EmailNotifier struct {
+	host     string
+	port     string
+	user     string
+	password string
+	from     string
+	to       []string
+}
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// NewEmailNotifier creates a notifier from environment variables.
+// Returns nil if not configured (no-op, won't crash).
+func NewEmailNotifier() *EmailNotifier {
+	host := os.Getenv("SMTP_HOST")
+	to := os.Getenv("REPORT_EMAIL_TO")
+	if host == "" || to == "" {
+		return nil
+	}
+	e := &EmailNotifier{
+		host:     host,
+		port:     os.Getenv("SMTP_PORT"),
+		user:     os.Getenv("SMTP_USER"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     os.Getenv("SMTP_FROM"),
+	}
+	if e.port == "" {
+		e.port = "587"
+	}
+	if e.from == "" {
+		e.from = e.user
+	}
+	for _, addr := range strings.Split(to, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			e.to = append(e.to, addr)
+		}
+	}
+	if e.from == "" || len(e.to) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Recipients returns the configured recipient addresses.
+func (e *EmailNotifier) Recipients() []string {
+	if e == nil {
+		return nil
+	}
+	return e.to
+}
+
+// AttachFile reads a file as an attachment (content type from extension).
+func AttachFile(path string) (Attachment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Attachment{}, err
+	}
+	ct := mime.TypeByExtension(filepath.Ext(path))
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	return Attachment{Name: filepath.Base(path), ContentType: ct, Data: data}, nil
+}
+
+// Send sends a plain-text email with attachments. Blocks until the SMTP session ends,
+// so callers at shutdown can be sure the report went out.
+func (e *EmailNotifier) Send(subject, body string, attachments []Attachment) error {
+	if e == nil {
+		return nil
+	}
+	msg, err := e.buildMessage(subject, body, attachments)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if e.user != "" {
+		auth = smtp.PlainAuth("", e.user, e.password, e.host)
+	}
+	addr := net.JoinHostPort(e.host, e.port)
+	if e.port != "465" {
+		return smtp.SendMail(addr, auth, e.from, e.to, msg)
+	}
+
+	// SMTPS (implicit TLS)
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 30 * time.Second}, "tcp", addr, &tls.Config{ServerName: e.host})
+	if err != nil {
+		return fmt.Errorf("smtp tls dial: %w", err)
+	}
+	c, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp client: %w", err)
+	}
+	defer c.Close()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := c.Mail(e.from); err != nil {
+		return err
+	}
+	for _, rcpt := range e.to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (e *EmailNotifier) buildMessage(subject, body string, attachments []Attachment) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", e.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeBase64Lines(part, []byte(body))
+
+	for _, a := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", a.ContentType, a.Name)},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", a.Name)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64Lines(part, a.Data)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBase64Lines base64 인코딩 후 76자 줄바꿈 (RFC 2045)
+func writeBase64Lines(w io.Writer, data []byte) {
+	enc := base64.StdEncoding.EncodeToString(data)
+	for len(enc) > 76 {
+		w.Write([]byte(enc[:76] + "\r\n"))
+		enc = enc[76:]
+	}
+	w.Write([]byte(enc + "\r\n"))
+}
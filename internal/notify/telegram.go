@@ -10,6 +10,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"traveler/internal/i18n"
 )
 
 // TelegramNotifier sends notifications via Telegram Bot API.
@@ -47,6 +49,14 @@ func (t *TelegramNotifier) Send(ctx context.Context, message string) {
 	}()
 }
 
+// SendSync sends a text message and waits for the result (for CLI commands that exit right after).
+func (t *TelegramNotifier) SendSync(ctx context.Context, message string) error {
+	if t == nil {
+		return nil
+	}
+	return t.sendMessage(ctx, message)
+}
+
 // Sendf sends a formatted message.
 func (t *TelegramNotifier) Sendf(ctx context.Context, format string, args ...interface{}) {
 	t.Send(ctx, fmt.Sprintf(format, args...))
@@ -78,10 +88,10 @@ func (t *TelegramNotifier) TradeAlert(ctx context.Context, strategy, symbol, act
 		if pnl < 0 {
 			pnlEmoji = "📉"
 		}
-		msg += fmt.Sprintf("%s PnL: %s %.2f\n", pnlEmoji, currency, pnl)
+		msg += fmt.Sprintf("%s %s: %s %.2f\n", pnlEmoji, i18n.T("tg.pnl"), currency, pnl)
 	}
 	if reason != "" {
-		msg += fmt.Sprintf("Reason: %s", reason)
+		msg += fmt.Sprintf("%s: %s", i18n.T("tg.reason"), reason)
 	}
 
 	t.Send(ctx, msg)
@@ -93,7 +103,7 @@ func (t *TelegramNotifier) DailySummary(ctx context.Context, summaries []string)
 		return
 	}
 
-	msg := "📋 *Daily Summary*\n" + strings.Join(summaries, "\n")
+	msg := "📋 *" + i18n.T("tg.daily_summary") + "*\n" + strings.Join(summaries, "\n")
 	t.Send(ctx, msg)
 }
 
@@ -0,0 +1,227 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types
+const (
+	EventScanCompleted = "scan.completed"  // 스캔 완료 (웹/데몬)
+	EventOrderFilled   = "order.filled"    // 매수/매도 체결 (주문 접수가 아니라 체결 확인 후)
+	EventStopHit       = "stop.hit"        // 손절/트레일링 스탑 청산
+	EventDailyReport   = "daily.report"    // 세션 종료 일일 리포트
+	EventAlert         = "alert.triggered" // 사용자 알림 규칙 발동
+	EventDataStale     = "data.stale"      // 스캔 전 일봉이 오래돼 데몬 매매 중단
+)
+
+// WebhookEvents 지원 이벤트 목록
+var WebhookEvents = []string{EventScanCompleted, EventOrderFilled, EventStopHit, EventDailyReport, EventAlert, EventDataStale}
+
+// WebhookTarget 웹훅 대상 하나
+type WebhookTarget struct {
+	URL    string
+	Secret string   // HMAC-SHA256 서명 키 (비우면 서명 헤더 생략)
+	Events []string // 비우면 전체, "order.*" 같은 접두 와일드카드 허용
+}
+
+// WebhookPayload 전송 본문
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	Source    string      `json:"source,omitempty"` // daemon-us, daemon-kr, web ...
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookNotifier sends signed JSON events to configured HTTP endpoints
+// (n8n, Zapier, home-grown services).
+//
+// Each request carries X-Traveler-Event, X-Traveler-Delivery and X-Traveler-Timestamp headers.
+// When a secret is set, X-Traveler-Signature is "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// Receivers should recompute it and reject stale timestamps.
+type WebhookNotifier struct {
+	targets []WebhookTarget
+	source  string
+	client  *http.Client
+}
+
+// NewWebhookNotifier validates targets. Returns nil (no-op) when none are configured.
+func NewWebhookNotifier(targets []WebhookTarget) (*WebhookNotifier, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	for i, t := range targets {
+		u, err := url.Parse(t.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhooks[%d]: invalid url %q", i, t.URL)
+		}
+		for _, e := range t.Events {
+			if !validEventFilter(e) {
+				return nil, fmt.Errorf("webhooks[%d]: unknown event %q (use %s)", i, e, strings.Join(WebhookEvents, ", "))
+			}
+		}
+	}
+	return &WebhookNotifier{
+		targets: targets,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// WithSource returns a copy that tags payloads with source (e.g. "daemon-kr").
+func (n *WebhookNotifier) WithSource(source string) *WebhookNotifier {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.source = source
+	return &c
+}
+
+// Emit sends an event to every matching target in the background.
+// Failures are logged and never block trading.
+func (n *WebhookNotifier) Emit(event string, data interface{}) {
+	if n == nil || !n.wants(event) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := n.EmitSync(ctx, event, data); err != nil {
+			log.Printf("[WEBHOOK] %s: %v", event, err)
+		}
+	}()
+}
+
+// EmitSync sends an event and waits (for shutdown paths that exit right after).
+func (n *WebhookNotifier) EmitSync(ctx context.Context, event string, data interface{}) error {
+	if n == nil {
+		return nil
+	}
+	payload := WebhookPayload{
+		ID:        uuid.NewString(),
+		Event:     event,
+		Source:    n.source,
+		CreatedAt: time.Now(),
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	var errs []string
+	for _, t := range n.targets {
+		if !MatchWebhookEvent(t.Events, event) {
+			continue
+		}
+		if err := n.deliver(ctx, t, payload, body); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", t.URL, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// deliver POST + 재시도 (네트워크 오류/429/5xx만, 1s → 2s 백오프)
+func (n *WebhookNotifier) deliver(ctx context.Context, t WebhookTarget, p WebhookPayload, body []byte) error {
+	var lastErr error
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(1<<(attempt-1)) * time.Second):
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		ts := time.Now().Unix()
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "traveler-webhook/1")
+		req.Header.Set("X-Traveler-Event", p.Event)
+		req.Header.Set("X-Traveler-Delivery", p.ID)
+		req.Header.Set("X-Traveler-Timestamp", strconv.FormatInt(ts, 10))
+		if t.Secret != "" {
+			req.Header.Set("X-Traveler-Signature", SignWebhook(t.Secret, ts, body))
+		}
+
+		resp, err := n.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		if resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return lastErr // 4xx는 재시도해도 같은 결과
+		}
+	}
+	return lastErr
+}
+
+// SignWebhook "sha256=" + hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// MatchWebhookEvent 이벤트 필터 매칭 (빈 필터 = 전체, "*" 전체, "order.*" 접두)
+func MatchWebhookEvent(filters []string, event string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if f == "*" || f == event {
+			return true
+		}
+		if strings.HasSuffix(f, ".*") && strings.HasPrefix(event, strings.TrimSuffix(f, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// wants 어느 대상이라도 이 이벤트를 받는지
+func (n *WebhookNotifier) wants(event string) bool {
+	for _, t := range n.targets {
+		if MatchWebhookEvent(t.Events, event) {
+			return true
+		}
+	}
+	return false
+}
+
+func validEventFilter(f string) bool {
+	for _, e := range WebhookEvents {
+		if MatchWebhookEvent([]string{f}, e) {
+			return true
+		}
+	}
+	return false
+}
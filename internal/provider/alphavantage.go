@@ -87,7 +87,7 @@ func (p *AlphaVantageProvider) GetIntradayData(ctx context.Context, symbol strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -109,7 +109,7 @@ func (p *AlphaVantageProvider) GetIntradayData(ctx context.Context, symbol strin
 	// Get the correct time series based on interval
 	timeSeries := p.getTimeSeries(&data, interval)
 	if len(timeSeries) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	candles := p.parseTimeSeries(timeSeries, date)
@@ -211,7 +211,7 @@ func (p *AlphaVantageProvider) GetMultiDayIntraday(ctx context.Context, symbol s
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -232,7 +232,7 @@ func (p *AlphaVantageProvider) GetMultiDayIntraday(ctx context.Context, symbol s
 
 	timeSeries := p.getTimeSeries(&data, interval)
 	if len(timeSeries) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	// Group all candles by date
@@ -315,7 +315,7 @@ func (p *AlphaVantageProvider) GetDailyCandles(ctx context.Context, symbol strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -15,6 +15,7 @@ type CachingProvider struct {
 	cache   map[string][]model.Candle
 	mu      sync.Mutex
 	maxDays int
+	health  *SymbolHealthStore // nil이면 실패 추적 안 함
 }
 
 // NewCachingProvider creates a caching wrapper. maxDays is the number of days
@@ -27,6 +28,11 @@ func NewCachingProvider(inner Provider, maxDays int) *CachingProvider {
 	}
 }
 
+// SetHealthStore 영구 실패 종목 추적/자동 제외 활성화
+func (p *CachingProvider) SetHealthStore(h *SymbolHealthStore) {
+	p.health = h
+}
+
 func (p *CachingProvider) Name() string      { return p.inner.Name() }
 func (p *CachingProvider) IsAvailable() bool  { return p.inner.IsAvailable() }
 func (p *CachingProvider) RateLimit() int     { return p.inner.RateLimit() }
@@ -54,6 +60,10 @@ func (p *CachingProvider) GetDailyCandles(ctx context.Context, symbol string, da
 	}
 	p.mu.Unlock()
 
+	if p.health != nil && p.health.IsPruned(symbol) {
+		return nil, errPruned(p.Name(), symbol)
+	}
+
 	// Fetch max days to satisfy all strategies in one call
 	fetchDays := p.maxDays
 	if days > fetchDays {
@@ -61,6 +71,9 @@ func (p *CachingProvider) GetDailyCandles(ctx context.Context, symbol string, da
 	}
 
 	candles, err := p.inner.GetDailyCandles(ctx, symbol, fetchDays)
+	if p.health != nil {
+		p.health.RecordResult(symbol, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,395 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+	"traveler/pkg/model"
+)
+
+// Data source modes for daily candles
+const (
+	DataSourceNetwork = "network" // 항상 API 조회 (StoreProvider로 감싸면 받은 일봉을 캐시에 병합)
+	DataSourceAuto    = "auto"    // 로컬 캐시 우선, 부족/오래되면 API 조회 후 캐시에 병합
+	DataSourceCache   = "cache"   // 오프라인: 로컬 캐시만 사용, 네트워크 접근 없음
+)
+
+// ParseDataSource --data-source 값 검증 ("offline"은 cache 별칭)
+func ParseDataSource(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", DataSourceNetwork:
+		return DataSourceNetwork, nil
+	case DataSourceAuto:
+		return DataSourceAuto, nil
+	case DataSourceCache, "offline", "cache-only":
+		return DataSourceCache, nil
+	}
+	return "", fmt.Errorf("unknown data source %q (use network, auto or cache)", s)
+}
+
+// CandleStore 영구 일봉 캐시 (dataDir/candles/daily/{SYMBOL}.json, 종목당 파일 하나, 날짜순)
+type CandleStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewCandleStore 생성자
+func NewCandleStore(dataDir string) *CandleStore {
+	return &CandleStore{dir: filepath.Join(dataDir, "candles", "daily")}
+}
+
+// Dir 캐시 디렉토리
+func (s *CandleStore) Dir() string { return s.dir }
+
+// Path 종목 캐시 파일 경로
+func (s *CandleStore) Path(symbol string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(strings.ToUpper(symbol))
+	return filepath.Join(s.dir, name+".json")
+}
+
+// Load 캐시된 일봉 전체 (없으면 os.ErrNotExist)
+func (s *CandleStore) Load(symbol string) ([]model.Candle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(symbol)
+}
+
+func (s *CandleStore) load(symbol string) ([]model.Candle, error) {
+	data, err := os.ReadFile(s.Path(symbol))
+	if err != nil {
+		return nil, err
+	}
+	var candles []model.Candle
+	if err := json.Unmarshal(data, &candles); err != nil {
+		return nil, fmt.Errorf("corrupt cache %s: %w", s.Path(symbol), err)
+	}
+	return candles, nil
+}
+
+// Symbols 캐시된 종목 목록 (파일명 기준, 정렬)
+func (s *CandleStore) Symbols() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var syms []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			syms = append(syms, strings.TrimSuffix(e.Name(), ".json"))
+		}
+	}
+	sort.Strings(syms)
+	return syms, nil
+}
+
+// Replace 일봉 전체를 그대로 저장 (정리/복구용)
+func (s *CandleStore) Replace(symbol string, candles []model.Candle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(candles)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(s.Path(symbol), data, 0644)
+}
+
+// Remove 종목 일봉 캐시 삭제
+func (s *CandleStore) Remove(symbol string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return os.Remove(s.Path(symbol))
+}
+
+// IntradayDir 분봉 캐시 루트 (candles/intraday)
+func (s *CandleStore) IntradayDir() string {
+	return filepath.Join(filepath.Dir(s.dir), "intraday")
+}
+
+// Merge 새 일봉을 기존 캐시에 병합 (같은 날짜는 새 값으로 교체) 후 저장, 병합 결과 반환
+// fetch, 웹, 데몬이 같은 캐시를 채우므로 파일 잠금 안에서 다시 읽고 병합한다.
+func (s *CandleStore) Merge(symbol string, candles []model.Candle) ([]model.Candle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var merged []model.Candle
+	err := fsutil.Update(s.Path(symbol), func() error {
+		var err error
+		merged, err = s.mergeLocked(symbol, candles)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func (s *CandleStore) mergeLocked(symbol string, candles []model.Candle) ([]model.Candle, error) {
+	existing, _ := s.load(symbol) // 손상된 캐시는 새 데이터로 덮어씀
+	byDate := make(map[string]model.Candle, len(existing)+len(candles))
+	for _, c := range existing {
+		byDate[c.Time.Format("2006-01-02")] = c
+	}
+	for _, c := range candles {
+		byDate[c.Time.Format("2006-01-02")] = c
+	}
+	merged := make([]model.Candle, 0, len(byDate))
+	for _, c := range byDate {
+		merged = append(merged, c)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Time.Before(merged[j].Time) })
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if err := fsutil.WriteFile(s.Path(symbol), data, 0644); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// ValidateCandles 일봉 무결성 검사 → 문제 있는 봉 인덱스와 사유 (날짜순 전제)
+// 가격 0/음수, 고가<저가, 시가/종가가 고저 범위 밖, 중복/역순 날짜를 잡는다.
+func ValidateCandles(candles []model.Candle) map[int]string {
+	issues := make(map[int]string)
+	for i, c := range candles {
+		switch {
+		case c.Open <= 0 || c.High <= 0 || c.Low <= 0 || c.Close <= 0:
+			issues[i] = "non-positive price"
+		case c.High < c.Low:
+			issues[i] = "high < low"
+		case c.Open > c.High*1.0001 || c.Open < c.Low*0.9999 || c.Close > c.High*1.0001 || c.Close < c.Low*0.9999:
+			issues[i] = "open/close outside high-low range"
+		case c.Volume < 0:
+			issues[i] = "negative volume"
+		case i > 0 && !c.Time.After(candles[i-1].Time):
+			issues[i] = "duplicate or out-of-order date"
+		}
+	}
+	return issues
+}
+
+// DropInvalid 무결성 검사에 걸린 봉 제거 → (정상 봉, 제거 수)
+func DropInvalid(candles []model.Candle) ([]model.Candle, int) {
+	issues := ValidateCandles(candles)
+	if len(issues) == 0 {
+		return candles, 0
+	}
+	out := make([]model.Candle, 0, len(candles)-len(issues))
+	for i, c := range candles {
+		if _, bad := issues[i]; !bad {
+			out = append(out, c)
+		}
+	}
+	return out, len(issues)
+}
+
+// IntradayPath 분봉 캐시 파일 경로 (candles/intraday/{SYMBOL}/{date}_{interval}m.json)
+func (s *CandleStore) IntradayPath(symbol string, date time.Time, interval int) string {
+	base := strings.TrimSuffix(filepath.Base(s.Path(symbol)), ".json")
+	return filepath.Join(s.IntradayDir(), base, fmt.Sprintf("%s_%dm.json", date.Format("2006-01-02"), interval))
+}
+
+// SaveIntraday 하루치 분봉 저장 (빈 데이터는 건너뜀)
+func (s *CandleStore) SaveIntraday(symbol string, interval int, day model.IntradayData) error {
+	if len(day.Candles) == 0 {
+		return nil
+	}
+	path := s.IntradayPath(symbol, day.Date, interval)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(day)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(path, data, 0644)
+}
+
+// LoadIntraday 캐시된 하루치 분봉
+func (s *CandleStore) LoadIntraday(symbol string, date time.Time, interval int) (*model.IntradayData, error) {
+	data, err := os.ReadFile(s.IntradayPath(symbol, date, interval))
+	if err != nil {
+		return nil, err
+	}
+	var day model.IntradayData
+	if err := json.Unmarshal(data, &day); err != nil {
+		return nil, fmt.Errorf("corrupt intraday cache: %w", err)
+	}
+	return &day, nil
+}
+
+// isFresh 마지막 일봉이 직전 평일 이후인지 (휴장일엔 한 번 더 조회될 수 있음)
+// 캐시에는 오늘 봉이 없으므로 직전 평일 봉까지 있으면 최신으로 본다.
+func isFresh(candles []model.Candle, now time.Time) bool {
+	if len(candles) == 0 {
+		return false
+	}
+	prev := now.AddDate(0, 0, -1)
+	for prev.Weekday() == time.Saturday || prev.Weekday() == time.Sunday {
+		prev = prev.AddDate(0, 0, -1)
+	}
+	return !candles[len(candles)-1].Time.Before(time.Date(prev.Year(), prev.Month(), prev.Day(), 0, 0, 0, 0, candles[len(candles)-1].Time.Location()))
+}
+
+// StoreProvider 일봉을 CandleStore로 라우팅하는 Provider 래퍼.
+// cache 모드에서는 네트워크를 전혀 쓰지 않고, 캐시에 없는 종목/분봉 조회는 에러.
+// network 모드는 항상 API를 조회하되 받은 일봉을 캐시에 남긴다 (백테스트가 받은 데이터를 스캔이 재사용).
+type StoreProvider struct {
+	inner  Provider
+	store  *CandleStore
+	mode   string
+	maxAge time.Duration // auto 모드: > 0이면 캐시 파일이 이 시간 안에 갱신됐을 때만 사용
+}
+
+// NewStoreProvider 생성자 (mode: DataSourceNetwork / DataSourceAuto / DataSourceCache)
+func NewStoreProvider(inner Provider, store *CandleStore, mode string) *StoreProvider {
+	return &StoreProvider{inner: inner, store: store, mode: mode}
+}
+
+// SetMaxAge auto 모드에서 캐시를 믿는 최대 경과 시간 (스캔은 장중 갱신이 필요해 짧게 둔다)
+func (p *StoreProvider) SetMaxAge(d time.Duration) {
+	p.maxAge = d
+}
+
+// recent 캐시 파일이 maxAge 안에 갱신됐는지 (maxAge <= 0이면 항상 true)
+func (p *StoreProvider) recent(symbol string) bool {
+	if p.maxAge <= 0 {
+		return true
+	}
+	info, err := os.Stat(p.store.Path(symbol))
+	return err == nil && time.Since(info.ModTime()) < p.maxAge
+}
+
+// errOffline 오프라인 모드에서 캐시 미스
+func errOffline(symbol, what string) error {
+	return fmt.Errorf("offline: no cached %s for %s (run 'traveler fetch' or use --data-source auto)", what, symbol)
+}
+
+func (p *StoreProvider) Name() string {
+	switch p.mode {
+	case DataSourceCache:
+		return "cache"
+	case DataSourceNetwork:
+		return p.inner.Name()
+	}
+	return p.inner.Name() + "+cache"
+}
+
+func (p *StoreProvider) IsAvailable() bool {
+	return p.mode == DataSourceCache || p.inner.IsAvailable()
+}
+
+func (p *StoreProvider) RateLimit() int { return p.inner.RateLimit() }
+
+func (p *StoreProvider) GetIntradayData(ctx context.Context, symbol string, date time.Time, interval int) (*model.IntradayData, error) {
+	if p.mode == DataSourceNetwork {
+		return p.inner.GetIntradayData(ctx, symbol, date, interval)
+	}
+	if day, err := p.store.LoadIntraday(symbol, date, interval); err == nil {
+		return day, nil
+	}
+	if p.mode == DataSourceCache {
+		return nil, errOffline(symbol, "intraday data")
+	}
+	return p.inner.GetIntradayData(ctx, symbol, date, interval)
+}
+
+func (p *StoreProvider) GetMultiDayIntraday(ctx context.Context, symbol string, days int, interval int) ([]model.IntradayData, error) {
+	if p.mode == DataSourceCache {
+		return nil, errOffline(symbol, "intraday data")
+	}
+	return p.inner.GetMultiDayIntraday(ctx, symbol, days, interval)
+}
+
+func (p *StoreProvider) GetSymbols(ctx context.Context, exchange string) ([]model.Stock, error) {
+	if p.mode == DataSourceCache {
+		return nil, fmt.Errorf("offline: symbol lists are not cached")
+	}
+	return p.inner.GetSymbols(ctx, exchange)
+}
+
+func (p *StoreProvider) GetDailyCandles(ctx context.Context, symbol string, days int) ([]model.Candle, error) {
+	if p.mode == DataSourceNetwork {
+		candles, err := p.inner.GetDailyCandles(ctx, symbol, days)
+		if err == nil {
+			if completed, _ := SplitForming(candles, time.Now()); len(completed) > 0 {
+				p.store.Merge(symbol, completed) // 캐시 저장 실패는 결과에 영향 없음
+			}
+		}
+		return candles, err
+	}
+
+	cached, _ := p.store.Load(symbol)
+
+	if p.mode == DataSourceCache {
+		if len(cached) == 0 {
+			return nil, errOffline(symbol, "daily candles")
+		}
+		return tailCandles(cached, days), nil
+	}
+
+	if len(cached) >= days && isFresh(cached, time.Now()) && p.recent(symbol) {
+		return tailCandles(cached, days), nil
+	}
+
+	candles, err := p.inner.GetDailyCandles(ctx, symbol, days)
+	if err != nil {
+		// 네트워크 실패 시 오래된 캐시라도 반환 (maxAge를 둔 스캔은 오래된 데이터로 시그널을 내지 않게 에러)
+		if len(cached) > 0 && p.maxAge <= 0 {
+			return tailCandles(cached, days), nil
+		}
+		return nil, err
+	}
+	completed, forming := SplitForming(candles, time.Now())
+	if len(completed) == 0 {
+		return candles, nil
+	}
+	merged, err := p.store.Merge(symbol, completed)
+	if err != nil {
+		return candles, nil
+	}
+	if forming != nil {
+		merged = append(merged, *forming)
+	}
+	return tailCandles(merged, days), nil
+}
+
+// SplitForming 오늘 날짜 봉(아직 형성 중일 수 있음)을 떼어낸다.
+// 캐시에는 완성된 봉만 저장해야 장중 가격이 그날의 일봉으로 굳지 않는다.
+func SplitForming(candles []model.Candle, now time.Time) ([]model.Candle, *model.Candle) {
+	if len(candles) == 0 {
+		return candles, nil
+	}
+	last := candles[len(candles)-1]
+	y, m, d := now.In(last.Time.Location()).Date()
+	ly, lm, ld := last.Time.Date()
+	if ly != y || lm != m || ld != d {
+		return candles, nil
+	}
+	return candles[:len(candles)-1], &last
+}
+
+func tailCandles(candles []model.Candle, days int) []model.Candle {
+	if days > 0 && len(candles) > days {
+		return candles[len(candles)-days:]
+	}
+	return candles
+}
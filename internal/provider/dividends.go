@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// dividendCacheTTL 종목별 배당 이력 캐시 유효기간
+const dividendCacheTTL = 24 * time.Hour
+
+// DividendEvent 배당락일 및 주당 배당금
+type DividendEvent struct {
+	Symbol    string    `json:"symbol"`
+	ExDate    time.Time `json:"ex_date"`
+	Amount    float64   `json:"amount"`
+	Projected bool      `json:"projected,omitempty"` // 과거 주기로 추정한 예정 배당락일
+}
+
+// yahooDividendResponse chart API events=div 응답
+type yahooDividendResponse struct {
+	Chart struct {
+		Result []struct {
+			Events struct {
+				Dividends map[string]struct {
+					Amount float64 `json:"amount"`
+					Date   int64   `json:"date"`
+				} `json:"dividends"`
+			} `json:"events"`
+		} `json:"result"`
+		Error *struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"chart"`
+}
+
+// GetDividends 최근 years년 배당 이력 (배당락일 오름차순)
+// 한국 종목은 .KS → .KQ 순으로 조회한다. 배당이 없으면 빈 슬라이스.
+func (p *YahooProvider) GetDividends(ctx context.Context, symbol string, years int) ([]DividendEvent, error) {
+	if years <= 0 {
+		years = 2
+	}
+	candidates := []string{symbol}
+	if isKoreanSymbol(symbol) {
+		candidates = []string{symbol + ".KS", symbol + ".KQ"}
+	}
+
+	var lastErr error
+	for _, ySym := range candidates {
+		events, err := p.fetchDividends(ctx, ySym, years)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for i := range events {
+			events[i].Symbol = symbol
+		}
+		return events, nil
+	}
+	return nil, lastErr
+}
+
+func (p *YahooProvider) fetchDividends(ctx context.Context, ySymbol string, years int) ([]DividendEvent, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// period2를 미래로 잡아 이미 공시된 예정 배당락일도 포함
+	now := time.Now()
+	url := fmt.Sprintf("%s/%s?period1=%d&period2=%d&interval=1d&events=div",
+		yahooBaseURL, ySymbol, now.AddDate(-years, 0, 0).Unix(), now.AddDate(0, 0, 90).Unix())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, &ProviderError{Provider: p.Name(), Err: err, Retryable: true}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		p.limiter.SignalRateLimited()
+		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("rate limited"), Retryable: true}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
+	}
+	p.limiter.ResetBackoff()
+
+	var data yahooDividendResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if data.Chart.Error != nil {
+		return nil, &ProviderError{Provider: p.Name(), Err: chartError(data.Chart.Error.Code, data.Chart.Error.Description), Retryable: false}
+	}
+	if len(data.Chart.Result) == 0 {
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
+	}
+
+	events := make([]DividendEvent, 0, len(data.Chart.Result[0].Events.Dividends))
+	for _, d := range data.Chart.Result[0].Events.Dividends {
+		if d.Amount <= 0 || d.Date == 0 {
+			continue
+		}
+		events = append(events, DividendEvent{ExDate: time.Unix(d.Date, 0), Amount: d.Amount})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ExDate.Before(events[j].ExDate) })
+	return events, nil
+}
+
+// ProjectNextExDate after 이후 첫 배당락일
+// 이력에 이미 미래 배당락일이 있으면 그대로, 없으면 최근 배당 간격(중앙값)으로 추정한다.
+// 마지막 배당이 주기의 2배 넘게 지났으면 배당 중단으로 보고 false.
+func ProjectNextExDate(history []DividendEvent, after time.Time) (DividendEvent, bool) {
+	if len(history) == 0 {
+		return DividendEvent{}, false
+	}
+	for _, ev := range history {
+		if ev.ExDate.After(after) {
+			return ev, true
+		}
+	}
+	if len(history) < 2 {
+		return DividendEvent{}, false
+	}
+
+	intervals := make([]float64, 0, len(history)-1)
+	for i := 1; i < len(history); i++ {
+		intervals = append(intervals, history[i].ExDate.Sub(history[i-1].ExDate).Hours()/24)
+	}
+	sort.Float64s(intervals)
+	median := intervals[len(intervals)/2]
+	if median < 20 {
+		return DividendEvent{}, false
+	}
+
+	last := history[len(history)-1]
+	if after.Sub(last.ExDate).Hours()/24 > median*2 {
+		return DividendEvent{}, false
+	}
+	next := last.ExDate.AddDate(0, 0, int(median+0.5))
+	for !next.After(after) {
+		next = next.AddDate(0, 0, int(median+0.5))
+	}
+	return DividendEvent{Symbol: last.Symbol, ExDate: next, Amount: last.Amount, Projected: true}, true
+}
+
+// dividendEntry 종목별 캐시 항목
+type dividendEntry struct {
+	History   []DividendEvent `json:"history"`
+	FetchedAt time.Time       `json:"fetched_at"`
+}
+
+// DividendCalendar 배당 이력 캐시 (dividends.json, 종목별 24시간)
+type DividendCalendar struct {
+	mu      sync.Mutex
+	path    string
+	yahoo   *YahooProvider
+	entries map[string]*dividendEntry
+}
+
+// NewDividendCalendar 생성자
+func NewDividendCalendar(dataDir string) *DividendCalendar {
+	c := &DividendCalendar{
+		path:    filepath.Join(dataDir, "dividends.json"),
+		yahoo:   NewYahooProvider(),
+		entries: make(map[string]*dividendEntry),
+	}
+	if data, err := os.ReadFile(c.path); err == nil {
+		if err := json.Unmarshal(data, &c.entries); err != nil {
+			log.Printf("[DIVIDEND] Warning: could not parse %s: %v", c.path, err)
+			c.entries = make(map[string]*dividendEntry)
+		}
+	}
+	return c
+}
+
+// History 종목 배당 이력 (캐시 만료 시 Yahoo 재조회, 실패하면 만료된 캐시라도 반환)
+func (c *DividendCalendar) History(ctx context.Context, symbol string) ([]DividendEvent, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[symbol]
+	c.mu.Unlock()
+	if ok && time.Since(entry.FetchedAt) < dividendCacheTTL {
+		return entry.History, nil
+	}
+
+	history, err := c.yahoo.GetDividends(ctx, symbol, 3)
+	if err != nil {
+		if ok {
+			return entry.History, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[symbol] = &dividendEntry{History: history, FetchedAt: time.Now()}
+	c.persist()
+	return history, nil
+}
+
+// NextExDate 오늘 이후 첫 배당락일 (배당 없음/중단이면 false)
+func (c *DividendCalendar) NextExDate(ctx context.Context, symbol string) (DividendEvent, bool) {
+	history, err := c.History(ctx, symbol)
+	if err != nil {
+		return DividendEvent{}, false
+	}
+	return ProjectNextExDate(history, time.Now())
+}
+
+func (c *DividendCalendar) persist() {
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		log.Printf("[DIVIDEND] Warning: could not save %s: %v", c.path, err)
+	}
+}
+
+// DividendsBetween (from, to] 구간 배당락일 이벤트 — 보유 중 받는 배당
+// 배당락일 전날 종가에 보유해야 배당을 받으므로 진입일 당일 배당락은 제외, 청산일 당일은 포함.
+func DividendsBetween(history []DividendEvent, from, to time.Time) []DividendEvent {
+	fromDay, toDay := from.Format("2006-01-02"), to.Format("2006-01-02")
+	var out []DividendEvent
+	for _, ev := range history {
+		day := ev.ExDate.Format("2006-01-02")
+		if day > fromDay && day <= toDay {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
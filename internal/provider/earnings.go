@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// calendarEventsResponse quoteSummary calendarEvents 모듈 응답
+type calendarEventsResponse struct {
+	QuoteSummary struct {
+		Result []struct {
+			CalendarEvents *struct {
+				Earnings struct {
+					EarningsDate []yfValue `json:"earningsDate"` // Raw = unix seconds (예정일 범위)
+				} `json:"earnings"`
+			} `json:"calendarEvents"`
+		} `json:"result"`
+		Error *struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"quoteSummary"`
+}
+
+// NextEarningsDate 다음 실적 발표 예정일 (Init 필요, 예정 없으면 zero time)
+// Yahoo가 범위(예: 1/28~2/1)로 줄 때는 가장 이른 날짜를 쓴다.
+func (f *FundamentalsChecker) NextEarningsDate(ctx context.Context, symbol string) (time.Time, error) {
+	url := fmt.Sprintf("https://query2.finance.yahoo.com/v10/finance/quoteSummary/%s?modules=calendarEvents&crumb=%s",
+		f.toYahooSymbol(symbol), f.crumb)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	req.Header.Set("User-Agent", yahooUserAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var data calendarEventsResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return time.Time{}, fmt.Errorf("parse error: %w", err)
+	}
+	if data.QuoteSummary.Error != nil {
+		return time.Time{}, fmt.Errorf("API error: %s", data.QuoteSummary.Error.Description)
+	}
+	if len(data.QuoteSummary.Result) == 0 || data.QuoteSummary.Result[0].CalendarEvents == nil {
+		return time.Time{}, nil
+	}
+
+	var next time.Time
+	today := time.Now().Truncate(24 * time.Hour)
+	for _, d := range data.QuoteSummary.Result[0].CalendarEvents.Earnings.EarningsDate {
+		if d.Raw <= 0 {
+			continue
+		}
+		t := time.Unix(int64(d.Raw), 0)
+		if t.Before(today) {
+			continue
+		}
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next, nil
+}
@@ -97,7 +97,7 @@ func (p *FinnhubProvider) GetIntradayData(ctx context.Context, symbol string, da
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -108,7 +108,7 @@ func (p *FinnhubProvider) GetIntradayData(ctx context.Context, symbol string, da
 	}
 
 	if data.S != "ok" || len(data.T) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	candles := make([]model.Candle, len(data.T))
@@ -163,7 +163,7 @@ func (p *FinnhubProvider) GetMultiDayIntraday(ctx context.Context, symbol string
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -174,7 +174,7 @@ func (p *FinnhubProvider) GetMultiDayIntraday(ctx context.Context, symbol string
 	}
 
 	if data.S != "ok" || len(data.T) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	// Group candles by date
@@ -247,7 +247,7 @@ func (p *FinnhubProvider) GetSymbols(ctx context.Context, exchange string) ([]mo
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -301,7 +301,7 @@ func (p *FinnhubProvider) GetDailyCandles(ctx context.Context, symbol string, da
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -312,7 +312,7 @@ func (p *FinnhubProvider) GetDailyCandles(ctx context.Context, symbol string, da
 	}
 
 	if data.S == "no_data" || len(data.T) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	loc, _ := time.LoadLocation("America/New_York")
@@ -42,6 +42,7 @@ type FundamentalsChecker struct {
 	cacheDir   string
 	kosdaqSyms map[string]bool // KOSDAQ symbols for .KQ suffix
 	cache      map[string]FundamentalsData
+	sectors    map[string]string // 업종 (프로세스 메모리 캐시)
 	mu         sync.Mutex
 }
 
@@ -94,6 +95,7 @@ func NewFundamentalsChecker(cacheDir string, kosdaqSyms map[string]bool) *Fundam
 		cacheDir:   cacheDir,
 		kosdaqSyms: kosdaqSyms,
 		cache:      make(map[string]FundamentalsData),
+		sectors:    make(map[string]string),
 	}
 	f.loadDayCache()
 	return f
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+)
+
+const (
+	// PruneFailureDays 서로 다른 날 연속 실패 횟수가 이 값에 도달하면 자동 제외
+	PruneFailureDays = 3
+	// PruneDuration 자동 제외 기간 (이후 다시 한 번 시도)
+	PruneDuration = 14 * 24 * time.Hour
+)
+
+// SymbolHealth 종목별 데이터 조회 실패 이력
+type SymbolHealth struct {
+	Symbol       string    `json:"symbol"`
+	FailureDays  int       `json:"failure_days"` // 서로 다른 날 연속 실패 횟수
+	LastError    string    `json:"last_error"`
+	FirstFailure time.Time `json:"first_failure"`
+	LastFailure  time.Time `json:"last_failure"`
+	PrunedUntil  time.Time `json:"pruned_until,omitempty"`
+}
+
+// IsPruned 현재 자동 제외 중인지
+func (h SymbolHealth) IsPruned() bool {
+	return !h.PrunedUntil.IsZero() && time.Now().Before(h.PrunedUntil)
+}
+
+// SymbolHealthStore 상장폐지/잘못된 심볼 추적 (symbol_health.json)
+// 종목 없음 오류(404, 빈 응답 — ErrSymbolNotFound)만 집계하고, 성공하면 기록을 지운다.
+type SymbolHealthStore struct {
+	mu      sync.Mutex
+	path    string
+	symbols map[string]*SymbolHealth
+}
+
+// NewSymbolHealthStore 생성자
+func NewSymbolHealthStore(dataDir string) (*SymbolHealthStore, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	s := &SymbolHealthStore{
+		path:    filepath.Join(dataDir, "symbol_health.json"),
+		symbols: make(map[string]*SymbolHealth),
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &s.symbols); err != nil {
+			log.Printf("[PRUNE] Warning: could not parse %s: %v", s.path, err)
+			s.symbols = make(map[string]*SymbolHealth)
+		}
+	}
+	return s, nil
+}
+
+// IsPruned 심볼이 자동 제외 중인지
+func (s *SymbolHealthStore) IsPruned(symbol string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.symbols[symbol]
+	return ok && h.IsPruned()
+}
+
+// RecordResult 조회 결과 기록 (err == nil이면 성공)
+func (s *SymbolHealthStore) RecordResult(symbol string, err error) {
+	if err == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.symbols[symbol]; ok {
+			delete(s.symbols, symbol)
+			s.persist(symbol)
+		}
+		return
+	}
+	if !isPermanentError(err) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	h, ok := s.symbols[symbol]
+	if !ok {
+		h = &SymbolHealth{Symbol: symbol, FirstFailure: now}
+		s.symbols[symbol] = h
+	}
+	// 같은 날 여러 번 실패는 1회로 집계 (한 번의 스캔에서 바로 제외되지 않도록),
+	// 실패 없이 지나간 평일이 있으면 연속이 끊긴 것으로 보고 다시 센다
+	switch {
+	case h.FailureDays > 0 && h.LastFailure.Format("2006-01-02") == now.Format("2006-01-02"):
+	case h.FailureDays > 0 && skippedWeekday(h.LastFailure, now) && !h.IsPruned():
+		h.FailureDays = 1
+		h.FirstFailure = now
+	default:
+		h.FailureDays++
+	}
+	h.LastFailure = now
+	h.LastError = err.Error()
+	if h.FailureDays >= PruneFailureDays && !h.IsPruned() {
+		h.PrunedUntil = now.Add(PruneDuration)
+		log.Printf("[PRUNE] %s pruned until %s (%d failure days: %s)",
+			symbol, h.PrunedUntil.Format("2006-01-02"), h.FailureDays, h.LastError)
+	}
+	s.persist(symbol)
+}
+
+// Unprune 자동 제외 해제 (실패 이력 삭제)
+func (s *SymbolHealthStore) Unprune(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.symbols, symbol)
+	s.persist(symbol)
+}
+
+// Pruned 현재 자동 제외 중인 종목 리포트 (심볼순)
+func (s *SymbolHealthStore) Pruned() []SymbolHealth {
+	return s.list(true)
+}
+
+// Failing 실패 이력이 있지만 아직 제외되지 않은 종목 (심볼순)
+func (s *SymbolHealthStore) Failing() []SymbolHealth {
+	return s.list(false)
+}
+
+func (s *SymbolHealthStore) list(pruned bool) []SymbolHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []SymbolHealth
+	for _, h := range s.symbols {
+		if h.IsPruned() == pruned {
+			result = append(result, *h)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+	return result
+}
+
+// persist 바뀐 종목 하나만 디스크 최신본에 병합해 저장 (웹과 마켓별 데몬이 같은 파일을 쓴다)
+// 다른 프로세스가 기록한 종목은 메모리에도 반영된다.
+func (s *SymbolHealthStore) persist(symbol string) {
+	err := fsutil.Update(s.path, func() error {
+		onDisk := make(map[string]*SymbolHealth)
+		data, err := os.ReadFile(s.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &onDisk); err != nil {
+				return err
+			}
+		}
+		if onDisk == nil {
+			onDisk = make(map[string]*SymbolHealth)
+		}
+		if h, ok := s.symbols[symbol]; ok {
+			onDisk[symbol] = h
+		} else {
+			delete(onDisk, symbol)
+		}
+		s.symbols = onDisk
+		data, err = json.MarshalIndent(s.symbols, "", "  ")
+		if err != nil {
+			return err
+		}
+		return fsutil.WriteFile(s.path, data, 0644)
+	})
+	if err != nil {
+		log.Printf("[PRUNE] Warning: could not save %s: %v", s.path, err)
+	}
+}
+
+// ErrSymbolNotFound 종목 자체가 없음 (404, 빈 응답, Yahoo "Not Found") — 자동 제외 판정 기준
+var ErrSymbolNotFound = errors.New("symbol not found")
+
+// notFoundError 원래 메시지를 유지하면서 ErrSymbolNotFound로 판정되는 에러
+type notFoundError struct{ msg string }
+
+func (e notFoundError) Error() string { return e.msg }
+
+func (e notFoundError) Is(target error) bool { return target == ErrSymbolNotFound }
+
+// errNoData 정상 응답인데 캔들이 없음 (알 수 없는 심볼)
+var errNoData error = notFoundError{"no data available"}
+
+// statusError HTTP 상태 코드 에러 (404만 종목 없음으로 분류 — 401/403 인증 오류와 5xx 장애는 제외 대상 아님)
+func statusError(code int) error {
+	if code == http.StatusNotFound {
+		return notFoundError{fmt.Sprintf("status %d", code)}
+	}
+	return fmt.Errorf("status %d", code)
+}
+
+// chartError Yahoo chart 응답의 error 객체 (code "Not Found"만 종목 없음)
+func chartError(code, description string) error {
+	if strings.EqualFold(code, "Not Found") {
+		return notFoundError{description}
+	}
+	return fmt.Errorf("%s", description)
+}
+
+// isPermanentError 재시도해도 소용없는 오류인지 (404/알 수 없는 심볼만 — 키 오류나 제공자 장애로 유니버스가 줄지 않도록)
+func isPermanentError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return errors.Is(err, ErrSymbolNotFound)
+}
+
+// skippedWeekday last와 now 사이(양 끝 제외)에 실패 기록이 없는 평일이 있는지 (연속 실패가 끊겼는지)
+func skippedWeekday(last, now time.Time) bool {
+	day := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if wd := day.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			return true
+		}
+	}
+	return false
+}
+
+// errPruned 자동 제외된 심볼 조회 시 반환
+func errPruned(name, symbol string) error {
+	return &ProviderError{Provider: name, Err: fmt.Errorf("%s pruned (persistent failures)", symbol), Retryable: false}
+}
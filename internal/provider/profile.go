@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// assetProfileResponse quoteSummary assetProfile 모듈 응답
+type assetProfileResponse struct {
+	QuoteSummary struct {
+		Result []struct {
+			AssetProfile *struct {
+				Sector   string `json:"sector"`
+				Industry string `json:"industry"`
+			} `json:"assetProfile"`
+		} `json:"result"`
+		Error *struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"quoteSummary"`
+}
+
+// Sector 종목 업종 (Yahoo 업종명: Technology, Energy, Financial Services ...)
+// Init 필요. 프로세스 안에서 캐시하며, ETF처럼 업종이 없으면 빈 문자열.
+func (f *FundamentalsChecker) Sector(ctx context.Context, symbol string) (string, error) {
+	f.mu.Lock()
+	if s, ok := f.sectors[symbol]; ok {
+		f.mu.Unlock()
+		return s, nil
+	}
+	f.mu.Unlock()
+
+	url := fmt.Sprintf("https://query2.finance.yahoo.com/v10/finance/quoteSummary/%s?modules=assetProfile&crumb=%s",
+		f.toYahooSymbol(symbol), f.crumb)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", yahooUserAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var data assetProfileResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("parse error: %w", err)
+	}
+	if data.QuoteSummary.Error != nil {
+		return "", fmt.Errorf("API error: %s", data.QuoteSummary.Error.Description)
+	}
+
+	var sector string
+	if len(data.QuoteSummary.Result) > 0 && data.QuoteSummary.Result[0].AssetProfile != nil {
+		sector = data.QuoteSummary.Result[0].AssetProfile.Sector
+	}
+	f.mu.Lock()
+	f.sectors[symbol] = sector
+	f.mu.Unlock()
+	return sector, nil
+}
@@ -168,7 +168,7 @@ func (p *UpbitProvider) fetchDailyCandles(ctx context.Context, url string) ([]mo
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -234,7 +234,7 @@ func (p *UpbitProvider) GetIntradayData(ctx context.Context, symbol string, date
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -328,7 +328,7 @@ func (p *UpbitProvider) GetSymbols(ctx context.Context, exchange string) ([]mode
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -401,7 +401,7 @@ func (p *UpbitProvider) GetRecentMinuteCandles(ctx context.Context, symbol strin
 		}
 		if resp.StatusCode != http.StatusOK {
 			resp.Body.Close()
-			return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+			return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 		}
 
 		p.limiter.ResetBackoff()
@@ -102,7 +102,7 @@ func (p *YahooProvider) GetIntradayData(ctx context.Context, symbol string, date
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -113,11 +113,11 @@ func (p *YahooProvider) GetIntradayData(ctx context.Context, symbol string, date
 	}
 
 	if data.Chart.Error != nil {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("%s", data.Chart.Error.Description), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: chartError(data.Chart.Error.Code, data.Chart.Error.Description), Retryable: false}
 	}
 
 	if len(data.Chart.Result) == 0 || len(data.Chart.Result[0].Timestamp) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	result := data.Chart.Result[0]
@@ -192,7 +192,7 @@ func (p *YahooProvider) GetMultiDayIntraday(ctx context.Context, symbol string,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -203,11 +203,11 @@ func (p *YahooProvider) GetMultiDayIntraday(ctx context.Context, symbol string,
 	}
 
 	if data.Chart.Error != nil {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("%s", data.Chart.Error.Description), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: chartError(data.Chart.Error.Code, data.Chart.Error.Description), Retryable: false}
 	}
 
 	if len(data.Chart.Result) == 0 || len(data.Chart.Result[0].Timestamp) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	result := data.Chart.Result[0]
@@ -304,7 +304,7 @@ func (p *YahooProvider) GetDailyCandles(ctx context.Context, symbol string, days
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("status %d", resp.StatusCode), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
 	}
 
 	p.limiter.ResetBackoff()
@@ -315,11 +315,11 @@ func (p *YahooProvider) GetDailyCandles(ctx context.Context, symbol string, days
 	}
 
 	if data.Chart.Error != nil {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("%s", data.Chart.Error.Description), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: chartError(data.Chart.Error.Code, data.Chart.Error.Description), Retryable: false}
 	}
 
 	if len(data.Chart.Result) == 0 || len(data.Chart.Result[0].Timestamp) == 0 {
-		return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("no data available"), Retryable: false}
+		return nil, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
 	}
 
 	result := data.Chart.Result[0]
@@ -363,6 +363,74 @@ func (p *YahooProvider) GetDailyCandles(ctx context.Context, symbol string, days
 	return candles, nil
 }
 
+// GetPreMarketQuote returns the last pre-market trade for today's US session
+// (04:00-09:30 ET). Returns errNoData if the symbol has not traded pre-market yet.
+func (p *YahooProvider) GetPreMarketQuote(ctx context.Context, symbol string) (float64, time.Time, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	loc, _ := time.LoadLocation("America/New_York")
+	now := time.Now().In(loc)
+	preOpen := time.Date(now.Year(), now.Month(), now.Day(), 4, 0, 0, 0, loc)
+	regularOpen := time.Date(now.Year(), now.Month(), now.Day(), 9, 30, 0, 0, loc)
+
+	url := fmt.Sprintf("%s/%s?period1=%d&period2=%d&interval=1m&includePrePost=true",
+		yahooBaseURL, symbol, preOpen.Unix(), regularOpen.Unix())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, time.Time{}, &ProviderError{Provider: p.Name(), Err: err, Retryable: true}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		p.limiter.SignalRateLimited()
+		return 0, time.Time{}, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("rate limited"), Retryable: true}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, time.Time{}, &ProviderError{Provider: p.Name(), Err: statusError(resp.StatusCode), Retryable: false}
+	}
+
+	p.limiter.ResetBackoff()
+
+	var data yahooResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, time.Time{}, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if data.Chart.Error != nil {
+		return 0, time.Time{}, &ProviderError{Provider: p.Name(), Err: chartError(data.Chart.Error.Code, data.Chart.Error.Description), Retryable: false}
+	}
+
+	if len(data.Chart.Result) == 0 || len(data.Chart.Result[0].Indicators.Quote) == 0 {
+		return 0, time.Time{}, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
+	}
+
+	// Latest non-empty pre-market bar (bars without trades come back as null → 0)
+	result := data.Chart.Result[0]
+	closes := result.Indicators.Quote[0].Close
+	for i := len(result.Timestamp) - 1; i >= 0; i-- {
+		if i >= len(closes) || closes[i] == 0 {
+			continue
+		}
+		t := time.Unix(result.Timestamp[i], 0).In(loc)
+		if t.Before(preOpen) || !t.Before(regularOpen) {
+			continue
+		}
+		return closes[i], t, nil
+	}
+
+	return 0, time.Time{}, &ProviderError{Provider: p.Name(), Err: errNoData, Retryable: false}
+}
+
 // GetSymbols is not supported by Yahoo Finance unofficial API
 func (p *YahooProvider) GetSymbols(ctx context.Context, exchange string) ([]model.Stock, error) {
 	return nil, &ProviderError{Provider: p.Name(), Err: fmt.Errorf("symbol listing not supported"), Retryable: false}
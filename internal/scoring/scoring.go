@@ -0,0 +1,198 @@
+// Package scoring 모닝딥 패턴 분석과 전략 시그널이 함께 쓰는 RSI/거래량/추세 점수
+// 두 파이프라인의 결과를 같은 0-100 척도(Score)로 비교할 수 있게 한다.
+package scoring
+
+import (
+	"math"
+
+	"traveler/pkg/model"
+)
+
+// 통합 점수 배점 (합계 100)
+const (
+	SetupWeight  = 50 // 패턴/전략 고유 셋업 (0-100 강도를 절반으로)
+	RSIWeight    = 20
+	VolumeWeight = 15
+	TrendWeight  = 15
+)
+
+// RSI Relative Strength Index (단순 평균, 소수 2자리)
+func RSI(candles []model.Candle, period int) float64 {
+	if len(candles) < period+1 {
+		return 50 // neutral
+	}
+
+	var gains, losses float64
+	for i := len(candles) - period; i < len(candles); i++ {
+		change := candles[i].Close - candles[i-1].Close
+		if change > 0 {
+			gains += change
+		} else {
+			losses -= change
+		}
+	}
+
+	avgGain := gains / float64(period)
+	avgLoss := losses / float64(period)
+
+	if avgLoss == 0 {
+		return 100
+	}
+
+	rs := avgGain / avgLoss
+	rsi := 100 - (100 / (1 + rs))
+
+	return math.Round(rsi*100) / 100
+}
+
+// VolumeRatio 마지막 봉 거래량 / 직전 period-1봉 평균
+func VolumeRatio(candles []model.Candle, period int) float64 {
+	if len(candles) < period {
+		return 1.0
+	}
+
+	var sum int64
+	for i := len(candles) - period; i < len(candles)-1; i++ {
+		sum += candles[i].Volume
+	}
+	avgVolume := float64(sum) / float64(period-1)
+
+	if avgVolume == 0 {
+		return 1.0
+	}
+
+	todayVolume := float64(candles[len(candles)-1].Volume)
+	return math.Round(todayVolume/avgVolume*100) / 100
+}
+
+// PriceVsMA 종가의 이동평균 대비 위치 (%, 소수 2자리)
+func PriceVsMA(candles []model.Candle, period int) float64 {
+	if len(candles) < period {
+		return 0
+	}
+
+	var sum float64
+	for i := len(candles) - period; i < len(candles); i++ {
+		sum += candles[i].Close
+	}
+	ma := sum / float64(period)
+
+	if ma == 0 {
+		return 0
+	}
+
+	currentPrice := candles[len(candles)-1].Close
+	return math.Round((currentPrice-ma)/ma*10000) / 100
+}
+
+// RSISignal RSI 해석
+func RSISignal(rsi float64) string {
+	if rsi < 30 {
+		return "oversold"
+	} else if rsi > 70 {
+		return "overbought"
+	}
+	return "neutral"
+}
+
+// VolumeSignal 거래량 비율 해석
+func VolumeSignal(ratio float64) string {
+	if ratio < 0.7 {
+		return "low"
+	} else if ratio > 1.5 {
+		return "high"
+	}
+	return "normal"
+}
+
+// TrendSignal MA5/MA20 대비 위치로 추세 판단
+func TrendSignal(priceVsMA5, priceVsMA20 float64) string {
+	if priceVsMA5 > 1 && priceVsMA20 > 1 {
+		return "uptrend"
+	} else if priceVsMA5 < -1 && priceVsMA20 < -1 {
+		return "downtrend"
+	}
+	return "neutral"
+}
+
+// RSIPoints 과매도일수록 높게 (0-20, RSI가 없으면 0)
+func RSIPoints(rsi float64) float64 {
+	if rsi <= 0 {
+		return 0
+	}
+	if rsi < 30 {
+		return 20 // Oversold - good for bounce
+	} else if rsi < 50 {
+		return 15
+	} else if rsi < 70 {
+		return 10
+	}
+	return 5 // Overbought - less favorable
+}
+
+// VolumePoints 거래량이 많을수록 높게 (0-15, 비율이 없으면 0)
+func VolumePoints(ratio float64) float64 {
+	if ratio <= 0 {
+		return 0
+	}
+	if ratio > 1.5 {
+		return 15
+	} else if ratio > 1.0 {
+		return 10
+	}
+	return 5
+}
+
+// TrendPoints 상승 추세 15, 중립 10, 하락 5
+func TrendPoints(trend string) float64 {
+	switch trend {
+	case "uptrend":
+		return 15
+	case "downtrend":
+		return 5
+	case "neutral":
+		return 10
+	}
+	return 0
+}
+
+// Snapshot 일봉에서 RSI/거래량/추세 지표만 채운 분석 (데이터가 모자라면 해당 지표는 0)
+func Snapshot(candles []model.Candle) *model.TechnicalAnalysis {
+	ta := &model.TechnicalAnalysis{}
+	if len(candles) >= 15 {
+		ta.RSI = RSI(candles, 14)
+		ta.RSISignal = RSISignal(ta.RSI)
+	}
+	if len(candles) >= 20 {
+		ta.VolumeRatio = VolumeRatio(candles, 20)
+		ta.VolumeSignal = VolumeSignal(ta.VolumeRatio)
+		ta.PriceVsMA20 = PriceVsMA(candles, 20)
+	}
+	if len(candles) >= 5 {
+		ta.PriceVsMA5 = PriceVsMA(candles, 5)
+	}
+	ta.TrendSignal = TrendSignal(ta.PriceVsMA5, ta.PriceVsMA20)
+	return ta
+}
+
+// Composite 셋업 강도(0-100)와 RSI/거래량/추세 점수를 합친 통합 점수 (0-100)
+func Composite(setup float64, ta *model.TechnicalAnalysis) float64 {
+	setup = math.Max(0, math.Min(setup, 100))
+	score := setup * SetupWeight / 100
+	if ta != nil {
+		score += RSIPoints(ta.RSI) + VolumePoints(ta.VolumeRatio) + TrendPoints(ta.TrendSignal)
+	}
+	return math.Round(score*100) / 100
+}
+
+// Recommendation 점수/확률 구간별 추천 (strong/moderate/weak/avoid)
+func Recommendation(score float64) string {
+	if score >= 70 {
+		return "strong"
+	} else if score >= 50 {
+		return "moderate"
+	} else if score >= 30 {
+		return "weak"
+	}
+	return "avoid"
+}
@@ -3,9 +3,8 @@ package strategy
 import (
 	"context"
 	"fmt"
-	"log"
 	"math"
-	"sync"
+	"time"
 
 	"traveler/internal/provider"
 	"traveler/internal/symbols"
@@ -67,9 +66,7 @@ type BreakoutStrategy struct {
 	provider provider.Provider
 
 	// Market regime cache (per scan session)
-	regimeMu      sync.Mutex
-	regimeChecked bool
-	regimeOK      bool
+	regime ma20RegimeGate
 }
 
 // NewBreakoutStrategy creates a new breakout strategy
@@ -92,64 +89,18 @@ func (s *BreakoutStrategy) Description() string {
 
 // ResetRegimeCache resets the cached regime check (call at start of each scan cycle)
 func (s *BreakoutStrategy) ResetRegimeCache() {
-	s.regimeMu.Lock()
-	s.regimeChecked = false
-	s.regimeOK = false
-	s.regimeMu.Unlock()
-}
-
-// checkMarketRegime checks if the broad market is above MA20.
-// Result is cached for the entire scan session.
-func (s *BreakoutStrategy) checkMarketRegime(ctx context.Context) bool {
-	s.regimeMu.Lock()
-	defer s.regimeMu.Unlock()
-
-	if s.regimeChecked {
-		return s.regimeOK
-	}
-
-	s.regimeChecked = true
-	s.regimeOK = true // default to true if no symbol configured or on error
-
-	sym := s.config.MarketRegimeSymbol
-	if sym == "" {
-		return s.regimeOK
-	}
-
-	candles, err := s.provider.GetDailyCandles(ctx, sym, 30)
-	if err != nil {
-		log.Printf("[BREAKOUT] regime check: failed to fetch %s: %v (allowing entries)", sym, err)
-		return s.regimeOK
-	}
-
-	if len(candles) < 20 {
-		log.Printf("[BREAKOUT] regime check: insufficient %s data (%d candles)", sym, len(candles))
-		return s.regimeOK
-	}
-
-	ma20 := CalculateMA(candles, 20)
-	lastClose := candles[len(candles)-1].Close
-	s.regimeOK = lastClose > ma20
-
-	if !s.regimeOK {
-		log.Printf("[BREAKOUT] regime BEARISH: %s %.2f < MA20 %.2f — skipping breakout entries", sym, lastClose, ma20)
-	} else {
-		log.Printf("[BREAKOUT] regime OK: %s %.2f > MA20 %.2f", sym, lastClose, ma20)
-	}
-
-	return s.regimeOK
+	s.regime.reset()
 }
 
 // Analyze analyzes a stock for breakout opportunity
 func (s *BreakoutStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
 	// Market regime filter: skip all entries if broad market is below MA20
-	if !s.checkMarketRegime(ctx) {
+	if !s.regime.check(ctx, s.provider, s.config.MarketRegimeSymbol, "BREAKOUT", "breakout") {
 		return nil, nil
 	}
 
-	// Pre-filter: Ticker length (skip for Korean 6-digit symbols)
-	if s.config.MaxTickerLength > 0 && len(stock.Symbol) > s.config.MaxTickerLength && !symbols.IsKoreanSymbol(stock.Symbol) {
-		return nil, fmt.Errorf("ticker too long: %s", stock.Symbol)
+	if err := checkTickerLength(stock.Symbol, s.config.MaxTickerLength); err != nil {
+		return nil, err
 	}
 
 	// Need at least 60 days for MA50 + buffer
@@ -161,6 +112,7 @@ func (s *BreakoutStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 	if len(candles) < 50 {
 		return nil, fmt.Errorf("insufficient data: got %d candles, need 50", len(candles))
 	}
+	candles = projectSessionVolume(stock.Symbol, candles, time.Now())
 
 	// Calculate indicators
 	ind := CalculateIndicators(candles)
@@ -178,8 +130,8 @@ func (s *BreakoutStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 	// Get latest candle
 	today := candles[len(candles)-1]
 
-	// Quality filters
-	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice {
+	// Quality filters (min price does not apply to coins)
+	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice && !symbols.IsCryptoSymbol(stock.Symbol) {
 		return nil, fmt.Errorf("price too low: $%.2f", today.Close)
 	}
 
@@ -281,15 +233,33 @@ func (s *BreakoutStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 	}
 	guide := s.calculateTradeGuide(today.Close, highestHigh, ind.ATR14, candles)
 
+	rationale := NewRationale("Close above the 20-day high on expanding volume").
+		Require("Closed above 20d high", breakout, "%+.1f%% over %.2f", details["breakout_pct"], highestHigh).
+		Require("Volume confirmation", volumeConfirm, "%.1fx avg", volumeRatio).
+		Require("Above MA50", aboveMA50, "%+.1f%%", details["price_vs_ma50_pct"]).
+		Prefer("RSI not overbought", rsiNotOverbought, "RSI %.0f < %.0f", ind.RSI14, s.config.MaxRSI).
+		Prefer("Above MA20", aboveMA20, "MA20 %.2f", ind.MA20)
+	if s.config.RequireConsolidation {
+		rationale.Require("Prior consolidation", priorConsolidation, "BB width %.3f → %.3f", priorBBWidth, ind.BBWidth)
+	} else {
+		rationale.Prefer("Prior consolidation", priorConsolidation, "BB width %.3f → %.3f", priorBBWidth, ind.BBWidth)
+	}
+	if !priorConsolidation {
+		rationale.Note("no prior squeeze — probability reduced 30%% (false-breakout risk)")
+	}
+	rationale.Level("20d high", highestHigh).Level("MA50", ind.MA50)
+
 	return &Signal{
 		Stock:       stock,
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
-		Reason:  reason,
-		Details: details,
-		Guide:   guide,
+		Reason:    reason,
+		Rationale: rationale,
+		Details:   details,
+		Guide:     guide,
 	}, nil
 }
 
@@ -136,6 +136,9 @@ func (s *CryptoMetaStrategy) Analyze(ctx context.Context, stock model.Stock) (*S
 
 	// Add regime prefix to reason
 	bestSignal.Reason = fmt.Sprintf("[%s] %s", regimeLabel(regime), bestSignal.Reason)
+	if r := bestSignal.Rationale; r != nil {
+		r.Summary = fmt.Sprintf("[%s] %s", regimeLabel(regime), r.Summary)
+	}
 
 	// Override strategy name to include regime info
 	bestSignal.Strategy = fmt.Sprintf("%s(%s)", bestSignal.Strategy, regime)
@@ -100,6 +100,7 @@ func (s *CryptoTrendStrategy) Analyze(ctx context.Context, stock model.Stock) (*
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    70,
+		Technical:   scoreTechnical(candles, 70),
 		Probability: probability,
 		Reason:      reason,
 		Details: map[string]float64{
@@ -0,0 +1,89 @@
+package strategy
+
+import (
+	"traveler/pkg/model"
+)
+
+// RSIDivergence 강세 RSI 다이버전스: 가격은 저점을 낮췄는데 RSI 저점은 높아짐 (매도 압력 소진)
+// Index는 분석한 candles 기준, Time은 차트 표시용
+type RSIDivergence struct {
+	PriorLow  SwingPoint
+	PriorRSI  float64
+	PriorTime int64 // unix seconds
+
+	RecentLow  SwingPoint
+	RecentRSI  float64
+	RecentTime int64
+}
+
+// 다이버전스 판정 기준
+const (
+	divergenceRecentBars = 3 // 최근 저점은 마지막 3봉(오늘 포함) 중 최저가
+	divergenceMinGap     = 5 // 두 저점 사이 최소 봉 수
+	divergenceMinRSIGap  = 1 // RSI 저점 차이 최소값 (노이즈 제외)
+	divergenceSwingOrder = 2 // 이전 저점은 양쪽 2봉보다 낮은 스윙 저점
+)
+
+// FindBullishRSIDivergence 최근 lookback봉에서 강세 RSI 다이버전스 탐색 (없으면 nil)
+// 최근 저점은 지금 진행 중인 눌림(오늘 포함)이고, 이전 저점은 그보다 가격이 높은 가장 가까운 스윙 저점이다.
+func FindBullishRSIDivergence(candles []model.Candle, period, lookback int) *RSIDivergence {
+	n := len(candles)
+	rsi := CalculateRSISeries(candles, period)
+	if rsi == nil || n < lookback || n < divergenceRecentBars {
+		return nil
+	}
+	// rsi[k]는 candles[period+k]까지의 RSI
+	rsiAt := func(i int) (float64, bool) {
+		k := i - period
+		if k < 0 || k >= len(rsi) {
+			return 0, false
+		}
+		return rsi[k], true
+	}
+
+	recent := SwingPoint{Price: candles[n-1].Low, Index: n - 1}
+	for i := n - divergenceRecentBars; i < n-1; i++ {
+		if candles[i].Low < recent.Price {
+			recent = SwingPoint{Price: candles[i].Low, Index: i}
+		}
+	}
+	recentRSI, ok := rsiAt(recent.Index)
+	if !ok {
+		return nil
+	}
+
+	// FindSwingLows는 최근 것부터 반환
+	for _, prior := range FindSwingLows(candles, lookback, divergenceSwingOrder) {
+		if recent.Index-prior.Index < divergenceMinGap {
+			continue
+		}
+		if prior.Price <= recent.Price {
+			// 가격이 저점을 낮추지 않음 (이전 저점이 더 낮거나 같음) → 다이버전스 아님
+			return nil
+		}
+		priorRSI, ok := rsiAt(prior.Index)
+		if !ok || recentRSI < priorRSI+divergenceMinRSIGap {
+			return nil
+		}
+		return &RSIDivergence{
+			PriorLow:   prior,
+			PriorRSI:   priorRSI,
+			PriorTime:  candles[prior.Index].Time.Unix(),
+			RecentLow:  recent,
+			RecentRSI:  recentRSI,
+			RecentTime: candles[recent.Index].Time.Unix(),
+		}
+	}
+	return nil
+}
+
+// addToDetails 차트 표시용 다이버전스 지점을 Details에 기록
+func (d *RSIDivergence) addToDetails(details map[string]float64) {
+	details["rsi_divergence"] = 1
+	details["div_prior_low"] = d.PriorLow.Price
+	details["div_prior_rsi"] = d.PriorRSI
+	details["div_prior_time"] = float64(d.PriorTime)
+	details["div_recent_low"] = d.RecentLow.Price
+	details["div_recent_rsi"] = d.RecentRSI
+	details["div_recent_time"] = float64(d.RecentTime)
+}
@@ -156,6 +156,7 @@ func (s *ETFMomentumStrategy) analyzeGEM(ctx context.Context, stock model.Stock)
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    70,
+		Technical:   scoreTechnical(candles, 70),
 		Probability: 65,
 		Reason:      reason,
 		Details: map[string]float64{
@@ -246,6 +247,7 @@ func (s *ETFMomentumStrategy) analyzeTQQQSMA(ctx context.Context, stock model.St
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    65,
+		Technical:   scoreTechnical(tqqqCandles, 65),
 		Probability: 74,
 		Reason:      reason,
 		Details: map[string]float64{
@@ -373,6 +375,7 @@ func (s *ETFMomentumStrategy) analyzeKRTiming(ctx context.Context, stock model.S
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    70,
+		Technical:   scoreTechnical(candles, 70),
 		Probability: 60,
 		Reason:      reason,
 		Details: map[string]float64{
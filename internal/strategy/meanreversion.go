@@ -3,9 +3,8 @@ package strategy
 import (
 	"context"
 	"fmt"
-	"log"
 	"math"
-	"sync"
+	"time"
 
 	"traveler/internal/provider"
 	"traveler/internal/symbols"
@@ -28,6 +27,9 @@ type MeanReversionConfig struct {
 
 	// Sideways mode relaxation (set by StockMetaStrategy for sideways regime)
 	RequireUptrend bool // Require close > MA200 (default true, sideways: false)
+
+	// Bullish RSI divergence boost: 가격 저점↓ + RSI 저점↑이면 강도/승률 가산
+	DivergenceLookback int // 이전 저점 탐색 구간 (봉 수, 0 = 끔)
 }
 
 // DefaultMeanReversionConfig returns default configuration
@@ -41,6 +43,8 @@ func DefaultMeanReversionConfig() MeanReversionConfig {
 		MinDailyDollarVol: 500000,
 
 		RequireUptrend: true,
+
+		DivergenceLookback: 30,
 	}
 }
 
@@ -50,15 +54,13 @@ func DefaultMeanReversionConfig() MeanReversionConfig {
 // 2. Price at or below Bollinger lower band
 // 3. Reversal candle (bullish body or long lower shadow, ATR 기준 포함)
 // 4. Above MA200 (or MA50 fallback) — 장기 상승 추세 내 급락만 (칼날잡기 방지)
-// Supporting: volume increase, deeply oversold
+// Supporting: volume increase, deeply oversold, bullish RSI divergence
 type MeanReversionStrategy struct {
 	config   MeanReversionConfig
 	provider provider.Provider
 
 	// Market regime cache (per scan session)
-	regimeMu      sync.Mutex
-	regimeChecked bool
-	regimeOK      bool
+	regime ma20RegimeGate
 }
 
 // NewMeanReversionStrategy creates a new mean reversion strategy
@@ -81,64 +83,18 @@ func (s *MeanReversionStrategy) Description() string {
 
 // ResetRegimeCache resets the cached regime check (call at start of each scan cycle)
 func (s *MeanReversionStrategy) ResetRegimeCache() {
-	s.regimeMu.Lock()
-	s.regimeChecked = false
-	s.regimeOK = false
-	s.regimeMu.Unlock()
-}
-
-// checkMarketRegime checks if the broad market is above MA20.
-// Result is cached for the entire scan session.
-func (s *MeanReversionStrategy) checkMarketRegime(ctx context.Context) bool {
-	s.regimeMu.Lock()
-	defer s.regimeMu.Unlock()
-
-	if s.regimeChecked {
-		return s.regimeOK
-	}
-
-	s.regimeChecked = true
-	s.regimeOK = true // default to true if no symbol configured or on error
-
-	sym := s.config.MarketRegimeSymbol
-	if sym == "" {
-		return s.regimeOK
-	}
-
-	candles, err := s.provider.GetDailyCandles(ctx, sym, 30)
-	if err != nil {
-		log.Printf("[MEAN-REV] regime check: failed to fetch %s: %v (allowing entries)", sym, err)
-		return s.regimeOK
-	}
-
-	if len(candles) < 20 {
-		log.Printf("[MEAN-REV] regime check: insufficient %s data (%d candles)", sym, len(candles))
-		return s.regimeOK
-	}
-
-	ma20 := CalculateMA(candles, 20)
-	lastClose := candles[len(candles)-1].Close
-	s.regimeOK = lastClose > ma20
-
-	if !s.regimeOK {
-		log.Printf("[MEAN-REV] regime BEARISH: %s %.2f < MA20 %.2f — skipping mean-reversion entries", sym, lastClose, ma20)
-	} else {
-		log.Printf("[MEAN-REV] regime OK: %s %.2f > MA20 %.2f", sym, lastClose, ma20)
-	}
-
-	return s.regimeOK
+	s.regime.reset()
 }
 
 // Analyze analyzes a stock for mean reversion opportunity
 func (s *MeanReversionStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
 	// Market regime filter: skip all entries if broad market is below MA20
-	if !s.checkMarketRegime(ctx) {
+	if !s.regime.check(ctx, s.provider, s.config.MarketRegimeSymbol, "MEAN-REV", "mean-reversion") {
 		return nil, nil
 	}
 
-	// Pre-filter: Ticker length (skip for Korean 6-digit symbols)
-	if s.config.MaxTickerLength > 0 && len(stock.Symbol) > s.config.MaxTickerLength && !symbols.IsKoreanSymbol(stock.Symbol) {
-		return nil, fmt.Errorf("ticker too long: %s", stock.Symbol)
+	if err := checkTickerLength(stock.Symbol, s.config.MaxTickerLength); err != nil {
+		return nil, err
 	}
 
 	// Need 250 days for MA200 + buffer
@@ -150,6 +106,7 @@ func (s *MeanReversionStrategy) Analyze(ctx context.Context, stock model.Stock)
 	if len(candles) < 50 {
 		return nil, fmt.Errorf("insufficient data: got %d candles, need 50", len(candles))
 	}
+	candles = projectSessionVolume(stock.Symbol, candles, time.Now())
 
 	// Calculate indicators
 	ind := CalculateIndicators(candles)
@@ -160,8 +117,8 @@ func (s *MeanReversionStrategy) Analyze(ctx context.Context, stock model.Stock)
 	// Get latest candle
 	today := candles[len(candles)-1]
 
-	// Quality filters
-	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice {
+	// Quality filters (min price does not apply to coins)
+	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice && !symbols.IsCryptoSymbol(stock.Symbol) {
 		return nil, fmt.Errorf("price too low: $%.2f", today.Close)
 	}
 
@@ -239,23 +196,69 @@ func (s *MeanReversionStrategy) Analyze(ctx context.Context, stock model.Stock)
 		}
 	}
 
+	// Boost: 강세 RSI 다이버전스 (차트 표시용 지점은 Details에)
+	var divergence *RSIDivergence
+	if s.config.DivergenceLookback > 0 {
+		divergence = FindBullishRSIDivergence(candles, 14, s.config.DivergenceLookback)
+	}
+	if divergence != nil {
+		divergence.addToDetails(details)
+		strength = math.Min(strength+10, 100)
+	}
+
 	probability := calculateMeanReversionProbability(strength, ind.RSI14, inUptrend, volumeIncrease)
+	if divergence != nil {
+		probability = math.Min(probability+2, 65)
+	}
 	guide := s.calculateTradeGuide(today, ind)
 
+	reason := fmt.Sprintf("Oversold bounce: RSI=%.0f, at BB lower ($%.2f), %s, above %s",
+		ind.RSI14, ind.BBLower, reversalDesc(bullishCandle, longLowerShadow),
+		func() string { if ind.MA200 > 0 { return "MA200" }; return "MA50" }())
+	if divergence != nil {
+		reason += fmt.Sprintf(", RSI divergence (%.0f→%.0f)", divergence.PriorRSI, divergence.RecentRSI)
+	}
+
+	trendMA, trendLevel := "MA50", ind.MA50
+	if ind.MA200 > 0 {
+		trendMA, trendLevel = "MA200", ind.MA200
+	}
+	rationale := NewRationale("Oversold bounce off the lower Bollinger band").
+		Require("RSI oversold", rsiOversold, "RSI %.0f < %.0f", ind.RSI14, s.config.RSIOversold).
+		Require("At lower band", atBBLower, "low %.2f vs band %.2f", today.Low, ind.BBLower).
+		Require("Reversal candle", hasReversal, "%s", reversalDesc(bullishCandle, longLowerShadow))
+	if s.config.RequireUptrend {
+		rationale.Require("Above "+trendMA, inUptrend, "%.2f", trendLevel)
+	} else {
+		rationale.Prefer("Above "+trendMA, inUptrend, "%.2f", trendLevel)
+	}
+	rationale.Prefer("Volume pickup", volumeIncrease, "%.1fx avg", volumeRatio).
+		Prefer("Deeply oversold", deeplyOversold, "RSI %.0f < 25", ind.RSI14).
+		Prefer("Bullish RSI divergence", divergence != nil, "%s", divergenceDesc(divergence)).
+		Level("BB lower", ind.BBLower).Level("MA20", ind.MA20).Level(trendMA, trendLevel)
+
 	return &Signal{
 		Stock:       stock,
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
-		Reason: fmt.Sprintf("Oversold bounce: RSI=%.0f, at BB lower ($%.2f), %s, above %s",
-			ind.RSI14, ind.BBLower, reversalDesc(bullishCandle, longLowerShadow),
-			func() string { if ind.MA200 > 0 { return "MA200" }; return "MA50" }()),
-		Details: details,
-		Guide:   guide,
+		Reason:      reason,
+		Rationale:   rationale,
+		Details:     details,
+		Guide:       guide,
 	}, nil
 }
 
+// divergenceDesc 다이버전스 요약 (없으면 빈 문자열)
+func divergenceDesc(d *RSIDivergence) string {
+	if d == nil {
+		return ""
+	}
+	return fmt.Sprintf("RSI %.0f → %.0f on a lower low", d.PriorRSI, d.RecentRSI)
+}
+
 // calculateTradeGuide generates trading guidance for mean reversion
 func (s *MeanReversionStrategy) calculateTradeGuide(today model.Candle, ind *Indicators) *TradeGuide {
 	// ATR 기반 손절: 스윙 보유(5일)에 맞게 충분한 여유
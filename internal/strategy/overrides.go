@@ -0,0 +1,109 @@
+package strategy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"traveler/internal/provider"
+)
+
+// configurable --pattern-config로 파라미터를 바꿀 수 있는 레지스트리 전략
+// 기본 설정 위에 JSON을 덮어쓰고 (필드명은 Go 필드명, 대소문자 무시) 그 설정으로 만드는 팩토리를 돌려준다.
+var configurable = map[string]func(raw json.RawMessage) (StrategyFactory, error){
+	"pullback": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultPullbackConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewPullbackStrategy(cfg, p) }, nil
+	},
+	"breakout": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultBreakoutConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewBreakoutStrategy(cfg, p) }, nil
+	},
+	"mean-reversion": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultMeanReversionConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewMeanReversionStrategy(cfg, p) }, nil
+	},
+	"oversold": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultOversoldConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewOversoldStrategy(cfg, p) }, nil
+	},
+	"volatility-breakout": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultVolatilityBreakoutConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewVolatilityBreakoutStrategy(cfg, p) }, nil
+	},
+	"bb-squeeze": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultBBSqueezeConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewBBSqueezeStrategy(cfg, p) }, nil
+	},
+	"pairs": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := DefaultPairsConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewPairsStrategy(cfg, p) }, nil
+	},
+	"sector-rotation": func(raw json.RawMessage) (StrategyFactory, error) {
+		cfg := CurrentSectorRotationConfig()
+		if err := decodeStrict(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return func(p provider.Provider) Strategy { return NewSectorRotationStrategy(cfg, p) }, nil
+	},
+}
+
+// OverrideConfigs 전략별 설정 JSON으로 레지스트리 전략을 다시 등록 (한 번의 실행 동안만)
+// 하나라도 잘못되면 아무것도 바꾸지 않는다.
+func OverrideConfigs(configs map[string]json.RawMessage) error {
+	factories := make(map[string]StrategyFactory, len(configs))
+	for name, raw := range configs {
+		build, ok := configurable[name]
+		if !ok {
+			return fmt.Errorf("strategy %q has no configurable parameters (available: %v)", name, ConfigurableStrategies())
+		}
+		f, err := build(raw)
+		if err != nil {
+			return fmt.Errorf("strategy %s: %w", name, err)
+		}
+		factories[name] = f
+	}
+	for name, f := range factories {
+		Register(name, f)
+	}
+	return nil
+}
+
+// ConfigurableStrategies --pattern-config로 설정할 수 있는 전략 이름
+func ConfigurableStrategies() []string {
+	names := make([]string, 0, len(configurable))
+	for name := range configurable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decodeStrict 알 수 없는 필드는 에러 (오타로 기본값이 조용히 쓰이지 않도록)
+func decodeStrict(raw json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -8,7 +8,6 @@ import (
 	"sync"
 
 	"traveler/internal/provider"
-	"traveler/internal/symbols"
 	"traveler/pkg/model"
 )
 
@@ -140,9 +139,8 @@ func (s *OversoldStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 		return nil, nil
 	}
 
-	// Pre-filter: Ticker length
-	if s.config.MaxTickerLength > 0 && len(stock.Symbol) > s.config.MaxTickerLength && !symbols.IsKoreanSymbol(stock.Symbol) {
-		return nil, fmt.Errorf("ticker too long: %s", stock.Symbol)
+	if err := checkTickerLength(stock.Symbol, s.config.MaxTickerLength); err != nil {
+		return nil, err
 	}
 
 	// Need enough data for MA50 + RSI(2)
@@ -284,6 +282,7 @@ func (s *OversoldStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 			Type:        SignalBuy,
 			Strategy:    s.Name(),
 			Strength:    strength,
+			Technical:   scoreTechnical(candles, strength),
 			Probability: prob,
 			Reason:      reason,
 			Details:     details,
@@ -0,0 +1,309 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"traveler/internal/provider"
+	"traveler/pkg/model"
+)
+
+// PairGuide 2-leg 페어 트레이드의 숏 레그와 스프레드 기준
+// TradeGuide의 진입/손절/목표가는 롱 레그 가격이고, 숏 레그 가격이 그대로일 때 스프레드가 ExitZ/StopZ에 닿는 값이다.
+type PairGuide struct {
+	ShortSymbol   string  `json:"short_symbol"`
+	ShortPrice    float64 `json:"short_price"`
+	ShortQuantity float64 `json:"short_quantity"` // 사이징 후 숏 수량
+	HedgeRatio    float64 `json:"hedge_ratio"`    // 롱 1달러당 숏 금액 (달러 기준 헤지 비율)
+
+	Beta     float64 `json:"beta"`      // log(A) = α + β·log(B), A = 쌍의 첫 종목
+	ZScore   float64 `json:"z_score"`   // 현재 스프레드 z (A 기준, 음수면 A가 상대적으로 쌈)
+	EntryZ   float64 `json:"entry_z"`   // 진입 |z|
+	ExitZ    float64 `json:"exit_z"`    // 청산 |z| (평균 회귀)
+	StopZ    float64 `json:"stop_z"`    // 손절 |z| (스프레드 발산)
+	HalfLife float64 `json:"half_life"` // 스프레드 평균 회귀 반감기 (거래일)
+}
+
+// PairStat 두 종목 로그 가격의 회귀 스프레드 통계
+type PairStat struct {
+	Alpha       float64
+	Beta        float64 // log(A) = Alpha + Beta·log(B) + spread
+	Mean        float64 // 스프레드 평균 (OLS라 0에 가까움)
+	StdDev      float64
+	ZScore      float64 // 마지막 봉 스프레드 z
+	HalfLife    float64 // 평균 회귀 반감기 (회귀하지 않으면 +Inf)
+	Correlation float64 // 일간 로그 수익률 상관
+	PriceA      float64 // 마지막 종가
+	PriceB      float64
+	Bars        int // 날짜를 맞춘 봉 수
+}
+
+// AnalyzePair 날짜가 겹치는 최근 lookback봉으로 스프레드 통계 계산
+// 스프레드 = log(A) - α - β·log(B). 반감기는 Δs = λ·s(t-1) 회귀의 -ln2/λ (간이 공적분 검사).
+func AnalyzePair(a, b []model.Candle, lookback int) (*PairStat, error) {
+	closeB := make(map[string]float64, len(b))
+	for _, c := range b {
+		closeB[c.Time.Format("2006-01-02")] = c.Close
+	}
+	var la, lb []float64
+	for _, c := range a {
+		pb, ok := closeB[c.Time.Format("2006-01-02")]
+		if !ok || c.Close <= 0 || pb <= 0 {
+			continue
+		}
+		la = append(la, math.Log(c.Close))
+		lb = append(lb, math.Log(pb))
+	}
+	if len(la) < lookback || lookback < 20 {
+		return nil, fmt.Errorf("insufficient overlapping data: %d < %d", len(la), lookback)
+	}
+	la, lb = la[len(la)-lookback:], lb[len(lb)-lookback:]
+
+	meanA, meanB := mean(la), mean(lb)
+	var cov, varB float64
+	for i := range la {
+		cov += (la[i] - meanA) * (lb[i] - meanB)
+		varB += (lb[i] - meanB) * (lb[i] - meanB)
+	}
+	if varB == 0 {
+		return nil, fmt.Errorf("flat price series")
+	}
+	st := &PairStat{Bars: lookback, PriceA: math.Exp(la[lookback-1]), PriceB: math.Exp(lb[lookback-1])}
+	st.Beta = cov / varB
+	st.Alpha = meanA - st.Beta*meanB
+
+	spread := make([]float64, lookback)
+	for i := range la {
+		spread[i] = la[i] - st.Alpha - st.Beta*lb[i]
+	}
+	st.Mean = mean(spread)
+	for _, s := range spread {
+		st.StdDev += (s - st.Mean) * (s - st.Mean)
+	}
+	st.StdDev = math.Sqrt(st.StdDev / float64(lookback))
+	if st.StdDev == 0 {
+		return nil, fmt.Errorf("zero spread deviation")
+	}
+	st.ZScore = (spread[lookback-1] - st.Mean) / st.StdDev
+
+	// 반감기: Δs(t) = λ·(s(t-1) - mean)
+	var num, den float64
+	for i := 1; i < lookback; i++ {
+		x := spread[i-1] - st.Mean
+		num += x * (spread[i] - spread[i-1])
+		den += x * x
+	}
+	st.HalfLife = math.Inf(1)
+	if lambda := num / den; den > 0 && lambda < 0 {
+		st.HalfLife = -math.Ln2 / lambda
+	}
+
+	// 일간 로그 수익률 상관
+	ra := make([]float64, lookback-1)
+	rb := make([]float64, lookback-1)
+	for i := 1; i < lookback; i++ {
+		ra[i-1] = la[i] - la[i-1]
+		rb[i-1] = lb[i] - lb[i-1]
+	}
+	st.Correlation = correlation(ra, rb)
+	return st, nil
+}
+
+// SpreadAt z에 해당하는 스프레드 값
+func (st *PairStat) SpreadAt(z float64) float64 {
+	return st.Mean + z*st.StdDev
+}
+
+// PriceAAtZ B 가격이 그대로일 때 스프레드가 z가 되는 A 가격
+func (st *PairStat) PriceAAtZ(z float64) float64 {
+	return math.Exp(st.Alpha + st.Beta*math.Log(st.PriceB) + st.SpreadAt(z))
+}
+
+// PriceBAtZ A 가격이 그대로일 때 스프레드가 z가 되는 B 가격 (Beta > 0일 때만 의미 있음)
+func (st *PairStat) PriceBAtZ(z float64) float64 {
+	return math.Exp((math.Log(st.PriceA) - st.Alpha - st.SpreadAt(z)) / st.Beta)
+}
+
+func mean(v []float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float64(len(v))
+}
+
+func correlation(x, y []float64) float64 {
+	mx, my := mean(x), mean(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		sxy += (x[i] - mx) * (y[i] - my)
+		sxx += (x[i] - mx) * (x[i] - mx)
+		syy += (y[i] - my) * (y[i] - my)
+	}
+	if sxx == 0 || syy == 0 {
+		return 0
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+// PairsConfig 페어(상대가치) 전략 설정
+type PairsConfig struct {
+	Pairs          [][2]string // [A, B] 쌍 (같은 업종 대형주)
+	Lookback       int         // 회귀/z 계산 기간 (거래일)
+	EntryZ         float64     // |z| 이상이면 진입
+	ExitZ          float64     // |z| 이하로 돌아오면 청산
+	StopZ          float64     // |z| 이상 벌어지면 손절
+	MinCorrelation float64     // 일간 수익률 상관 최소값
+	MaxHalfLife    float64     // 평균 회귀 반감기 최대 (거래일, 느린 쌍 제외)
+}
+
+// DefaultPairsConfig 기본 설정: 업종이 같은 US 대형주 쌍
+func DefaultPairsConfig() PairsConfig {
+	return PairsConfig{
+		Pairs: [][2]string{
+			{"KO", "PEP"},
+			{"XOM", "CVX"},
+			{"V", "MA"},
+			{"HD", "LOW"},
+			{"JPM", "BAC"},
+			{"MCD", "YUM"},
+		},
+		Lookback:       60,
+		EntryZ:         2.0,
+		ExitZ:          0.5,
+		StopZ:          3.5,
+		MinCorrelation: 0.6,
+		MaxHalfLife:    20,
+	}
+}
+
+// PairsStrategy 페어 z-score 평균 회귀
+// 두 종목 로그 가격 회귀 스프레드가 ±EntryZ 이상 벌어지면 싼 쪽 롱 / 비싼 쪽 숏, ExitZ로 돌아오면 청산.
+// 시그널은 롱 레그 종목으로 한 번만 나온다 (숏 레그는 Guide.Pair).
+type PairsStrategy struct {
+	config   PairsConfig
+	provider provider.Provider
+}
+
+// NewPairsStrategy 생성자
+func NewPairsStrategy(cfg PairsConfig, p provider.Provider) *PairsStrategy {
+	return &PairsStrategy{config: cfg, provider: p}
+}
+
+func (s *PairsStrategy) Name() string {
+	return "pairs"
+}
+
+func (s *PairsStrategy) Description() string {
+	return fmt.Sprintf("Pairs z-score: long the cheap leg / short the rich leg when the log-price spread exceeds %.1fσ", s.config.EntryZ)
+}
+
+// Analyze 종목이 속한 쌍마다 스프레드를 보고, 이 종목이 롱 레그인 가장 강한 시그널을 반환
+func (s *PairsStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
+	sym := strings.ToUpper(stock.Symbol)
+	var best *Signal
+	for _, pair := range s.config.Pairs {
+		if pair[0] != sym && pair[1] != sym {
+			continue
+		}
+		sig, err := s.analyzePair(ctx, stock, pair)
+		if err != nil {
+			return nil, err
+		}
+		if sig != nil && (best == nil || sig.Strength > best.Strength) {
+			best = sig
+		}
+	}
+	return best, nil
+}
+
+func (s *PairsStrategy) analyzePair(ctx context.Context, stock model.Stock, pair [2]string) (*Signal, error) {
+	cfg := s.config
+	if cfg.EntryZ <= cfg.ExitZ || cfg.StopZ <= cfg.EntryZ {
+		return nil, fmt.Errorf("pairs: need exit_z < entry_z < stop_z")
+	}
+	candlesA, err := s.provider.GetDailyCandles(ctx, pair[0], cfg.Lookback+20)
+	if err != nil {
+		return nil, fmt.Errorf("%s data: %w", pair[0], err)
+	}
+	candlesB, err := s.provider.GetDailyCandles(ctx, pair[1], cfg.Lookback+20)
+	if err != nil {
+		return nil, fmt.Errorf("%s data: %w", pair[1], err)
+	}
+	st, err := AnalyzePair(candlesA, candlesB, cfg.Lookback)
+	if err != nil {
+		return nil, nil
+	}
+	if st.Beta <= 0 || st.Correlation < cfg.MinCorrelation || st.HalfLife > cfg.MaxHalfLife {
+		return nil, nil
+	}
+	if math.Abs(st.ZScore) < cfg.EntryZ || math.Abs(st.ZScore) >= cfg.StopZ {
+		return nil, nil
+	}
+
+	// z < 0: A가 상대적으로 쌈 → A 롱 / B 숏, z > 0: B 롱 / A 숏
+	longSym, shortSym := pair[0], pair[1]
+	longCandles := candlesA
+	entry, shortPrice := st.PriceA, st.PriceB
+	stop, target := st.PriceAAtZ(-cfg.StopZ), st.PriceAAtZ(-cfg.ExitZ)
+	hedge := st.Beta // A 1달러당 B β달러 (로그 수익률 기준 중립)
+	if st.ZScore > 0 {
+		longSym, shortSym = pair[1], pair[0]
+		longCandles = candlesB
+		entry, shortPrice = st.PriceB, st.PriceA
+		stop, target = st.PriceBAtZ(cfg.StopZ), st.PriceBAtZ(cfg.ExitZ)
+		hedge = 1 / st.Beta
+	}
+	if longSym != strings.ToUpper(stock.Symbol) || stop >= entry || target <= entry {
+		return nil, nil
+	}
+
+	strength := math.Min(90, 60+10*(math.Abs(st.ZScore)-cfg.EntryZ))
+	rr := (target - entry) / (entry - stop)
+	atr := CalculateATR(longCandles, 14)
+
+	return &Signal{
+		Stock:       stock,
+		Type:        SignalBuy,
+		Strategy:    s.Name(),
+		Strength:    strength,
+		Technical:   scoreTechnical(longCandles, strength),
+		Probability: 58,
+		Reason: fmt.Sprintf("[PAIRS] %s/%s z=%+.2f: long %s, short %s (β %.2f, half-life %.0fd, corr %.2f)",
+			pair[0], pair[1], st.ZScore, longSym, shortSym, st.Beta, st.HalfLife, st.Correlation),
+		Details: map[string]float64{
+			"z_score":     st.ZScore,
+			"beta":        st.Beta,
+			"half_life":   st.HalfLife,
+			"correlation": st.Correlation,
+			"spread_sd":   st.StdDev,
+			"hedge_ratio": hedge,
+		},
+		Guide: &TradeGuide{
+			EntryPrice:      entry,
+			EntryType:       "market",
+			StopLoss:        stop,
+			StopLossPct:     (entry - stop) / entry * 100,
+			Target1:         target,
+			Target1Pct:      (target - entry) / entry * 100,
+			Target2:         target,
+			Target2Pct:      (target - entry) / entry * 100,
+			RiskRewardRatio: rr,
+			EntryATR:        atr,
+			Pair: &PairGuide{
+				ShortSymbol: shortSym,
+				ShortPrice:  shortPrice,
+				HedgeRatio:  hedge,
+				Beta:        st.Beta,
+				ZScore:      st.ZScore,
+				EntryZ:      cfg.EntryZ,
+				ExitZ:       cfg.ExitZ,
+				StopZ:       cfg.StopZ,
+				HalfLife:    st.HalfLife,
+			},
+		},
+		Candles: trimCandles(longCandles, 90),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"traveler/internal/provider"
+	"traveler/internal/symbols"
+)
+
+// checkTickerLength 티커 길이 사전 필터 (0이면 끔)
+//
+// 5글자 이상 OTC·워런트를 거르려는 필터라 미국 티커에만 적용한다.
+// KR 종목코드, KRW-XXX 코인, 7203.T 같은 해외 심볼은 길이와 무관하게 통과.
+func checkTickerLength(symbol string, max int) error {
+	if max > 0 && len(symbol) > max && symbols.IsUSSymbol(symbol) {
+		return fmt.Errorf("ticker too long: %s", symbol)
+	}
+	return nil
+}
+
+// ma20RegimeGate 광역 지수가 MA20 위인지 스캔 세션당 한 번만 확인하는 캐시
+type ma20RegimeGate struct {
+	mu      sync.Mutex
+	checked bool
+	ok      bool
+}
+
+// reset 캐시 초기화 (스캔 사이클 시작 시)
+func (g *ma20RegimeGate) reset() {
+	g.mu.Lock()
+	g.checked = false
+	g.ok = false
+	g.mu.Unlock()
+}
+
+// check sym 종가가 MA20 위인지 (심볼 미설정, 조회 실패, 데이터 부족이면 진입 허용)
+// tag는 로그 접두어, label은 건너뛰는 진입 종류
+func (g *ma20RegimeGate) check(ctx context.Context, p provider.Provider, sym, tag, label string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.checked {
+		return g.ok
+	}
+
+	g.checked = true
+	g.ok = true
+
+	if sym == "" {
+		return g.ok
+	}
+
+	candles, err := p.GetDailyCandles(ctx, sym, 30)
+	if err != nil {
+		log.Printf("[%s] regime check: failed to fetch %s: %v (allowing entries)", tag, sym, err)
+		return g.ok
+	}
+
+	if len(candles) < 20 {
+		log.Printf("[%s] regime check: insufficient %s data (%d candles)", tag, sym, len(candles))
+		return g.ok
+	}
+
+	ma20 := CalculateMA(candles, 20)
+	lastClose := candles[len(candles)-1].Close
+	g.ok = lastClose > ma20
+
+	if !g.ok {
+		log.Printf("[%s] regime BEARISH: %s %.2f < MA20 %.2f — skipping %s entries", tag, sym, lastClose, ma20, label)
+	} else {
+		log.Printf("[%s] regime OK: %s %.2f > MA20 %.2f", tag, sym, lastClose, ma20)
+	}
+
+	return g.ok
+}
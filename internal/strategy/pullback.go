@@ -3,9 +3,8 @@ package strategy
 import (
 	"context"
 	"fmt"
-	"log"
 	"math"
-	"sync"
+	"time"
 
 	"traveler/internal/provider"
 	"traveler/internal/symbols"
@@ -72,9 +71,7 @@ type PullbackStrategy struct {
 	provider provider.Provider
 
 	// Market regime cache (per scan session)
-	regimeMu      sync.Mutex
-	regimeChecked bool
-	regimeOK      bool
+	regime ma20RegimeGate
 }
 
 // NewPullbackStrategy creates a new pullback strategy
@@ -97,65 +94,18 @@ func (s *PullbackStrategy) Description() string {
 
 // ResetRegimeCache resets the cached regime check (call at start of each scan cycle)
 func (s *PullbackStrategy) ResetRegimeCache() {
-	s.regimeMu.Lock()
-	s.regimeChecked = false
-	s.regimeOK = false
-	s.regimeMu.Unlock()
-}
-
-// checkMarketRegime checks if the broad market is above MA20.
-// Result is cached for the entire scan session.
-func (s *PullbackStrategy) checkMarketRegime(ctx context.Context) bool {
-	s.regimeMu.Lock()
-	defer s.regimeMu.Unlock()
-
-	if s.regimeChecked {
-		return s.regimeOK
-	}
-
-	s.regimeChecked = true
-	s.regimeOK = true // default to true if no symbol configured or on error
-
-	sym := s.config.MarketRegimeSymbol
-	if sym == "" {
-		return s.regimeOK
-	}
-
-	candles, err := s.provider.GetDailyCandles(ctx, sym, 30)
-	if err != nil {
-		log.Printf("[PULLBACK] regime check: failed to fetch %s: %v (allowing entries)", sym, err)
-		return s.regimeOK
-	}
-
-	if len(candles) < 20 {
-		log.Printf("[PULLBACK] regime check: insufficient %s data (%d candles)", sym, len(candles))
-		return s.regimeOK
-	}
-
-	ma20 := CalculateMA(candles, 20)
-	lastClose := candles[len(candles)-1].Close
-	s.regimeOK = lastClose > ma20
-
-	if !s.regimeOK {
-		log.Printf("[PULLBACK] regime BEARISH: %s %.2f < MA20 %.2f — skipping pullback entries", sym, lastClose, ma20)
-	} else {
-		log.Printf("[PULLBACK] regime OK: %s %.2f > MA20 %.2f", sym, lastClose, ma20)
-	}
-
-	return s.regimeOK
+	s.regime.reset()
 }
 
 // Analyze analyzes a stock for pullback opportunity
 func (s *PullbackStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
 	// Market regime filter: skip all entries if broad market is below MA20
-	if !s.checkMarketRegime(ctx) {
+	if !s.regime.check(ctx, s.provider, s.config.MarketRegimeSymbol, "PULLBACK", "pullback") {
 		return nil, nil
 	}
 
-	// Pre-filter: Ticker length (exclude OTC 5-letter tickers, warrants, etc.)
-	// Korean symbols are 6-digit numeric codes — skip this filter for them
-	if s.config.MaxTickerLength > 0 && len(stock.Symbol) > s.config.MaxTickerLength && !symbols.IsKoreanSymbol(stock.Symbol) {
-		return nil, fmt.Errorf("ticker too long: %s (likely OTC/warrant)", stock.Symbol)
+	if err := checkTickerLength(stock.Symbol, s.config.MaxTickerLength); err != nil {
+		return nil, err
 	}
 
 	// Need at least 60 days of data for MA50 + buffer
@@ -167,6 +117,7 @@ func (s *PullbackStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 	if len(candles) < 50 {
 		return nil, fmt.Errorf("insufficient data: got %d candles, need 50", len(candles))
 	}
+	candles = projectSessionVolume(stock.Symbol, candles, time.Now())
 
 	// Calculate indicators
 	ind := CalculateIndicators(candles)
@@ -178,8 +129,8 @@ func (s *PullbackStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 	today := candles[len(candles)-1]
 	yesterday := candles[len(candles)-2]
 
-	// Quality filter: Minimum price (no penny stocks)
-	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice {
+	// Quality filter: Minimum price (no penny stocks; coin unit prices are meaningless)
+	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice && !symbols.IsCryptoSymbol(stock.Symbol) {
 		return nil, fmt.Errorf("price too low: $%.2f < $%.2f", today.Close, s.config.MinPrice)
 	}
 
@@ -328,13 +279,38 @@ func (s *PullbackStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sig
 	probability := calculatePullbackProbability(strength, ind.RSI14, volumeRatio, bouncing)
 	guide := s.calculateTradeGuide(today.Close, ind.MA20, ind.ATR14, probability, candles)
 
+	rationale := NewRationale("Pullback to MA20 within an uptrend, reversal confirmed").
+		Require("Touched MA20", touchedMA20, "low %.1f%% from MA20", details["price_vs_ma20_pct"]).
+		Require("Reversal candle", hasReversalSign, "%s", reversalDesc(bullishCandle, longLowerShadow)).
+		Require("RSI not extended", rsiOK, "RSI %.0f < %.0f", ind.RSI14, maxRSI).
+		Require("Closing higher", closingHigher, "close %.2f ≥ prior %.2f", today.Close, yesterday.Close)
+	if s.config.RequireUptrend {
+		rationale.Require("Above MA50", aboveMA50, "%+.1f%%", details["price_vs_ma50_pct"]).
+			Require("Trend confirmed", trendConfirmed, "MA50 slope %.2f%%", ind.MA50Slope)
+	} else {
+		rationale.Prefer("Above MA50", aboveMA50, "%+.1f%%", details["price_vs_ma50_pct"])
+	}
+	cond := rationale.Prefer
+	if s.config.RequireVolumePattern {
+		cond = rationale.Require
+	}
+	cond("Volume dry-up then return", volumePattern, "pullback %.1fx, reversal %.1fx avg", pullbackAvgVol/ind.AvgVol, volumeRatio)
+	cond = rationale.Prefer
+	if s.config.RequireBouncing {
+		cond = rationale.Require
+	}
+	cond("Higher low", bouncing, "low %.2f vs prior %.2f", today.Low, yesterday.Low)
+	rationale.Level("MA20", ind.MA20).Level("MA50", ind.MA50)
+
 	return &Signal{
 		Stock:       stock,
 		Type:        signalType,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
 		Reason:      reason,
+		Rationale:   rationale,
 		Details:     details,
 		Guide:       guide,
 	}, nil
@@ -0,0 +1,122 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"traveler/internal/money"
+)
+
+// Rationale 시그널 근거: 충족/미충족 조건과 핵심 가격대 (Reason 한 줄보다 자세한 설명용)
+type Rationale struct {
+	Summary    string      `json:"summary"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	Levels     []Level     `json:"levels,omitempty"` // 전략이 본 가격대 (MA, 돌파선 등). 진입/손절/목표는 Guide에서 렌더링 시 추가
+	Notes      []string    `json:"notes,omitempty"`
+}
+
+// Condition 전략 조건 하나
+type Condition struct {
+	Name     string `json:"name"`
+	Met      bool   `json:"met"`
+	Required bool   `json:"required"`        // false = 가점 조건 (미충족이어도 시그널)
+	Value    string `json:"value,omitempty"` // 판단에 쓴 값 ("RSI 42 < 50")
+}
+
+// Level 핵심 가격대
+type Level struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// NewRationale 요약 문장으로 시작
+func NewRationale(summary string) *Rationale {
+	return &Rationale{Summary: summary}
+}
+
+// Require 필수 조건 추가
+func (r *Rationale) Require(name string, met bool, format string, args ...interface{}) *Rationale {
+	r.Conditions = append(r.Conditions, Condition{Name: name, Met: met, Required: true, Value: fmt.Sprintf(format, args...)})
+	return r
+}
+
+// Prefer 가점 조건 추가
+func (r *Rationale) Prefer(name string, met bool, format string, args ...interface{}) *Rationale {
+	r.Conditions = append(r.Conditions, Condition{Name: name, Met: met, Value: fmt.Sprintf(format, args...)})
+	return r
+}
+
+// Level 가격대 추가 (0 이하는 무시)
+func (r *Rationale) Level(name string, price float64) *Rationale {
+	if price > 0 {
+		r.Levels = append(r.Levels, Level{Name: name, Price: price})
+	}
+	return r
+}
+
+// Note 부가 설명 추가
+func (r *Rationale) Note(format string, args ...interface{}) *Rationale {
+	r.Notes = append(r.Notes, fmt.Sprintf(format, args...))
+	return r
+}
+
+// Missed 충족하지 못한 조건 (가점 조건 포함)
+func (r *Rationale) Missed() []Condition {
+	var out []Condition
+	for _, c := range r.Conditions {
+		if !c.Met {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Explain 리포트/알림용 여러 줄 설명. Rationale이 없는 전략은 Reason + 가이드 가격대로 대신한다.
+//
+//	Pullback to MA20 in an uptrend
+//	  ✓ Above MA50: +6.2%
+//	  ✗ Volume pattern (optional): pullback 1.1x, reversal 0.7x
+//	  Levels: MA20 $101.30 · Entry $102.10 · Stop $98.40 · T1 $107.50 · T2 $111.20
+func (s *Signal) Explain() string {
+	r := s.Rationale
+	if r == nil {
+		r = &Rationale{Summary: s.Reason}
+	}
+	cur := money.ForSymbol(s.Stock.Symbol)
+
+	var b strings.Builder
+	b.WriteString(r.Summary)
+	for _, c := range r.Conditions {
+		mark := "✓"
+		if !c.Met {
+			mark = "✗"
+		}
+		name := c.Name
+		if !c.Required {
+			name += " (optional)"
+		}
+		fmt.Fprintf(&b, "\n  %s %s", mark, name)
+		if c.Value != "" {
+			b.WriteString(": " + c.Value)
+		}
+	}
+
+	levels := append([]Level(nil), r.Levels...)
+	if g := s.Guide; g != nil {
+		levels = append(levels, Level{"Entry", g.EntryPrice}, Level{"Stop", g.StopLoss},
+			Level{"T1", g.Target1}, Level{"T2", g.Target2}, Level{"T3", g.Target3})
+	}
+	var parts []string
+	for _, l := range levels {
+		if l.Price > 0 {
+			parts = append(parts, l.Name+" "+money.Price(l.Price, cur))
+		}
+	}
+	if len(parts) > 0 {
+		b.WriteString("\n  Levels: " + strings.Join(parts, " · "))
+	}
+	for _, n := range r.Notes {
+		b.WriteString("\n  Note: " + n)
+	}
+	return b.String()
+}
@@ -34,7 +34,7 @@ func Get(name string, p provider.Provider) (Strategy, error) {
 		return nil, fmt.Errorf("unknown strategy: %s (available: %v)", name, List())
 	}
 
-	return factory(p), nil
+	return withExitConfig(name, factory(p), p), nil
 }
 
 // List 등록된 전략 목록
@@ -64,8 +64,8 @@ func GetAll(p provider.Provider) []Strategy {
 	defer registryLock.RUnlock()
 
 	strategies := make([]Strategy, 0, len(registry))
-	for _, factory := range registry {
-		strategies = append(strategies, factory(p))
+	for name, factory := range registry {
+		strategies = append(strategies, withExitConfig(name, factory(p), p))
 	}
 	return strategies
 }
@@ -96,6 +96,15 @@ func init() {
 	Register("volume-spike", func(p provider.Provider) Strategy {
 		return NewVolumeSpikeStrategy(p)
 	})
+	Register("bb-squeeze", func(p provider.Provider) Strategy {
+		return NewBBSqueezeStrategy(DefaultBBSqueezeConfig(), p)
+	})
+	Register("sector-rotation", func(p provider.Provider) Strategy {
+		return NewSectorRotationStrategy(CurrentSectorRotationConfig(), p)
+	})
+	Register("pairs", func(p provider.Provider) Strategy {
+		return NewPairsStrategy(DefaultPairsConfig(), p)
+	})
 	Register("crypto-meta", func(p provider.Provider) Strategy {
 		return NewCryptoMetaStrategy(p)
 	})
@@ -155,6 +155,7 @@ func (s *RSIContrarianStrategy) Analyze(ctx context.Context, stock model.Stock)
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
 		Reason:      reason,
 		Details:     details,
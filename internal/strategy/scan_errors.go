@@ -0,0 +1,132 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"traveler/internal/provider"
+)
+
+// 스캔 이슈 분류 (시그널 없음과 데이터 문제를 구분)
+const (
+	IssueRateLimited   = "rate_limited"   // 제공자 호출 한도
+	IssueNoData        = "no_data"        // 캔들 없음/부족, 상장폐지·미지원 티커
+	IssueProviderError = "provider_error" // 그 밖의 제공자·네트워크 에러
+	IssueOther         = "other"          // 분석 중 에러
+	IssueFiltered      = "filtered"       // 시그널은 나왔지만 필터·임계값·상위 N·사이징에서 빠짐
+)
+
+// issueOrder 요약 출력 순서
+var issueOrder = []string{IssueRateLimited, IssueNoData, IssueProviderError, IssueOther, IssueFiltered}
+
+// ScanIssue 종목별 실패/제외 사유
+type ScanIssue struct {
+	Symbol   string `json:"symbol"`
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// ScanIssues 스캔 중 종목별 이슈 수집 (스캔 루프는 순차 실행)
+type ScanIssues struct {
+	Failed   int            `json:"failed"`   // 에러로 분석하지 못한 종목 수
+	Filtered int            `json:"filtered"` // 시그널이 걸러진 종목 수
+	Counts   map[string]int `json:"counts"`   // 분류별 종목 수
+	Symbols  []ScanIssue    `json:"symbols"`
+}
+
+// NewScanIssues 빈 수집기
+func NewScanIssues() *ScanIssues {
+	return &ScanIssues{Counts: make(map[string]int), Symbols: []ScanIssue{}}
+}
+
+// AddError 분석 에러 기록 (종목당 한 번, 멀티 전략이면 전부 실패한 종목만)
+func (s *ScanIssues) AddError(symbol string, err error) {
+	if s == nil || err == nil {
+		return
+	}
+	cat := ClassifyScanError(err)
+	s.Failed++
+	s.Counts[cat]++
+	s.Symbols = append(s.Symbols, ScanIssue{Symbol: symbol, Category: cat, Reason: err.Error()})
+}
+
+// TrackFiltered before에 있던 시그널 중 after에 없는 종목을 reason으로 제외 기록
+func (s *ScanIssues) TrackFiltered(before []string, after []Signal, reason string) {
+	if s == nil {
+		return
+	}
+	kept := make(map[string]bool, len(after))
+	for _, sig := range after {
+		kept[sig.Stock.Symbol] = true
+	}
+	for _, sym := range before {
+		if kept[sym] {
+			continue
+		}
+		s.Filtered++
+		s.Counts[IssueFiltered]++
+		s.Symbols = append(s.Symbols, ScanIssue{Symbol: sym, Category: IssueFiltered, Reason: reason})
+	}
+}
+
+// Empty 기록된 이슈가 없으면 true
+func (s *ScanIssues) Empty() bool {
+	return s == nil || len(s.Symbols) == 0
+}
+
+// Lines 분류별 한 줄 요약 ("no_data: 12 (AAPL, MSFT, ... +9)")
+func (s *ScanIssues) Lines(maxSymbols int) []string {
+	if s.Empty() {
+		return nil
+	}
+	bySym := make(map[string][]string)
+	for _, is := range s.Symbols {
+		bySym[is.Category] = append(bySym[is.Category], is.Symbol)
+	}
+	var lines []string
+	for _, cat := range issueOrder {
+		syms := bySym[cat]
+		if len(syms) == 0 {
+			continue
+		}
+		sort.Strings(syms)
+		shown := syms
+		if maxSymbols > 0 && len(shown) > maxSymbols {
+			shown = shown[:maxSymbols]
+		}
+		line := fmt.Sprintf("%s: %d (%s", cat, len(syms), strings.Join(shown, ", "))
+		if len(shown) < len(syms) {
+			line += fmt.Sprintf(", ... +%d", len(syms)-len(shown))
+		}
+		lines = append(lines, line+")")
+	}
+	return lines
+}
+
+// ClassifyScanError 분석 에러 → 이슈 분류
+func ClassifyScanError(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "rate limit") || strings.Contains(msg, "429") || strings.Contains(msg, "too many requests"):
+		return IssueRateLimited
+	case strings.Contains(msg, "insufficient data") || strings.Contains(msg, "no data") ||
+		strings.Contains(msg, "not found") || strings.Contains(msg, "no candles"):
+		return IssueNoData
+	}
+	var pe *provider.ProviderError
+	if errors.As(err, &pe) {
+		return IssueProviderError
+	}
+	return IssueOther
+}
+
+// SignalSymbols 필터 단계 전 종목 목록 (필터가 슬라이스를 제자리에서 줄이므로 복사)
+func SignalSymbols(signals []Signal) []string {
+	out := make([]string, len(signals))
+	for i, s := range signals {
+		out[i] = s.Stock.Symbol
+	}
+	return out
+}
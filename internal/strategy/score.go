@@ -0,0 +1,17 @@
+package strategy
+
+import (
+	"traveler/internal/scoring"
+	"traveler/pkg/model"
+)
+
+// scoreTechnical 시그널 일봉의 RSI/거래량/추세 스냅샷 + 통합 점수 (모닝딥 분석과 같은 척도)
+func scoreTechnical(candles []model.Candle, strength float64) *model.TechnicalAnalysis {
+	if len(candles) == 0 {
+		return nil
+	}
+	ta := scoring.Snapshot(candles)
+	ta.Score = scoring.Composite(strength, ta)
+	ta.Recommendation = scoring.Recommendation(ta.Score)
+	return ta
+}
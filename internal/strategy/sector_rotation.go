@@ -0,0 +1,322 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/provider"
+	"traveler/internal/symbols"
+	"traveler/pkg/model"
+)
+
+// SectorRotationConfig 섹터 모멘텀 로테이션 설정
+type SectorRotationConfig struct {
+	Sectors         []string // 후보 섹터 ETF (비우면 SPDR 11종)
+	TopN            int      // 보유할 상위 섹터 수
+	ShortLookback   int      // 단기 모멘텀 기간 (거래일, 63 = 3개월)
+	LongLookback    int      // 장기 모멘텀 기간 (거래일, 126 = 6개월)
+	ShortWeight     float64  // 단기 모멘텀 가중치 (장기는 1 - ShortWeight)
+	RequirePositive bool     // 모멘텀 점수가 0 이하인 섹터는 상위여도 매수하지 않음 (절대 모멘텀)
+	StopPct         float64  // 안전망 손절 % (주 청산은 순위 이탈 리밸런싱)
+}
+
+// DefaultSectorRotationConfig 기본 설정
+func DefaultSectorRotationConfig() SectorRotationConfig {
+	return SectorRotationConfig{
+		Sectors:         symbols.USSectorETFSymbols,
+		TopN:            3,
+		ShortLookback:   63,
+		LongLookback:    126,
+		ShortWeight:     0.5,
+		RequirePositive: true,
+		StopPct:         8,
+	}
+}
+
+var (
+	sectorRotationConfig   = DefaultSectorRotationConfig()
+	sectorRotationConfigMu sync.RWMutex
+)
+
+// SetSectorRotationConfig 레지스트리/모니터가 쓰는 섹터 로테이션 설정 (0 = 기본값)
+func SetSectorRotationConfig(cfg SectorRotationConfig) error {
+	def := DefaultSectorRotationConfig()
+	if len(cfg.Sectors) == 0 {
+		cfg.Sectors = def.Sectors
+	}
+	if cfg.TopN == 0 {
+		cfg.TopN = def.TopN
+	}
+	if cfg.ShortLookback == 0 {
+		cfg.ShortLookback = def.ShortLookback
+	}
+	if cfg.LongLookback == 0 {
+		cfg.LongLookback = def.LongLookback
+	}
+	if cfg.ShortWeight == 0 {
+		cfg.ShortWeight = def.ShortWeight
+	}
+	if cfg.StopPct == 0 {
+		cfg.StopPct = def.StopPct
+	}
+	switch {
+	case cfg.TopN < 0 || cfg.TopN > len(cfg.Sectors):
+		return fmt.Errorf("sector rotation top_n %d out of range (1-%d)", cfg.TopN, len(cfg.Sectors))
+	case cfg.ShortLookback < 0 || cfg.LongLookback < 0:
+		return fmt.Errorf("sector rotation lookbacks must be positive")
+	case cfg.ShortWeight < 0 || cfg.ShortWeight > 1:
+		return fmt.Errorf("sector rotation short_weight %.2f out of range (0-1)", cfg.ShortWeight)
+	case cfg.StopPct < 0 || cfg.StopPct >= 100:
+		return fmt.Errorf("sector rotation stop_pct %.1f out of range", cfg.StopPct)
+	}
+	sectors := make([]string, len(cfg.Sectors))
+	for i, sym := range cfg.Sectors {
+		sectors[i] = strings.ToUpper(strings.TrimSpace(sym))
+	}
+	cfg.Sectors = sectors
+
+	sectorRotationConfigMu.Lock()
+	sectorRotationConfig = cfg
+	sectorRotationConfigMu.Unlock()
+	return nil
+}
+
+// CurrentSectorRotationConfig 현재 섹터 로테이션 설정
+func CurrentSectorRotationConfig() SectorRotationConfig {
+	sectorRotationConfigMu.RLock()
+	defer sectorRotationConfigMu.RUnlock()
+	return sectorRotationConfig
+}
+
+// SectorMomentum 섹터별 모멘텀 순위
+type SectorMomentum struct {
+	Symbol      string
+	Rank        int     // 1부터
+	ShortReturn float64 // 단기 수익률 (%)
+	LongReturn  float64 // 장기 수익률 (%)
+	Score       float64 // 가중 모멘텀 (%)
+}
+
+// 월간 순위는 전월 마지막 거래일 종가 기준으로 계산한다 (그 달 첫 거래일에 정해진 순위).
+// 매일 최신 데이터로 다시 계산하면 데몬이 세션마다 재시작할 때 순위 흔들림마다 매매하게 된다.
+// 같은 달 안에서는 어느 날 계산해도 같은 결과이므로 캐시는 조회를 줄이는 용도다.
+var (
+	sectorRankCache   = make(map[string][]SectorMomentum)
+	sectorRankCacheMu sync.Mutex
+)
+
+// ResetSectorRankingCache 월간 순위 캐시 초기화 (백테스트 시작 시)
+func ResetSectorRankingCache() {
+	sectorRankCacheMu.Lock()
+	sectorRankCache = make(map[string][]SectorMomentum)
+	sectorRankCacheMu.Unlock()
+}
+
+// SectorRanking asOf가 속한 달의 섹터 순위 (전월 마지막 거래일 종가까지의 데이터로 계산)
+func SectorRanking(ctx context.Context, p provider.Provider, cfg SectorRotationConfig, asOf time.Time) ([]SectorMomentum, error) {
+	key := fmt.Sprintf("%s|%v|%d/%d/%.2f", asOf.Format("2006-01"), cfg.Sectors, cfg.ShortLookback, cfg.LongLookback, cfg.ShortWeight)
+
+	sectorRankCacheMu.Lock()
+	defer sectorRankCacheMu.Unlock()
+	if ranking, ok := sectorRankCache[key]; ok {
+		return ranking, nil
+	}
+
+	ranking, err := rankSectors(ctx, p, cfg, asOf)
+	if err != nil {
+		return nil, err
+	}
+	sectorRankCache[key] = ranking
+	return ranking, nil
+}
+
+// rankSectors 섹터별 단기/장기 수익률 가중 평균으로 순위 (asOf 달 이전 캔들만, 데이터가 부족한 섹터는 제외)
+func rankSectors(ctx context.Context, p provider.Provider, cfg SectorRotationConfig, asOf time.Time) ([]SectorMomentum, error) {
+	days := cfg.LongLookback
+	if cfg.ShortLookback > days {
+		days = cfg.ShortLookback
+	}
+
+	ranking := make([]SectorMomentum, 0, len(cfg.Sectors))
+	for _, sym := range cfg.Sectors {
+		// 이번 달 경과 거래일(최대 23일)만큼 더 받아 잘라낸다
+		candles, err := p.GetDailyCandles(ctx, sym, days+35)
+		if err == nil {
+			candles = beforeMonth(candles, asOf)
+		}
+		if err != nil || len(candles) <= days {
+			log.Printf("[SECTOR] %s skipped: insufficient data", sym)
+			continue
+		}
+		short := periodReturn(candles, cfg.ShortLookback)
+		long := periodReturn(candles, cfg.LongLookback)
+		ranking = append(ranking, SectorMomentum{
+			Symbol:      sym,
+			ShortReturn: short,
+			LongReturn:  long,
+			Score:       short*cfg.ShortWeight + long*(1-cfg.ShortWeight),
+		})
+	}
+	if len(ranking) == 0 {
+		return nil, fmt.Errorf("no sector data")
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool { return ranking[i].Score > ranking[j].Score })
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+	return ranking, nil
+}
+
+// beforeMonth asOf가 속한 달 이전 캔들 (시간순 정렬 가정)
+func beforeMonth(candles []model.Candle, asOf time.Time) []model.Candle {
+	y, m := asOf.Year(), asOf.Month()
+	n := len(candles)
+	for n > 0 {
+		t := candles[n-1].Time
+		if t.Year() < y || (t.Year() == y && t.Month() < m) {
+			break
+		}
+		n--
+	}
+	return candles[:n]
+}
+
+// periodReturn 마지막 종가의 lookback봉 전 종가 대비 수익률 (%)
+func periodReturn(candles []model.Candle, lookback int) float64 {
+	n := len(candles)
+	base := candles[n-1-lookback].Close
+	if base == 0 {
+		return 0
+	}
+	return (candles[n-1].Close - base) / base * 100
+}
+
+// InTopSectors 순위에서 상위 topN(필요하면 양의 모멘텀까지) 안에 드는지
+func InTopSectors(ranking []SectorMomentum, cfg SectorRotationConfig, symbol string) (SectorMomentum, bool) {
+	for _, m := range ranking {
+		if m.Symbol != symbol {
+			continue
+		}
+		if m.Rank > cfg.TopN || (cfg.RequirePositive && m.Score <= 0) {
+			return m, false
+		}
+		return m, true
+	}
+	return SectorMomentum{}, false
+}
+
+// SectorRotationStrategy 섹터 ETF 모멘텀 로테이션
+// 매달 3/6개월 모멘텀으로 섹터 ETF 순위를 매겨 상위 N개를 같은 비중으로 보유한다.
+// 순위에서 밀려난 섹터는 모니터가 리밸런싱 매도하고, 새로 올라온 섹터는 다음 스캔에서 매수한다.
+// 매매 빈도가 낮아 보수적인 사용자를 위한 대안.
+type SectorRotationStrategy struct {
+	config   SectorRotationConfig
+	provider provider.Provider
+}
+
+// NewSectorRotationStrategy 생성자
+func NewSectorRotationStrategy(cfg SectorRotationConfig, p provider.Provider) *SectorRotationStrategy {
+	return &SectorRotationStrategy{config: cfg, provider: p}
+}
+
+func (s *SectorRotationStrategy) Name() string {
+	return "sector-rotation"
+}
+
+func (s *SectorRotationStrategy) Description() string {
+	return fmt.Sprintf("Sector momentum rotation: hold top %d sector ETFs by 3/6-month momentum, rebalanced monthly", s.config.TopN)
+}
+
+// ResetRegimeCache 백테스트 일자 전환 시 호출 (순위는 월 단위 캐시라 그대로 둔다)
+func (s *SectorRotationStrategy) ResetRegimeCache() {}
+
+// Analyze 이번 달 상위 섹터에 드는 ETF만 BUY (목표 비중은 Details["target_weight"])
+func (s *SectorRotationStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
+	sym := strings.ToUpper(stock.Symbol)
+	isSector := false
+	for _, sector := range s.config.Sectors {
+		if sector == sym {
+			isSector = true
+			break
+		}
+	}
+	if !isSector || s.config.TopN <= 0 || s.config.StopPct <= 0 {
+		return nil, nil
+	}
+
+	candles, err := s.provider.GetDailyCandles(ctx, stock.Symbol, 90)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) < 20 {
+		return nil, nil
+	}
+
+	ranking, err := SectorRanking(ctx, s.provider, s.config, candles[len(candles)-1].Time)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := InTopSectors(ranking, s.config, sym)
+	if !ok {
+		return nil, nil
+	}
+
+	price := candles[len(candles)-1].Close
+	atr := CalculateATR(candles, 14)
+	// 안전망 손절: 주 청산은 순위 이탈 리밸런싱
+	stopLoss := price * (1 - s.config.StopPct/100)
+	// 목표가는 손절폭 기준 (R/R 1.6 / 2.5) — 대부분 리밸런싱으로 먼저 청산된다
+	target1Pct := s.config.StopPct * 1.6
+	target2Pct := s.config.StopPct * 2.5
+	target1 := price * (1 + target1Pct/100)
+	target2 := price * (1 + target2Pct/100)
+
+	strength := 90 - 10*float64(m.Rank-1)
+	if strength < 50 {
+		strength = 50
+	}
+
+	reason := fmt.Sprintf("[SECTOR] #%d of %d: momentum %.1f%% (3m %+.1f%%, 6m %+.1f%%)",
+		m.Rank, len(ranking), m.Score, m.ShortReturn, m.LongReturn)
+	if name, ok := symbols.USETFNames[sym]; ok {
+		reason += " — " + name
+	}
+
+	return &Signal{
+		Stock:       stock,
+		Type:        SignalBuy,
+		Strategy:    s.Name(),
+		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
+		Probability: 60,
+		Reason:      reason,
+		Details: map[string]float64{
+			"sector_rank":    float64(m.Rank),
+			"momentum_3m":    m.ShortReturn,
+			"momentum_6m":    m.LongReturn,
+			"momentum_score": m.Score,
+			"target_weight":  1 / float64(s.config.TopN),
+			"top_n":          float64(s.config.TopN),
+			"regime":         0, // 섹터 로테이션은 레짐 무관 (절대 모멘텀으로 방어)
+		},
+		Guide: &TradeGuide{
+			EntryPrice:      price,
+			EntryType:       "market",
+			StopLoss:        stopLoss,
+			StopLossPct:     s.config.StopPct,
+			Target1:         target1,
+			Target1Pct:      target1Pct,
+			Target2:         target2,
+			Target2Pct:      target2Pct,
+			RiskRewardRatio: target1Pct / s.config.StopPct,
+			EntryATR:        atr,
+		},
+		Candles: trimCandles(candles, 90),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package strategy
+
+import (
+	"time"
+
+	"traveler/internal/symbols"
+	"traveler/pkg/model"
+)
+
+// cryptoSession 업비트 일봉 길이 (KST 09:00 시작, 24/7 거래)
+const cryptoSession = 24 * time.Hour
+
+// minSessionElapsed 진행 중 일봉 거래량 환산의 최소 경과 시간 (세션 초반 과대 환산 방지)
+const minSessionElapsed = 2 * time.Hour
+
+// projectSessionVolume 24/7 시장(크립토)의 진행 중인 마지막 일봉 거래량을 하루치로 환산한 복사본
+// 주식은 장 마감 후 스캔하면 마지막 일봉이 완성돼 있지만 크립토 일봉은 언제 스캔해도 진행 중이라
+// 평균 대비 거래량 비율(돌파 확인, 눌림 거래량 감소, 유동성 필터)이 스캔 시각에 따라 왜곡된다.
+// 크립토가 아니거나 마지막 일봉이 이미 끝났으면 원본을 그대로 돌려준다.
+func projectSessionVolume(symbol string, candles []model.Candle, now time.Time) []model.Candle {
+	if len(candles) == 0 || !symbols.IsCryptoSymbol(symbol) {
+		return candles
+	}
+	last := candles[len(candles)-1]
+	elapsed := now.Sub(last.Time)
+	if elapsed < 0 || elapsed >= cryptoSession {
+		return candles
+	}
+	if elapsed < minSessionElapsed {
+		elapsed = minSessionElapsed
+	}
+	out := make([]model.Candle, len(candles))
+	copy(out, candles)
+	out[len(out)-1].Volume = int64(float64(last.Volume) * float64(cryptoSession) / float64(elapsed))
+	return out
+}
@@ -0,0 +1,48 @@
+package strategy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"traveler/internal/symbols"
+)
+
+// SignalID 종목+전략+거래일 해시 (같은 날 같은 종목·전략 시그널은 실행이 달라도 같은 ID)
+// diff, 주문 저널, 웹 제외 목록이 종목 이름 대신 이 ID로 시그널을 가리킨다.
+func SignalID(symbol, strategyName string, date time.Time) string {
+	sum := sha256.Sum256([]byte(symbol + "|" + strategyName + "|" + date.Format("2006-01-02")))
+	return hex.EncodeToString(sum[:6])
+}
+
+// SignalDate 시그널의 거래일: 일봉이 있으면 마지막 봉 날짜, 없으면 마켓 현지 날짜
+func SignalDate(sig *Signal, now time.Time) time.Time {
+	if n := len(sig.Candles); n > 0 && !sig.Candles[n-1].Time.IsZero() {
+		return sig.Candles[n-1].Time
+	}
+	return now.In(signalLocation(sig.Stock.Symbol))
+}
+
+// AssignSignalIDs ID가 비어 있는 시그널에 SignalID를 채운다 (제자리)
+func AssignSignalIDs(signals []Signal, now time.Time) {
+	for i := range signals {
+		if signals[i].ID == "" {
+			signals[i].ID = SignalID(signals[i].Stock.Symbol, signals[i].Strategy, SignalDate(&signals[i], now))
+		}
+	}
+}
+
+// signalLocation 거래일을 가르는 시간대 (US는 뉴욕, KR은 서울, 크립토는 Upbit 일봉 기준 UTC)
+func signalLocation(symbol string) *time.Location {
+	switch {
+	case symbols.IsCryptoSymbol(symbol):
+		return time.UTC
+	case symbols.IsKoreanSymbol(symbol):
+		return time.FixedZone("KST", 9*60*60)
+	}
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
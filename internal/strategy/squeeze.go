@@ -0,0 +1,393 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"traveler/internal/provider"
+	"traveler/internal/symbols"
+	"traveler/pkg/model"
+)
+
+// BBSqueezeConfig holds configuration for the Bollinger squeeze strategy
+type BBSqueezeConfig struct {
+	WidthLookback    int     // BB 폭 백분위 비교 구간 (default 120 days, ~6개월)
+	SqueezePct       float64 // 수렴 판정: BB 폭이 구간 하위 N% 이내 (default 20)
+	SqueezeWindow    int     // 수렴을 확인할 최근 구간 (default 15 days, ~3주)
+	MinSqueezeDays   int     // 그 구간 중 하위 N%였던 최소 일수 (default 10)
+	NR7Window        int     // NR7(7일 중 최소 레인지) 날을 찾을 최근 구간 (default 10 days)
+	BreakoutPeriod   int     // 돌파 기준 최고가 기간 (default 20 days)
+	VolumeMultiple   float64 // Minimum volume vs average (default 1.5x)
+	MaxRSI           float64 // Maximum RSI (default 75)
+	RequireAboveMA50 bool    // MA50 위 필수 여부 (default true)
+
+	// Quality filters
+	MinPrice          float64
+	MaxTickerLength   int
+	MinDailyDollarVol float64
+
+	// Market regime filter: broad market must be above MA20
+	// US: "SPY", KR: "069500" (KODEX 200)
+	MarketRegimeSymbol string
+}
+
+// DefaultBBSqueezeConfig returns default configuration
+func DefaultBBSqueezeConfig() BBSqueezeConfig {
+	return BBSqueezeConfig{
+		WidthLookback:    120,
+		SqueezePct:       20,
+		SqueezeWindow:    15,
+		MinSqueezeDays:   10,
+		NR7Window:        10,
+		BreakoutPeriod:   20,
+		VolumeMultiple:   1.5,
+		MaxRSI:           75,
+		RequireAboveMA50: true,
+
+		MinPrice:          5.0,
+		MaxTickerLength:   4,
+		MinDailyDollarVol: 500000,
+	}
+}
+
+// BBSqueezeStrategy implements the "Bollinger Squeeze" strategy
+// breakout 전략은 5일 전 BB 폭만 비교하지만, 여기서는 수 주간의 변동성 수축을 확인한 뒤 확장 돌파를 산다.
+// Buy signal when:
+// 1. 최근 3주 중 10일 이상 BB 폭이 6개월 하위 20% (다주간 수렴)
+// 2. 종가가 20일 최고가 돌파 + BB 폭 확장 시작
+// 3. Volume 1.5x+ above 20-day average
+// 4. Above MA50
+// Supporting: NR7 day during the squeeze, RSI not overbought, very tight squeeze (하위 10%)
+type BBSqueezeStrategy struct {
+	config   BBSqueezeConfig
+	provider provider.Provider
+
+	// Market regime cache (per scan session)
+	regime ma20RegimeGate
+}
+
+// NewBBSqueezeStrategy creates a new Bollinger squeeze strategy
+func NewBBSqueezeStrategy(cfg BBSqueezeConfig, p provider.Provider) *BBSqueezeStrategy {
+	return &BBSqueezeStrategy{
+		config:   cfg,
+		provider: p,
+	}
+}
+
+// Name returns the strategy name
+func (s *BBSqueezeStrategy) Name() string {
+	return "bb-squeeze"
+}
+
+// Description returns the strategy description
+func (s *BBSqueezeStrategy) Description() string {
+	return "BB Squeeze - Buy the volume-confirmed expansion after multi-week volatility contraction"
+}
+
+// ResetRegimeCache resets the cached regime check (call at start of each scan cycle)
+func (s *BBSqueezeStrategy) ResetRegimeCache() {
+	s.regime.reset()
+}
+
+// Analyze analyzes a stock for a squeeze breakout
+func (s *BBSqueezeStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
+	// Market regime filter: skip all entries if broad market is below MA20
+	if !s.regime.check(ctx, s.provider, s.config.MarketRegimeSymbol, "SQUEEZE", "squeeze") {
+		return nil, nil
+	}
+
+	if err := checkTickerLength(stock.Symbol, s.config.MaxTickerLength); err != nil {
+		return nil, err
+	}
+
+	// BB 폭 백분위 구간 + BB 기간(20) + 여유
+	need := s.config.WidthLookback + 20
+	candles, err := s.provider.GetDailyCandles(ctx, stock.Symbol, need+10)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candles) < need {
+		return nil, fmt.Errorf("insufficient data: got %d candles, need %d", len(candles), need)
+	}
+	candles = projectSessionVolume(stock.Symbol, candles, time.Now())
+
+	// Calculate indicators
+	ind := CalculateIndicators(candles)
+	if ind.MA50 == 0 || ind.BBWidth == 0 {
+		return nil, fmt.Errorf("could not calculate indicators")
+	}
+
+	today := candles[len(candles)-1]
+
+	// Quality filters (min price does not apply to coins)
+	if s.config.MinPrice > 0 && today.Close < s.config.MinPrice && !symbols.IsCryptoSymbol(stock.Symbol) {
+		return nil, fmt.Errorf("price too low: $%.2f", today.Close)
+	}
+
+	dailyDollarVol := today.Close * float64(today.Volume)
+	if s.config.MinDailyDollarVol > 0 && dailyDollarVol < s.config.MinDailyDollarVol {
+		return nil, fmt.Errorf("liquidity too low: $%.0f", dailyDollarVol)
+	}
+
+	details := make(map[string]float64)
+	details["daily_dollar_vol"] = dailyDollarVol
+	details["close"] = today.Close
+
+	// Condition 1: 다주간 수렴 — 어제까지 SqueezeWindow일 중 MinSqueezeDays일 이상 BB 폭 하위 SqueezePct%
+	widths := bbWidthSeries(candles[:len(candles)-1], 20, s.config.WidthLookback)
+	if len(widths) < s.config.SqueezeWindow {
+		return nil, fmt.Errorf("could not calculate BB width history")
+	}
+	threshold := percentileValue(widths, s.config.SqueezePct)
+	squeezeDays := 0
+	for _, w := range widths[len(widths)-s.config.SqueezeWindow:] {
+		if w <= threshold {
+			squeezeDays++
+		}
+	}
+	priorWidth := widths[len(widths)-1]
+	priorPctRank := percentileRank(widths, priorWidth)
+	squeeze := squeezeDays >= s.config.MinSqueezeDays
+	details["bb_width"] = ind.BBWidth
+	details["prior_bb_width"] = priorWidth
+	details["bb_width_threshold"] = threshold
+	details["bb_width_pct_rank"] = priorPctRank
+	details["squeeze_days"] = float64(squeezeDays)
+
+	// Condition 2: 확장 돌파 — 종가가 20일 최고가 위 + BB 폭이 어제보다 넓어짐
+	highestHigh := CalculateHighestHigh(candles, s.config.BreakoutPeriod)
+	rangeLow := CalculateLowestLow(candles, s.config.BreakoutPeriod)
+	breakout := highestHigh > 0 && today.Close > highestHigh
+	expanding := ind.BBWidth > priorWidth
+	details["highest_high_20"] = highestHigh
+	details["range_low"] = rangeLow
+	if highestHigh > 0 {
+		details["breakout_pct"] = (today.Close - highestHigh) / highestHigh * 100
+	}
+
+	// Condition 3: Volume confirmation
+	volumeRatio := float64(today.Volume) / ind.AvgVol
+	volumeConfirm := volumeRatio >= s.config.VolumeMultiple
+	details["volume_ratio"] = volumeRatio
+
+	// Condition 4: Above MA50 (trend confirmation)
+	aboveMA50 := today.Close > ind.MA50
+	details["ma50"] = ind.MA50
+	details["ma20"] = ind.MA20
+
+	// Supporting conditions
+	nr7Days := countNR7(candles[:len(candles)-1], s.config.NR7Window)
+	hasNR7 := nr7Days > 0
+	details["nr7_days"] = float64(nr7Days)
+
+	rsiOK := ind.RSI14 < s.config.MaxRSI
+	details["rsi14"] = ind.RSI14
+
+	tightSqueeze := priorPctRank <= s.config.SqueezePct/2
+
+	if !squeeze || !breakout || !expanding || !volumeConfirm {
+		return nil, nil
+	}
+	if s.config.RequireAboveMA50 && !aboveMA50 {
+		return nil, nil
+	}
+
+	strength := calculateSqueezeStrength(squeeze, breakout, volumeConfirm, aboveMA50, hasNR7, rsiOK, tightSqueeze, volumeRatio)
+	probability := calculateSqueezeProbability(strength, volumeRatio, hasNR7, tightSqueeze)
+	guide := s.calculateTradeGuide(today.Close, highestHigh, rangeLow, ind.ATR14)
+
+	reason := fmt.Sprintf("Squeeze breakout: BB width bottom %.0f%% for %d/%d days, close above 20d high ($%.2f), volume %.1fx avg",
+		s.config.SqueezePct, squeezeDays, s.config.SqueezeWindow, highestHigh, volumeRatio)
+	if hasNR7 {
+		reason += fmt.Sprintf(", %d NR7 day(s)", nr7Days)
+	}
+
+	return &Signal{
+		Stock:       stock,
+		Type:        SignalBuy,
+		Strategy:    s.Name(),
+		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
+		Probability: probability,
+		Reason:      reason,
+		Details:     details,
+		Guide:       guide,
+	}, nil
+}
+
+// calculateTradeGuide 손절은 수렴 박스 하단, 목표는 박스 높이 측정이동
+func (s *BBSqueezeStrategy) calculateTradeGuide(currentPrice, breakoutLevel, rangeLow, atr float64) *TradeGuide {
+	// 손절: 수렴 박스 하단 - 0.25 ATR 과 2.5 ATR 중 높은 쪽 (최대 -7%)
+	stopLoss := currentPrice - atr*2.5
+	if rangeLow > 0 {
+		stopLoss = math.Max(stopLoss, rangeLow-atr*0.25)
+	}
+	if minStop := currentPrice * 0.93; stopLoss < minStop {
+		stopLoss = minStop
+	}
+	riskPerShare := currentPrice - stopLoss
+
+	// 익절: 박스 높이의 1배/2배 (최소 1.5R/3R)
+	boxHeight := breakoutLevel - rangeLow
+	target1 := breakoutLevel + boxHeight
+	if target1-currentPrice < riskPerShare*1.5 {
+		target1 = currentPrice + riskPerShare*1.5
+	}
+	target2 := breakoutLevel + boxHeight*2
+	if target2-currentPrice < riskPerShare*3 {
+		target2 = currentPrice + riskPerShare*3
+	}
+
+	guide := &TradeGuide{
+		EntryPrice:  currentPrice,
+		EntryType:   "limit",
+		StopLoss:    stopLoss,
+		StopLossPct: riskPerShare / currentPrice * 100,
+		Target1:     target1,
+		Target1Pct:  (target1 - currentPrice) / currentPrice * 100,
+		Target2:     target2,
+		Target2Pct:  (target2 - currentPrice) / currentPrice * 100,
+	}
+	if riskPerShare > 0 {
+		guide.RiskRewardRatio = (target1 - currentPrice) / riskPerShare
+	}
+
+	// Kelly fraction (breakout과 같은 가정: 낮은 승률, 높은 R:R)
+	winRate := 0.45
+	avgWin := 2.25
+	avgLoss := 1.0
+	guide.KellyFraction = (winRate*avgWin - (1-winRate)*avgLoss) / avgWin
+	if guide.KellyFraction < 0 {
+		guide.KellyFraction = 0
+	}
+
+	guide.UseTrailingStop = false
+	guide.TrailingMultiplier = 2.5
+	guide.EntryATR = atr
+
+	return guide
+}
+
+// bbWidthSeries 마지막 lookback봉 각각의 BB 폭 (오래된 것부터)
+func bbWidthSeries(candles []model.Candle, period, lookback int) []float64 {
+	widths := make([]float64, 0, lookback)
+	for end := len(candles) - lookback + 1; end <= len(candles); end++ {
+		if end < period {
+			continue
+		}
+		_, _, w := CalculateBollingerBands(candles[:end], period, 2.0)
+		widths = append(widths, w)
+	}
+	return widths
+}
+
+// percentileValue 값 목록의 pct 백분위 값 (최근접 순위)
+func percentileValue(values []float64, pct float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	idx := int(math.Ceil(pct/100*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// percentileRank v 이하인 값의 비율 (%)
+func percentileRank(values []float64, v float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	n := 0
+	for _, x := range values {
+		if x <= v {
+			n++
+		}
+	}
+	return float64(n) / float64(len(values)) * 100
+}
+
+// countNR7 최근 window봉 중 NR7(직전 7봉 중 고저 레인지가 가장 좁은 날) 개수
+func countNR7(candles []model.Candle, window int) int {
+	count := 0
+	for i := len(candles) - window; i < len(candles); i++ {
+		if i < 6 {
+			continue
+		}
+		r := candles[i].High - candles[i].Low
+		narrowest := true
+		for j := i - 6; j < i; j++ {
+			if candles[j].High-candles[j].Low <= r {
+				narrowest = false
+				break
+			}
+		}
+		if narrowest {
+			count++
+		}
+	}
+	return count
+}
+
+func calculateSqueezeStrength(
+	squeeze, breakout, volumeConfirm, aboveMA50 bool,
+	hasNR7, rsiOK, tightSqueeze bool,
+	volumeRatio float64,
+) float64 {
+	var score float64
+
+	// Core conditions (70 points)
+	if squeeze {
+		score += 20
+	}
+	if breakout {
+		score += 20
+	}
+	if volumeConfirm {
+		score += 15
+		if volumeRatio > 2.0 {
+			score += 5
+		}
+	}
+	if aboveMA50 {
+		score += 10
+	}
+
+	// Supporting conditions (30 points)
+	if hasNR7 {
+		score += 10
+	}
+	if rsiOK {
+		score += 10
+	}
+	if tightSqueeze {
+		score += 10
+	}
+
+	return math.Min(score, 100)
+}
+
+func calculateSqueezeProbability(strength, volumeRatio float64, hasNR7, tightSqueeze bool) float64 {
+	prob := 45.0
+
+	// Strength contribution: max +8%
+	prob += strength * 0.08
+
+	if volumeRatio > 2.0 {
+		prob += 2
+	}
+	if hasNR7 {
+		prob += 1
+	}
+	if tightSqueeze {
+		prob += 2
+	}
+
+	return math.Max(45, math.Min(prob, 65))
+}
@@ -21,6 +21,7 @@ type StockMetaConfig struct {
 	Sideways        []string          // strategy names active in sideways regime
 	Bear            []string          // strategy names active in bear regime
 	MaxHoldOverride map[string]int    // strategy name → override max hold days
+	Tranches        TrancheExit       // three-tranche exits (config trader.tranches, Enabled=false = strategy targets)
 }
 
 // DefaultStockMetaConfig returns the optimized config for a market and capital level.
@@ -239,6 +240,9 @@ func (s *StockMetaStrategy) Analyze(ctx context.Context, stock model.Stock) (*Si
 	var bestScore float64
 
 	for _, strat := range strategies {
+		if !CoversSymbol(strat.Name(), stock.Symbol) {
+			continue
+		}
 		sig, err := strat.Analyze(ctx, stock)
 		if err != nil || sig == nil {
 			continue
@@ -256,6 +260,9 @@ func (s *StockMetaStrategy) Analyze(ctx context.Context, stock model.Stock) (*Si
 		return nil, nil
 	}
 
+	// 전략별 손절 모델/분할 익절 (config trader.stop_models, trader.tranches) — RR 검사 전에 적용해 바뀐 기준으로 거른다
+	ApplyConfiguredExits(ctx, s.provider, bestSignal, s.config.Tranches)
+
 	// RR ratio 최소 1.5 강제 (개별종목만 — ETF는 시그널 역전 기반 청산이라 RR 무의미)
 	isETF := strings.Contains(bestSignal.Strategy, "etf-momentum")
 	if !isETF && bestSignal.Guide != nil && bestSignal.Guide.RiskRewardRatio > 0 && bestSignal.Guide.RiskRewardRatio < 1.45 {
@@ -284,6 +291,9 @@ func (s *StockMetaStrategy) Analyze(ctx context.Context, stock model.Stock) (*Si
 
 	// Add regime prefix to reason
 	bestSignal.Reason = fmt.Sprintf("[%s] %s", regimeLabel(regime), bestSignal.Reason)
+	if r := bestSignal.Rationale; r != nil {
+		r.Summary = fmt.Sprintf("[%s] %s", regimeLabel(regime), r.Summary)
+	}
 
 	// Override strategy name to include regime info (e.g., "breakout(bull)")
 	bestSignal.Strategy = fmt.Sprintf("%s(%s)", bestSignal.Strategy, regime)
@@ -0,0 +1,254 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"traveler/internal/provider"
+	"traveler/pkg/model"
+)
+
+// StopModel 전략 기본 손절 대신 쓸 손절 모델
+//
+//	atr[:배수]           진입가 - ATR14 × 배수 (기본 2)
+//	swing[:기간[:버퍼%]]  최근 N일 최저가 아래 (기본 10일, 0.5%)
+//	ma[:기간[:버퍼%]]     이동평균 아래 (기본 MA20, 1%)
+//
+// Kind가 비어 있으면 전략 자체 손절을 그대로 쓴다.
+type StopModel struct {
+	Kind      string  // "", "atr", "swing", "ma"
+	ATRMult   float64 // atr
+	Period    int     // swing: 저점 탐색 기간, ma: 이동평균 기간
+	BufferPct float64 // swing/ma: 기준가 아래 여유 (%)
+}
+
+// minStopDistance 진입가에 너무 붙은 손절은 무시 (수량 폭증 방지)
+const minStopDistance = 0.005
+
+// ParseStopModel "atr:2.5", "swing:10:0.5", "ma:20:1", "default" 파싱
+func ParseStopModel(spec string) (StopModel, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "" || spec == "default" {
+		return StopModel{}, nil
+	}
+	parts := strings.Split(spec, ":")
+	num := func(i int, def float64) (float64, error) {
+		if i >= len(parts) || parts[i] == "" {
+			return def, nil
+		}
+		v, err := strconv.ParseFloat(parts[i], 64)
+		if err != nil || v <= 0 {
+			return 0, fmt.Errorf("stop model %q: bad number %q", spec, parts[i])
+		}
+		return v, nil
+	}
+
+	var m StopModel
+	var err error
+	var period float64
+	switch parts[0] {
+	case "atr":
+		m.Kind = "atr"
+		if len(parts) > 2 {
+			return StopModel{}, fmt.Errorf("stop model %q: want atr[:multiple]", spec)
+		}
+		m.ATRMult, err = num(1, 2)
+	case "swing", "ma":
+		m.Kind = parts[0]
+		if len(parts) > 3 {
+			return StopModel{}, fmt.Errorf("stop model %q: want %s[:period[:buffer%%]]", spec, parts[0])
+		}
+		defPeriod, defBuf := 10.0, 0.5
+		if m.Kind == "ma" {
+			defPeriod, defBuf = 20, 1
+		}
+		if period, err = num(1, defPeriod); err == nil {
+			m.Period = int(period)
+			m.BufferPct, err = num(2, defBuf)
+		}
+	default:
+		return StopModel{}, fmt.Errorf("unknown stop model %q (atr, swing, ma, default)", spec)
+	}
+	if err != nil {
+		return StopModel{}, err
+	}
+	return m, nil
+}
+
+// IsSet 전략 기본 손절이 아닌 모델인지
+func (m StopModel) IsSet() bool {
+	return m.Kind != ""
+}
+
+// String ParseStopModel 입력 형식
+func (m StopModel) String() string {
+	switch m.Kind {
+	case "atr":
+		return fmt.Sprintf("atr:%g", m.ATRMult)
+	case "swing", "ma":
+		return fmt.Sprintf("%s:%d:%g", m.Kind, m.Period, m.BufferPct)
+	}
+	return "default"
+}
+
+// StopPrice candles(결정일까지)와 진입가로 손절가 계산. 계산할 수 없거나 진입가에 너무 가까우면 0
+func (m StopModel) StopPrice(candles []model.Candle, entry float64) float64 {
+	if entry <= 0 || len(candles) == 0 {
+		return 0
+	}
+	var stop float64
+	switch m.Kind {
+	case "atr":
+		if len(candles) < 15 {
+			return 0
+		}
+		stop = entry - CalculateATR(candles, 14)*m.ATRMult
+	case "swing":
+		if len(candles) < m.Period {
+			return 0
+		}
+		stop = CalculateLowestLow(candles, m.Period) * (1 - m.BufferPct/100)
+	case "ma":
+		if len(candles) < m.Period {
+			return 0
+		}
+		stop = CalculateMA(candles, m.Period) * (1 - m.BufferPct/100)
+	default:
+		return 0
+	}
+	if stop <= 0 || stop > entry*(1-minStopDistance) {
+		return 0
+	}
+	return stop
+}
+
+// Apply 가이드 손절가를 모델 손절가로 교체 (목표가 유지, 손익비 재계산). 바뀌었으면 true
+func (m StopModel) Apply(g *TradeGuide, candles []model.Candle) bool {
+	if g == nil || !m.IsSet() {
+		return false
+	}
+	stop := m.StopPrice(candles, g.EntryPrice)
+	if stop <= 0 {
+		return false
+	}
+	g.StopLoss = stop
+	g.StopLossPct = (g.EntryPrice - stop) / g.EntryPrice * 100
+	if risk := g.EntryPrice - stop; risk > 0 && g.Target1 > 0 {
+		g.RiskRewardRatio = (g.Target1 - g.EntryPrice) / risk
+	}
+	return true
+}
+
+// 전략별 손절 모델 (config trader.stop_models). 스캔/데몬/백테스트/모니터 플랜이 같은 값을 쓴다.
+var (
+	stopModels   = make(map[string]StopModel)
+	stopModelsMu sync.RWMutex
+)
+
+// SetStopModels 전략별 손절 모델 설정 ("default" = 전략 기본 손절로 되돌림)
+// 데몬/백테스트 시작 전에 호출한다.
+func SetStopModels(specs map[string]string) error {
+	parsed := make(map[string]StopModel, len(specs))
+	for name, spec := range specs {
+		m, err := ParseStopModel(spec)
+		if err != nil {
+			return fmt.Errorf("stop model for %s: %w", name, err)
+		}
+		parsed[name] = m
+	}
+	stopModelsMu.Lock()
+	defer stopModelsMu.Unlock()
+	for name, m := range parsed {
+		if !m.IsSet() {
+			delete(stopModels, name)
+			continue
+		}
+		stopModels[name] = m
+	}
+	return nil
+}
+
+// StopModelFor 전략 손절 모델 ("breakout(bull)" 같은 레짐 접미사는 무시)
+func StopModelFor(strategyName string) (StopModel, bool) {
+	if i := strings.Index(strategyName, "("); i > 0 {
+		strategyName = strategyName[:i]
+	}
+	stopModelsMu.RLock()
+	defer stopModelsMu.RUnlock()
+	m, ok := stopModels[strategyName]
+	return m, ok
+}
+
+// ApplyConfiguredExits 시그널 전략에 손절 모델/3분할 익절이 설정돼 있으면 가이드에 적용
+// 손절을 먼저 바꾼 뒤 그 손절 기준 R로 목표가를 잡는다. 시그널에 캔들이 없으면 일봉을 다시 받는다.
+func ApplyConfiguredExits(ctx context.Context, p provider.Provider, sig *Signal, tranches TrancheExit) {
+	if sig == nil || sig.Guide == nil {
+		return
+	}
+	m, hasStop := StopModelFor(sig.Strategy)
+	t, hasTranches := tranches.For(sig.Strategy)
+	if !hasStop && !hasTranches {
+		return
+	}
+	candles := sig.Candles
+	if len(candles) < 30 && p != nil {
+		if fetched, err := p.GetDailyCandles(ctx, sig.Stock.Symbol, 60); err == nil {
+			candles = fetched
+		} else if hasStop {
+			return
+		}
+	}
+	if hasStop {
+		m.Apply(sig.Guide, candles)
+	}
+	if hasTranches {
+		t.Apply(sig.Guide, candles)
+	}
+}
+
+// exitConfigStrategy 설정된 손절 모델/분할 익절을 시그널 가이드에 적용하는 래퍼
+type exitConfigStrategy struct {
+	Strategy
+	provider provider.Provider
+	tranches TrancheExit
+}
+
+// withExitConfig 손절 모델이 설정돼 있을 때만 감싼다 (설정 없으면 원래 타입 그대로)
+func withExitConfig(name string, s Strategy, p provider.Provider) Strategy {
+	if _, hasStop := StopModelFor(name); !hasStop {
+		return s
+	}
+	return &exitConfigStrategy{Strategy: s, provider: p}
+}
+
+// WithTrancheExit 레지스트리 전략(Get/GetAll)에 3분할 익절 적용 (꺼져 있거나 대상이 아니면 그대로)
+// 메타 전략은 StockMetaConfig.Tranches로 받는다.
+func WithTrancheExit(s Strategy, t TrancheExit, p provider.Provider) Strategy {
+	if _, ok := t.For(s.Name()); !ok {
+		return s
+	}
+	if ec, ok := s.(*exitConfigStrategy); ok {
+		wrapped := *ec
+		wrapped.tranches = t
+		return &wrapped
+	}
+	return &exitConfigStrategy{Strategy: s, provider: p, tranches: t}
+}
+
+func (s *exitConfigStrategy) Analyze(ctx context.Context, stock model.Stock) (*Signal, error) {
+	sig, err := s.Strategy.Analyze(ctx, stock)
+	if err == nil {
+		ApplyConfiguredExits(ctx, s.provider, sig, s.tranches)
+	}
+	return sig, err
+}
+
+// ResetRegimeCache 감싼 전략의 레짐 캐시 초기화 (백테스트 일자 전환)
+func (s *exitConfigStrategy) ResetRegimeCache() {
+	if rr, ok := s.Strategy.(interface{ ResetRegimeCache() }); ok {
+		rr.ResetRegimeCache()
+	}
+}
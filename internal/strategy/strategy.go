@@ -28,6 +28,10 @@ type TradeGuide struct {
 	Target1Pct    float64 `json:"target_1_pct"`
 	Target2       float64 `json:"target_2"`
 	Target2Pct    float64 `json:"target_2_pct"`
+	Target3       float64 `json:"target_3,omitempty"`     // 3분할 익절 러너 목표 (0 = 트레일링만)
+	Target3Pct    float64 `json:"target_3_pct,omitempty"`
+	T1Fraction    float64 `json:"t1_fraction,omitempty"` // T1에서 파는 원래 수량 비율 (0 = 절반)
+	T2Fraction    float64 `json:"t2_fraction,omitempty"` // T2에서 파는 원래 수량 비율 (0 = T2 전량 청산)
 
 	// Position sizing
 	RiskRewardRatio float64 `json:"risk_reward_ratio"`
@@ -44,16 +48,21 @@ type TradeGuide struct {
 	UseTrailingStop    bool    `json:"use_trailing_stop"`
 	TrailingMultiplier float64 `json:"trailing_multiplier"` // ATR multiplier (e.g., 3.0, 2.5)
 	EntryATR           float64 `json:"entry_atr"`           // ATR14 at entry
+
+	// 페어 트레이드 숏 레그 (2-leg 시그널만, 위 가격들은 롱 레그 기준)
+	Pair *PairGuide `json:"pair,omitempty"`
 }
 
 // Signal represents a trading signal from a strategy
 type Signal struct {
+	ID          string                   `json:"id,omitempty"` // SignalID (종목+전략+거래일), 순위 매길 때 채움
 	Stock       model.Stock              `json:"stock"`
 	Type        SignalType               `json:"type"`
 	Strategy    string                   `json:"strategy"`
 	Strength    float64                  `json:"strength"`     // 0-100
 	Probability float64                  `json:"probability"`  // Success probability 0-100
 	Reason      string                   `json:"reason"`       // Human readable reason
+	Rationale   *Rationale               `json:"rationale,omitempty"` // 조건 충족/미충족 + 가격대 (Explain으로 여러 줄 설명)
 	Details     map[string]float64       `json:"details"`      // Strategy-specific metrics
 	Technical   *model.TechnicalAnalysis `json:"technical,omitempty"`
 	Guide       *TradeGuide              `json:"guide,omitempty"` // Trading guide
@@ -85,4 +94,7 @@ type ScanResult struct {
 	TotalInvest   float64       `json:"total_invest,omitempty"`
 	TotalRisk     float64       `json:"total_risk,omitempty"`
 	GeneratedAt   string        `json:"generated_at,omitempty"`
+	MinProb       float64       `json:"min_prob,omitempty"`     // --min-prob로 걸러낸 기준
+	MinStrength   float64       `json:"min_strength,omitempty"` // --min-strength로 걸러낸 기준
+	Issues        *ScanIssues   `json:"issues,omitempty"`       // 종목별 실패/제외 사유 (데이터 문제 vs 시그널 없음)
 }
@@ -0,0 +1,146 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"traveler/pkg/model"
+)
+
+// TrancheExit 3분할 익절 (예: 1R에서 1/3, 2R에서 1/3, 나머지는 트레일링 러너)
+//
+// 목표가는 진입가와 손절가의 거리(R)로 잡는다. T3R이 0이면 러너는 고정 목표 없이
+// 트레일링 스탑(ATR × TrailATR)으로만 청산한다.
+type TrancheExit struct {
+	Enabled    bool
+	T1R        float64 // 1차 목표 (R 배수)
+	T2R        float64 // 2차 목표 (R 배수)
+	T3R        float64 // 러너 목표 (R 배수, 0 = 트레일링만)
+	T1Fraction float64 // T1에서 파는 원래 수량 비율
+	T2Fraction float64 // T2에서 파는 원래 수량 비율
+	TrailATR   float64 // 러너 트레일링 ATR 배수
+}
+
+// DefaultTrancheExit 1/3 @ 1R, 1/3 @ 2R, 러너 ATR×2.5 트레일링
+var DefaultTrancheExit = TrancheExit{
+	T1R:        1,
+	T2R:        2,
+	T1Fraction: 1.0 / 3,
+	T2Fraction: 1.0 / 3,
+	TrailATR:   2.5,
+}
+
+// withDefaults 0인 값은 기본값으로
+func (t TrancheExit) withDefaults() TrancheExit {
+	d := DefaultTrancheExit
+	if t.T1R <= 0 {
+		t.T1R = d.T1R
+	}
+	if t.T2R <= 0 {
+		t.T2R = d.T2R
+	}
+	if t.T1Fraction <= 0 {
+		t.T1Fraction = d.T1Fraction
+	}
+	if t.T2Fraction <= 0 {
+		t.T2Fraction = d.T2Fraction
+	}
+	if t.TrailATR <= 0 {
+		t.TrailATR = d.TrailATR
+	}
+	return t
+}
+
+// Validate 목표 순서와 비율 검사
+func (t TrancheExit) Validate() error {
+	if t.T2R <= t.T1R {
+		return fmt.Errorf("t2_r (%g) must be above t1_r (%g)", t.T2R, t.T1R)
+	}
+	if t.T3R > 0 && t.T3R <= t.T2R {
+		return fmt.Errorf("t3_r (%g) must be above t2_r (%g) or 0", t.T3R, t.T2R)
+	}
+	if t.T1Fraction+t.T2Fraction >= 1 {
+		return fmt.Errorf("t1_fraction + t2_fraction (%g) must leave a runner (< 1)", t.T1Fraction+t.T2Fraction)
+	}
+	return nil
+}
+
+// RunnerFraction T2 이후 남는 러너 비율
+func (t TrancheExit) RunnerFraction() float64 {
+	return 1 - t.T1Fraction - t.T2Fraction
+}
+
+// Targets 진입가/손절가 → T1, T2, T3 (T3R이 0이면 T3 = 0). 리스크가 없으면 모두 0
+func (t TrancheExit) Targets(entry, stop float64) (t1, t2, t3 float64) {
+	risk := entry - stop
+	if entry <= 0 || risk <= 0 {
+		return 0, 0, 0
+	}
+	t1 = entry + risk*t.T1R
+	t2 = entry + risk*t.T2R
+	if t.T3R > 0 {
+		t3 = entry + risk*t.T3R
+	}
+	return t1, t2, t3
+}
+
+// BlendedR 분할 청산 전부 목표가에 체결됐을 때의 평균 R (러너는 T3, 없으면 T2 기준)
+// RR 필터가 1R 첫 목표만 보고 시그널을 버리지 않도록 가이드 RiskRewardRatio로 쓴다.
+func (t TrancheExit) BlendedR() float64 {
+	runnerR := t.T3R
+	if runnerR <= 0 {
+		runnerR = t.T2R
+	}
+	return t.T1Fraction*t.T1R + t.T2Fraction*t.T2R + t.RunnerFraction()*runnerR
+}
+
+// Apply 가이드 목표가를 3분할 익절로 교체하고 러너 트레일링을 켠다. 바뀌었으면 true
+// candles는 EntryATR이 비어 있을 때 ATR14 계산에 쓴다.
+func (t TrancheExit) Apply(g *TradeGuide, candles []model.Candle) bool {
+	if g == nil || !t.Enabled {
+		return false
+	}
+	t1, t2, t3 := t.Targets(g.EntryPrice, g.StopLoss)
+	if t1 <= 0 {
+		return false
+	}
+	if g.EntryATR <= 0 && len(candles) >= 15 {
+		g.EntryATR = CalculateATR(candles, 14)
+	}
+	g.Target1, g.Target2, g.Target3 = t1, t2, t3
+	g.Target1Pct = (t1 - g.EntryPrice) / g.EntryPrice * 100
+	g.Target2Pct = (t2 - g.EntryPrice) / g.EntryPrice * 100
+	g.Target3Pct = 0
+	if t3 > 0 {
+		g.Target3Pct = (t3 - g.EntryPrice) / g.EntryPrice * 100
+	}
+	g.T1Fraction = t.T1Fraction
+	g.T2Fraction = t.T2Fraction
+	if g.EntryATR > 0 {
+		g.UseTrailingStop = true
+		g.TrailingMultiplier = t.TrailATR
+	}
+	g.RiskRewardRatio = t.BlendedR()
+	return true
+}
+
+// Normalized 0인 값은 기본값으로 채우고 목표 순서/비율 검사 (Enabled=false면 그대로)
+func (t TrancheExit) Normalized() (TrancheExit, error) {
+	if !t.Enabled {
+		return t, nil
+	}
+	t = t.withDefaults()
+	if err := t.Validate(); err != nil {
+		return TrancheExit{}, err
+	}
+	return t, nil
+}
+
+// For 전략에 적용할 3분할 익절 (꺼져 있거나 ETF 로테이션/페어면 false)
+// ETF는 시그널 역전으로, 페어는 스프레드 회귀로 청산하므로 목표가 분할이 의미 없다.
+func (t TrancheExit) For(strategyName string) (TrancheExit, bool) {
+	if strings.Contains(strategyName, "etf-momentum") || strategyName == "sector-rotation" || strategyName == "pairs" {
+		return TrancheExit{}, false
+	}
+	return t, t.Enabled
+}
@@ -0,0 +1,134 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"traveler/internal/symbols"
+)
+
+var (
+	// strategyUniverses 전략 → 분석할 종목 집합 (바인딩 없는 전략은 스캔 대상 전체)
+	strategyUniverses   map[string]map[string]bool
+	strategyUniverseCfg map[string][]string
+	// enabledStrategies 멀티 전략 스캔에서 돌릴 전략 (nil = 전부)
+	enabledStrategies   map[string]bool
+	strategyUniversesMu sync.RWMutex
+)
+
+// knownStrategyNames 레지스트리 전략 + 메타전략 전용 이름
+func knownStrategyNames() map[string]bool {
+	known := make(map[string]bool)
+	for _, name := range List() {
+		known[name] = true
+	}
+	known["etf-momentum"] = true // 메타전략 전용 (gem, tqqq_sma, kr_timing 모두)
+	return known
+}
+
+// SetStrategyUniverses 멀티 전략 스캔에서 전략별로 분석할 유니버스 (config scanner.strategy_universes)
+// 예: breakout: [russell, midcap], mean-reversion: [sp500, nasdaq100]. 스캔 시작 전에 호출한다.
+func SetStrategyUniverses(bindings map[string][]string) error {
+	known := knownStrategyNames()
+
+	sets := make(map[string]map[string]bool, len(bindings))
+	cfg := make(map[string][]string, len(bindings))
+	for name, universes := range bindings {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return fmt.Errorf("unknown strategy %q", name)
+		}
+		if len(universes) == 0 {
+			continue
+		}
+		set := make(map[string]bool)
+		for _, u := range universes {
+			syms := symbols.GetUniverse(symbols.Universe(strings.TrimSpace(u)))
+			if syms == nil {
+				return fmt.Errorf("strategy %s: unknown universe %q", name, u)
+			}
+			for _, sym := range syms {
+				set[strings.ToUpper(sym)] = true
+			}
+		}
+		sets[name] = set
+		cfg[name] = universes
+	}
+
+	strategyUniversesMu.Lock()
+	strategyUniverses = sets
+	strategyUniverseCfg = cfg
+	strategyUniversesMu.Unlock()
+	return nil
+}
+
+// StrategyUniverses 설정된 전략별 유니버스 (표시용, 이름순 "전략=유니버스,...")
+func StrategyUniverses() []string {
+	strategyUniversesMu.RLock()
+	defer strategyUniversesMu.RUnlock()
+	out := make([]string, 0, len(strategyUniverseCfg))
+	for name, universes := range strategyUniverseCfg {
+		out = append(out, name+"="+strings.Join(universes, ","))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SetEnabledStrategies 멀티 전략 스캔에서 돌릴 전략 (config scanner.enabled_strategies, 비우면 전부)
+// `traveler tune`이 백테스트 결과로 이 목록을 추천한다. --strategy로 직접 고른 전략은 영향 없음.
+func SetEnabledStrategies(names []string) error {
+	known := knownStrategyNames()
+
+	var enabled map[string]bool
+	if len(names) > 0 {
+		enabled = make(map[string]bool, len(names))
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !known[name] {
+				return fmt.Errorf("unknown strategy %q", name)
+			}
+			enabled[name] = true
+		}
+		enabled["etf-momentum"] = true // 메타전략의 ETF 타이밍은 tune 대상이 아니라 항상 활성
+	}
+
+	strategyUniversesMu.Lock()
+	enabledStrategies = enabled
+	strategyUniversesMu.Unlock()
+	return nil
+}
+
+// EnabledStrategies 설정된 활성 전략 (이름순, 비어 있으면 전부 활성)
+func EnabledStrategies() []string {
+	strategyUniversesMu.RLock()
+	defer strategyUniversesMu.RUnlock()
+	out := make([]string, 0, len(enabledStrategies))
+	for name := range enabledStrategies {
+		if name != "etf-momentum" {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// CoversSymbol 멀티 전략 스캔에서 이 전략이 종목을 분석해야 하는지
+// 비활성 전략이면 false, 유니버스 바인딩이 없으면 true.
+// "volatility-breakout(bull)", "etf-momentum(gem)"처럼 괄호 접미사가 붙은 이름은 기본 이름으로 찾는다.
+func CoversSymbol(strategyName, symbol string) bool {
+	if i := strings.Index(strategyName, "("); i > 0 {
+		strategyName = strategyName[:i]
+	}
+	strategyUniversesMu.RLock()
+	defer strategyUniversesMu.RUnlock()
+	if enabledStrategies != nil && !enabledStrategies[strategyName] {
+		return false
+	}
+	set, ok := strategyUniverses[strategyName]
+	if !ok {
+		return true
+	}
+	return set[strings.ToUpper(symbol)]
+}
@@ -201,6 +201,7 @@ func (s *VolatilityBreakoutStrategy) Analyze(ctx context.Context, stock model.St
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
 		Reason:      reason,
 		Details:     details,
@@ -152,6 +152,7 @@ func (s *VolumeSpikeStrategy) Analyze(ctx context.Context, stock model.Stock) (*
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
 		Reason:      reason,
 		Details:     details,
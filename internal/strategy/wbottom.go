@@ -310,6 +310,7 @@ func (s *WBottomStrategy) Analyze(ctx context.Context, stock model.Stock) (*Sign
 		Type:        SignalBuy,
 		Strategy:    s.Name(),
 		Strength:    strength,
+		Technical:   scoreTechnical(candles, strength),
 		Probability: probability,
 		Reason:      reason,
 		Details:     details,
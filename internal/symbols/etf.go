@@ -1,11 +1,30 @@
 package symbols
 
+import "traveler/internal/broker"
+
 const (
 	// ETF 유니버스
 	UniverseUSETF Universe = "us-etf" // US ETF (GEM + TQQQ/SMA)
 	UniverseKRETF Universe = "kr-etf" // KR ETF (KODEX 타이밍)
+
+	UniverseUSSectors Universe = "us-sectors" // US 섹터 ETF (섹터 로테이션)
 )
 
+// USSectorETFSymbols SPDR 섹터 ETF 11종 (섹터 로테이션)
+var USSectorETFSymbols = []string{
+	"XLK",  // Technology
+	"XLF",  // Financials
+	"XLV",  // Health Care
+	"XLE",  // Energy
+	"XLI",  // Industrials
+	"XLY",  // Consumer Discretionary
+	"XLP",  // Consumer Staples
+	"XLU",  // Utilities
+	"XLB",  // Materials
+	"XLRE", // Real Estate
+	"XLC",  // Communication Services
+}
+
 // USETFSymbols US ETF 유니버스 (GEM + TQQQ/SMA)
 var USETFSymbols = []string{
 	"SPY",  // S&P 500 ETF
@@ -15,12 +34,8 @@ var USETFSymbols = []string{
 	"TQQQ", // ProShares UltraPro QQQ (3x leverage)
 }
 
-// KRETFSymbols 한국 ETF 유니버스
-var KRETFSymbols = []string{
-	"069500", // KODEX 200 (KOSPI 200 추종)
-	"122630", // KODEX 레버리지 (KOSPI 200 2x)
-	"114800", // KODEX 인버스 (KOSPI 200 -1x)
-}
+// KRETFSymbols 한국 ETF 유니버스 (ETF 호가단위 판단과 같은 목록)
+var KRETFSymbols = broker.KRETFCodes
 
 // KRETFNames 한국 ETF 종목명
 var KRETFNames = map[string]string{
@@ -36,6 +51,18 @@ var USETFNames = map[string]string{
 	"SHY":  "iShares 1-3Y Treasury Bond ETF",
 	"QQQ":  "Invesco QQQ Trust",
 	"TQQQ": "ProShares UltraPro QQQ",
+
+	"XLK":  "Technology Select Sector SPDR",
+	"XLF":  "Financial Select Sector SPDR",
+	"XLV":  "Health Care Select Sector SPDR",
+	"XLE":  "Energy Select Sector SPDR",
+	"XLI":  "Industrial Select Sector SPDR",
+	"XLY":  "Consumer Discretionary Select Sector SPDR",
+	"XLP":  "Consumer Staples Select Sector SPDR",
+	"XLU":  "Utilities Select Sector SPDR",
+	"XLB":  "Materials Select Sector SPDR",
+	"XLRE": "Real Estate Select Sector SPDR",
+	"XLC":  "Communication Services Select Sector SPDR",
 }
 
 func init() {
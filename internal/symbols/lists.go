@@ -0,0 +1,194 @@
+package symbols
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+)
+
+// ListEntry 블랙/화이트리스트 항목
+type ListEntry struct {
+	Symbol  string    `json:"symbol"`
+	Reason  string    `json:"reason,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// symbolListsFile symbol_lists.json 저장 형식
+type symbolListsFile struct {
+	Blacklist map[string]ListEntry `json:"blacklist"`
+	Whitelist map[string]ListEntry `json:"whitelist"`
+}
+
+// SymbolLists 영구 블랙리스트/화이트리스트 저장소
+// 블랙리스트: 절대 매매하지 않을 종목 (과거 급락, 공매도 불가, 개인 제외 등)
+// 화이트리스트: 비어있지 않으면 목록에 있는 종목만 허용
+type SymbolLists struct {
+	mu   sync.RWMutex
+	path string
+	data symbolListsFile
+}
+
+// NewSymbolLists 생성자 (dataDir/symbol_lists.json)
+func NewSymbolLists(dataDir string) (*SymbolLists, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	l := &SymbolLists{path: filepath.Join(dataDir, "symbol_lists.json")}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload 디스크에서 최신 목록 리로드
+func (l *SymbolLists) Reload() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.load()
+}
+
+// load 디스크에서 목록 로드 (파싱 실패 시 기존 목록 유지)
+func (l *SymbolLists) load() error {
+	data, err := l.read()
+	if err != nil {
+		return err
+	}
+	l.data = data
+	return nil
+}
+
+// read 디스크의 목록 파싱 (파일이 없으면 빈 목록)
+func (l *SymbolLists) read() (symbolListsFile, error) {
+	data := symbolListsFile{}
+	raw, err := os.ReadFile(l.path)
+	if err != nil && !os.IsNotExist(err) {
+		return data, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return data, err
+		}
+	}
+	if data.Blacklist == nil {
+		data.Blacklist = make(map[string]ListEntry)
+	}
+	if data.Whitelist == nil {
+		data.Whitelist = make(map[string]ListEntry)
+	}
+	return data, nil
+}
+
+// update 파일 잠금 안에서 디스크 최신본을 다시 읽어 fn 적용 후 저장
+// (웹과 CLI가 같은 파일을 고치므로 메모리 사본으로 덮어쓰지 않는다)
+func (l *SymbolLists) update(fn func(d *symbolListsFile)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return fsutil.Update(l.path, func() error {
+		data, err := l.read()
+		if err != nil {
+			return err
+		}
+		l.data = data
+		fn(&l.data)
+		raw, err := json.MarshalIndent(l.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		return fsutil.WriteFile(l.path, raw, 0644)
+	})
+}
+
+// NormalizeSymbol 심볼 정규화 (한국 6자리 코드는 그대로, 그 외 대문자)
+func NormalizeSymbol(sym string) string {
+	sym = strings.TrimSpace(sym)
+	if IsKoreanSymbol(sym) {
+		return sym
+	}
+	return strings.ToUpper(sym)
+}
+
+// Block 블랙리스트 추가
+func (l *SymbolLists) Block(sym, reason string) error {
+	return l.add(blacklistOf, sym, reason)
+}
+
+// Unblock 블랙리스트 제거
+func (l *SymbolLists) Unblock(sym string) error {
+	return l.remove(blacklistOf, sym)
+}
+
+// Allow 화이트리스트 추가
+func (l *SymbolLists) Allow(sym, reason string) error {
+	return l.add(whitelistOf, sym, reason)
+}
+
+// Disallow 화이트리스트 제거
+func (l *SymbolLists) Disallow(sym string) error {
+	return l.remove(whitelistOf, sym)
+}
+
+// blacklistOf/whitelistOf 대상 목록 선택 (update가 맵을 교체하므로 락 안에서 꺼낸다)
+func blacklistOf(d *symbolListsFile) map[string]ListEntry { return d.Blacklist }
+func whitelistOf(d *symbolListsFile) map[string]ListEntry { return d.Whitelist }
+
+func (l *SymbolLists) add(list func(*symbolListsFile) map[string]ListEntry, sym, reason string) error {
+	sym = NormalizeSymbol(sym)
+	return l.update(func(d *symbolListsFile) {
+		list(d)[sym] = ListEntry{Symbol: sym, Reason: reason, AddedAt: time.Now()}
+	})
+}
+
+func (l *SymbolLists) remove(list func(*symbolListsFile) map[string]ListEntry, sym string) error {
+	sym = NormalizeSymbol(sym)
+	return l.update(func(d *symbolListsFile) {
+		delete(list(d), sym)
+	})
+}
+
+// IsAllowed 매매 허용 여부와 거부 사유
+func (l *SymbolLists) IsAllowed(sym string) (bool, string) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	sym = NormalizeSymbol(sym)
+	if e, ok := l.data.Blacklist[sym]; ok {
+		if e.Reason != "" {
+			return false, "blacklisted: " + e.Reason
+		}
+		return false, "blacklisted"
+	}
+	if len(l.data.Whitelist) > 0 {
+		if _, ok := l.data.Whitelist[sym]; !ok {
+			return false, "not in whitelist"
+		}
+	}
+	return true, ""
+}
+
+// Blacklist 블랙리스트 (심볼순)
+func (l *SymbolLists) Blacklist() []ListEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return sortedEntries(l.data.Blacklist)
+}
+
+// Whitelist 화이트리스트 (심볼순)
+func (l *SymbolLists) Whitelist() []ListEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return sortedEntries(l.data.Whitelist)
+}
+
+func sortedEntries(m map[string]ListEntry) []ListEntry {
+	entries := make([]ListEntry, 0, len(m))
+	for _, e := range m {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Symbol < entries[j].Symbol })
+	return entries
+}
@@ -60,6 +60,10 @@ func (l *Loader) LoadSymbols(ctx context.Context, symbols []string) ([]model.Sto
 			Name:     sym,
 			Exchange: "US",
 		}
+		if IsCryptoSymbol(stocks[i].Symbol) {
+			stocks[i].Name = GetCryptoSymbolName(stocks[i].Symbol)
+			stocks[i].Exchange = "UPBIT"
+		}
 	}
 	return stocks, nil
 }
@@ -0,0 +1,130 @@
+package symbols
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Membership 시점별 지수 구성종목 (생존 편향 없는 백테스트용)
+//
+// CSV 형식 (헤더 선택, # 주석 허용):
+//
+//	symbol,start,end
+//	AAPL,1982-11-30,
+//	ENRN,1990-01-01,2001-11-29
+//
+// end가 비어 있으면 현재 구성종목. 같은 종목이 여러 구간으로 편입/편출될 수 있다.
+// 구간은 [start, end) — 편출일에는 더 이상 구성종목이 아니다.
+type Membership struct {
+	Name      string
+	intervals map[string][]memberInterval
+}
+
+type memberInterval struct {
+	start, end time.Time // end zero = 현재까지
+}
+
+// MembershipDir 시점별 유니버스 파일 디렉토리 (<data-dir>/universes)
+func MembershipDir(dataDir string) string {
+	return filepath.Join(dataDir, "universes")
+}
+
+// MembershipPath 이름(<data-dir>/universes/<name>.csv) 또는 파일 경로
+func MembershipPath(dataDir, nameOrPath string) string {
+	if strings.ContainsAny(nameOrPath, `/\`) || strings.HasSuffix(nameOrPath, ".csv") {
+		return nameOrPath
+	}
+	return filepath.Join(MembershipDir(dataDir), nameOrPath+".csv")
+}
+
+// LoadMembership CSV 파일에서 시점별 구성종목 로드
+func LoadMembership(path string) (*Membership, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	m := &Membership{
+		Name:      strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		intervals: make(map[string][]memberInterval),
+	}
+	for line := 1; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(rec) < 2 {
+			return nil, fmt.Errorf("%s:%d: want symbol,start[,end]", path, line)
+		}
+		symbol := strings.ToUpper(strings.TrimSpace(rec[0]))
+		if line == 1 && strings.EqualFold(symbol, "symbol") {
+			continue // header
+		}
+		start, err := time.Parse("2006-01-02", strings.TrimSpace(rec[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: bad start date %q", path, line, rec[1])
+		}
+		var end time.Time
+		if len(rec) > 2 && strings.TrimSpace(rec[2]) != "" {
+			end, err = time.Parse("2006-01-02", strings.TrimSpace(rec[2]))
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: bad end date %q", path, line, rec[2])
+			}
+			if !end.After(start) {
+				return nil, fmt.Errorf("%s:%d: %s end %s is not after start", path, line, symbol, rec[2])
+			}
+		}
+		m.intervals[symbol] = append(m.intervals[symbol], memberInterval{start: start, end: end})
+	}
+	if len(m.intervals) == 0 {
+		return nil, fmt.Errorf("%s: no members", path)
+	}
+	return m, nil
+}
+
+// IsMember date(일 단위)에 symbol이 구성종목이었는지
+func (m *Membership) IsMember(symbol string, date time.Time) bool {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	for _, iv := range m.intervals[strings.ToUpper(symbol)] {
+		if !day.Before(iv.start) && (iv.end.IsZero() || day.Before(iv.end)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Symbols 한 번이라도 구성종목이었던 전체 종목 (편출/상폐 종목 포함, 정렬)
+func (m *Membership) Symbols() []string {
+	syms := make([]string, 0, len(m.intervals))
+	for s := range m.intervals {
+		syms = append(syms, s)
+	}
+	sort.Strings(syms)
+	return syms
+}
+
+// MembersOn date 기준 구성종목 (정렬)
+func (m *Membership) MembersOn(date time.Time) []string {
+	var syms []string
+	for _, s := range m.Symbols() {
+		if m.IsMember(s, date) {
+			syms = append(syms, s)
+		}
+	}
+	return syms
+}
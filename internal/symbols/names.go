@@ -0,0 +1,267 @@
+package symbols
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/provider"
+)
+
+// NameRefreshInterval provider 심볼 목록 재조회 주기
+const NameRefreshInterval = 7 * 24 * time.Hour
+
+// SymbolInfo 심볼 검색 결과 항목
+type SymbolInfo struct {
+	Symbol   string  `json:"symbol"`
+	Name     string  `json:"name"`
+	Market   string  `json:"market"` // "us", "kr", "crypto"
+	Exchange string  `json:"exchange,omitempty"`
+	Score    float64 `json:"score,omitempty"`
+}
+
+// symbolNamesFile symbol_names.json 저장 형식 (provider에서 받은 목록)
+type symbolNamesFile struct {
+	UpdatedAt map[string]time.Time    `json:"updated_at"` // market → 마지막 갱신
+	Symbols   map[string][]SymbolInfo `json:"symbols"`    // market → 목록
+}
+
+// krRefreshedNames provider에서 갱신된 KR 종목명 (GetKRSymbolName 폴백)
+var (
+	krRefreshedMu    sync.RWMutex
+	krRefreshedNames = map[string]string{}
+)
+
+// NameIndex KR/US 심볼-종목명 검색 서비스
+// 하드코딩된 종목명/유니버스를 기본으로, provider 심볼 목록(주기적 갱신)을 덧붙인다.
+type NameIndex struct {
+	mu      sync.RWMutex
+	path    string
+	file    symbolNamesFile
+	entries map[string]SymbolInfo // market + ":" + symbol → info
+}
+
+// NewNameIndex 생성자 (dataDir이 비어있으면 디스크 캐시 없이 정적 목록만)
+func NewNameIndex(dataDir string) *NameIndex {
+	idx := &NameIndex{
+		file: symbolNamesFile{
+			UpdatedAt: make(map[string]time.Time),
+			Symbols:   make(map[string][]SymbolInfo),
+		},
+	}
+	if dataDir != "" {
+		idx.path = filepath.Join(dataDir, "symbol_names.json")
+		if raw, err := os.ReadFile(idx.path); err == nil {
+			var f symbolNamesFile
+			if json.Unmarshal(raw, &f) == nil && f.Symbols != nil {
+				if f.UpdatedAt == nil {
+					f.UpdatedAt = make(map[string]time.Time)
+				}
+				idx.file = f
+			}
+		}
+	}
+	idx.rebuild()
+	return idx
+}
+
+// rebuild 정적 목록 + 저장된 provider 목록으로 인덱스 재구성 (호출자가 lock 보유 또는 생성 중)
+func (idx *NameIndex) rebuild() {
+	entries := make(map[string]SymbolInfo)
+	add := func(info SymbolInfo) {
+		key := info.Market + ":" + info.Symbol
+		if cur, ok := entries[key]; ok && cur.Name != "" && cur.Name != cur.Symbol && info.Name == "" {
+			return
+		}
+		entries[key] = info
+	}
+
+	// provider 목록 (이름은 정적 목록이 있으면 아래에서 덮어씀)
+	var krNames = make(map[string]string)
+	for market, list := range idx.file.Symbols {
+		for _, info := range list {
+			info.Market = market
+			add(info)
+			if market == "kr" && info.Name != "" {
+				krNames[info.Symbol] = info.Name
+			}
+		}
+	}
+
+	for sym, name := range KRSymbolNames {
+		add(SymbolInfo{Symbol: sym, Name: name, Market: "kr", Exchange: "KRX"})
+	}
+	for _, sym := range append(append([]string{}, Kospi200Symbols...), Kosdaq30Symbols...) {
+		add(SymbolInfo{Symbol: sym, Name: GetKRSymbolName(sym), Market: "kr", Exchange: "KRX"})
+	}
+	for _, s := range (&Loader{}).getDefaultUSStocks() {
+		add(SymbolInfo{Symbol: s.Symbol, Name: s.Name, Market: "us", Exchange: s.Exchange})
+	}
+	for sym, name := range USETFNames {
+		add(SymbolInfo{Symbol: sym, Name: name, Market: "us"})
+	}
+	for _, list := range [][]string{SP500Symbols, Nasdaq100Symbols, MidCap100Symbols, Russell200Symbols} {
+		for _, sym := range list {
+			add(SymbolInfo{Symbol: sym, Market: "us"})
+		}
+	}
+	for sym, name := range CryptoSymbolNames {
+		add(SymbolInfo{Symbol: sym, Name: name, Market: "crypto", Exchange: "UPBIT"})
+	}
+
+	idx.entries = entries
+
+	krRefreshedMu.Lock()
+	krRefreshedNames = krNames
+	krRefreshedMu.Unlock()
+}
+
+// lookupRefreshedKRName provider 갱신 목록에서 KR 종목명 조회
+func lookupRefreshedKRName(sym string) (string, bool) {
+	krRefreshedMu.RLock()
+	defer krRefreshedMu.RUnlock()
+	name, ok := krRefreshedNames[sym]
+	return name, ok
+}
+
+// NeedsRefresh 해당 마켓 목록이 오래되었는지
+func (idx *NameIndex) NeedsRefresh(market string) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return time.Since(idx.file.UpdatedAt[market]) > NameRefreshInterval
+}
+
+// Refresh provider 심볼 목록으로 마켓 목록 갱신 (market: "us" → exchange "US", "kr" → "KR")
+// provider가 목록 조회를 지원하지 않으면 에러를 반환하고 기존(정적) 목록을 유지한다.
+func (idx *NameIndex) Refresh(ctx context.Context, market string, p provider.Provider) error {
+	if p == nil {
+		return fmt.Errorf("no provider for %s symbols", market)
+	}
+	stocks, err := p.GetSymbols(ctx, strings.ToUpper(market))
+	if err != nil {
+		return fmt.Errorf("fetching %s symbols: %w", market, err)
+	}
+	if len(stocks) == 0 {
+		return fmt.Errorf("fetching %s symbols: empty list", market)
+	}
+
+	list := make([]SymbolInfo, 0, len(stocks))
+	for _, s := range stocks {
+		sym := NormalizeSymbol(s.Symbol)
+		if sym == "" {
+			continue
+		}
+		list = append(list, SymbolInfo{Symbol: sym, Name: s.Name, Market: market, Exchange: s.Exchange})
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.file.Symbols[market] = list
+	idx.file.UpdatedAt[market] = time.Now()
+	idx.rebuild()
+	if idx.path == "" {
+		return nil
+	}
+	raw, err := json.Marshal(idx.file)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(idx.path, raw, 0644)
+}
+
+// Len 인덱스 항목 수
+func (idx *NameIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.entries)
+}
+
+// Search 심볼/종목명 퍼지 검색 (market이 비어있으면 전체, limit <= 0이면 20)
+// 우선순위: 심볼 일치 > 심볼 접두 > 이름 접두 > 이름 포함 > 글자 순서 일치(subsequence)
+func (idx *NameIndex) Search(query, market string, limit int) []SymbolInfo {
+	q := normalizeQuery(query)
+	if q == "" {
+		return nil
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	idx.mu.RLock()
+	var results []SymbolInfo
+	for _, info := range idx.entries {
+		if market != "" && info.Market != market {
+			continue
+		}
+		if score := matchScore(q, info); score > 0 {
+			info.Score = score
+			results = append(results, info)
+		}
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if len(results[i].Symbol) != len(results[j].Symbol) {
+			return len(results[i].Symbol) < len(results[j].Symbol)
+		}
+		return results[i].Symbol < results[j].Symbol
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// normalizeQuery 대소문자/공백 무시
+func normalizeQuery(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
+
+func matchScore(q string, info SymbolInfo) float64 {
+	sym := strings.ToLower(info.Symbol)
+	name := normalizeQuery(info.Name)
+
+	switch {
+	case sym == q:
+		return 100
+	case name == q:
+		return 90
+	case strings.HasPrefix(sym, q):
+		return 80 - float64(len(sym)-len(q))
+	case strings.HasPrefix(name, q):
+		return 60
+	case strings.Contains(name, q):
+		return 40
+	case strings.Contains(sym, q):
+		return 30
+	case isSubsequence(q, name):
+		return 10
+	}
+	return 0
+}
+
+// isSubsequence q의 모든 글자가 순서대로 s에 등장하는지 ("삼전" → "삼성전자")
+// 첫 글자는 일치해야 한다 (약어 검색용, 무관한 긴 이름 매칭 방지).
+func isSubsequence(q, s string) bool {
+	qr := []rune(q)
+	sr := []rune(s)
+	if len(qr) < 2 || len(sr) == 0 || sr[0] != qr[0] {
+		return false
+	}
+	i := 0
+	for _, r := range s {
+		if i < len(qr) && r == qr[i] {
+			i++
+		}
+	}
+	return i == len(qr)
+}
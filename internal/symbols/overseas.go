@@ -0,0 +1,95 @@
+package symbols
+
+import "strings"
+
+// 미국 외 해외 유니버스 (Yahoo 심볼 접미사: .T 도쿄, .HK 홍콩, .SS 상해, .SZ 심천)
+const (
+	UniverseJPTop30 Universe = "jp-top30" // 도쿄 시총 상위 30
+	UniverseHKTop30 Universe = "hk-top30" // 홍콩 시총 상위 30
+)
+
+// overseasSuffixes 지원 거래소 접미사
+var overseasSuffixes = []string{".T", ".HK", ".SS", ".SZ"}
+
+// JPTop30Symbols 도쿄증권거래소 시총 상위 30
+var JPTop30Symbols = []string{
+	"7203.T", // Toyota Motor
+	"6758.T", // Sony Group
+	"8306.T", // Mitsubishi UFJ
+	"6861.T", // Keyence
+	"9983.T", // Fast Retailing
+	"6501.T", // Hitachi
+	"9432.T", // NTT
+	"8035.T", // Tokyo Electron
+	"9984.T", // SoftBank Group
+	"4063.T", // Shin-Etsu Chemical
+	"8316.T", // Sumitomo Mitsui FG
+	"6098.T", // Recruit Holdings
+	"4502.T", // Takeda Pharmaceutical
+	"7974.T", // Nintendo
+	"8058.T", // Mitsubishi Corp
+	"8001.T", // Itochu
+	"9433.T", // KDDI
+	"6367.T", // Daikin Industries
+	"4568.T", // Daiichi Sankyo
+	"7267.T", // Honda Motor
+	"8766.T", // Tokio Marine
+	"6902.T", // Denso
+	"6981.T", // Murata Manufacturing
+	"8031.T", // Mitsui & Co
+	"6594.T", // Nidec
+	"6273.T", // SMC
+	"7741.T", // HOYA
+	"4519.T", // Chugai Pharmaceutical
+	"6954.T", // Fanuc
+	"8411.T", // Mizuho FG
+}
+
+// HKTop30Symbols 홍콩거래소 시총 상위 30 (Yahoo 4자리 코드)
+var HKTop30Symbols = []string{
+	"0700.HK", // Tencent
+	"9988.HK", // Alibaba
+	"0005.HK", // HSBC
+	"1299.HK", // AIA
+	"0941.HK", // China Mobile
+	"3690.HK", // Meituan
+	"1810.HK", // Xiaomi
+	"0939.HK", // China Construction Bank
+	"1398.HK", // ICBC
+	"3988.HK", // Bank of China
+	"2318.HK", // Ping An Insurance
+	"0388.HK", // HKEX
+	"9618.HK", // JD.com
+	"1211.HK", // BYD
+	"0883.HK", // CNOOC
+	"0857.HK", // PetroChina
+	"2388.HK", // BOC Hong Kong
+	"0016.HK", // Sun Hung Kai Properties
+	"0001.HK", // CK Hutchison
+	"0011.HK", // Hang Seng Bank
+	"0002.HK", // CLP Holdings
+	"0003.HK", // HK & China Gas
+	"0027.HK", // Galaxy Entertainment
+	"1024.HK", // Kuaishou
+	"9999.HK", // NetEase
+	"2020.HK", // Anta Sports
+	"0175.HK", // Geely Auto
+	"0386.HK", // Sinopec
+	"2628.HK", // China Life
+	"0066.HK", // MTR
+}
+
+// IsOverseasSymbol 미국 외 해외 거래소 심볼 여부 (7203.T, 0700.HK 등)
+func IsOverseasSymbol(sym string) bool {
+	for _, s := range overseasSuffixes {
+		if strings.HasSuffix(strings.ToUpper(sym), s) && len(sym) > len(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUSSymbol 미국 티커 형식 여부 (한국 6자리 코드, 코인 마켓, 해외 접미사 심볼 제외)
+func IsUSSymbol(sym string) bool {
+	return !IsKoreanSymbol(sym) && !IsCryptoSymbol(sym) && !IsOverseasSymbol(sym)
+}
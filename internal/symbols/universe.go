@@ -45,9 +45,13 @@ func AvailableUniverses() []UniverseInfo {
 		// ETF
 		{UniverseUSETF, "US ETF", "US ETF (GEM + TQQQ/SMA)", len(USETFSymbols)},
 		{UniverseKRETF, "KR ETF", "한국 ETF (KODEX 타이밍)", len(KRETFSymbols)},
+		{UniverseUSSectors, "US Sectors", "SPDR sector ETFs (sector rotation)", len(USSectorETFSymbols)},
 		// Crypto
 		{UniverseCryptoTop10, "Crypto Top 10", "Upbit KRW 거래량 상위 10 코인", len(CryptoTop10Symbols)},
 		{UniverseCryptoTop30, "Crypto Top 30", "Upbit KRW 거래량 상위 30 코인", len(CryptoTop30Symbols)},
+		// 해외 (KIS 해외주식)
+		{UniverseJPTop30, "Japan Top 30", "Tokyo Stock Exchange top 30 by market cap", len(JPTop30Symbols)},
+		{UniverseHKTop30, "Hong Kong Top 30", "HKEX top 30 by market cap", len(HKTop30Symbols)},
 	}
 }
 
@@ -78,10 +82,16 @@ func GetUniverse(u Universe) []string {
 		return USETFSymbols
 	case UniverseKRETF:
 		return KRETFSymbols
+	case UniverseUSSectors:
+		return USSectorETFSymbols
 	case UniverseCryptoTop10:
 		return CryptoTop10Symbols
 	case UniverseCryptoTop30:
 		return CryptoTop30Symbols
+	case UniverseJPTop30:
+		return JPTop30Symbols
+	case UniverseHKTop30:
+		return HKTop30Symbols
 	default:
 		return nil
 	}
@@ -114,6 +124,9 @@ func GetKRSymbolName(sym string) string {
 	if name, ok := KRSymbolNames[sym]; ok {
 		return name
 	}
+	if name, ok := lookupRefreshedKRName(sym); ok {
+		return name
+	}
 	return sym
 }
 
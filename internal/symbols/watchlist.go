@@ -0,0 +1,265 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+)
+
+// WatchlistPrefix --universe watchlist:<name> 형식의 접두어
+const WatchlistPrefix = "watchlist:"
+
+// Watchlist 사용자 관심종목 목록
+type Watchlist struct {
+	Name      string    `json:"name"`
+	Symbols   []string  `json:"symbols"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Watchlists 영구 관심종목 저장소 (dataDir/watchlists.json)
+type Watchlists struct {
+	mu   sync.RWMutex
+	path string
+	data map[string]*Watchlist
+}
+
+// NewWatchlists 생성자
+func NewWatchlists(dataDir string) (*Watchlists, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	w := &Watchlists{path: filepath.Join(dataDir, "watchlists.json")}
+	if err := w.load(); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Reload 디스크에서 최신 목록 리로드
+func (w *Watchlists) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.load()
+}
+
+func (w *Watchlists) load() error {
+	w.data = make(map[string]*Watchlist)
+	raw, err := os.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &w.data); err != nil {
+		return err
+	}
+	if w.data == nil {
+		w.data = make(map[string]*Watchlist)
+	}
+	return nil
+}
+
+// update 파일 잠금 안에서 디스크 최신본을 다시 읽어 fn 적용 후 저장 (fn 에러면 저장 안 함)
+func (w *Watchlists) update(fn func(data map[string]*Watchlist) error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return fsutil.Update(w.path, func() error {
+		data := make(map[string]*Watchlist)
+		raw, err := os.ReadFile(w.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(raw, &data); err != nil {
+				return err
+			}
+		}
+		if data == nil {
+			data = make(map[string]*Watchlist)
+		}
+		w.data = data
+		if err := fn(w.data); err != nil {
+			return err
+		}
+		raw, err = json.MarshalIndent(w.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		return fsutil.WriteFile(w.path, raw, 0644)
+	})
+}
+
+// normalizeWatchlistName 이름 정규화 (소문자, 공백 제거) — 영문/숫자/-/_ 만 허용
+func normalizeWatchlistName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("watchlist name required")
+	}
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_') {
+			return "", fmt.Errorf("invalid watchlist name %q (use letters, digits, - or _)", name)
+		}
+	}
+	return name, nil
+}
+
+// Create 빈 관심종목 목록 생성 (이미 있으면 에러)
+func (w *Watchlists) Create(name string) error {
+	name, err := normalizeWatchlistName(name)
+	if err != nil {
+		return err
+	}
+	return w.update(func(data map[string]*Watchlist) error {
+		if _, ok := data[name]; ok {
+			return fmt.Errorf("watchlist %q already exists", name)
+		}
+		now := time.Now()
+		data[name] = &Watchlist{Name: name, Symbols: []string{}, CreatedAt: now, UpdatedAt: now}
+		return nil
+	})
+}
+
+// Delete 관심종목 목록 삭제
+func (w *Watchlists) Delete(name string) error {
+	name, err := normalizeWatchlistName(name)
+	if err != nil {
+		return err
+	}
+	return w.update(func(data map[string]*Watchlist) error {
+		if _, ok := data[name]; !ok {
+			return fmt.Errorf("watchlist %q not found", name)
+		}
+		delete(data, name)
+		return nil
+	})
+}
+
+// Add 종목 추가 (목록이 없으면 생성, 중복은 무시)
+func (w *Watchlists) Add(name string, syms ...string) error {
+	name, err := normalizeWatchlistName(name)
+	if err != nil {
+		return err
+	}
+	return w.update(func(data map[string]*Watchlist) error {
+		now := time.Now()
+		wl, ok := data[name]
+		if !ok {
+			wl = &Watchlist{Name: name, CreatedAt: now}
+			data[name] = wl
+		}
+		for _, sym := range syms {
+			sym = NormalizeSymbol(sym)
+			if sym == "" || containsSymbol(wl.Symbols, sym) {
+				continue
+			}
+			wl.Symbols = append(wl.Symbols, sym)
+		}
+		wl.UpdatedAt = now
+		return nil
+	})
+}
+
+// Remove 종목 제거
+func (w *Watchlists) Remove(name string, syms ...string) error {
+	name, err := normalizeWatchlistName(name)
+	if err != nil {
+		return err
+	}
+	return w.update(func(data map[string]*Watchlist) error {
+		wl, ok := data[name]
+		if !ok {
+			return fmt.Errorf("watchlist %q not found", name)
+		}
+		kept := wl.Symbols[:0]
+		for _, s := range wl.Symbols {
+			remove := false
+			for _, sym := range syms {
+				if NormalizeSymbol(sym) == s {
+					remove = true
+					break
+				}
+			}
+			if !remove {
+				kept = append(kept, s)
+			}
+		}
+		wl.Symbols = kept
+		wl.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// Get 관심종목 목록 조회
+func (w *Watchlists) Get(name string) (Watchlist, bool) {
+	name, err := normalizeWatchlistName(name)
+	if err != nil {
+		return Watchlist{}, false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	wl, ok := w.data[name]
+	if !ok {
+		return Watchlist{}, false
+	}
+	cp := *wl
+	cp.Symbols = append([]string{}, wl.Symbols...)
+	return cp, true
+}
+
+// List 모든 관심종목 목록 (이름순)
+func (w *Watchlists) List() []Watchlist {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	result := make([]Watchlist, 0, len(w.data))
+	for _, wl := range w.data {
+		cp := *wl
+		cp.Symbols = append([]string{}, wl.Symbols...)
+		result = append(result, cp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func containsSymbol(list []string, sym string) bool {
+	for _, s := range list {
+		if s == sym {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWatchlistUniverse "watchlist:<name>" 형식인지
+func IsWatchlistUniverse(u Universe) bool {
+	return strings.HasPrefix(string(u), WatchlistPrefix)
+}
+
+// ResolveUniverse 고정 유니버스 또는 watchlist:<name> 심볼 목록
+func ResolveUniverse(u Universe, dataDir string) ([]string, error) {
+	if !IsWatchlistUniverse(u) {
+		syms := GetUniverse(u)
+		if syms == nil {
+			return nil, fmt.Errorf("unknown universe: %s", u)
+		}
+		return syms, nil
+	}
+	name := strings.TrimPrefix(string(u), WatchlistPrefix)
+	lists, err := NewWatchlists(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading watchlists: %w", err)
+	}
+	wl, ok := lists.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("watchlist %q not found", name)
+	}
+	if len(wl.Symbols) == 0 {
+		return nil, fmt.Errorf("watchlist %q is empty", name)
+	}
+	return wl.Symbols, nil
+}
@@ -3,7 +3,6 @@ package trader
 import (
 	"context"
 	"log"
-	"sort"
 
 	"traveler/internal/strategy"
 	"traveler/internal/symbols"
@@ -144,6 +143,9 @@ type AdaptiveScanner struct {
 	scanFunc    ScanFunc
 	tierFunc    TierFunc   // nil이면 기본 GetUniverseTiers 사용
 	filterFunc  FilterFunc // nil이면 필터 없음 (품질 평가 전에 적용)
+	lists       *symbols.SymbolLists // nil이면 블랙/화이트리스트 미적용
+	filters     SignalFilterChain    // 설정된 시그널 필터 (nil이면 블랙/화이트리스트만)
+	sources     SignalFilterSources  // 시그널 필터의 조회 대상 (업종, 실적, 일봉)
 }
 
 // ScanFunc 스캔 함수 타입
@@ -168,6 +170,33 @@ func (s *AdaptiveScanner) SetFilterFunc(fn FilterFunc) {
 	s.filterFunc = fn
 }
 
+// SetSymbolLists 블랙/화이트리스트 설정 (모든 전략 시그널에 적용)
+func (s *AdaptiveScanner) SetSymbolLists(lists *symbols.SymbolLists) {
+	s.lists = lists
+}
+
+// SetSignalFilters 시그널 필터 체인(trader.signal_filters)과 필터가 쓸 조회 대상
+func (s *AdaptiveScanner) SetSignalFilters(chain SignalFilterChain, src SignalFilterSources) {
+	s.filters = chain
+	s.sources = src
+}
+
+// FilterBySymbolLists 블랙리스트/화이트리스트로 시그널 필터링
+func FilterBySymbolLists(signals []strategy.Signal, lists *symbols.SymbolLists) []strategy.Signal {
+	if lists == nil {
+		return signals
+	}
+	filtered := make([]strategy.Signal, 0, len(signals))
+	for _, sig := range signals {
+		if ok, reason := lists.IsAllowed(sig.Stock.Symbol); !ok {
+			log.Printf("[SYMBOLS] %s rejected (%s)", sig.Stock.Symbol, reason)
+			continue
+		}
+		filtered = append(filtered, sig)
+	}
+	return filtered
+}
+
 // ScanResult 스캔 결과
 type AdaptiveScanResult struct {
 	Signals       []strategy.Signal
@@ -261,6 +290,11 @@ func (s *AdaptiveScanner) Scan(ctx context.Context, loader StockLoader) (*Adapti
 				tier.Name, len(signals), len(signals)-filtered, maxPrice, filtered)
 		}
 
+		// 블랙/화이트리스트 + 설정된 시그널 필터 적용
+		src := s.sources
+		src.Lists = s.lists
+		allSignals = s.filters.Apply(ctx, allSignals, src)
+
 		// 펀더멘탈 등 필터 적용 (품질 평가 전)
 		if s.filterFunc != nil {
 			before := len(allSignals)
@@ -310,10 +344,8 @@ func (s *AdaptiveScanner) Scan(ctx context.Context, loader StockLoader) (*Adapti
 		}
 	}
 
-	// 승률순 정렬
-	sort.Slice(result.Signals, func(i, j int) bool {
-		return result.Signals[i].Probability > result.Signals[j].Probability
-	})
+	// 공통 순위 기준 정렬 (trader.top_n.rank_by — 개수 제한은 사이징 직전 SelectTopN)
+	RankSignals(result.Signals)
 
 	return result, nil
 }
@@ -354,7 +386,7 @@ func AdjustConfigForKRBalance(balance float64) SizerConfig {
 		MaxPositionPct: 0.20,
 		MaxPositions:   5,
 		MinRiskReward:  1.5,
-		CommissionRate: 0.005, // 국내 수수료 0.25% x 2 = 0.5%
+		CommissionRate: CostModelForMarket("kr").RoundTripRate(), // kis-kr: 수수료 0.015% x 2 + 거래세
 	}
 
 	switch {
@@ -400,6 +432,13 @@ func GetUSETFTiers(balance float64) []UniverseTier {
 	}
 }
 
+// GetUSSectorTiers returns sector ETF universe tiers for US sector rotation
+func GetUSSectorTiers(balance float64) []UniverseTier {
+	return []UniverseTier{
+		{Name: "us-sectors", Universe: symbols.UniverseUSSectors, Priority: 1},
+	}
+}
+
 // GetKRETFTiers returns ETF-only universe tiers for KR
 func GetKRETFTiers(balance float64) []UniverseTier {
 	return []UniverseTier{
@@ -0,0 +1,141 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"traveler/internal/broker"
+	"traveler/internal/money"
+)
+
+// ValueCaps 주문/종목 최대 금액 (사이징 계산과 무관한 최종 방어선)
+// 0이면 기본값, 음수면 해당 한도 비활성
+type ValueCaps struct {
+	MaxOrderUSD  float64 // US 주문 1건 최대 금액 ($)
+	MaxSymbolUSD float64 // US 종목당 최대 보유 금액 (기존 보유 + 주문)
+	MaxOrderKRW  float64 // KR/크립토 주문 1건 최대 금액 (₩)
+	MaxSymbolKRW float64 // KR/크립토 종목당 최대 보유 금액
+
+	// 도쿄/홍콩/중국 종목 (현지 통화)
+	MaxOrderJPY  float64
+	MaxSymbolJPY float64
+	MaxOrderHKD  float64
+	MaxSymbolHKD float64
+	MaxOrderCNY  float64
+	MaxSymbolCNY float64
+}
+
+// DefaultValueCaps 기본값: 주문 $25,000 / ₩30,000,000, 종목 $50,000 / ₩60,000,000
+// 엔/홍콩달러/위안은 달러 한도와 비슷한 금액
+func DefaultValueCaps() ValueCaps {
+	return ValueCaps{
+		MaxOrderUSD:  25_000,
+		MaxSymbolUSD: 50_000,
+		MaxOrderKRW:  30_000_000,
+		MaxSymbolKRW: 60_000_000,
+		MaxOrderJPY:  3_750_000,
+		MaxSymbolJPY: 7_500_000,
+		MaxOrderHKD:  195_000,
+		MaxSymbolHKD: 390_000,
+		MaxOrderCNY:  180_000,
+		MaxSymbolCNY: 360_000,
+	}
+}
+
+// withDefaults 0인 한도를 기본값으로 채움
+func (c ValueCaps) withDefaults() ValueCaps {
+	d := DefaultValueCaps()
+	if c.MaxOrderUSD == 0 {
+		c.MaxOrderUSD = d.MaxOrderUSD
+	}
+	if c.MaxSymbolUSD == 0 {
+		c.MaxSymbolUSD = d.MaxSymbolUSD
+	}
+	if c.MaxOrderKRW == 0 {
+		c.MaxOrderKRW = d.MaxOrderKRW
+	}
+	if c.MaxSymbolKRW == 0 {
+		c.MaxSymbolKRW = d.MaxSymbolKRW
+	}
+	if c.MaxOrderJPY == 0 {
+		c.MaxOrderJPY = d.MaxOrderJPY
+	}
+	if c.MaxSymbolJPY == 0 {
+		c.MaxSymbolJPY = d.MaxSymbolJPY
+	}
+	if c.MaxOrderHKD == 0 {
+		c.MaxOrderHKD = d.MaxOrderHKD
+	}
+	if c.MaxSymbolHKD == 0 {
+		c.MaxSymbolHKD = d.MaxSymbolHKD
+	}
+	if c.MaxOrderCNY == 0 {
+		c.MaxOrderCNY = d.MaxOrderCNY
+	}
+	if c.MaxSymbolCNY == 0 {
+		c.MaxSymbolCNY = d.MaxSymbolCNY
+	}
+	return c
+}
+
+// limits 종목 통화 기준 (주문 한도, 종목 한도)
+func (c ValueCaps) limits(cur money.Currency) (order, symbol float64) {
+	switch cur {
+	case money.KRW:
+		return c.MaxOrderKRW, c.MaxSymbolKRW
+	case money.JPY:
+		return c.MaxOrderJPY, c.MaxSymbolJPY
+	case money.HKD:
+		return c.MaxOrderHKD, c.MaxSymbolHKD
+	case money.CNY:
+		return c.MaxOrderCNY, c.MaxSymbolCNY
+	}
+	return c.MaxOrderUSD, c.MaxSymbolUSD
+}
+
+// orderValue 주문 금액 (시장가 금액 주문은 Amount, 그 외 수량 × 지정가)
+func orderValue(order broker.Order) float64 {
+	if order.Amount > 0 {
+		return order.Amount
+	}
+	return order.Quantity * order.LimitPrice
+}
+
+// checkValueCaps 매수 주문이 한도를 넘으면 에러. 보유 조회가 실패하면 주문 한도만 확인한다.
+func (e *Executor) checkValueCaps(ctx context.Context, order broker.Order) error {
+	if order.Side != broker.OrderSideBuy {
+		return nil
+	}
+	cur := money.ForSymbol(order.Symbol)
+	maxOrder, maxSymbol := e.config.Caps.limits(cur)
+	value := orderValue(order)
+
+	if maxOrder > 0 && value > maxOrder {
+		return fmt.Errorf("order value %s exceeds per-order cap %s",
+			money.Format(value, cur), money.Format(maxOrder, cur))
+	}
+	if maxSymbol <= 0 {
+		return nil
+	}
+	positions, err := e.broker.GetPositions(ctx)
+	if err != nil {
+		log.Printf("[EXECUTOR] %s: per-symbol cap not checked (positions unavailable: %v)", order.Symbol, err)
+		return nil
+	}
+	held := 0.0
+	for _, p := range positions {
+		if p.Symbol != order.Symbol {
+			continue
+		}
+		held = p.MarketValue
+		if held <= 0 {
+			held = p.Quantity * p.AvgCost
+		}
+	}
+	if held+value > maxSymbol {
+		return fmt.Errorf("position value %s + order %s exceeds per-symbol cap %s",
+			money.Format(held, cur), money.Format(value, cur), money.Format(maxSymbol, cur))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package trader
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"traveler/internal/provider"
+)
+
+// AnnotateDividend 플랜에 다음 배당락일을 기록하고, 보유기간 중 배당락이면 경고
+// 배당락일에는 주가가 배당금만큼 하락해 출발하므로 손절가에 가까운 포지션은 갭으로 손절될 수 있다.
+// 롱 포지션은 배당을 받으므로 청산하지 않고 경고만 한다.
+// (숏 미지원 — 숏 지원 시 배당락 전 숏 진입은 배당금을 물어야 하므로 회피 필요)
+func AnnotateDividend(ctx context.Context, cal *provider.DividendCalendar, plan *PositionPlan) bool {
+	if cal == nil || plan == nil {
+		return false
+	}
+	ev, ok := cal.NextExDate(ctx, plan.Symbol)
+	if !ok {
+		plan.ExDividendDate = ""
+		plan.DividendAmount = 0
+		return false
+	}
+	plan.ExDividendDate = ev.ExDate.Format("2006-01-02")
+	plan.DividendAmount = ev.Amount
+
+	if !plan.CrossesExDividend(time.Now()) {
+		return false
+	}
+	projected := ""
+	if ev.Projected {
+		projected = " (projected)"
+	}
+	gapNote := ""
+	if plan.EntryPrice > 0 && plan.StopLoss > 0 {
+		cushion := plan.EntryPrice - plan.StopLoss
+		if cushion > 0 && ev.Amount >= cushion*0.5 {
+			gapNote = ", dividend ≥ 50% of stop distance"
+		}
+	}
+	log.Printf("[DIVIDEND] %s: hold crosses ex-date %s%s, %.4f/share (~%.2f%% gap)%s",
+		plan.Symbol, plan.ExDividendDate, projected, ev.Amount, dividendYieldPct(ev.Amount, plan.EntryPrice), gapNote)
+	return true
+}
+
+func dividendYieldPct(amount, price float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	return amount / price * 100
+}
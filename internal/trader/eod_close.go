@@ -0,0 +1,74 @@
+package trader
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+)
+
+// EODPolicy 전략별 장 마감 N분 전 전량 청산 정책 (오버나이트 보유 안 함)
+// nil이면 DefaultEODPolicy. 만든 뒤에는 읽기만 하므로 여러 고루틴이 공유해도 된다.
+type EODPolicy map[string]int
+
+// DefaultEODPolicy 장중 전략 기본값 (strategy.IntradayConfig.ForceCloseMin 30분과 같다)
+func DefaultEODPolicy() EODPolicy {
+	return EODPolicy{
+		"intraday_orb": 30,
+		"intraday_dip": 30,
+	}
+}
+
+// WithOverrides config trader.eod_close를 덮어쓴 새 정책 (0 이하 = 정책 해제)
+func (p EODPolicy) WithOverrides(overrides map[string]int) EODPolicy {
+	if p == nil {
+		p = DefaultEODPolicy()
+	}
+	merged := make(EODPolicy, len(p)+len(overrides))
+	for strategy, min := range p {
+		merged[strategy] = min
+	}
+	for strategy, min := range overrides {
+		if min <= 0 {
+			delete(merged, strategy)
+			continue
+		}
+		merged[strategy] = min
+	}
+	return merged
+}
+
+// Minutes 전략의 마감 전 청산 시점 (분, 0 = 정책 없음)
+// "orb(bull)" 같은 레짐 접미사는 떼고 찾는다.
+func (p EODPolicy) Minutes(strategy string) int {
+	if p == nil {
+		p = DefaultEODPolicy()
+	}
+	if min, ok := p[strategy]; ok {
+		return min
+	}
+	if idx := strings.Index(strategy, "("); idx > 0 {
+		return p[strategy[:idx]]
+	}
+	return 0
+}
+
+// CloseBeforeEOD 마감까지 남은 시간이 전략 정책 이하인 포지션 청산 (크립토는 호출하지 않음)
+func (m *Monitor) CloseBeforeEOD(ctx context.Context, timeToClose time.Duration) int {
+	m.mu.RLock()
+	var due []string
+	for symbol, pos := range m.positions {
+		if min := m.config.EODClose.Minutes(pos.Strategy); min > 0 && timeToClose <= time.Duration(min)*time.Minute {
+			due = append(due, symbol)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, symbol := range due {
+		log.Printf("[MONITOR] %s: %s before close, flattening per end-of-day policy", symbol, timeToClose.Round(time.Minute))
+		if err := m.ClosePosition(ctx, symbol, "eod_close"); err != nil {
+			log.Printf("[MONITOR] %s: end-of-day close failed: %v", symbol, err)
+		}
+	}
+	return len(due)
+}
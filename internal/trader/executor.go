@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"time"
 
 	"traveler/internal/broker"
+	"traveler/internal/money"
 	"traveler/internal/strategy"
+	"traveler/internal/symbols"
 )
 
 // ExecutionResult 실행 결과
@@ -17,6 +20,7 @@ type ExecutionResult struct {
 	Result  *broker.OrderResult
 	Success bool
 	Error   string
+	TradeID string // 체결된 매수의 진입-청산 짝 ID (모니터/플랜과 공유)
 }
 
 // Executor Signal을 Order로 변환하고 실행
@@ -24,14 +28,23 @@ type Executor struct {
 	broker      broker.Broker
 	config      Config
 	marketOrder bool
+	throttle    *OrderThrottle
+	journal     *OrderJournal   // 중복 주문 방지 (nil이면 브로커 미체결 주문만 확인)
+	checks      []PreTradeCheck // 기본 검사 앞에 돌리는 주문 직전 검사 (장 운영, 블랙리스트 등)
 }
 
 // NewExecutor 생성자
 func NewExecutor(b broker.Broker, cfg Config, marketOrder bool) *Executor {
+	if cfg.Throttle == (ThrottleConfig{}) {
+		cfg.Throttle = DefaultThrottleConfig()
+	}
+	cfg.Caps = cfg.Caps.withDefaults()
+	cfg.PriceCheck = cfg.PriceCheck.withDefaults()
 	return &Executor{
 		broker:      b,
 		config:      cfg,
 		marketOrder: marketOrder,
+		throttle:    NewOrderThrottle(cfg.Throttle),
 	}
 }
 
@@ -47,36 +60,16 @@ func (e *Executor) Execute(ctx context.Context, signal strategy.Signal) Executio
 	}
 	result.Order = order
 
-	// Dry-run 모드
-	if e.config.DryRun {
-		result.Success = true
-		result.Result = &broker.OrderResult{
-			OrderID:  "DRY-RUN",
-			Symbol:   order.Symbol,
-			Side:     order.Side,
-			Type:     order.Type,
-			Quantity: order.Quantity,
-			Status:   "simulated",
-			Message:  "Dry-run mode - no actual order placed",
-		}
-		if e.marketOrder {
-			log.Printf("[DRY-RUN] %s %s MARKET ₩%.0f",
-				order.Side, order.Symbol, order.Amount)
-		} else {
-			log.Printf("[DRY-RUN] %s %s %.0f shares @ $%.2f",
-				order.Side, order.Symbol, order.Quantity, order.LimitPrice)
-		}
-		return result
-	}
-
-	// 실제 주문 실행
-	orderResult, err := e.broker.PlaceOrder(ctx, *order)
+	orderResult, err := e.submit(ctx, *order, &signal, signal.ID)
 	if err != nil {
-		result.Error = fmt.Sprintf("place order: %v", err)
+		result.Error = err.Error()
 		return result
 	}
-
 	result.Result = orderResult
+	if e.config.DryRun {
+		result.Success = true
+		return result
+	}
 	result.Success = orderResult.Status != "rejected"
 
 	// 매수 성공 시: 실제 체결가 조회
@@ -91,8 +84,9 @@ func (e *Executor) Execute(ctx context.Context, signal strategy.Signal) Executio
 					orderResult.AvgPrice = p.AvgCost
 					orderResult.FilledQty = p.Quantity
 					orderResult.Status = "filled"
-					log.Printf("[EXECUTOR] %s actual fill: $%.2f (order: $%.2f)",
-						order.Symbol, p.AvgCost, order.LimitPrice)
+					cur := money.ForSymbol(order.Symbol)
+					log.Printf("[EXECUTOR] %s actual fill: %s (order: %s)",
+						order.Symbol, money.Price(p.AvgCost, cur), money.Price(order.LimitPrice, cur))
 					break
 				}
 			}
@@ -102,6 +96,92 @@ func (e *Executor) Execute(ctx context.Context, signal strategy.Signal) Executio
 	return result
 }
 
+// ExecuteOrder 수동 주문 실행 (웹/RPC). 시그널 주문과 같은 주문 직전 검사와 주문 의도 저널을 거친다
+func (e *Executor) ExecuteOrder(ctx context.Context, order broker.Order) (*broker.OrderResult, error) {
+	return e.submit(ctx, order, nil, "")
+}
+
+// submit 주문 직전 검사 → dry-run이면 모의 결과 → 주문 의도 저널 → 주문 (거부/중복/주문 실패는 에러)
+func (e *Executor) submit(ctx context.Context, order broker.Order, signal *strategy.Signal, signalID string) (*broker.OrderResult, error) {
+	// 주문 직전 검사: 설정된 검사 → 금액 한도(dry-run 포함) → 시세 확인 → 브로커 미체결 중복
+	checks := append(append([]PreTradeCheck{}, e.checks...), e.builtinChecks()...)
+	req := PreTradeRequest{Order: order, Signal: signal, DryRun: e.config.DryRun}
+	if _, denied := runPreTradeChecks(ctx, checks, req); denied != nil {
+		log.Printf("[EXECUTOR] %s: refusing order (%s): %s", order.Symbol, denied.Check, denied.Reason)
+		if e.journal != nil && !e.config.DryRun {
+			if err := e.journal.RecordDenied(order, signalID, *denied, time.Now()); err != nil {
+				log.Printf("[EXECUTOR] %s: failed to record denied order: %v", order.Symbol, err)
+			}
+		}
+		return nil, fmt.Errorf("%s: %s", denied.Check, denied.Reason)
+	}
+
+	// Dry-run 모드
+	if e.config.DryRun {
+		if e.marketOrder {
+			log.Printf("[DRY-RUN] %s %s MARKET %s",
+				order.Side, order.Symbol, money.Format(order.Amount, money.ForSymbol(order.Symbol)))
+		} else {
+			log.Printf("[DRY-RUN] %s %s %.0f shares @ %s",
+				order.Side, order.Symbol, order.Quantity, money.Price(order.LimitPrice, money.ForSymbol(order.Symbol)))
+		}
+		return &broker.OrderResult{
+			OrderID:  "DRY-RUN",
+			Symbol:   order.Symbol,
+			Side:     order.Side,
+			Type:     order.Type,
+			Quantity: order.Quantity,
+			Status:   "simulated",
+			Message:  "Dry-run mode - no actual order placed",
+		}, nil
+	}
+
+	// 중복 주문 방지: 주문 의도 저널 (브로커 미체결 주문은 duplicate 검사에서 확인)
+	var intentKey string
+	if e.journal != nil {
+		key, dup, err := e.journal.Begin(order, signalID, time.Now())
+		switch {
+		case err != nil:
+			log.Printf("[EXECUTOR] %s: order journal unavailable, submitting without it: %v", order.Symbol, err)
+		case dup != nil:
+			log.Printf("[EXECUTOR] %s: skipping duplicate %s (intent %s at %s, %s)",
+				order.Symbol, order.Side, dup.Key, dup.Created.Format("15:04:05"), dup.Status)
+			return nil, fmt.Errorf("duplicate order skipped: %s intent from %s (%s)",
+				dup.Side, dup.Created.Format("15:04:05"), dup.Status)
+		default:
+			intentKey = key
+		}
+	}
+
+	// 실제 주문 실행
+	orderResult, err := e.placeOrder(ctx, order)
+	if intentKey != "" {
+		if jerr := e.journal.Complete(intentKey, orderResult, err); jerr != nil {
+			log.Printf("[EXECUTOR] %s: failed to record order intent: %v", order.Symbol, jerr)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("place order: %w", err)
+	}
+	return orderResult, nil
+}
+
+// duplicateReason 같은 종목·방향의 미체결 주문이 브로커에 있으면 사유 반환 (조회 실패 시 통과)
+func (e *Executor) duplicateReason(ctx context.Context, order broker.Order) string {
+	pending, err := e.broker.GetPendingOrders(ctx)
+	if err != nil {
+		log.Printf("[EXECUTOR] %s: pending order check failed: %v", order.Symbol, err)
+		return ""
+	}
+	for _, p := range pending {
+		if p.Symbol == order.Symbol && p.Side == order.Side {
+			return fmt.Sprintf("pending order %s %.0f @ %s", p.OrderID, p.Quantity-p.FilledQty,
+				money.Price(p.Price, money.ForSymbol(p.Symbol)))
+		}
+	}
+	return ""
+}
+
 // ExecuteSell 매도 주문 실행
 func (e *Executor) ExecuteSell(ctx context.Context, symbol string, quantity float64, reason string) (*broker.OrderResult, error) {
 	order := broker.Order{
@@ -124,7 +204,27 @@ func (e *Executor) ExecuteSell(ctx context.Context, symbol string, quantity floa
 		}, nil
 	}
 
-	return e.broker.PlaceOrder(ctx, order)
+	return e.placeOrder(ctx, order)
+}
+
+// placeOrder 속도 제한 대기 후 주문. 일시적 rate 에러는 지수 백오프로 재시도한다.
+func (e *Executor) placeOrder(ctx context.Context, order broker.Order) (*broker.OrderResult, error) {
+	backoff := e.config.Throttle.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if err := e.throttle.Wait(ctx); err != nil {
+			return nil, err
+		}
+		res, err := e.broker.PlaceOrder(ctx, order)
+		if err == nil || !IsOrderRateError(err) || attempt >= e.config.Throttle.MaxRetries {
+			return res, err
+		}
+		log.Printf("[EXECUTOR] %s %s rate limited, retry %d/%d in %s: %v",
+			order.Side, order.Symbol, attempt+1, e.config.Throttle.MaxRetries, backoff, err)
+		if err := sleepCtx(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
 }
 
 // signalToOrder Signal을 Order로 변환
@@ -132,28 +232,76 @@ func (e *Executor) signalToOrder(signal strategy.Signal) (*broker.Order, error)
 	if signal.Guide == nil {
 		return nil, fmt.Errorf("signal has no trade guide")
 	}
+	// 2-leg 페어는 롱만 체결되면 방향성 포지션이 되므로 자동 주문하지 않는다 (스캔/백테스트 전용)
+	if p := signal.Guide.Pair; p != nil {
+		return nil, fmt.Errorf("pair signal (long %s / short %s) needs two-leg execution, not supported by %s",
+			signal.Stock.Symbol, p.ShortSymbol, e.broker.Name())
+	}
 
 	guide := signal.Guide
+	caps := e.broker.Capabilities()
 
-	// 주문 유형 결정
+	// 주문 유형 결정: 요청이 시장가여도 브로커가 지원하지 않으면 지정가, 지정가를 못 내는 브로커는 시장가
 	orderType := broker.OrderTypeLimit
-	if e.marketOrder {
+	switch {
+	case !caps.LimitOrders:
+		orderType = broker.OrderTypeMarket
+	case e.marketOrder && caps.MarketOrders:
 		orderType = broker.OrderTypeMarket
+	case e.marketOrder:
+		log.Printf("[EXECUTOR] %s: %s has no native market orders, using limit @ %s",
+			signal.Stock.Symbol, e.broker.Name(), money.Price(guide.EntryPrice, money.ForSymbol(signal.Stock.Symbol)))
+	}
+
+	// 소수점 수량을 못 받는 브로커는 정수 주로 내림
+	qty := guide.PositionSize
+	if !caps.FractionalShares {
+		qty = math.Floor(qty)
+	}
+	if qty <= 0 {
+		return nil, fmt.Errorf("position size %g rounds to zero shares on %s", guide.PositionSize, e.broker.Name())
 	}
 
 	order := &broker.Order{
 		Symbol:     signal.Stock.Symbol,
 		Side:       broker.OrderSideBuy,
 		Type:       orderType,
-		Quantity:   guide.PositionSize,
+		Quantity:   qty,
 		LimitPrice: guide.EntryPrice,
 		StopPrice:  guide.StopLoss,
 	}
 
-	// 시장가 매수: KRW 투자금액 설정 (Upbit는 Amount 기반)
-	if e.marketOrder {
-		order.Amount = guide.PositionSize * guide.EntryPrice
+	// 시장가 매수: 금액 주문을 받는 브로커(Upbit KRW, Binance USDT)는 투자금액으로
+	if orderType == broker.OrderTypeMarket && caps.AmountOrders {
+		order.Amount = qty * guide.EntryPrice
 	}
 
+	alignOrderPrices(order)
+
 	return order, nil
 }
+
+// alignOrderPrices 지정가/손절가를 거래소 호가단위에 맞춤 (KRX 호가 밴드, US 페니/서브페니)
+// 매수 지정가는 내림(계획보다 비싸게 사지 않음), 매도 지정가와 손절가는 올림(리스크 예산 내 유지)
+func alignOrderPrices(order *broker.Order) {
+	switch {
+	case symbols.IsCryptoSymbol(order.Symbol):
+		return
+	case symbols.IsKoreanSymbol(order.Symbol):
+		etf := broker.IsKRETF(order.Symbol)
+		if order.LimitPrice > 0 {
+			order.LimitPrice = broker.RoundKRXPrice(order.LimitPrice, etf, order.Side == broker.OrderSideSell)
+		}
+		if order.StopPrice > 0 {
+			order.StopPrice = broker.RoundKRXPrice(order.StopPrice, etf, true)
+		}
+	default:
+		if order.LimitPrice > 0 {
+			order.LimitPrice = broker.RoundUSPrice(order.LimitPrice, order.Side == broker.OrderSideSell)
+		}
+		if order.StopPrice > 0 {
+			order.StopPrice = broker.RoundUSPrice(order.StopPrice, true)
+		}
+	}
+}
+
@@ -0,0 +1,191 @@
+package trader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExitSample 청산 1건 (백테스트/실거래 공통 입력)
+type ExitSample struct {
+	Reason   string
+	HoldDays int // 보유 거래일 (크립토는 달력일, 당일 청산 = 0)
+	PnL      float64
+	PnLPct   float64
+	R        float64
+	HasR     bool
+}
+
+// ExitStat 청산 사유 또는 보유 기간 그룹별 성과
+type ExitStat struct {
+	Key       string  `json:"key"`
+	Trades    int     `json:"trades"`
+	Wins      int     `json:"wins"`
+	WinRate   float64 `json:"win_rate"`
+	TotalPnL  float64 `json:"total_pnl"`
+	AvgPnL    float64 `json:"avg_pnl"`
+	AvgPnLPct float64 `json:"avg_pnl_pct"`
+	AvgR      float64 `json:"avg_r,omitempty"` // R을 아는 거래만 평균
+	rTrades   int
+}
+
+// ExitAnalytics 청산 사유별 / 보유 기간별 성과 (전략별 MaxHoldDays 조정 근거)
+type ExitAnalytics struct {
+	Trades     int        `json:"trades"`
+	ByReason   []ExitStat `json:"by_reason"`
+	ByHoldDays []ExitStat `json:"by_hold_days"`
+}
+
+// holdBuckets 보유 기간 구간 (상한 포함, 마지막은 그 이상)
+var holdBuckets = []struct {
+	max   int
+	label string
+}{
+	{0, "0d"}, {1, "1d"}, {2, "2d"}, {3, "3d"}, {5, "4-5d"}, {10, "6-10d"}, {20, "11-20d"},
+}
+
+func holdBucket(days int) (int, string) {
+	for i, b := range holdBuckets {
+		if days <= b.max {
+			return i, b.label
+		}
+	}
+	return len(holdBuckets), fmt.Sprintf(">%dd", holdBuckets[len(holdBuckets)-1].max)
+}
+
+// AnalyzeExits 청산 목록 → 사유별 (거래 수 내림차순) / 보유 기간별 (짧은 순) 집계. 비어 있으면 nil
+func AnalyzeExits(samples []ExitSample) *ExitAnalytics {
+	if len(samples) == 0 {
+		return nil
+	}
+	byReason := make(map[string]*ExitStat)
+	byHold := make(map[int]*ExitStat)
+	for _, s := range samples {
+		reason := s.Reason
+		if reason == "" {
+			reason = "unknown"
+		}
+		rs := byReason[reason]
+		if rs == nil {
+			rs = &ExitStat{Key: reason}
+			byReason[reason] = rs
+		}
+		rs.add(s)
+
+		idx, label := holdBucket(s.HoldDays)
+		hs := byHold[idx]
+		if hs == nil {
+			hs = &ExitStat{Key: label}
+			byHold[idx] = hs
+		}
+		hs.add(s)
+	}
+
+	a := &ExitAnalytics{Trades: len(samples)}
+	for _, st := range byReason {
+		a.ByReason = append(a.ByReason, st.finish())
+	}
+	sort.Slice(a.ByReason, func(i, j int) bool {
+		if a.ByReason[i].Trades != a.ByReason[j].Trades {
+			return a.ByReason[i].Trades > a.ByReason[j].Trades
+		}
+		return a.ByReason[i].Key < a.ByReason[j].Key
+	})
+	idxs := make([]int, 0, len(byHold))
+	for idx := range byHold {
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	for _, idx := range idxs {
+		a.ByHoldDays = append(a.ByHoldDays, byHold[idx].finish())
+	}
+	return a
+}
+
+func (st *ExitStat) add(s ExitSample) {
+	st.Trades++
+	if s.PnL > 0 {
+		st.Wins++
+	}
+	st.TotalPnL += s.PnL
+	st.AvgPnLPct += s.PnLPct
+	if s.HasR {
+		st.AvgR += s.R
+		st.rTrades++
+	}
+}
+
+func (st *ExitStat) finish() ExitStat {
+	out := *st
+	n := float64(out.Trades)
+	out.WinRate = float64(out.Wins) / n * 100
+	out.AvgPnL = out.TotalPnL / n
+	out.AvgPnLPct /= n
+	if out.rTrades > 0 {
+		out.AvgR /= float64(out.rTrades)
+	}
+	return out
+}
+
+// Format 텍스트 표 (fmtMoney: 통화 표시 함수)
+func (a *ExitAnalytics) Format(fmtMoney func(float64) string) string {
+	if a == nil {
+		return ""
+	}
+	var sb strings.Builder
+	table := func(title string, stats []ExitStat) {
+		fmt.Fprintf(&sb, " %-12s %6s %7s %9s %7s %14s\n", title, "Trades", "Win%", "Avg P&L%", "Avg R", "Total P&L")
+		for _, st := range stats {
+			avgR := "-"
+			if st.rTrades > 0 {
+				avgR = fmt.Sprintf("%+.2f", st.AvgR)
+			}
+			fmt.Fprintf(&sb, " %-12s %6d %6.1f%% %+8.2f%% %7s %14s\n",
+				st.Key, st.Trades, st.WinRate, st.AvgPnLPct, avgR, fmtMoney(st.TotalPnL))
+		}
+	}
+	table("Exit reason", a.ByReason)
+	sb.WriteString("\n")
+	table("Held", a.ByHoldDays)
+	return sb.String()
+}
+
+// ExitSamplesFromHistory 매도 기록 → 청산 샘플
+// 진입 시각이 없는 예전 기록은 같은 종목의 직전 매수 시각으로 보유 기간을 추정한다.
+func ExitSamplesFromHistory(records []TradeRecord, strategy string) []ExitSample {
+	lastBuy := make(map[string]time.Time)
+	var samples []ExitSample
+	for _, rec := range records {
+		key := rec.Market + ":" + rec.Symbol
+		if rec.Side == "buy" {
+			lastBuy[key] = rec.Timestamp
+			continue
+		}
+		if strategy != "" && rec.Strategy != strategy {
+			continue
+		}
+		entry := rec.EntryTime
+		if entry.IsZero() {
+			entry = lastBuy[key]
+		}
+		hold := 0
+		if !entry.IsZero() {
+			if rec.Market == "crypto" {
+				hold = int(rec.Timestamp.Sub(entry).Hours() / 24)
+			} else {
+				hold = TradingDaysBetween(entry, rec.Timestamp)
+			}
+		}
+		r, hasR := rec.RMultiple()
+		samples = append(samples, ExitSample{
+			Reason:   rec.Reason,
+			HoldDays: hold,
+			PnL:      rec.PnL,
+			PnLPct:   rec.PnLPct,
+			R:        r,
+			HasR:     hasR,
+		})
+	}
+	return samples
+}
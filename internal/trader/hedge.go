@@ -0,0 +1,236 @@
+package trader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/fsutil"
+	"traveler/internal/money"
+	"traveler/internal/provider"
+	"traveler/pkg/model"
+)
+
+// HedgeInstrument 인버스 ETF 헤지 수단
+type HedgeInstrument struct {
+	Symbol    string  `json:"symbol"`
+	Benchmark string  `json:"benchmark"` // 베타 추정 기준 지수 ETF
+	Leverage  float64 `json:"leverage"`  // 인버스 배수 (절대값)
+}
+
+// HedgeInstruments 지원 헤지 수단
+var HedgeInstruments = map[string]HedgeInstrument{
+	"SH":     {Symbol: "SH", Benchmark: "SPY", Leverage: 1},        // ProShares Short S&P500
+	"SQQQ":   {Symbol: "SQQQ", Benchmark: "QQQ", Leverage: 3},      // ProShares UltraPro Short QQQ
+	"114800": {Symbol: "114800", Benchmark: "069500", Leverage: 1}, // KODEX 인버스
+}
+
+// DefaultHedgeInstrument 마켓별 기본 헤지 수단
+func DefaultHedgeInstrument(market string) string {
+	if market == "kr" {
+		return "114800"
+	}
+	return "SH"
+}
+
+const (
+	// DefaultHedgeRatio 베타 가중 익스포저 중 헤지할 비율
+	DefaultHedgeRatio = 0.5
+	// hedgeBetaLookback 베타 추정 기간 (거래일)
+	hedgeBetaLookback = 60
+)
+
+// EstimateBeta 일간 수익률 기준 베타 (날짜 정렬, 데이터 부족 시 1.0)
+func EstimateBeta(asset, bench []model.Candle, lookback int) float64 {
+	benchClose := make(map[string]float64, len(bench))
+	for _, c := range bench {
+		benchClose[c.Time.Format("2006-01-02")] = c.Close
+	}
+
+	var ra, rb []float64
+	var prevA, prevB float64
+	for _, c := range asset {
+		b, ok := benchClose[c.Time.Format("2006-01-02")]
+		if !ok || c.Close <= 0 || b <= 0 {
+			continue
+		}
+		if prevA > 0 && prevB > 0 {
+			ra = append(ra, c.Close/prevA-1)
+			rb = append(rb, b/prevB-1)
+		}
+		prevA, prevB = c.Close, b
+	}
+	if lookback > 0 && len(ra) > lookback {
+		ra = ra[len(ra)-lookback:]
+		rb = rb[len(rb)-lookback:]
+	}
+	if len(ra) < 20 {
+		return 1.0
+	}
+
+	var meanA, meanB float64
+	for i := range ra {
+		meanA += ra[i]
+		meanB += rb[i]
+	}
+	meanA /= float64(len(ra))
+	meanB /= float64(len(rb))
+
+	var cov, varB float64
+	for i := range ra {
+		cov += (ra[i] - meanA) * (rb[i] - meanB)
+		varB += (rb[i] - meanB) * (rb[i] - meanB)
+	}
+	if varB == 0 {
+		return 1.0
+	}
+	return cov / varB
+}
+
+// HedgeSuggestion 헤지 제안
+type HedgeSuggestion struct {
+	Instrument     HedgeInstrument    `json:"instrument"`
+	Price          float64            `json:"price"`
+	LongExposure   float64            `json:"long_exposure"` // 롱 포지션 평가액 합
+	BetaExposure   float64            `json:"beta_exposure"` // Σ 평가액 × 베타
+	Ratio          float64            `json:"ratio"`
+	TargetNotional float64            `json:"target_notional"` // 인버스 ETF 목표 매수 금액
+	CurrentQty     float64            `json:"current_qty"`     // 이미 보유 중인 헤지 수량
+	TargetQty      float64            `json:"target_qty"`
+	DeltaQty       float64            `json:"delta_qty"` // 양수=매수, 음수=매도
+	Betas          map[string]float64 `json:"betas"`
+}
+
+// isHedgeSymbol 헤지 수단(인버스 ETF) 여부 — 롱 익스포저에서 제외
+func isHedgeSymbol(sym string) bool {
+	_, ok := HedgeInstruments[sym]
+	return ok
+}
+
+// CalculateHedge 베타 가중 롱 익스포저 × ratio를 상쇄하는 인버스 ETF 수량
+// currentQty: 이미 헤지로 보유 중인 수량
+func CalculateHedge(positions []broker.Position, betas map[string]float64, inst HedgeInstrument,
+	ratio, price, currentQty float64) *HedgeSuggestion {
+
+	s := &HedgeSuggestion{
+		Instrument: inst,
+		Price:      price,
+		Ratio:      ratio,
+		CurrentQty: currentQty,
+		Betas:      betas,
+	}
+	// 헤지 수단과 다른 통화 포지션(해외 계좌의 도쿄/홍콩 종목 등)은 환율 없이 합산할 수 없어 제외
+	cur := money.ForSymbol(inst.Symbol)
+	for _, p := range positions {
+		if isHedgeSymbol(p.Symbol) || p.Quantity <= 0 || PositionCurrency(p) != cur {
+			continue
+		}
+		value := positionValue(p)
+		beta, ok := betas[p.Symbol]
+		if !ok {
+			beta = 1.0
+		}
+		s.LongExposure += value
+		s.BetaExposure += value * beta
+	}
+	if price <= 0 || inst.Leverage <= 0 || s.BetaExposure <= 0 {
+		s.DeltaQty = -currentQty
+		return s
+	}
+	s.TargetNotional = s.BetaExposure * ratio / inst.Leverage
+	s.TargetQty = math.Floor(s.TargetNotional / price)
+	s.DeltaQty = s.TargetQty - currentQty
+	return s
+}
+
+// HedgeCalculator provider 일봉으로 베타를 추정해 헤지 수량 계산
+type HedgeCalculator struct {
+	provider provider.Provider
+}
+
+// NewHedgeCalculator 생성자
+func NewHedgeCalculator(p provider.Provider) *HedgeCalculator {
+	return &HedgeCalculator{provider: p}
+}
+
+// Suggest 현재 포지션 기준 헤지 제안
+func (h *HedgeCalculator) Suggest(ctx context.Context, positions []broker.Position, instrument string,
+	ratio, currentQty float64) (*HedgeSuggestion, error) {
+
+	inst, ok := HedgeInstruments[instrument]
+	if !ok {
+		return nil, fmt.Errorf("unknown hedge instrument: %s", instrument)
+	}
+	if ratio <= 0 {
+		ratio = DefaultHedgeRatio
+	}
+
+	days := hedgeBetaLookback + 10
+	bench, err := h.provider.GetDailyCandles(ctx, inst.Benchmark, days)
+	if err != nil {
+		return nil, fmt.Errorf("benchmark %s candles: %w", inst.Benchmark, err)
+	}
+	hedgeCandles, err := h.provider.GetDailyCandles(ctx, inst.Symbol, 2)
+	if err != nil || len(hedgeCandles) == 0 {
+		return nil, fmt.Errorf("hedge instrument %s price unavailable: %v", inst.Symbol, err)
+	}
+	price := hedgeCandles[len(hedgeCandles)-1].Close
+
+	betas := make(map[string]float64, len(positions))
+	for _, p := range positions {
+		if isHedgeSymbol(p.Symbol) {
+			continue
+		}
+		candles, err := h.provider.GetDailyCandles(ctx, p.Symbol, days)
+		if err != nil {
+			betas[p.Symbol] = 1.0
+			continue
+		}
+		betas[p.Symbol] = EstimateBeta(candles, bench, hedgeBetaLookback)
+	}
+
+	return CalculateHedge(positions, betas, inst, ratio, price, currentQty), nil
+}
+
+// HedgeState 자동 헤지로 보유 중인 수량 (hedge_<market>.json)
+// 전략이 같은 인버스 ETF를 매수할 수 있으므로 헤지분만 별도 추적한다.
+type HedgeState struct {
+	Instrument string    `json:"instrument"`
+	Quantity   float64   `json:"quantity"`
+	OpenedAt   time.Time `json:"opened_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func hedgeStatePath(dataDir, market string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("hedge_%s.json", market))
+}
+
+// LoadHedgeState 헤지 상태 로드 (없으면 빈 상태)
+func LoadHedgeState(dataDir, market string) HedgeState {
+	var st HedgeState
+	if data, err := os.ReadFile(hedgeStatePath(dataDir, market)); err == nil {
+		json.Unmarshal(data, &st)
+	}
+	return st
+}
+
+// SaveHedgeState 헤지 상태 저장 (수량 0이면 파일 삭제)
+func SaveHedgeState(dataDir, market string, st HedgeState) error {
+	path := hedgeStatePath(dataDir, market)
+	if st.Quantity <= 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,23 @@
+package trader
+
+import (
+	"testing"
+
+	"traveler/internal/broker"
+)
+
+func TestCalculateHedgeSkipsForeignCurrency(t *testing.T) {
+	positions := []broker.Position{
+		{Symbol: "AAPL", Quantity: 100, MarketValue: 20000, Currency: "USD"},
+		{Symbol: "7203.T", Quantity: 100, MarketValue: 280000, Currency: "JPY"}, // ¥280,000 — 달러로 합산하면 안 됨
+		{Symbol: "0700.HK", Quantity: 100, MarketValue: 38000},                   // 통화 미표기: 심볼로 HKD 추정
+		{Symbol: "SH", Quantity: 50, MarketValue: 2000},                          // 헤지 수단 자체
+	}
+	s := CalculateHedge(positions, nil, HedgeInstruments["SH"], 0.5, 40, 0)
+	if s.LongExposure != 20000 {
+		t.Errorf("LongExposure = %.0f, want 20000 (USD positions only)", s.LongExposure)
+	}
+	if s.TargetQty != 250 {
+		t.Errorf("TargetQty = %.0f, want 250", s.TargetQty)
+	}
+}
@@ -6,26 +6,28 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
-)
 
-// DefaultCommissionRate 기본 수수료율 (0.25% — US stocks)
-const DefaultCommissionRate = 0.0025
+	"traveler/internal/fees"
+	"traveler/internal/fsutil"
+)
 
-// CommissionRateByMarket 마켓별 수수료율 (편도)
+// CommissionRateByMarket 마켓별 수수료율 (편도, 거래세 제외 — fees 활성 프리셋)
 func CommissionRateByMarket(market string) float64 {
-	switch market {
-	case "crypto":
-		return 0.0005 // 0.05% (Upbit)
-	case "kr":
-		return 0.0025 // 0.25% (KIS domestic)
-	default:
-		return 0.0025 // 0.25% (KIS overseas)
+	return fees.ForMarket(market).Commission
+}
+
+// recordMarket 기록의 마켓 (market 필드가 없는 기록은 심볼로 판별)
+func recordMarket(r TradeRecord) string {
+	if r.Market != "" {
+		return r.Market
 	}
+	return PlanMarket(r.Symbol)
 }
 
 // TradeRecord 개별 매매 기록
 type TradeRecord struct {
 	Timestamp  time.Time `json:"timestamp"`
+	TradeID    string    `json:"trade_id,omitempty"`   // 진입-청산 짝 ID (같은 포지션의 매수와 (분할) 매도가 공유)
 	Market     string    `json:"market"`               // "us" or "kr"
 	Symbol     string    `json:"symbol"`
 	Name       string    `json:"name,omitempty"`
@@ -39,6 +41,22 @@ type TradeRecord struct {
 	EntryPrice float64   `json:"entry_price,omitempty"` // 매도 시 진입가
 	PnL        float64   `json:"pnl,omitempty"`         // 매도 시 실현손익 (수수료 포함 순손익)
 	PnLPct     float64   `json:"pnl_pct,omitempty"`     // 매도 시 수익률%
+	EntryStop  float64   `json:"entry_stop,omitempty"`  // 매도 시 진입 당시 손절가 (R 배수 기준)
+	EntryTime  time.Time `json:"entry_time,omitempty"`  // 매도 시 진입 시각 (보유 기간 분석)
+	OrderID    string    `json:"order_id,omitempty"`    // 브로커 주문번호 (체결 확인용)
+}
+
+// NewTradeID 진입-청산 짝 ID: 종목 + 진입 시각 (플랜의 EntryTime만 있으면 다시 만들 수 있다)
+func NewTradeID(symbol string, entryTime time.Time) string {
+	return symbol + "-" + entryTime.Format("20060102-150405")
+}
+
+// RMultiple 매도 기록의 R 배수 (진입 손절폭 기준, 수수료 전). 손절가 기록이 없으면 false
+func (r TradeRecord) RMultiple() (float64, bool) {
+	if r.Side != "sell" || r.EntryPrice <= 0 || r.EntryStop <= 0 || r.EntryStop >= r.EntryPrice {
+		return 0, false
+	}
+	return (r.Price - r.EntryPrice) / (r.EntryPrice - r.EntryStop), true
 }
 
 // StrategySummary 전략별 요약
@@ -81,9 +99,10 @@ type TradeSummary struct {
 
 // TradeHistory 영구 매매 기록 저장소
 type TradeHistory struct {
-	mu      sync.RWMutex
-	records []TradeRecord
-	path    string
+	mu       sync.RWMutex
+	records  []TradeRecord
+	path     string
+	onAppend func(TradeRecord) // 체결 기록 후 호출 (웹훅 등)
 }
 
 // NewTradeHistory 생성자
@@ -117,7 +136,7 @@ func (h *TradeHistory) save() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(h.path, data, 0644)
+	return fsutil.WriteFile(h.path, data, 0644)
 }
 
 // Append 매매 기록 추가
@@ -132,11 +151,28 @@ func (h *TradeHistory) Append(rec TradeRecord) error {
 		rec.Amount = rec.Quantity * rec.Price
 	}
 	if rec.Commission == 0 {
-		rec.Commission = rec.Amount * CommissionRateByMarket(rec.Market)
+		rec.Commission = fees.ForMarket(recordMarket(rec)).Cost(rec.Side, rec.Amount)
 	}
 
-	h.records = append(h.records, rec)
-	return h.save()
+	// US/KR 데몬이 같은 파일에 기록하므로 락 아래에서 최신본에 추가
+	err := fsutil.Update(h.path, func() error {
+		if err := h.load(); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		h.records = append(h.records, rec)
+		return h.save()
+	})
+	if h.onAppend != nil {
+		h.onAppend(rec)
+	}
+	return err
+}
+
+// SetOnAppend 기록 추가 시 호출할 콜백 (락 안에서 호출되므로 오래 걸리는 작업은 비동기로)
+func (h *TradeHistory) SetOnAppend(fn func(TradeRecord)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onAppend = fn
 }
 
 // GetAll 전체 기록 반환 (마켓 필터 옵션)
@@ -152,11 +188,7 @@ func (h *TradeHistory) GetAll(market string) []TradeRecord {
 
 	var filtered []TradeRecord
 	for _, r := range h.records {
-		rm := r.Market
-		if rm == "" {
-			rm = "us"
-		}
-		if rm == market {
+		if recordMarket(r) == market {
 			filtered = append(filtered, r)
 		}
 	}
@@ -180,10 +212,7 @@ func (h *TradeHistory) Summary(market string) TradeSummary {
 		s.TotalTrades++
 		s.TotalCommission += r.Commission
 
-		mkt := r.Market
-		if mkt == "" {
-			mkt = "us"
-		}
+		mkt := recordMarket(r)
 
 		if r.Side == "buy" {
 			s.BuyCount++
@@ -201,7 +230,7 @@ func (h *TradeHistory) Summary(market string) TradeSummary {
 			sellComm := r.Commission
 			buyComm := 0.0
 			if r.EntryPrice > 0 {
-				buyComm = r.EntryPrice * r.Quantity * CommissionRateByMarket(mkt)
+				buyComm = fees.ForMarket(mkt).BuyCost(r.EntryPrice * r.Quantity)
 			}
 			realizedCommission += sellComm + buyComm
 			realizedCommByMarket[mkt] += sellComm + buyComm
@@ -0,0 +1,243 @@
+package trader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/fsutil"
+)
+
+// DefaultDedupWindow 같은 종목·방향 주문을 중복으로 보는 기간
+const DefaultDedupWindow = 15 * time.Minute
+
+// journalRetention 저널 보관 기간 (시작 시 대조에서 결과 불명 주문 확인용)
+const journalRetention = 24 * time.Hour
+
+// Order intent states
+const (
+	IntentPending   = "pending"   // 기록 후 제출 중 (프로세스가 죽으면 이 상태로 남음)
+	IntentSubmitted = "submitted" // 브로커가 접수
+	IntentUnknown   = "unknown"   // 타임아웃/네트워크 오류 — 접수됐을 수 있음
+	IntentFailed    = "failed"    // 브로커가 명시적으로 거절 (재시도 허용)
+	IntentDenied    = "denied"    // 주문 직전 검사에서 거부 (제출 안 함)
+)
+
+// OrderIntent 제출 직전에 기록하는 주문 의도
+type OrderIntent struct {
+	Key      string    `json:"key"`
+	Symbol   string    `json:"symbol"`
+	Side     string    `json:"side"`
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price,omitempty"`
+	Amount   float64   `json:"amount,omitempty"`
+	Created  time.Time `json:"created"`
+	Status   string    `json:"status"`
+	OrderID  string    `json:"order_id,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Check    string    `json:"check,omitempty"`     // 거부한 주문 직전 검사 (denied)
+	SignalID string    `json:"signal_id,omitempty"` // 주문을 낸 시그널 (strategy.SignalID)
+}
+
+// blocks 실패로 확정되지 않은 의도는 윈도우 안에서 같은 주문을 막는다 (검사 거부는 제출되지 않았으므로 제외)
+func (i OrderIntent) blocks() bool {
+	return i.Status != IntentFailed && i.Status != IntentDenied
+}
+
+// OrderJournal 주문 의도 저널 ({dataDir}/order_intents_{market}.json)
+// 데몬, CLI, 웹이 같은 마켓 파일을 공유하므로 프로세스 간에도 중복을 막는다.
+type OrderJournal struct {
+	path   string
+	window time.Duration
+	mu     sync.Mutex
+}
+
+// NewOrderJournal 생성자
+func NewOrderJournal(dataDir, market string) *OrderJournal {
+	if market == "" {
+		market = "us"
+	}
+	return &OrderJournal{
+		path:   filepath.Join(dataDir, fmt.Sprintf("order_intents_%s.json", market)),
+		window: DefaultDedupWindow,
+	}
+}
+
+// SetWindow 중복 판정 기간 변경
+func (j *OrderJournal) SetWindow(d time.Duration) {
+	j.window = d
+}
+
+// IdempotencyKey 주문 내용 + 날짜 기반 키 (같은 날 같은 주문은 같은 키)
+func IdempotencyKey(order broker.Order, now time.Time) string {
+	raw := fmt.Sprintf("%s|%s|%s|%g|%g|%g|%s",
+		order.Side, order.Symbol, order.Type, order.Quantity, order.LimitPrice, order.Amount, now.Format("2006-01-02"))
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Begin 제출 전 의도 기록. 윈도우 안에 같은 종목·방향의 미확정 의도가 있으면 그것을 반환하고 기록하지 않는다.
+// signalID는 주문을 낸 시그널 (없으면 빈 문자열).
+func (j *OrderJournal) Begin(order broker.Order, signalID string, now time.Time) (key string, dup *OrderIntent, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key = IdempotencyKey(order, now)
+	err = fsutil.Update(j.path, func() error {
+		intents, err := j.load()
+		if err != nil {
+			return err
+		}
+		kept := intents[:0]
+		for _, in := range intents {
+			if now.Sub(in.Created) <= journalRetention {
+				kept = append(kept, in)
+			}
+		}
+		for i := range kept {
+			in := kept[i]
+			if now.Sub(in.Created) <= j.window && in.Symbol == order.Symbol && in.Side == string(order.Side) && in.blocks() {
+				dup = &in
+				return j.save(kept)
+			}
+		}
+		kept = append(kept, OrderIntent{
+			Key:      key,
+			Symbol:   order.Symbol,
+			Side:     string(order.Side),
+			Quantity: order.Quantity,
+			Price:    order.LimitPrice,
+			Amount:   order.Amount,
+			Created:  now,
+			Status:   IntentPending,
+			SignalID: signalID,
+		})
+		return j.save(kept)
+	})
+	return key, dup, err
+}
+
+// RecordDenied 주문 직전 검사 거부 기록 (중복 판정에는 쓰지 않는다)
+func (j *OrderJournal) RecordDenied(order broker.Order, signalID string, d PreTradeDecision, now time.Time) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return fsutil.Update(j.path, func() error {
+		intents, err := j.load()
+		if err != nil {
+			return err
+		}
+		kept := intents[:0]
+		for _, in := range intents {
+			if now.Sub(in.Created) <= journalRetention {
+				kept = append(kept, in)
+			}
+		}
+		kept = append(kept, OrderIntent{
+			Key:      IdempotencyKey(order, now),
+			Symbol:   order.Symbol,
+			Side:     string(order.Side),
+			Quantity: order.Quantity,
+			Price:    order.LimitPrice,
+			Amount:   order.Amount,
+			Created:  now,
+			Status:   IntentDenied,
+			Error:    d.Reason,
+			Check:    d.Check,
+			SignalID: signalID,
+		})
+		return j.save(kept)
+	})
+}
+
+// Complete 제출 결과 기록
+func (j *OrderJournal) Complete(key string, res *broker.OrderResult, orderErr error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return fsutil.Update(j.path, func() error {
+		intents, err := j.load()
+		if err != nil {
+			return err
+		}
+		for i := range intents {
+			if intents[i].Key != key || intents[i].Status != IntentPending {
+				continue
+			}
+			switch {
+			case orderErr == nil && res != nil && res.Status == "rejected":
+				intents[i].Status = IntentFailed
+			case orderErr == nil:
+				intents[i].Status = IntentSubmitted
+				if res != nil {
+					intents[i].OrderID = res.OrderID
+				}
+			case isDefiniteRejection(orderErr):
+				intents[i].Status = IntentFailed
+				intents[i].Error = orderErr.Error()
+			default:
+				intents[i].Status = IntentUnknown
+				intents[i].Error = orderErr.Error()
+			}
+		}
+		return j.save(intents)
+	})
+}
+
+// Recent since 이후 기록된 의도 (최대 24시간 보관)
+func (j *OrderJournal) Recent(since time.Time) ([]OrderIntent, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	intents, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []OrderIntent
+	for _, in := range intents {
+		if !in.Created.Before(since) {
+			out = append(out, in)
+		}
+	}
+	return out, nil
+}
+
+// isDefiniteRejection 주문이 접수되지 않았음이 확실한 에러 (재시도해도 중복 위험 없음)
+// 브로커가 broker.Rejection으로 표시한 에러만 해당하고, 전송 실패/타임아웃은 접수 여부를 모르므로 제외한다.
+func isDefiniteRejection(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return broker.IsRejection(err)
+}
+
+func (j *OrderJournal) load() ([]OrderIntent, error) {
+	data, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var intents []OrderIntent
+	if err := json.Unmarshal(data, &intents); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(j.path), err)
+	}
+	return intents, nil
+}
+
+func (j *OrderJournal) save(intents []OrderIntent) error {
+	data, err := json.MarshalIndent(intents, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(j.path, data, 0644)
+}
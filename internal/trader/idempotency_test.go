@@ -0,0 +1,157 @@
+package trader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"traveler/internal/broker"
+)
+
+func TestOrderJournalBegin(t *testing.T) {
+	now := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	buy := broker.Order{Symbol: "AAPL", Side: broker.OrderSideBuy, Type: broker.OrderTypeLimit, Quantity: 10, LimitPrice: 100}
+
+	tests := []struct {
+		name    string
+		prior   *OrderIntent // 먼저 기록된 의도 (nil이면 빈 저널)
+		order   broker.Order
+		at      time.Time
+		wantDup bool
+	}{
+		{"empty journal", nil, buy, now, false},
+		{"pending within window", &OrderIntent{Symbol: "AAPL", Side: "buy", Status: IntentPending, Created: now.Add(-5 * time.Minute)}, buy, now, true},
+		{"unknown within window", &OrderIntent{Symbol: "AAPL", Side: "buy", Status: IntentUnknown, Created: now.Add(-5 * time.Minute)}, buy, now, true},
+		{"submitted within window", &OrderIntent{Symbol: "AAPL", Side: "buy", Status: IntentSubmitted, Created: now.Add(-5 * time.Minute)}, buy, now, true},
+		// 다른 수량이어도 같은 종목·방향이면 중복 (재사이징된 재시도)
+		{"different quantity", &OrderIntent{Symbol: "AAPL", Side: "buy", Quantity: 3, Status: IntentPending, Created: now.Add(-time.Minute)}, buy, now, true},
+		{"outside window", &OrderIntent{Symbol: "AAPL", Side: "buy", Status: IntentPending, Created: now.Add(-DefaultDedupWindow - time.Minute)}, buy, now, false},
+		{"failed does not block", &OrderIntent{Symbol: "AAPL", Side: "buy", Status: IntentFailed, Created: now.Add(-time.Minute)}, buy, now, false},
+		{"denied does not block", &OrderIntent{Symbol: "AAPL", Side: "buy", Status: IntentDenied, Created: now.Add(-time.Minute)}, buy, now, false},
+		{"other side", &OrderIntent{Symbol: "AAPL", Side: "sell", Status: IntentPending, Created: now.Add(-time.Minute)}, buy, now, false},
+		{"other symbol", &OrderIntent{Symbol: "MSFT", Side: "buy", Status: IntentPending, Created: now.Add(-time.Minute)}, buy, now, false},
+	}
+	for _, tt := range tests {
+		j := NewOrderJournal(t.TempDir(), "us")
+		if tt.prior != nil {
+			tt.prior.Key = "prior"
+			if err := j.save([]OrderIntent{*tt.prior}); err != nil {
+				t.Fatalf("%s: seed journal: %v", tt.name, err)
+			}
+		}
+		key, dup, err := j.Begin(tt.order, "sig-1", tt.at)
+		if err != nil {
+			t.Fatalf("%s: Begin: %v", tt.name, err)
+		}
+		if (dup != nil) != tt.wantDup {
+			t.Errorf("%s: dup=%v, want %v", tt.name, dup != nil, tt.wantDup)
+		}
+		recent, err := j.Recent(tt.at.Add(-time.Second))
+		if err != nil {
+			t.Fatalf("%s: Recent: %v", tt.name, err)
+		}
+		// 중복이면 새 의도를 기록하지 않는다
+		if recorded := len(recent) == 1 && recent[0].Key == key; recorded == tt.wantDup {
+			t.Errorf("%s: new intent recorded=%v with dup=%v", tt.name, recorded, tt.wantDup)
+		}
+	}
+}
+
+func TestOrderJournalBeginDropsExpired(t *testing.T) {
+	now := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	j := NewOrderJournal(t.TempDir(), "us")
+	if err := j.save([]OrderIntent{{Key: "old", Symbol: "MSFT", Side: "buy", Status: IntentSubmitted, Created: now.Add(-journalRetention - time.Hour)}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := j.Begin(broker.Order{Symbol: "AAPL", Side: broker.OrderSideBuy, Quantity: 1}, "", now); err != nil {
+		t.Fatal(err)
+	}
+	intents, err := j.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intents) != 1 || intents[0].Symbol != "AAPL" {
+		t.Errorf("journal = %+v, want only the new AAPL intent", intents)
+	}
+}
+
+func TestOrderJournalComplete(t *testing.T) {
+	now := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	order := broker.Order{Symbol: "AAPL", Side: broker.OrderSideBuy, Type: broker.OrderTypeMarket, Quantity: 5}
+
+	tests := []struct {
+		name       string
+		res        *broker.OrderResult
+		err        error
+		wantStatus string
+		wantID     string
+	}{
+		{"accepted", &broker.OrderResult{OrderID: "A1", Status: "submitted"}, nil, IntentSubmitted, "A1"},
+		{"filled", &broker.OrderResult{OrderID: "A2", Status: "filled"}, nil, IntentSubmitted, "A2"},
+		{"rejected result", &broker.OrderResult{Status: "rejected"}, nil, IntentFailed, ""},
+		{"broker rejection", nil, &broker.OrderRejectedError{Reason: "insufficient funds"}, IntentFailed, ""},
+		{"wrapped rejection", nil, fmt.Errorf("place: %w", &broker.OrderRejectedError{Reason: "halted"}), IntentFailed, ""},
+		{"timeout", nil, context.DeadlineExceeded, IntentUnknown, ""},
+		{"network error", nil, errors.New("read tcp: connection reset by peer"), IntentUnknown, ""},
+	}
+	for _, tt := range tests {
+		j := NewOrderJournal(t.TempDir(), "us")
+		key, _, err := j.Begin(order, "", now)
+		if err != nil {
+			t.Fatalf("%s: Begin: %v", tt.name, err)
+		}
+		if err := j.Complete(key, tt.res, tt.err); err != nil {
+			t.Fatalf("%s: Complete: %v", tt.name, err)
+		}
+		intents, err := j.Recent(now.Add(-time.Second))
+		if err != nil || len(intents) != 1 {
+			t.Fatalf("%s: Recent = %v, %v", tt.name, intents, err)
+		}
+		if got := intents[0]; got.Status != tt.wantStatus || got.OrderID != tt.wantID {
+			t.Errorf("%s: status=%s id=%q, want %s %q", tt.name, got.Status, got.OrderID, tt.wantStatus, tt.wantID)
+		}
+	}
+}
+
+func TestOrderJournalCompleteOnlyPending(t *testing.T) {
+	now := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	j := NewOrderJournal(t.TempDir(), "us")
+	key, _, err := j.Begin(broker.Order{Symbol: "AAPL", Side: broker.OrderSideBuy, Quantity: 1}, "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Complete(key, &broker.OrderResult{OrderID: "A1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// 이미 확정된 의도는 늦게 온 에러로 덮어쓰지 않는다
+	if err := j.Complete(key, nil, errors.New("late timeout")); err != nil {
+		t.Fatal(err)
+	}
+	intents, _ := j.Recent(now.Add(-time.Second))
+	if len(intents) != 1 || intents[0].Status != IntentSubmitted || intents[0].OrderID != "A1" {
+		t.Errorf("intents = %+v, want submitted A1", intents)
+	}
+}
+
+func TestIsDefiniteRejection(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"order rejected", &broker.OrderRejectedError{Reason: "market closed"}, true},
+		{"rate limited", &broker.OrderRejectedError{Reason: "EGW00201", RateLimit: true}, true},
+		{"wrapped rejection", fmt.Errorf("kis: %w", &broker.OrderRejectedError{Reason: "no quote"}), true},
+		{"canceled", context.Canceled, false},
+		{"deadline", fmt.Errorf("post order: %w", context.DeadlineExceeded), false},
+		{"transport", errors.New("dial tcp: i/o timeout"), false},
+		{"rejection text only", errors.New("order failed: rejected"), false},
+	}
+	for _, tt := range tests {
+		if got := isDefiniteRejection(tt.err); got != tt.want {
+			t.Errorf("%s: isDefiniteRejection(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package trader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"traveler/internal/strategy"
+)
+
+// ExternalStrategy 전략명이 없는 외부 시그널에 붙이는 이름
+const ExternalStrategy = "external"
+
+// ImportResult 외부 시그널 실행 결과
+type ImportResult struct {
+	Executed []ExecutionResult
+	Rejected []RejectedSignal
+}
+
+// ParseSignals 외부 시그널 JSON 파싱 (strategy.Signal 스키마)
+// 허용 형식: Signal 배열, {"signals": [...]} (웹 스캔 결과/리포트 파일), Signal 단건
+func ParseSignals(data []byte) ([]strategy.Signal, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty signal payload")
+	}
+
+	var signals []strategy.Signal
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(data, &signals); err != nil {
+			return nil, fmt.Errorf("parse signals: %w", err)
+		}
+		return signals, nil
+	}
+
+	var wrapped struct {
+		Signals *[]strategy.Signal `json:"signals"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("parse signals: %w", err)
+	}
+	if wrapped.Signals != nil {
+		return *wrapped.Signals, nil
+	}
+
+	var single strategy.Signal
+	if err := json.Unmarshal(data, &single); err != nil {
+		return nil, fmt.Errorf("parse signal: %w", err)
+	}
+	return []strategy.Signal{single}, nil
+}
+
+// PrepareSignals 외부 시그널 정규화 + 사이징
+// 외부에서 준 수량/금액은 무시하고 스캔 시그널과 같은 Sizer 규칙으로 다시 계산한다.
+func PrepareSignals(signals []strategy.Signal, sizerCfg SizerConfig) ([]strategy.Signal, []RejectedSignal) {
+	sizer := NewPositionSizer(sizerCfg)
+	ready := make([]strategy.Signal, 0, len(signals))
+	rejected := make([]RejectedSignal, 0)
+	seen := make(map[string]bool)
+
+	for _, sig := range signals {
+		sig.Stock.Symbol = strings.ToUpper(strings.TrimSpace(sig.Stock.Symbol))
+		if sig.Type == "" {
+			sig.Type = strategy.SignalBuy
+		}
+		if sig.Strategy == "" {
+			sig.Strategy = ExternalStrategy
+		}
+		if sig.Guide != nil {
+			g := *sig.Guide // 호출자 데이터 보존
+			sig.Guide = &g
+		}
+
+		if reason := validateImportedSignal(sig); reason != "" {
+			rejected = append(rejected, RejectedSignal{Signal: sig, Reason: reason})
+			continue
+		}
+		if seen[sig.Stock.Symbol] {
+			rejected = append(rejected, RejectedSignal{Signal: sig, Reason: "duplicate symbol"})
+			continue
+		}
+		seen[sig.Stock.Symbol] = true
+
+		g := sig.Guide
+		if g.EntryType == "" {
+			g.EntryType = "limit"
+		}
+		g.StopLossPct = (g.EntryPrice - g.StopLoss) / g.EntryPrice * 100
+		g.Target1Pct = (g.Target1 - g.EntryPrice) / g.EntryPrice * 100
+		if g.Target2 > 0 {
+			g.Target2Pct = (g.Target2 - g.EntryPrice) / g.EntryPrice * 100
+		}
+		g.RiskRewardRatio = (g.Target1 - g.EntryPrice) / (g.EntryPrice - g.StopLoss)
+
+		size := sizer.CalculateSize(&sig)
+		if size.Skipped {
+			rejected = append(rejected, RejectedSignal{Signal: sig, Reason: size.SkipReason})
+			continue
+		}
+		g.PositionSize = size.Quantity
+		g.InvestAmount = size.InvestAmount
+		g.RiskAmount = size.RiskAmount
+		g.RiskPct = size.RiskPct
+		g.AllocationPct = size.AllocationPct
+		ready = append(ready, sig)
+	}
+
+	return ready, rejected
+}
+
+// validateImportedSignal 필수 필드 검증 (빈 문자열이면 통과)
+func validateImportedSignal(sig strategy.Signal) string {
+	g := sig.Guide
+	switch {
+	case sig.Stock.Symbol == "":
+		return "symbol is required"
+	case sig.Type != strategy.SignalBuy:
+		return fmt.Sprintf("unsupported signal type %q (only BUY)", sig.Type)
+	case g == nil:
+		return "guide with entry_price, stop_loss and target_1 is required"
+	case g.EntryPrice <= 0:
+		return "entry_price must be positive"
+	case g.StopLoss <= 0 || g.StopLoss >= g.EntryPrice:
+		return "stop_loss must be between 0 and entry_price"
+	case g.Target1 <= g.EntryPrice:
+		return "target_1 must be above entry_price"
+	case g.Target2 != 0 && g.Target2 < g.Target1:
+		return "target_2 must not be below target_1"
+	}
+	return ""
+}
+
+// ImportSignals 외부 시그널 실행: 정규화/사이징 → 리스크 검증 → 주문 (+ 플랜 저장)
+func (t *AutoTrader) ImportSignals(ctx context.Context, signals []strategy.Signal, sizerCfg SizerConfig) (*ImportResult, error) {
+	ready, rejected := PrepareSignals(signals, sizerCfg)
+	result := &ImportResult{Rejected: rejected}
+	if len(ready) == 0 {
+		return result, nil
+	}
+
+	// 리스크 거절 사유를 호출자에게 돌려주기 위해 미리 검증 (ExecuteSignals는 로그만 남김)
+	positions, err := t.broker.GetPositions(ctx)
+	if err != nil && !t.config.DryRun {
+		return nil, fmt.Errorf("get positions: %w", err)
+	}
+	approved, riskRejected := t.risk.ValidateSignals(ready, positions)
+	result.Rejected = append(result.Rejected, riskRejected...)
+	if len(approved) == 0 {
+		return result, nil
+	}
+
+	executed, err := t.ExecuteSignals(ctx, approved)
+	if err != nil {
+		return nil, err
+	}
+	result.Executed = executed
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package trader
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+)
+
+const (
+	// KellyRecalcInterval 전략별 Kelly 리스크 재계산 주기
+	KellyRecalcInterval = 7 * 24 * time.Hour
+	// KellyMinTrades Kelly 계산에 필요한 최소 청산 건수 (미만이면 캡 미적용)
+	KellyMinTrades = 10
+	// kellyRiskFloor 음의 엣지 전략도 완전히 차단하지 않는 최소 리스크 (0.25%)
+	kellyRiskFloor = 0.0025
+)
+
+// StrategyKellyStats 전략별 저널 통계 + half-Kelly 리스크
+type StrategyKellyStats struct {
+	Trades    int     `json:"trades"`
+	WinRate   float64 `json:"win_rate"` // 0-1
+	AvgWin    float64 `json:"avg_win"`
+	AvgLoss   float64 `json:"avg_loss"`
+	Kelly     float64 `json:"kelly"`
+	HalfKelly float64 `json:"half_kelly"`
+}
+
+// kellyRiskState kelly_risk_<market>.json 저장 형식
+type kellyRiskState struct {
+	UpdatedAt time.Time                     `json:"updated_at"`
+	Stats     map[string]StrategyKellyStats `json:"stats"`
+}
+
+// KellyRiskStore 전략별 half-Kelly 리스크 캡을 주 1회 재계산해 저장
+type KellyRiskStore struct {
+	mu    sync.Mutex
+	path  string
+	state kellyRiskState
+}
+
+// NewKellyRiskStore 생성자
+func NewKellyRiskStore(dataDir, market string) *KellyRiskStore {
+	s := &KellyRiskStore{
+		path: filepath.Join(dataDir, "kelly_risk_"+market+".json"),
+	}
+	if data, err := os.ReadFile(s.path); err == nil {
+		if err := json.Unmarshal(data, &s.state); err != nil {
+			log.Printf("[KELLY] Warning: could not parse %s: %v", s.path, err)
+			s.state = kellyRiskState{}
+		}
+	}
+	return s
+}
+
+// RiskCaps 전략별 리스크 캡 반환 (마지막 계산이 KellyRecalcInterval보다 오래되면 재계산)
+func (s *KellyRiskStore) RiskCaps(history *TradeHistory, market string) map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if history != nil && time.Since(s.state.UpdatedAt) >= KellyRecalcInterval {
+		s.state = kellyRiskState{
+			UpdatedAt: time.Now(),
+			Stats:     StrategyKellyFromHistory(history.GetAll(market), KellyMinTrades),
+		}
+		if err := s.persist(); err != nil {
+			log.Printf("[KELLY] Warning: could not save %s: %v", s.path, err)
+		}
+		for name, st := range s.state.Stats {
+			log.Printf("[KELLY] %s: %d trades, WR %.0f%%, half-Kelly %.2f%%",
+				name, st.Trades, st.WinRate*100, st.HalfKelly*100)
+		}
+	}
+
+	caps := make(map[string]float64, len(s.state.Stats))
+	for name, st := range s.state.Stats {
+		caps[name] = st.HalfKelly
+	}
+	return caps
+}
+
+func (s *KellyRiskStore) persist() error {
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(s.path, data, 0644)
+}
+
+// StrategyKellyFromHistory 매도 기록(실현손익)으로 전략별 half-Kelly 계산
+// minTrades 미만인 전략은 결과에서 제외 (통계적으로 의미 없음)
+func StrategyKellyFromHistory(records []TradeRecord, minTrades int) map[string]StrategyKellyStats {
+	type acc struct {
+		wins, losses    int
+		winSum, lossSum float64
+	}
+	byStrategy := make(map[string]*acc)
+	for _, r := range records {
+		if r.Side != "sell" || r.Strategy == "" {
+			continue
+		}
+		name := baseStrategyName(r.Strategy)
+		a := byStrategy[name]
+		if a == nil {
+			a = &acc{}
+			byStrategy[name] = a
+		}
+		if r.PnL > 0 {
+			a.wins++
+			a.winSum += r.PnL
+		} else {
+			a.losses++
+			a.lossSum += -r.PnL
+		}
+	}
+
+	result := make(map[string]StrategyKellyStats)
+	for name, a := range byStrategy {
+		trades := a.wins + a.losses
+		if trades < minTrades {
+			continue
+		}
+		st := StrategyKellyStats{
+			Trades:  trades,
+			WinRate: float64(a.wins) / float64(trades),
+		}
+		if a.wins > 0 {
+			st.AvgWin = a.winSum / float64(a.wins)
+		}
+		if a.losses > 0 {
+			st.AvgLoss = a.lossSum / float64(a.losses)
+			st.Kelly = KellyFraction(st.WinRate, st.AvgWin, st.AvgLoss)
+		} else {
+			st.Kelly = 1 // 손실 없음 → 캡이 구성된 리스크보다 작아질 일 없음
+		}
+		st.HalfKelly = st.Kelly / 2
+		if st.HalfKelly < kellyRiskFloor {
+			st.HalfKelly = kellyRiskFloor
+		}
+		result[name] = st
+	}
+	return result
+}
+
+// baseStrategyName "volatility-breakout(bull)" → "volatility-breakout"
+func baseStrategyName(name string) string {
+	if idx := strings.Index(name, "("); idx > 0 {
+		return name[:idx]
+	}
+	return name
+}
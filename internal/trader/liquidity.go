@@ -0,0 +1,178 @@
+package trader
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+	"traveler/pkg/model"
+)
+
+// LiquidityEstimate 최근 1분봉 기반 종목별 유동성 추정치
+type LiquidityEstimate struct {
+	Symbol       string  `json:"symbol"`
+	SpreadPct    float64 `json:"spread_pct"`     // 추정 스프레드 (1분봉 고저폭 중앙값, 비율)
+	SlippagePct  float64 `json:"slippage_pct"`   // 편도 예상 슬리피지 (비율)
+	AvgBarVolume float64 `json:"avg_bar_volume"` // 1분봉 평균 거래량
+	Bars         int     `json:"bars"`
+}
+
+const (
+	// liquidityMinSlippage 유동성이 아무리 좋아도 최소 편도 슬리피지 (0.02%)
+	liquidityMinSlippage = 0.0002
+	// liquidityMaxSlippage 추정치 상한 (데이터 이상치 방어, 2%)
+	liquidityMaxSlippage = 0.02
+	// liquidityThinVolume 1분봉 평균 거래량이 이보다 적으면 슬리피지 가중
+	liquidityThinVolume = 1000
+)
+
+// EstimateLiquidity 1분봉으로 스프레드/슬리피지 추정
+// 스프레드 ≈ 1분봉 (고가-저가)/종가 중앙값, 편도 슬리피지 ≈ 스프레드의 절반.
+// 거래량이 얇은 종목은 슬리피지를 최대 2배까지 가중한다.
+func EstimateLiquidity(symbol string, days []model.IntradayData) LiquidityEstimate {
+	est := LiquidityEstimate{Symbol: symbol}
+
+	var ranges []float64
+	var volSum float64
+	for _, day := range days {
+		for _, c := range day.Candles {
+			if c.Close <= 0 || c.High < c.Low {
+				continue
+			}
+			ranges = append(ranges, (c.High-c.Low)/c.Close)
+			volSum += float64(c.Volume)
+		}
+	}
+	est.Bars = len(ranges)
+	if est.Bars == 0 {
+		return est
+	}
+
+	sort.Float64s(ranges)
+	est.SpreadPct = ranges[len(ranges)/2]
+	est.AvgBarVolume = volSum / float64(est.Bars)
+
+	slip := est.SpreadPct / 2
+	if est.AvgBarVolume < liquidityThinVolume {
+		slip *= 2 - est.AvgBarVolume/liquidityThinVolume
+	}
+	if slip < liquidityMinSlippage {
+		slip = liquidityMinSlippage
+	}
+	if slip > liquidityMaxSlippage {
+		slip = liquidityMaxSlippage
+	}
+	est.SlippagePct = slip
+	return est
+}
+
+// LiquidityModel provider의 1분봉으로 종목별 유동성 추정 (세션 내 캐시)
+type LiquidityModel struct {
+	provider provider.Provider
+	days     int
+	mu       sync.Mutex
+	cache    map[string]LiquidityEstimate
+}
+
+// NewLiquidityModel 생성자 (days: 참고할 최근 거래일 수)
+func NewLiquidityModel(p provider.Provider, days int) *LiquidityModel {
+	if days <= 0 {
+		days = 3
+	}
+	return &LiquidityModel{
+		provider: p,
+		days:     days,
+		cache:    make(map[string]LiquidityEstimate),
+	}
+}
+
+// Estimate 종목 유동성 추정 (1분봉 조회 실패 시 ok=false)
+func (m *LiquidityModel) Estimate(ctx context.Context, symbol string) (LiquidityEstimate, bool) {
+	m.mu.Lock()
+	if est, ok := m.cache[symbol]; ok {
+		m.mu.Unlock()
+		return est, est.Bars > 0
+	}
+	m.mu.Unlock()
+
+	var est LiquidityEstimate
+	data, err := m.provider.GetMultiDayIntraday(ctx, symbol, m.days, 1)
+	if err == nil {
+		est = EstimateLiquidity(symbol, data)
+	} else {
+		est = LiquidityEstimate{Symbol: symbol}
+	}
+
+	m.mu.Lock()
+	m.cache[symbol] = est
+	m.mu.Unlock()
+	return est, est.Bars > 0
+}
+
+// SlippageMap 여러 종목의 편도 슬리피지 맵 (추정 실패 종목은 제외)
+func (m *LiquidityModel) SlippageMap(ctx context.Context, syms []string) map[string]float64 {
+	result := make(map[string]float64, len(syms))
+	for _, sym := range syms {
+		if ctx.Err() != nil {
+			break
+		}
+		if est, ok := m.Estimate(ctx, sym); ok {
+			result[sym] = est.SlippagePct
+		}
+	}
+	return result
+}
+
+const (
+	// defaultMaxADVPct 주문 수량 기본 상한 (20일 평균 거래량의 0.5%)
+	defaultMaxADVPct = 0.005
+	// advDays ADV 계산 기간 (거래일)
+	advDays = 20
+)
+
+// maxADVFraction 주문 수량 상한 비율 (0 = 기본값, 음수 = 비활성 → 0)
+func (c SizerConfig) maxADVFraction() float64 {
+	switch {
+	case c.MaxADVPct < 0:
+		return 0
+	case c.MaxADVPct == 0:
+		return defaultMaxADVPct
+	}
+	return c.MaxADVPct
+}
+
+// signalADV 시그널 종목의 20일 평균 거래량: 설정 맵 → 시그널 일봉 → 전략 Details 순 (모르면 0)
+func (p *PositionSizer) signalADV(sig *strategy.Signal) float64 {
+	if adv, ok := p.config.ADV[sig.Stock.Symbol]; ok && adv > 0 {
+		return adv
+	}
+	if adv := strategy.CalculateAvgVolume(sig.Candles, advDays); adv > 0 {
+		return adv
+	}
+	for _, key := range []string{"avg_volume_20", "avg_volume"} {
+		if adv := sig.Details[key]; adv > 0 {
+			return adv
+		}
+	}
+	return 0
+}
+
+// ADVMap provider 일봉으로 종목별 20일 평균 거래량 (조회 실패 종목은 제외)
+func ADVMap(ctx context.Context, p provider.Provider, syms []string) map[string]float64 {
+	result := make(map[string]float64, len(syms))
+	for _, sym := range syms {
+		if ctx.Err() != nil {
+			break
+		}
+		candles, err := p.GetDailyCandles(ctx, sym, advDays+5)
+		if err != nil {
+			continue
+		}
+		if adv := strategy.CalculateAvgVolume(candles, advDays); adv > 0 {
+			result[sym] = adv
+		}
+	}
+	return result
+}
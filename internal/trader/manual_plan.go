@@ -0,0 +1,229 @@
+package trader
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"traveler/internal/strategy"
+	"traveler/pkg/model"
+)
+
+// PlanFromCandles 일봉 기반 손절/목표가로 플랜 생성 (candles 20개 이상 필요, 부족하면 nil)
+// strategyName이 비어 있으면 현재 기술적 상태로 전략을 추정한다.
+// 데몬의 기존 보유 종목 플랜 자동 생성과 수동 포지션 등록이 함께 쓴다.
+// tranches가 켜져 있으면 스캔 가이드와 같은 R 배수 목표로 잡는다.
+func PlanFromCandles(symbol string, candles []model.Candle, avgCost, quantity float64, strategyName string, tranches strategy.TrancheExit) *PositionPlan {
+	if len(candles) < 20 {
+		return nil
+	}
+
+	ind := strategy.CalculateIndicators(candles)
+	lastClose := candles[len(candles)-1].Close
+
+	// 전략 추정: 현재 기술적 상태를 기반으로 가장 적합한 전략 배정
+	if strategyName == "" {
+		strategyName = inferStrategy(lastClose, avgCost, ind)
+	}
+
+	// R 기반 손절/익절 계산
+	var stopLoss, target1, target2 float64
+
+	switch strategyName {
+	case "mean-reversion":
+		// 손절: 최근 저점 아래 or 진입가 -3%
+		recentLow := candles[len(candles)-1].Low
+		for i := len(candles) - 5; i < len(candles); i++ {
+			if i >= 0 && candles[i].Low < recentLow {
+				recentLow = candles[i].Low
+			}
+		}
+		stopLoss = recentLow * 0.99
+		if stopLoss > avgCost*0.97 {
+			stopLoss = avgCost * 0.97
+		}
+		// 타겟: MA20(평균 회귀), BB상단
+		target1 = ind.MA20
+		target2 = ind.BBUpper
+		if target1 <= avgCost {
+			target1 = avgCost * 1.02
+		}
+		if target2 <= target1 {
+			target2 = avgCost * 1.04
+		}
+
+	case "breakout":
+		// 손절: 돌파 레벨 아래 or 진입가 -3%
+		breakoutLevel := strategy.CalculateHighestHigh(candles, 20)
+		stopLoss = breakoutLevel * 0.97
+		if stopLoss > avgCost*0.97 {
+			stopLoss = avgCost * 0.97
+		}
+		riskPerShare := avgCost - stopLoss
+		// Measured move T1, Fib 1.618 T2
+		swingLow, _ := strategy.FindSwingLow(candles, 20)
+		if swingLow > 0 && breakoutLevel > swingLow {
+			target1 = breakoutLevel + (breakoutLevel - swingLow)
+			target2 = strategy.FibonacciExtension(swingLow, breakoutLevel, 0.618)
+		}
+		if target1 <= 0 || (target1-avgCost) < riskPerShare*0.5 {
+			target1 = avgCost + riskPerShare*1.5
+		}
+		if target2 <= target1 {
+			target2 = avgCost + riskPerShare*3.0
+		}
+
+	default: // pullback
+		// 손절: MA20 아래 or 진입가 -3%
+		stopLoss = ind.MA20 * 0.98
+		if stopLoss > avgCost*0.97 {
+			stopLoss = avgCost * 0.97
+		}
+		riskPerShare := avgCost - stopLoss
+		if riskPerShare <= 0 {
+			riskPerShare = avgCost * 0.02
+		}
+		// Swing high T1, Fib 1.272 T2
+		swingHigh, _ := strategy.FindSwingHigh(candles, 20)
+		swingLow, _ := strategy.FindSwingLow(candles, 20)
+		target1 = swingHigh
+		if target1 <= 0 || (target1-avgCost) < riskPerShare*0.5 {
+			target1 = avgCost + riskPerShare*1.5
+		}
+		if swingLow > 0 && swingHigh > swingLow {
+			target2 = strategy.FibonacciExtension(swingLow, swingHigh, 0.272)
+		}
+		if target2 <= target1 {
+			target2 = avgCost + riskPerShare*2.5
+		}
+	}
+
+	// 전략별 손절 모델이 설정돼 있으면 스캔 가이드와 같은 규칙으로 손절
+	if m, ok := strategy.StopModelFor(strategyName); ok {
+		if stop := m.StopPrice(candles, avgCost); stop > 0 {
+			stopLoss = stop
+		}
+	}
+
+	maxDays := GetMaxHoldDays(strategyName)
+
+	plan := &PositionPlan{
+		Symbol:      symbol,
+		Strategy:    strategyName,
+		EntryPrice:  avgCost,
+		Quantity:    quantity,
+		StopLoss:    stopLoss,
+		Target1:     target1,
+		Target2:     target2,
+		Target1Hit:  false,
+		EntryTime:   time.Now(), // 기존 종목은 진입 시점 불명 → 지금부터 카운트
+		MaxHoldDays: maxDays,
+	}
+
+	// Breakout: 돌파 레벨 저장
+	if strategyName == "breakout" {
+		plan.BreakoutLevel = strategy.CalculateHighestHigh(candles, 20)
+	}
+
+	// 3분할 익절이 켜져 있으면 스캔 가이드와 같은 R 배수 목표 + 러너 트레일링
+	if t, ok := tranches.For(strategyName); ok {
+		if t1, t2, t3 := t.Targets(avgCost, stopLoss); t1 > 0 {
+			plan.Target1, plan.Target2, plan.Target3 = t1, t2, t3
+			plan.T1Fraction, plan.T2Fraction = t.T1Fraction, t.T2Fraction
+			if atr := strategy.CalculateATR(candles, 14); atr > 0 {
+				plan.UseTrailingStop = true
+				plan.TrailingATR = atr
+				plan.TrailingMultiplier = t.TrailATR
+			}
+		}
+	}
+
+	return plan
+}
+
+// inferStrategy 현재 기술적 상태로 전략 추정
+func inferStrategy(lastClose, avgCost float64, ind *strategy.Indicators) string {
+	// RSI < 40이고 BB 하단 근처 → mean-reversion
+	if ind.RSI14 < 40 && ind.BBLower > 0 && lastClose < ind.MA20 {
+		return "mean-reversion"
+	}
+
+	// 20일 고점 근처이고 MA50 위 → breakout
+	if ind.MA50 > 0 && lastClose > ind.MA50 && lastClose > ind.MA20*1.02 {
+		return "breakout"
+	}
+
+	// 기본: pullback
+	return "pullback"
+}
+
+// ManualPosition traveler 밖에서 매수한 포지션 등록 정보 (0 값은 자동 산출)
+type ManualPosition struct {
+	Symbol     string
+	Quantity   float64
+	EntryPrice float64
+	Strategy   string // 비우면 일봉 분석으로 추정
+	StopLoss   float64
+	Target1    float64
+	Target2    float64
+	EntryTime  time.Time            // 비우면 지금 (보유기간 카운트 시작)
+	Tranches   strategy.TrancheExit // 3분할 익절 (config trader.tranches)
+}
+
+// BuildManualPlan 수동 포지션 플랜 생성
+// 손절/목표가를 주지 않으면 일봉 분석(PlanFromCandles)으로 채우고,
+// 캔들도 없으면 데몬 fallback과 같은 진입가 -2% / +2% / +4%를 쓴다.
+func BuildManualPlan(mp ManualPosition, candles []model.Candle) (*PositionPlan, error) {
+	symbol := strings.TrimSpace(mp.Symbol)
+	switch {
+	case symbol == "":
+		return nil, fmt.Errorf("symbol is required")
+	case mp.Quantity <= 0:
+		return nil, fmt.Errorf("quantity must be positive")
+	case mp.EntryPrice <= 0:
+		return nil, fmt.Errorf("entry price must be positive")
+	}
+
+	plan := PlanFromCandles(symbol, candles, mp.EntryPrice, mp.Quantity, mp.Strategy, mp.Tranches)
+	if plan == nil {
+		strategyName := mp.Strategy
+		if strategyName == "" {
+			strategyName = "pullback"
+		}
+		plan = &PositionPlan{
+			Symbol:      symbol,
+			Strategy:    strategyName,
+			EntryPrice:  mp.EntryPrice,
+			Quantity:    mp.Quantity,
+			StopLoss:    mp.EntryPrice * 0.98,
+			Target1:     mp.EntryPrice * 1.02,
+			Target2:     mp.EntryPrice * 1.04,
+			EntryTime:   time.Now(),
+			MaxHoldDays: GetMaxHoldDays(strategyName),
+		}
+	}
+	if mp.StopLoss > 0 {
+		plan.StopLoss = mp.StopLoss
+	}
+	if mp.Target1 > 0 {
+		plan.Target1 = mp.Target1
+	}
+	if mp.Target2 > 0 {
+		plan.Target2 = mp.Target2
+	}
+	if plan.Target2 < plan.Target1 {
+		plan.Target2 = plan.Target1
+	}
+	if !mp.EntryTime.IsZero() {
+		plan.EntryTime = mp.EntryTime
+	}
+	plan.Source = PlanSourceManual
+
+	switch {
+	case plan.StopLoss >= plan.EntryPrice:
+		return nil, fmt.Errorf("stop loss %.4g must be below entry %.4g", plan.StopLoss, plan.EntryPrice)
+	case plan.Target1 <= plan.EntryPrice:
+		return nil, fmt.Errorf("target1 %.4g must be above entry %.4g", plan.Target1, plan.EntryPrice)
+	}
+	return plan, nil
+}
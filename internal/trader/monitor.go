@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"traveler/internal/broker"
+	"traveler/internal/fees"
 	"traveler/internal/provider"
+	"traveler/internal/strategy"
 )
 
 // ActivePosition 활성 포지션 (진입 정보 포함)
@@ -19,6 +21,7 @@ type ActivePosition struct {
 	Quantity      float64
 	EntryPrice    float64
 	StopLoss      float64
+	InitialStop   float64 // 진입 시 손절가 (R 배수 계산용)
 	Target1       float64
 	Target2       float64
 	EntryTime     time.Time
@@ -26,6 +29,7 @@ type ActivePosition struct {
 	Strategy      string // 전략 이름
 	MaxHoldDays   int    // 최대 보유 거래일
 	Intraday      bool   // 장중 매매 포지션 (장 마감 전 강제 청산)
+	TradeID       string // 진입-청산 짝 ID (매도 기록에 그대로 남긴다)
 	sellFailCount int    // 매도 실패 횟수 (무한 재시도 방지)
 
 	// Trailing stop (activated after T1 hit)
@@ -33,6 +37,12 @@ type ActivePosition struct {
 	TrailingATR        float64
 	TrailingMultiplier float64
 	HighestSinceT1     float64
+
+	// 3분할 익절 (T2Fraction > 0): T1/T2에서 일부 매도, 러너는 트레일링 또는 T3에서 청산
+	Target3    float64
+	Target2Hit bool
+	T1Fraction float64 // 원래 수량 대비 T1 매도 비율 (0 = 절반)
+	T2Fraction float64 // 원래 수량 대비 T2 매도 비율 (0 = T2에서 전량)
 }
 
 // SellCallback 매도 발생 시 호출되는 콜백 (invested, sold 금액)
@@ -47,7 +57,14 @@ type Monitor struct {
 	history      *TradeHistory
 	market       string // "us" or "kr"
 	onSell       SellCallback
+	onClose      func(TradeRecord) // 청산(전량/분할) 체결 후 호출 — 일일 승/패 집계
 	provider     provider.Provider // ETF 시그널 역전 체크용
+	snapshots    *PositionHistoryStore // 포지션별 P&L 시계열 (nil이면 기록 안 함)
+
+	planModTime  time.Time             // AdoptPlans가 마지막으로 본 plans.json 수정 시각
+	quotes       *quoteGuard           // 시세 조회 실패 백오프/에러 예산
+	onAlert      func(msg string)      // 운영 알림 (시세 장애로 모니터링 중지 등)
+	rebalanceDay map[string]string     // 섹터 로테이션 순위 확인한 날 (종목별 하루 1회)
 
 	mu        sync.RWMutex
 	positions map[string]*ActivePosition
@@ -60,7 +77,10 @@ func NewMonitor(b broker.Broker, executor *Executor, cfg Config, planStore *Plan
 		executor:  executor,
 		config:    cfg,
 		planStore: planStore,
+		quotes:    newQuoteGuard(),
 		positions: make(map[string]*ActivePosition),
+
+		rebalanceDay: make(map[string]string),
 	}
 }
 
@@ -75,11 +95,85 @@ func (m *Monitor) SetProvider(p provider.Provider) {
 	m.provider = p
 }
 
+// SetPositionHistory 시세 조회 시 포지션 스냅샷 기록 (차트용)
+func (m *Monitor) SetPositionHistory(s *PositionHistoryStore) {
+	m.snapshots = s
+}
+
+// SetOnAlert 운영 알림 콜백 설정 (시세 조회 장애로 모니터링 일시 중지/재개)
+func (m *Monitor) SetOnAlert(fn func(msg string)) {
+	m.onAlert = fn
+}
+
+func (m *Monitor) alert(msg string) {
+	log.Printf("[MONITOR] %s", msg)
+	if m.onAlert != nil {
+		m.onAlert(msg)
+	}
+}
+
 // SetOnSell 매도 콜백 설정 (자본 추적용)
 func (m *Monitor) SetOnSell(cb SellCallback) {
 	m.onSell = cb
 }
 
+// SetOnClose 청산 콜백 설정 (체결가 기준 순손익이 담긴 매도 기록, 매매 기록 저장소가 없어도 호출)
+func (m *Monitor) SetOnClose(fn func(TradeRecord)) {
+	m.onClose = fn
+}
+
+// orderIDOf 주문 결과의 주문번호 (결과가 없으면 "")
+func orderIDOf(res *broker.OrderResult) string {
+	if res == nil {
+		return ""
+	}
+	return res.OrderID
+}
+
+// fillPrice 체결 평균가 (브로커가 알려주지 않으면 매도 트리거 시점 가격)
+func fillPrice(res *broker.OrderResult, fallback float64) float64 {
+	if res != nil && res.AvgPrice > 0 {
+		return res.AvgPrice
+	}
+	return fallback
+}
+
+// recordClose 매도 체결 기록 (수수료 포함 순손익) → 매매 기록 + 청산 콜백
+func (m *Monitor) recordClose(symbol string, active *ActivePosition, qty, price float64, reason, orderID string) {
+	if m.history == nil && m.onClose == nil {
+		return
+	}
+	fee := fees.ForMarket(m.market)
+	pnl := qty*(price-active.EntryPrice) - fee.BuyCost(qty*active.EntryPrice) - fee.SellCost(qty*price)
+	pnlPct := 0.0
+	if active.EntryPrice > 0 {
+		pnlPct = pnl / (qty * active.EntryPrice) * 100
+	}
+	rec := TradeRecord{
+		Timestamp:  time.Now(),
+		TradeID:    active.TradeID,
+		Market:     m.market,
+		Symbol:     symbol,
+		Side:       "sell",
+		Quantity:   qty,
+		Price:      price,
+		Strategy:   active.Strategy,
+		Reason:     reason,
+		EntryPrice: active.EntryPrice,
+		PnL:        pnl,
+		PnLPct:     pnlPct,
+		EntryStop:  active.InitialStop,
+		EntryTime:  active.EntryTime,
+		OrderID:    orderID,
+	}
+	if m.history != nil {
+		m.history.Append(rec)
+	}
+	if m.onClose != nil {
+		m.onClose(rec)
+	}
+}
+
 // RegisterPosition 포지션 등록 (진입시 호출)
 func (m *Monitor) RegisterPosition(symbol string, quantity float64, entryPrice, stopLoss, target1, target2 float64) {
 	m.RegisterPositionWithPlan(symbol, quantity, entryPrice, stopLoss, target1, target2, "", 0, time.Time{})
@@ -102,18 +196,111 @@ func (m *Monitor) RegisterPositionWithPlan(symbol string, quantity float64, entr
 		Quantity:    quantity,
 		EntryPrice:  entryPrice,
 		StopLoss:    stopLoss,
+		InitialStop: stopLoss,
 		Target1:     target1,
 		Target2:     target2,
 		EntryTime:   entryTime,
 		Target1Hit:  false,
 		Strategy:    strategy,
 		MaxHoldDays: maxHoldDays,
+		Intraday:    m.config.EODClose.Minutes(strategy) > 0,
+		TradeID:     NewTradeID(symbol, entryTime),
 	}
 
 	log.Printf("[MONITOR] Registered %s: strategy=%s, entry=$%.2f, stop=$%.2f, T1=$%.2f, T2=$%.2f, maxDays=%d",
 		symbol, strategy, entryPrice, stopLoss, target1, target2, maxHoldDays)
 }
 
+// RegisterPlan 저장된 플랜으로 포지션 등록 (트레일링/T1/장중 상태까지 복원)
+func (m *Monitor) RegisterPlan(plan *PositionPlan, quantity float64) {
+	m.RegisterPositionWithPlan(
+		plan.Symbol, quantity, plan.EntryPrice,
+		plan.StopLoss, plan.Target1, plan.Target2,
+		plan.Strategy, plan.MaxHoldDays, plan.EntryTime,
+	)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pos := m.positions[plan.Symbol]
+	if plan.UseTrailingStop {
+		pos.UseTrailingStop = true
+		pos.TrailingATR = plan.TrailingATR
+		pos.TrailingMultiplier = plan.TrailingMultiplier
+		// T1 이미 도달한 포지션은 최고가도 복원
+		pos.HighestSinceT1 = plan.HighestSinceT1
+	}
+	pos.Target1Hit = plan.Target1Hit
+	pos.Target3 = plan.Target3
+	pos.Target2Hit = plan.Target2Hit
+	pos.T1Fraction = plan.T1Fraction
+	pos.T2Fraction = plan.T2Fraction
+	pos.Intraday = m.config.EODClose.Minutes(plan.Strategy) > 0
+	pos.TradeID = plan.GetTradeID()
+	if plan.InitialStop > 0 {
+		pos.InitialStop = plan.InitialStop
+	}
+}
+
+// AdoptPlans 다른 프로세스가 plans.json에 추가한 플랜 반영 (traveler position add, 웹 폼)
+// 파일이 바뀌었을 때만 다시 읽고, 브로커가 실제로 보유한 종목만 등록한다.
+// 이미 감시 중인 포지션은 웹 수동 분할 매도로 줄어든 수량만 맞춘다.
+func (m *Monitor) AdoptPlans(ctx context.Context) int {
+	if m.planStore == nil {
+		return 0
+	}
+	mod := m.planStore.ModTime()
+	if mod.IsZero() || mod.Equal(m.planModTime) {
+		return 0
+	}
+	if err := m.planStore.Reload(); err != nil {
+		log.Printf("[MONITOR] Failed to reload plans: %v", err)
+		return 0
+	}
+	prev := m.planModTime
+	m.planModTime = mod
+
+	m.mu.Lock()
+	var pending []*PositionPlan
+	for _, plan := range m.planStore.GetAll() {
+		pos, ok := m.positions[plan.Symbol]
+		if !ok {
+			pending = append(pending, plan)
+			continue
+		}
+		if plan.Quantity > 0 && plan.Quantity < pos.Quantity {
+			log.Printf("[MONITOR] %s: plan quantity reduced externally %.4g → %.4g", plan.Symbol, pos.Quantity, plan.Quantity)
+			pos.Quantity = plan.Quantity
+		}
+	}
+	m.mu.Unlock()
+	if len(pending) == 0 {
+		return 0
+	}
+
+	positions, err := m.broker.GetPositions(ctx)
+	if err != nil {
+		log.Printf("[MONITOR] Failed to get positions for new plans: %v", err)
+		m.planModTime = prev // 다음 주기 재시도
+		return 0
+	}
+	held := make(map[string]float64, len(positions))
+	for _, p := range positions {
+		held[p.Symbol] = p.Quantity
+	}
+
+	adopted := 0
+	for _, plan := range pending {
+		qty, ok := held[plan.Symbol]
+		if !ok || qty <= 0 {
+			continue // 다른 마켓 플랜 또는 아직 체결 전
+		}
+		m.RegisterPlan(plan, qty)
+		log.Printf("[MONITOR] Adopted plan for %s (source=%s)", plan.Symbol, plan.Source)
+		adopted++
+	}
+	return adopted
+}
+
 // SetTrailingStop 트레일링 스탑 설정 (RegisterPositionWithPlan 이후 호출)
 func (m *Monitor) SetTrailingStop(symbol string, useTrailing bool, atr, multiplier float64) {
 	m.mu.Lock()
@@ -125,6 +312,17 @@ func (m *Monitor) SetTrailingStop(symbol string, useTrailing bool, atr, multipli
 	}
 }
 
+// SetTranches 3분할 익절 설정 (RegisterPositionWithPlan 이후 호출, t2Fraction 0 = 기존 2단계)
+func (m *Monitor) SetTranches(symbol string, target3, t1Fraction, t2Fraction float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if pos, ok := m.positions[symbol]; ok {
+		pos.Target3 = target3
+		pos.T1Fraction = t1Fraction
+		pos.T2Fraction = t2Fraction
+	}
+}
+
 // SetHighestSinceT1 복원용: T1 이후 최고가 설정
 func (m *Monitor) SetHighestSinceT1(symbol string, highest float64) {
 	m.mu.Lock()
@@ -202,19 +400,37 @@ func (m *Monitor) CheckPositions(ctx context.Context) {
 	}
 	m.mu.Unlock()
 
+	// 시세 장애로 에러 예산 초과 → 일시 중지
+	if until, paused := m.quotes.paused(time.Now()); paused {
+		log.Printf("[MONITOR] Paused after sustained quote failures, resuming at %s", until.Format("15:04:05"))
+		return
+	}
+	defer func() {
+		if tripped, failed, total := m.quotes.checkBudget(time.Now()); tripped {
+			m.alert(fmt.Sprintf("⚠️ %s quote failures: %d/%d in the last %s — pausing position monitoring for %s",
+				strings.ToUpper(m.market), failed, total, errorBudgetWindow, errorBudgetPause))
+		}
+	}()
+
 	for symbol, active := range positionsCopy {
-		// 현재가 조회
-		currentPrice, err := m.broker.GetQuote(ctx, symbol)
-		if err != nil {
-			log.Printf("[MONITOR] Error getting quote for %s: %v", symbol, err)
+		// 현재가 조회 (실패한 종목은 지수 백오프, 브로커 실패 시 provider 분봉으로 대체)
+		if !m.quotes.allow(symbol, time.Now()) {
 			continue
 		}
-
-		// 현재가 0이면 조회 실패 - 스킵
-		if currentPrice <= 0 {
-			log.Printf("[MONITOR] Invalid price for %s: $%.2f, skipping", symbol, currentPrice)
+		currentPrice, source, err := m.getQuote(ctx, symbol)
+		if err != nil {
+			wait := m.quotes.failure(symbol, time.Now())
+			log.Printf("[MONITOR] Error getting quote for %s (retry in %s): %v", symbol, wait, err)
 			continue
 		}
+		if m.quotes.success(symbol, time.Now()) {
+			m.alert(fmt.Sprintf("✅ %s quotes recovered — position monitoring resumed", strings.ToUpper(m.market)))
+		}
+		if source != "broker" {
+			log.Printf("[MONITOR] %s: broker quote unavailable, using %s price %.4g", symbol, source, currentPrice)
+		}
+
+		m.snapshots.Record(active, currentPrice, time.Now())
 
 		// 매도 실패가 반복되면 스킵 (sellFailCount 체크)
 		if active.sellFailCount >= 3 {
@@ -284,8 +500,44 @@ func (m *Monitor) CheckPositions(ctx context.Context) {
 
 		// Post-T1: T2 profit taking + trailing stop protection
 		if active.Target1Hit {
-			// T2 check always applies (fixed profit target)
-			if currentPrice >= active.Target2 {
+			if active.T2Fraction > 0 {
+				// 3분할: T2에서 일부만 팔고 러너는 트레일링 스탑 또는 T3로 청산
+				if !active.Target2Hit && currentPrice >= active.Target2 {
+					if active.Quantity <= 1 {
+						log.Printf("[TARGET2] %s hit target2 at $%.2f - no runner left, closing position",
+							symbol, active.Target2)
+						m.executeSell(ctx, symbol, active.Quantity, "target2", currentPrice)
+						continue
+					}
+					sellQty := TrancheQty(active.Quantity, active.T2Fraction/(1-active.T1Fraction))
+					log.Printf("[TARGET2] %s hit target2 at $%.2f - selling %.0f shares, runner %.0f",
+						symbol, active.Target2, sellQty, active.Quantity-sellQty)
+					if !m.sellTranche(ctx, symbol, active, sellQty, "target2", currentPrice) {
+						continue
+					}
+					// 러너 손절은 최소 T1으로 (이후 트레일링이 더 올린다)
+					newStop := math.Max(active.StopLoss, active.Target1)
+					remaining := active.Quantity - sellQty
+					m.mu.Lock()
+					if pos, ok := m.positions[symbol]; ok {
+						pos.Target2Hit = true
+						pos.Quantity = remaining
+						pos.StopLoss = newStop
+					}
+					m.mu.Unlock()
+					if m.planStore != nil {
+						m.planStore.UpdateTarget2Hit(symbol, remaining, newStop)
+					}
+					continue
+				}
+				if active.Target2Hit && active.Target3 > 0 && currentPrice >= active.Target3 {
+					log.Printf("[TARGET3] %s hit target3 at $%.2f - closing runner",
+						symbol, active.Target3)
+					m.executeSell(ctx, symbol, active.Quantity, "target3", currentPrice)
+					continue
+				}
+			} else if currentPrice >= active.Target2 {
+				// T2 check always applies (fixed profit target)
 				log.Printf("[TARGET2] %s hit target2 at $%.2f - closing position",
 					symbol, active.Target2)
 				m.executeSell(ctx, symbol, active.Quantity, "target2", currentPrice)
@@ -324,54 +576,24 @@ func (m *Monitor) CheckPositions(ctx context.Context) {
 			}
 		}
 
-		// Target1 도달 - 절반 청산 (또는 1주면 stop을 본전으로 이동)
+		// Target1 도달 - 절반(3분할이면 T1 비율) 청산 (1주 이하면 전량)
 		if !active.Target1Hit && currentPrice >= active.Target1 {
 			if active.Quantity > 1 {
-				// 2주 이상: 절반 매도
-				halfQty := math.Floor(active.Quantity / 2)
+				// 2주 이상: 절반 (3분할이면 T1 비율) 매도
+				halfQty := TrancheQty(active.Quantity, active.T1Fraction)
 				log.Printf("[TARGET1] %s hit target1 at $%.2f - selling %.0f shares",
 					symbol, active.Target1, halfQty)
 
-				if _, err := m.executor.ExecuteSell(ctx, symbol, halfQty, "target1"); err != nil {
-					log.Printf("[MONITOR] Error selling %s: %v", symbol, err)
-					m.recordSellFailure(symbol)
+				if !m.sellTranche(ctx, symbol, active, halfQty, "target1", currentPrice) {
 					continue
 				}
 
-				// Target1 매도 기록 (수수료 포함 순손익)
-				if m.history != nil {
-					grossPnl := halfQty * (currentPrice - active.EntryPrice)
-					commRate := CommissionRateByMarket(m.market)
-					buyComm := halfQty * active.EntryPrice * commRate
-					sellComm := halfQty * currentPrice * commRate
-					pnl := grossPnl - buyComm - sellComm
-					pnlPct := 0.0
-					if active.EntryPrice > 0 {
-						invested := halfQty * active.EntryPrice
-						pnlPct = pnl / invested * 100
-					}
-					m.history.Append(TradeRecord{
-						Market:     m.market,
-						Symbol:     symbol,
-						Side:       "sell",
-						Quantity:   halfQty,
-						Price:      currentPrice,
-						Strategy:   active.Strategy,
-						Reason:     "target1",
-						EntryPrice: active.EntryPrice,
-						PnL:        pnl,
-						PnLPct:     pnlPct,
-					})
-				}
-				if m.onSell != nil {
-					m.onSell(halfQty*active.EntryPrice, halfQty*currentPrice)
-				}
-
-				// 상태 업데이트
+				// 상태 업데이트 (active는 m.positions와 같은 포인터라 여기서 같이 줄어든다)
+				remaining := active.Quantity - halfQty
 				m.mu.Lock()
 				if pos, ok := m.positions[symbol]; ok {
 					pos.Target1Hit = true
-					pos.Quantity -= halfQty
+					pos.Quantity = remaining
 					pos.StopLoss = pos.EntryPrice // 손절가를 본전으로 이동
 					log.Printf("[MONITOR] %s: moved stop to breakeven ($%.2f), remaining %.0f shares",
 						symbol, pos.StopLoss, pos.Quantity)
@@ -380,7 +602,6 @@ func (m *Monitor) CheckPositions(ctx context.Context) {
 
 				// PlanStore 업데이트
 				if m.planStore != nil {
-					remaining := active.Quantity - halfQty
 					m.planStore.UpdateTarget1Hit(symbol, remaining, active.EntryPrice)
 				}
 			} else {
@@ -397,39 +618,18 @@ func (m *Monitor) CheckPositions(ctx context.Context) {
 				log.Printf("[TARGET1] %s hit target1 at $%.2f - selling all (qty=%.8f)",
 					symbol, active.Target1, sellQty)
 
-				if _, err := m.executor.ExecuteSell(ctx, symbol, sellQty, "target1"); err != nil {
+				res, err := m.executor.ExecuteSell(ctx, symbol, sellQty, "target1")
+				if err != nil {
 					log.Printf("[MONITOR] Error selling %s: %v", symbol, err)
 					m.recordSellFailure(symbol)
 					continue
 				}
+				price := fillPrice(res, currentPrice)
 
-				if m.history != nil {
-					grossPnl := sellQty * (currentPrice - active.EntryPrice)
-					commRate := CommissionRateByMarket(m.market)
-					buyComm := sellQty * active.EntryPrice * commRate
-					sellComm := sellQty * currentPrice * commRate
-					pnl := grossPnl - buyComm - sellComm
-					pnlPct := 0.0
-					if active.EntryPrice > 0 {
-						invested := sellQty * active.EntryPrice
-						pnlPct = pnl / invested * 100
-					}
-					m.history.Append(TradeRecord{
-						Market:     m.market,
-						Symbol:     symbol,
-						Side:       "sell",
-						Quantity:   sellQty,
-						Price:      currentPrice,
-						Strategy:   active.Strategy,
-						Reason:     "target1",
-						EntryPrice: active.EntryPrice,
-						PnL:        pnl,
-						PnLPct:     pnlPct,
-					})
-				}
+				m.recordClose(symbol, active, sellQty, price, "target1", orderIDOf(res))
 
 				if m.onSell != nil {
-					m.onSell(sellQty*active.EntryPrice, sellQty*currentPrice)
+					m.onSell(sellQty*active.EntryPrice, sellQty*price)
 				}
 
 				m.mu.Lock()
@@ -450,6 +650,13 @@ func (m *Monitor) CheckPositions(ctx context.Context) {
 			}
 		}
 
+		// 섹터 로테이션 리밸런싱: 이번 달 상위 N 섹터에서 밀려나면 청산 (새 상위 섹터는 다음 스캔에서 매수)
+		if m.provider != nil && active.Strategy == "sector-rotation" {
+			if m.checkSectorRebalance(ctx, symbol, active, currentPrice) {
+				continue
+			}
+		}
+
 		// Time stop: 최대 보유일 초과
 		if active.MaxHoldDays > 0 && !active.EntryTime.IsZero() {
 			// 크립토는 주말 포함 달력일 기준, 주식은 거래일 기준
@@ -533,6 +740,37 @@ func (m *Monitor) checkETFSignalReversal(ctx context.Context, symbol string, act
 	return false
 }
 
+// checkSectorRebalance 보유 섹터 ETF가 월간 순위 상위 N에서 빠졌으면 매도 (종목별 하루 1회 확인)
+// 순위는 한 달 동안 고정이라 같은 날 다시 볼 필요가 없다. 매도했으면 true.
+func (m *Monitor) checkSectorRebalance(ctx context.Context, symbol string, active *ActivePosition, currentPrice float64) bool {
+	today := time.Now().Format("2006-01-02")
+	m.mu.Lock()
+	if m.rebalanceDay[symbol] == today {
+		m.mu.Unlock()
+		return false
+	}
+	m.rebalanceDay[symbol] = today
+	m.mu.Unlock()
+
+	cfg := strategy.CurrentSectorRotationConfig()
+	ranking, err := strategy.SectorRanking(ctx, m.provider, cfg, time.Now())
+	if err != nil {
+		log.Printf("[REBALANCE] %s: sector ranking unavailable: %v", symbol, err)
+		return false
+	}
+	rank, ok := strategy.InTopSectors(ranking, cfg, symbol)
+	if ok {
+		return false
+	}
+
+	pnlPct := (currentPrice - active.EntryPrice) / active.EntryPrice * 100
+	reason := fmt.Sprintf("rebalance: %s dropped out of top %d sectors (rank %d, momentum %.1f%%, P&L: %.1f%%)",
+		symbol, cfg.TopN, rank.Rank, rank.Score, pnlPct)
+	log.Printf("[REBALANCE] %s", reason)
+	m.executeSell(ctx, symbol, active.Quantity, reason, currentPrice)
+	return true
+}
+
 // recordSellFailure 매도 실패 카운트 증가
 func (m *Monitor) recordSellFailure(symbol string) {
 	m.mu.Lock()
@@ -560,37 +798,17 @@ func (m *Monitor) executeSell(ctx context.Context, symbol string, quantity float
 		return
 	}
 
-	_, err := m.executor.ExecuteSell(ctx, symbol, sellQty, reason)
+	res, err := m.executor.ExecuteSell(ctx, symbol, sellQty, reason)
 	if err != nil {
 		log.Printf("[MONITOR] Error selling %s: %v", symbol, err)
 		m.recordSellFailure(symbol)
 		return
 	}
+	exitPrice = fillPrice(res, exitPrice)
 
-	// 매매 기록 저장 (수수료 포함 순손익)
-	if m.history != nil && hasActive {
-		grossPnl := sellQty * (exitPrice - active.EntryPrice)
-		commRate := CommissionRateByMarket(m.market)
-		buyComm := sellQty * active.EntryPrice * commRate
-		sellComm := sellQty * exitPrice * commRate
-		pnl := grossPnl - buyComm - sellComm
-		pnlPct := 0.0
-		if active.EntryPrice > 0 {
-			invested := sellQty * active.EntryPrice
-			pnlPct = pnl / invested * 100
-		}
-		m.history.Append(TradeRecord{
-			Market:     m.market,
-			Symbol:     symbol,
-			Side:       "sell",
-			Quantity:   sellQty,
-			Price:      exitPrice,
-			Strategy:   active.Strategy,
-			Reason:     reason,
-			EntryPrice: active.EntryPrice,
-			PnL:        pnl,
-			PnLPct:     pnlPct,
-		})
+	// 매매 기록 저장 (체결가 기준 수수료 포함 순손익)
+	if hasActive {
+		m.recordClose(symbol, active, sellQty, exitPrice, reason, orderIDOf(res))
 	}
 
 	// 자본 추적 콜백
@@ -610,6 +828,40 @@ func (m *Monitor) executeSell(ctx context.Context, symbol string, quantity float
 	log.Printf("[MONITOR] Closed position %s (%s)", symbol, reason)
 }
 
+// TrancheQty 분할 매도 수량 (모니터와 시뮬레이터 공용): 보유 수량 × fraction을 정수 주로 내림 (최소 1주, 2주 이상이면 1주는 남김)
+// fraction 0 = 절반
+func TrancheQty(quantity, fraction float64) float64 {
+	if fraction <= 0 {
+		fraction = 0.5
+	}
+	qty := math.Floor(quantity * fraction)
+	if qty < 1 {
+		qty = 1
+	}
+	if quantity >= 2 && qty > quantity-1 {
+		qty = math.Floor(quantity - 1)
+	}
+	return qty
+}
+
+// sellTranche 포지션 일부 매도 + 매매 기록/자본 콜백 (포지션·플랜 상태 갱신은 호출자가). 실패하면 false
+func (m *Monitor) sellTranche(ctx context.Context, symbol string, active *ActivePosition, qty float64, reason string, price float64) bool {
+	res, err := m.executor.ExecuteSell(ctx, symbol, qty, reason)
+	if err != nil {
+		log.Printf("[MONITOR] Error selling %s: %v", symbol, err)
+		m.recordSellFailure(symbol)
+		return false
+	}
+	price = fillPrice(res, price)
+
+	// 분할 매도 기록 (체결가 기준 수수료 포함 순손익)
+	m.recordClose(symbol, active, qty, price, reason, orderIDOf(res))
+	if m.onSell != nil {
+		m.onSell(qty*active.EntryPrice, qty*price)
+	}
+	return true
+}
+
 // ClosePosition 외부에서 호출 가능한 포지션 청산 (전략 무효화 등)
 func (m *Monitor) ClosePosition(ctx context.Context, symbol string, reason string) error {
 	m.mu.RLock()
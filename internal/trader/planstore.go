@@ -2,12 +2,15 @@ package trader
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
+
+	"traveler/internal/fsutil"
 )
 
 // PositionPlan stores the original trading plan for a position
@@ -17,6 +20,7 @@ type PositionPlan struct {
 	EntryPrice  float64   `json:"entry_price"`
 	Quantity    float64   `json:"quantity"`
 	StopLoss    float64   `json:"stop_loss"`
+	InitialStop float64   `json:"initial_stop,omitempty"` // 진입 시 손절가 (R 배수 기준, 손절 이동과 무관)
 	Target1     float64   `json:"target1"`
 	Target2     float64   `json:"target2"`
 	Target1Hit  bool      `json:"target1_hit"`
@@ -29,9 +33,61 @@ type PositionPlan struct {
 	TrailingMultiplier float64 `json:"trailing_multiplier,omitempty"` // ATR × N
 	HighestSinceT1     float64 `json:"highest_since_t1,omitempty"`   // Highest price since T1
 
+	// 3분할 익절 (T2Fraction > 0이면 T2에서 일부만 매도하고 러너를 남김)
+	Target3    float64 `json:"target3,omitempty"`     // 러너 목표 (0 = 트레일링만)
+	Target2Hit bool    `json:"target2_hit,omitempty"`
+	T1Fraction float64 `json:"t1_fraction,omitempty"` // 원래 수량 대비 T1 매도 비율
+	T2Fraction float64 `json:"t2_fraction,omitempty"` // 원래 수량 대비 T2 매도 비율
+
+	// 분할 매도 이력 (웹 포지션 화면 표시용)
+	SoldT1     float64 `json:"sold_t1,omitempty"`
+	SoldT2     float64 `json:"sold_t2,omitempty"`
+	SoldManual float64 `json:"sold_manual,omitempty"` // 웹/수동 분할 매도 누계
+
 	// Strategy invalidation fields
 	BreakoutLevel        float64 `json:"breakout_level,omitempty"`         // breakout: 20D high at entry
 	ConsecutiveDaysBelow int     `json:"consecutive_days_below,omitempty"` // pullback: days close < MA20
+
+	// Dividend awareness (stocks only)
+	ExDividendDate string  `json:"ex_dividend_date,omitempty"` // next ex-dividend date (2006-01-02)
+	DividendAmount float64 `json:"dividend_amount,omitempty"`  // per-share amount (last paid if projected)
+
+	Source string `json:"source,omitempty"` // PlanSourceManual = traveler 밖에서 매수 후 수동 등록
+
+	TradeID string `json:"trade_id,omitempty"` // 진입-청산 짝 ID (매수/매도 기록 연결)
+}
+
+// PlanSourceManual 수동 등록 플랜 (traveler position add / 웹 폼)
+const PlanSourceManual = "manual"
+
+// GetTradeID 진입-청산 짝 ID (ID 도입 전 플랜은 진입 시각으로 만든다)
+func (p *PositionPlan) GetTradeID() string {
+	if p.TradeID != "" {
+		return p.TradeID
+	}
+	return NewTradeID(p.Symbol, p.EntryTime)
+}
+
+// PlannedExitDate 최대 보유기간 만료일 (진입일 + MaxHoldDays 거래일)
+func (p *PositionPlan) PlannedExitDate() time.Time {
+	d := time.Date(p.EntryTime.Year(), p.EntryTime.Month(), p.EntryTime.Day(), 0, 0, 0, 0, p.EntryTime.Location())
+	for n := 0; n < p.MaxHoldDays; {
+		d = d.AddDate(0, 0, 1)
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			n++
+		}
+	}
+	return d
+}
+
+// CrossesExDividend 남은 보유기간 중 배당락일이 있는지 (now 이후 ~ 만료일)
+// 배당락일에 주가가 배당금만큼 갭다운하므로 손절/목표가 판단 시 주의가 필요하다.
+func (p *PositionPlan) CrossesExDividend(now time.Time) bool {
+	if p.ExDividendDate == "" {
+		return false
+	}
+	today := now.Format("2006-01-02")
+	return p.ExDividendDate >= today && p.ExDividendDate <= p.PlannedExitDate().Format("2006-01-02")
 }
 
 // MaxHoldDays per strategy
@@ -46,6 +102,9 @@ var strategyMaxHoldDays = map[string]int{
 	"range-trading":       5,
 	"rsi-contrarian":      5,
 	"volume-spike":        3,
+	"bb-squeeze":          10,
+	"pairs":               20, // 스프레드 반감기 상한(20일) 안에 회귀하지 않으면 정리
+	"sector-rotation":     63, // 월간 리밸런싱이 주 청산, time stop은 ~3개월 안전망
 	"wbottom":             15, // W-Bottom: pattern completion ~15 calendar days
 	"etf-momentum":       25, // ETF monthly rotation (~1 month trading days)
 	"crypto-trend":       60, // BTC trend following (weeks to months)
@@ -70,7 +129,11 @@ func GetMaxHoldDays(strategy string) int {
 // TradingDaysSince counts weekday days between entry date and today (date-based, not time-based).
 // Same day always returns 0. Day 1 starts at midnight.
 func TradingDaysSince(entry time.Time) int {
-	now := time.Now()
+	return TradingDaysBetween(entry, time.Now())
+}
+
+// TradingDaysBetween counts weekday days from entry date to now's date (same day = 0).
+func TradingDaysBetween(entry, now time.Time) int {
 	entryDate := time.Date(entry.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, entry.Location())
 	nowDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
@@ -135,13 +198,15 @@ func NewPlanStore(dir string) (*PlanStore, error) {
 
 // Save stores a position plan
 func (ps *PlanStore) Save(plan *PositionPlan) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	ps.plans[plan.Symbol] = plan
 	log.Printf("[PLANSTORE] Saved plan for %s (strategy=%s, stop=$%.2f, T1=$%.2f, T2=$%.2f, maxDays=%d)",
 		plan.Symbol, plan.Strategy, plan.StopLoss, plan.Target1, plan.Target2, plan.MaxHoldDays)
-	return ps.persist()
+	if plan.InitialStop == 0 {
+		plan.InitialStop = plan.StopLoss
+	}
+	return ps.update(func() bool {
+		ps.plans[plan.Symbol] = plan
+		return true
+	})
 }
 
 // Get retrieves a plan by symbol
@@ -164,82 +229,176 @@ func (ps *PlanStore) GetAll() []*PositionPlan {
 
 // Delete removes a plan
 func (ps *PlanStore) Delete(symbol string) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	if _, ok := ps.plans[symbol]; ok {
+	return ps.update(func() bool {
+		if _, ok := ps.plans[symbol]; !ok {
+			return false
+		}
 		delete(ps.plans, symbol)
 		log.Printf("[PLANSTORE] Deleted plan for %s", symbol)
-		return ps.persist()
-	}
-	return nil
+		return true
+	})
 }
 
 // UpdateTarget1Hit marks target1 as hit and updates quantity
 func (ps *PlanStore) UpdateTarget1Hit(symbol string, remainingQty float64, newStopLoss float64) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	if plan, ok := ps.plans[symbol]; ok {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
 		plan.Target1Hit = true
+		if plan.Quantity > remainingQty {
+			plan.SoldT1 = plan.Quantity - remainingQty
+		}
 		plan.Quantity = remainingQty
 		plan.StopLoss = newStopLoss
 		log.Printf("[PLANSTORE] Updated %s: target1 hit, qty=%.0f, new stop=$%.2f",
 			symbol, remainingQty, newStopLoss)
-		return ps.persist()
-	}
-	return nil
+		return true
+	})
+}
+
+// UpdateTarget2Hit marks target2 as hit (tranche mode) and updates the runner quantity and stop
+func (ps *PlanStore) UpdateTarget2Hit(symbol string, remainingQty float64, newStopLoss float64) error {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
+		plan.Target2Hit = true
+		if plan.Quantity > remainingQty {
+			plan.SoldT2 = plan.Quantity - remainingQty
+		}
+		plan.Quantity = remainingQty
+		plan.StopLoss = newStopLoss
+		log.Printf("[PLANSTORE] Updated %s: target2 hit, runner qty=%.0f, new stop=$%.2f",
+			symbol, remainingQty, newStopLoss)
+		return true
+	})
+}
+
+// RecordManualSell records a manual partial sell (web "sell half now") and the remaining quantity
+func (ps *PlanStore) RecordManualSell(symbol string, soldQty, remainingQty float64) error {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
+		plan.SoldManual += soldQty
+		plan.Quantity = remainingQty
+		log.Printf("[PLANSTORE] Updated %s: manual sell %.4g, remaining qty=%.4g", symbol, soldQty, remainingQty)
+		return true
+	})
 }
 
 // UpdateTrailingStop updates trailing stop state (HighestSinceT1 and StopLoss)
 func (ps *PlanStore) UpdateTrailingStop(symbol string, highestSinceT1, newStopLoss float64) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	if plan, ok := ps.plans[symbol]; ok {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
 		plan.HighestSinceT1 = highestSinceT1
 		plan.StopLoss = newStopLoss
-		return ps.persist()
-	}
-	return nil
+		return true
+	})
 }
 
 // UpdateStopLoss updates only the stop loss price (for breakeven stop)
 func (ps *PlanStore) UpdateStopLoss(symbol string, newStopLoss float64) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	if plan, ok := ps.plans[symbol]; ok {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
 		plan.StopLoss = newStopLoss
-		return ps.persist()
-	}
-	return nil
+		return true
+	})
 }
 
 // UpdateConsecutiveDaysBelow updates the consecutive days below counter
 func (ps *PlanStore) UpdateConsecutiveDaysBelow(symbol string, days int) error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	if plan, ok := ps.plans[symbol]; ok {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
 		plan.ConsecutiveDaysBelow = days
-		return ps.persist()
-	}
-	return nil
+		return true
+	})
+}
+
+// UpdateDividend updates the next ex-dividend date and per-share amount
+func (ps *PlanStore) UpdateDividend(symbol, exDate string, amount float64) error {
+	return ps.update(func() bool {
+		plan, ok := ps.plans[symbol]
+		if !ok {
+			return false
+		}
+		if plan.ExDividendDate == exDate && plan.DividendAmount == amount {
+			return false
+		}
+		plan.ExDividendDate = exDate
+		plan.DividendAmount = amount
+		return true
+	})
 }
 
 // Reload re-reads plans from disk (for cross-process freshness)
 func (ps *PlanStore) Reload() error {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
+	return ps.reload()
+}
 
-	ps.plans = make(map[string]*PositionPlan)
-	if err := ps.load(); err != nil && !os.IsNotExist(err) {
+// reload 디스크 내용으로 교체 (파일이 없으면 비움, 읽기/파싱 실패면 메모리 그대로 두고 에러)
+func (ps *PlanStore) reload() error {
+	plans := make(map[string]*PositionPlan)
+	data, err := os.ReadFile(ps.filepath)
+	if err != nil && !os.IsNotExist(err) {
 		return err
 	}
+	if err == nil {
+		if err := json.Unmarshal(data, &plans); err != nil {
+			return err
+		}
+	}
+	if plans == nil {
+		plans = make(map[string]*PositionPlan)
+	}
+	ps.plans = plans
 	return nil
 }
 
+// update applies fn to the latest on-disk plans under the cross-process file lock
+// and persists if fn reports a change. plans.json is shared by every daemon,
+// the web server and the CLI, so each writer must merge into the current file
+// instead of overwriting it with its own (possibly stale) in-memory copy.
+func (ps *PlanStore) update(fn func() bool) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	return fsutil.Update(ps.filepath, func() error {
+		// 읽을 수 없는 파일을 덮어쓰면 다른 포지션의 손절/목표가가 모두 사라진다
+		if err := ps.reload(); err != nil {
+			return fmt.Errorf("reload %s: %w", filepath.Base(ps.filepath), err)
+		}
+		if !fn() {
+			return nil
+		}
+		return ps.persist()
+	})
+}
+
+// ModTime returns the plans file modification time (zero if missing)
+func (ps *PlanStore) ModTime() time.Time {
+	info, err := os.Stat(ps.filepath)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
 // All returns all plans
 func (ps *PlanStore) All() map[string]*PositionPlan {
 	ps.mu.RLock()
@@ -266,5 +425,5 @@ func (ps *PlanStore) persist() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(ps.filepath, data, 0644)
+	return fsutil.WriteFile(ps.filepath, data, 0644)
 }
@@ -0,0 +1,43 @@
+package trader
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPlanStoreSaveRefusesCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	ps, err := NewPlanStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	if err := ps.Save(&PositionPlan{Symbol: "AAPL", EntryPrice: 100, StopLoss: 90, Quantity: 10, EntryTime: entry}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 다른 프로세스가 쓰다 만 파일 (torn write)
+	path := filepath.Join(dir, "plans.json")
+	corrupt := []byte(`{"AAPL": {"symbol": "AAPL", "entry_pri`)
+	if err := os.WriteFile(path, corrupt, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ps.Save(&PositionPlan{Symbol: "MSFT", EntryPrice: 300, StopLoss: 280, Quantity: 1, EntryTime: entry}); err == nil {
+		t.Fatal("Save over corrupt plans.json: err = nil, want reload error")
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, corrupt) {
+		t.Errorf("plans.json rewritten to %q, want it left unchanged", got)
+	}
+	// 메모리 캐시도 비우지 않는다
+	if ps.Get("AAPL") == nil {
+		t.Error("in-memory AAPL plan dropped after failed reload")
+	}
+}
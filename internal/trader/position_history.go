@@ -0,0 +1,156 @@
+package trader
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/fsutil"
+)
+
+const (
+	// DefaultSnapshotInterval 스냅샷 최소 간격 (모니터 주기가 더 짧아도 이 간격으로만 기록)
+	DefaultSnapshotInterval = 5 * time.Minute
+	// maxPositionSnapshots 포지션당 보관 스냅샷 수 (5분 간격 ≈ 장중 약 25거래일)
+	maxPositionSnapshots = 2000
+)
+
+// PositionSnapshot 포지션 시점별 가격/손익 + 당시 손절/목표가
+type PositionSnapshot struct {
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	PnL      float64   `json:"pnl"`     // 미실현 손익 (수수료 제외)
+	PnLPct   float64   `json:"pnl_pct"` // 진입가 대비 %
+	StopLoss float64   `json:"stop_loss"`
+	Target1  float64   `json:"target1"`
+	Target2  float64   `json:"target2,omitempty"`
+}
+
+// PositionSeries 포지션 하나의 진입 이후 스냅샷 시계열
+type PositionSeries struct {
+	Symbol     string             `json:"symbol"`
+	Strategy   string             `json:"strategy,omitempty"`
+	EntryPrice float64            `json:"entry_price"`
+	Quantity   float64            `json:"quantity"`
+	EntryTime  time.Time          `json:"entry_time"`
+	Snapshots  []PositionSnapshot `json:"snapshots"`
+}
+
+// PositionHistoryStore 포지션별 P&L 시계열 저장소 ({dir}/position_history/{SYMBOL}.json)
+type PositionHistoryStore struct {
+	dir      string
+	interval time.Duration
+
+	mu   sync.Mutex
+	last map[string]time.Time // 심볼별 마지막 기록 시각 (쓰기 빈도 제한)
+}
+
+// NewPositionHistoryStore 생성자 (dataDir 아래 position_history/ 사용)
+func NewPositionHistoryStore(dataDir string) *PositionHistoryStore {
+	return &PositionHistoryStore{
+		dir:      filepath.Join(dataDir, "position_history"),
+		interval: DefaultSnapshotInterval,
+		last:     make(map[string]time.Time),
+	}
+}
+
+// SetInterval 스냅샷 최소 간격 변경 (0 이하면 매 호출 기록)
+func (s *PositionHistoryStore) SetInterval(d time.Duration) {
+	s.mu.Lock()
+	s.interval = d
+	s.mu.Unlock()
+}
+
+// Record 모니터 시세 조회 시 호출. 간격 안이면 무시.
+// 같은 심볼이라도 진입 시각이 다르면(재진입) 이전 시계열을 버리고 새로 시작한다.
+func (s *PositionHistoryStore) Record(p *ActivePosition, price float64, now time.Time) {
+	if s == nil || p == nil || price <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.last[p.Symbol]; ok && now.Sub(last) < s.interval {
+		return
+	}
+
+	series, err := s.load(p.Symbol)
+	if err != nil || !series.EntryTime.Equal(p.EntryTime) {
+		series = &PositionSeries{Symbol: p.Symbol, EntryTime: p.EntryTime}
+	}
+	series.Strategy = p.Strategy
+	series.EntryPrice = p.EntryPrice
+	series.Quantity = p.Quantity
+
+	snap := PositionSnapshot{
+		Time:     now,
+		Price:    price,
+		PnL:      (price - p.EntryPrice) * p.Quantity,
+		StopLoss: p.StopLoss,
+		Target1:  p.Target1,
+		Target2:  p.Target2,
+	}
+	if p.EntryPrice > 0 {
+		snap.PnLPct = (price - p.EntryPrice) / p.EntryPrice * 100
+	}
+	series.Snapshots = append(series.Snapshots, snap)
+	if n := len(series.Snapshots); n > maxPositionSnapshots {
+		series.Snapshots = series.Snapshots[n-maxPositionSnapshots:]
+	}
+
+	if err := s.save(series); err != nil {
+		log.Printf("[MONITOR] Failed to save position history for %s: %v", p.Symbol, err)
+		return
+	}
+	s.last[p.Symbol] = now
+}
+
+// Load 심볼의 시계열 (없으면 os.ErrNotExist)
+func (s *PositionHistoryStore) Load(symbol string) (*PositionSeries, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(symbol)
+}
+
+func (s *PositionHistoryStore) path(symbol string) (string, error) {
+	if symbol == "" || strings.ContainsAny(symbol, `/\`) || strings.Contains(symbol, "..") {
+		return "", fmt.Errorf("invalid symbol %q", symbol)
+	}
+	return filepath.Join(s.dir, symbol+".json"), nil
+}
+
+func (s *PositionHistoryStore) load(symbol string) (*PositionSeries, error) {
+	path, err := s.path(symbol)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var series PositionSeries
+	if err := json.Unmarshal(data, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (s *PositionHistoryStore) save(series *PositionSeries) error {
+	path, err := s.path(series.Symbol)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(series, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(path, data, 0644)
+}
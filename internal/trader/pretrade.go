@@ -0,0 +1,262 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/money"
+	"traveler/internal/strategy"
+	"traveler/internal/symbols"
+)
+
+// PreTradeRequest 주문 직전 검사 입력
+type PreTradeRequest struct {
+	Order  broker.Order
+	Signal *strategy.Signal // 시그널 기반 매수만 (없으면 nil)
+	DryRun bool
+}
+
+// PreTradeCheck 주문 직전 검사 하나. nil이면 허용, 에러면 거부 (에러 메시지가 거부 사유)
+// 장 운영, 실적 발표, 블랙리스트, 리스크 총량, 중복, 시세 확인을 같은 인터페이스로 묶어 순서대로 돌린다.
+type PreTradeCheck interface {
+	Name() string
+	Check(ctx context.Context, req PreTradeRequest) error
+}
+
+// PreTradeDecision 검사 결과 (거부 시 주문 의도 저널에 기록)
+type PreTradeDecision struct {
+	Check   string `json:"check"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// runPreTradeChecks 검사를 순서대로 실행해 첫 거부에서 멈춤. 전부 통과하면 denied = nil
+func runPreTradeChecks(ctx context.Context, checks []PreTradeCheck, req PreTradeRequest) (decisions []PreTradeDecision, denied *PreTradeDecision) {
+	for _, c := range checks {
+		d := PreTradeDecision{Check: c.Name(), Allowed: true}
+		if err := c.Check(ctx, req); err != nil {
+			d.Allowed = false
+			d.Reason = err.Error()
+		}
+		decisions = append(decisions, d)
+		if !d.Allowed {
+			return decisions, &decisions[len(decisions)-1]
+		}
+	}
+	return decisions, nil
+}
+
+// checkFunc 이름 붙은 검사 함수 (Executor 기본 검사용)
+type checkFunc struct {
+	name string
+	fn   func(ctx context.Context, req PreTradeRequest) error
+}
+
+func (c checkFunc) Name() string { return c.name }
+
+func (c checkFunc) Check(ctx context.Context, req PreTradeRequest) error { return c.fn(ctx, req) }
+
+// builtinChecks Executor 기본 검사: 금액 한도(dry-run 포함), 시세 확인, 브로커 미체결 중복 (실주문만)
+func (e *Executor) builtinChecks() []PreTradeCheck {
+	return []PreTradeCheck{
+		checkFunc{"value_caps", func(ctx context.Context, req PreTradeRequest) error {
+			return e.checkValueCaps(ctx, req.Order)
+		}},
+		checkFunc{"price_sanity", func(ctx context.Context, req PreTradeRequest) error {
+			if req.DryRun {
+				return nil
+			}
+			return e.checkPrice(ctx, req.Order)
+		}},
+		checkFunc{"duplicate", func(ctx context.Context, req PreTradeRequest) error {
+			if req.DryRun {
+				return nil
+			}
+			if reason := e.duplicateReason(ctx, req.Order); reason != "" {
+				return fmt.Errorf("duplicate order: %s", reason)
+			}
+			return nil
+		}},
+	}
+}
+
+// MarketOpenCheck 장이 닫혀 있으면 매수 거부 (dry-run 제외)
+type MarketOpenCheck struct {
+	Status func() (open bool, reason string)
+}
+
+func (MarketOpenCheck) Name() string { return "market_open" }
+
+func (c MarketOpenCheck) Check(_ context.Context, req PreTradeRequest) error {
+	if req.DryRun || req.Order.Side != broker.OrderSideBuy || c.Status == nil {
+		return nil
+	}
+	if open, reason := c.Status(); !open {
+		return fmt.Errorf("market closed (%s)", reason)
+	}
+	return nil
+}
+
+// BlacklistCheck 블랙/화이트리스트 재확인 (스캔 후 목록이 바뀌었거나 외부 시그널 가져오기)
+// 매번 디스크에서 다시 읽어 CLI/웹에서 방금 추가한 항목도 반영한다.
+type BlacklistCheck struct {
+	Lists *symbols.SymbolLists
+}
+
+func (BlacklistCheck) Name() string { return "blacklist" }
+
+func (c BlacklistCheck) Check(_ context.Context, req PreTradeRequest) error {
+	if c.Lists == nil || req.Order.Side != broker.OrderSideBuy {
+		return nil
+	}
+	if err := c.Lists.Reload(); err != nil && !os.IsNotExist(err) {
+		log.Printf("[PRETRADE] symbol lists reload failed: %v", err)
+	}
+	if ok, reason := c.Lists.IsAllowed(req.Order.Symbol); !ok {
+		return fmt.Errorf("%s", reason)
+	}
+	return nil
+}
+
+// PositionSizeCheck 수동 매수 금액이 종목당 사이징 한도(자본 × MaxPct)를 넘으면 거부
+// 시그널 매수는 사이저가 수량을 정하지만 수동 주문은 수량을 그대로 받으므로 같은 한도를 여기서 건다.
+// 수량만 준 시장가 매수는 현재가로 금액을 추정한다 (조회 실패 시 거부).
+type PositionSizeCheck struct {
+	Quote   func(ctx context.Context, symbol string) (float64, error)
+	Capital float64
+	MaxPct  float64
+}
+
+func (PositionSizeCheck) Name() string { return "position_size" }
+
+func (c PositionSizeCheck) Check(ctx context.Context, req PreTradeRequest) error {
+	if req.Order.Side != broker.OrderSideBuy || c.Capital <= 0 || c.MaxPct <= 0 {
+		return nil
+	}
+	value := orderValue(req.Order)
+	if value <= 0 && c.Quote != nil {
+		price, err := c.Quote(ctx, req.Order.Symbol)
+		if err != nil || price <= 0 {
+			return fmt.Errorf("cannot size order: quote unavailable (%v)", err)
+		}
+		value = req.Order.Quantity * price
+	}
+	cur := money.ForSymbol(req.Order.Symbol)
+	if limit := c.Capital * c.MaxPct; value > limit {
+		return fmt.Errorf("order value %s exceeds position limit %s (%.0f%% of %s)",
+			money.Format(value, cur), money.Format(limit, cur), c.MaxPct*100, money.Format(c.Capital, cur))
+	}
+	return nil
+}
+
+// EarningsSource 다음 실적 발표일 조회 (provider.FundamentalsChecker)
+type EarningsSource interface {
+	NextEarningsDate(ctx context.Context, symbol string) (time.Time, error)
+}
+
+// EarningsBlackoutCheck 실적 발표 Days일 이내면 매수 거부 (조회 실패는 통과, 크립토 제외)
+type EarningsBlackoutCheck struct {
+	Source EarningsSource
+	Days   int
+	Now    func() time.Time // nil이면 time.Now
+}
+
+func (EarningsBlackoutCheck) Name() string { return "earnings_blackout" }
+
+func (c EarningsBlackoutCheck) Check(ctx context.Context, req PreTradeRequest) error {
+	if c.Source == nil || c.Days <= 0 || req.Order.Side != broker.OrderSideBuy || symbols.IsCryptoSymbol(req.Order.Symbol) {
+		return nil
+	}
+	return c.checkSymbol(ctx, req.Order.Symbol)
+}
+
+// checkSymbol 종목 하나의 블랙아웃 확인 (시그널 필터 earnings_window와 공유, 조회 실패는 통과)
+func (c EarningsBlackoutCheck) checkSymbol(ctx context.Context, symbol string) error {
+	date, err := c.Source.NextEarningsDate(ctx, symbol)
+	if err != nil {
+		log.Printf("[PRETRADE] %s: earnings date unavailable, not blocking: %v", symbol, err)
+		return nil
+	}
+	now := time.Now()
+	if c.Now != nil {
+		now = c.Now()
+	}
+	if date.IsZero() || date.Before(now.Truncate(24*time.Hour)) {
+		return nil
+	}
+	if days := date.Sub(now).Hours() / 24; days <= float64(c.Days) {
+		return fmt.Errorf("earnings on %s (within %d-day blackout)", date.Format("2006-01-02"), c.Days)
+	}
+	return nil
+}
+
+// HeatCapCheck 보유 플랜 오픈 리스크 + 신규 주문 리스크가 자본 대비 MaxHeat를 넘으면 거부
+// 오픈 리스크 = 수량 × (진입가 - 손절가), 손절이 본전 이상으로 올라간 포지션은 0.
+// plans.json은 US/KR/크립토 공용이라 Market의 플랜만 합산한다 (통화가 다른 리스크를 더하지 않도록).
+type HeatCapCheck struct {
+	Plans   *PlanStore
+	Market  string         // 합산할 플랜 마켓 (PlanMarket 기준, 비우면 주문 종목의 마켓)
+	Capital func() float64 // 현재 자본 (실시간 잔고, 0 이하면 검사 생략)
+	MaxHeat float64        // 예: 0.06 = 자본의 6%
+}
+
+func (HeatCapCheck) Name() string { return "heat_cap" }
+
+func (c HeatCapCheck) Check(_ context.Context, req PreTradeRequest) error {
+	if c.MaxHeat <= 0 || c.Capital == nil || req.Order.Side != broker.OrderSideBuy {
+		return nil
+	}
+	if req.Signal == nil || req.Signal.Guide == nil {
+		return nil
+	}
+	capital := c.Capital()
+	if capital <= 0 {
+		return nil
+	}
+	g := req.Signal.Guide
+	qty := req.Order.Quantity
+	if qty <= 0 && g.EntryPrice > 0 {
+		qty = orderValue(req.Order) / g.EntryPrice
+	}
+	newRisk := qty * (g.EntryPrice - g.StopLoss)
+	if newRisk < 0 {
+		newRisk = 0
+	}
+	market := c.Market
+	if market == "" {
+		market = PlanMarket(req.Order.Symbol)
+	}
+	// 자본은 마켓 통화 — 도쿄/홍콩/중국 종목 리스크는 환율 없이 비교할 수 없으므로 검사하지 않는다
+	// (해당 주문은 ValueCaps의 현지 통화 한도로 막는다)
+	cur := money.ForSymbol(req.Order.Symbol)
+	if cur != money.ForMarket(market) {
+		return nil
+	}
+	var open float64
+	if c.Plans != nil {
+		for _, p := range c.Plans.GetAll() {
+			if PlanMarket(p.Symbol) == market && money.ForSymbol(p.Symbol) == cur && p.StopLoss < p.EntryPrice {
+				open += p.Quantity * (p.EntryPrice - p.StopLoss)
+			}
+		}
+	}
+	if limit := capital * c.MaxHeat; open+newRisk > limit {
+		return fmt.Errorf("portfolio heat %s + %s exceeds cap %s (%.1f%% of capital)",
+			money.Format(open, cur), money.Format(newRisk, cur), money.Format(limit, cur), c.MaxHeat*100)
+	}
+	return nil
+}
+
+// describeChecks 검사 이름 목록 (로그용)
+func describeChecks(checks []PreTradeCheck) string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = c.Name()
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,78 @@
+package trader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/strategy"
+)
+
+func TestHeatCapCheck(t *testing.T) {
+	ps, err := NewPlanStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	for _, p := range []*PositionPlan{
+		{Symbol: "AAPL", EntryPrice: 100, StopLoss: 90, Quantity: 10, EntryTime: entry},        // 오픈 리스크 $100
+		{Symbol: "MSFT", EntryPrice: 100, StopLoss: 105, Quantity: 10, EntryTime: entry},       // 본전 위 손절 → 0
+		{Symbol: "005930", EntryPrice: 70000, StopLoss: 65000, Quantity: 10, EntryTime: entry}, // KR ₩50,000
+	} {
+		if err := ps.Save(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buy := func(symbol string, qty, amount, entry, stop float64) PreTradeRequest {
+		return PreTradeRequest{
+			Order:  broker.Order{Symbol: symbol, Side: broker.OrderSideBuy, Quantity: qty, Amount: amount, LimitPrice: entry},
+			Signal: &strategy.Signal{Guide: &strategy.TradeGuide{EntryPrice: entry, StopLoss: stop}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		capital float64
+		market  string
+		maxHeat float64
+		req     PreTradeRequest
+		wantErr bool
+	}{
+		// 한도 $600 = $10,000 × 6%
+		{"within cap", 10000, "us", 0.06, buy("NVDA", 20, 0, 50, 40), false},             // 100 + 200
+		{"at cap", 10000, "us", 0.06, buy("NVDA", 50, 0, 50, 40), false},                 // 100 + 500 = 600 (넘지 않음)
+		{"over cap", 10000, "us", 0.06, buy("NVDA", 60, 0, 50, 40), true},                // 100 + 600
+		{"amount order over cap", 10000, "us", 0.06, buy("NVDA", 0, 3000, 50, 40), true}, // $3,000 / $50 = 60주
+		{"KR plans not added to US", 10000, "us", 0.06, buy("NVDA", 45, 0, 50, 40), false},
+		// KR 한도 ₩60,000 = ₩1,000,000 × 6%: 보유 ₩50,000 + 신규 ₩20,000
+		{"KR market from symbol", 1000000, "", 0.06, buy("000660", 2, 0, 70000, 60000), true},
+		{"KR within cap", 1000000, "kr", 0.06, buy("000660", 1, 0, 70000, 60000), false},
+		// 엔화 주문은 USD 자본과 비교하지 않음 (현지 통화 한도는 ValueCaps 담당)
+		{"foreign currency skipped", 10000, "us", 0.06, buy("7203.T", 100, 0, 2800, 2600), false},
+		{"disabled", 10000, "us", 0, buy("NVDA", 600, 0, 50, 40), false},
+		{"capital unknown", 0, "us", 0.06, buy("NVDA", 600, 0, 50, 40), false},
+		{"sell ignored", 10000, "us", 0.06, PreTradeRequest{Order: broker.Order{Symbol: "AAPL", Side: broker.OrderSideSell, Quantity: 600}}, false},
+		{"no signal", 10000, "us", 0.06, PreTradeRequest{Order: broker.Order{Symbol: "NVDA", Side: broker.OrderSideBuy, Quantity: 600, LimitPrice: 50}}, false},
+	}
+	for _, tt := range tests {
+		capital := tt.capital
+		c := HeatCapCheck{Plans: ps, Market: tt.market, Capital: func() float64 { return capital }, MaxHeat: tt.maxHeat}
+		err := c.Check(context.Background(), tt.req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestHeatCapCheckNilCapital(t *testing.T) {
+	c := HeatCapCheck{MaxHeat: 0.01}
+	req := PreTradeRequest{
+		Order:  broker.Order{Symbol: "NVDA", Side: broker.OrderSideBuy, Quantity: 1000, LimitPrice: 50},
+		Signal: &strategy.Signal{Guide: &strategy.TradeGuide{EntryPrice: 50, StopLoss: 10}},
+	}
+	if err := c.Check(context.Background(), req); err != nil {
+		t.Errorf("nil Capital func: err = %v, want skipped", err)
+	}
+}
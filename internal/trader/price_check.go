@@ -0,0 +1,76 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/money"
+)
+
+// PriceCheckConfig 주문 직전 시세 확인 (0이면 기본값, 음수면 해당 검사 비활성)
+type PriceCheckConfig struct {
+	MaxDeviation float64       // 주문가와 현재가 최대 괴리 (0.05 = 5%)
+	MaxQuoteAge  time.Duration // 시세 체결 시각 최대 경과 (시각을 모르면 거부)
+}
+
+// DefaultPriceCheckConfig 기본값: 괴리 5%, 시세 10분 이내
+func DefaultPriceCheckConfig() PriceCheckConfig {
+	return PriceCheckConfig{
+		MaxDeviation: 0.05,
+		MaxQuoteAge:  10 * time.Minute,
+	}
+}
+
+func (c PriceCheckConfig) withDefaults() PriceCheckConfig {
+	d := DefaultPriceCheckConfig()
+	if c.MaxDeviation == 0 {
+		c.MaxDeviation = d.MaxDeviation
+	}
+	if c.MaxQuoteAge == 0 {
+		c.MaxQuoteAge = d.MaxQuoteAge
+	}
+	return c
+}
+
+// checkPrice 브로커 현재가를 새로 조회해 주문가가 합리적인지 확인 (매수만, 매도는 청산을 막지 않도록 제외)
+// 현재가 0/조회 실패, 오래되었거나 시각을 모르는 시세, 시그널 가격과 현재가의 큰 괴리 → 주문 거부
+func (e *Executor) checkPrice(ctx context.Context, order broker.Order) error {
+	if order.Side != broker.OrderSideBuy || order.LimitPrice <= 0 {
+		return nil
+	}
+	cfg := e.config.PriceCheck
+
+	var quote float64
+	var at time.Time
+	var err error
+	if qt, ok := e.broker.(broker.QuoteTimer); ok {
+		quote, at, err = qt.GetQuoteTime(ctx, order.Symbol)
+	} else {
+		quote, err = e.broker.GetQuote(ctx, order.Symbol)
+	}
+	if err != nil {
+		return fmt.Errorf("quote unavailable: %w", err)
+	}
+	if quote <= 0 || math.IsNaN(quote) || math.IsInf(quote, 0) {
+		return fmt.Errorf("invalid quote %v", quote)
+	}
+	if cfg.MaxQuoteAge > 0 {
+		if at.IsZero() {
+			return fmt.Errorf("quote age unknown for %s (max %s)", order.Symbol, cfg.MaxQuoteAge)
+		}
+		if age := time.Since(at); age > cfg.MaxQuoteAge {
+			return fmt.Errorf("stale quote: last trade %s ago", age.Round(time.Second))
+		}
+	}
+	if cfg.MaxDeviation > 0 {
+		if dev := math.Abs(order.LimitPrice/quote - 1); dev > cfg.MaxDeviation {
+			cur := money.ForSymbol(order.Symbol)
+			return fmt.Errorf("order price %s is %.1f%% from quote %s (max %.1f%%)",
+				money.Price(order.LimitPrice, cur), dev*100, money.Price(quote, cur), cfg.MaxDeviation*100)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 시세 조회 실패 대응 (레이트 리밋, 브로커 장애)
+const (
+	quoteBackoffBase = 30 * time.Second // 종목별 첫 재시도 대기 (매 실패 2배)
+	quoteBackoffMax  = 10 * time.Minute
+
+	errorBudgetWindow      = 10 * time.Minute // 전역 에러 예산 집계 기간
+	errorBudgetMinFailures = 10               // 최소 실패 수 (종목이 적을 때 오탐 방지)
+	errorBudgetRatio       = 0.8              // 기간 내 실패 비율이 이 이상이면 일시 중지
+	errorBudgetPause       = 5 * time.Minute
+
+	providerQuoteMaxAge = 7 * time.Minute // provider 대체 시세로 쓸 5분봉 시작 시각 최대 경과 (봉 하나 + 지연 여유)
+)
+
+// quoteGuard 종목별 지수 백오프 + 전역 에러 예산
+type quoteGuard struct {
+	mu          sync.Mutex
+	backoff     map[string]*quoteBackoff
+	attempts    []quoteAttempt
+	pausedUntil time.Time
+	tripped     bool // 예산 초과 후 아직 회복 안 됨
+}
+
+type quoteBackoff struct {
+	failures int
+	next     time.Time
+}
+
+type quoteAttempt struct {
+	at     time.Time
+	failed bool
+}
+
+func newQuoteGuard() *quoteGuard {
+	return &quoteGuard{backoff: make(map[string]*quoteBackoff)}
+}
+
+// paused 전역 일시 중지 중이면 재개 시각 반환
+func (g *quoteGuard) paused(now time.Time) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.pausedUntil, now.Before(g.pausedUntil)
+}
+
+// allow 종목 백오프가 끝났으면 true
+func (g *quoteGuard) allow(symbol string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b := g.backoff[symbol]
+	return b == nil || !now.Before(b.next)
+}
+
+// success 조회 성공. 예산 초과 상태에서 회복한 첫 성공이면 true
+func (g *quoteGuard) success(symbol string, now time.Time) (recovered bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.backoff, symbol)
+	g.attempts = append(g.attempts, quoteAttempt{at: now})
+	if g.tripped {
+		g.tripped = false
+		return true
+	}
+	return false
+}
+
+// failure 조회 실패 기록 → 다음 시도까지 대기 시간
+func (g *quoteGuard) failure(symbol string, now time.Time) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b := g.backoff[symbol]
+	if b == nil {
+		b = &quoteBackoff{}
+		g.backoff[symbol] = b
+	}
+	b.failures++
+	wait := quoteBackoffBase << (b.failures - 1)
+	if b.failures > 10 || wait > quoteBackoffMax {
+		wait = quoteBackoffMax
+	}
+	b.next = now.Add(wait)
+	g.attempts = append(g.attempts, quoteAttempt{at: now, failed: true})
+	return wait
+}
+
+// checkBudget 최근 기간 실패가 예산을 넘으면 전역 일시 중지 시작 (true 반환)
+func (g *quoteGuard) checkBudget(now time.Time) (tripped bool, failed, total int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	cutoff := now.Add(-errorBudgetWindow)
+	i := 0
+	for i < len(g.attempts) && g.attempts[i].at.Before(cutoff) {
+		i++
+	}
+	g.attempts = g.attempts[i:]
+	for _, a := range g.attempts {
+		if a.failed {
+			failed++
+		}
+	}
+	total = len(g.attempts)
+	if failed < errorBudgetMinFailures || float64(failed) < errorBudgetRatio*float64(total) {
+		return false, failed, total
+	}
+	g.pausedUntil = now.Add(errorBudgetPause)
+	g.attempts = nil
+	g.tripped = true
+	return true, failed, total
+}
+
+// getQuote 브로커 현재가, 실패하면 provider 최근 분봉 종가로 대체 (source: "broker" / provider 이름)
+func (m *Monitor) getQuote(ctx context.Context, symbol string) (float64, string, error) {
+	price, err := m.broker.GetQuote(ctx, symbol)
+	if err == nil && price > 0 {
+		return price, "broker", nil
+	}
+	if err == nil {
+		err = fmt.Errorf("invalid price %v", price)
+	}
+	if m.provider == nil {
+		return 0, "", err
+	}
+
+	now := time.Now()
+	data, perr := m.provider.GetIntradayData(ctx, symbol, now, 5)
+	if perr != nil || data == nil || len(data.Candles) == 0 {
+		return 0, "", fmt.Errorf("%v (provider fallback: %v)", err, perr)
+	}
+	last := data.Candles[len(data.Candles)-1]
+	if last.Close <= 0 || now.Sub(last.Time) > providerQuoteMaxAge {
+		return 0, "", fmt.Errorf("%v (provider fallback stale: %s)", err, last.Time.Format("01-02 15:04"))
+	}
+	return last.Close, m.provider.Name(), nil
+}
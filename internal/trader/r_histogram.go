@@ -0,0 +1,129 @@
+package trader
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// R 배수 히스토그램 구간: 0.5R 간격, 구간 중심이 -3R ~ +5R (손절 -1R, 목표 +2R 같은 값이 한 구간에 모이도록)
+// 양 끝 밖은 꼬리 구간으로 묶는다.
+const (
+	rHistMin   = -3.0
+	rHistMax   = 5.0
+	rHistWidth = 0.5
+)
+
+// RBucket 히스토그램 한 구간 [Low, High). 꼬리 구간은 관측된 최저/최고값까지
+type RBucket struct {
+	Label string  `json:"label"`
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Count int     `json:"count"`
+	Pct   float64 `json:"pct"`
+}
+
+// RDistribution R 배수 분포 (평균만으로는 안 보이는 꼬리/군집 확인용)
+type RDistribution struct {
+	Trades       int       `json:"trades"`
+	Mean         float64   `json:"mean"`
+	Median       float64   `json:"median"`
+	Worst        float64   `json:"worst"`
+	Best         float64   `json:"best"`
+	BeyondMinus1 int       `json:"beyond_minus_1r"` // -1R보다 나쁜 손실 (갭/슬리피지로 손절 초과)
+	Buckets      []RBucket `json:"buckets"`
+}
+
+// NewRDistribution R 배수 목록 → 분포 (비어 있으면 nil)
+func NewRDistribution(rs []float64) *RDistribution {
+	var vals []float64
+	for _, r := range rs {
+		if !math.IsNaN(r) && !math.IsInf(r, 0) {
+			vals = append(vals, r)
+		}
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	sort.Float64s(vals)
+
+	d := &RDistribution{
+		Trades: len(vals),
+		Worst:  vals[0],
+		Best:   vals[len(vals)-1],
+	}
+	n := len(vals)
+	if n%2 == 1 {
+		d.Median = vals[n/2]
+	} else {
+		d.Median = (vals[n/2-1] + vals[n/2]) / 2
+	}
+
+	half := rHistWidth / 2
+	lowest, highest := rHistMin-half, rHistMax+half
+	steps := int(math.Round((rHistMax-rHistMin)/rHistWidth)) + 1
+	d.Buckets = make([]RBucket, 0, steps+2)
+	d.Buckets = append(d.Buckets, RBucket{Label: fmt.Sprintf("<%+.1fR", rHistMin), Low: math.Min(d.Worst, lowest), High: lowest})
+	for i := 0; i < steps; i++ {
+		center := rHistMin + float64(i)*rHistWidth
+		d.Buckets = append(d.Buckets, RBucket{Label: fmt.Sprintf("%+.1fR", center), Low: center - half, High: center + half})
+	}
+	d.Buckets = append(d.Buckets, RBucket{Label: fmt.Sprintf(">%+.1fR", rHistMax), Low: highest, High: math.Max(d.Best, highest)})
+
+	var sum float64
+	for _, r := range vals {
+		sum += r
+		if r < -1.05 { // 손절가 체결 오차 여유
+			d.BeyondMinus1++
+		}
+		idx := 0 // 왼쪽 꼬리
+		switch {
+		case r >= highest:
+			idx = len(d.Buckets) - 1
+		case r >= lowest:
+			idx = 1 + int(math.Floor((r-lowest)/rHistWidth))
+		}
+		d.Buckets[idx].Count++
+	}
+	d.Mean = sum / float64(n)
+	for i := range d.Buckets {
+		d.Buckets[i].Pct = float64(d.Buckets[i].Count) / float64(n) * 100
+	}
+	return d
+}
+
+// Format 텍스트 히스토그램 (빈 구간은 양 끝에서 잘라냄)
+func (d *RDistribution) Format(width int) string {
+	if d == nil || d.Trades == 0 {
+		return ""
+	}
+	first, last := 0, len(d.Buckets)-1
+	for first < last && d.Buckets[first].Count == 0 {
+		first++
+	}
+	for last > first && d.Buckets[last].Count == 0 {
+		last--
+	}
+	maxCount := 0
+	for _, b := range d.Buckets[first : last+1] {
+		if b.Count > maxCount {
+			maxCount = b.Count
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, " Trades %d | mean %+.2fR | median %+.2fR | worst %+.2fR | best %+.2fR | worse than -1R: %d\n",
+		d.Trades, d.Mean, d.Median, d.Worst, d.Best, d.BeyondMinus1)
+	for _, b := range d.Buckets[first : last+1] {
+		bar := 0
+		if maxCount > 0 {
+			bar = int(math.Round(float64(b.Count) / float64(maxCount) * float64(width)))
+		}
+		if b.Count > 0 && bar == 0 {
+			bar = 1
+		}
+		fmt.Fprintf(&sb, " %8s │%-*s %4d (%4.1f%%)\n", b.Label, width, strings.Repeat("█", bar), b.Count, b.Pct)
+	}
+	return sb.String()
+}
@@ -0,0 +1,112 @@
+package trader
+
+import (
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/fees"
+)
+
+// ClosedTrade 체결내역 기준으로 확정된 매도 한 건 (FIFO 매칭)
+type ClosedTrade struct {
+	Symbol     string    `json:"symbol"`
+	Quantity   float64   `json:"quantity"`
+	EntryPrice float64   `json:"entry_price"` // FIFO 가중평균 매수가
+	ExitPrice  float64   `json:"exit_price"`
+	ExitTime   time.Time `json:"exit_time"`
+	OrderID    string    `json:"order_id,omitempty"`
+	Commission float64   `json:"commission"` // 매수분 + 매도분 수수료/세금
+	PnL        float64   `json:"pnl"`        // 수수료 차감 순손익
+	PnLPct     float64   `json:"pnl_pct"`
+}
+
+// RealizedFills 체결내역 기반 실현손익
+type RealizedFills struct {
+	Trades     []ClosedTrade `json:"trades"`
+	GrossPnL   float64       `json:"gross_pnl"`
+	Commission float64       `json:"commission"`
+	NetPnL     float64       `json:"net_pnl"`
+	Wins       int           `json:"wins"`
+	Losses     int           `json:"losses"`
+	Unmatched  []string      `json:"unmatched,omitempty"` // 매수 체결을 못 찾은 매도 (조회 기간 이전 매수)
+}
+
+type fillLot struct {
+	qty   float64
+	price float64
+}
+
+// RealizedFromFills 체결내역을 종목별 FIFO로 매칭해 since 이후 매도분의 실현손익 계산.
+// fills는 매수 원가를 찾을 수 있도록 since보다 충분히 앞선 기간부터 넘겨야 한다.
+// 수수료는 체결 금액에 마켓의 활성 fees 프리셋을 적용한 추정치.
+func RealizedFromFills(fills []broker.Fill, market string, since time.Time) RealizedFills {
+	fee := fees.ForMarket(market)
+	lots := make(map[string][]fillLot)
+	var out RealizedFills
+
+	for _, f := range fills {
+		if f.Quantity <= 0 {
+			continue
+		}
+		if f.Side == broker.OrderSideBuy {
+			lots[f.Symbol] = append(lots[f.Symbol], fillLot{qty: f.Quantity, price: f.Price})
+			continue
+		}
+
+		// 매도: FIFO로 매수 로트 소진
+		remaining := f.Quantity
+		var matched, cost float64
+		queue := lots[f.Symbol]
+		for remaining > 1e-9 && len(queue) > 0 {
+			take := queue[0].qty
+			if take > remaining {
+				take = remaining
+			}
+			matched += take
+			cost += take * queue[0].price
+			queue[0].qty -= take
+			remaining -= take
+			if queue[0].qty <= 1e-9 {
+				queue = queue[1:]
+			}
+		}
+		lots[f.Symbol] = queue
+
+		if f.FilledAt.Before(since) {
+			continue
+		}
+		if remaining > 1e-9 {
+			out.Unmatched = append(out.Unmatched, f.Symbol)
+		}
+		if matched <= 0 {
+			continue
+		}
+
+		proceeds := matched * f.Price
+		commission := fee.BuyCost(cost) + fee.SellCost(proceeds)
+		pnl := proceeds - cost - commission
+		t := ClosedTrade{
+			Symbol:     f.Symbol,
+			Quantity:   matched,
+			EntryPrice: cost / matched,
+			ExitPrice:  f.Price,
+			ExitTime:   f.FilledAt,
+			OrderID:    f.OrderID,
+			Commission: commission,
+			PnL:        pnl,
+		}
+		if cost > 0 {
+			t.PnLPct = pnl / cost * 100
+		}
+		out.Trades = append(out.Trades, t)
+		out.GrossPnL += proceeds - cost
+		out.Commission += commission
+		out.NetPnL += pnl
+		if pnl > 0 {
+			out.Wins++
+		} else {
+			out.Losses++
+		}
+	}
+	return out
+}
@@ -0,0 +1,283 @@
+package trader
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/money"
+	"traveler/internal/strategy"
+	"traveler/pkg/model"
+)
+
+// 리밸런싱 액션
+const (
+	RebalanceHold = "hold"
+	RebalanceExit = "exit"
+	RebalanceTrim = "trim"
+	RebalanceAdd  = "add"
+)
+
+// unplannedStopPct 플랜(손절가)이 없는 보유 종목의 가정 손절폭
+const unplannedStopPct = 0.08
+
+// RebalanceConfig 보유 포지션 리밸런싱 기준
+type RebalanceConfig struct {
+	RiskPerTrade   float64 // 포지션당 목표 리스크 (자본 대비)
+	MaxPositionPct float64 // 종목당 최대 비중
+	TargetHeat     float64 // 포트폴리오 전체 목표 리스크 (열기, heat)
+	AllowAdds      bool    // 수익 중인 건전한 포지션 추가매수 제안 여부
+}
+
+// RebalanceConfigFromSizer 사이저 설정 기반 기본값 (목표 heat = 포지션당 리스크 × 최대 포지션 수)
+func RebalanceConfigFromSizer(cfg SizerConfig) RebalanceConfig {
+	return RebalanceConfig{
+		RiskPerTrade:   cfg.RiskPerTrade,
+		MaxPositionPct: cfg.MaxPositionPct,
+		TargetHeat:     cfg.RiskPerTrade * float64(cfg.MaxPositions),
+		AllowAdds:      true,
+	}
+}
+
+// HoldingReview 보유 종목별 점검 결과
+type HoldingReview struct {
+	Symbol       string   `json:"symbol"`
+	Strategy     string   `json:"strategy,omitempty"`
+	Quantity     float64  `json:"quantity"`
+	Price        float64  `json:"price"`
+	StopLoss     float64  `json:"stop_loss"`
+	Weight       float64  `json:"weight"`   // 자본 대비 비중
+	RiskPct      float64  `json:"risk_pct"` // 자본 대비 손절 시 손실
+	Flags        []string `json:"flags,omitempty"`
+	Action       string   `json:"action"`
+	DeltaQty     float64  `json:"delta_qty"` // 음수=매도, 양수=매수
+	Reason       string   `json:"reason,omitempty"`
+	riskPerShare float64
+}
+
+// RebalanceReport 포트폴리오 점검 리포트
+type RebalanceReport struct {
+	Equity        float64         `json:"equity"`
+	Cash          float64         `json:"cash"`
+	Heat          float64         `json:"heat"`       // 현재 전체 리스크 (자본 대비)
+	HeatAfter     float64         `json:"heat_after"` // 제안 반영 후
+	TargetHeat    float64         `json:"target_heat"`
+	MaxWeight     float64         `json:"max_weight"` // 최대 단일 종목 비중
+	MaxWeightSym  string          `json:"max_weight_symbol"`
+	Concentration float64         `json:"concentration"` // HHI (비중 제곱합, 1=단일 종목)
+	Holdings      []HoldingReview `json:"holdings"`
+}
+
+// CheckPlanInvalidation 플랜 기준 무효화 여부 (손절 이탈, 보유기간 초과, 전략별 규칙)
+// candles는 최근 일봉 (전략별 규칙에 최소 20개 필요, 부족하면 해당 규칙 생략).
+// 데몬의 일일 무효화 체크와 같은 규칙을 상태 없이 적용한다 (pullback: 최근 2일 종가 < MA20).
+func CheckPlanInvalidation(plan *PositionPlan, price float64, candles []model.Candle) (bool, string) {
+	if plan == nil {
+		return false, ""
+	}
+	if plan.StopLoss > 0 && price > 0 && price <= plan.StopLoss {
+		return true, "price at/below stop loss"
+	}
+	if plan.MaxHoldDays > 0 && TradingDaysSince(plan.EntryTime) > plan.MaxHoldDays {
+		return true, "max hold days exceeded"
+	}
+	if len(candles) < 21 {
+		return false, ""
+	}
+	lastClose := candles[len(candles)-1].Close
+
+	switch baseStrategyName(plan.Strategy) {
+	case "pullback":
+		prev := strategy.CalculateIndicators(candles[:len(candles)-1])
+		cur := strategy.CalculateIndicators(candles)
+		prevClose := candles[len(candles)-2].Close
+		if cur.MA20 > 0 && prev.MA20 > 0 && lastClose < cur.MA20 && prevClose < prev.MA20 {
+			return true, "close below MA20 for 2 consecutive days"
+		}
+	case "breakout":
+		if plan.BreakoutLevel > 0 && lastClose < plan.BreakoutLevel {
+			return true, "failed breakout - close below breakout level"
+		}
+	case "mean-reversion":
+		if TradingDaysSince(plan.EntryTime) >= 2 {
+			ind := strategy.CalculateIndicators(candles)
+			if ind.RSI14 < 35 && ind.BBLower > 0 && lastClose < ind.BBLower {
+				return true, "RSI recovery failure + still below BB lower band"
+			}
+		}
+	}
+	return false, ""
+}
+
+// AnalyzeRebalance 현재 보유 포지션 점검 및 축소/추가 제안
+// candles: 종목별 최근 일봉 (없으면 전략별 무효화 규칙 생략)
+func AnalyzeRebalance(positions []broker.Position, cash float64, plans map[string]*PositionPlan,
+	candles map[string][]model.Candle, cfg RebalanceConfig) *RebalanceReport {
+
+	report := &RebalanceReport{Cash: cash, TargetHeat: cfg.TargetHeat}
+	// 도쿄/홍콩/중국 종목은 현지 통화 평가라 계좌 통화 자산·비중 계산에서 제외
+	domestic := positions[:0:0]
+	for _, p := range positions {
+		switch PositionCurrency(p) {
+		case money.JPY, money.HKD, money.CNY:
+			continue
+		}
+		domestic = append(domestic, p)
+	}
+	positions = domestic
+
+	equity := cash
+	for _, p := range positions {
+		equity += positionValue(p)
+	}
+	report.Equity = equity
+	if equity <= 0 {
+		return report
+	}
+
+	for _, p := range positions {
+		price := p.CurrentPrice
+		if price <= 0 && p.Quantity > 0 {
+			price = positionValue(p) / p.Quantity
+		}
+		h := HoldingReview{
+			Symbol:   p.Symbol,
+			Quantity: p.Quantity,
+			Price:    price,
+			Weight:   positionValue(p) / equity,
+			Action:   RebalanceHold,
+		}
+
+		plan := plans[p.Symbol]
+		if plan != nil && plan.StopLoss > 0 {
+			h.Strategy = plan.Strategy
+			h.StopLoss = plan.StopLoss
+			if plan.CrossesExDividend(time.Now()) {
+				h.Flags = append(h.Flags, fmt.Sprintf("ex-dividend %s during hold (%.2f/share)", plan.ExDividendDate, plan.DividendAmount))
+			}
+		} else {
+			h.StopLoss = price * (1 - unplannedStopPct)
+			h.Flags = append(h.Flags, "no plan (assumed 8% stop)")
+		}
+		h.riskPerShare = math.Max(price-h.StopLoss, 0)
+		h.RiskPct = h.riskPerShare * p.Quantity / equity
+		report.Heat += h.RiskPct
+
+		if h.Weight > report.MaxWeight {
+			report.MaxWeight = h.Weight
+			report.MaxWeightSym = p.Symbol
+		}
+		report.Concentration += h.Weight * h.Weight
+
+		// 1) 전략 무효화 → 전량 청산
+		if invalid, reason := CheckPlanInvalidation(plan, price, candles[p.Symbol]); invalid {
+			h.Flags = append(h.Flags, "invalidated: "+reason)
+			h.Action = RebalanceExit
+			h.DeltaQty = -p.Quantity
+			h.Reason = reason
+		} else if cfg.MaxPositionPct > 0 && h.Weight > cfg.MaxPositionPct && price > 0 {
+			// 2) 비중 초과 → 최대 비중까지 축소
+			h.Flags = append(h.Flags, "over max weight")
+			h.Action = RebalanceTrim
+			h.DeltaQty = -math.Min(p.Quantity, math.Ceil((h.Weight-cfg.MaxPositionPct)*equity/price))
+			h.Reason = "weight above max position size"
+		}
+		report.Holdings = append(report.Holdings, h)
+	}
+
+	heat := report.Heat
+	for _, h := range report.Holdings {
+		heat += h.DeltaQty * h.riskPerShare / equity
+	}
+
+	// 3) heat 초과 → 리스크 큰 종목부터 축소
+	if cfg.TargetHeat > 0 && heat > cfg.TargetHeat {
+		order := sortedByRisk(report.Holdings)
+		for _, i := range order {
+			h := &report.Holdings[i]
+			if heat <= cfg.TargetHeat {
+				break
+			}
+			if h.Action == RebalanceExit || h.riskPerShare <= 0 {
+				continue
+			}
+			remaining := h.Quantity + h.DeltaQty
+			cut := math.Min(remaining, math.Ceil((heat-cfg.TargetHeat)*equity/h.riskPerShare))
+			if cut <= 0 {
+				continue
+			}
+			h.DeltaQty -= cut
+			heat -= cut * h.riskPerShare / equity
+			h.Action = RebalanceTrim
+			if h.Reason == "" {
+				h.Reason = "portfolio heat above target"
+			} else {
+				h.Reason += "; portfolio heat above target"
+			}
+		}
+	}
+
+	// 4) heat 여유 → 수익 중인 건전한 포지션을 포지션당 리스크까지 추가
+	if cfg.AllowAdds && cfg.TargetHeat > 0 && heat < cfg.TargetHeat {
+		budgetCash := cash
+		for _, h := range report.Holdings {
+			if h.DeltaQty < 0 {
+				budgetCash += -h.DeltaQty * h.Price
+			}
+		}
+		order := sortedByRisk(report.Holdings)
+		for k := len(order) - 1; k >= 0; k-- {
+			h := &report.Holdings[order[k]]
+			plan := plans[h.Symbol]
+			if h.Action != RebalanceHold || plan == nil || h.riskPerShare <= 0 || h.Price <= plan.EntryPrice {
+				continue
+			}
+			addRisk := math.Min(cfg.RiskPerTrade-h.RiskPct, cfg.TargetHeat-heat)
+			qty := math.Floor(addRisk * equity / h.riskPerShare)
+			if cfg.MaxPositionPct > 0 {
+				qty = math.Min(qty, math.Floor((cfg.MaxPositionPct-h.Weight)*equity/h.Price))
+			}
+			qty = math.Min(qty, math.Floor(budgetCash/h.Price))
+			if qty < 1 {
+				continue
+			}
+			h.Action = RebalanceAdd
+			h.DeltaQty = qty
+			h.Reason = "in profit, below per-position risk target"
+			heat += qty * h.riskPerShare / equity
+			budgetCash -= qty * h.Price
+		}
+	}
+
+	report.HeatAfter = heat
+	return report
+}
+
+// sortedByRisk 리스크 큰 순 인덱스
+func sortedByRisk(holdings []HoldingReview) []int {
+	order := make([]int, len(holdings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return holdings[order[a]].RiskPct > holdings[order[b]].RiskPct
+	})
+	return order
+}
+
+// PositionCurrency 포지션 평가 통화 (브로커가 알려주지 않으면 심볼로 추정)
+func PositionCurrency(p broker.Position) money.Currency {
+	if p.Currency != "" {
+		return money.Parse(p.Currency)
+	}
+	return money.ForSymbol(p.Symbol)
+}
+
+func positionValue(p broker.Position) float64 {
+	if p.MarketValue > 0 {
+		return p.MarketValue
+	}
+	return p.CurrentPrice * p.Quantity
+}
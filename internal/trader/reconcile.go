@@ -0,0 +1,244 @@
+package trader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/fsutil"
+	"traveler/internal/symbols"
+)
+
+// Reconcile issue kinds
+const (
+	IssueUnknownPosition  = "unknown_position"  // 브로커 보유, 플랜 없음 (모니터 대상 아님)
+	IssueOrphanedPlan     = "orphaned_plan"     // 플랜 있음, 브로커 보유 없음
+	IssueQuantityMismatch = "quantity_mismatch" // 플랜 수량 ≠ 브로커 수량
+	IssueUnconfirmedOrder = "unconfirmed_order" // 결과 불명 주문 의도, 브로커에 흔적 없음
+)
+
+// ReconcileIssue 불일치 하나
+type ReconcileIssue struct {
+	Kind   string `json:"kind"`
+	Symbol string `json:"symbol"`
+	Detail string `json:"detail"`
+}
+
+// ReconcileReport 시작 시 브로커 ↔ PlanStore ↔ 주문 저널 대조 결과
+type ReconcileReport struct {
+	Market          string           `json:"market"`
+	Time            time.Time        `json:"time"`
+	BrokerPositions int              `json:"broker_positions"`
+	PendingOrders   int              `json:"pending_orders"`
+	Plans           int              `json:"plans"`
+	Issues          []ReconcileIssue `json:"issues"`
+	Fingerprint     string           `json:"fingerprint"` // 이슈 종류+심볼 해시 (같은 이슈면 재확인 불필요)
+
+	Acknowledged bool      `json:"acknowledged"`
+	AckedAt      time.Time `json:"acked_at,omitempty"`
+}
+
+// NeedsAck 이슈가 있고 아직 확인되지 않았으면 true (신규 매수 보류)
+func (r *ReconcileReport) NeedsAck() bool {
+	return r != nil && len(r.Issues) > 0 && !r.Acknowledged
+}
+
+// PlanMarket 심볼로 마켓 판별 (plans.json은 전 마켓 공용)
+func PlanMarket(symbol string) string {
+	switch {
+	case strings.HasPrefix(symbol, "KRW-"):
+		return "crypto"
+	case symbols.IsKoreanSymbol(symbol):
+		return "kr"
+	default:
+		return "us"
+	}
+}
+
+// Reconcile 브로커 포지션/미체결 주문을 PlanStore와 주문 저널(nil 가능)에 대조
+// 크립토는 수동 보유분을 의도적으로 관리하지 않으므로 unknown_position을 보고하지 않는다.
+func Reconcile(ctx context.Context, b broker.Broker, ps *PlanStore, j *OrderJournal, market string) (*ReconcileReport, error) {
+	positions, err := b.GetPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get positions: %w", err)
+	}
+	pending, err := b.GetPendingOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get pending orders: %w", err)
+	}
+
+	report := &ReconcileReport{
+		Market:          market,
+		Time:            time.Now(),
+		BrokerPositions: len(positions),
+		PendingOrders:   len(pending),
+		Issues:          []ReconcileIssue{},
+	}
+
+	plans := make(map[string]*PositionPlan)
+	if ps != nil {
+		ps.Reload()
+		for sym, p := range ps.All() {
+			if PlanMarket(sym) == market {
+				plans[sym] = p
+			}
+		}
+	}
+	report.Plans = len(plans)
+
+	held := make(map[string]broker.Position, len(positions))
+	for _, p := range positions {
+		held[p.Symbol] = p
+	}
+	pendingSyms := make(map[string]bool, len(pending))
+	for _, o := range pending {
+		pendingSyms[o.Symbol] = true
+	}
+
+	add := func(kind, symbol, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, ReconcileIssue{Kind: kind, Symbol: symbol, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	for _, p := range positions {
+		plan, ok := plans[p.Symbol]
+		switch {
+		case !ok:
+			if market != "crypto" {
+				add(IssueUnknownPosition, p.Symbol, "broker holds %g @ %.4g with no plan", p.Quantity, p.AvgCost)
+			}
+		case quantityDiffers(plan.Quantity, p.Quantity):
+			add(IssueQuantityMismatch, p.Symbol, "plan %g vs broker %g", plan.Quantity, p.Quantity)
+		}
+	}
+	for sym, plan := range plans {
+		if _, ok := held[sym]; !ok && !pendingSyms[sym] {
+			add(IssueOrphanedPlan, sym, "%s plan (qty %g, entered %s) but no broker position",
+				plan.Strategy, plan.Quantity, plan.EntryTime.Format("2006-01-02"))
+		}
+	}
+	if j != nil {
+		intents, err := j.Recent(report.Time.Add(-24 * time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		for _, in := range intents {
+			if in.Status != IntentPending && in.Status != IntentUnknown {
+				continue
+			}
+			if _, ok := held[in.Symbol]; ok || pendingSyms[in.Symbol] {
+				continue
+			}
+			add(IssueUnconfirmedOrder, in.Symbol, "%s %g at %s ended %s%s — check the broker order history",
+				in.Side, in.Quantity, in.Created.Format("01-02 15:04"), in.Status, errSuffix(in.Error))
+		}
+	}
+
+	sort.Slice(report.Issues, func(a, b int) bool {
+		if report.Issues[a].Kind != report.Issues[b].Kind {
+			return report.Issues[a].Kind < report.Issues[b].Kind
+		}
+		return report.Issues[a].Symbol < report.Issues[b].Symbol
+	})
+	report.Fingerprint = issuesFingerprint(report.Issues)
+	return report, nil
+}
+
+// quantityDiffers 1% 초과 차이 (크립토 수수료 차감분 허용)
+func quantityDiffers(plan, actual float64) bool {
+	if actual <= 0 {
+		return plan > 0
+	}
+	return math.Abs(plan-actual)/actual > 0.01
+}
+
+func errSuffix(msg string) string {
+	if msg == "" {
+		return ""
+	}
+	return " (" + msg + ")"
+}
+
+func issuesFingerprint(issues []ReconcileIssue) string {
+	if len(issues) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	for _, is := range issues {
+		fmt.Fprintf(h, "%s|%s\n", is.Kind, is.Symbol)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// ReconcileStore 최근 대조 결과 + 확인 상태 ({dataDir}/reconcile_{market}.json)
+type ReconcileStore struct {
+	path string
+}
+
+// NewReconcileStore 생성자
+func NewReconcileStore(dataDir, market string) *ReconcileStore {
+	return &ReconcileStore{path: filepath.Join(dataDir, fmt.Sprintf("reconcile_%s.json", market))}
+}
+
+// Save 새 결과 저장. 직전에 확인한 것과 같은 이슈 목록이면 확인 상태를 이어받는다.
+func (s *ReconcileStore) Save(r *ReconcileReport) error {
+	return fsutil.Update(s.path, func() error {
+		if prev, err := s.load(); err == nil && prev.Acknowledged && prev.Fingerprint == r.Fingerprint {
+			r.Acknowledged, r.AckedAt = true, prev.AckedAt
+		}
+		if len(r.Issues) == 0 {
+			r.Acknowledged = true
+		}
+		return s.save(r)
+	})
+}
+
+// Load 최근 결과 (없으면 os.ErrNotExist)
+func (s *ReconcileStore) Load() (*ReconcileReport, error) {
+	return s.load()
+}
+
+// Acknowledge 현재 결과 확인 처리 → 데몬이 다음 모니터 주기에 신규 매수 재개
+func (s *ReconcileStore) Acknowledge() (*ReconcileReport, error) {
+	var r *ReconcileReport
+	err := fsutil.Update(s.path, func() error {
+		var err error
+		if r, err = s.load(); err != nil {
+			return err
+		}
+		if r.Acknowledged {
+			return nil
+		}
+		r.Acknowledged, r.AckedAt = true, time.Now()
+		return s.save(r)
+	})
+	return r, err
+}
+
+func (s *ReconcileStore) load() (*ReconcileReport, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var r ReconcileReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (s *ReconcileStore) save(r *ReconcileReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(s.path, data, 0644)
+}
@@ -0,0 +1,320 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"traveler/internal/money"
+	"traveler/internal/strategy"
+	"traveler/internal/symbols"
+	"traveler/pkg/model"
+)
+
+// 시그널 필터 종류 (trader.signal_filters[].type)
+const (
+	FilterMinProbability = "min_probability" // 승률 value% 미만 제외
+	FilterMinRiskReward  = "min_rr"          // R/R value 미만 제외
+	FilterMaxPrice       = "max_price"       // 진입가 value 초과 제외 (시그널 통화 기준)
+	FilterRSRank         = "rs_rank"         // 3개월 수익률 백분위 value 미만 제외 (같은 스캔 시그널끼리 비교)
+	FilterExcludeSectors = "exclude_sectors" // sectors에 속한 업종 제외 (Yahoo 업종명)
+	FilterEarningsWindow = "earnings_window" // 실적 발표 value일 이내 제외
+)
+
+// SignalFilterSpec 설정 파일의 시그널 필터 하나
+type SignalFilterSpec struct {
+	Type    string
+	Value   float64
+	Sectors []string // exclude_sectors
+	Markets []string // 적용 마켓 (us, kr, crypto, jp, hk, cn — 비우면 전체)
+}
+
+// SectorSource 종목 업종 조회 (provider.FundamentalsChecker)
+type SectorSource interface {
+	Sector(ctx context.Context, symbol string) (string, error)
+}
+
+// CandleSource 일봉 조회 (provider.Provider)
+type CandleSource interface {
+	GetDailyCandles(ctx context.Context, symbol string, days int) ([]model.Candle, error)
+}
+
+// SignalFilterSources 외부 데이터가 필요한 필터의 조회 대상 (nil이면 해당 필터는 통과시킨다)
+type SignalFilterSources struct {
+	Lists    *symbols.SymbolLists // 블랙/화이트리스트 (항상 먼저 적용)
+	Candles  CandleSource         // rs_rank
+	Earnings EarningsSource       // earnings_window
+	Sectors  SectorSource         // exclude_sectors
+}
+
+// SignalFilter 전략 후·사이징 전 시그널 필터 하나. 통과한 시그널과 제외 사유를 반환
+// rs_rank처럼 시그널끼리 비교하는 필터가 있어 시그널 목록 단위로 동작한다.
+type SignalFilter interface {
+	Name() string
+	Filter(ctx context.Context, signals []strategy.Signal, src SignalFilterSources) (kept []strategy.Signal, rejected map[string]string)
+}
+
+// SignalFilterChain 설정 순서대로 적용하는 시그널 필터 체인 (CLI/웹/데몬 공용)
+// 빈 체인(nil 포함)이면 블랙/화이트리스트만 적용된다. 만든 뒤에는 읽기만 한다.
+type SignalFilterChain []SignalFilter
+
+// NewSignalFilterChain 설정(trader.signal_filters)으로 필터 체인 구성
+func NewSignalFilterChain(specs []SignalFilterSpec) (SignalFilterChain, error) {
+	chain := make(SignalFilterChain, 0, len(specs))
+	for i, spec := range specs {
+		f, err := newSignalFilter(spec)
+		if err != nil {
+			return nil, fmt.Errorf("signal filter #%d (%s): %w", i+1, spec.Type, err)
+		}
+		chain = append(chain, f)
+	}
+	return chain, nil
+}
+
+// Needs 체인에 해당 종류 필터가 하나라도 있는지 (업종/실적 조회기 초기화 여부 판단용)
+func (c SignalFilterChain) Needs(kinds ...string) bool {
+	for _, f := range c {
+		for _, k := range kinds {
+			if f.Name() == k {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Apply 블랙/화이트리스트 후 체인의 필터를 순서대로 적용 (제외 사유는 로그)
+func (c SignalFilterChain) Apply(ctx context.Context, signals []strategy.Signal, src SignalFilterSources) []strategy.Signal {
+	signals = FilterBySymbolLists(signals, src.Lists)
+
+	for _, f := range c {
+		if len(signals) == 0 {
+			break
+		}
+		kept, rejected := f.Filter(ctx, signals, src)
+		for _, sig := range signals {
+			if reason, ok := rejected[sig.Stock.Symbol]; ok {
+				log.Printf("[FILTER] %s rejected by %s: %s", sig.Stock.Symbol, f.Name(), reason)
+			}
+		}
+		if len(rejected) > 0 {
+			log.Printf("[FILTER] %s: %d → %d signals", f.Name(), len(signals), len(kept))
+		}
+		signals = kept
+	}
+	return signals
+}
+
+func newSignalFilter(spec SignalFilterSpec) (SignalFilter, error) {
+	markets := make(map[string]bool, len(spec.Markets))
+	for _, m := range spec.Markets {
+		markets[strings.ToLower(strings.TrimSpace(m))] = true
+	}
+	base := signalFilterBase{name: spec.Type, markets: markets}
+
+	switch spec.Type {
+	case FilterMinProbability:
+		if spec.Value <= 0 || spec.Value > 100 {
+			return nil, fmt.Errorf("value must be a probability in (0, 100]")
+		}
+		return perSignalFilter{base, func(_ context.Context, sig *strategy.Signal, _ SignalFilterSources) string {
+			if sig.Probability < spec.Value {
+				return fmt.Sprintf("probability %.0f%% < %.0f%%", sig.Probability, spec.Value)
+			}
+			return ""
+		}}, nil
+	case FilterMinRiskReward:
+		if spec.Value <= 0 {
+			return nil, fmt.Errorf("value must be a positive R/R")
+		}
+		return perSignalFilter{base, func(_ context.Context, sig *strategy.Signal, _ SignalFilterSources) string {
+			if sig.Guide != nil && sig.Guide.RiskRewardRatio < spec.Value {
+				return fmt.Sprintf("R/R %.2f < %.2f", sig.Guide.RiskRewardRatio, spec.Value)
+			}
+			return ""
+		}}, nil
+	case FilterMaxPrice:
+		if spec.Value <= 0 {
+			return nil, fmt.Errorf("value must be a positive price")
+		}
+		return perSignalFilter{base, func(_ context.Context, sig *strategy.Signal, _ SignalFilterSources) string {
+			if sig.Guide != nil && sig.Guide.EntryPrice > spec.Value {
+				cur := money.ForSymbol(sig.Stock.Symbol)
+				return fmt.Sprintf("entry %s > max %s", money.Price(sig.Guide.EntryPrice, cur), money.Price(spec.Value, cur))
+			}
+			return ""
+		}}, nil
+	case FilterRSRank:
+		if spec.Value <= 0 || spec.Value >= 100 {
+			return nil, fmt.Errorf("value must be a percentile in (0, 100)")
+		}
+		return rsRankFilter{signalFilterBase: base, minPct: spec.Value}, nil
+	case FilterExcludeSectors:
+		if len(spec.Sectors) == 0 {
+			return nil, fmt.Errorf("sectors is empty")
+		}
+		excluded := make(map[string]bool, len(spec.Sectors))
+		for _, s := range spec.Sectors {
+			excluded[normalizeSector(s)] = true
+		}
+		return perSignalFilter{base, func(ctx context.Context, sig *strategy.Signal, src SignalFilterSources) string {
+			if src.Sectors == nil || symbols.IsCryptoSymbol(sig.Stock.Symbol) {
+				return ""
+			}
+			sector, err := src.Sectors.Sector(ctx, sig.Stock.Symbol)
+			if err != nil {
+				log.Printf("[FILTER] %s: sector unavailable, not excluding: %v", sig.Stock.Symbol, err)
+				return ""
+			}
+			if excluded[normalizeSector(sector)] {
+				return fmt.Sprintf("sector %s is excluded", sector)
+			}
+			return ""
+		}}, nil
+	case FilterEarningsWindow:
+		if spec.Value < 1 {
+			return nil, fmt.Errorf("value must be at least 1 day")
+		}
+		days := int(spec.Value)
+		return perSignalFilter{base, func(ctx context.Context, sig *strategy.Signal, src SignalFilterSources) string {
+			if src.Earnings == nil || symbols.IsCryptoSymbol(sig.Stock.Symbol) {
+				return ""
+			}
+			check := EarningsBlackoutCheck{Source: src.Earnings, Days: days}
+			if err := check.checkSymbol(ctx, sig.Stock.Symbol); err != nil {
+				return err.Error()
+			}
+			return ""
+		}}, nil
+	}
+	return nil, fmt.Errorf("unknown type (want %s, %s, %s, %s, %s or %s)", FilterMinProbability, FilterMinRiskReward,
+		FilterMaxPrice, FilterRSRank, FilterExcludeSectors, FilterEarningsWindow)
+}
+
+// signalFilterBase 필터 이름과 적용 마켓
+type signalFilterBase struct {
+	name    string
+	markets map[string]bool
+}
+
+func (b signalFilterBase) Name() string { return b.name }
+
+// applies 시그널 종목이 필터 적용 마켓인지
+func (b signalFilterBase) applies(symbol string) bool {
+	return len(b.markets) == 0 || b.markets[SignalMarket(symbol)]
+}
+
+// perSignalFilter 시그널 하나씩 판단하는 필터 (사유가 비어 있으면 통과)
+type perSignalFilter struct {
+	signalFilterBase
+	check func(ctx context.Context, sig *strategy.Signal, src SignalFilterSources) string
+}
+
+func (f perSignalFilter) Filter(ctx context.Context, signals []strategy.Signal, src SignalFilterSources) ([]strategy.Signal, map[string]string) {
+	kept := make([]strategy.Signal, 0, len(signals))
+	rejected := make(map[string]string)
+	for i := range signals {
+		if f.applies(signals[i].Stock.Symbol) {
+			if reason := f.check(ctx, &signals[i], src); reason != "" {
+				rejected[signals[i].Stock.Symbol] = reason
+				continue
+			}
+		}
+		kept = append(kept, signals[i])
+	}
+	return kept, rejected
+}
+
+// rsRankLookback RS 순위 기준 기간 (약 3개월 거래일)
+const rsRankLookback = 63
+
+// rsRankFilter 3개월 수익률 백분위가 minPct 미만이면 제외
+// 유니버스 전체가 아니라 이번 스캔에서 나온 시그널끼리 비교한다 (시그널 1개면 통과).
+type rsRankFilter struct {
+	signalFilterBase
+	minPct float64
+}
+
+func (f rsRankFilter) Filter(ctx context.Context, signals []strategy.Signal, src SignalFilterSources) ([]strategy.Signal, map[string]string) {
+	returns := make(map[string]float64)
+	for _, sig := range signals {
+		if !f.applies(sig.Stock.Symbol) {
+			continue
+		}
+		if r, ok := lookbackReturn(ctx, sig, src.Candles); ok {
+			returns[sig.Stock.Symbol] = r
+		}
+	}
+	rejected := make(map[string]string)
+	if len(returns) < 2 {
+		return signals, rejected
+	}
+
+	sorted := make([]float64, 0, len(returns))
+	for _, r := range returns {
+		sorted = append(sorted, r)
+	}
+	sort.Float64s(sorted)
+
+	kept := make([]strategy.Signal, 0, len(signals))
+	for _, sig := range signals {
+		r, ok := returns[sig.Stock.Symbol]
+		if ok {
+			below := sort.SearchFloat64s(sorted, r) // r보다 낮은 수익률 개수
+			pct := float64(below) / float64(len(sorted)-1) * 100
+			if pct < f.minPct {
+				rejected[sig.Stock.Symbol] = fmt.Sprintf("RS rank %.0f < %.0f (3M return %+.1f%%)", pct, f.minPct, r*100)
+				continue
+			}
+		}
+		kept = append(kept, sig)
+	}
+	return kept, rejected
+}
+
+// lookbackReturn 3개월 수익률 (시그널 캔들이 짧으면 일봉 재조회, 데이터 부족이면 ok=false)
+func lookbackReturn(ctx context.Context, sig strategy.Signal, candles CandleSource) (float64, bool) {
+	c := sig.Candles
+	if len(c) <= rsRankLookback && candles != nil {
+		fetched, err := candles.GetDailyCandles(ctx, sig.Stock.Symbol, rsRankLookback+10)
+		if err != nil {
+			log.Printf("[FILTER] %s: candles unavailable for RS rank: %v", sig.Stock.Symbol, err)
+			return 0, false
+		}
+		c = fetched
+	}
+	if len(c) <= rsRankLookback {
+		return 0, false
+	}
+	base := c[len(c)-1-rsRankLookback].Close
+	if base <= 0 {
+		return 0, false
+	}
+	return c[len(c)-1].Close/base - 1, true
+}
+
+// SignalMarket 심볼 형식으로 마켓 구분 (kr, crypto, jp, hk, cn, binance, 그 외 us)
+func SignalMarket(symbol string) string {
+	if symbols.IsCryptoSymbol(symbol) {
+		return "crypto"
+	}
+	switch money.ForSymbol(symbol) {
+	case money.KRW:
+		return "kr"
+	case money.JPY:
+		return "jp"
+	case money.HKD:
+		return "hk"
+	case money.CNY:
+		return "cn"
+	case money.USDT:
+		return "binance"
+	}
+	return "us"
+}
+
+func normalizeSector(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -1,8 +1,11 @@
 package trader
 
 import (
+	"fmt"
 	"math"
 
+	"traveler/internal/fees"
+	"traveler/internal/money"
 	"traveler/internal/strategy"
 )
 
@@ -15,6 +18,26 @@ type SizerConfig struct {
 	MinRiskReward     float64 // 최소 R/R (이하면 스킵)
 	MinExpectedReturn float64 // 최소 기대수익률 (수수료 커버용, 예: 0.01 = 1%)
 	CommissionRate    float64 // 수수료율 (왕복, 예: 0.005 = 0.5%)
+
+	// StrategyRisk 전략별 리스크 캡 (half-Kelly 등). 실제 리스크 = min(RiskPerTrade, 캡)
+	StrategyRisk map[string]float64
+
+	// Slippage 종목별 편도 예상 슬리피지 (LiquidityModel). 리스크/기대수익 계산에 반영
+	Slippage map[string]float64
+
+	// VolTarget 포트폴리오 일간 변동성 예산 (자본 대비, 예: 0.015 = 1.5%/일, 0 = 비활성)
+	// 초과하면 신규 포지션 수량을 같은 비율로 줄인다. HeldVol은 보유 포지션별 일간 변동성 금액 (HeldPositionVols)
+	VolTarget float64
+	HeldVol   []float64
+
+	// MaxADVPct 주문 수량 상한 (20일 평균 거래량 대비 비율, 0 = 기본 0.5%, 음수 = 비활성)
+	// ADV는 종목별 평균 거래량 맵 (없으면 시그널 일봉/Details에서 계산, 그래도 모르면 상한 없음)
+	MaxADVPct float64
+	ADV       map[string]float64
+
+	// Currency 자본 통화. 비우면 USD/KRW/USDT 종목만 사이징한다
+	// (도쿄/홍콩/중국 종목 가격은 현지 통화라 환율 없이 자본과 비교할 수 없음)
+	Currency money.Currency
 }
 
 // DefaultSizerConfig 기본 설정
@@ -26,10 +49,49 @@ func DefaultSizerConfig(capital float64) SizerConfig {
 		MaxPositions:      5,
 		MinRiskReward:     1.5,
 		MinExpectedReturn: 0.01,   // 1% (수수료 0.5% + 마진 0.5%)
-		CommissionRate:    0.005,  // 0.5% (매수 0.25% + 매도 0.25%)
+		CommissionRate:    CostModelForMarket("us").RoundTripRate(), // kis-us: 0.5% (매수 0.25% + 매도 0.25%)
+	}
+}
+
+// CostModel 거래 비용 모델 (CLI, 웹, 데몬, 백테스트 공용)
+type CostModel struct {
+	Commission float64 // 편도 수수료율 (예: 0.0025 = 0.25%)
+	Slippage   float64 // 편도 예상 슬리피지 (예: 0.001 = 0.1%)
+	SellTax    float64 // 매도 시 거래세 (수수료에 포함되지 않은 경우)
+}
+
+// CostModelForMarket 마켓별 비용 모델 (fees 활성 프리셋 기준)
+func CostModelForMarket(market string) CostModel {
+	f := fees.ForMarket(market)
+	return CostModel{
+		Commission: f.Commission,
+		Slippage:   f.Slippage,
+		SellTax:    f.SellTax,
 	}
 }
 
+// RoundTripRate 왕복 수수료+세금 비율 (슬리피지 제외 — 체결가에 반영)
+func (c CostModel) RoundTripRate() float64 {
+	return c.Commission*2 + c.SellTax
+}
+
+// TotalCost 투자금액 기준 왕복 총 비용 (수수료 + 슬리피지 + 세금)
+func (c CostModel) TotalCost(investAmount float64) float64 {
+	return investAmount * (c.RoundTripRate() + c.Slippage*2)
+}
+
+// KellyFraction Kelly 비율 계산 (0~1로 제한)
+// winRate: 승률 (0-1), avgWin/avgLoss: 평균 수익/손실 (같은 단위)
+func KellyFraction(winRate, avgWin, avgLoss float64) float64 {
+	if avgLoss <= 0 || avgWin <= 0 {
+		return 0
+	}
+	// Kelly = (W * B - L) / B, B = avgWin / avgLoss
+	b := avgWin / avgLoss
+	kelly := (winRate*b - (1 - winRate)) / b
+	return math.Max(0, math.Min(kelly, 1))
+}
+
 // PositionSizer stop-distance 기반 포지션 사이징
 type PositionSizer struct {
 	config SizerConfig
@@ -40,6 +102,15 @@ func NewPositionSizer(cfg SizerConfig) *PositionSizer {
 	return &PositionSizer{config: cfg}
 }
 
+// capitalCurrency 종목 가격이 자본과 같은 통화인지
+func (p *PositionSizer) capitalCurrency(symbol string) bool {
+	cur := money.ForSymbol(symbol)
+	if p.config.Currency != "" {
+		return cur == p.config.Currency
+	}
+	return cur == money.USD || cur == money.KRW || cur == money.USDT
+}
+
 // SizingResult 사이징 결과
 type SizingResult struct {
 	Symbol        string
@@ -52,6 +123,11 @@ type SizingResult struct {
 	InvestAmount  float64 // 투자 금액
 	RiskPct       float64 // 자본 대비 리스크 %
 	AllocationPct float64 // 자본 대비 투자 %
+	ADVPct        float64 // 주문 수량 / 20일 평균 거래량 % (ADV를 모르면 0)
+	ADVCapped     bool    // 거래량 상한으로 수량이 줄었음
+	HedgeSymbol   string  // 페어 숏 레그 (2-leg 시그널만)
+	HedgeQuantity float64 // 숏 레그 수량 (롱 금액 × 헤지 비율)
+	HedgeAmount   float64 // 숏 레그 금액 (InvestAmount에 포함)
 	RiskReward    float64 // R/R 비율
 	Skipped       bool
 	SkipReason    string
@@ -70,6 +146,12 @@ func (p *PositionSizer) CalculateSize(sig *strategy.Signal) SizingResult {
 		return result
 	}
 
+	if !p.capitalCurrency(sig.Stock.Symbol) {
+		result.Skipped = true
+		result.SkipReason = "price currency differs from capital currency"
+		return result
+	}
+
 	g := sig.Guide
 	result.EntryPrice = g.EntryPrice
 	result.StopLoss = g.StopLoss
@@ -83,6 +165,10 @@ func (p *PositionSizer) CalculateSize(sig *strategy.Signal) SizingResult {
 		result.SkipReason = "invalid stop distance"
 		return result
 	}
+
+	// 비유동 종목 페널티: 진입/손절 양쪽 슬리피지만큼 실제 손실폭이 커짐
+	slippage := p.config.Slippage[sig.Stock.Symbol]
+	stopDistance += g.EntryPrice * slippage * 2
 	result.StopDistance = stopDistance
 
 	// 2. R/R 체크
@@ -93,7 +179,7 @@ func (p *PositionSizer) CalculateSize(sig *strategy.Signal) SizingResult {
 	}
 
 	// 3. 기대수익률 체크 (수수료 커버 확인)
-	expectedReturn := (g.Target1 - g.EntryPrice) / g.EntryPrice
+	expectedReturn := (g.Target1-g.EntryPrice)/g.EntryPrice - slippage*2
 	if expectedReturn < p.config.MinExpectedReturn {
 		result.Skipped = true
 		result.SkipReason = "expected return too low (< commission)"
@@ -101,15 +187,24 @@ func (p *PositionSizer) CalculateSize(sig *strategy.Signal) SizingResult {
 	}
 
 	// 4. 가격이 최대 포지션 금액 초과 체크
+	// 목표 비중 시그널(섹터 로테이션)은 리스크 대신 비중만큼 배분
 	maxPositionValue := p.config.TotalCapital * p.config.MaxPositionPct
+	targetWeight := sig.Details["target_weight"]
+	if targetWeight > 0 {
+		maxPositionValue = p.config.TotalCapital * math.Min(targetWeight, 1)
+	}
 	if g.EntryPrice > maxPositionValue {
 		result.Skipped = true
 		result.SkipReason = "price exceeds max position value"
 		return result
 	}
 
-	// 5. 리스크 예산 계산
-	riskBudget := p.config.TotalCapital * p.config.RiskPerTrade
+	// 5. 리스크 예산 계산 (전략별 캡이 있으면 더 작은 값)
+	riskPerTrade := p.config.RiskPerTrade
+	if kellyRisk, ok := p.config.StrategyRisk[baseStrategyName(sig.Strategy)]; ok && kellyRisk < riskPerTrade {
+		riskPerTrade = kellyRisk
+	}
+	riskBudget := p.config.TotalCapital * riskPerTrade
 
 	// Bear regime: 리스크 절반 (자본 보존 우선)
 	if sig.Details != nil {
@@ -122,12 +217,16 @@ func (p *PositionSizer) CalculateSize(sig *strategy.Signal) SizingResult {
 	// qty = floor(riskBudget / stopDistance)
 	qtyByRisk := math.Floor(riskBudget / stopDistance)
 
-	// 7. 최대 포지션 금액 기반 수량 제한
-	qtyByAllocation := math.Floor(maxPositionValue / g.EntryPrice)
+	// 7. 최대 포지션 금액 기반 수량 제한 (페어는 롱+숏 총액 기준)
+	hedgeRatio := 0.0
+	if g.Pair != nil {
+		hedgeRatio = math.Abs(g.Pair.HedgeRatio)
+	}
+	qtyByAllocation := math.Floor(maxPositionValue / (g.EntryPrice * (1 + hedgeRatio)))
 
 	// 8. 둘 중 작은 값 선택
 	qty := qtyByRisk
-	if qtyByAllocation < qty {
+	if qtyByAllocation < qty || targetWeight > 0 {
 		qty = qtyByAllocation
 	}
 
@@ -136,8 +235,45 @@ func (p *PositionSizer) CalculateSize(sig *strategy.Signal) SizingResult {
 		qty = 1
 	}
 
+	// 10. 유동성 상한: 일평균 거래량의 일정 비율 이하 (소형주 주문이 하루 거래량을 잡아먹지 않도록)
+	if adv := p.signalADV(sig); adv > 0 {
+		if frac := p.config.maxADVFraction(); frac > 0 {
+			maxQty := math.Floor(adv * frac)
+			if maxQty < 1 {
+				result.Skipped = true
+				result.SkipReason = fmt.Sprintf("insufficient liquidity (ADV %.0f)", adv)
+				return result
+			}
+			if qty > maxQty {
+				qty = maxQty
+				result.ADVCapped = true
+			}
+		}
+		result.ADVPct = qty / adv * 100
+	}
+
 	result.Quantity = qty
 	result.InvestAmount = qty * g.EntryPrice
+
+	// 11. 페어 숏 레그: 롱 금액 × 헤지 비율로 맞춤 (스프레드 손익만 남도록)
+	if g.Pair != nil {
+		if g.Pair.ShortPrice <= 0 {
+			result.Skipped = true
+			result.SkipReason = "pair short leg has no price"
+			return result
+		}
+		hedgeQty := math.Floor(result.InvestAmount * hedgeRatio / g.Pair.ShortPrice)
+		if hedgeQty < 1 {
+			result.Skipped = true
+			result.SkipReason = fmt.Sprintf("pair hedge leg %s rounds to zero shares", g.Pair.ShortSymbol)
+			return result
+		}
+		result.HedgeSymbol = g.Pair.ShortSymbol
+		result.HedgeQuantity = hedgeQty
+		result.HedgeAmount = hedgeQty * g.Pair.ShortPrice
+		result.InvestAmount += result.HedgeAmount
+	}
+
 	result.RiskAmount = qty * stopDistance
 	result.RiskPct = result.RiskAmount / p.config.TotalCapital * 100
 	result.AllocationPct = result.InvestAmount / p.config.TotalCapital * 100
@@ -191,6 +327,7 @@ type PortfolioSummary struct {
 // ApplyToSignals 시그널들에 사이징 결과 적용
 func (p *PositionSizer) ApplyToSignals(signals []strategy.Signal) []strategy.Signal {
 	results, _ := p.CalculatePortfolio(signals)
+	p.applyVolTarget(signals, results)
 
 	sized := make([]strategy.Signal, 0)
 	for i, result := range results {
@@ -205,6 +342,9 @@ func (p *PositionSizer) ApplyToSignals(signals []strategy.Signal) []strategy.Sig
 			sig.Guide.RiskAmount = result.RiskAmount
 			sig.Guide.RiskPct = result.RiskPct
 			sig.Guide.AllocationPct = result.AllocationPct
+			if sig.Guide.Pair != nil {
+				sig.Guide.Pair.ShortQuantity = result.HedgeQuantity
+			}
 		}
 		sized = append(sized, sig)
 	}
@@ -212,11 +352,24 @@ func (p *PositionSizer) ApplyToSignals(signals []strategy.Signal) []strategy.Sig
 	return sized
 }
 
+// SizerConfigForMarket 마켓별 잔고 기반 Sizer 설정 (kr, crypto, 그 외 us)
+func SizerConfigForMarket(market string, balance float64) SizerConfig {
+	switch market {
+	case "kr":
+		return AdjustConfigForKRBalance(balance)
+	case "crypto":
+		return AdjustConfigForCryptoBalance(balance)
+	default:
+		return AdjustConfigForBalance(balance)
+	}
+}
+
 // AdjustConfigForCryptoBalance adjusts sizer config for crypto trading
 func AdjustConfigForCryptoBalance(balance float64) SizerConfig {
 	cfg := DefaultSizerConfig(balance)
-	cfg.CommissionRate = 0.001     // 0.1% (Upbit: 0.05% each side)
+	cfg.CommissionRate = CostModelForMarket("crypto").RoundTripRate() // upbit: 0.1% (0.05% each side)
 	cfg.MinExpectedReturn = 0.005  // 0.5%
+	cfg.MaxADVPct = -1             // 시그널 캔들이 분봉이라 ADV 상한 미적용
 
 	switch {
 	case balance < 100000: // 10만원 미만
@@ -0,0 +1,106 @@
+package trader
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"traveler/internal/broker"
+)
+
+// ThrottleConfig 주문 속도 제한 (KIS 초당 거래건수 제한, 이상거래 탐지 회피)
+type ThrottleConfig struct {
+	MinInterval  time.Duration // 주문 간 최소 간격
+	Jitter       time.Duration // 간격에 더하는 0~Jitter 랜덤 지연
+	MaxPerMinute int           // 최근 1분 최대 주문 수 (0 = 제한 없음)
+	MaxRetries   int           // 일시적 rate 에러 재시도 횟수
+	RetryBackoff time.Duration // 첫 재시도 대기 (매 회 2배)
+}
+
+// DefaultThrottleConfig 기본값: 1~1.5초 간격, 분당 20건, rate 에러 3회 재시도
+func DefaultThrottleConfig() ThrottleConfig {
+	return ThrottleConfig{
+		MinInterval:  time.Second,
+		Jitter:       500 * time.Millisecond,
+		MaxPerMinute: 20,
+		MaxRetries:   3,
+		RetryBackoff: 2 * time.Second,
+	}
+}
+
+// OrderThrottle 주문 직전 대기 (Executor의 매수/매도가 공유)
+type OrderThrottle struct {
+	cfg ThrottleConfig
+
+	mu     sync.Mutex
+	last   time.Time
+	recent []time.Time // 최근 1분 주문 시각
+}
+
+// NewOrderThrottle 생성자
+func NewOrderThrottle(cfg ThrottleConfig) *OrderThrottle {
+	return &OrderThrottle{cfg: cfg}
+}
+
+// Wait 다음 주문이 허용될 때까지 대기. 동시 호출은 순서대로 직렬화된다.
+func (t *OrderThrottle) Wait(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+	if !t.last.IsZero() && t.cfg.MinInterval > 0 {
+		gap := t.cfg.MinInterval
+		if t.cfg.Jitter > 0 {
+			gap += time.Duration(rand.Int63n(int64(t.cfg.Jitter)))
+		}
+		if d := t.last.Add(gap).Sub(now); d > wait {
+			wait = d
+		}
+	}
+	if t.cfg.MaxPerMinute > 0 {
+		cutoff := now.Add(-time.Minute)
+		i := 0
+		for i < len(t.recent) && !t.recent[i].After(cutoff) {
+			i++
+		}
+		t.recent = t.recent[i:]
+		if len(t.recent) >= t.cfg.MaxPerMinute {
+			oldest := t.recent[len(t.recent)-t.cfg.MaxPerMinute]
+			if d := oldest.Add(time.Minute).Sub(now); d > wait {
+				wait = d
+				log.Printf("[EXECUTOR] %d orders in the last minute, pausing %s", len(t.recent), d.Round(time.Second))
+			}
+		}
+	}
+
+	if wait > 0 {
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+	t.last = time.Now()
+	if t.cfg.MaxPerMinute > 0 {
+		t.recent = append(t.recent, t.last)
+	}
+	return nil
+}
+
+// IsOrderRateError 브로커의 일시적 주문 속도 제한 에러 여부 (주문이 접수되지 않았으므로 재시도 안전)
+// KIS: EGW00201 "초당 거래건수를 초과하였습니다", Upbit: too_many_requests, 공통: HTTP 429
+func IsOrderRateError(err error) bool {
+	return broker.IsRateLimited(err)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
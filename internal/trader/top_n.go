@@ -0,0 +1,137 @@
+package trader
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"traveler/internal/strategy"
+)
+
+// 시그널 순위 기준 (trader.top_n.rank_by)
+const (
+	RankByProbability = "probability" // 승률
+	RankByRiskReward  = "rr"          // R/R
+	RankByRS          = "rs"          // 3개월 수익률 (시그널에 일봉이 있을 때만)
+	RankByStrength    = "strength"    // 시그널 강도
+	RankByScore       = "score"       // 통합 점수 (모닝딥 분석과 같은 척도, 일봉 시그널만)
+)
+
+// DefaultTopN 사이징/매매 후보 기본 개수
+const DefaultTopN = 10
+
+// TopNPolicy 사이징 전 후보 선택 규칙 (CLI/웹/데몬 공통)
+type TopNPolicy struct {
+	Count  int      // 0 = DefaultTopN, 음수 = 제한 없음
+	RankBy []string // 앞 기준이 같으면 다음 기준으로 (비우면 probability, rr, rs)
+}
+
+var (
+	topNPolicy   = TopNPolicy{Count: DefaultTopN, RankBy: []string{RankByProbability, RankByRiskReward, RankByRS}}
+	topNPolicyMu sync.RWMutex
+)
+
+// SetTopNPolicy 후보 선택 규칙 설정. 스캔 시작 전에 호출한다.
+func SetTopNPolicy(p TopNPolicy) error {
+	if p.Count == 0 {
+		p.Count = DefaultTopN
+	}
+	if len(p.RankBy) == 0 {
+		p.RankBy = []string{RankByProbability, RankByRiskReward, RankByRS}
+	}
+	keys := make([]string, 0, len(p.RankBy))
+	for _, k := range p.RankBy {
+		k = strings.ToLower(strings.TrimSpace(k))
+		switch k {
+		case RankByProbability, RankByRiskReward, RankByRS, RankByStrength, RankByScore:
+			keys = append(keys, k)
+		default:
+			return fmt.Errorf("unknown rank key %q (want %s, %s, %s, %s or %s)", k, RankByProbability, RankByRiskReward, RankByRS, RankByStrength, RankByScore)
+		}
+	}
+	p.RankBy = keys
+	topNPolicyMu.Lock()
+	topNPolicy = p
+	topNPolicyMu.Unlock()
+	return nil
+}
+
+// CurrentTopNPolicy 현재 후보 선택 규칙
+func CurrentTopNPolicy() TopNPolicy {
+	topNPolicyMu.RLock()
+	defer topNPolicyMu.RUnlock()
+	return topNPolicy
+}
+
+// RankSignals 설정된 기준 순서로 정렬 (제자리). 모두 같으면 종목·전략 이름 순이라
+// 스캔 순서(워커 완료 순서)와 무관하게 실행마다 같은 순서가 나온다. 비어 있는 시그널 ID도 채운다.
+func RankSignals(signals []strategy.Signal) {
+	strategy.AssignSignalIDs(signals, time.Now())
+	keys := CurrentTopNPolicy().RankBy
+	values := make([][]float64, len(signals))
+	for i := range signals {
+		values[i] = rankValues(signals[i], keys)
+	}
+	idx := make([]int, len(signals))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		va, vb := values[idx[a]], values[idx[b]]
+		for k := range va {
+			if va[k] != vb[k] {
+				return va[k] > vb[k]
+			}
+		}
+		sa, sb := signals[idx[a]], signals[idx[b]]
+		if sa.Stock.Symbol != sb.Stock.Symbol {
+			return sa.Stock.Symbol < sb.Stock.Symbol
+		}
+		return sa.Strategy < sb.Strategy
+	})
+	sorted := make([]strategy.Signal, len(signals))
+	for i, j := range idx {
+		sorted[i] = signals[j]
+	}
+	copy(signals, sorted)
+}
+
+// SelectTopN 순위를 매겨 상위 Count개만 반환 (사이징 직전에 호출)
+func SelectTopN(signals []strategy.Signal) []strategy.Signal {
+	RankSignals(signals)
+	if n := CurrentTopNPolicy().Count; n > 0 && len(signals) > n {
+		return signals[:n]
+	}
+	return signals
+}
+
+// rankValues 시그널의 기준별 값 (값이 없으면 가장 낮은 순위)
+func rankValues(sig strategy.Signal, keys []string) []float64 {
+	const missing = -1e18
+	v := make([]float64, len(keys))
+	for i, k := range keys {
+		v[i] = missing
+		switch k {
+		case RankByProbability:
+			v[i] = sig.Probability
+		case RankByStrength:
+			v[i] = sig.Strength
+		case RankByScore:
+			if sig.Technical != nil && sig.Technical.Score > 0 {
+				v[i] = sig.Technical.Score
+			}
+		case RankByRiskReward:
+			if sig.Guide != nil {
+				v[i] = sig.Guide.RiskRewardRatio
+			}
+		case RankByRS:
+			if r, ok := lookbackReturn(context.Background(), sig, nil); ok {
+				v[i] = r
+			}
+		}
+	}
+	return v
+}
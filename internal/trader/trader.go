@@ -8,17 +8,23 @@ import (
 	"time"
 
 	"traveler/internal/broker"
+	"traveler/internal/money"
+	"traveler/internal/provider"
 	"traveler/internal/strategy"
 )
 
 // Config 자동 매매 설정
 type Config struct {
-	DryRun          bool          // 모의 실행 (실제 주문 안함)
-	MaxPositions    int           // 최대 동시 포지션 수
-	MaxPositionPct  float64       // 종목당 최대 투자 비율 (예: 0.2 = 20%)
-	TotalCapital    float64       // 총 투자 자본
-	RiskPerTrade    float64       // 거래당 리스크 비율 (예: 0.01 = 1%)
-	MonitorInterval time.Duration // 포지션 모니터링 주기
+	DryRun          bool             // 모의 실행 (실제 주문 안함)
+	MaxPositions    int              // 최대 동시 포지션 수
+	MaxPositionPct  float64          // 종목당 최대 투자 비율 (예: 0.2 = 20%)
+	TotalCapital    float64          // 총 투자 자본
+	RiskPerTrade    float64          // 거래당 리스크 비율 (예: 0.01 = 1%)
+	MonitorInterval time.Duration    // 포지션 모니터링 주기
+	Throttle        ThrottleConfig   // 주문 속도 제한 (zero 값이면 DefaultThrottleConfig)
+	Caps            ValueCaps        // 주문/종목 최대 금액 (0인 항목은 DefaultValueCaps)
+	PriceCheck      PriceCheckConfig // 주문 직전 시세 확인 (0인 항목은 DefaultPriceCheckConfig)
+	EODClose        EODPolicy        // 전략별 마감 전 청산 (nil이면 DefaultEODPolicy)
 }
 
 // DefaultConfig 기본 설정
@@ -30,6 +36,9 @@ func DefaultConfig() Config {
 		TotalCapital:    10000,
 		RiskPerTrade:    0.01,
 		MonitorInterval: 30 * time.Second,
+		Throttle:        DefaultThrottleConfig(),
+		Caps:            DefaultValueCaps(),
+		PriceCheck:      DefaultPriceCheckConfig(),
 	}
 }
 
@@ -41,6 +50,8 @@ type AutoTrader struct {
 	monitor   *Monitor
 	risk      *RiskManager
 	planStore *PlanStore
+	dividends *provider.DividendCalendar
+	entryGate func() error // 신규 매수 허용 여부 (nil 반환 시 허용)
 
 	mu         sync.RWMutex
 	isRunning  bool
@@ -67,8 +78,47 @@ func NewAutoTraderWithPlanStore(cfg Config, b broker.Broker, marketOrder bool, p
 	}
 }
 
+// SetOrderJournal 주문 의도 저널 설정 (재시도·재시작 시 중복 매수 방지)
+func (t *AutoTrader) SetOrderJournal(j *OrderJournal) {
+	t.executor.journal = j
+}
+
+// SetEntryGate 신규 매수 전 확인 함수 설정 (시작 시 대조 미확인 안전 모드 등)
+func (t *AutoTrader) SetEntryGate(fn func() error) {
+	t.entryGate = fn
+}
+
+// AddPreTradeChecks 주문 직전 검사 추가 (금액 한도/시세/중복 기본 검사 앞에 순서대로 실행)
+func (t *AutoTrader) AddPreTradeChecks(checks ...PreTradeCheck) {
+	t.executor.checks = append(t.executor.checks, checks...)
+	if len(t.executor.checks) > 0 {
+		log.Printf("[TRADER] Pre-trade checks: %s", describeChecks(t.executor.checks))
+	}
+}
+
+// PlaceOrder 수동 주문 (웹/RPC): 신규 매수는 진입 게이트 확인 후, 매수/매도 모두 주문 직전 검사·주문 의도 저널을 거친다
+func (t *AutoTrader) PlaceOrder(ctx context.Context, order broker.Order) (*broker.OrderResult, error) {
+	if order.Side == broker.OrderSideBuy && t.entryGate != nil {
+		if err := t.entryGate(); err != nil {
+			return nil, err
+		}
+	}
+	return t.executor.ExecuteOrder(ctx, order)
+}
+
+// SetDividendCalendar 신규 플랜에 배당락일 기록 (nil이면 생략, 크립토는 설정하지 않음)
+func (t *AutoTrader) SetDividendCalendar(c *provider.DividendCalendar) {
+	t.dividends = c
+}
+
 // ExecuteSignals Signal 목록을 받아 주문 실행
 func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Signal) ([]ExecutionResult, error) {
+	if t.entryGate != nil {
+		if err := t.entryGate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// 1. 현재 포지션 확인
 	positions, err := t.broker.GetPositions(ctx)
 	if err != nil {
@@ -98,13 +148,18 @@ func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Sign
 	// 3. 투자 요약
 	totalInvest := t.risk.CalculateTotalInvestment(approved)
 	totalRisk := t.risk.CalculateTotalRisk(approved)
-	log.Printf("[TRADER] Executing %d orders: invest=$%.2f, risk=$%.2f (%.2f%%)",
-		len(approved), totalInvest, totalRisk, totalRisk/t.config.TotalCapital*100)
+	cur := money.ForSymbol(approved[0].Stock.Symbol)
+	log.Printf("[TRADER] Executing %d orders: invest=%s, risk=%s (%.2f%%)",
+		len(approved), money.Format(totalInvest, cur), money.Format(totalRisk, cur), totalRisk/t.config.TotalCapital*100)
 
 	// 4. 주문 실행
 	results := make([]ExecutionResult, 0, len(approved))
 	for _, sig := range approved {
 		result := t.executor.Execute(ctx, sig)
+		entryTime := time.Now()
+		if result.Success {
+			result.TradeID = NewTradeID(sig.Stock.Symbol, entryTime)
+		}
 		results = append(results, result)
 
 		if result.Success {
@@ -114,12 +169,12 @@ func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Sign
 				actualEntryPrice = result.Result.AvgPrice
 			}
 
-			if result.Order.Type == broker.OrderTypeMarket {
-				log.Printf("[EXECUTED] %s: MARKET BUY ₩%.0f",
-					sig.Stock.Symbol, result.Order.Amount)
+			if result.Order.Type == broker.OrderTypeMarket && result.Order.Amount > 0 {
+				log.Printf("[EXECUTED] %s: MARKET BUY %s",
+					sig.Stock.Symbol, money.Format(result.Order.Amount, money.ForSymbol(sig.Stock.Symbol)))
 			} else {
-				log.Printf("[EXECUTED] %s: BUY %.0f shares @ $%.2f",
-					sig.Stock.Symbol, result.Order.Quantity, actualEntryPrice)
+				log.Printf("[EXECUTED] %s: BUY %.0f shares @ %s",
+					sig.Stock.Symbol, result.Order.Quantity, money.Price(actualEntryPrice, money.ForSymbol(sig.Stock.Symbol)))
 			}
 
 			// 모니터링 등록 (전략 정보 포함)
@@ -134,7 +189,7 @@ func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Sign
 					sig.Guide.Target2,
 					sig.Strategy,
 					maxDays,
-					time.Now(),
+					entryTime,
 				)
 
 				// Trailing stop 설정
@@ -142,6 +197,11 @@ func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Sign
 					t.monitor.SetTrailingStop(sig.Stock.Symbol,
 						true, sig.Guide.EntryATR, sig.Guide.TrailingMultiplier)
 				}
+				// 3분할 익절
+				if sig.Guide.T2Fraction > 0 {
+					t.monitor.SetTranches(sig.Stock.Symbol,
+						sig.Guide.Target3, sig.Guide.T1Fraction, sig.Guide.T2Fraction)
+				}
 
 				// PlanStore에 저장
 				if t.planStore != nil {
@@ -154,11 +214,15 @@ func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Sign
 						Target1:            sig.Guide.Target1,
 						Target2:            sig.Guide.Target2,
 						Target1Hit:         false,
-						EntryTime:          time.Now(),
+						EntryTime:          entryTime,
 						MaxHoldDays:        maxDays,
 						UseTrailingStop:    sig.Guide.UseTrailingStop,
 						TrailingATR:        sig.Guide.EntryATR,
 						TrailingMultiplier: sig.Guide.TrailingMultiplier,
+						Target3:            sig.Guide.Target3,
+						T1Fraction:         sig.Guide.T1Fraction,
+						T2Fraction:         sig.Guide.T2Fraction,
+						TradeID:            result.TradeID,
 					}
 
 					// Breakout: store breakout level for invalidation check
@@ -168,6 +232,7 @@ func (t *AutoTrader) ExecuteSignals(ctx context.Context, signals []strategy.Sign
 						}
 					}
 
+					AnnotateDividend(ctx, t.dividends, plan)
 					t.planStore.Save(plan)
 				}
 			}
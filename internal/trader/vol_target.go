@@ -0,0 +1,119 @@
+package trader
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"traveler/internal/broker"
+	"traveler/internal/provider"
+	"traveler/internal/strategy"
+)
+
+// volTargetCorrelation 포트폴리오 변동성 추정 시 가정하는 종목 간 평균 상관계수
+// 종목별 공분산을 추정할 만큼 데이터가 없으므로 단일 값으로 근사 (주식 모멘텀 바스켓은 대략 0.4~0.6)
+const volTargetCorrelation = 0.5
+
+// PortfolioDailyVol 종목별 일간 변동성(금액)으로 포트폴리오 일간 변동성 추정
+// σp² = (1-ρ)·Σσi² + ρ·(Σσi)²
+func PortfolioDailyVol(vols []float64) float64 {
+	var sum, sumSq float64
+	for _, v := range vols {
+		sum += v
+		sumSq += v * v
+	}
+	rho := volTargetCorrelation
+	return math.Sqrt((1-rho)*sumSq + rho*sum*sum)
+}
+
+// volScale 기존 포지션(held)에 신규 포지션(new)을 k배로 더했을 때 포트폴리오 변동성이 target 이하가 되는 최대 k (0~1)
+func volScale(held, added []float64, target float64) float64 {
+	var se, qe, sn, qn float64
+	for _, v := range held {
+		se += v
+		qe += v * v
+	}
+	for _, v := range added {
+		sn += v
+		qn += v * v
+	}
+	rho := volTargetCorrelation
+	c := (1-rho)*qe + rho*se*se - target*target
+	if c >= 0 {
+		return 0 // 보유 포지션만으로 예산 소진
+	}
+	a := (1-rho)*qn + rho*sn*sn
+	if a <= 0 {
+		return 1
+	}
+	b := 2 * rho * se * sn
+	k := (-b + math.Sqrt(b*b-4*a*c)) / (2 * a)
+	return math.Min(1, k)
+}
+
+// signalDailyVol 신규 포지션 1주당 일간 변동성 (ATR, 없으면 손절폭으로 근사)
+func signalDailyVol(sig *strategy.Signal) float64 {
+	g := sig.Guide
+	if g.EntryATR > 0 {
+		return g.EntryATR
+	}
+	return g.EntryPrice - g.StopLoss
+}
+
+// applyVolTarget 신규 포지션 수량을 일괄 축소해 포트폴리오 일간 변동성을 예산 이하로 유지
+func (p *PositionSizer) applyVolTarget(signals []strategy.Signal, results []SizingResult) {
+	if p.config.VolTarget <= 0 {
+		return
+	}
+	var added []float64
+	for i := range results {
+		if !results[i].Skipped {
+			added = append(added, results[i].Quantity*signalDailyVol(&signals[i]))
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	target := p.config.TotalCapital * p.config.VolTarget
+	k := volScale(p.config.HeldVol, added, target)
+	if k >= 1 {
+		return
+	}
+	log.Printf("[SIZER] Vol target %.2f%%/day: held %.2f%%, new positions scaled to %.0f%%",
+		p.config.VolTarget*100, PortfolioDailyVol(p.config.HeldVol)/p.config.TotalCapital*100, k*100)
+
+	for i := range results {
+		r := &results[i]
+		if r.Skipped {
+			continue
+		}
+		qty := math.Floor(r.Quantity * k)
+		if qty < 1 {
+			r.Skipped = true
+			r.SkipReason = "portfolio volatility budget exhausted"
+			continue
+		}
+		r.Quantity = qty
+		r.InvestAmount = qty * r.EntryPrice
+		r.RiskAmount = qty * r.StopDistance
+		r.RiskPct = r.RiskAmount / p.config.TotalCapital * 100
+		r.AllocationPct = r.InvestAmount / p.config.TotalCapital * 100
+	}
+}
+
+// HeldPositionVols 보유 포지션별 일간 변동성 (수량 × 14일 ATR, 캔들 조회 실패 종목은 제외)
+func HeldPositionVols(ctx context.Context, p provider.Provider, positions []broker.Position) []float64 {
+	vols := make([]float64, 0, len(positions))
+	for _, pos := range positions {
+		candles, err := p.GetDailyCandles(ctx, pos.Symbol, 30)
+		if err != nil || len(candles) < 15 {
+			log.Printf("[SIZER] %s: ATR unavailable for vol target, skipping", pos.Symbol)
+			continue
+		}
+		if atr := strategy.CalculateATR(candles, 14); atr > 0 {
+			vols = append(vols, pos.Quantity*atr)
+		}
+	}
+	return vols
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -17,11 +18,15 @@ import (
 	"time"
 
 	"traveler/internal/ai"
+	"traveler/internal/backtest"
 	"database/sql"
 
 	_ "modernc.org/sqlite"
 
 	"traveler/internal/broker"
+	"traveler/internal/dailystate"
+	"traveler/internal/daemon"
+	"traveler/internal/money"
 	"traveler/internal/provider"
 	"traveler/internal/strategy"
 	"traveler/internal/symbols"
@@ -32,12 +37,29 @@ import (
 // createMarketAwareStrategies creates a regime-aware meta strategy for stock markets (US/KR).
 // Uses StockMetaStrategy with optimized regime-strategy mapping, matching the daemon.
 // capital=0 means unspecified → uses "full" tier (backward compatible).
-func createMarketAwareStrategies(p provider.Provider, market string, capital float64) []strategy.Strategy {
+func createMarketAwareStrategies(p provider.Provider, market string, capital float64, tranches strategy.TrancheExit) []strategy.Strategy {
 	metaCfg := strategy.DefaultStockMetaConfig(market, capital)
+	metaCfg.Tranches = tranches
 	meta := strategy.NewStockMetaStrategy(metaCfg, p)
 	return []strategy.Strategy{meta}
 }
 
+// trancheExit config trader.tranches (잘못된 설정이면 꺼짐 — CLI가 시작 시 검증한다)
+func (s *Server) trancheExit() strategy.TrancheExit {
+	if s.config == nil {
+		return strategy.TrancheExit{}
+	}
+	tr := s.config.Trader.Tranches
+	t, err := strategy.TrancheExit{
+		Enabled: tr.Enabled, T1R: tr.T1R, T2R: tr.T2R, T3R: tr.T3R,
+		T1Fraction: tr.T1Fraction, T2Fraction: tr.T2Fraction, TrailATR: tr.TrailATR,
+	}.Normalized()
+	if err != nil {
+		return strategy.TrancheExit{}
+	}
+	return t
+}
+
 // ScanRequest represents a scan request
 type ScanRequest struct {
 	Capital  float64  `json:"capital"`
@@ -75,6 +97,14 @@ type ScanResponse struct {
 	// AI filter info
 	AIFiltered       int             `json:"ai_filtered,omitempty"`       // signals rejected by AI
 	AIRejections     []ai.AIRejection `json:"ai_rejections,omitempty"`    // rejected signals with reasons
+
+	// Resumable scan info
+	Partial bool `json:"partial,omitempty"` // soft limit에 걸려 일부 종목 미분석 — 다시 스캔하면 이어서 진행
+	Resumed int  `json:"resumed,omitempty"` // 이전 스캔 체크포인트에서 이어받은 종목 수
+
+	// 요청에서 지정한 스캔 대상 (비어 있으면 자본 기반 기본 티어)
+	Universe string   `json:"universe,omitempty"`
+	Symbols  []string `json:"symbols,omitempty"`
 }
 
 // SignalWithChart extends Signal with chart data and fundamentals
@@ -96,7 +126,7 @@ type StockResponse struct {
 type PortfolioRequest struct {
 	Capital  float64           `json:"capital"`
 	Signals  []strategy.Signal `json:"signals"`
-	Excluded []string          `json:"excluded,omitempty"`
+	Excluded []string          `json:"excluded,omitempty"` // 시그널 ID (이전 클라이언트는 종목 코드)
 }
 
 // UniverseResponse represents available universes
@@ -141,14 +171,19 @@ func (s *Server) getProviderForMarket(market string) provider.Provider {
 
 // webStockLoader implements trader.StockLoader for web scanning
 type webStockLoader struct {
-	korean bool // true이면 한국 유니버스에서 종목명 적용
-	crypto bool // true이면 크립토 유니버스에서 종목명 적용
+	korean  bool     // true이면 한국 유니버스에서 종목명 적용
+	crypto  bool     // true이면 크립토 유니버스에서 종목명 적용
+	custom  []string // scanUniverseCustom 요청 시 반환할 심볼
+	dataDir string   // watchlist:<name> 해석용
 }
 
 func (l *webStockLoader) LoadUniverse(ctx context.Context, u symbols.Universe) ([]model.Stock, error) {
-	syms := symbols.GetUniverse(u)
-	if syms == nil {
-		return nil, fmt.Errorf("unknown universe: %s", u)
+	syms := l.custom
+	if u != scanUniverseCustom {
+		var err error
+		if syms, err = symbols.ResolveUniverse(u, l.dataDir); err != nil {
+			return nil, err
+		}
 	}
 	stocks := make([]model.Stock, len(syms))
 	for i, sym := range syms {
@@ -163,6 +198,34 @@ func (l *webStockLoader) LoadUniverse(ctx context.Context, u symbols.Universe) (
 	return stocks, nil
 }
 
+// submitScan 스캔 요청 검증 후 작업 큐에 추가 → (작업, 앞선 작업 수)
+func (s *Server) submitScan(market string, req ScanRequest, cont bool) (*scanJob, int, error) {
+	target, err := s.normalizeScanTarget(market, scanTarget{Universe: req.Universe, Symbols: req.Symbols})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 작업 큐에 추가 — 같은 마켓 스캔은 순서대로 하나씩 실행
+	job := &scanJob{
+		ID:         newScanJobID(),
+		Market:     market,
+		Capital:    req.Capital,
+		scanTarget: target,
+		Continue:   cont,
+		Status:     "queued",
+		EnqueuedAt: time.Now(),
+	}
+	s.saveScanSettings(market, scanSettings{Capital: job.Capital, scanTarget: target})
+	ahead, err := s.enqueueScanJob(job)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ahead > 0 {
+		log.Printf("[WEB] %s scan queued (job %s, %d ahead)", market, job.ID, ahead)
+	}
+	return job, ahead, nil
+}
+
 // handleScan starts an async scan (POST) — browser polls /api/scan/status
 func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -183,103 +246,49 @@ func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if scan already running for this market
-	s.scanMu.RLock()
-	running := false
-	switch market {
-	case "kr":
-		running = s.scanKR.Status == "running"
-	case "crypto":
-		running = s.scanCrypto.Status == "running"
-	default:
-		running = s.scan.Status == "running"
-	}
-	s.scanMu.RUnlock()
-	if running {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]string{"status": "already_running"})
-		return
+	// 본문(ScanRequest)은 선택 — capital은 쿼리값이 우선
+	var req ScanRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			http.Error(w, "invalid scan request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 	}
-
-	// Parse capital — query param > broker balance > default
-	capital := s.capital
 	if c := r.URL.Query().Get("capital"); c != "" {
 		if v, err := strconv.ParseFloat(c, 64); err == nil {
-			capital = v
-		}
-	} else {
-		// 실제 브로커 잔고 조회
-		var b broker.Broker
-		switch market {
-		case "kr":
-			b = s.brokerKR
-		case "crypto":
-			b = s.brokerCrypto
-		default:
-			b = s.broker
-		}
-		if b != nil {
-			if bal, err := b.GetBalance(context.Background()); err == nil && bal.TotalEquity > 0 {
-				capital = bal.TotalEquity
-				log.Printf("[WEB] Using actual broker balance for %s: %.2f", market, capital)
-			}
+			req.Capital = v
 		}
 	}
-
-	// Init scan state per market
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-	s.scanMu.Lock()
-	switch market {
-	case "kr":
-		s.scanKRCancel = cancel
-		s.scanKR = scanState{
-			Status:    "running",
-			Message:   "Starting KR adaptive scan...",
-			StartedAt: time.Now(),
-		}
-	case "crypto":
-		s.scanCryptoCancel = cancel
-		s.scanCrypto = scanState{
-			Status:    "running",
-			Message:   "Starting crypto scan...",
-			StartedAt: time.Now(),
-		}
-	default:
-		s.scanCancel = cancel
-		s.scan = scanState{
-			Status:    "running",
-			Message:   "Starting adaptive multi-strategy scan...",
-			StartedAt: time.Now(),
-		}
+	job, ahead, err := s.submitScan(market, req, r.URL.Query().Get("continue") != "")
+	if errors.Is(err, errScanQueueFull) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
 	}
-	s.scanMu.Unlock()
-
-	switch market {
-	case "kr":
-		log.Printf("[WEB] KR scan starting (capital=₩%.0f)", capital)
-		go s.runKRScanAsync(ctx, cancel, capital)
-	case "crypto":
-		log.Printf("[WEB] Crypto scan starting (capital=₩%.0f)", capital)
-		go s.runCryptoScanAsync(ctx, cancel, capital)
-	default:
-		log.Printf("[WEB] Adaptive scan starting (capital=$%.2f)", capital)
-		go s.runScanAsync(ctx, cancel, capital)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "started"})
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "started",
+		"job_id":   job.ID,
+		"queued":   ahead > 0,
+		"position": ahead,
+	})
 }
 
 // runScanAsync runs the scan in background, updating scanState as it goes
-func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, capital float64) {
+func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, capital float64, cp *scanCheckpoint, target scanTarget) {
 	defer cancel()
 	startTime := time.Now()
 
 	// Caching provider: each stock fetched once, shared across strategies
 	cachedProvider := provider.NewCachingProvider(s.provider, 250)
+	cachedProvider.SetHealthStore(s.health)
 
 	capitalTier := strategy.GetCapitalTier("us", capital)
-	strategies := createMarketAwareStrategies(cachedProvider, "us", capital)
+	strategies := createMarketAwareStrategies(cachedProvider, "us", capital, s.trancheExit())
 	meta := strategies[0].(*strategy.StockMetaStrategy)
 	regimeInfo := meta.GetRegimeInfo(ctx)
 	activeStrats := meta.GetActiveStrategyNames(ctx)
@@ -293,9 +302,21 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 		for i, stock := range stocks {
 			select {
 			case <-ctx.Done():
+				cp.Save()
 				return signals, ctx.Err()
 			default:
 			}
+			if cp.ShouldStop() {
+				return signals, nil
+			}
+			if prev, done := cp.Lookup(stock.Symbol); done {
+				if prev != nil {
+					signals = append(signals, *prev)
+					totalFound++
+				}
+				totalScanned++
+				continue
+			}
 
 			stockCtx, stockCancel := context.WithTimeout(ctx, 15*time.Second)
 
@@ -309,6 +330,9 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 				}
 			}
 			stockCancel()
+			if ctx.Err() == nil {
+				cp.Record(stock.Symbol, best)
+			}
 
 			if best != nil {
 				signals = append(signals, *best)
@@ -335,12 +359,21 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 		scanner.SetTierFunc(trader.GetUSETFTiers)
 	}
 
-	result, err := scanner.Scan(ctx, &webStockLoader{})
+	scanner.SetSymbolLists(s.reloadSymbolLists())
+	scanner.SetSignalFilters(s.filters, s.signalFilterSources(ctx, cachedProvider))
+
+	loader := &webStockLoader{dataDir: s.dataDir}
+	applyScanTarget(scanner, loader, target)
+
+	result, err := scanner.Scan(ctx, loader)
 	if err != nil {
 		log.Printf("[WEB] Scan error: %v", err)
 		s.scanMu.Lock()
 		s.scan.Status = "error"
 		s.scan.Error = err.Error()
+		if ctx.Err() != nil {
+			s.scan.Error += " (progress saved — scan again to resume)"
+		}
 		s.scanMu.Unlock()
 		return
 	}
@@ -393,10 +426,7 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 	s.updateScanProgress("Applying position sizing...", totalScanned, totalFound)
 
 	sizer := trader.NewPositionSizer(sizerCfg)
-	sized := sizer.ApplyToSignals(result.Signals)
-	if len(sized) > 10 {
-		sized = sized[:10]
-	}
+	sized := sizer.ApplyToSignals(trader.SelectTopN(result.Signals))
 
 	s.updateScanProgress("Loading chart data...", totalScanned, totalFound)
 
@@ -421,6 +451,7 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 	log.Printf("[WEB] Scan complete: %d signals from %v in %s (decision: %s)",
 		len(signals), result.UniversesUsed, scanTime.Round(time.Second), result.Decision)
 
+	partial := cp.Finish()
 	resp := ScanResponse{
 		Strategy:             "multi",
 		TotalScanned:         result.ScannedCount,
@@ -444,6 +475,10 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 		CapitalTier:      capitalTier,
 		AIFiltered:       aiFilteredCount,
 		AIRejections:     aiRejections,
+		Partial:          partial,
+		Resumed:          cp.resumed,
+		Universe:         target.Universe,
+		Symbols:          target.Symbols,
 	}
 
 	respJSON, _ := json.Marshal(resp)
@@ -451,6 +486,9 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 	s.scanMu.Lock()
 	s.scan.Status = "done"
 	s.scan.Message = fmt.Sprintf("Complete: %d signals in %s", len(signals), scanTime.Round(time.Second))
+	if partial {
+		s.scan.Message = fmt.Sprintf("Partial: %d signals in %s — scan again to resume remaining symbols", len(signals), scanTime.Round(time.Second))
+	}
 	s.scan.Result = respJSON
 	s.scanMu.Unlock()
 
@@ -458,7 +496,7 @@ func (s *Server) runScanAsync(ctx context.Context, cancel context.CancelFunc, ca
 }
 
 // runKRScanAsync runs Korean market scan in background
-func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc, capital float64) {
+func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc, capital float64, cp *scanCheckpoint, target scanTarget) {
 	defer cancel()
 	startTime := time.Now()
 
@@ -471,8 +509,9 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 	}
 
 	cachedProvider := provider.NewCachingProvider(s.providerKR, 250)
+	cachedProvider.SetHealthStore(s.health)
 	capitalTierKR := strategy.GetCapitalTier("kr", capital)
-	strategies := createMarketAwareStrategies(cachedProvider, "kr", capital)
+	strategies := createMarketAwareStrategies(cachedProvider, "kr", capital, s.trancheExit())
 	metaKR := strategies[0].(*strategy.StockMetaStrategy)
 	regimeInfoKR := metaKR.GetRegimeInfo(ctx)
 	activeStratsKR := metaKR.GetActiveStrategyNames(ctx)
@@ -486,9 +525,21 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 		for i, stock := range stocks {
 			select {
 			case <-ctx.Done():
+				cp.Save()
 				return signals, ctx.Err()
 			default:
 			}
+			if cp.ShouldStop() {
+				return signals, nil
+			}
+			if prev, done := cp.Lookup(stock.Symbol); done {
+				if prev != nil {
+					signals = append(signals, *prev)
+					totalFound++
+				}
+				totalScanned++
+				continue
+			}
 
 			stockCtx, stockCancel := context.WithTimeout(ctx, 15*time.Second)
 			var best *strategy.Signal
@@ -501,6 +552,9 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 				}
 			}
 			stockCancel()
+			if ctx.Err() == nil {
+				cp.Record(stock.Symbol, best)
+			}
 
 			if best != nil {
 				signals = append(signals, *best)
@@ -529,12 +583,21 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 		})
 	}
 
-	result, err := scanner.Scan(ctx, &webStockLoader{korean: true})
+	scanner.SetSymbolLists(s.reloadSymbolLists())
+	scanner.SetSignalFilters(s.filters, s.signalFilterSources(ctx, cachedProvider))
+
+	loader := &webStockLoader{korean: true, dataDir: s.dataDir}
+	applyScanTarget(scanner, loader, target)
+
+	result, err := scanner.Scan(ctx, loader)
 	if err != nil {
 		log.Printf("[WEB] KR Scan error: %v", err)
 		s.scanMu.Lock()
 		s.scanKR.Status = "error"
 		s.scanKR.Error = err.Error()
+		if ctx.Err() != nil {
+			s.scanKR.Error += " (progress saved — scan again to resume)"
+		}
 		s.scanMu.Unlock()
 		return
 	}
@@ -591,10 +654,7 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 	s.updateScanKRProgress("Applying position sizing...", totalScanned, totalFound)
 
 	sizer := trader.NewPositionSizer(sizerCfg)
-	sized := sizer.ApplyToSignals(result.Signals)
-	if len(sized) > 10 {
-		sized = sized[:10]
-	}
+	sized := sizer.ApplyToSignals(trader.SelectTopN(result.Signals))
 
 	s.updateScanKRProgress("Loading chart data...", totalScanned, totalFound)
 
@@ -619,6 +679,7 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 	log.Printf("[WEB] KR Scan complete: %d signals from %v in %s",
 		len(signals), result.UniversesUsed, scanTime.Round(time.Second))
 
+	partial := cp.Finish()
 	resp := ScanResponse{
 		Strategy:             "multi-kr",
 		TotalScanned:         result.ScannedCount,
@@ -642,6 +703,10 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 		CapitalTier:      capitalTierKR,
 		AIFiltered:       aiFilteredKR,
 		AIRejections:     aiRejectionsKR,
+		Partial:          partial,
+		Resumed:          cp.resumed,
+		Universe:         target.Universe,
+		Symbols:          target.Symbols,
 	}
 
 	respJSON, _ := json.Marshal(resp)
@@ -649,6 +714,9 @@ func (s *Server) runKRScanAsync(ctx context.Context, cancel context.CancelFunc,
 	s.scanMu.Lock()
 	s.scanKR.Status = "done"
 	s.scanKR.Message = fmt.Sprintf("KR Complete: %d signals in %s", len(signals), scanTime.Round(time.Second))
+	if partial {
+		s.scanKR.Message = fmt.Sprintf("KR Partial: %d signals in %s — scan again to resume remaining symbols", len(signals), scanTime.Round(time.Second))
+	}
 	s.scanKR.Result = respJSON
 	s.scanMu.Unlock()
 
@@ -665,7 +733,7 @@ func (s *Server) updateScanCryptoProgress(message string, scanned, found int) {
 }
 
 // runCryptoScanAsync runs crypto market scan in background
-func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFunc, capital float64) {
+func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFunc, capital float64, cp *scanCheckpoint, target scanTarget) {
 	defer cancel()
 	startTime := time.Now()
 
@@ -678,6 +746,7 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 	}
 
 	cachedProvider := provider.NewCachingProvider(s.providerCrypto, 50)
+	cachedProvider.SetHealthStore(s.health)
 	totalScanned := 0
 	totalFound := 0
 
@@ -694,9 +763,21 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 		for i, stock := range stocks {
 			select {
 			case <-ctx.Done():
+				cp.Save()
 				return signals, ctx.Err()
 			default:
 			}
+			if cp.ShouldStop() {
+				return signals, nil
+			}
+			if prev, done := cp.Lookup(stock.Symbol); done {
+				if prev != nil {
+					signals = append(signals, *prev)
+					totalFound++
+				}
+				totalScanned++
+				continue
+			}
 
 			stockCtx, stockCancel := context.WithTimeout(ctx, 15*time.Second)
 			var best *strategy.Signal
@@ -709,6 +790,9 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 				}
 			}
 			stockCancel()
+			if ctx.Err() == nil {
+				cp.Record(stock.Symbol, best)
+			}
 
 			if best != nil {
 				signals = append(signals, *best)
@@ -732,12 +816,21 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 		return trader.GetCryptoUniverseTiers(balance)
 	})
 
-	result, err := scanner.Scan(ctx, &webStockLoader{crypto: true})
+	scanner.SetSymbolLists(s.reloadSymbolLists())
+	scanner.SetSignalFilters(s.filters, s.signalFilterSources(ctx, cachedProvider))
+
+	loader := &webStockLoader{crypto: true, dataDir: s.dataDir}
+	applyScanTarget(scanner, loader, target)
+
+	result, err := scanner.Scan(ctx, loader)
 	if err != nil {
 		log.Printf("[WEB] Crypto Scan error: %v", err)
 		s.scanMu.Lock()
 		s.scanCrypto.Status = "error"
 		s.scanCrypto.Error = err.Error()
+		if ctx.Err() != nil {
+			s.scanCrypto.Error += " (progress saved — scan again to resume)"
+		}
 		s.scanMu.Unlock()
 		return
 	}
@@ -759,10 +852,7 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 	s.updateScanCryptoProgress("Applying position sizing...", totalScanned, totalFound)
 
 	sizer := trader.NewPositionSizer(sizerCfg)
-	sized := sizer.ApplyToSignals(result.Signals)
-	if len(sized) > 10 {
-		sized = sized[:10]
-	}
+	sized := sizer.ApplyToSignals(trader.SelectTopN(result.Signals))
 
 	s.updateScanCryptoProgress("Loading chart data...", totalScanned, totalFound)
 
@@ -791,6 +881,7 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 	case strategy.RegimeBear:
 		cryptoActiveStrats = []string{"(none — bear skip)"}
 	}
+	partial := cp.Finish()
 	resp := ScanResponse{
 		Strategy:         "multi-crypto",
 		TotalScanned:     result.ScannedCount,
@@ -813,6 +904,10 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 		CapitalTier:      capitalTierCrypto,
 		AIFiltered:       aiFilteredCrypto,
 		AIRejections:     aiRejectionsCrypto,
+		Partial:          partial,
+		Resumed:          cp.resumed,
+		Universe:         target.Universe,
+		Symbols:          target.Symbols,
 	}
 
 	respJSON, _ := json.Marshal(resp)
@@ -820,6 +915,9 @@ func (s *Server) runCryptoScanAsync(ctx context.Context, cancel context.CancelFu
 	s.scanMu.Lock()
 	s.scanCrypto.Status = "done"
 	s.scanCrypto.Message = fmt.Sprintf("Crypto Complete: %d signals in %s", len(signals), scanTime.Round(time.Second))
+	if partial {
+		s.scanCrypto.Message = fmt.Sprintf("Crypto Partial: %d signals in %s — scan again to resume remaining symbols", len(signals), scanTime.Round(time.Second))
+	}
 	s.scanCrypto.Result = respJSON
 	s.scanMu.Unlock()
 
@@ -894,6 +992,7 @@ func (s *Server) handleStock(w http.ResponseWriter, r *http.Request) {
 			market = "kr"
 		}
 		metaCfg := strategy.DefaultStockMetaConfig(market)
+		metaCfg.Tranches = s.trancheExit()
 		strat := strategy.NewStockMetaStrategy(metaCfg, prov)
 		signal, _ = strat.Analyze(ctx, stock)
 	}
@@ -927,15 +1026,15 @@ func (s *Server) handlePortfolio(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Filter out excluded symbols
+	// Filter out excluded signals (by ID, or by symbol for older clients)
 	excludedMap := make(map[string]bool)
-	for _, sym := range req.Excluded {
-		excludedMap[sym] = true
+	for _, ref := range req.Excluded {
+		excludedMap[ref] = true
 	}
 
 	var activeSignals []strategy.Signal
 	for _, sig := range req.Signals {
-		if !excludedMap[sig.Stock.Symbol] {
+		if !excludedMap[sig.Stock.Symbol] && (sig.ID == "" || !excludedMap[sig.ID]) {
 			activeSignals = append(activeSignals, sig)
 		}
 	}
@@ -1006,6 +1105,56 @@ func (s *Server) handleUniverses(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// MarketSessionInfo 마켓 하나의 현재 상태와 다음 개장/폐장 (ET·KST 둘 다)
+type MarketSessionInfo struct {
+	Market       string    `json:"market"`
+	IsOpen       bool      `json:"is_open"`
+	Reason       string    `json:"reason"`
+	NextOpen     time.Time `json:"next_open"`
+	NextClose    time.Time `json:"next_close"`
+	NextOpenET   string    `json:"next_open_et"`
+	NextOpenKST  string    `json:"next_open_kst"`
+	NextCloseET  string    `json:"next_close_et"`
+	NextCloseKST string    `json:"next_close_kst"`
+	TimeToOpen   string    `json:"time_to_open,omitempty"`
+	TimeToClose  string    `json:"time_to_close,omitempty"`
+}
+
+// handleMarketStatus GET /api/market/status — US/KR 장 상태와 다음 개장/폐장 (DST 반영)
+func (s *Server) handleMarketStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	const layout = "2006-01-02 15:04 MST"
+	et, kst := daemon.GetETLocation(), daemon.GetKSTLocation()
+	markets := make([]MarketSessionInfo, 0, 2)
+	for _, m := range []string{"us", "kr"} {
+		st := daemon.MarketStatusFor(m)
+		info := MarketSessionInfo{
+			Market:       m,
+			IsOpen:       st.IsOpen,
+			Reason:       st.Reason,
+			NextOpen:     st.NextOpen,
+			NextClose:    st.NextClose,
+			NextOpenET:   st.NextOpen.In(et).Format(layout),
+			NextOpenKST:  st.NextOpen.In(kst).Format(layout),
+			NextCloseET:  st.NextClose.In(et).Format(layout),
+			NextCloseKST: st.NextClose.In(kst).Format(layout),
+		}
+		if st.IsOpen {
+			info.TimeToClose = daemon.FormatDuration(st.TimeToClose)
+		} else {
+			info.TimeToOpen = daemon.FormatDuration(st.TimeToOpen)
+		}
+		markets = append(markets, info)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"markets": markets})
+}
+
 // PositionResponse represents a position with plan data merged
 type PositionResponse struct {
 	Symbol        string  `json:"symbol"`
@@ -1018,18 +1167,27 @@ type PositionResponse struct {
 	UnrealizedPct float64 `json:"unrealized_pct"`
 
 	// Plan data (from PlanStore)
-	HasPlan              bool    `json:"has_plan"`
-	Strategy             string  `json:"strategy,omitempty"`
-	StopLoss             float64 `json:"stop_loss,omitempty"`
-	Target1              float64 `json:"target1,omitempty"`
-	Target2              float64 `json:"target2,omitempty"`
-	Target1Hit           bool    `json:"target1_hit,omitempty"`
-	EntryTime            string  `json:"entry_time,omitempty"`
-	MaxHoldDays          int     `json:"max_hold_days,omitempty"`
-	DaysHeld             int     `json:"days_held,omitempty"`
-	DaysRemaining        int     `json:"days_remaining,omitempty"`
-	BreakoutLevel        float64 `json:"breakout_level,omitempty"`
-	ConsecutiveDaysBelow int     `json:"consecutive_days_below,omitempty"`
+	HasPlan              bool          `json:"has_plan"`
+	Strategy             string        `json:"strategy,omitempty"`
+	StopLoss             float64       `json:"stop_loss,omitempty"`
+	Target1              float64       `json:"target1,omitempty"`
+	Target2              float64       `json:"target2,omitempty"`
+	Target1Hit           bool          `json:"target1_hit,omitempty"`
+	EntryTime            string        `json:"entry_time,omitempty"`
+	MaxHoldDays          int           `json:"max_hold_days,omitempty"`
+	DaysHeld             int           `json:"days_held,omitempty"`
+	DaysRemaining        int           `json:"days_remaining,omitempty"`
+	BreakoutLevel        float64       `json:"breakout_level,omitempty"`
+	ConsecutiveDaysBelow int           `json:"consecutive_days_below,omitempty"`
+	ExDividendDate       string        `json:"ex_dividend_date,omitempty"`
+	DividendAmount       float64       `json:"dividend_amount,omitempty"`
+	CrossesExDividend    bool          `json:"crosses_ex_dividend,omitempty"` // 남은 보유기간 중 배당락
+	PlanSource           string        `json:"plan_source,omitempty"`         // "manual" = 수동 등록
+	Tranche              *TrancheState `json:"tranche,omitempty"`             // 분할 청산 진행 상태
+
+	// Display strings (currency/locale aware: "$12.34", "₩12,340")
+	Currency  string            `json:"currency"`
+	Formatted map[string]string `json:"formatted"`
 }
 
 // BalanceResponse represents the account balance
@@ -1038,6 +1196,10 @@ type BalanceResponse struct {
 	CashBalance float64 `json:"cash_balance"`
 	BuyingPower float64 `json:"buying_power"`
 	Currency    string  `json:"currency"`
+	FXRate      float64 `json:"fx_rate,omitempty"`    // 원화 결제 해외 계좌 가환율
+	KRWBacked   float64 `json:"krw_backed,omitempty"` // 매수가능금액 중 원화 환전분 (헤어컷 전)
+
+	Formatted map[string]string `json:"formatted"` // "$12.3K" / "₩1,234,567"
 }
 
 // OrderResponse represents a pending order
@@ -1055,6 +1217,10 @@ type OrderResponse struct {
 
 // handlePositions returns positions merged with plan data
 func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		s.handleManualPosition(w, r)
+		return
+	}
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -1075,28 +1241,36 @@ func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	positions, err := b.GetPositions(ctx)
+	result, err := s.positionResponses(ctx, b, market)
 	if err != nil {
 		log.Printf("[WEB] GetPositions error: %v", err)
 		http.Error(w, "Failed to get positions: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"positions": result,
+	})
+}
+
+// positionResponses 브로커 포지션 + 포지션 플랜 병합
+func (s *Server) positionResponses(ctx context.Context, b broker.Broker, market string) ([]PositionResponse, error) {
+	positions, err := b.GetPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Reload PlanStore from disk for freshness (sim 마켓은 별도 planStore)
 	var plans map[string]*trader.PositionPlan
-	ps := s.planStore
-	switch market {
-	case "sim-us":
-		ps = s.planStoreSimUS
-	case "sim-kr":
-		ps = s.planStoreSimKR
-	}
+	ps := s.planStoreForMarket(market)
 	if ps != nil {
 		ps.Reload()
 		plans = ps.All()
 	}
 
 	// Merge positions with plan data
+	cur := money.ForMarket(strings.TrimPrefix(market, "sim-"))
 	result := make([]PositionResponse, 0, len(positions))
 	for _, pos := range positions {
 		pr := PositionResponse{
@@ -1108,6 +1282,13 @@ func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
 			MarketValue:   pos.MarketValue,
 			UnrealizedPnL: pos.UnrealizedPnL,
 			UnrealizedPct: pos.UnrealizedPct,
+			Currency:      string(cur),
+			Formatted: map[string]string{
+				"avg_cost":       money.Price(pos.AvgCost, cur),
+				"current_price":  money.Price(pos.CurrentPrice, cur),
+				"market_value":   money.Format(pos.MarketValue, cur),
+				"unrealized_pnl": money.Signed(pos.UnrealizedPnL, cur),
+			},
 		}
 
 		if plan, ok := plans[pos.Symbol]; ok {
@@ -1126,15 +1307,82 @@ func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
 			}
 			pr.BreakoutLevel = plan.BreakoutLevel
 			pr.ConsecutiveDaysBelow = plan.ConsecutiveDaysBelow
+			pr.ExDividendDate = plan.ExDividendDate
+			pr.DividendAmount = plan.DividendAmount
+			pr.CrossesExDividend = plan.CrossesExDividend(time.Now())
+			pr.PlanSource = plan.Source
+			pr.Tranche = trancheState(plan)
 		}
 
 		result = append(result, pr)
 	}
 
+	return result, nil
+}
+
+// PositionHistoryResponse 포지션 진입 이후 P&L 시계열 + 현재 플랜 손절/목표가
+type PositionHistoryResponse struct {
+	trader.PositionSeries
+	StopLoss float64 `json:"stop_loss,omitempty"`
+	Target1  float64 `json:"target1,omitempty"`
+	Target2  float64 `json:"target2,omitempty"`
+	Currency string  `json:"currency"`
+}
+
+// handlePositionHistory GET /api/positions/{symbol}/history?market=us
+// 데몬 모니터가 기록한 스냅샷 (기록 전이면 빈 snapshots)
+// POST /api/positions/{symbol}/sell은 handlePositionSell로 보낸다.
+func (s *Server) handlePositionHistory(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/positions/")
+	if symbol, ok := strings.CutSuffix(rest, "/sell"); ok && symbol != "" && !strings.Contains(symbol, "/") {
+		s.handlePositionSell(w, r, symbol)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	symbol, ok := strings.CutSuffix(rest, "/history")
+	if !ok || symbol == "" || strings.Contains(symbol, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "position history not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+
+	market := r.URL.Query().Get("market")
+	dir := s.marketDataDir(market)
+	if !symbols.IsKoreanSymbol(symbol) {
+		symbol = strings.ToUpper(symbol)
+	}
+
+	resp := PositionHistoryResponse{Currency: string(money.ForMarket(strings.TrimPrefix(market, "sim-")))}
+	series, err := trader.NewPositionHistoryStore(dir).Load(symbol)
+	switch {
+	case err == nil:
+		resp.PositionSeries = *series
+	case os.IsNotExist(err):
+		resp.Symbol = symbol
+		resp.Snapshots = []trader.PositionSnapshot{}
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ps := s.planStoreForMarket(market); ps != nil {
+		ps.Reload()
+		if plan := ps.Get(symbol); plan != nil {
+			resp.StopLoss, resp.Target1, resp.Target2 = plan.StopLoss, plan.Target1, plan.Target2
+			if resp.EntryPrice == 0 {
+				resp.EntryPrice, resp.Quantity, resp.EntryTime, resp.Strategy = plan.EntryPrice, plan.Quantity, plan.EntryTime, plan.Strategy
+			}
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"positions": result,
-	})
+	json.NewEncoder(w).Encode(resp)
 }
 
 // handleBalance returns account balance
@@ -1156,22 +1404,37 @@ func (s *Server) handleBalance(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	balance, err := b.GetBalance(ctx)
+	resp, err := balanceResponse(ctx, b)
 	if err != nil {
 		log.Printf("[WEB] GetBalance error: %v", err)
 		http.Error(w, "Failed to get balance: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	resp := BalanceResponse{
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// balanceResponse 브로커 잔고 → 응답 (표시용 포맷 포함)
+func balanceResponse(ctx context.Context, b broker.Broker) (BalanceResponse, error) {
+	balance, err := b.GetBalance(ctx)
+	if err != nil {
+		return BalanceResponse{}, err
+	}
+	cur := money.Parse(balance.Currency)
+	return BalanceResponse{
 		TotalEquity: balance.TotalEquity,
 		CashBalance: balance.CashBalance,
 		BuyingPower: balance.BuyingPower,
 		Currency:    balance.Currency,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+		FXRate:      balance.FXRate,
+		KRWBacked:   balance.KRWBacked,
+		Formatted: map[string]string{
+			"total_equity": money.Format(balance.TotalEquity, cur),
+			"cash_balance": money.Format(balance.CashBalance, cur),
+			"buying_power": money.Format(balance.BuyingPower, cur),
+		},
+	}, nil
 }
 
 // handleOrders returns pending orders
@@ -1195,13 +1458,25 @@ func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	orders, err := b.GetPendingOrders(ctx)
+	result, err := pendingOrderResponses(ctx, b)
 	if err != nil {
 		log.Printf("[WEB] GetPendingOrders error: %v", err)
 		http.Error(w, "Failed to get orders: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"orders": result,
+	})
+}
+
+// pendingOrderResponses 미체결 주문 목록
+func pendingOrderResponses(ctx context.Context, b broker.Broker) ([]OrderResponse, error) {
+	orders, err := b.GetPendingOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
 	result := make([]OrderResponse, 0, len(orders))
 	for _, o := range orders {
 		result = append(result, OrderResponse{
@@ -1216,11 +1491,7 @@ func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
 			CreatedAt: o.CreatedAt.Format(time.RFC3339),
 		})
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"orders": result,
-	})
+	return result, nil
 }
 
 // handleTradeHistory 누적 매매 기록 + 요약 반환
@@ -1784,7 +2055,7 @@ func (s *Server) handlePortfolioOverview(w http.ResponseWriter, r *http.Request)
 			if investedUSD > 0 {
 				so.PnLPct = totalPnLUSD / investedUSD * 100
 			}
-			so.ExtraInfo = fmt.Sprintf("$%.2f (₩%.0f)", bal.TotalEquity, valueKRW)
+			so.ExtraInfo = fmt.Sprintf("%s (%s)", money.Format(bal.TotalEquity, money.USD), money.Format(valueKRW, money.KRW))
 			resp.Strategies = append(resp.Strategies, so)
 			totalValue += valueKRW
 			totalCost += investedKRW
@@ -1875,9 +2146,9 @@ func (s *Server) handlePortfolioOverview(w http.ResponseWriter, r *http.Request)
 			if investedKRW > 0 {
 				so.PnLPct = netPnLKRW / investedKRW * 100
 			}
-			info := fmt.Sprintf("$%.2f", totalUSDT)
+			info := money.Format(totalUSDT, money.USDT)
 			if binance.EarnBalance > 0 {
-				info += fmt.Sprintf(" (Earn: $%.2f)", binance.EarnBalance)
+				info += fmt.Sprintf(" (Earn: %s)", money.Format(binance.EarnBalance, money.USDT))
 			}
 			if binance.Total.Trades > 0 {
 				info += fmt.Sprintf(", WR: %.0f%% (%d trades)", binance.Total.WinRate, binance.Total.Trades)
@@ -2453,3 +2724,349 @@ func (s *Server) handleCollectorStatus(w http.ResponseWriter, r *http.Request) {
 		"db_size": dbSize,
 	})
 }
+
+// SymbolListRequest 블랙/화이트리스트 변경 요청
+type SymbolListRequest struct {
+	Action string `json:"action"` // "block", "unblock", "allow", "disallow"
+	Symbol string `json:"symbol"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// reloadSymbolLists 디스크에서 최신 블랙/화이트리스트 로드 (CLI/데몬 변경 반영)
+func (s *Server) reloadSymbolLists() *symbols.SymbolLists {
+	if s.lists == nil {
+		return nil
+	}
+	if err := s.lists.Reload(); err != nil && !os.IsNotExist(err) {
+		log.Printf("[WEB] Symbol lists reload failed: %v", err)
+	}
+	return s.lists
+}
+
+// signalFilterSources 설정된 시그널 필터의 조회 대상 (업종/실적 필터가 있을 때만 Yahoo 세션 초기화)
+func (s *Server) signalFilterSources(ctx context.Context, p provider.Provider) trader.SignalFilterSources {
+	src := trader.SignalFilterSources{Candles: p}
+	if s.dataDir != "" && s.filters.Needs(trader.FilterExcludeSectors, trader.FilterEarningsWindow) {
+		fc := provider.NewFundamentalsChecker(s.dataDir, nil)
+		if err := fc.Init(ctx); err != nil {
+			log.Printf("[WEB] Sector/earnings signal filters skipped (Yahoo init failed): %v", err)
+		} else {
+			src.Earnings, src.Sectors = fc, fc
+		}
+	}
+	return src
+}
+
+// handleSymbolLists GET: 블랙/화이트리스트 조회, POST: 항목 추가/제거
+func (s *Server) handleSymbolLists(w http.ResponseWriter, r *http.Request) {
+	lists := s.reloadSymbolLists()
+	if lists == nil {
+		http.Error(w, "Symbol lists not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req SymbolListRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if strings.TrimSpace(req.Symbol) == "" {
+			http.Error(w, "Symbol required", http.StatusBadRequest)
+			return
+		}
+		var err error
+		switch req.Action {
+		case "block":
+			err = lists.Block(req.Symbol, req.Reason)
+		case "unblock":
+			err = lists.Unblock(req.Symbol)
+		case "allow":
+			err = lists.Allow(req.Symbol, req.Reason)
+		case "disallow":
+			err = lists.Disallow(req.Symbol)
+		default:
+			http.Error(w, "Unknown action (use block, unblock, allow, disallow)", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to update symbol lists: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[WEB] Symbol lists: %s %s", req.Action, symbols.NormalizeSymbol(req.Symbol))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"blacklist": lists.Blacklist(),
+		"whitelist": lists.Whitelist(),
+	})
+}
+
+// handleSymbolsPruned GET: 자동 제외(상장폐지/잘못된 심볼 추정) 리포트, POST {symbol}: 제외 해제
+func (s *Server) handleSymbolsPruned(w http.ResponseWriter, r *http.Request) {
+	if s.health == nil {
+		http.Error(w, "Symbol health not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req struct {
+			Symbol string `json:"symbol"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Symbol) == "" {
+			http.Error(w, "Symbol required", http.StatusBadRequest)
+			return
+		}
+		sym := symbols.NormalizeSymbol(req.Symbol)
+		s.health.Unprune(sym)
+		log.Printf("[WEB] Symbol unpruned: %s", sym)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pruned":  s.health.Pruned(),
+		"failing": s.health.Failing(),
+	})
+}
+
+// refreshSymbolNames provider 심볼 목록으로 검색 인덱스 갱신 (오래된 마켓만)
+func (s *Server) refreshSymbolNames() {
+	for market, p := range map[string]provider.Provider{"us": s.provider, "kr": s.providerKR} {
+		if p == nil || !s.names.NeedsRefresh(market) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		if err := s.names.Refresh(ctx, market, p); err != nil {
+			log.Printf("[WEB] Symbol names refresh (%s) skipped, using built-in list: %v", market, err)
+		} else {
+			log.Printf("[WEB] Symbol names refreshed (%s): %d entries indexed", market, s.names.Len())
+		}
+		cancel()
+	}
+}
+
+// handleSymbolSearch GET ?q=삼성&market=kr&limit=10 — 심볼 자동완성
+func (s *Server) handleSymbolSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query().Get("q")
+	market := strings.ToLower(r.URL.Query().Get("market"))
+	switch market {
+	case "", "us", "kr", "crypto":
+	default:
+		http.Error(w, "Unknown market (use us, kr, crypto)", http.StatusBadRequest)
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit > 100 {
+		limit = 100
+	}
+
+	results := s.names.Search(q, market, limit)
+	if results == nil {
+		results = []symbols.SymbolInfo{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"query":   q,
+		"results": results,
+	})
+}
+
+// WatchlistRequest 관심종목 변경 요청
+type WatchlistRequest struct {
+	Action  string   `json:"action"` // "create", "delete", "add", "remove"
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols,omitempty"`
+}
+
+// handleWatchlists GET: 관심종목 목록 (?name=으로 단건), POST: 생성/삭제/종목 추가·제거
+// 스캔/백테스트는 --universe watchlist:<name> 으로 사용
+func (s *Server) handleWatchlists(w http.ResponseWriter, r *http.Request) {
+	if s.watchlists == nil {
+		http.Error(w, "Watchlists not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	if err := s.watchlists.Reload(); err != nil && !os.IsNotExist(err) {
+		log.Printf("[WEB] Watchlists reload failed: %v", err)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if name := r.URL.Query().Get("name"); name != "" {
+			wl, ok := s.watchlists.Get(name)
+			if !ok {
+				http.Error(w, "Watchlist not found", http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(wl)
+			return
+		}
+	case http.MethodPost:
+		var req WatchlistRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var err error
+		switch req.Action {
+		case "create":
+			err = s.watchlists.Create(req.Name)
+		case "delete":
+			err = s.watchlists.Delete(req.Name)
+		case "add":
+			if len(req.Symbols) == 0 {
+				http.Error(w, "Symbols required", http.StatusBadRequest)
+				return
+			}
+			err = s.watchlists.Add(req.Name, req.Symbols...)
+		case "remove":
+			err = s.watchlists.Remove(req.Name, req.Symbols...)
+		default:
+			http.Error(w, "Unknown action (use create, delete, add, remove)", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("[WEB] Watchlist: %s %s %v", req.Action, req.Name, req.Symbols)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"watchlists": s.watchlists.List(),
+	})
+}
+
+// handleBacktestRuns 저장된 백테스트 결과 목록 (--bt-save)
+func (s *Server) handleBacktestRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Backtest runs not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	runs, err := backtest.ListRuns(backtest.RunsDir(s.dataDir))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"runs": runs})
+}
+
+// handleBacktestCompare 두 백테스트 결과 비교 (?a=name&b=name)
+func (s *Server) handleBacktestCompare(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Backtest runs not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	a, err := s.loadSavedRun(r.URL.Query().Get("a"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	b, err := s.loadSavedRun(r.URL.Query().Get("b"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cmp := backtest.CompareRuns(a, b)
+	// 거래 내역은 비교 화면에 불필요 — 응답 크기 축소
+	a.Trades, b.Trades = nil, nil
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cmp)
+}
+
+// loadSavedRun 저장된 백테스트 결과 로드 (이름은 runs 디렉토리 안의 파일명만 허용)
+func (s *Server) loadSavedRun(name string) (*backtest.SavedRun, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid run name %q", name)
+	}
+	return backtest.LoadRun(filepath.Join(backtest.RunsDir(s.dataDir), name+".json"))
+}
+
+// handleHistorySummary 주간/월간 성과 집계 (?market=us&period=week|month)
+func (s *Server) handleHistorySummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "History not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	period, err := dailystate.NormalizePeriod(r.URL.Query().Get("period"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	market := r.URL.Query().Get("market")
+	if market == "" {
+		market = "us"
+	}
+	dir := s.dataDir
+	switch market {
+	case "us", "kr", "crypto":
+	case "sim-us":
+		dir, market = filepath.Join(s.dataDir, "sim_us"), "us"
+	case "sim-kr":
+		dir, market = filepath.Join(s.dataDir, "sim_kr"), "kr"
+	default:
+		http.Error(w, "unknown market", http.StatusBadRequest)
+		return
+	}
+
+	states, err := dailystate.Load(dir, market)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var records []trader.TradeRecord
+	if h, err := trader.NewTradeHistory(dir); err == nil {
+		records = h.GetAll(market)
+	}
+
+	tzName := "America/New_York"
+	if market != "us" {
+		tzName = "Asia/Seoul"
+	}
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		loc = time.Local
+	}
+
+	rollups := dailystate.BuildRollups(states, records, period, loc)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"market":  market,
+		"period":  period,
+		"rollups": rollups,
+	})
+}
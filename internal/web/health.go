@@ -0,0 +1,76 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	"traveler/internal/daemon"
+)
+
+// DaemonHealth /healthz 데몬 하나의 상태
+type DaemonHealth struct {
+	Market     string    `json:"market"`
+	Status     string    `json:"status"` // ok, stalled, stopped
+	Phase      string    `json:"phase"`
+	PID        int       `json:"pid"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	AgeSec     int       `json:"age_sec"` // 마지막 하트비트 이후 경과 (초)
+	Cycles     int64     `json:"cycles"`
+	StaleAfter time.Time `json:"stale_after,omitempty"`
+}
+
+// handleHealthz 웹 서버 + 데몬 하트비트 상태 (외부 감시용)
+// GET /healthz[?market=us] → 200 정상 (정상 종료한 데몬 포함), 503 멈춘 데몬이 있음, 404 해당 마켓 하트비트 없음
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var beats []*daemon.Heartbeat
+	if market := r.URL.Query().Get("market"); market != "" {
+		h, err := daemon.LoadHeartbeat(s.dataDir, market)
+		if err != nil {
+			if os.IsNotExist(err) {
+				http.Error(w, "no heartbeat for "+market+" daemon", http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		beats = append(beats, h)
+	} else if s.dataDir != "" {
+		beats, _ = daemon.LoadHeartbeats(s.dataDir)
+	}
+
+	now := time.Now()
+	status, code := daemon.HealthOK, http.StatusOK
+	daemons := make([]DaemonHealth, 0, len(beats))
+	for _, h := range beats {
+		st := h.Status(now)
+		if st == daemon.HealthStalled {
+			status, code = daemon.HealthStalled, http.StatusServiceUnavailable
+		}
+		daemons = append(daemons, DaemonHealth{
+			Market:     h.Market,
+			Status:     st,
+			Phase:      h.Phase,
+			PID:        h.PID,
+			UpdatedAt:  h.UpdatedAt,
+			AgeSec:     int(now.Sub(h.UpdatedAt).Seconds()),
+			Cycles:     h.Cycles,
+			StaleAfter: h.StaleAfter,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  status,
+		"time":    now,
+		"daemons": daemons,
+	})
+}
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"traveler/internal/i18n"
+)
+
+// handleI18n 대시보드 라벨 카탈로그 (config language, ?lang=ko|en으로 덮어쓰기)
+// GET /api/i18n → {"language": "ko", "messages": {"web.tab.scanner": "스캐너", ...}}
+func (s *Server) handleI18n(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	lang := i18n.Language()
+	if q := r.URL.Query().Get("lang"); q != "" {
+		code, ok := i18n.Normalize(q)
+		if !ok {
+			http.Error(w, "unknown language "+q, http.StatusBadRequest)
+			return
+		}
+		lang = code
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"language": lang,
+		"messages": i18n.Catalog(lang),
+	})
+}
@@ -0,0 +1,137 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"traveler/internal/trader"
+	"traveler/pkg/model"
+)
+
+// ManualPositionRequest POST /api/positions 본문 (0/빈 값은 브로커 잔고·차트 분석으로 채움)
+type ManualPositionRequest struct {
+	Market     string  `json:"market"`
+	Symbol     string  `json:"symbol"`
+	Quantity   float64 `json:"quantity"`
+	EntryPrice float64 `json:"entry_price"`
+	Strategy   string  `json:"strategy"`
+	StopLoss   float64 `json:"stop_loss"`
+	Target1    float64 `json:"target1"`
+	Target2    float64 `json:"target2"`
+	EntryDate  string  `json:"entry_date"` // YYYY-MM-DD
+}
+
+// handleManualPosition traveler 밖에서 매수한 포지션의 플랜 등록
+// 데몬은 다음 모니터 주기에 plans.json 변경을 감지해 모니터링을 시작한다.
+// 데몬이 이 플랜으로 손절/익절 주문을 내므로 TRAVELER_RPC_TOKEN이 설정돼 있어야 한다.
+func (s *Server) handleManualPosition(w http.ResponseWriter, r *http.Request) {
+	if !s.bearerAuthorized(r) {
+		http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+	if s.rpcToken == "" {
+		http.Error(w, "manual positions are disabled: set TRAVELER_RPC_TOKEN to enable plan registration", http.StatusForbidden)
+		return
+	}
+	var req ManualPositionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.Market == "" {
+		req.Market = r.URL.Query().Get("market")
+	}
+	req.Symbol = strings.ToUpper(strings.TrimSpace(req.Symbol))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	plan, status, err := s.registerManualPosition(ctx, req)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	log.Printf("[WEB] Manual plan registered: %s %s qty=%g entry=%.4g stop=%.4g T1=%.4g",
+		plan.Symbol, plan.Strategy, plan.Quantity, plan.EntryPrice, plan.StopLoss, plan.Target1)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
+
+func (s *Server) registerManualPosition(ctx context.Context, req ManualPositionRequest) (*trader.PositionPlan, int, error) {
+	ps := s.planStoreForMarket(req.Market)
+	if ps == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("plan store not available")
+	}
+	if req.Symbol == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("symbol is required")
+	}
+
+	mp := trader.ManualPosition{
+		Symbol:     req.Symbol,
+		Quantity:   req.Quantity,
+		EntryPrice: req.EntryPrice,
+		Strategy:   req.Strategy,
+		StopLoss:   req.StopLoss,
+		Target1:    req.Target1,
+		Target2:    req.Target2,
+		Tranches:   s.trancheExit(),
+	}
+	if req.EntryDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", req.EntryDate, time.Local)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid entry_date %q (use YYYY-MM-DD)", req.EntryDate)
+		}
+		mp.EntryTime = t
+	}
+
+	// 수량/진입가 미입력 → 브로커 보유 내역
+	if mp.Quantity <= 0 || mp.EntryPrice <= 0 {
+		if b := s.getBrokerForMarket(req.Market); b != nil {
+			positions, err := b.GetPositions(ctx)
+			if err != nil {
+				return nil, http.StatusBadGateway, fmt.Errorf("get positions: %w", err)
+			}
+			for _, p := range positions {
+				if p.Symbol != mp.Symbol {
+					continue
+				}
+				if mp.Quantity <= 0 {
+					mp.Quantity = p.Quantity
+				}
+				if mp.EntryPrice <= 0 {
+					mp.EntryPrice = p.AvgCost
+				}
+			}
+		}
+	}
+
+	var candles []model.Candle
+	if mp.StopLoss <= 0 || mp.Target1 <= 0 || mp.Strategy == "" {
+		if p := s.getProviderForMarket(req.Market); p != nil {
+			c, err := p.GetDailyCandles(ctx, mp.Symbol, 50)
+			if err != nil {
+				log.Printf("[WEB] Manual plan %s: candles unavailable, using fallback levels: %v", mp.Symbol, err)
+			}
+			candles = c
+		}
+	}
+
+	plan, err := trader.BuildManualPlan(mp, candles)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	// 데몬이 그 사이 갱신한 플랜을 덮어쓰지 않도록 최신 파일 기준으로 저장
+	if err := ps.Reload(); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("reload plans: %w", err)
+	}
+	if err := ps.Save(plan); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("save plan: %w", err)
+	}
+	return plan, http.StatusOK, nil
+}
@@ -0,0 +1,264 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"traveler/internal/fees"
+	"traveler/internal/trader"
+)
+
+// TrancheState 분할 청산 진행 상태 (/api/positions)
+type TrancheState struct {
+	Mode         string  `json:"mode"`          // "two-step" (T1 절반 + T2 전량), "three-tranche"
+	InitialQty   float64 `json:"initial_qty"`   // 현재 수량 + 분할 매도 누계
+	RemainingQty float64 `json:"remaining_qty"` // 플랜 기준 남은 수량
+	SoldT1       float64 `json:"sold_t1"`       // T1에서 판 수량
+	SoldT2       float64 `json:"sold_t2"`       // T2에서 판 수량 (3분할)
+	SoldManual   float64 `json:"sold_manual"`   // 웹/수동 분할 매도
+	Target1Hit   bool    `json:"target1_hit"`
+	Target2Hit   bool    `json:"target2_hit"`            // 3분할: 러너만 남음
+	Target3      float64 `json:"target3,omitempty"`      // 러너 목표 (0 = 트레일링만)
+	T1Fraction   float64 `json:"t1_fraction,omitempty"`  // 계획된 T1 매도 비율
+	T2Fraction   float64 `json:"t2_fraction,omitempty"`  // 계획된 T2 매도 비율
+	InitialStop  float64 `json:"initial_stop,omitempty"` // 진입 시 손절가
+	CurrentStop  float64 `json:"current_stop"`           // 현재 손절가 (본전/트레일링 이동 반영)
+	StopState    string  `json:"stop_state"`             // "initial", "breakeven", "trailing", "locked_t1"
+}
+
+// trancheState 플랜 → 분할 청산 상태
+func trancheState(plan *trader.PositionPlan) *TrancheState {
+	ts := &TrancheState{
+		Mode:         "two-step",
+		RemainingQty: plan.Quantity,
+		SoldT1:       plan.SoldT1,
+		SoldT2:       plan.SoldT2,
+		SoldManual:   plan.SoldManual,
+		Target1Hit:   plan.Target1Hit,
+		Target2Hit:   plan.Target2Hit,
+		Target3:      plan.Target3,
+		T1Fraction:   plan.T1Fraction,
+		T2Fraction:   plan.T2Fraction,
+		InitialStop:  plan.InitialStop,
+		CurrentStop:  plan.StopLoss,
+		StopState:    "initial",
+	}
+	if plan.T2Fraction > 0 {
+		ts.Mode = "three-tranche"
+	}
+	ts.InitialQty = plan.Quantity + plan.SoldT1 + plan.SoldT2 + plan.SoldManual
+	switch {
+	case plan.Target2Hit && plan.StopLoss > plan.Target1:
+		ts.StopState = "trailing"
+	case plan.Target2Hit:
+		ts.StopState = "locked_t1"
+	case plan.Target1Hit && plan.UseTrailingStop && plan.StopLoss > plan.EntryPrice:
+		ts.StopState = "trailing"
+	case plan.StopLoss >= plan.EntryPrice:
+		ts.StopState = "breakeven"
+	}
+	return ts
+}
+
+// PositionSellRequest POST /api/positions/{symbol}/sell 본문 (quantity 또는 fraction 중 하나)
+type PositionSellRequest struct {
+	Quantity float64 `json:"quantity,omitempty"` // 매도 수량
+	Fraction float64 `json:"fraction,omitempty"` // 보유 수량 대비 비율 (0.5 = 절반, 1 = 전량)
+	Reason   string  `json:"reason,omitempty"`   // 매매 기록 사유 (기본 manual_tranche)
+}
+
+// PositionSellResponse 수동 분할 매도 결과
+type PositionSellResponse struct {
+	Market    string  `json:"market"`
+	Symbol    string  `json:"symbol"`
+	DryRun    bool    `json:"dry_run"`
+	Quantity  float64 `json:"quantity"`
+	Remaining float64 `json:"remaining"`
+	Price     float64 `json:"price"` // 체결가 (미체결이면 현재가)
+	OrderID   string  `json:"order_id"`
+	Status    string  `json:"status"`
+}
+
+// handlePositionSell 보유 포지션 일부/전량 시장가 매도 (Executor 경유: 속도 제한, 재시도, dry-run)
+// POST /api/positions/{symbol}/sell?market=us[&dry_run=true]  {"fraction": 0.5} 또는 {"quantity": 10}
+// 실주문은 TRAVELER_RPC_TOKEN이 설정돼 있어야 한다.
+func (s *Server) handlePositionSell(w http.ResponseWriter, r *http.Request, symbol string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.bearerAuthorized(r) {
+		http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+	market := r.URL.Query().Get("market")
+	if market == "" {
+		market = "us"
+	}
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	if !dryRun && s.rpcToken == "" {
+		http.Error(w, "manual sells are disabled: set TRAVELER_RPC_TOKEN to enable order execution", http.StatusForbidden)
+		return
+	}
+
+	var req PositionSellRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	resp, status, err := s.sellPosition(ctx, market, strings.ToUpper(symbol), req, dryRun)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) sellPosition(ctx context.Context, market, symbol string, req PositionSellRequest, dryRun bool) (*PositionSellResponse, int, error) {
+	if (req.Quantity > 0) == (req.Fraction > 0) {
+		return nil, http.StatusBadRequest, fmt.Errorf("give exactly one of quantity or fraction")
+	}
+	if req.Fraction > 1 {
+		return nil, http.StatusBadRequest, fmt.Errorf("fraction must be in (0, 1]")
+	}
+	b := s.getBrokerForMarket(market)
+	if b == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("broker not configured for market %s", market)
+	}
+	if !dryRun {
+		if err := s.reconcileBlocked(market); err != nil {
+			return nil, http.StatusConflict, err
+		}
+	}
+
+	positions, err := b.GetPositions(ctx)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("get positions: %w", err)
+	}
+	var held, price float64
+	for _, p := range positions {
+		if p.Symbol == symbol {
+			held, price = p.Quantity, p.CurrentPrice
+		}
+	}
+	if held <= 0 {
+		return nil, http.StatusNotFound, fmt.Errorf("no %s position in %s account", symbol, market)
+	}
+
+	baseMarket := strings.TrimPrefix(market, "sim-")
+	fractional := b.Capabilities().FractionalShares
+	qty := req.Quantity
+	switch {
+	case req.Fraction >= 1:
+		qty = held
+	case req.Fraction > 0 && fractional:
+		qty = held * req.Fraction // 소수점 수량
+	case req.Fraction > 0 && held > 1:
+		qty = trader.TrancheQty(held, req.Fraction)
+	case req.Fraction > 0:
+		qty = held
+	}
+	if !fractional {
+		qty = math.Floor(qty)
+	}
+	if qty <= 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("sell quantity rounds to zero")
+	}
+	if qty > held {
+		return nil, http.StatusBadRequest, fmt.Errorf("quantity %g exceeds held %g", qty, held)
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = "manual_tranche"
+	}
+
+	cfg := trader.DefaultConfig()
+	cfg.DryRun = dryRun
+	s.applyOrderLimits(&cfg)
+	exec := trader.NewExecutor(b, cfg, true)
+
+	log.Printf("[WEB] Manual sell: %s %.4g of %.4g (%s, %s, dry-run=%v)", symbol, qty, held, reason, market, dryRun)
+	res, err := exec.ExecuteSell(ctx, symbol, qty, reason)
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	if res.AvgPrice > 0 {
+		price = res.AvgPrice
+	}
+	resp := &PositionSellResponse{
+		Market:    market,
+		Symbol:    symbol,
+		DryRun:    dryRun,
+		Quantity:  qty,
+		Remaining: held - qty,
+		Price:     price,
+		OrderID:   res.OrderID,
+		Status:    res.Status,
+	}
+	if dryRun {
+		return resp, http.StatusOK, nil
+	}
+
+	// 플랜/매매 기록 반영 (데몬 모니터는 다음 주기에 줄어든 수량을 읽는다)
+	ps := s.planStoreForMarket(market)
+	var plan *trader.PositionPlan
+	if ps != nil {
+		if err := ps.Reload(); err == nil {
+			plan = ps.Get(symbol)
+		}
+	}
+	if plan != nil {
+		if resp.Remaining <= 0 {
+			ps.Delete(symbol)
+		} else {
+			ps.RecordManualSell(symbol, qty, resp.Remaining)
+		}
+	}
+	if hist := s.historyForMarket(market); hist != nil && plan != nil && price > 0 {
+		fee := fees.ForMarket(baseMarket)
+		pnl := qty*(price-plan.EntryPrice) - fee.BuyCost(qty*plan.EntryPrice) - fee.SellCost(qty*price)
+		pnlPct := 0.0
+		if plan.EntryPrice > 0 {
+			pnlPct = pnl / (qty * plan.EntryPrice) * 100
+		}
+		hist.Append(trader.TradeRecord{
+			TradeID:    plan.GetTradeID(),
+			Market:     baseMarket,
+			Symbol:     symbol,
+			Side:       "sell",
+			Quantity:   qty,
+			Price:      price,
+			Strategy:   plan.Strategy,
+			Reason:     reason,
+			EntryPrice: plan.EntryPrice,
+			PnL:        pnl,
+			PnLPct:     pnlPct,
+			EntryStop:  plan.InitialStop,
+			EntryTime:  plan.EntryTime,
+		})
+	}
+	return resp, http.StatusOK, nil
+}
+
+// historyForMarket 마켓별 매매 기록 (sim 마켓은 별도 인스턴스)
+func (s *Server) historyForMarket(market string) *trader.TradeHistory {
+	switch market {
+	case "sim-us":
+		return s.historySimUS
+	case "sim-kr":
+		return s.historySimKR
+	default:
+		return s.history
+	}
+}
@@ -0,0 +1,40 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// readOnlyWrites 읽기 전용 모드에서도 허용하는 POST (스캔/계산만, 주문·포지션·설정 변경 없음)
+var readOnlyWrites = map[string]bool{
+	"/api/scan":      true, // 스캔 시작/큐 등록
+	"/api/portfolio": true, // 배분 재계산 (저장 안 함)
+	"/rpc":           true, // 주문 메서드는 callRPC에서 막는다
+}
+
+// SetReadOnly 읽기 전용 모드 (web.read_only, --web-readonly)
+func (s *Server) SetReadOnly(on bool) {
+	s.readOnly = on
+}
+
+// readOnlyMiddleware 읽기 전용이면 조회(GET/HEAD)와 허용된 스캔 요청만 통과시킨다.
+// 매도, 수동 포지션 등록, 시그널 실행, 대조 확인, 블랙/화이트리스트·워치리스트 수정은 403.
+func (s *Server) readOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.readOnly && r.Method != http.MethodGet && r.Method != http.MethodHead && !readOnlyWrites[r.URL.Path] {
+			http.Error(w, "web server is read-only (web.read_only)", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleMode 웹 UI가 쓰기 버튼을 숨길지 판단
+// GET /api/mode
+func (s *Server) handleMode(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{
+		"read_only":       s.readOnly,
+		"trading_enabled": s.rpcToken != "" && !s.readOnly,
+	})
+}
@@ -0,0 +1,136 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"traveler/internal/trader"
+)
+
+// reconcileAtStartup 웹 서버 시작 시 실계좌 마켓 대조 (결과는 reconcile_{market}.json, 확인 전에는 실주문 임포트 거부)
+func (s *Server) reconcileAtStartup() {
+	if s.dataDir == "" {
+		return
+	}
+	for _, market := range []string{"us", "kr", "crypto"} {
+		if b := s.getBrokerForMarket(market); b == nil || strings.HasPrefix(b.Name(), "sim-") {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		report, err := s.runReconcile(ctx, market)
+		cancel()
+		if err != nil {
+			log.Printf("[WEB] Reconcile %s skipped: %v", market, err)
+			continue
+		}
+		if report.NeedsAck() {
+			log.Printf("[WEB] Reconcile %s: %d issue(s) — live orders refused until acknowledged (POST /api/reconcile/ack?market=%s)",
+				market, len(report.Issues), market)
+			for _, is := range report.Issues {
+				log.Printf("  - [%s] %s: %s", is.Kind, is.Symbol, is.Detail)
+			}
+		}
+	}
+}
+
+func (s *Server) runReconcile(ctx context.Context, market string) (*trader.ReconcileReport, error) {
+	b := s.getBrokerForMarket(market)
+	if b == nil {
+		return nil, fmt.Errorf("broker not configured for market %s", market)
+	}
+	report, err := trader.Reconcile(ctx, b, s.planStoreForMarket(market), trader.NewOrderJournal(s.dataDir, market), market)
+	if err != nil {
+		return nil, err
+	}
+	if err := trader.NewReconcileStore(s.dataDir, market).Save(report); err != nil {
+		return nil, fmt.Errorf("save report: %w", err)
+	}
+	return report, nil
+}
+
+// reconcileBlocked 미확인 대조 이슈가 있으면 에러 (실주문 전 확인, sim 마켓은 대상 아님)
+func (s *Server) reconcileBlocked(market string) error {
+	if strings.HasPrefix(market, "sim-") || s.dataDir == "" {
+		return nil
+	}
+	report, err := trader.NewReconcileStore(s.dataDir, market).Load()
+	if err != nil || !report.NeedsAck() {
+		return nil
+	}
+	return fmt.Errorf("reconciliation for %s has %d unacknowledged issue(s) — review GET /api/reconcile?market=%s and acknowledge first",
+		market, len(report.Issues), market)
+}
+
+// handleReconcile 최근 대조 결과
+// GET /api/reconcile?market=us[&refresh=1] (refresh는 브로커 재조회, 토큰 필요)
+func (s *Server) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	market := r.URL.Query().Get("market")
+	if market == "" {
+		market = "us"
+	}
+
+	var report *trader.ReconcileReport
+	var err error
+	if r.URL.Query().Get("refresh") == "1" {
+		if !s.bearerAuthorized(r) {
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+		if report, err = s.runReconcile(ctx, market); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	} else if report, err = trader.NewReconcileStore(s.dataDir, market).Load(); err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, "no reconciliation report for "+market, http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// handleReconcileAck 대조 결과 확인 → 데몬 신규 매수 재개, 웹 실주문 임포트 허용
+// POST /api/reconcile/ack?market=us
+func (s *Server) handleReconcileAck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.bearerAuthorized(r) {
+		http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+	market := r.URL.Query().Get("market")
+	if market == "" {
+		market = "us"
+	}
+	report, err := trader.NewReconcileStore(s.dataDir, market).Acknowledge()
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, "no reconciliation report for "+market, http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	log.Printf("[WEB] Reconcile %s acknowledged (%d issue(s))", market, len(report.Issues))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
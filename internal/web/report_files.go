@@ -0,0 +1,219 @@
+package web
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"traveler/internal/backtest"
+	"traveler/internal/daemon"
+)
+
+// reportSource /reports로 공개하는 dataDir 파일 (dir은 dataDir 기준 glob, 여기 없는 파일은 404)
+// 설정·토큰·상태 파일이 같은 디렉토리에 있으므로 디렉토리 통째가 아니라 패턴으로만 연다.
+type reportSource struct {
+	kind    string
+	dir     string
+	pattern string
+}
+
+var reportSources = []reportSource{
+	{"daily", "", "report_*.txt"}, // 데몬 일일 리포트
+	{"daily", "sim_us", "report_*.txt"},
+	{"daily", "sim_kr", "report_*.txt"},
+	{"brief", "", "brief_*.txt"},
+	{"cli", "reports/*", "report_*.txt"}, // CLI 스캔 리포트 (기본 reports_dir, 날짜별 폴더)
+	{"cli", "reports/*", "report_*.json"},
+	{"scan", "", "last_scan_*.json"},
+	{"scan", "sim_us", "last_scan_*.json"},
+	{"scan", "sim_kr", "last_scan_*.json"},
+	{"session", filepath.Base(daemon.RunsDir("")), "*.json"},
+	{"backtest", filepath.Base(backtest.RunsDir("")), "*.json"},
+}
+
+// reportKinds 인덱스 섹션 순서와 제목
+var reportKinds = []struct{ kind, title string }{
+	{"daily", "Daily reports"},
+	{"session", "Daemon sessions"},
+	{"scan", "Last scans"},
+	{"cli", "Scan reports"},
+	{"brief", "Pre-market briefs"},
+	{"backtest", "Backtests"},
+}
+
+// ReportFile /reports 인덱스 항목
+type ReportFile struct {
+	Kind    string    `json:"kind"`
+	Path    string    `json:"path"` // /reports/ 아래 상대 경로 (슬래시 구분)
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// listReportFiles 공개 대상 파일 (최신순)
+func (s *Server) listReportFiles() []ReportFile {
+	var files []ReportFile
+	for _, src := range reportSources {
+		matches, _ := filepath.Glob(filepath.Join(s.dataDir, src.dir, src.pattern))
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			rel, err := filepath.Rel(s.dataDir, m)
+			if err != nil {
+				continue
+			}
+			files = append(files, ReportFile{Kind: src.kind, Path: filepath.ToSlash(rel),
+				Size: info.Size(), ModTime: info.ModTime()})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime.After(files[j].ModTime) })
+	return files
+}
+
+// reportFilePath 요청 경로가 공개 패턴에 맞으면 실제 파일 경로
+func (s *Server) reportFilePath(rel string) (string, bool) {
+	if rel == "" || strings.Contains(rel, "..") || strings.Contains(rel, `\`) {
+		return "", false
+	}
+	dir, name := path.Split(rel)
+	dir = strings.TrimSuffix(dir, "/")
+	for _, src := range reportSources {
+		if ok, _ := path.Match(filepath.ToSlash(src.dir), dir); !ok {
+			continue
+		}
+		if ok, _ := filepath.Match(src.pattern, name); ok {
+			return filepath.Join(s.dataDir, filepath.FromSlash(dir), name), true
+		}
+	}
+	return "", false
+}
+
+// handleReportFiles 생성된 리포트 파일 인덱스와 원본
+// GET /reports/ (HTML 인덱스), GET /reports/index.json, GET /reports/<path>
+func (s *Server) handleReportFiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Reports not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	rel := strings.TrimPrefix(r.URL.Path, "/reports/")
+	switch rel {
+	case "":
+		s.serveReportIndex(w)
+		return
+	case "index.json":
+		files := s.listReportFiles()
+		if files == nil {
+			files = []ReportFile{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"reports": files})
+		return
+	}
+
+	p, ok := s.reportFilePath(rel)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+	switch filepath.Ext(p) {
+	case ".json":
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	case ".html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// reportIndexSection 인덱스 한 섹션
+type reportIndexSection struct {
+	Title string
+	Files []ReportFile
+}
+
+var reportIndexTmpl = template.Must(template.New("reports").Funcs(template.FuncMap{
+	"size": formatFileSize,
+	"when": func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Traveler reports</title>
+<style>
+body { background: #111827; color: #e5e7eb; font: 14px -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0 auto; max-width: 960px; padding: 16px; }
+a { color: #60a5fa; text-decoration: none; }
+a:hover { text-decoration: underline; }
+h1 { font-size: 20px; }
+h2 { font-size: 15px; color: #9ca3af; border-bottom: 1px solid #374151; padding-bottom: 4px; margin-top: 24px; }
+table { width: 100%; border-collapse: collapse; }
+td { padding: 3px 6px; }
+td.num, td.time { color: #9ca3af; text-align: right; white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>Reports</h1>
+<p><a href="/">&larr; Dashboard</a> &middot; <a href="/reports/index.json">index.json</a></p>
+{{range .}}<h2>{{.Title}} ({{len .Files}})</h2>
+<table>
+{{range .Files}}<tr><td><a href="/reports/{{.Path}}">{{.Path}}</a></td><td class="num">{{size .Size}}</td><td class="time">{{when .ModTime}}</td></tr>
+{{end}}</table>
+{{else}}<p>No reports yet in the data directory.</p>
+{{end}}</body>
+</html>
+`))
+
+// serveReportIndex 종류별 파일 목록 (HTML)
+func (s *Server) serveReportIndex(w http.ResponseWriter) {
+	byKind := make(map[string][]ReportFile)
+	for _, f := range s.listReportFiles() {
+		byKind[f.Kind] = append(byKind[f.Kind], f)
+	}
+	var sections []reportIndexSection
+	for _, k := range reportKinds {
+		if files := byKind[k.kind]; len(files) > 0 {
+			sections = append(sections, reportIndexSection{Title: k.title, Files: files})
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := reportIndexTmpl.Execute(w, sections); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// formatFileSize 1.2 KB 형식
+func formatFileSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + " MB"
+	case n >= 1<<10:
+		return strconv.FormatFloat(float64(n)/(1<<10), 'f', 1, 64) + " KB"
+	}
+	return strconv.FormatInt(n, 10) + " B"
+}
@@ -0,0 +1,574 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/backtest"
+	"traveler/internal/broker"
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
+)
+
+// JSON-RPC 2.0 (POST /rpc) — 봇/외부 서비스용 API.
+// 화면용 HTTP 핸들러와 같은 로직을 쓰되 스키마가 고정된 결과만 반환한다.
+//
+// 인증: TRAVELER_RPC_TOKEN이 설정되면 모든 호출에 "Authorization: Bearer <token>" 필요.
+// 주문 메서드(orders.place, orders.cancel, signals.import)는 토큰이 설정된 경우에만 활성화된다.
+
+// JSON-RPC 에러 코드
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+	rpcUnauthorized   = -32001
+	rpcUnavailable    = -32002
+)
+
+// rpcTimeout 메서드 하나의 실행 제한 (브로커 호출 포함)
+const rpcTimeout = 30 * time.Second
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // 없으면 notification (응답 없음)
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+func rpcErrorf(code int, format string, args ...interface{}) *rpcError {
+	return &rpcError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// rpcMethod 메서드 정의 (Params는 rpc.describe에 노출되는 파라미터 설명)
+type rpcMethod struct {
+	Params string
+	Result string
+	Trade  bool // 주문 실행 — 토큰 필수
+	fn     func(ctx context.Context, params json.RawMessage) (interface{}, error)
+}
+
+// rpcMarketParams 마켓만 받는 메서드 공통 파라미터
+type rpcMarketParams struct {
+	Market string `json:"market"`
+}
+
+// RPCOrderRequest orders.place 파라미터
+type RPCOrderRequest struct {
+	Market     string  `json:"market"`
+	Symbol     string  `json:"symbol"`
+	Side       string  `json:"side"` // buy, sell
+	Type       string  `json:"type"` // market, limit (기본 market)
+	Quantity   float64 `json:"quantity"`
+	LimitPrice float64 `json:"limit_price,omitempty"`
+}
+
+// RPCOrderResult 주문 결과
+type RPCOrderResult struct {
+	OrderID     string    `json:"order_id"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	Type        string    `json:"type"`
+	Quantity    float64   `json:"quantity"`
+	FilledQty   float64   `json:"filled_qty"`
+	AvgPrice    float64   `json:"avg_price"`
+	Status      string    `json:"status"`
+	Message     string    `json:"message,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// rpcMethods 메서드 테이블
+func (s *Server) rpcMethods() map[string]rpcMethod {
+	return map[string]rpcMethod{
+		"rpc.describe": {
+			Result: "{methods: [{name, params, result, trade}]}",
+			fn: func(ctx context.Context, _ json.RawMessage) (interface{}, error) {
+				return s.rpcDescribe(), nil
+			},
+		},
+		"scan.start": {
+			Params: "{market, capital?, universe?, symbols?, continue?}",
+			Result: "{job_id, queued, position}",
+			fn:     s.rpcScanStart,
+		},
+		"scan.status": {
+			Params: "{market?, job_id?}",
+			Result: "ScanJob",
+			fn:     s.rpcScanStatus,
+		},
+		"scan.result": {
+			Params: "{market?, job_id?, include_candles?}",
+			Result: "ScanResponse (signals[] with guide = TradeGuide)",
+			fn:     s.rpcScanResult,
+		},
+		"scan.jobs": {
+			Params: "{market?, limit?}",
+			Result: "{jobs: ScanJob[]}",
+			fn:     s.rpcScanJobs,
+		},
+		"positions.list": {
+			Params: "{market}",
+			Result: "{positions: Position[]}",
+			fn: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+				var p rpcMarketParams
+				b, err := s.rpcBroker(params, &p, &p.Market)
+				if err != nil {
+					return nil, err
+				}
+				positions, err := s.positionResponses(ctx, b, p.Market)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{"positions": positions}, nil
+			},
+		},
+		"balance.get": {
+			Params: "{market}",
+			Result: "Balance",
+			fn: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+				var p rpcMarketParams
+				b, err := s.rpcBroker(params, &p, &p.Market)
+				if err != nil {
+					return nil, err
+				}
+				return balanceResponse(ctx, b)
+			},
+		},
+		"orders.list": {
+			Params: "{market}",
+			Result: "{orders: Order[]}",
+			fn: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+				var p rpcMarketParams
+				b, err := s.rpcBroker(params, &p, &p.Market)
+				if err != nil {
+					return nil, err
+				}
+				orders, err := pendingOrderResponses(ctx, b)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{"orders": orders}, nil
+			},
+		},
+		"orders.place": {
+			Params: "{market, symbol, side, type?, quantity, limit_price?}",
+			Result: "OrderResult",
+			Trade:  true,
+			fn:     s.rpcPlaceOrder,
+		},
+		"orders.cancel": {
+			Params: "{market, order_id}",
+			Result: "{cancelled: true}",
+			Trade:  true,
+			fn: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+				var p struct {
+					Market  string `json:"market"`
+					OrderID string `json:"order_id"`
+				}
+				b, err := s.rpcBroker(params, &p, &p.Market)
+				if err != nil {
+					return nil, err
+				}
+				if p.OrderID == "" {
+					return nil, rpcErrorf(rpcInvalidParams, "order_id is required")
+				}
+				if err := b.CancelOrder(ctx, p.OrderID); err != nil {
+					return nil, err
+				}
+				log.Printf("[WEB] RPC cancelled order %s (%s)", p.OrderID, p.Market)
+				return map[string]bool{"cancelled": true}, nil
+			},
+		},
+		"signals.import": {
+			Params: "{market, signals: Signal[], dry_run?, market_order?}",
+			Result: "SignalImportResponse",
+			Trade:  true,
+			fn: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+				var p struct {
+					Market      string            `json:"market"`
+					Signals     []strategy.Signal `json:"signals"`
+					DryRun      *bool             `json:"dry_run"` // 기본 true
+					MarketOrder bool              `json:"market_order"`
+				}
+				if err := decodeRPCParams(params, &p); err != nil {
+					return nil, err
+				}
+				if p.Market == "" {
+					p.Market = "us"
+				}
+				dryRun := p.DryRun == nil || *p.DryRun
+				resp, status, err := s.importSignals(ctx, p.Market, p.Signals, dryRun, p.MarketOrder)
+				if err != nil {
+					code := rpcInternalError
+					switch status {
+					case http.StatusBadRequest:
+						code = rpcInvalidParams
+					case http.StatusServiceUnavailable:
+						code = rpcUnavailable
+					}
+					return nil, rpcErrorf(code, "%v", err)
+				}
+				return resp, nil
+			},
+		},
+		"backtest.runs": {
+			Result: "{runs: RunInfo[]}",
+			fn: func(ctx context.Context, _ json.RawMessage) (interface{}, error) {
+				if s.dataDir == "" {
+					return nil, rpcErrorf(rpcUnavailable, "backtest runs not available (no data dir)")
+				}
+				runs, err := backtest.ListRuns(backtest.RunsDir(s.dataDir))
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{"runs": runs}, nil
+			},
+		},
+		"backtest.get": {
+			Params: "{name, include_trades?}",
+			Result: "BacktestResult (SavedRun)",
+			fn: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+				var p struct {
+					Name          string `json:"name"`
+					IncludeTrades bool   `json:"include_trades"`
+				}
+				if err := decodeRPCParams(params, &p); err != nil {
+					return nil, err
+				}
+				if s.dataDir == "" {
+					return nil, rpcErrorf(rpcUnavailable, "backtest runs not available (no data dir)")
+				}
+				run, err := s.loadSavedRun(p.Name)
+				if err != nil {
+					return nil, rpcErrorf(rpcInvalidParams, "%v", err)
+				}
+				if !p.IncludeTrades {
+					run.Trades = nil
+				}
+				return run, nil
+			},
+		},
+	}
+}
+
+// handleRPC JSON-RPC 2.0 엔드포인트 (단건/배치)
+func (s *Server) handleRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed — use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.bearerAuthorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: rpcErrorf(rpcUnauthorized, "missing or invalid bearer token")})
+		return
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		json.NewEncoder(w).Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: rpcErrorf(rpcParseError, "parse error: %v", err)})
+		return
+	}
+
+	methods := s.rpcMethods()
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "[") {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(raw, &batch); err != nil || len(batch) == 0 {
+			json.NewEncoder(w).Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+				Error: rpcErrorf(rpcInvalidRequest, "invalid batch")})
+			return
+		}
+		var out []rpcResponse
+		for _, item := range batch {
+			if resp, ok := s.callRPC(r.Context(), methods, item); ok {
+				out = append(out, resp)
+			}
+		}
+		if len(out) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(out)
+		return
+	}
+
+	resp, ok := s.callRPC(r.Context(), methods, raw)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// callRPC 요청 하나 실행 → (응답, 응답 필요 여부). notification이면 false.
+func (s *Server) callRPC(ctx context.Context, methods map[string]rpcMethod, raw json.RawMessage) (rpcResponse, bool) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil || req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: rpcErrorf(rpcInvalidRequest, "invalid request")}, true
+	}
+	notify := len(req.ID) == 0
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+
+	m, ok := methods[req.Method]
+	switch {
+	case !ok:
+		resp.Error = rpcErrorf(rpcMethodNotFound, "method %q not found", req.Method)
+	case m.Trade && s.readOnly:
+		resp.Error = rpcErrorf(rpcUnauthorized, "%s is disabled: web server is read-only", req.Method)
+	case m.Trade && s.rpcToken == "":
+		resp.Error = rpcErrorf(rpcUnauthorized, "%s is disabled: set TRAVELER_RPC_TOKEN to enable order methods", req.Method)
+	default:
+		callCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+		result, err := m.fn(callCtx, req.Params)
+		cancel()
+		if err != nil {
+			var re *rpcError
+			if !errors.As(err, &re) {
+				re = rpcErrorf(rpcInternalError, "%v", err)
+			}
+			resp.Error = re
+		} else {
+			resp.Result = result
+		}
+	}
+	return resp, !notify
+}
+
+func (s *Server) rpcDescribe() interface{} {
+	type methodInfo struct {
+		Name   string `json:"name"`
+		Params string `json:"params,omitempty"`
+		Result string `json:"result"`
+		Trade  bool   `json:"trade,omitempty"`
+	}
+	var list []methodInfo
+	for name, m := range s.rpcMethods() {
+		list = append(list, methodInfo{Name: name, Params: m.Params, Result: m.Result, Trade: m.Trade})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return map[string]interface{}{"methods": list, "trading_enabled": s.rpcToken != "" && !s.readOnly}
+}
+
+// decodeRPCParams params 디코딩 (없으면 그대로 둠, 모르는 필드는 에러)
+func decodeRPCParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(string(params)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return rpcErrorf(rpcInvalidParams, "invalid params: %v", err)
+	}
+	return nil
+}
+
+// rpcBroker params 디코딩 + 마켓 브로커 조회 (market 기본값 us)
+func (s *Server) rpcBroker(params json.RawMessage, v interface{}, market *string) (broker.Broker, error) {
+	if err := decodeRPCParams(params, v); err != nil {
+		return nil, err
+	}
+	if *market == "" {
+		*market = "us"
+	}
+	b := s.getBrokerForMarket(*market)
+	if b == nil {
+		return nil, rpcErrorf(rpcUnavailable, "broker not configured for market %s", *market)
+	}
+	return b, nil
+}
+
+func (s *Server) rpcScanStart(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Market   string   `json:"market"`
+		Capital  float64  `json:"capital"`
+		Universe string   `json:"universe"`
+		Symbols  []string `json:"symbols"`
+		Continue bool     `json:"continue"`
+	}
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Market == "" {
+		p.Market = "us"
+	}
+	if strings.HasPrefix(p.Market, "sim-") {
+		return nil, rpcErrorf(rpcInvalidParams, "scan not available for simulation markets")
+	}
+	job, ahead, err := s.submitScan(p.Market, ScanRequest{Capital: p.Capital, Universe: p.Universe, Symbols: p.Symbols}, p.Continue)
+	if errors.Is(err, errScanQueueFull) {
+		return nil, rpcErrorf(rpcUnavailable, "%v", err)
+	}
+	if err != nil {
+		return nil, rpcErrorf(rpcInvalidParams, "%v", err)
+	}
+	return map[string]interface{}{"job_id": job.ID, "queued": ahead > 0, "position": ahead}, nil
+}
+
+func (s *Server) rpcScanStatus(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Market string `json:"market"`
+		JobID  string `json:"job_id"`
+	}
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.JobID == "" {
+		// 마켓의 가장 최근 작업
+		s.jobs.mu.Lock()
+		for i := len(s.jobs.jobs) - 1; i >= 0; i-- {
+			if j := s.jobs.jobs[i]; p.Market == "" || j.Market == p.Market {
+				p.JobID = j.ID
+				break
+			}
+		}
+		s.jobs.mu.Unlock()
+	}
+	if p.JobID == "" {
+		return nil, rpcErrorf(rpcUnavailable, "no scan jobs yet")
+	}
+	job, ok := s.findScanJob(p.JobID)
+	if !ok {
+		return nil, rpcErrorf(rpcInvalidParams, "scan job %q not found", p.JobID)
+	}
+	if job.Status == "running" {
+		st := s.getScanState(job.Market)
+		job.Message, job.Scanned, job.Signals = st.Message, st.Scanned, st.Found
+	}
+	return job, nil
+}
+
+func (s *Server) rpcScanResult(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Market         string `json:"market"`
+		JobID          string `json:"job_id"`
+		IncludeCandles bool   `json:"include_candles"`
+	}
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	var data json.RawMessage
+	if p.JobID != "" {
+		job, ok := s.findScanJob(p.JobID)
+		if !ok {
+			return nil, rpcErrorf(rpcInvalidParams, "scan job %q not found", p.JobID)
+		}
+		if job.ResultURL == "" {
+			return nil, rpcErrorf(rpcUnavailable, "scan job %s has no stored result (status %s)", job.ID, job.Status)
+		}
+		var err error
+		if data, err = s.readScanJobResult(job.ID); err != nil {
+			return nil, err
+		}
+	} else if data = s.latestScanResult(p.Market); data == nil {
+		return nil, rpcErrorf(rpcUnavailable, "no scan result available")
+	}
+
+	var resp ScanResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if !p.IncludeCandles {
+		// 차트 데이터는 화면용 — 기본 응답에서 제외
+		for i := range resp.Signals {
+			resp.Signals[i].Candles = nil
+			resp.Signals[i].Signal.Candles = nil
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) rpcScanJobs(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Market string `json:"market"`
+		Limit  int    `json:"limit"`
+	}
+	if err := decodeRPCParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Limit <= 0 {
+		p.Limit = 20
+	}
+	return map[string]interface{}{"jobs": s.listScanJobs(p.Market, p.Limit), "dropped": s.droppedScanJobs()}, nil
+}
+
+func (s *Server) rpcPlaceOrder(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p RPCOrderRequest
+	b, err := s.rpcBroker(params, &p, &p.Market)
+	if err != nil {
+		return nil, err
+	}
+	order := broker.Order{
+		Symbol:     strings.ToUpper(strings.TrimSpace(p.Symbol)),
+		Side:       broker.OrderSide(strings.ToLower(p.Side)),
+		Type:       broker.OrderType(strings.ToLower(p.Type)),
+		Quantity:   p.Quantity,
+		LimitPrice: p.LimitPrice,
+	}
+	if order.Type == "" {
+		order.Type = broker.OrderTypeMarket
+	}
+	switch {
+	case order.Symbol == "":
+		return nil, rpcErrorf(rpcInvalidParams, "symbol is required")
+	case order.Side != broker.OrderSideBuy && order.Side != broker.OrderSideSell:
+		return nil, rpcErrorf(rpcInvalidParams, "side must be buy or sell")
+	case order.Type != broker.OrderTypeMarket && order.Type != broker.OrderTypeLimit:
+		return nil, rpcErrorf(rpcInvalidParams, "type must be market or limit")
+	case order.Quantity <= 0:
+		return nil, rpcErrorf(rpcInvalidParams, "quantity must be positive")
+	case order.Type == broker.OrderTypeLimit && order.LimitPrice <= 0:
+		return nil, rpcErrorf(rpcInvalidParams, "limit_price is required for limit orders")
+	}
+
+	at, sizerCfg, capital, _, err := s.newWebTrader(ctx, p.Market, false, order.Type == broker.OrderTypeMarket)
+	if err != nil {
+		return nil, rpcErrorf(rpcUnavailable, "%v", err)
+	}
+	at.AddPreTradeChecks(trader.PositionSizeCheck{Quote: b.GetQuote, Capital: capital, MaxPct: sizerCfg.MaxPositionPct})
+
+	log.Printf("[WEB] RPC order: %s %s %.4f %s (%s)", order.Side, order.Symbol, order.Quantity, order.Type, p.Market)
+	res, err := at.PlaceOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	return RPCOrderResult{
+		OrderID:     res.OrderID,
+		Symbol:      res.Symbol,
+		Side:        string(res.Side),
+		Type:        string(res.Type),
+		Quantity:    res.Quantity,
+		FilledQty:   res.FilledQty,
+		AvgPrice:    res.AvgPrice,
+		Status:      res.Status,
+		Message:     res.Message,
+		SubmittedAt: res.SubmittedAt,
+	}, nil
+}
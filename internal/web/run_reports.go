@@ -0,0 +1,59 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"traveler/internal/daemon"
+)
+
+// handleRunReports 데몬 세션 리포트 목록 (최신순)
+// GET /api/reports?market=us&limit=20
+func (s *Server) handleRunReports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Run reports not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	infos, err := daemon.ListRunReports(s.dataDir, r.URL.Query().Get("market"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 && len(infos) > limit {
+		infos = infos[:limit]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"reports": infos})
+}
+
+// handleRunReport 세션 리포트 하나
+// GET /api/reports/<id>
+func (s *Server) handleRunReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Run reports not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/api/reports/")
+	rep, err := daemon.LoadRunReport(s.dataDir, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, "no run report "+id, http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rep)
+}
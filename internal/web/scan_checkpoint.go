@@ -0,0 +1,179 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"traveler/internal/daemon"
+	"traveler/internal/fsutil"
+	"traveler/internal/strategy"
+)
+
+const (
+	scanSoftLimit      = 15 * time.Minute // 이후 남은 종목은 다음 스캔으로 이월 (?continue=1이면 계속)
+	scanHardLimit      = 45 * time.Minute // 컨텍스트 상한 (후처리 포함)
+	scanCheckpointTTL  = 6 * time.Hour    // 이보다 오래된 체크포인트는 버리고 새로 시작
+	scanCheckpointLive = time.Hour        // 장중에는 이보다 먼저 시작한 체크포인트의 시그널을 재사용하지 않음
+	scanCheckpointSave = 25               // N 종목마다 디스크 저장
+)
+
+// scanCheckpoint 웹 스캔 중간 결과 (종목별 분석 완료 여부 + 최고 시그널).
+// 시간 제한에 걸리면 남은 종목만 다음 스캔 때 이어서 분석한다.
+type scanCheckpoint struct {
+	mu   sync.Mutex
+	path string // ""이면 메모리에만 유지
+
+	Market    string                     `json:"market"`
+	Session   string                     `json:"session"` // 시작 당시 거래일/장 상태 (바뀌면 시그널 가격이 낡음)
+	StartedAt time.Time                  `json:"started_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+	Scanned   map[string]bool            `json:"scanned"`
+	Signals   map[string]strategy.Signal `json:"signals"`
+
+	softDeadline time.Time // zero면 제한 없음
+	stopped      bool      // soft limit으로 중단됨
+	resumed      int       // 이전 체크포인트에서 이어받은 종목 수
+	pending      int       // 마지막 저장 이후 기록 수
+}
+
+// loadScanCheckpoint 체크포인트 로드 (없거나 만료됐거나 다른 세션에서 시작했으면 새로 생성)
+// 이어받은 시그널은 다시 분석하지 않으므로 같은 세션(거래일 + 장 전/장중/장 후)에서만 재사용하고,
+// 장중에는 가격이 계속 움직이므로 scanCheckpointLive 안에 시작한 것만 쓴다.
+func (s *Server) loadScanCheckpoint(market, key string, noSoftLimit bool) *scanCheckpoint {
+	status := daemon.MarketStatusFor(market)
+	cp := &scanCheckpoint{
+		Market:    key,
+		Session:   scanSession(status),
+		StartedAt: time.Now(),
+		Scanned:   make(map[string]bool),
+		Signals:   make(map[string]strategy.Signal),
+	}
+	if s.dataDir != "" {
+		removeExpiredCheckpoints(s.dataDir)
+		cp.path = filepath.Join(s.dataDir, fmt.Sprintf("scan_checkpoint_%s.json", key))
+		if data, err := os.ReadFile(cp.path); err == nil {
+			var prev scanCheckpoint
+			if json.Unmarshal(data, &prev) == nil && prev.Scanned != nil && resumable(&prev, cp.Session, status.IsOpen, time.Now()) {
+				cp.StartedAt = prev.StartedAt
+				cp.Scanned = prev.Scanned
+				if prev.Signals != nil {
+					cp.Signals = prev.Signals
+				}
+				cp.resumed = len(cp.Scanned)
+				log.Printf("[WEB] Resuming %s scan: %d symbols already analyzed", key, cp.resumed)
+			}
+		}
+	}
+	if !noSoftLimit {
+		cp.softDeadline = time.Now().Add(scanSoftLimit)
+	}
+	return cp
+}
+
+// removeExpiredCheckpoints 만료된 지정 대상 체크포인트 파일 정리 (대상마다 파일이 생기므로)
+func removeExpiredCheckpoints(dataDir string) {
+	paths, _ := filepath.Glob(filepath.Join(dataDir, "scan_checkpoint_*-custom-*.json"))
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && time.Since(info.ModTime()) > scanCheckpointTTL {
+			os.Remove(p)
+		}
+	}
+}
+
+// scanSession 거래일 + 장 상태 ("2026-10-16/open"): 같은 값이면 이어받은 시그널의 가격 기준이 같다
+func scanSession(st daemon.MarketStatus) string {
+	return st.CurrentTimeET.Format("2006-01-02") + "/" + st.Reason
+}
+
+// resumable 이전 체크포인트를 이어서 써도 되는지
+func resumable(prev *scanCheckpoint, session string, open bool, now time.Time) bool {
+	if now.Sub(prev.UpdatedAt) >= scanCheckpointTTL || prev.Session != session {
+		return false
+	}
+	return !open || now.Sub(prev.StartedAt) < scanCheckpointLive
+}
+
+// Lookup 이미 분석한 종목이면 (시그널, true)
+func (cp *scanCheckpoint) Lookup(symbol string) (*strategy.Signal, bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if !cp.Scanned[symbol] {
+		return nil, false
+	}
+	if sig, ok := cp.Signals[symbol]; ok {
+		return &sig, true
+	}
+	return nil, true
+}
+
+// Record 종목 분석 결과 기록 (scanCheckpointSave 종목마다 저장)
+func (cp *scanCheckpoint) Record(symbol string, sig *strategy.Signal) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.Scanned[symbol] = true
+	if sig != nil {
+		cp.Signals[symbol] = *sig
+	}
+	cp.pending++
+	if cp.pending >= scanCheckpointSave {
+		cp.saveLocked()
+	}
+}
+
+// ShouldStop soft limit 초과 여부 (초과 시 체크포인트 저장)
+func (cp *scanCheckpoint) ShouldStop() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.stopped {
+		return true
+	}
+	if cp.softDeadline.IsZero() || time.Now().Before(cp.softDeadline) {
+		return false
+	}
+	cp.stopped = true
+	cp.saveLocked()
+	log.Printf("[WEB] %s scan reached %s soft limit after %d symbols — remaining symbols resume on next scan",
+		cp.Market, scanSoftLimit, len(cp.Scanned))
+	return true
+}
+
+// Finish 스캔 종료: 완주했으면 체크포인트 삭제, 중단됐으면 저장 후 true
+func (cp *scanCheckpoint) Finish() (partial bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.stopped {
+		cp.saveLocked()
+		return true
+	}
+	if cp.path != "" {
+		os.Remove(cp.path)
+	}
+	return false
+}
+
+// Save 즉시 저장 (에러/취소 경로)
+func (cp *scanCheckpoint) Save() {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.saveLocked()
+}
+
+func (cp *scanCheckpoint) saveLocked() {
+	cp.pending = 0
+	cp.UpdatedAt = time.Now()
+	if cp.path == "" {
+		return
+	}
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return
+	}
+	if err := fsutil.WriteFile(cp.path, data, 0644); err != nil {
+		log.Printf("[WEB] Failed to save scan checkpoint: %v", err)
+	}
+}
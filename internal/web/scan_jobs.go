@@ -0,0 +1,393 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"traveler/internal/broker"
+	"traveler/internal/fsutil"
+	"traveler/internal/money"
+	"traveler/internal/notify"
+	"traveler/internal/strategy"
+)
+
+// scanJobHistoryLimit 보관할 스캔 작업 수 (초과분은 결과 파일과 함께 삭제)
+const scanJobHistoryLimit = 50
+
+// scanJob 스캔 요청 한 건 (마켓별로 한 번에 하나씩 실행)
+type scanJob struct {
+	ID         string    `json:"id"`
+	Market     string    `json:"market"`
+	Capital    float64   `json:"capital,omitempty"` // 0이면 실행 시점 브로커 잔고
+	scanTarget           // 요청에서 지정한 universe/symbols
+	Continue   bool      `json:"continue,omitempty"`
+	Status     string    `json:"status"` // queued, running, done, error
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Signals    int       `json:"signals"`
+	Scanned    int       `json:"scanned"`
+	Partial    bool      `json:"partial,omitempty"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	StartedAt  time.Time `json:"started_at,omitempty"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	ResultURL  string    `json:"result_url,omitempty"` // /api/scan/jobs/result?id=...
+}
+
+// scanQueue 마켓별 직렬 실행 큐 + 작업 이력
+type scanQueue struct {
+	mu      sync.Mutex
+	jobs    []*scanJob               // 오래된 순
+	workers map[string]chan *scanJob // 마켓별 워커 채널
+	dropped int                      // 대기열이 가득 차 거절한 작업 수 (재시작 시 초기화)
+	path    string
+}
+
+// scanQueueDepth 마켓별 대기 작업 상한
+const scanQueueDepth = 100
+
+// errScanQueueFull 마켓 대기열이 가득 차 작업을 받지 않음
+var errScanQueueFull = errors.New("scan queue full — try again after queued scans finish")
+
+func newScanJobID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return time.Now().Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+// initScanQueue 이력 로드 (재시작 시 queued/running 상태는 중단으로 표시)
+func (s *Server) initScanQueue() {
+	q := &scanQueue{workers: make(map[string]chan *scanJob)}
+	if s.dataDir != "" {
+		q.path = filepath.Join(s.dataDir, "scan_jobs.json")
+		if data, err := os.ReadFile(q.path); err == nil {
+			json.Unmarshal(data, &q.jobs)
+		}
+		for _, j := range q.jobs {
+			if j.Status == "queued" || j.Status == "running" {
+				j.Status = "error"
+				j.Error = "interrupted by server restart"
+			}
+		}
+	}
+	s.jobs = q
+}
+
+// scanResultDir 작업별 결과 저장 디렉토리
+func (s *Server) scanResultDir() string {
+	if s.dataDir == "" {
+		return ""
+	}
+	return filepath.Join(s.dataDir, "scan_results")
+}
+
+// enqueueScanJob 작업 추가 → 앞에 대기/실행 중인 작업 수
+// 대기열이 가득 차면 기다리지 않고 작업을 error로 기록한 뒤 errScanQueueFull
+func (s *Server) enqueueScanJob(job *scanJob) (int, error) {
+	q := s.jobs
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ahead := 0
+	for _, j := range q.jobs {
+		if j.Market == job.Market && (j.Status == "queued" || j.Status == "running") {
+			ahead++
+		}
+	}
+	ch, ok := q.workers[job.Market]
+	if !ok {
+		ch = make(chan *scanJob, scanQueueDepth)
+		q.workers[job.Market] = ch
+		go s.scanWorker(ch)
+	}
+
+	// 락을 잡은 채로 보내므로 절대 블록하지 않는다
+	select {
+	case ch <- job:
+	default:
+		q.dropped++
+		job.Status = "error"
+		job.Error = errScanQueueFull.Error()
+		job.FinishedAt = time.Now()
+		log.Printf("[WEB] %s scan queue full — dropped job %s (%d dropped total)", job.Market, job.ID, q.dropped)
+	}
+	q.jobs = append(q.jobs, job)
+	s.trimScanJobsLocked()
+	s.saveScanJobsLocked()
+	if job.Status == "error" {
+		return ahead, errScanQueueFull
+	}
+	return ahead, nil
+}
+
+// scanWorker 마켓 하나의 작업을 순서대로 실행
+func (s *Server) scanWorker(ch chan *scanJob) {
+	for job := range ch {
+		s.runScanJob(job)
+	}
+}
+
+// runScanJob 작업 실행 (스캔 완료까지 블록) 후 결과/이력 기록
+func (s *Server) runScanJob(job *scanJob) {
+	market := job.Market
+
+	// soft limit(15분) 이후 남은 종목은 체크포인트에 남겨 다음 스캔에서 이어서 분석 (continue면 계속 진행)
+	cp := s.loadScanCheckpoint(market, scanCheckpointKey(market, job.scanTarget), job.Continue)
+	ctx, cancel := context.WithTimeout(context.Background(), scanHardLimit)
+	s.scanMu.Lock()
+	switch market {
+	case "kr":
+		s.scanKRCancel = cancel
+		s.scanKR = scanState{Status: "running", Message: "Starting KR adaptive scan...", StartedAt: time.Now()}
+	case "crypto":
+		s.scanCryptoCancel = cancel
+		s.scanCrypto = scanState{Status: "running", Message: "Starting crypto scan...", StartedAt: time.Now()}
+	default:
+		s.scanCancel = cancel
+		s.scan = scanState{Status: "running", Message: "Starting adaptive multi-strategy scan...", StartedAt: time.Now()}
+	}
+	s.scanMu.Unlock()
+
+	s.updateScanJob(job, func(j *scanJob) {
+		j.Status = "running"
+		j.StartedAt = time.Now()
+	})
+
+	// capital — 요청값 > 브로커 잔고 > 기본값
+	capital := job.Capital
+	if capital <= 0 {
+		capital = s.capital
+		var b broker.Broker
+		switch market {
+		case "kr":
+			b = s.brokerKR
+		case "crypto":
+			b = s.brokerCrypto
+		default:
+			b = s.broker
+		}
+		if b != nil {
+			if bal, err := b.GetBalance(context.Background()); err == nil && bal.TotalEquity > 0 {
+				capital = bal.TotalEquity
+				log.Printf("[WEB] Using actual broker balance for %s: %.2f", market, capital)
+			}
+		}
+	}
+
+	if job.IsCustom() {
+		log.Printf("[WEB] %s scan target: universe=%q symbols=%v", market, job.Universe, job.Symbols)
+	}
+	switch market {
+	case "kr":
+		log.Printf("[WEB] KR scan starting (job %s, capital=%s)", job.ID, money.Format(capital, money.KRW))
+		s.runKRScanAsync(ctx, cancel, capital, cp, job.scanTarget)
+	case "crypto":
+		log.Printf("[WEB] Crypto scan starting (job %s, capital=%s)", job.ID, money.Format(capital, money.KRW))
+		s.runCryptoScanAsync(ctx, cancel, capital, cp, job.scanTarget)
+	default:
+		log.Printf("[WEB] Adaptive scan starting (job %s, capital=%s)", job.ID, money.Format(capital, money.USD))
+		s.runScanAsync(ctx, cancel, capital, cp, job.scanTarget)
+	}
+
+	state := s.getScanState(market)
+	var summary struct {
+		SignalsFound int  `json:"signals_found"`
+		TotalScanned int  `json:"total_scanned"`
+		Partial      bool `json:"partial"`
+	}
+	resultURL := ""
+	if state.Status == "done" && state.Result != nil {
+		json.Unmarshal(state.Result, &summary)
+		if dir := s.scanResultDir(); dir != "" {
+			if err := os.MkdirAll(dir, 0755); err == nil {
+				if err := fsutil.WriteFile(filepath.Join(dir, job.ID+".json"), state.Result, 0644); err == nil {
+					resultURL = "/api/scan/jobs/result?id=" + job.ID
+				}
+			}
+		}
+	}
+
+	s.updateScanJob(job, func(j *scanJob) {
+		j.FinishedAt = time.Now()
+		j.DurationMs = j.FinishedAt.Sub(j.StartedAt).Milliseconds()
+		j.Status = state.Status
+		if j.Status != "done" {
+			j.Status = "error"
+		}
+		j.Message = state.Message
+		j.Error = state.Error
+		j.Signals = summary.SignalsFound
+		j.Scanned = summary.TotalScanned
+		j.Partial = summary.Partial
+		j.ResultURL = resultURL
+	})
+
+	if state.Status == "done" {
+		s.emitScanCompleted(job, state.Result)
+	}
+}
+
+// emitScanCompleted scan.completed 웹훅 (시그널은 차트 없이 요약)
+func (s *Server) emitScanCompleted(job *scanJob, result json.RawMessage) {
+	if s.webhooks == nil {
+		return
+	}
+	var resp ScanResponse
+	if err := json.Unmarshal(result, &resp); err != nil {
+		return
+	}
+	type signalSummary struct {
+		Symbol      string               `json:"symbol"`
+		Name        string               `json:"name,omitempty"`
+		Strategy    string               `json:"strategy"`
+		Strength    float64              `json:"strength"`
+		Probability float64              `json:"probability"`
+		Guide       *strategy.TradeGuide `json:"guide,omitempty"`
+	}
+	sigs := make([]signalSummary, 0, len(resp.Signals))
+	for _, sig := range resp.Signals {
+		sigs = append(sigs, signalSummary{
+			Symbol:      sig.Stock.Symbol,
+			Name:        sig.Stock.Name,
+			Strategy:    sig.Strategy,
+			Strength:    sig.Strength,
+			Probability: sig.Probability,
+			Guide:       sig.Guide,
+		})
+	}
+	s.jobs.mu.Lock()
+	j := *job
+	s.jobs.mu.Unlock()
+	s.webhooks.Emit(notify.EventScanCompleted, map[string]interface{}{
+		"market":         j.Market,
+		"job":            j,
+		"total_scanned":  resp.TotalScanned,
+		"signals_found":  resp.SignalsFound,
+		"universes_used": resp.UniversesUsed,
+		"regime":         resp.Regime,
+		"partial":        resp.Partial,
+		"scan_time":      resp.ScanTime,
+		"signals":        sigs,
+	})
+}
+
+// updateScanJob 작업 상태 변경 + 이력 저장
+func (s *Server) updateScanJob(job *scanJob, fn func(*scanJob)) {
+	s.jobs.mu.Lock()
+	defer s.jobs.mu.Unlock()
+	fn(job)
+	s.saveScanJobsLocked()
+}
+
+// findScanJob ID로 작업 조회 (복사본)
+func (s *Server) findScanJob(id string) (scanJob, bool) {
+	s.jobs.mu.Lock()
+	defer s.jobs.mu.Unlock()
+	for _, j := range s.jobs.jobs {
+		if j.ID == id {
+			return *j, true
+		}
+	}
+	return scanJob{}, false
+}
+
+// trimScanJobsLocked 이력 상한 초과분 삭제 (실행 중/대기 작업은 유지)
+func (s *Server) trimScanJobsLocked() {
+	q := s.jobs
+	for len(q.jobs) > scanJobHistoryLimit {
+		old := q.jobs[0]
+		if old.Status == "queued" || old.Status == "running" {
+			break
+		}
+		if dir := s.scanResultDir(); dir != "" {
+			os.Remove(filepath.Join(dir, old.ID+".json"))
+		}
+		q.jobs = q.jobs[1:]
+	}
+}
+
+func (s *Server) saveScanJobsLocked() {
+	if s.jobs.path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(s.jobs.jobs, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := fsutil.WriteFile(s.jobs.path, data, 0644); err != nil {
+		log.Printf("[WEB] Failed to save scan jobs: %v", err)
+	}
+}
+
+// handleScanJobs 스캔 작업 이력 (?market=us&limit=20, 최신순)
+func (s *Server) handleScanJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	market := r.URL.Query().Get("market")
+	limit := 20
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"jobs": s.listScanJobs(market, limit), "dropped": s.droppedScanJobs()})
+}
+
+// droppedScanJobs 대기열 초과로 거절된 작업 수
+func (s *Server) droppedScanJobs() int {
+	s.jobs.mu.Lock()
+	defer s.jobs.mu.Unlock()
+	return s.jobs.dropped
+}
+
+// listScanJobs 최근 작업 (최신순, market이 비면 전체)
+func (s *Server) listScanJobs(market string, limit int) []scanJob {
+	s.jobs.mu.Lock()
+	defer s.jobs.mu.Unlock()
+	jobs := make([]scanJob, 0, limit)
+	for i := len(s.jobs.jobs) - 1; i >= 0 && len(jobs) < limit; i-- {
+		j := s.jobs.jobs[i]
+		if market == "" || j.Market == market {
+			jobs = append(jobs, *j)
+		}
+	}
+	return jobs
+}
+
+// handleScanJobResult 작업별 저장된 스캔 결과 (?id=...)
+func (s *Server) handleScanJobResult(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	job, ok := s.findScanJob(id)
+	if !ok || job.ResultURL == "" {
+		http.Error(w, fmt.Sprintf("no stored result for job %q", id), http.StatusNotFound)
+		return
+	}
+	data, err := s.readScanJobResult(job.ID)
+	if err != nil {
+		http.Error(w, "result file missing", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// readScanJobResult 작업별로 저장된 스캔 결과 (ScanResponse JSON)
+func (s *Server) readScanJobResult(id string) (json.RawMessage, error) {
+	dir := s.scanResultDir()
+	if dir == "" {
+		return nil, fmt.Errorf("no data dir")
+	}
+	return os.ReadFile(filepath.Join(dir, id+".json"))
+}
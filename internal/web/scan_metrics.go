@@ -0,0 +1,104 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"traveler/internal/daemon"
+)
+
+// handleScanMetrics 일별 스캔 품질 시계열 + 최근/직전 구간 비교
+// GET /api/scan-metrics?market=us&days=180&window=20
+func (s *Server) handleScanMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Scan metrics not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	market := r.URL.Query().Get("market")
+	if market == "" {
+		market = "us"
+	}
+	metrics, err := daemon.LoadScanMetrics(s.dataDir, market)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	window, _ := strconv.Atoi(r.URL.Query().Get("window"))
+	trend := daemon.BuildScanTrend(metrics, window)
+	if days, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && days > 0 && len(metrics) > days {
+		metrics = metrics[len(metrics)-days:]
+	}
+	if metrics == nil {
+		metrics = []daemon.ScanMetric{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"market":  market,
+		"metrics": metrics,
+		"trend":   trend,
+	})
+}
+
+// scanGauges /metrics로 내보내는 최근 스캔 값 (이름, 설명, 값)
+var scanGauges = []struct {
+	name, help string
+	value      func(m daemon.ScanMetric) float64
+}{
+	{"traveler_scan_scanned_symbols", "Symbols scanned in the latest daily scan", func(m daemon.ScanMetric) float64 { return float64(m.Scanned) }},
+	{"traveler_scan_candidates", "Signals at adaptive quality evaluation (before AI/VIX filters)", func(m daemon.ScanMetric) float64 { return float64(m.Candidates) }},
+	{"traveler_scan_signals", "Final signals after filters and sizing", func(m daemon.ScanMetric) float64 { return float64(m.Signals) }},
+	{"traveler_scan_avg_probability", "Average signal win probability (percent)", func(m daemon.ScanMetric) float64 { return m.AvgProb }},
+	{"traveler_scan_avg_risk_reward", "Average signal risk/reward", func(m daemon.ScanMetric) float64 { return m.AvgRR }},
+	{"traveler_scan_expansions", "Universe expansions by the adaptive scanner", func(m daemon.ScanMetric) float64 { return float64(m.Expansions) }},
+	{"traveler_scan_fundamentals_filtered", "Signals removed by the fundamentals filter", func(m daemon.ScanMetric) float64 { return float64(m.FundamentalsFiltered) }},
+	{"traveler_scan_ai_filtered", "Signals removed by the AI filter", func(m daemon.ScanMetric) float64 { return float64(m.AIFiltered) }},
+	{"traveler_scan_duration_seconds", "Duration of the latest daily scan", func(m daemon.ScanMetric) float64 { return m.Seconds }},
+	{"traveler_scan_timestamp_seconds", "Unix time of the latest daily scan", func(m daemon.ScanMetric) float64 { return float64(m.UpdatedAt.Unix()) }},
+}
+
+// handleMetrics 마켓별 마지막 스캔 품질 (Prometheus 텍스트 형식)
+// GET /metrics
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	latest := make(map[string]daemon.ScanMetric)
+	var markets []string
+	if s.dataDir != "" {
+		files, _ := filepath.Glob(filepath.Join(s.dataDir, "scan_metrics_*.json"))
+		for _, f := range files {
+			market := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), "scan_metrics_"), ".json")
+			metrics, err := daemon.LoadScanMetrics(s.dataDir, market)
+			if err != nil || len(metrics) == 0 {
+				continue
+			}
+			latest[market] = metrics[len(metrics)-1]
+			markets = append(markets, market)
+		}
+	}
+	sort.Strings(markets)
+
+	var b strings.Builder
+	for _, g := range scanGauges {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for _, market := range markets {
+			fmt.Fprintf(&b, "%s{market=%q} %s\n", g.name, market, strconv.FormatFloat(g.value(latest[market]), 'g', -1, 64))
+		}
+	}
+	b.WriteString("# HELP traveler_scan_decision Decision of the latest daily scan (1 = current decision)\n# TYPE traveler_scan_decision gauge\n")
+	for _, market := range markets {
+		fmt.Fprintf(&b, "traveler_scan_decision{market=%q,decision=%q} 1\n", market, latest[market].Decision)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,162 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/fsutil"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+)
+
+// scanUniverseCustom Symbols 지정 스캔에 쓰는 가상 유니버스 이름
+const scanUniverseCustom symbols.Universe = "custom"
+
+// scanTarget 요청별 스캔 대상 (비어 있으면 자본 기반 기본 티어)
+type scanTarget struct {
+	Universe string   `json:"universe,omitempty"`
+	Symbols  []string `json:"symbols,omitempty"` // 지정 시 Universe보다 우선
+}
+
+// IsCustom 기본 티어 대신 지정 대상을 스캔하는지
+func (t scanTarget) IsCustom() bool {
+	return t.Universe != "" || len(t.Symbols) > 0
+}
+
+// scanSettings 마켓별 마지막 스캔 설정
+type scanSettings struct {
+	Capital float64 `json:"capital,omitempty"` // 0이면 브로커 잔고 사용
+	scanTarget
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// normalizeScanTarget 심볼 정리(대문자/중복 제거) + 유니버스가 마켓에 맞는지 검증
+func (s *Server) normalizeScanTarget(market string, t scanTarget) (scanTarget, error) {
+	var syms []string
+	seen := make(map[string]bool)
+	for _, sym := range t.Symbols {
+		sym = strings.ToUpper(strings.TrimSpace(sym))
+		if sym != "" && !seen[sym] {
+			seen[sym] = true
+			syms = append(syms, sym)
+		}
+	}
+	t.Symbols = syms
+	t.Universe = strings.TrimSpace(t.Universe)
+	if len(t.Symbols) > 0 || t.Universe == "" {
+		t.Universe = ""
+		return t, nil
+	}
+
+	u := symbols.Universe(t.Universe)
+	if _, err := symbols.ResolveUniverse(u, s.dataDir); err != nil {
+		return t, err
+	}
+	if !symbols.IsWatchlistUniverse(u) {
+		um := "us"
+		if symbols.IsCryptoUniverse(u) {
+			um = "crypto"
+		} else if symbols.IsKoreanUniverse(u) || u == symbols.UniverseKRETF {
+			um = "kr"
+		}
+		if um != market {
+			return t, fmt.Errorf("universe %s is for the %s market, not %s", u, um, market)
+		}
+	}
+	return t, nil
+}
+
+// applyScanTarget 지정 대상이 있으면 티어를 그 하나로 교체
+func applyScanTarget(scanner *trader.AdaptiveScanner, loader *webStockLoader, t scanTarget) {
+	switch {
+	case len(t.Symbols) > 0:
+		loader.custom = t.Symbols
+		scanner.SetTierFunc(func(float64) []trader.UniverseTier {
+			return []trader.UniverseTier{{Name: string(scanUniverseCustom), Universe: scanUniverseCustom, Priority: 1}}
+		})
+	case t.Universe != "":
+		scanner.SetTierFunc(func(float64) []trader.UniverseTier {
+			return []trader.UniverseTier{{Name: t.Universe, Universe: symbols.Universe(t.Universe), Priority: 1}}
+		})
+	}
+}
+
+// scanCheckpointKey 지정 대상 스캔은 대상(유니버스 + 정렬한 심볼 목록)별로 체크포인트를 분리
+// 대상이 다른 스캔이 서로의 분석 완료 목록을 이어받지 않도록 해시를 키에 넣는다.
+func scanCheckpointKey(market string, t scanTarget) string {
+	if !t.IsCustom() {
+		return market
+	}
+	syms := append([]string(nil), t.Symbols...)
+	sort.Strings(syms)
+	h := fnv.New32a()
+	h.Write([]byte(t.Universe + "|" + strings.Join(syms, ",")))
+	return fmt.Sprintf("%s-custom-%08x", market, h.Sum32())
+}
+
+func (s *Server) scanSettingsPath() string {
+	if s.dataDir == "" {
+		return ""
+	}
+	return filepath.Join(s.dataDir, "scan_settings.json")
+}
+
+// loadScanSettings 마켓별 마지막 설정 (없으면 빈 맵)
+func (s *Server) loadScanSettings() map[string]scanSettings {
+	settings := make(map[string]scanSettings)
+	if path := s.scanSettingsPath(); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			json.Unmarshal(data, &settings)
+		}
+	}
+	return settings
+}
+
+// saveScanSettings 마켓의 마지막 설정 갱신
+func (s *Server) saveScanSettings(market string, st scanSettings) {
+	path := s.scanSettingsPath()
+	if path == "" {
+		return
+	}
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	err := fsutil.Update(path, func() error {
+		settings := s.loadScanSettings()
+		st.UpdatedAt = time.Now()
+		settings[market] = st
+		data, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return err
+		}
+		return fsutil.WriteFile(path, data, 0644)
+	})
+	if err != nil {
+		log.Printf("[WEB] Failed to save scan settings: %v", err)
+	}
+}
+
+// handleScanSettings 마지막 스캔 설정 (?market=us, 없으면 전체)
+func (s *Server) handleScanSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.settingsMu.Lock()
+	settings := s.loadScanSettings()
+	s.settingsMu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if market := r.URL.Query().Get("market"); market != "" {
+		json.NewEncoder(w).Encode(settings[market])
+		return
+	}
+	json.NewEncoder(w).Encode(settings)
+}
@@ -17,7 +17,10 @@ import (
 	"traveler/internal/ai"
 	"traveler/internal/broker"
 	"traveler/internal/config"
+	"traveler/internal/fsutil"
+	"traveler/internal/notify"
 	"traveler/internal/provider"
+	"traveler/internal/symbols"
 	"traveler/internal/trader"
 )
 
@@ -37,15 +40,20 @@ type scanState struct {
 
 // Server represents the web server
 type Server struct {
-	config    *config.Config
-	provider  provider.Provider
-	capital   float64
-	universe  string
-	broker    broker.Broker
-	planStore *trader.PlanStore
-	history   *trader.TradeHistory
-	srv       *http.Server
-	dataDir   string
+	config     *config.Config
+	provider   provider.Provider
+	capital    float64
+	universe   string
+	broker     broker.Broker
+	planStore  *trader.PlanStore
+	history    *trader.TradeHistory
+	lists      *symbols.SymbolLists
+	filters    trader.SignalFilterChain // config trader.signal_filters (nil이면 블랙/화이트리스트만)
+	health     *provider.SymbolHealthStore
+	names      *symbols.NameIndex
+	watchlists *symbols.Watchlists
+	srv        *http.Server
+	dataDir    string
 
 	// 국내 시장 지원
 	brokerKR   broker.Broker
@@ -75,6 +83,16 @@ type Server struct {
 	scanCancel       context.CancelFunc
 	scanKRCancel     context.CancelFunc
 	scanCryptoCancel context.CancelFunc
+	jobs             *scanQueue // 스캔 작업 큐/이력
+	settingsMu       sync.Mutex // scan_settings.json
+	rpcToken         string     // TRAVELER_RPC_TOKEN — /rpc 인증 + 주문 메서드 활성화
+	readOnly         bool       // 조회/스캔만 허용 (주문·포지션·설정 변경 차단)
+	webhooks         *notify.WebhookNotifier
+}
+
+// SetWebhooks 외부 웹훅 설정 (스캔 완료 이벤트)
+func (s *Server) SetWebhooks(n *notify.WebhookNotifier) {
+	s.webhooks = n.WithSource("web")
 }
 
 // SetKoreanMarket 국내 시장 브로커/Provider 설정
@@ -126,6 +144,21 @@ func NewServer(cfg *config.Config, p provider.Provider, capital float64, univers
 		broker:   b,
 		dataDir:  dataDir,
 		scan:     scanState{Status: "idle"},
+		rpcToken: os.Getenv("TRAVELER_RPC_TOKEN"),
+		readOnly: cfg != nil && cfg.Web.ReadOnly,
+	}
+	s.initScanQueue()
+
+	if cfg != nil && len(cfg.Trader.SignalFilters) > 0 {
+		specs := make([]trader.SignalFilterSpec, 0, len(cfg.Trader.SignalFilters))
+		for _, f := range cfg.Trader.SignalFilters {
+			specs = append(specs, trader.SignalFilterSpec{Type: f.Type, Value: f.Value, Sectors: f.Sectors, Markets: f.Markets})
+		}
+		if chain, err := trader.NewSignalFilterChain(specs); err == nil {
+			s.filters = chain
+		} else {
+			log.Printf("[WEB] Warning: signal filters disabled: %v", err)
+		}
 	}
 
 	if b != nil && dataDir != "" {
@@ -146,6 +179,27 @@ func NewServer(cfg *config.Config, p provider.Provider, capital float64, univers
 		}
 	}
 
+	if dataDir != "" {
+		lists, err := symbols.NewSymbolLists(dataDir)
+		if err == nil {
+			s.lists = lists
+		} else {
+			log.Printf("[WEB] Warning: could not load symbol lists: %v", err)
+		}
+		if wl, err := symbols.NewWatchlists(dataDir); err == nil {
+			s.watchlists = wl
+		} else {
+			log.Printf("[WEB] Warning: could not load watchlists: %v", err)
+		}
+		if h, err := provider.NewSymbolHealthStore(dataDir); err == nil {
+			s.health = h
+		} else {
+			log.Printf("[WEB] Warning: could not load symbol health: %v", err)
+		}
+	}
+
+	s.names = symbols.NewNameIndex(dataDir)
+
 	// Load last scan result from disk
 	s.loadScanResultFromDisk()
 
@@ -160,16 +214,30 @@ func (s *Server) Start(port int) error {
 	mux.HandleFunc("/api/scan", s.handleScan)
 	mux.HandleFunc("/api/scan/status", s.handleScanStatus)
 	mux.HandleFunc("/api/scan/result", s.handleScanResult)
+	mux.HandleFunc("/api/scan/jobs", s.handleScanJobs)
+	mux.HandleFunc("/api/scan/jobs/result", s.handleScanJobResult)
+	mux.HandleFunc("/api/scan/settings", s.handleScanSettings)
+
+	// Machine-first API (JSON-RPC 2.0)
+	mux.HandleFunc("/rpc", s.handleRPC)
 
 	// Other API routes
 	mux.HandleFunc("/api/signals", s.handleSignals)
+	mux.HandleFunc("/api/signals/import", s.handleSignalsImport)
 	mux.HandleFunc("/api/stock/", s.handleStock)
 	mux.HandleFunc("/api/portfolio", s.handlePortfolio)
 	mux.HandleFunc("/api/universes", s.handleUniverses)
+	mux.HandleFunc("/api/market/status", s.handleMarketStatus)
+	mux.HandleFunc("/api/symbols/lists", s.handleSymbolLists)
+	mux.HandleFunc("/api/symbols/pruned", s.handleSymbolsPruned)
+	mux.HandleFunc("/api/symbols/search", s.handleSymbolSearch)
+	mux.HandleFunc("/api/watchlists", s.handleWatchlists)
 	mux.HandleFunc("/api/positions", s.handlePositions)
+	mux.HandleFunc("/api/positions/", s.handlePositionHistory)
 	mux.HandleFunc("/api/balance", s.handleBalance)
 	mux.HandleFunc("/api/orders", s.handleOrders)
 	mux.HandleFunc("/api/trade-history", s.handleTradeHistory)
+	mux.HandleFunc("/api/history/summary", s.handleHistorySummary)
 	mux.HandleFunc("/api/dca/status", s.handleDCAStatus)
 	mux.HandleFunc("/api/dca/feargreed", s.handleDCAFearGreed)
 	mux.HandleFunc("/api/scalp/status", s.handleScalpStatus)
@@ -182,6 +250,19 @@ func (s *Server) Start(port int) error {
 	mux.HandleFunc("/api/kr-dca/status", s.handleKRDCAStatus)
 	mux.HandleFunc("/api/portfolio/overview", s.handlePortfolioOverview)
 	mux.HandleFunc("/api/collector/status", s.handleCollectorStatus)
+	mux.HandleFunc("/api/backtest/runs", s.handleBacktestRuns)
+	mux.HandleFunc("/api/backtest/compare", s.handleBacktestCompare)
+	mux.HandleFunc("/api/reconcile", s.handleReconcile)
+	mux.HandleFunc("/api/reconcile/ack", s.handleReconcileAck)
+	mux.HandleFunc("/api/reports", s.handleRunReports)
+	mux.HandleFunc("/api/reports/", s.handleRunReport)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/api/i18n", s.handleI18n)
+	mux.HandleFunc("/api/scan-metrics", s.handleScanMetrics)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/api/mode", s.handleMode)
+	mux.HandleFunc("/api/summary", s.handleSummary)
+	mux.HandleFunc("/reports/", s.handleReportFiles)
 
 	// Static files (no-cache to prevent stale JS)
 	staticFS, err := fs.Sub(staticFiles, "static")
@@ -196,13 +277,22 @@ func (s *Server) Start(port int) error {
 
 	s.srv = &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
-		Handler:      corsMiddleware(mux),
+		Handler:      corsMiddleware(s.readOnlyMiddleware(mux)),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 60 * time.Second,
 		IdleTimeout:  120 * time.Second,
 	}
 
+	// 심볼 검색용 종목명 목록 갱신 (백그라운드, 주 1회)
+	go s.refreshSymbolNames()
+
+	// 실계좌 브로커 ↔ 플랜 ↔ 주문 저널 대조 (백그라운드)
+	go s.reconcileAtStartup()
+
 	log.Printf("Starting Traveler Web UI at http://localhost:%d", port)
+	if s.readOnly {
+		log.Printf("Read-only mode: scans and viewing only, order/position changes disabled")
+	}
 	log.Printf("Press Ctrl+C to stop")
 
 	return s.srv.ListenAndServe()
@@ -257,6 +347,16 @@ func (s *Server) handleScanStatus(w http.ResponseWriter, r *http.Request) {
 	market := r.URL.Query().Get("market")
 	state := s.getScanState(market)
 
+	// ?job=ID: 큐에서 대기 중이면 queued, 끝났으면 그 작업의 최종 상태 (다른 작업의 진행 상태와 섞이지 않도록)
+	if id := r.URL.Query().Get("job"); id != "" && s.jobs != nil {
+		if job, ok := s.findScanJob(id); ok && job.Status != "running" {
+			state = scanState{Status: job.Status, Message: job.Message, Error: job.Error, Found: job.Signals, Scanned: job.Scanned}
+			if job.Status == "queued" {
+				state.Message = "Waiting for the previous scan to finish..."
+			}
+		}
+	}
+
 	// idle 상태이면 디스크에서 로드 시도
 	if state.Status == "idle" || (state.Status == "" && state.Result == nil) {
 		if data := s.tryLoadFromDisk(market); data != nil {
@@ -289,19 +389,8 @@ func (s *Server) handleScanStatus(w http.ResponseWriter, r *http.Request) {
 // handleScanResult returns the completed scan result
 func (s *Server) handleScanResult(w http.ResponseWriter, r *http.Request) {
 	market := r.URL.Query().Get("market")
-	state := s.getScanState(market)
-
 	w.Header().Set("Content-Type", "application/json")
-
-	// 메모리에 결과가 있으면 바로 반환
-	if state.Status == "done" && state.Result != nil {
-		w.Write(state.Result)
-		return
-	}
-
-	// 메모리에 없으면 디스크에서 직접 로드 (데몬이 별도 프로세스로 결과를 썼을 수 있음)
-	data := s.tryLoadFromDisk(market)
-	if data != nil {
+	if data := s.latestScanResult(market); data != nil {
 		w.Write(data)
 		return
 	}
@@ -310,6 +399,17 @@ func (s *Server) handleScanResult(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"error": "no scan result available"})
 }
 
+// latestScanResult 마켓의 마지막 완료 스캔 결과 (없으면 nil)
+func (s *Server) latestScanResult(market string) json.RawMessage {
+	// 메모리에 결과가 있으면 바로 반환
+	state := s.getScanState(market)
+	if state.Status == "done" && state.Result != nil {
+		return state.Result
+	}
+	// 메모리에 없으면 디스크에서 직접 로드 (데몬이 별도 프로세스로 결과를 썼을 수 있음)
+	return s.tryLoadFromDisk(market)
+}
+
 // tryLoadFromDisk 디스크에서 스캔 결과를 읽고 메모리에 캐시
 func (s *Server) tryLoadFromDisk(market string) json.RawMessage {
 	path := s.scanResultPath(market)
@@ -380,7 +480,8 @@ func (s *Server) saveScanResultToDisk(data json.RawMessage, market string) {
 	if path == "" {
 		return
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	// 데몬도 같은 파일을 쓴다 (last writer wins, 원자적 교체)
+	if err := fsutil.WriteFile(path, data, 0644); err != nil {
 		log.Printf("[WEB] Failed to save scan result: %v", err)
 	}
 }
@@ -0,0 +1,259 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"traveler/internal/daemon"
+	"traveler/internal/strategy"
+	"traveler/internal/trader"
+)
+
+// maxSignalImportBytes import 요청 본문 제한
+const maxSignalImportBytes = 1 << 20
+
+// SignalImportResponse POST /api/signals/import 결과
+type SignalImportResponse struct {
+	Market   string            `json:"market"`
+	DryRun   bool              `json:"dry_run"`
+	Capital  float64           `json:"capital"`
+	Received int               `json:"received"`
+	Executed []ImportedOrder   `json:"executed"`
+	Rejected []ImportRejection `json:"rejected"`
+}
+
+// ImportedOrder 실행된(또는 실패한) 주문 하나
+type ImportedOrder struct {
+	Symbol     string  `json:"symbol"`
+	Strategy   string  `json:"strategy"`
+	Quantity   float64 `json:"quantity"`
+	EntryPrice float64 `json:"entry_price"`
+	StopLoss   float64 `json:"stop_loss"`
+	Target1    float64 `json:"target_1"`
+	OrderID    string  `json:"order_id,omitempty"`
+	Success    bool    `json:"success"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// ImportRejection 사이징/리스크 검증에서 거절된 시그널
+type ImportRejection struct {
+	Symbol   string `json:"symbol"`
+	Strategy string `json:"strategy"`
+	Reason   string `json:"reason"`
+}
+
+// bearerAuthorized TRAVELER_RPC_TOKEN 검사 (토큰 미설정이면 항상 통과)
+func (s *Server) bearerAuthorized(r *http.Request) bool {
+	if s.rpcToken == "" {
+		return true
+	}
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(auth), []byte(s.rpcToken)) == 1
+}
+
+// planStoreForMarket 마켓별 PlanStore (sim 마켓은 별도)
+func (s *Server) planStoreForMarket(market string) *trader.PlanStore {
+	switch market {
+	case "sim-us":
+		return s.planStoreSimUS
+	case "sim-kr":
+		return s.planStoreSimKR
+	default:
+		return s.planStore
+	}
+}
+
+// applyOrderLimits config의 주문 한도/시세 확인/마감 전 청산 설정을 Executor 설정에 반영
+func (s *Server) applyOrderLimits(cfg *trader.Config) {
+	if s.config == nil {
+		return
+	}
+	c := s.config.Trader.Caps
+	cfg.Caps = trader.ValueCaps{
+		MaxOrderUSD:  c.MaxOrderUSD,
+		MaxSymbolUSD: c.MaxSymbolUSD,
+		MaxOrderKRW:  c.MaxOrderKRW,
+		MaxSymbolKRW: c.MaxSymbolKRW,
+		MaxOrderJPY:  c.MaxOrderJPY,
+		MaxSymbolJPY: c.MaxSymbolJPY,
+		MaxOrderHKD:  c.MaxOrderHKD,
+		MaxSymbolHKD: c.MaxSymbolHKD,
+		MaxOrderCNY:  c.MaxOrderCNY,
+		MaxSymbolCNY: c.MaxSymbolCNY,
+	}
+	pc := s.config.Trader.PriceCheck
+	cfg.PriceCheck = trader.PriceCheckConfig{MaxDeviation: pc.MaxDeviationPct / 100, MaxQuoteAge: time.Duration(pc.MaxQuoteAgeSec) * time.Second}
+	cfg.EODClose = trader.DefaultEODPolicy().WithOverrides(s.config.Trader.EODClose)
+}
+
+// marketDataDir 마켓별 데이터 디렉터리 (sim 데몬은 dataDir/sim_us, sim_kr 사용)
+func (s *Server) marketDataDir(market string) string {
+	switch market {
+	case "sim-us":
+		return filepath.Join(s.dataDir, "sim_us")
+	case "sim-kr":
+		return filepath.Join(s.dataDir, "sim_kr")
+	default:
+		return s.dataDir
+	}
+}
+
+// handleSignalsImport 외부 시그널 실행
+// POST /api/signals/import?market=us&dry_run=false&market_order=true
+// 본문: strategy.Signal 배열, {"signals": [...]}, 또는 단건. 기본은 dry-run.
+func (s *Server) handleSignalsImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.bearerAuthorized(r) {
+		http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	market := q.Get("market")
+	if market == "" {
+		market = "us"
+	}
+	dryRun := q.Get("dry_run") != "false"
+	if !dryRun && s.rpcToken == "" {
+		http.Error(w, "live import is disabled: set TRAVELER_RPC_TOKEN to enable order execution", http.StatusForbidden)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignalImportBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("read body: %v", err), http.StatusBadRequest)
+		return
+	}
+	signals, err := trader.ParseSignals(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, status, err := s.importSignals(r.Context(), market, signals, dryRun, q.Get("market_order") == "true")
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// newWebTrader 웹 주문용 AutoTrader: 계좌 잔고 기준 사이징, 주문 한도/시세 확인, 주문 의도 저널,
+// 장 운영/블랙리스트 검사 (시그널 가져오기와 RPC 주문 공용, 실패 시 HTTP 상태 코드 반환)
+func (s *Server) newWebTrader(ctx context.Context, market string, dryRun, marketOrder bool) (*trader.AutoTrader, trader.SizerConfig, float64, int, error) {
+	b := s.getBrokerForMarket(market)
+	if b == nil {
+		return nil, trader.SizerConfig{}, 0, http.StatusServiceUnavailable, fmt.Errorf("broker not configured for market %s", market)
+	}
+
+	if !dryRun {
+		if err := s.reconcileBlocked(market); err != nil {
+			return nil, trader.SizerConfig{}, 0, http.StatusConflict, err
+		}
+	}
+
+	baseMarket := strings.TrimPrefix(market, "sim-")
+	capital := 0.0
+	if bal, err := b.GetBalance(ctx); err == nil {
+		capital = bal.TotalEquity
+	} else {
+		log.Printf("[WEB] Balance fetch failed (%s): %v", market, err)
+	}
+	if capital <= 0 {
+		if baseMarket != "us" {
+			return nil, trader.SizerConfig{}, 0, http.StatusServiceUnavailable, fmt.Errorf("could not determine %s account balance", market)
+		}
+		capital = s.capital
+	}
+
+	sizerCfg := trader.SizerConfigForMarket(baseMarket, capital)
+	traderCfg := trader.Config{
+		DryRun:          dryRun,
+		MaxPositions:    sizerCfg.MaxPositions,
+		MaxPositionPct:  sizerCfg.MaxPositionPct,
+		TotalCapital:    capital,
+		RiskPerTrade:    sizerCfg.RiskPerTrade,
+		MonitorInterval: trader.DefaultConfig().MonitorInterval,
+	}
+	s.applyOrderLimits(&traderCfg)
+	// dry-run은 플랜을 남기지 않음 (데몬 모니터가 가짜 포지션을 관리하지 않도록)
+	var ps *trader.PlanStore
+	if !dryRun {
+		ps = s.planStoreForMarket(market)
+	}
+	at := trader.NewAutoTraderWithPlanStore(traderCfg, b, marketOrder || baseMarket == "crypto", ps)
+	if !dryRun && s.dataDir != "" {
+		at.SetOrderJournal(trader.NewOrderJournal(s.marketDataDir(market), baseMarket))
+	}
+	at.AddPreTradeChecks(
+		trader.MarketOpenCheck{Status: func() (bool, string) {
+			st := daemon.MarketStatusFor(market)
+			return st.IsOpen, st.Reason
+		}},
+		trader.BlacklistCheck{Lists: s.lists},
+	)
+
+	return at, sizerCfg, capital, http.StatusOK, nil
+}
+
+// importSignals 외부 시그널 → 스캔 시그널과 같은 사이징/리스크 검증 → AutoTrader 실행
+// (HTTP 핸들러와 RPC signals.import 공용, 실패 시 HTTP 상태 코드 반환)
+func (s *Server) importSignals(ctx context.Context, market string, signals []strategy.Signal, dryRun, marketOrder bool) (*SignalImportResponse, int, error) {
+	if len(signals) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("no signals in payload")
+	}
+	at, sizerCfg, capital, status, err := s.newWebTrader(ctx, market, dryRun, marketOrder)
+	if err != nil {
+		return nil, status, err
+	}
+
+	log.Printf("[WEB] Signal import: %d signals (%s, dry-run=%v)", len(signals), market, dryRun)
+	res, err := at.ImportSignals(ctx, signals, sizerCfg)
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+
+	resp := &SignalImportResponse{
+		Market:   market,
+		DryRun:   dryRun,
+		Capital:  capital,
+		Received: len(signals),
+		Executed: make([]ImportedOrder, 0, len(res.Executed)),
+		Rejected: make([]ImportRejection, 0, len(res.Rejected)),
+	}
+	for _, e := range res.Executed {
+		o := ImportedOrder{
+			Symbol:   e.Signal.Stock.Symbol,
+			Strategy: e.Signal.Strategy,
+			Success:  e.Success,
+			Error:    e.Error,
+		}
+		if g := e.Signal.Guide; g != nil {
+			o.Quantity, o.EntryPrice, o.StopLoss, o.Target1 = g.PositionSize, g.EntryPrice, g.StopLoss, g.Target1
+		}
+		if e.Result != nil {
+			o.OrderID = e.Result.OrderID
+		}
+		resp.Executed = append(resp.Executed, o)
+	}
+	for _, rj := range res.Rejected {
+		resp.Rejected = append(resp.Rejected, ImportRejection{
+			Symbol:   rj.Signal.Stock.Symbol,
+			Strategy: rj.Signal.Strategy,
+			Reason:   rj.Reason,
+		})
+	}
+	return resp, http.StatusOK, nil
+}
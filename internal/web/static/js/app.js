@@ -13,11 +13,43 @@ class TravelerApp {
         this.initEventListeners();
         this.initTabs();
         this.initMarketToggle();
+        this.applyLabels();
+        this.applyMode();
 
         // Auto-load last scan result or attach to running scan
         this.loadLastResult();
     }
 
+    // 읽기 전용 서버면 매도/플랜 등록 같은 쓰기 컨트롤을 숨긴다 (body.read-only, style.css)
+    async applyMode() {
+        try {
+            const res = await fetch('/api/mode');
+            if (!res.ok) return;
+            const mode = await res.json();
+            this.readOnly = !!mode.read_only;
+            document.body.classList.toggle('read-only', this.readOnly);
+        } catch (e) {
+            console.error('Failed to load server mode:', e);
+        }
+    }
+
+    // ==================== LABELS (i18n) ====================
+    // data-i18n 요소를 config language 카탈로그로 교체 (실패하면 HTML의 영어 라벨 유지)
+    async applyLabels() {
+        try {
+            const res = await fetch('/api/i18n');
+            if (!res.ok) return;
+            const { language, messages } = await res.json();
+            document.documentElement.lang = language;
+            document.querySelectorAll('[data-i18n]').forEach(el => {
+                const msg = messages[el.dataset.i18n];
+                if (msg) el.textContent = msg;
+            });
+        } catch (e) {
+            // 라벨은 부가 기능 — 대시보드 동작에는 영향 없음
+        }
+    }
+
     // ==================== TAB NAVIGATION ====================
     initTabs() {
         document.querySelectorAll('.tab-btn').forEach(btn => {
@@ -126,6 +158,7 @@ class TravelerApp {
         document.getElementById('panelBtcFutures').classList.toggle('hidden', tab !== 'btc-futures');
         document.getElementById('panelPortfolio').classList.toggle('hidden', tab !== 'portfolio');
         document.getElementById('panelCollector').classList.toggle('hidden', tab !== 'collector');
+        document.getElementById('panelBacktests').classList.toggle('hidden', tab !== 'backtests');
 
         // DCA tab: market-aware (crypto → Crypto DCA, kr → KR DCA)
         const isDca = tab === 'dca';
@@ -174,6 +207,10 @@ class TravelerApp {
         if (tab === 'collector') {
             this.loadCollectorStatus();
         }
+
+        if (tab === 'backtests') {
+            this.loadBacktestRuns();
+        }
     }
 
     startPositionsRefresh() {
@@ -249,6 +286,20 @@ class TravelerApp {
         noPos.classList.add('hidden');
         container.innerHTML = positions.map(pos => this.createPositionCard(pos)).join('');
 
+        // Manual tranche sells: keep clicks off the chart handler
+        container.querySelectorAll('.tranche-sell').forEach(btn => {
+            btn.addEventListener('click', e => {
+                e.stopPropagation();
+                this.sellTranche(btn.dataset.symbol, parseFloat(btn.dataset.fraction));
+            });
+        });
+
+        // Manual plan forms (no-plan positions): keep clicks off the chart handler
+        container.querySelectorAll('.manual-plan-form').forEach(form => {
+            form.addEventListener('click', e => e.stopPropagation());
+            form.querySelector('.mp-submit').addEventListener('click', () => this.submitManualPlan(form));
+        });
+
         // Add click handlers for chart view
         container.querySelectorAll('.position-card').forEach(card => {
             card.addEventListener('click', () => {
@@ -290,7 +341,10 @@ class TravelerApp {
                 <div><span class="text-gray-500">T2:</span> <span class="text-green-400">${fp(pos.target2)}</span> <span class="text-gray-600">(${this.pctDiff(avgCost, pos.target2)})</span></div>
                 ${pos.breakout_level > 0 ? `<div><span class="text-gray-500">Breakout:</span> <span class="text-orange-400">${fp(pos.breakout_level)}</span></div>` : ''}
             </div>
-        ` : '<div class="text-xs text-gray-500 mt-2">No plan data - restart daemon to generate</div>';
+        ` : this.createManualPlanForm(pos);
+
+        // Tranche state + manual scaling controls
+        const trancheInfo = hasPlan ? this.createTrancheInfo(pos) : '';
 
         // Time progress
         const timeProgress = hasPlan && pos.max_hold_days > 0 ? this.createTimeProgress(pos) : '';
@@ -315,12 +369,109 @@ class TravelerApp {
                 </div>
                 ${priceLevelBar}
                 ${stopTarget}
+                ${trancheInfo}
                 ${timeProgress}
                 ${invalidationWarning}
             </div>
         `;
     }
 
+    createManualPlanForm(pos) {
+        const input = (cls, ph) => `<input type="number" step="any" min="0" placeholder="${ph}" class="${cls} w-20 bg-gray-700 border border-gray-600 rounded px-1 py-0.5 text-white">`;
+        return `
+            <div class="manual-plan-form text-xs mt-2" data-symbol="${pos.symbol || ''}">
+                <div class="text-gray-500 mb-1">No plan — add one to have the daemon monitor stops and targets (blank = from chart)</div>
+                <div class="flex flex-wrap items-center gap-1">
+                    <select class="mp-strategy bg-gray-700 border border-gray-600 rounded px-1 py-0.5 text-white">
+                        <option value="">auto</option>
+                        <option value="pullback">pullback</option>
+                        <option value="breakout">breakout</option>
+                        <option value="mean-reversion">mean-reversion</option>
+                    </select>
+                    ${input('mp-stop', 'Stop')}
+                    ${input('mp-t1', 'T1')}
+                    ${input('mp-t2', 'T2')}
+                    <button class="mp-submit bg-blue-600 hover:bg-blue-500 text-white rounded px-2 py-0.5">Add plan</button>
+                </div>
+                <div class="mp-error text-red-400 mt-1 hidden"></div>
+            </div>
+        `;
+    }
+
+    createTrancheInfo(pos) {
+        const t = pos.tranche;
+        if (!t) return '';
+        const q = (v) => +(v || 0).toFixed(4);
+        const parts = [];
+        if (t.sold_t1 > 0) parts.push(`T1 sold ${q(t.sold_t1)}`);
+        if (t.sold_t2 > 0) parts.push(`T2 sold ${q(t.sold_t2)}`);
+        if (t.sold_manual > 0) parts.push(`manual ${q(t.sold_manual)}`);
+        const sold = parts.length ? parts.join(' · ') : 'nothing sold yet';
+        const mode = t.mode === 'three-tranche'
+            ? `3 tranches${t.target3 > 0 ? ` · T3 ${this.formatPrice(t.target3)}` : ' · runner trails'}`
+            : 'T1 half / T2 rest';
+        const stopColor = t.stop_state === 'initial' ? 'text-red-400' : 'text-yellow-400';
+        const btn = (fraction, label) => `<button class="tranche-sell bg-gray-700 hover:bg-gray-600 text-white rounded px-2 py-0.5" data-symbol="${pos.symbol}" data-fraction="${fraction}">${label}</button>`;
+        return `
+            <div class="text-xs mt-2 flex flex-wrap items-center gap-x-3 gap-y-1">
+                <span class="text-gray-500">${mode}</span>
+                <span class="text-gray-400">${sold} (${q(t.remaining_qty)}/${q(t.initial_qty)} left)</span>
+                <span class="${stopColor}">stop ${t.stop_state}</span>
+                <span class="ml-auto flex gap-1">${btn(0.5, 'Sell ½')}${btn(1, 'Sell all')}</span>
+            </div>
+        `;
+    }
+
+    async sellTranche(symbol, fraction) {
+        const label = fraction >= 1 ? 'all' : `${Math.round(fraction * 100)}%`;
+        if (!confirm(`Market-sell ${label} of ${symbol} now?`)) return;
+        const send = (token) => fetch(`/api/positions/${encodeURIComponent(symbol)}/sell${this.marketQuery()}`, {
+            method: 'POST',
+            headers: Object.assign({ 'Content-Type': 'application/json' }, token ? { Authorization: `Bearer ${token}` } : {}),
+            body: JSON.stringify({ fraction }),
+        });
+        try {
+            let res = await send(localStorage.getItem('travelerToken'));
+            if (res.status === 401) {
+                const token = prompt('API token (TRAVELER_RPC_TOKEN):');
+                if (!token) return;
+                localStorage.setItem('travelerToken', token);
+                res = await send(token);
+            }
+            if (!res.ok) throw new Error((await res.text()).trim() || res.statusText);
+            await this.loadPositionsData();
+        } catch (e) {
+            alert(`Sell failed: ${e.message}`);
+        }
+    }
+
+    async submitManualPlan(form) {
+        const num = cls => parseFloat(form.querySelector(cls).value) || 0;
+        const btn = form.querySelector('.mp-submit');
+        const errEl = form.querySelector('.mp-error');
+        btn.disabled = true;
+        errEl.classList.add('hidden');
+        try {
+            const res = await fetch('/api/positions' + this.marketQuery(), {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({
+                    symbol: form.dataset.symbol,
+                    strategy: form.querySelector('.mp-strategy').value,
+                    stop_loss: num('.mp-stop'),
+                    target1: num('.mp-t1'),
+                    target2: num('.mp-t2'),
+                }),
+            });
+            if (!res.ok) throw new Error((await res.text()).trim() || res.statusText);
+            await this.loadPositionsData();
+        } catch (e) {
+            errEl.textContent = e.message;
+            errEl.classList.remove('hidden');
+            btn.disabled = false;
+        }
+    }
+
     createPriceLevelBar(pos) {
         const stop = pos.stop_loss || 0;
         const entry = pos.avg_cost || 0;
@@ -471,6 +622,7 @@ class TravelerApp {
             if (data.candles && data.candles.length > 0) {
                 renderChart('chartContainer', data.candles, guide);
             }
+            this.loadPositionHistory(symbol);
 
             // Calculate investment/risk for position
             if (pos) {
@@ -496,6 +648,60 @@ class TravelerApp {
         }
     }
 
+    // Position P&L since entry: price line with the stop/target levels recorded at each snapshot
+    async loadPositionHistory(symbol) {
+        this.clearPositionHistory();
+        try {
+            const res = await fetch(`/api/positions/${encodeURIComponent(symbol)}/history${this.marketQuery()}`);
+            if (!res.ok) return;
+            const data = await res.json();
+            const snaps = data.snapshots || [];
+            if (snaps.length < 2) return;
+
+            const el = document.getElementById('positionHistoryChart');
+            document.getElementById('positionHistoryPanel').classList.remove('hidden');
+            const chart = LightweightCharts.createChart(el, {
+                width: el.clientWidth,
+                height: 192,
+                layout: { background: { color: '#111827' }, textColor: '#9ca3af' },
+                grid: { vertLines: { color: '#374151' }, horzLines: { color: '#374151' } },
+                timeScale: { timeVisible: true, secondsVisible: false },
+                rightPriceScale: { borderColor: '#374151' },
+            });
+            this._positionHistoryChart = chart;
+
+            const toTime = (t) => Math.floor(new Date(t).getTime() / 1000);
+            const line = (color, style, pick) => {
+                const pts = snaps.filter(s => pick(s) > 0).map(s => ({ time: toTime(s.time), value: pick(s) }));
+                if (pts.length === 0) return;
+                chart.addLineSeries({ color, lineWidth: 1, lineStyle: style, priceLineVisible: false, lastValueVisible: false }).setData(pts);
+            };
+            line('#ef4444', 2, s => s.stop_loss);
+            line('#22c55e', 2, s => s.target1);
+            line('#16a34a', 1, s => s.target2);
+
+            const price = chart.addLineSeries({ color: '#3b82f6', lineWidth: 2 });
+            price.setData(snaps.map(s => ({ time: toTime(s.time), value: s.price })));
+            price.createPriceLine({ price: data.entry_price, color: '#9ca3af', lineWidth: 1, lineStyle: 1, title: 'Entry' });
+            chart.timeScale().fitContent();
+
+            const last = snaps[snaps.length - 1];
+            const pnlEl = document.getElementById('positionHistoryPnl');
+            pnlEl.textContent = `${last.pnl_pct >= 0 ? '+' : ''}${last.pnl_pct.toFixed(2)}%`;
+            pnlEl.className = `font-semibold ${last.pnl_pct >= 0 ? 'text-green-400' : 'text-red-400'}`;
+        } catch (e) {
+            console.error('Failed to load position history:', e);
+        }
+    }
+
+    clearPositionHistory() {
+        if (this._positionHistoryChart) {
+            this._positionHistoryChart.remove();
+            this._positionHistoryChart = null;
+        }
+        document.getElementById('positionHistoryPanel').classList.add('hidden');
+    }
+
     async openScalpChart(symbol, exchange) {
         if (!symbol) return;
         try {
@@ -649,6 +855,7 @@ class TravelerApp {
     normalizeSignal(signal) {
         // Handle both camelCase and PascalCase from Go JSON
         return {
+            id: signal.id || signal.ID || '',
             stock: signal.stock || signal.Stock || { symbol: 'Unknown', name: 'Unknown' },
             type: signal.type || signal.Type || 'BUY',
             strategy: signal.strategy || signal.Strategy || 'pullback',
@@ -687,9 +894,7 @@ class TravelerApp {
             document.getElementById('capitalInput').value = minCap;
         }
 
-        const activeSignals = this.signals.filter(s =>
-            !this.excluded.has(s.stock.symbol || s.stock.Symbol)
-        );
+        const activeSignals = this.signals.filter(s => !this.excluded.has(this.signalKey(s)));
 
         // Recalculate position sizing
         let totalInvest = 0;
@@ -876,7 +1081,7 @@ class TravelerApp {
         document.getElementById('modalTarget1').textContent = `${this.formatPrice(guide.target_1 || guide.Target1 || 0)} (+${(guide.target_1_pct || guide.Target1Pct || 0).toFixed(1)}%)`;
         document.getElementById('modalTarget2').textContent = `${this.formatPrice(guide.target_2 || guide.Target2 || 0)} (+${(guide.target_2_pct || guide.Target2Pct || 0).toFixed(1)}%)`;
         document.getElementById('modalShares').value = guide.position_size || guide.PositionSize || 0;
-        document.getElementById('modalReason').textContent = signal.reason || 'N/A';
+        document.getElementById('modalReason').textContent = this.formatRationale(signal) || 'N/A';
 
         // Fundamentals section
         const fund = signal.fundamentals;
@@ -951,7 +1156,7 @@ class TravelerApp {
         // Render chart
         const candles = signal.candles || [];
         if (candles.length > 0) {
-            renderChart('chartContainer', candles, guide);
+            renderChart('chartContainer', candles, guide, signal.details);
         }
 
         modal.classList.remove('hidden');
@@ -959,6 +1164,7 @@ class TravelerApp {
 
     hideStockModal() {
         document.getElementById('stockModal').classList.add('hidden');
+        this.clearPositionHistory();
         this.currentSignal = null;
     }
 
@@ -979,11 +1185,15 @@ class TravelerApp {
         document.getElementById('modalRiskPct').textContent = `${riskPct.toFixed(2)}%`;
     }
 
+    // Stable signal ID (symbol+strategy+date); older reports without IDs fall back to the symbol
+    signalKey(signal) {
+        return signal.id || signal.stock.symbol || signal.stock.Symbol;
+    }
+
     excludeCurrentStock() {
         if (!this.currentSignal) return;
 
-        const symbol = this.currentSignal.stock.symbol || this.currentSignal.stock.Symbol;
-        this.excluded.add(symbol);
+        this.excluded.add(this.signalKey(this.currentSignal));
         this.hideStockModal();
         this.recalculate();
     }
@@ -1031,25 +1241,30 @@ class TravelerApp {
             const startRes = await fetch(`/api/scan?capital=${capital}${mq}`, { method: 'POST' });
             const startData = await startRes.json();
 
-            if (startData.status === 'already_running') {
-                // Scan already in progress, just start polling
-            } else if (startData.status !== 'started') {
+            if (startData.status !== 'started') {
                 this.showLoading(false);
                 alert('Failed to start scan: ' + JSON.stringify(startData));
                 return;
             }
+            if (startData.queued) {
+                const detail = document.getElementById('loadingDetail');
+                if (detail) detail.textContent = `Queued (${startData.position} scan${startData.position > 1 ? 's' : ''} ahead)`;
+            }
 
-            // Poll for progress
+            // Poll for progress (job_id: 이 요청의 작업 상태만 추적)
             const statusMq = this.marketQuery();
+            const jobQ = startData.job_id ? (statusMq ? '&' : '?') + 'job=' + encodeURIComponent(startData.job_id) : '';
             this._scanPoll = setInterval(async () => {
                 try {
-                    const res = await fetch('/api/scan/status' + statusMq);
+                    const res = await fetch('/api/scan/status' + statusMq + jobQ);
                     const st = await res.json();
 
                     const title = document.getElementById('loadingTitle');
                     const detail = document.getElementById('loadingDetail');
 
-                    if (st.status === 'running') {
+                    if (st.status === 'queued') {
+                        if (detail) detail.textContent = st.message || 'Queued...';
+                    } else if (st.status === 'running') {
                         if (title) title.textContent = `Scanned ${st.scanned} | Found ${st.found} signals`;
                         if (detail) detail.textContent = st.message || '';
                     } else if (st.status === 'done') {
@@ -1176,11 +1391,16 @@ class TravelerApp {
             if (data.universes_used && data.universes_used.length > 0) {
                 meta.push(data.universes_used.join(' → '));
             }
+            if (data.symbols && data.symbols.length > 0) {
+                meta.push(`custom: ${data.symbols.slice(0, 5).join(', ')}${data.symbols.length > 5 ? ` +${data.symbols.length - 5}` : ''}`);
+            }
             meta.push(`${data.total_scanned || 0} scanned`);
             if (data.avg_prob > 0) meta.push(`avg ${data.avg_prob.toFixed(0)}% prob`);
             if (data.expansions > 0) meta.push(`${data.expansions}x expanded`);
             if (data.fundamentals_filtered > 0) meta.push(`${data.fundamentals_filtered} rejected by fundamentals`);
             if (data.ai_filtered > 0) meta.push(`🤖 ${data.ai_filtered} rejected by AI`);
+            if (data.resumed > 0) meta.push(`${data.resumed} resumed`);
+            if (data.partial) meta.push('⏸ partial — scan again to resume');
             meta.push(data.scan_time || '');
             document.getElementById('scanMeta').textContent = meta.filter(Boolean).join(' | ');
             document.getElementById('recalculateBtn').classList.remove('hidden');
@@ -1289,6 +1509,51 @@ class TravelerApp {
         } catch (e) {
             console.error('Failed to load trade history:', e);
         }
+        this.loadScanMetrics();
+    }
+
+    // 데몬 일별 스캔 품질: 최종 시그널(막대) + 후보 수 + 평균 확률
+    async loadScanMetrics() {
+        try {
+            const res = await fetch('/api/scan-metrics?days=180' + this.marketQuery('&'));
+            if (!res.ok) return;
+            const data = await res.json();
+            const metrics = data.metrics || [];
+            const el = document.getElementById('scanQualityChart');
+            document.getElementById('scanQualityEmpty').classList.toggle('hidden', metrics.length > 0);
+            el.classList.toggle('hidden', metrics.length === 0);
+            if (this._scanQualityChart) {
+                this._scanQualityChart.remove();
+                this._scanQualityChart = null;
+            }
+            const t = data.trend || {};
+            document.getElementById('scanTrendSummary').textContent = t.prior_days > 0
+                ? `avg signals ${t.recent_avg_signals.toFixed(1)} vs ${t.prior_avg_signals.toFixed(1)}, prob ${t.recent_avg_prob.toFixed(1)}% vs ${t.prior_avg_prob.toFixed(1)}% (last ${t.recent_days} vs prior ${t.prior_days} scan days)`
+                : '';
+            if (metrics.length === 0) return;
+
+            const chart = LightweightCharts.createChart(el, {
+                width: el.clientWidth,
+                height: 250,
+                layout: { background: { color: '#1f2937' }, textColor: '#9ca3af' },
+                grid: { vertLines: { color: '#374151' }, horzLines: { color: '#374151' } },
+                rightPriceScale: { borderColor: '#374151' },
+                leftPriceScale: { visible: true, borderColor: '#374151' },
+            });
+            this._scanQualityChart = chart;
+            const signals = chart.addHistogramSeries({ priceScaleId: 'left', title: 'signals' });
+            signals.setData(metrics.map(m => ({
+                time: m.date, value: m.signals,
+                color: m.decision === 'skip' ? '#6b7280' : m.decision === 'trade' ? '#22c55e' : '#f59e0b',
+            })));
+            const candidates = chart.addLineSeries({ priceScaleId: 'left', color: '#60a5fa', lineWidth: 1, lineStyle: 2, title: 'candidates' });
+            candidates.setData(metrics.map(m => ({ time: m.date, value: m.candidates })));
+            const prob = chart.addLineSeries({ priceScaleId: 'right', color: '#a78bfa', lineWidth: 2, title: 'avg prob %' });
+            prob.setData(metrics.filter(m => m.candidates > 0).map(m => ({ time: m.date, value: m.avg_prob })));
+            chart.timeScale().fitContent();
+        } catch (e) {
+            console.error('Failed to load scan metrics:', e);
+        }
     }
 
     renderHistorySummary(summary) {
@@ -1461,6 +1726,21 @@ class TravelerApp {
         return `$${price.toFixed(2)}`;
     }
 
+    // 시그널 근거: rationale이 있으면 조건별 ✓/✗ 여러 줄, 없으면 reason 한 줄
+    formatRationale(signal) {
+        const r = signal.rationale;
+        if (!r) return signal.reason || '';
+        const lines = [r.summary];
+        (r.conditions || []).forEach(c => {
+            const name = c.required ? c.name : `${c.name} (optional)`;
+            lines.push(`${c.met ? '✓' : '✗'} ${name}${c.value ? ': ' + c.value : ''}`);
+        });
+        const levels = (r.levels || []).map(l => `${l.name} ${this.formatPrice(l.price)}`);
+        if (levels.length) lines.push(`Levels: ${levels.join(' · ')}`);
+        (r.notes || []).forEach(n => lines.push(`Note: ${n}`));
+        return lines.join('\n');
+    }
+
     formatQty(qty) {
         if (this.isCrypto()) {
             if (qty >= 100) return qty.toFixed(2);
@@ -2826,6 +3106,117 @@ class TravelerApp {
             console.error('Collector status error:', e);
         }
     }
+    // ==================== Backtest Compare Methods ====================
+
+    async loadBacktestRuns() {
+        try {
+            const resp = await fetch('/api/backtest/runs');
+            const data = resp.ok ? await resp.json() : { runs: [] };
+            const runs = data.runs || [];
+
+            document.getElementById('btRunsEmpty').classList.toggle('hidden', runs.length > 0);
+            document.getElementById('btCompareControls').classList.toggle('hidden', runs.length === 0);
+            if (runs.length === 0) {
+                document.getElementById('btCompareResult').classList.add('hidden');
+                return;
+            }
+
+            const options = runs.map(r =>
+                `<option value="${r.name}">${r.name} (${r.kind}, ${r.total_return_pct >= 0 ? '+' : ''}${r.total_return_pct.toFixed(1)}%)</option>`
+            ).join('');
+            const selA = document.getElementById('btRunA');
+            const selB = document.getElementById('btRunB');
+            const prevA = selA.value, prevB = selB.value;
+            selA.innerHTML = options;
+            selB.innerHTML = options;
+            // 기본: 최신(B) vs 직전(A)
+            selA.value = prevA || (runs[1] || runs[0]).name;
+            selB.value = prevB || runs[0].name;
+
+            const btn = document.getElementById('btCompareBtn');
+            if (!btn.dataset.bound) {
+                btn.addEventListener('click', () => this.compareBacktests());
+                btn.dataset.bound = '1';
+            }
+        } catch (e) {
+            console.error('Backtest runs error:', e);
+        }
+    }
+
+    async compareBacktests() {
+        const a = document.getElementById('btRunA').value;
+        const b = document.getElementById('btRunB').value;
+        if (!a || !b) return;
+        try {
+            const resp = await fetch(`/api/backtest/compare?a=${encodeURIComponent(a)}&b=${encodeURIComponent(b)}`);
+            if (!resp.ok) {
+                console.error('Backtest compare error:', await resp.text());
+                return;
+            }
+            const data = await resp.json();
+            document.getElementById('btCompareResult').classList.remove('hidden');
+
+            const fmt = (v, f, signed) => {
+                const sign = signed && v > 0 ? '+' : '';
+                switch (f) {
+                    case 'pct': return `${sign}${v.toFixed(2)}%`;
+                    case 'r': return `${sign}${v.toFixed(2)}R`;
+                    case 'int': return `${sign}${Math.round(v)}`;
+                    case 'days': return `${sign}${Math.round(v)}d`;
+                    default: return `${sign}${v.toFixed(2)}`;
+                }
+            };
+            document.getElementById('btMetricsTable').innerHTML = (data.metrics || []).map(m => {
+                const cls = m.better === 'b' ? 'text-green-400' : m.better === 'a' ? 'text-red-400' : 'text-gray-400';
+                return `<tr class="border-b border-gray-700/50">
+                    <td class="py-1 px-2 text-gray-300">${m.name}</td>
+                    <td class="py-1 px-2 text-right ${m.better === 'a' ? 'font-semibold' : ''}">${fmt(m.a, m.format)}</td>
+                    <td class="py-1 px-2 text-right ${m.better === 'b' ? 'font-semibold' : ''}">${fmt(m.b, m.format)}</td>
+                    <td class="py-1 px-2 text-right ${cls}">${fmt(m.delta, m.format, true)}</td>
+                </tr>`;
+            }).join('');
+
+            const params = data.params || [];
+            document.getElementById('btParamsTable').innerHTML = params.length === 0
+                ? '<tr><td colspan="3" class="text-center text-gray-500 py-4">Parameters identical</td></tr>'
+                : params.map(p => `<tr class="border-b border-gray-700/50">
+                    <td class="py-1 px-2 text-gray-300">${p.name}</td>
+                    <td class="py-1 px-2 text-right">${p.a}</td>
+                    <td class="py-1 px-2 text-right">${p.b}</td>
+                </tr>`).join('');
+
+            this.renderBacktestEquity(data.equity_a || [], data.equity_b || []);
+        } catch (e) {
+            console.error('Backtest compare error:', e);
+        }
+    }
+
+    renderBacktestEquity(equityA, equityB) {
+        const el = document.getElementById('btEquityChart');
+        if (this._btChart) {
+            this._btChart.remove();
+            this._btChart = null;
+        }
+        el.innerHTML = '';
+        const chart = LightweightCharts.createChart(el, {
+            width: el.clientWidth,
+            height: 320,
+            layout: { background: { color: '#111827' }, textColor: '#9ca3af' },
+            grid: { vertLines: { color: '#374151' }, horzLines: { color: '#374151' } },
+            rightPriceScale: { borderColor: '#374151' },
+        });
+        this._btChart = chart;
+
+        // 같은 날짜 중복 제거 (단일 백테스트는 청산일 기준이라 하루에 여러 점이 있을 수 있음)
+        const toSeries = (points) => {
+            const byDate = new Map();
+            points.forEach(p => byDate.set(p.date, p.equity));
+            return [...byDate.entries()].sort((x, y) => x[0].localeCompare(y[0])).map(([time, value]) => ({ time, value }));
+        };
+        chart.addLineSeries({ color: '#3b82f6', lineWidth: 2, title: 'A' }).setData(toSeries(equityA));
+        chart.addLineSeries({ color: '#f59e0b', lineWidth: 2, title: 'B' }).setData(toSeries(equityB));
+        chart.timeScale().fitContent();
+    }
 }
 
 // Initialize app when DOM is ready
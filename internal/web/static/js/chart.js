@@ -12,8 +12,9 @@ let bbLowerSeries = null;
  * @param {string} containerId - DOM element ID for the chart
  * @param {Array} candles - Array of candle objects
  * @param {Object} guide - Trade guide with entry, stop, targets
+ * @param {Object} [details] - Signal details (RSI divergence points are marked on the chart)
  */
-function renderChart(containerId, candles, guide) {
+function renderChart(containerId, candles, guide, details) {
     const container = document.getElementById(containerId);
     if (!container) return;
 
@@ -184,6 +185,20 @@ function renderChart(containerId, candles, guide) {
         }
     }
 
+    // Bullish RSI divergence: mark the two lows (price lower low, RSI higher low)
+    if (details && details.rsi_divergence) {
+        const markers = [
+            { time: details.div_prior_time, rsi: details.div_prior_rsi },
+            { time: details.div_recent_time, rsi: details.div_recent_rsi },
+        ].filter(p => p.time > 0).map(p => ({
+            time: p.time, position: 'belowBar', color: '#a78bfa',
+            shape: 'arrowUp', text: `RSI ${p.rsi.toFixed(0)}`,
+        }));
+        if (markers.length > 0) {
+            candleSeries.setMarkers(markers);
+        }
+    }
+
     // Scroll to latest candles (barSpacing controls zoom level)
     chartInstance.timeScale().scrollToRealTime();
 
@@ -0,0 +1,151 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"traveler/internal/dailystate"
+	"traveler/internal/money"
+	"traveler/internal/symbols"
+	"traveler/internal/trader"
+)
+
+// summaryTopSignals /api/summary에 싣는 대기 시그널 수
+const summaryTopSignals = 3
+
+// SummaryResponse 폰 위젯/단축어용 요약 (디스크에 캐시된 상태만 읽고 브로커/시세 조회 없음)
+type SummaryResponse struct {
+	Market    string            `json:"market"`
+	Currency  string            `json:"currency"`
+	Date      string            `json:"date,omitempty"` // 일일 트래커 거래일
+	Status    string            `json:"status,omitempty"`
+	Equity    float64           `json:"equity"`
+	DayPnL    float64           `json:"day_pnl"`
+	DayPnLPct float64           `json:"day_pnl_pct"`
+	UpdatedAt *time.Time        `json:"updated_at,omitempty"` // 일일 상태 파일 수정 시각
+	Positions []SummaryPosition `json:"positions"`
+	Signals   []SummarySignal   `json:"signals"`
+	ScanAt    *time.Time        `json:"scan_at,omitempty"`
+}
+
+// SummaryPosition 보유 포지션 (가격/손익은 모니터가 남긴 마지막 스냅샷)
+type SummaryPosition struct {
+	Symbol   string     `json:"symbol"`
+	Quantity float64    `json:"qty"`
+	Entry    float64    `json:"entry"`
+	Price    float64    `json:"price,omitempty"`
+	PnLPct   float64    `json:"pnl_pct"`
+	Stop     float64    `json:"stop"`
+	Target1  float64    `json:"t1"`
+	AsOf     *time.Time `json:"as_of,omitempty"`
+}
+
+// SummarySignal 아직 보유하지 않은 최근 스캔 시그널 (확률 높은 순)
+type SummarySignal struct {
+	Symbol   string  `json:"symbol"`
+	Name     string  `json:"name,omitempty"`
+	Strategy string  `json:"strategy"`
+	Prob     float64 `json:"prob"`
+	Entry    float64 `json:"entry"`
+	Stop     float64 `json:"stop"`
+	Target1  float64 `json:"t1"`
+}
+
+// handleSummary 자산, 당일 손익, 보유 포지션 손익률, 상위 대기 시그널 3개
+// GET /api/summary?market=us
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dataDir == "" {
+		http.Error(w, "Summary not available (no data dir)", http.StatusServiceUnavailable)
+		return
+	}
+	market := r.URL.Query().Get("market")
+	if market == "" {
+		market = "us"
+	}
+	base := strings.TrimPrefix(market, "sim-")
+	dir := s.marketDataDir(market)
+	resp := SummaryResponse{
+		Market:    market,
+		Currency:  string(money.ForMarket(base)),
+		Positions: []SummaryPosition{},
+		Signals:   []SummarySignal{},
+	}
+
+	if st, mod, err := dailystate.LoadLatest(dir, base); err == nil {
+		resp.Date, resp.Status = st.Date, st.Status
+		resp.Equity, resp.DayPnL, resp.DayPnLPct = st.CurrentBalance, st.TotalPnL, st.TotalPnLPct
+		if !mod.IsZero() {
+			resp.UpdatedAt = &mod
+		}
+	}
+
+	held := make(map[string]bool)
+	if ps := s.planStoreForMarket(market); ps != nil {
+		ps.Reload()
+		history := trader.NewPositionHistoryStore(dir)
+		for _, plan := range ps.GetAll() {
+			if summaryMarket(plan.Symbol) != base {
+				continue
+			}
+			held[plan.Symbol] = true
+			pos := SummaryPosition{Symbol: plan.Symbol, Quantity: plan.Quantity, Entry: plan.EntryPrice,
+				Stop: plan.StopLoss, Target1: plan.Target1}
+			if series, err := history.Load(plan.Symbol); err == nil && len(series.Snapshots) > 0 &&
+				series.EntryTime.Equal(plan.EntryTime) {
+				last := series.Snapshots[len(series.Snapshots)-1]
+				pos.Price, pos.PnLPct, pos.AsOf = last.Price, last.PnLPct, &last.Time
+			}
+			resp.Positions = append(resp.Positions, pos)
+		}
+		sort.Slice(resp.Positions, func(i, j int) bool { return resp.Positions[i].PnLPct > resp.Positions[j].PnLPct })
+	}
+
+	if path := s.scanResultPath(market); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			var scan ScanResponse
+			if json.Unmarshal(data, &scan) == nil {
+				if info, err := os.Stat(path); err == nil {
+					mod := info.ModTime()
+					resp.ScanAt = &mod
+				}
+				sort.SliceStable(scan.Signals, func(i, j int) bool {
+					return scan.Signals[i].Probability > scan.Signals[j].Probability
+				})
+				for _, sig := range scan.Signals {
+					if held[sig.Stock.Symbol] || sig.Guide == nil {
+						continue
+					}
+					resp.Signals = append(resp.Signals, SummarySignal{Symbol: sig.Stock.Symbol, Name: sig.Stock.Name,
+						Strategy: sig.Strategy, Prob: sig.Probability, Entry: sig.Guide.EntryPrice,
+						Stop: sig.Guide.StopLoss, Target1: sig.Guide.Target1})
+					if len(resp.Signals) == summaryTopSignals {
+						break
+					}
+				}
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// summaryMarket 플랜 종목의 마켓 (plans.json은 US/KR/크립토 공용)
+func summaryMarket(symbol string) string {
+	switch {
+	case symbols.IsCryptoSymbol(symbol):
+		return "crypto"
+	case symbols.IsKoreanSymbol(symbol):
+		return "kr"
+	}
+	return "us"
+}
@@ -44,6 +44,7 @@ type TechnicalAnalysis struct {
 	ConsistencyScore float64 `json:"consistency_score"`
 	ContinuationProb float64 `json:"continuation_prob"`
 	Recommendation   string  `json:"recommendation"`
+	Score            float64 `json:"score,omitempty"` // 전략 시그널과 공통 척도의 통합 점수 (0-100)
 }
 
 // PatternResult represents the complete pattern analysis for a stock